@@ -1,5 +1,7 @@
+export * from './interceptors';
 
 export * from './service';
 
-export * from './twirp';
+export * from './transports';
 
+export * from './twirp';
@@ -1,7 +1,6 @@
-import 'isomorphic-fetch';
-import {DefaultHaberdasher, Hat, TwirpError} from './index';
+import {DefaultHaberdasher, fetchTransport, Hat, TwirpError} from './index';
 
-const haberdasher = new DefaultHaberdasher('http://localhost:8080', fetch);
+const haberdasher = new DefaultHaberdasher('http://localhost:8080', fetchTransport(fetch));
 
 haberdasher.makeHat({inches: 10})
     .then((hat: Hat) => {
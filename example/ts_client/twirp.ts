@@ -1,34 +1,879 @@
+// Error codes defined by the Twirp spec: https://twitchtv.github.io/twirp/docs/spec_v5.html#error-codes
+export enum TwirpErrorCode {
+    Canceled = "canceled",
+    Unknown = "unknown",
+    InvalidArgument = "invalid_argument",
+    Malformed = "malformed",
+    DeadlineExceeded = "deadline_exceeded",
+    NotFound = "not_found",
+    BadRoute = "bad_route",
+    AlreadyExists = "already_exists",
+    PermissionDenied = "permission_denied",
+    Unauthenticated = "unauthenticated",
+    ResourceExhausted = "resource_exhausted",
+    FailedPrecondition = "failed_precondition",
+    Aborted = "aborted",
+    OutOfRange = "out_of_range",
+    Unimplemented = "unimplemented",
+    Internal = "internal",
+    Unavailable = "unavailable",
+    DataLoss = "data_loss",
+}
 
 export interface TwirpErrorJSON {
     code: string;
     msg: string;
-    meta: {[index:string]: string};
+    meta?: {[index:string]: string};
 }
 
 export class TwirpError extends Error {
-    code: string;
+    code: TwirpErrorCode;
     meta: {[index:string]: string};
 
     constructor(te: TwirpErrorJSON) {
         super(te.msg);
 
-        this.code = te.code;
-        this.meta = te.meta;
+        // restore the prototype chain so instanceof works when targeting ES5
+        (Object as any).setPrototypeOf(this, TwirpError.prototype);
+
+        this.name = "TwirpError";
+        this.code = te.code as TwirpErrorCode;
+        this.meta = te.meta || {};
+    }
+}
+
+// httpStatus is the HTTP status of each error code, as defined by the Twirp spec.
+export const httpStatus: {[code: string]: number} = {
+    canceled: 408,
+    unknown: 500,
+    invalid_argument: 400,
+    malformed: 400,
+    deadline_exceeded: 408,
+    not_found: 404,
+    bad_route: 404,
+    already_exists: 409,
+    permission_denied: 403,
+    unauthenticated: 401,
+    resource_exhausted: 429,
+    failed_precondition: 412,
+    aborted: 409,
+    out_of_range: 400,
+    unimplemented: 501,
+    internal: 500,
+    unavailable: 503,
+    data_loss: 500,
+};
+
+// Errors that do not come from a Twirp server (e.g. a proxy or load balancer) are mapped
+// to a Twirp error code based on the HTTP status, as described in the Twirp spec.
+const intermediaryError = (status: number, body: string): TwirpErrorJSON => {
+    let code = TwirpErrorCode.Unknown;
+
+    if (status >= 300 && status < 400) {
+        code = TwirpErrorCode.Internal;
+    } else if (status === 400) {
+        code = TwirpErrorCode.Internal;
+    } else if (status === 401) {
+        code = TwirpErrorCode.Unauthenticated;
+    } else if (status === 403) {
+        code = TwirpErrorCode.PermissionDenied;
+    } else if (status === 404) {
+        code = TwirpErrorCode.BadRoute;
+    } else if (status === 429 || status === 502 || status === 503 || status === 504) {
+        code = TwirpErrorCode.Unavailable;
     }
+
+    return {
+        code: code,
+        msg: "Error from intermediary with HTTP status code " + status,
+        meta: {
+            http_error_from_intermediary: "true",
+            status_code: String(status),
+            body: body,
+        },
+    };
+};
+
+export const throwTwirpError = (resp: TransportResponse): Promise<never> => {
+    return resp.text().then((body: string) => {
+        let err: TwirpErrorJSON;
+
+        try {
+            err = JSON.parse(body);
+        } catch (e) {
+            err = intermediaryError(resp.status, body);
+        }
+
+        if (!err || typeof err.code !== "string") {
+            err = intermediaryError(resp.status, body);
+        }
+
+        throw new TwirpError(err);
+    });
+};
+
+export type TwirpHeaders = {[index:string]: string};
+
+// HeadersProvider is called before every request, e.g. to attach a fresh Authorization header.
+export type HeadersProvider = () => TwirpHeaders | Promise<TwirpHeaders>;
+
+// CallOptions are the optional per-call settings accepted by every generated client method.
+export interface CallOptions {
+    // signal cancels the request when aborted, e.g. when a component unmounts
+    signal?: AbortSignal;
+    // headers are merged into the request, overriding headers with the same name set on the client
+    headers?: TwirpHeaders;
+    // timeoutMs is the deadline of the call, after which it is cancelled and rejects with a deadline_exceeded TwirpError
+    timeoutMs?: number;
+    // onResponse is called with the status and headers of each response of the call, including error responses,
+    // e.g. to read rate limits or request IDs
+    onResponse?: (response: ResponseMetadata) => void;
+}
+
+// ResponseHeaders are the headers of a response, e.g. the Headers of a fetch Response, which are looked up case
+// insensitively.
+export interface ResponseHeaders {
+    get(name: string): string | null;
 }
 
-export const throwTwirpError = (resp: Response) => {
-    return resp.json().then((err: TwirpErrorJSON) => { throw new TwirpError(err); })
+// ResponseMetadata is the status and headers of the response of a call.
+export interface ResponseMetadata {
+    status: number;
+    headers: ResponseHeaders;
+}
+
+// WithResponse is the result of a call along with the status and headers of its response, see withResponse.
+export interface WithResponse<T> extends ResponseMetadata {
+    data: T;
+}
+
+const noHeaders: ResponseHeaders = {get: () => null};
+
+// reportResponse calls the onResponse of the options of a call with the status and headers of a response.
+export const reportResponse = (options: CallOptions, resp: TransportResponse): void => {
+    if (options.onResponse) {
+        options.onResponse({status: resp.status, headers: resp.headers || noHeaders});
+    }
 };
 
-export const createTwirpRequest = (url: string, body: object): Request => {
-    return new Request(url, {
-        method: "POST",
-        headers: {
-            "Content-Type": "application/json"
+// withResponse makes a call with the CallOptions passed to call, and resolves with its result along with the status
+// and headers of its response, e.g. withResponse((options) => client.makeHat(size, options)). The options of the
+// call are the caller's options, so options added to CallOptions are passed on too.
+export const withResponse = <T>(call: (options: CallOptions) => Promise<T>, options: CallOptions = {}): Promise<WithResponse<T>> => {
+    let response: ResponseMetadata = {status: 0, headers: noHeaders};
+
+    return call({
+        ...options,
+        onResponse: (resp) => {
+            response = resp;
+            if (options.onResponse) {
+                options.onResponse(resp);
+            }
         },
-        body: JSON.stringify(body)
+    }).then((data) => ({data: data, status: response.status, headers: response.headers}));
+};
+
+const mergeHeaders = (...all: (TwirpHeaders | undefined)[]): TwirpHeaders => {
+    const merged: TwirpHeaders = {};
+
+    all.forEach((headers) => {
+        if (headers) {
+            Object.keys(headers).forEach((k) => { merged[k] = headers[k]; });
+        }
+    });
+
+    return merged;
+};
+
+// resolveCallOptions merges the headers and timeout configured on a client with the per-call options.
+export const resolveCallOptions = (clientHeaders?: TwirpHeaders | HeadersProvider, options: CallOptions = {}, timeoutMs?: number): Promise<CallOptions> => {
+    const headers = typeof clientHeaders === "function" ? clientHeaders() : clientHeaders;
+
+    return Promise.resolve(headers).then((headers) => {
+        return {
+            signal: options.signal,
+            headers: mergeHeaders(headers, options.headers),
+            timeoutMs: options.timeoutMs === undefined ? timeoutMs : options.timeoutMs,
+            onResponse: options.onResponse,
+        };
+    });
+};
+
+// withDeadline runs a call with the signal of an AbortController that is aborted after options.timeoutMs,
+// or when options.signal is aborted. A call that times out rejects with a deadline_exceeded TwirpError.
+export const withDeadline = <T>(options: CallOptions, call: (options: CallOptions) => Promise<T>): Promise<T> => {
+    const timeoutMs = options.timeoutMs;
+    if (!timeoutMs) {
+        return call(options);
+    }
+
+    const controller = new AbortController();
+    const signal = options.signal;
+    const abort = () => controller.abort();
+
+    if (signal) {
+        if (signal.aborted) {
+            abort();
+        }
+
+        signal.addEventListener("abort", abort);
+    }
+
+    return new Promise<T>((resolve, reject) => {
+        const timer = setTimeout(() => {
+            abort();
+            reject(new TwirpError({code: TwirpErrorCode.DeadlineExceeded, msg: "the call did not complete within " + timeoutMs + "ms"}));
+        }, timeoutMs);
+
+        const done = () => {
+            clearTimeout(timer);
+            if (signal) {
+                signal.removeEventListener("abort", abort);
+            }
+        };
+
+        call({signal: controller.signal, headers: options.headers, timeoutMs: timeoutMs, onResponse: options.onResponse}).then((resp) => {
+            done();
+            resolve(resp);
+        }, (err) => {
+            done();
+            reject(err);
+        });
     });
 };
 
+// TransportRequest is a Twirp request, which is always sent with the POST method, or a request of a REST route.
+export interface TransportRequest {
+    // method is the HTTP method of a REST request, and is POST when it is not set
+    method?: string;
+    url: string;
+    headers: TwirpHeaders;
+    // body is empty for a REST request without a body, which is sent without one
+    body: string | Uint8Array;
+    signal?: AbortSignal;
+}
+
+// TransportResponse is the subset of a fetch Response read by the generated clients.
+export interface TransportResponse {
+    ok: boolean;
+    status: number;
+    // headers are the headers of the response, which are not reported by transports that do not read them
+    headers?: ResponseHeaders;
+    text(): Promise<string>;
+    arrayBuffer(): Promise<ArrayBuffer>;
+}
+
+// Transport sends the requests of the generated clients, see the adapters in transports.ts.
+export type Transport = (req: TransportRequest) => Promise<TransportResponse>;
+
+// joinURL joins the hostname of a client to the path of a Twirp route, with a single slash between them,
+// e.g. joinURL("http://localhost:8080/", "/twirp/twitch.twirp.example.Haberdasher/MakeHat")
+export const joinURL = (base: string, path: string): string => {
+    return base.replace(/\/+$/, "") + "/" + path.replace(/^\/+/, "");
+};
+
+export const createTwirpRequest = (url: string, body: object, options: CallOptions = {}): TransportRequest => {
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/json",
+        }),
+        body: JSON.stringify(body),
+        signal: options.signal,
+    };
+};
+
 export type Fetch = (input: RequestInfo, init?: RequestInit) => Promise<Response>;
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+
+// bytesToBase64 encodes bytes fields using standard base64 with padding, as described by the proto3 JSON mapping.
+export const bytesToBase64 = (b: Uint8Array): string => {
+    let s = "";
+
+    for (let i = 0; i < b.length; i += 3) {
+        const n = (b[i] << 16) | ((i + 1 < b.length ? b[i + 1] : 0) << 8) | (i + 2 < b.length ? b[i + 2] : 0);
+
+        s += base64Chars.charAt(n >> 18) + base64Chars.charAt((n >> 12) & 63);
+        s += i + 1 < b.length ? base64Chars.charAt((n >> 6) & 63) : "=";
+        s += i + 2 < b.length ? base64Chars.charAt(n & 63) : "=";
+    }
+
+    return s;
+};
+
+// base64ToBytes decodes both standard and URL safe base64, with or without padding.
+export const base64ToBytes = (s: string): Uint8Array => {
+    s = s.replace(/-/g, "+").replace(/_/g, "/").replace(/=+$/, "");
+
+    const b = new Uint8Array(Math.floor(s.length * 3 / 4));
+    let n = 0;
+    let bits = 0;
+    let j = 0;
+
+    for (let i = 0; i < s.length; i++) {
+        n = (n << 6) | base64Chars.indexOf(s.charAt(i));
+        bits += 6;
+
+        if (bits >= 8) {
+            bits -= 8;
+            b[j++] = (n >> bits) & 255;
+        }
+    }
+
+    return b;
+};
+
+// ValidationError is a protoc-gen-validate rule that is violated by a field, e.g.
+// {field: "address.street", rule: "string.min_len", message: "must be at least 1 characters"}
+export interface ValidationError {
+    field: string;
+    rule: string;
+    message: string;
+}
+
+// validationError is the invalid_argument TwirpError of a request that violates its validation rules,
+// whose meta has the field of the first violated rule as the argument, as in the errors of a Twirp server.
+export const validationError = (errors: ValidationError[]): TwirpError => {
+    return new TwirpError({
+        code: TwirpErrorCode.InvalidArgument,
+        msg: errors[0].field + " " + errors[0].message,
+        meta: {argument: errors[0].field},
+    });
+};
+
+export const checkRule = (errors: ValidationError[], field: string, rule: string, valid: boolean, message: string): void => {
+    if (!valid) {
+        errors.push({field: field, rule: rule, message: message});
+    }
+};
+
+const addNestedErrors = (errors: ValidationError[], field: string, nested: ValidationError[]): void => {
+    nested.forEach((e) => errors.push({field: field + (e.field.charAt(0) === "[" ? "" : ".") + e.field, rule: e.rule, message: e.message}));
+};
+
+// validateMessage, validateList, and validateMap validate the messages of a field, and add their errors
+// with the name of the field as a prefix, e.g. address.street or previous[0].street
+export const validateMessage = <T>(errors: ValidationError[], field: string, m: T | undefined | null, validate: (m: T) => ValidationError[]): void => {
+    if (m !== undefined && m !== null) {
+        addNestedErrors(errors, field, validate(m));
+    }
+};
+
+export const validateList = <T>(errors: ValidationError[], field: string, list: T[] | undefined, validate: (m: T) => ValidationError[]): void => {
+    (list || []).forEach((m, i) => addNestedErrors(errors, field + "[" + i + "]", validate(m)));
+};
+
+export const validateMap = <T>(errors: ValidationError[], field: string, map: {[key: number]: T} | undefined, validate: (m: T) => ValidationError[]): void => {
+    const values = (map || {}) as {[key: string]: T};
+    Object.keys(values).forEach((k) => addNestedErrors(errors, field + "[" + k + "]", validate(values[k])));
+};
+
+// runeCount is the number of unicode code points of a string, which are counted by the length rules of strings
+export const runeCount = (s: string): number => {
+    return s.replace(/[\uD800-\uDBFF][\uDC00-\uDFFF]/g, "_").length;
+};
+
+export const utf8Length = (s: string): number => {
+    return new TextEncoder().encode(s).length;
+};
+
+export const isUnique = (list: any[]): boolean => {
+    return list.every((v, i) => list.indexOf(v) === i);
+};
+
+export const isEmail = (s: string): boolean => {
+    return /^[^@\s]+@[^@\s]+$/.test(s) && isHostname(s.slice(s.lastIndexOf("@") + 1));
+};
+
+export const isHostname = (s: string): boolean => {
+    return s.length <= 253 && /^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$/.test(s);
+};
+
+export const isURI = (s: string): boolean => {
+    return /^[a-zA-Z][a-zA-Z0-9+.-]*:[^\s]*$/.test(s);
+};
+
+export const isUUID = (s: string): boolean => {
+    return /^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$/.test(s);
+};
+
+// fieldMaskToString formats the paths of a google.protobuf.FieldMask as in the proto3 JSON mapping,
+// e.g. ["user.display_name", "photo"] => "user.displayName,photo"
+export const fieldMaskToString = (paths: string[]): string => {
+    return paths.map((p) => p.replace(/_([a-z])/g, (_, c) => c.toUpperCase())).join(",");
+};
+
+// mapEntries converts the keys and values of a map field, e.g. mapEntries(m.hats, String, HatToJSON).
+// Maps with number keys are accepted, since their keys are strings at runtime.
+export const mapEntries = <K extends string | number, T, U>(m: {[key: number]: T}, key: (k: string) => K, value: (v: T) => U): {[key: string]: U} => {
+    const out: {[key: string]: U} = {};
+    Object.keys(m).forEach((k) => out[key(k)] = value((m as {[key: string]: T})[k]));
+    return out;
+};
+
+// enumFromJSON converts an enum value from proto3 JSON, which may be either the name or the number of the value,
+// e.g. enumFromJSON<Color>(Color, "RED") and enumFromJSON<Color>(Color, 1) are both Color.RED
+export const enumFromJSON = <T>(e: object, v: string | number): T => {
+    return (typeof v === "number" ? v : (e as {[key: string]: number})[v]) as any as T;
+};
+
+// floatToJSON converts a float or double value to proto3 JSON, where NaN and the infinities are the strings
+// "NaN", "Infinity" and "-Infinity", since they are not JSON numbers.
+export const floatToJSON = (n: number): number | string => {
+    return isFinite(n) ? n : String(n);
+};
+
+// floatFromJSON converts a float or double value from proto3 JSON, which is either a number or a string,
+// e.g. "NaN", "Infinity" or "1.5"
+export const floatFromJSON = (v: number | string): number => {
+    return typeof v === "string" ? Number(v) : v;
+};
+
+// ResponseSchema is a schema that checks the JSON of a response, such as the zod schemas generated with zod=true.
+export interface ResponseSchema {
+    safeParse(data: unknown): {success: true; data: any} | {success: false; error: {message: string}};
+}
+
+// parseResponse checks the JSON of a response with the schema of its message, and throws an internal TwirpError
+// for a response that does not match, so the client never returns a message of the wrong shape.
+export const parseResponse = (schema: ResponseSchema, json: unknown): any => {
+    const result = schema.safeParse(json);
+    if (!result.success) {
+        throw new TwirpError({code: TwirpErrorCode.Internal, msg: "invalid response: " + result.error.message});
+    }
+
+    return result.data;
+};
+
+export const fieldMaskFromString = (s: string): string[] => {
+    if (!s) {
+        return [];
+    }
+
+    return s.split(",").map((p) => p.replace(/[A-Z]/g, (c) => "_" + c.toLowerCase()));
+};
+
+// cloneValue deeply copies the value of a field for the clone method of a message class, where
+// messages are copied by their own clone method.
+export const cloneValue = <T>(v: T): T => {
+    const value: any = v;
+    if (value === null || typeof value !== "object") {
+        return v;
+    }
+
+    if (typeof value.clone === "function") {
+        return value.clone();
+    }
+
+    if (value instanceof Date) {
+        return new Date(value.getTime()) as any;
+    }
+
+    if (value instanceof Uint8Array) {
+        return value.slice() as any;
+    }
+
+    if (Array.isArray(value)) {
+        return value.map(cloneValue) as any;
+    }
+
+    const copy: any = {};
+    Object.keys(value).forEach((k) => copy[k] = cloneValue(value[k]));
+    return copy;
+};
+
+// valuesEqual reports if the values of a field are deeply equal for the equals method of a message class.
+// A key whose value is undefined is the same as a key that is not set, as for unset optional fields.
+export const valuesEqual = (a: any, b: any): boolean => {
+    if (a === b) {
+        return true;
+    }
+
+    if (a === null || b === null || typeof a !== "object" || typeof b !== "object") {
+        return false;
+    }
+
+    if (typeof a.equals === "function") {
+        return a.equals(b);
+    }
+
+    if (a instanceof Date || b instanceof Date) {
+        return a instanceof Date && b instanceof Date && a.getTime() === b.getTime();
+    }
+
+    if (Array.isArray(a) || a instanceof Uint8Array) {
+        return a.length === b.length && Array.prototype.every.call(a, (v: any, i: number) => valuesEqual(v, b[i]));
+    }
+
+    const keys = (o: any) => Object.keys(o).filter((k) => o[k] !== undefined);
+    const aKeys = keys(a);
+
+    return aKeys.length === keys(b).length && aKeys.every((k) => valuesEqual(a[k], b[k]));
+};
+
+// mergeFields copies a message with the fields of a patch that are set for the merge function of the message, e.g.
+// mergeHat(hat, {color: "red"}). The fields of merge are merged with the fields of the patch, e.g. the nested
+// messages and the maps, while the other fields of the patch replace the fields of the message.
+export const mergeFields = <T>(base: T, patch: Partial<T>, merge: {[field: string]: (base: any, patch: any) => any}): T => {
+    const m: any = {};
+    Object.keys(base).forEach((k) => m[k] = (base as any)[k]);
+    Object.keys(patch).forEach((k) => {
+        const v = (patch as any)[k];
+        if (v === undefined) {
+            return;
+        }
+
+        m[k] = merge[k] && m[k] !== undefined && m[k] !== null ? merge[k](m[k], v) : v;
+    });
+
+    return m;
+};
+
+// mergeMap merges the entries of the map field of a patch into the map field of a message, which replace its
+// entries with the same keys.
+export const mergeMap = <T>(base: {[key: string]: T}, patch: {[key: string]: T}): {[key: string]: T} => {
+    const m: {[key: string]: T} = {};
+    Object.keys(base).forEach((k) => m[k] = base[k]);
+    Object.keys(patch).forEach((k) => m[k] = patch[k]);
+
+    return m;
+};
+
+// FieldDiff is a field of a message for diffFields, with its path in a FieldMask, and the diff function of its
+// message, or a oneof with the paths of its members by their kinds.
+export interface FieldDiff {
+    name: string;
+    path?: string;
+    diff?: (a: any, b: any) => string[];
+    members?: {[kind: string]: string};
+}
+
+// diffFields finds the paths of the fields that differ between two messages for the diff function of the messages,
+// e.g. diffHat(a, b) => ["color"]. The fields of nested messages that are set in both are compared by their diff
+// functions, e.g. "size.inches", while repeated and map fields differ as a whole, and a oneof differs by the paths
+// of the members that are set.
+export const diffFields = <T>(a: T, b: T, fields: FieldDiff[]): string[] => {
+    const paths: string[] = [];
+
+    fields.forEach((f) => {
+        const x = (a as any)[f.name];
+        const y = (b as any)[f.name];
+
+        if (f.diff && x !== undefined && x !== null && y !== undefined && y !== null) {
+            f.diff(x, y).forEach((p) => paths.push(f.path + "." + p));
+        } else if (valuesEqual(x, y)) {
+            return;
+        } else if (f.members) {
+            const members = f.members;
+            [x, y].forEach((o) => {
+                if (o && paths.indexOf(members[o.kind]) < 0) {
+                    paths.push(members[o.kind]);
+                }
+            });
+        } else {
+            paths.push(f.path as string);
+        }
+    });
+
+    return paths;
+};
+
+// canonicalJSON serializes a message, or a value of its fields, to JSON whose object keys are sorted, so equal
+// messages have the same JSON whatever order their properties were set in, e.g. canonicalJSON(user) for a cache key,
+// an ETag or a dedupe key. The properties that are undefined are left out, as for unset optional fields, and bigints
+// are their digits, bytes are their base64 and Dates are their ISO strings. The messages of models=classes are their
+// sorted proto3 JSON.
+export const canonicalJSON = (value: unknown): string => {
+    return JSON.stringify(value, (_, v) => {
+        if (typeof v === "bigint") {
+            return v.toString();
+        }
+
+        if (v instanceof Uint8Array) {
+            return bytesToBase64(v);
+        }
+
+        if (v === null || typeof v !== "object" || Array.isArray(v)) {
+            return v;
+        }
+
+        const sorted: {[key: string]: any} = {};
+        Object.keys(v).sort().forEach((k) => sorted[k] = v[k]);
+
+        return sorted;
+    });
+};
+
+// everyItem and everyValue check the items of a repeated field and the values of a map field for the type guard
+// of a message, e.g. everyItem(m.hats, isHat)
+export const everyItem = (v: unknown, check: (v: unknown) => boolean): boolean => {
+    return Array.isArray(v) && v.every((item) => check(item));
+};
+
+export const everyValue = (v: unknown, check: (v: unknown) => boolean): boolean => {
+    if (typeof v !== "object" || v === null || Array.isArray(v)) {
+        return false;
+    }
+
+    const values = v as {[key: string]: unknown};
+    return Object.keys(values).every((k) => check(values[k]));
+};
+
+// oneofMember checks the discriminated union of a oneof for the type guard of a message, with the check of
+// the value of each member, e.g. oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage})
+export const oneofMember = (v: unknown, members: {[kind: string]: (v: unknown) => boolean}): boolean => {
+    if (typeof v !== "object" || v === null) {
+        return false;
+    }
+
+    const member = v as {kind: unknown, value: unknown};
+    return typeof member.kind === "string" && members.hasOwnProperty(member.kind) && members[member.kind](member.value);
+};
+
+export const isDurationObject = (v: unknown): boolean => {
+    const d = v as Duration;
+    return typeof v === "object" && v !== null && typeof d.seconds === "number" && typeof d.nanos === "number";
+};
+
+// BuildWhenSet is the build method of the builder of a message, which can only be called once the Required fields
+// are Set. Until then it is not callable, and the compile error names the missing fields, e.g.
+// BuildWhenSet<"title" | "isbn", "title", Book> is {missingRequiredFields: "isbn"}.
+export type BuildWhenSet<Required extends string, Set extends string, T> = [Exclude<Required, Set>] extends [never] ? () => T : {missingRequiredFields: Exclude<Required, Set>};
+
+// messageBuilder makes the builder of a message, whose methods set the fields and return a new builder, and whose
+// build method creates the message from the fields that are set, e.g. messageBuilder(createBook, ["title", "isbn"]).
+export const messageBuilder = <B>(create: (partial: any) => unknown, fields: string[], partial: {[field: string]: unknown} = {}): B => {
+    const builder: {[method: string]: unknown} = {build: () => create(partial)};
+
+    fields.forEach((field) => {
+        builder[field] = (value: unknown) => messageBuilder<B>(create, fields, {...partial, [field]: value});
+    });
+
+    return builder as unknown as B;
+};
+
+// Duration is a google.protobuf.Duration, where nanos has the same sign as seconds.
+export interface Duration {
+    seconds: number;
+    nanos: number;
+}
+
+// durationToString formats a Duration as in the proto3 JSON mapping, e.g. "3.5s"
+export const durationToString = (d: Duration): string => {
+    const negative = d.seconds < 0 || d.nanos < 0;
+    let s = (negative ? "-" : "") + Math.abs(d.seconds);
+
+    if (d.nanos) {
+        s += "." + String(Math.abs(d.nanos) + 1000000000).substring(1).replace(/(000)+$/, "");
+    }
+
+    return s + "s";
+};
+
+export const durationFromString = (s: string): Duration => {
+    const negative = s.charAt(0) === "-";
+    const parts = s.replace(/^-|s$/g, "").split(".");
+    const seconds = Number(parts[0]);
+    const nanos = parts[1] ? Number((parts[1] + "00000000").substring(0, 9)) : 0;
+
+    return {
+        seconds: negative && seconds ? -seconds : seconds,
+        nanos: negative && nanos ? -nanos : nanos,
+    };
+};
+
+// Redaction redacts a field of a message in the copies made for logs and error reports, see redactFields.
+export type Redaction = (value: any) => any;
+
+// redacted replaces the value of a sensitive field, e.g. a field with the debug_redact option.
+export const redacted: Redaction = () => "[REDACTED]";
+
+// redactFields copies a message for logs and error reports, whose fields with a redaction are replaced by the
+// redaction of their value, e.g. redacted for the sensitive fields, and the redact functions of the messages of
+// message fields. Unset fields are copied as is.
+export const redactFields = (m: any, redactions: {[field: string]: Redaction}): any => {
+    const copy: {[key: string]: any} = {};
+    Object.keys(m).forEach((k) => {
+        const v = m[k];
+        copy[k] = redactions.hasOwnProperty(k) && v !== undefined && v !== null ? redactions[k](v) : v;
+    });
+
+    return copy;
+};
+
+// redactList redacts each item of a repeated field.
+export const redactList = (redact: Redaction): Redaction => {
+    return (values: any[]) => values.map(redact);
+};
+
+// redactMap redacts each value of a map field.
+export const redactMap = (redact: Redaction): Redaction => {
+    return (values: {[key: string]: any}) => {
+        const copy: {[key: string]: any} = {};
+        Object.keys(values).forEach((k) => copy[k] = redact(values[k]));
+
+        return copy;
+    };
+};
+
+// redactOneof redacts the value of the members of a oneof with a redaction, e.g. redactOneof({password: redacted}).
+export const redactOneof = (members: {[kind: string]: Redaction}): Redaction => {
+    return (oneof: {kind: string; value: any}) => {
+        return members.hasOwnProperty(oneof.kind) ? {kind: oneof.kind, value: members[oneof.kind](oneof.value)} : oneof;
+    };
+};
+
+// ProtobufJsLong is a 64 bit integer of a protobuf.js message, which is a Long when long.js is installed
+export type ProtobufJsLong = number | string | {toString(): string};
+
+// ProtobufJsSeconds is a google.protobuf.Timestamp or google.protobuf.Duration of a protobuf.js message
+export interface ProtobufJsSeconds {
+    seconds?: ProtobufJsLong | null;
+    nanos?: number | null;
+}
+
+// timestampToProtobufJs converts a Date to the object of a protobuf.js google.protobuf.Timestamp
+export const timestampToProtobufJs = (d: Date): ProtobufJsSeconds => {
+    const ms = d.getTime();
+    const seconds = Math.floor(ms / 1000);
+    return {seconds: seconds, nanos: (ms - seconds * 1000) * 1000000};
+};
+
+// timestampFromProtobufJs converts a protobuf.js google.protobuf.Timestamp to a Date, which is truncated to milliseconds
+export const timestampFromProtobufJs = (t: ProtobufJsSeconds): Date => {
+    return new Date(Number(String(t.seconds || 0)) * 1000 + Math.floor((t.nanos || 0) / 1000000));
+};
+
+// durationFromProtobufJs converts a protobuf.js google.protobuf.Duration to a Duration
+export const durationFromProtobufJs = (d: ProtobufJsSeconds): Duration => {
+    return {seconds: Number(String(d.seconds || 0)), nanos: d.nanos || 0};
+};
+
+// valueToProtobufJs converts a JSON value to the object of a protobuf.js google.protobuf.Value
+export const valueToProtobufJs = (v: any): {[key: string]: any} => {
+    if (v === null || v === undefined) {
+        return {nullValue: 0};
+    } else if (typeof v === "number") {
+        return {numberValue: v};
+    } else if (typeof v === "string") {
+        return {stringValue: v};
+    } else if (typeof v === "boolean") {
+        return {boolValue: v};
+    } else if (Array.isArray(v)) {
+        return {listValue: {values: v.map(valueToProtobufJs)}};
+    }
+
+    return {structValue: structToProtobufJs(v)};
+};
+
+// valueFromProtobufJs converts a protobuf.js google.protobuf.Value to a JSON value
+export const valueFromProtobufJs = (v: {[key: string]: any} | null | undefined): any => {
+    if (!v) {
+        return null;
+    } else if (v.numberValue !== null && v.numberValue !== undefined) {
+        return v.numberValue;
+    } else if (v.stringValue !== null && v.stringValue !== undefined) {
+        return v.stringValue;
+    } else if (v.boolValue !== null && v.boolValue !== undefined) {
+        return v.boolValue;
+    } else if (v.listValue) {
+        return (v.listValue.values || []).map(valueFromProtobufJs);
+    } else if (v.structValue) {
+        return structFromProtobufJs(v.structValue);
+    }
+
+    return null;
+};
+
+// structToProtobufJs converts a JSON object to the object of a protobuf.js google.protobuf.Struct
+export const structToProtobufJs = (s: {[key: string]: any}): {[key: string]: any} => {
+    return {fields: mapEntries(s, String, valueToProtobufJs)};
+};
+
+// structFromProtobufJs converts a protobuf.js google.protobuf.Struct to a JSON object
+export const structFromProtobufJs = (s: {[key: string]: any} | null | undefined): {[key: string]: any} => {
+    return mapEntries(s && s.fields || {}, String, valueFromProtobufJs);
+};
+
+// ProtobufTsType is the MessageType of a message generated by protobuf-ts, e.g. the Hat exported by service.ts
+export interface ProtobufTsType<T> {
+    fromJson(json: any): T;
+    toJson(message: T, options?: {useProtoFieldName?: boolean; emitDefaultValues?: boolean}): any;
+    fromBinary(data: Uint8Array): T;
+    toBinary(message: T): Uint8Array;
+}
+
+// Any is a google.protobuf.Any, which is the JSON of the packed message along with its type URL
+export interface Any {
+    "@type": string;
+    [key: string]: any;
+}
+
+// packAny packs the JSON of a message, e.g. packAny(HatToJSON(hat), "type.googleapis.com/twitch.twirp.example.Hat")
+export const packAny = (message: {[key: string]: any}, typeUrl: string): Any => {
+    const any: Any = {"@type": typeUrl};
+    Object.keys(message).forEach((k) => any[k] = message[k]);
+    return any;
+};
+
+// jsonAliases copies the fields of the JSON of a message that are set by their alias to their JSON names, e.g. the
+// original proto name of a field that is sent with its lowerCamelCase name, since proto3 JSON accepts either name.
+export const jsonAliases = <T>(json: T, aliases: {[alias: string]: string}): T => {
+    const m: {[key: string]: any} = {};
+    Object.keys(json).forEach((k) => m[k] = (json as any)[k]);
+    Object.keys(aliases).forEach((alias) => {
+        if (m[alias] !== undefined && m[aliases[alias]] === undefined) {
+            m[aliases[alias]] = m[alias];
+        }
+    });
+
+    return m as T;
+};
+
+// parseLosslessJSON parses JSON like JSON.parse, except that integers beyond Number.MAX_SAFE_INTEGER are parsed as
+// their decimal strings, which JSON.parse would round, e.g. the 64 bit fields of servers that send them as numbers.
+export const parseLosslessJSON = (body: string): any => {
+    // safe integers have at most 16 digits
+    if (!/[0-9]{16}/.test(body)) {
+        return JSON.parse(body);
+    }
+
+    let out = "";
+    let start = 0;
+
+    for (let i = 0; i < body.length; i++) {
+        const c = body.charAt(i);
+
+        if (c === "\"") {
+            // skip the string, and the quotes that it escapes
+            for (i++; i < body.length && body.charAt(i) !== "\""; i++) {
+                if (body.charAt(i) === "\\") {
+                    i++;
+                }
+            }
+        } else if (c === "-" || (c >= "0" && c <= "9")) {
+            let end = i + 1;
+            while (end < body.length && /[0-9.eE+-]/.test(body.charAt(end))) {
+                end++;
+            }
+
+            const n = body.substring(i, end);
+            if (/^-?[0-9]+$/.test(n) && Math.abs(Number(n)) > 9007199254740991) {
+                out += body.substring(start, i) + "\"" + n + "\"";
+                start = end;
+            }
+
+            i = end - 1;
+        }
+    }
+
+    return JSON.parse(out + body.substring(start));
+};
+
+// unpackAny unpacks a message using its JSON decoder, e.g. unpackAny(any, JSONToHat)
+export const unpackAny = <T>(any: Any, decoder: (m: any) => T): T => {
+    const m: {[key: string]: any} = {};
+    Object.keys(any).filter((k) => k !== "@type").forEach((k) => m[k] = any[k]);
+    return decoder(m);
+};
@@ -0,0 +1,189 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// AngularLibrary is the runtime module used by the generated Angular services, see Options.Angular.
+func AngularLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {InjectionToken} from '@angular/core';
+import {HttpClient, HttpErrorResponse, HttpHeaders} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions, Transport, TransportResponse} from './twirp';
+
+// TWIRP_HOSTNAME is the hostname of the Twirp server called by the generated Angular services,
+// e.g. {provide: TWIRP_HOSTNAME, useValue: "https://api.example.com"}
+export const TWIRP_HOSTNAME = new InjectionToken<string>("TWIRP_HOSTNAME");
+
+// TWIRP_PREFIX is the path prefix of the Twirp routes, when the server is not mounted at the default prefix.
+export const TWIRP_PREFIX = new InjectionToken<string>("TWIRP_PREFIX");
+
+const bufferResponse = (status: number, buf: ArrayBuffer | null, headers: HttpHeaders): TransportResponse => {
+    const body = buf || new ArrayBuffer(0);
+
+    return {
+        ok: status >= 200 && status < 300,
+        status: status,
+        headers: headers,
+        text: () => Promise.resolve(new TextDecoder().decode(body)),
+        arrayBuffer: () => Promise.resolve(body),
+    };
+};
+
+// httpClientTransport sends requests with Angular's HttpClient, so they pass through the HttpInterceptors
+// of the application.
+export const httpClientTransport = (http: HttpClient): Transport => {
+    return (req) => new Promise<TransportResponse>((resolve, reject) => {
+        if (req.signal && req.signal.aborted) {
+            return reject(new DOMException("Aborted", "AbortError"));
+        }
+
+        const subscription = http.request(req.method || "POST", req.url, {
+            body: req.body === "" ? null : req.body,
+            headers: new HttpHeaders(req.headers),
+            observe: "response",
+            responseType: "arraybuffer",
+        }).subscribe(
+            (resp) => resolve(bufferResponse(resp.status, resp.body, resp.headers)),
+            (err) => {
+                // Twirp errors are read from the response, while a status of 0 is a network error
+                if (err instanceof HttpErrorResponse && err.status !== 0) {
+                    return resolve(bufferResponse(err.status, err.error instanceof ArrayBuffer ? err.error : null, err.headers));
+                }
+
+                reject(err);
+            },
+        );
+
+        if (req.signal) {
+            req.signal.addEventListener("abort", () => {
+                subscription.unsubscribe();
+                reject(new DOMException("Aborted", "AbortError"));
+            });
+        }
+    });
+};
+
+// observeCall makes an Observable of a call, which is made when the Observable is subscribed, and is
+// aborted when it is unsubscribed before the call completes.
+export const observeCall = <T>(callOptions: CallOptions | undefined, call: (callOptions: CallOptions) => Promise<T>): Observable<T> => {
+    return new Observable<T>((subscriber) => {
+        const controller = new AbortController();
+        const options = callOptions || {};
+
+        if (options.signal) {
+            const signal = options.signal;
+            signal.aborted ? controller.abort() : signal.addEventListener("abort", () => controller.abort());
+        }
+
+        call({headers: options.headers, timeoutMs: options.timeoutMs, signal: controller.signal}).then(
+            (resp) => {
+                subscriber.next(resp);
+                subscriber.complete();
+            },
+            (err) => subscriber.error(err),
+        );
+
+        return () => controller.abort();
+    });
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_angular.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const angularTemplate = `
+{{- if not .DeclarationOnly}}
+import {Inject, Injectable, Optional} from '@angular/core';
+{{- end}}
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from '{{importPath "twirp"}}';
+{{- if not .DeclarationOnly}}
+import {TWIRP_HOSTNAME, TWIRP_PREFIX, httpClientTransport, observeCall} from '{{importPath "twirp_angular"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Services}}
+// {{.Name}}Service is an Angular service of the {{.Name}} rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+{{- if $.DeclarationOnly}}
+export declare class {{.Name}}Service {
+    readonly client: Default{{.Name}};
+
+    constructor(http: HttpClient, hostname: string, prefix?: string | null);
+{{range .Methods}}
+    {{jsdoc .Comment "    "}}{{.Name}}({{.RequestParam}}callOptions?: CallOptions): Observable<{{.ResponseType}}>;
+{{- end}}
+}
+{{- else}}
+@Injectable({providedIn: "root"})
+export class {{.Name}}Service {
+    // client is the {{.Name}} client of the service, e.g. to add interceptors or a retry policy
+    readonly client: Default{{.Name}};
+
+    constructor(http: HttpClient, @Inject(TWIRP_HOSTNAME) hostname: string, @Optional() @Inject(TWIRP_PREFIX) prefix?: string | null) {
+        this.client = new Default{{.Name}}(hostname, httpClientTransport(http), {}, prefix || undefined);
+    }
+    {{- range .Methods}}
+
+    {{jsdoc .Comment "    "}}{{.Name}}({{.RequestParam}}callOptions?: CallOptions): Observable<{{.ResponseType}}> {
+        return observeCall(callOptions, (options) => this.client.{{.Name}}({{.RequestArg}}options));
+    }
+    {{- end}}
+}
+{{- end}}
+{{end}}
+`
+
+// angularModule is the module of the Angular services for the services of a generated module, e.g. service_angular.ts
+type angularModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Services        []*Service
+}
+
+// renderAngular generates an Angular service for each service of the module, which wraps the generated
+// client of the service, e.g. HaberdasherService wraps DefaultHaberdasher.
+func (ctx *APIContext) renderAngular() (*plugin.CodeGeneratorResponse_File, error) {
+	module := angularModule{
+		DeclarationOnly: ctx.DeclarationOnly,
+		Imports:         ctx.serviceImports("Default"),
+		Services:        ctx.Services,
+	}
+
+	funcMap := template.FuncMap{
+		"join":  strings.Join,
+		"jsdoc": jsdoc,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("angular").Funcs(funcMap).Parse(angularTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_angular" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// Banner is the banner comment of Options.Banner for a file generated from a proto file, or for a runtime module
+// when source is empty, e.g.
+//
+//	// Code generated by protoc-gen-twirp_typescript. DO NOT EDIT.
+//	// versions:
+//	//   protoc-gen-twirp_typescript 1a2b3c4
+//	//   protoc v3.21.12
+//	// source: service.proto
+//
+// The license text of Options.LicenseFile follows the metadata.
+func Banner(source string, opts Options) (string, error) {
+	lines := []string{
+		"Code generated by protoc-gen-twirp_typescript. DO NOT EDIT.",
+		"versions:",
+		"  protoc-gen-twirp_typescript " + orUnknown(opts.PluginVersion),
+		"  protoc " + orUnknown(opts.CompilerVersion),
+	}
+
+	if source != "" {
+		lines = append(lines, "source: "+source)
+	}
+
+	if opts.LicenseFile != "" {
+		b, err := ioutil.ReadFile(opts.LicenseFile)
+		if err != nil {
+			return "", fmt.Errorf("license_file: %v", err)
+		}
+
+		lines = append(lines, "")
+		for _, l := range strings.Split(strings.TrimRight(strings.Replace(string(b), "\r\n", "\n", -1), "\n"), "\n") {
+			lines = append(lines, l)
+		}
+	}
+
+	b := &strings.Builder{}
+	for _, l := range lines {
+		if l == "" {
+			b.WriteString("//\n")
+			continue
+		}
+
+		b.WriteString("// " + l + "\n")
+	}
+
+	return b.String(), nil
+}
+
+// AddBanners prepends the Banner of a proto file to the typescript files generated from it, with Options.Banner.
+// The JSON files, e.g. package.json, have no comments and are left unchanged.
+func AddBanners(files []*plugin.CodeGeneratorResponse_File, source string, opts Options) error {
+	if !opts.Banner {
+		return nil
+	}
+
+	banner, err := Banner(source, opts)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if !strings.HasSuffix(f.GetName(), ".ts") {
+			continue
+		}
+
+		f.Content = proto.String(banner + "\n" + strings.TrimLeft(f.GetContent(), "\n"))
+	}
+
+	return nil
+}
+
+// CompilerVersion is the version of protoc from a request, e.g. v3.21.12, or empty when protoc does not send it.
+func CompilerVersion(v *plugin.Version) string {
+	if v == nil {
+		return ""
+	}
+
+	s := fmt.Sprintf("v%d.%d.%d", v.GetMajor(), v.GetMinor(), v.GetPatch())
+	if v.GetSuffix() != "" {
+		s += "-" + v.GetSuffix()
+	}
+
+	return s
+}
+
+func orUnknown(s string) string {
+	if s == "" {
+		return "(unknown)"
+	}
+
+	return s
+}
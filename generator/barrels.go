@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// createBarrels generates an index.ts for each proto package with Options.Barrels, e.g. acme/users/index.ts for
+// acme.users, which exports the modules of the proto files of the package, and the packages nested in it as
+// namespaces. The index.ts of the output directory exports the top-level packages as namespaces, along with the
+// modules of the proto files without a package and the runtime modules, so an application imports everything from
+// one root, e.g. import {acme} from './gen'. The modules of each package are named by packages, e.g. acme.users =>
+// ["users", "users_admin"], where the modules of the proto files without a package have an empty package.
+func createBarrels(packages map[string][]string, opts Options) ([]*plugin.CodeGeneratorResponse_File, error) {
+	modules := make(map[string][]string)         // directory of each barrel => the modules that it exports
+	children := make(map[string]map[string]bool) // directory of each barrel => the names of its nested packages
+
+	for pkg, names := range packages {
+		dir := strings.Replace(pkg, ".", "/", -1)
+		modules[dir] = append(modules[dir], names...)
+
+		// each package is a namespace of its parent package, up to the index.ts of the output directory
+		for dir != "" {
+			parent := path.Dir(dir)
+			if parent == "." {
+				parent = ""
+			}
+
+			if children[parent] == nil {
+				children[parent] = make(map[string]bool)
+			}
+
+			children[parent][path.Base(dir)] = true
+			dir = parent
+		}
+	}
+
+	for _, f := range RuntimeLibraries(opts) {
+		if strings.HasSuffix(f.GetName(), ".ts") {
+			modules[""] = append(modules[""], strings.TrimSuffix(f.GetName(), ".ts"))
+		}
+	}
+
+	var dirs []string
+	for dir := range children {
+		dirs = append(dirs, dir)
+	}
+
+	for dir := range modules {
+		if children[dir] == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	sort.Strings(dirs)
+
+	t, err := template.New("index.ts").Parse(indexTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*plugin.CodeGeneratorResponse_File
+	for _, dir := range dirs {
+		index := path.Join(dir, "index")
+
+		var exports []indexExport
+		sort.Strings(modules[dir])
+		for _, module := range modules[dir] {
+			exports = append(exports, indexExport{Module: importPath(index, module)})
+		}
+
+		for _, name := range sortedKeys(children[dir]) {
+			namespace := name
+			if reservedWords[namespace] {
+				namespace += "_"
+			}
+
+			// the barrel is imported by its index, so a module of the parent package with the same name is not
+			// imported instead, e.g. ./users/index rather than ./users
+			exports = append(exports, indexExport{Module: "./" + path.Join(name, "index"), Namespace: namespace})
+		}
+
+		b := bytes.NewBufferString("")
+		if err := t.Execute(b, exports); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &plugin.CodeGeneratorResponse_File{
+			Name:    proto.String(index + ".ts"),
+			Content: proto.String(b.String()),
+		})
+	}
+
+	return out, nil
+}
+
+func sortedKeys(set map[string]bool) []string {
+	var keys []string
+	for k := range set {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
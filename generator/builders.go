@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// builderValue generates the value of a field of the message made by the create function of its message with
+// Options.Builders, which is the value of the field of partial, or its proto3 default value when it is not set.
+func builderValue(f ModelField) string {
+	value := "partial." + f.Name
+	if f.Optional() {
+		return value
+	}
+
+	return fmt.Sprintf("%s !== undefined ? %s : %s", value, value, fieldDefault(f, "create"))
+}
+
+// fieldDefault generates the proto3 default value of a field that is not optional, whose messages are made by the
+// functions named after the messages with the prefix, e.g. createHat() or exampleHat().
+func fieldDefault(f ModelField, prefix string) string {
+	switch {
+	case f.IsMap:
+		return "{}"
+	case f.IsRepeated, f.IsFieldMask:
+		return "[]"
+	case f.Type == "Date":
+		return "new Date(0)"
+	case f.IsMessage:
+		return fmt.Sprintf("%s%s()", prefix, f.Type)
+	case f.IsDuration && f.Type == "Duration":
+		return "{seconds: 0, nanos: 0}"
+	case f.IsDuration:
+		return `"0s"`
+	case f.IsWrapper:
+		return "null"
+	}
+
+	switch f.Codec {
+	case "any":
+		return `{"@type": ""}`
+	case "struct":
+		return "{}"
+	case "value":
+		return "null"
+	case "listValue":
+		return "[]"
+	}
+
+	return zeroValue(f)
+}
+
+// HasBuilder reports if a model has a builder with Options.Builders, which is generated for the models of rpc
+// requests with fields, unless a field is named build like the method that builds the message.
+func (m *Model) HasBuilder() bool {
+	fields := m.builderFields()
+	if !m.Request || len(fields) == 0 {
+		return false
+	}
+
+	for _, name := range fields {
+		if name == "build" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequiredFields is the union of the names of the fields and oneofs of a model that are set before its builder
+// builds it, which have the REQUIRED field behavior or the PGV message.required or oneof.required rule, e.g.
+// "title" | "isbn", or never when there are none.
+func (m *Model) RequiredFields() string {
+	var required []string
+	for _, f := range m.Fields {
+		if f.IsRequired || m.requiredRules[f.Name] {
+			required = append(required, jsString(f.Name))
+		}
+	}
+
+	for _, o := range m.Oneofs {
+		if m.requiredRules[o.Name] {
+			required = append(required, jsString(o.Name))
+		}
+	}
+
+	if len(required) == 0 {
+		return "never"
+	}
+
+	return strings.Join(required, " | ")
+}
+
+// builderFields are the names of the fields and oneofs of a model, which are set by the methods of its builder.
+func (m *Model) builderFields() []string {
+	var names []string
+	for _, f := range m.Fields {
+		names = append(names, f.Name)
+	}
+
+	for _, o := range m.Oneofs {
+		names = append(names, o.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,218 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// CacheLibrary is the runtime module used by the generated caching clients, see Options.Cache.
+func CacheLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {canonicalJSON} from './twirp';
+
+export interface CacheOptions {
+    // ttl is the time in milliseconds that a response is cached
+    ttl: number;
+}
+
+interface CacheEntry {
+    expires: number;
+    response: Promise<any>;
+}
+
+// ResponseCache caches the responses of the calls of rpc methods for the ttl of its options, by method and
+// canonicalJSON of the request. The calls of the same request share a pending response, and a call that fails is not cached.
+export class ResponseCache {
+    private ttl: number;
+    private entries: {[key: string]: CacheEntry} = {};
+
+    constructor(options: CacheOptions) {
+        this.ttl = options.ttl;
+    }
+
+    // get resolves to the cached response of a request of a method, or to the response of call, which is cached
+    get<T>(method: string, request: object, call: () => Promise<T>): Promise<T> {
+        const key = method + " " + canonicalJSON(request);
+        const now = Date.now();
+        const cached = this.entries[key];
+        if (cached && cached.expires > now) {
+            return cached.response;
+        }
+
+        this.removeExpired(now);
+
+        const entry: CacheEntry = {expires: now + this.ttl, response: call()};
+        this.entries[key] = entry;
+        entry.response.catch(() => {
+            if (this.entries[key] === entry) {
+                delete this.entries[key];
+            }
+        });
+
+        return entry.response;
+    }
+
+    // invalidate removes the cached response of a request of a method, the cached responses of a method, or all of
+    // the cached responses
+    invalidate(method?: string, request?: object) {
+        if (method !== undefined && request !== undefined) {
+            delete this.entries[method + " " + canonicalJSON(request)];
+            return;
+        }
+
+        Object.keys(this.entries).forEach((key) => {
+            if (method === undefined || key.indexOf(method + " ") === 0) {
+                delete this.entries[key];
+            }
+        });
+    }
+
+    private removeExpired(now: number) {
+        Object.keys(this.entries).forEach((key) => {
+            if (this.entries[key].expires <= now) {
+                delete this.entries[key];
+            }
+        });
+    }
+}
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_cache.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const cacheTemplate = `
+{{- if .DeclarationOnly}}
+import {CacheOptions} from '{{importPath "twirp_cache"}}';
+{{- else}}
+import {CacheOptions, ResponseCache} from '{{importPath "twirp_cache"}}';
+{{- end}}
+import {CallOptions} from '{{importPath "twirp"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Services}}
+// Cached{{.Name}}Method is a method of {{.Name}} without side effects, whose responses are cached by the clients of
+// createCached{{.Name}}Client.
+export type Cached{{.Name}}Method = {{cachedMethods .}};
+
+// Cached{{.Name}} is a {{.Name}} that caches the responses of its methods without side effects.
+export interface Cached{{.Name}} extends {{.Name}} {
+    // invalidate removes the cached response of a request of a method, the cached responses of a method, or all of
+    // the cached responses
+    invalidate(method?: Cached{{.Name}}Method, request?: object): void;
+}
+
+// createCached{{.Name}}Client wraps a {{.Name}} in a client that caches the responses of the methods whose
+// idempotency_level is NO_SIDE_EFFECTS, e.g. createCached{{.Name}}Client(client, {ttl: 60000}). The other methods
+// are called by the client. The cached responses are shared by the calls, so they must not be changed.
+{{- if $.DeclarationOnly}}
+export declare const createCached{{.Name}}Client: (client: {{.Name}}, options: CacheOptions) => Cached{{.Name}};
+{{- else}}
+export const createCached{{.Name}}Client = (client: {{.Name}}, options: CacheOptions): Cached{{.Name}} => {
+    const cache = new ResponseCache(options);
+
+    return {
+        {{- range .Methods}}
+        {{- if .EmptyRequest}}
+        {{.Name}}: (callOptions?: CallOptions): Promise<{{.ResponseType}}> => {
+        {{- else}}
+        {{.Name}}: (request: {{.InputType}}, callOptions?: CallOptions): Promise<{{.ResponseType}}> => {
+        {{- end}}
+            {{- if eq .Idempotency "no_side_effects"}}
+            return cache.get("{{.Name}}", {{if .EmptyRequest}}{}{{else}}request{{end}}, () => client.{{.Name}}({{if not .EmptyRequest}}request, {{end}}callOptions));
+            {{- else}}
+            return client.{{.Name}}({{if not .EmptyRequest}}request, {{end}}callOptions);
+            {{- end}}
+        },
+        {{- end}}
+        invalidate: (method?: Cached{{.Name}}Method, request?: object) => cache.invalidate(method, request),
+    };
+};
+{{- end}}
+{{end}}`
+
+// cachedMethods generates the union of the names of the methods of a service whose responses are cached, e.g.
+// "getHat" | "listHats"
+func cachedMethods(s *Service) string {
+	var names []string
+	for _, m := range s.Methods {
+		if m.Idempotency == "no_side_effects" {
+			names = append(names, `"`+m.Name+`"`)
+		}
+	}
+
+	return strings.Join(names, " | ")
+}
+
+// cacheModule is the module of the caching clients of the services of a generated module, e.g. service_cache.ts
+type cacheModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Services        []*Service
+}
+
+// hasCache reports if any of the services of the module has a method without side effects, see idempotency.
+func (ctx *APIContext) hasCache() bool {
+	for _, s := range ctx.Services {
+		if cachedMethods(s) != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// renderCache generates the caching clients of the services of the module with methods without side effects, with
+// Options.Cache. A caching client wraps a service interface, so it caches the responses of a client, a mock client,
+// or a client of the functions of client_style=functions.
+func (ctx *APIContext) renderCache() (*plugin.CodeGeneratorResponse_File, error) {
+	module := cacheModule{DeclarationOnly: ctx.DeclarationOnly}
+
+	for _, s := range ctx.Services {
+		if cachedMethods(s) != "" {
+			module.Services = append(module.Services, s)
+		}
+	}
+
+	module.Imports = ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, s := range module.Services {
+			add(s.Name, s.Name)
+			for _, m := range s.Methods {
+				add(m.InputType, m.InputType)
+				add(m.OutputType, m.ResponseType)
+			}
+		}
+	})
+
+	funcMap := template.FuncMap{
+		"join":          strings.Join,
+		"cachedMethods": cachedMethods,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("cache").Funcs(funcMap).Parse(cacheTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_cache" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
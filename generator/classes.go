@@ -0,0 +1,78 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// classInit generates the value that the constructor of a message class assigns to a field from its
+// initializer, which is the proto3 default value for fields that have one.
+func classInit(f ModelField) string {
+	value := "init." + f.Name
+
+	var zero string
+	switch {
+	case f.Optional():
+		return value
+	case f.IsMap:
+		zero = "{}"
+	case f.IsRepeated, f.IsFieldMask:
+		zero = "[]"
+	case f.IsWrapper:
+		zero = "null"
+	case f.IsMessage, f.IsDuration, f.Codec != "", f.IsOptional:
+		// messages and REQUIRED optional fields have no default, and are left unset as in the interfaces
+		return fmt.Sprintf("%s as %s", value, f.Type)
+	default:
+		zero = zeroValue(f)
+	}
+
+	return fmt.Sprintf("%s !== undefined ? %s : %s", value, value, zero)
+}
+
+// classEquals generates the comparison of the fields and oneofs of a message class with those of another message.
+func classEquals(m *Model) string {
+	var names []string
+
+	for _, f := range m.Fields {
+		names = append(names, f.Name)
+	}
+
+	for _, o := range m.Oneofs {
+		names = append(names, o.Name)
+	}
+
+	if len(names) == 0 {
+		return "true"
+	}
+
+	var checks []string
+	for _, n := range names {
+		checks = append(checks, fmt.Sprintf("valuesEqual(this.%s, other.%s)", n, n))
+	}
+
+	return strings.Join(checks, "\n            && ")
+}
+
+// Classes reports if the messages are generated as classes, see Options.MessageModels.
+func (o Options) Classes() bool {
+	return o.MessageModels == ModelsClasses
+}
+
+// markAllModels sets the marshal flags of every model with Options.MessageModels set to classes, since the
+// fromJSON and toJSON methods of the classes call the JSON functions of their messages, and with Options.ModelsOnly,
+// since the messages of its modules are not sent by services.
+func (ctx *APIContext) markAllModels() {
+	if !ctx.Classes() && !ctx.ModelsOnly {
+		return
+	}
+
+	for _, m := range ctx.Models {
+		if m.Primitive {
+			continue
+		}
+
+		m.CanMarshal = true
+		m.CanUnmarshal = true
+	}
+}
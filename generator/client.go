@@ -3,7 +3,7 @@ package generator
 import (
 	"bytes"
 	"fmt"
-	"log"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -13,119 +13,855 @@ import (
 )
 
 const apiTemplate = `
-import {createTwirpRequest, throwTwirpError, Fetch} from './twirp';
-
+{{- if .ProtobufMessages}}
+import {createTwirpProtobufRequest, createGRPCWebRequest, grpcWebResponse, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, ProtobufReader, ProtobufWriter, timestampToProtobuf, protobufToTimestamp, fieldMaskToString, fieldMaskFromString, fieldMaskToProtobuf, protobufToFieldMask, Any, anyToProtobuf, protobufToAny, structToProtobuf, protobufToStruct, valueToProtobuf, protobufToValue, listValueToProtobuf, protobufToListValue, Duration, durationToString, durationFromString, durationToProtobuf, protobufToDuration, unwrapValue, everyItem, everyValue, oneofMember, isDurationObject, BuildWhenSet, messageBuilder, mergeFields, mergeMap, diffFields, redactFields, redacted, redactList, redactMap, redactOneof} from '{{importPath "twirp"}}';
+{{- else}}
+import {createTwirpRequest, createConnectRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, throwConnectError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, bytesToBase64, base64ToBytes, fieldMaskToString, fieldMaskFromString, Any, Duration, durationToString, durationFromString, mapEntries, enumFromJSON, floatToJSON, floatFromJSON, jsonAliases, everyItem, everyValue, oneofMember, isDurationObject, BuildWhenSet, messageBuilder, mergeFields, mergeMap, diffFields, redactFields, redacted, redactList, redactMap, redactOneof} from '{{importPath "twirp"}}';
+{{- end}}
+{{- if and .Zod .Services}}
+import {parseResponse} from '{{importPath "twirp"}}';
+{{- end}}
+{{- if .LosslessJSON}}
+import {parseLosslessJSON} from '{{importPath "twirp"}}';
+{{- end}}
+{{- if and .Classes .Models}}
+import {cloneValue, valuesEqual} from '{{importPath "twirp"}}';
+{{- end}}
+{{- if .Validates}}
+import {ValidationError, validationError, checkRule, validateMessage, validateList, validateMap, runeCount, utf8Length, isUnique, isEmail, isHostname, isURI, isUUID} from '{{importPath "twirp"}}';
+{{- end}}
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, retryNetworkFailures, TwirpClient, TwirpClientConfig, clientConfig, runInterceptors} from '{{importPath "interceptors"}}';
+{{- if and (eq .Target "node") .Services}}
+import {nodeTransport} from '{{importPath "transports"}}';
+{{- else if and (eq .Target "deno") .Services}}
+import {fetchTransport} from '{{importPath "transports"}}';
+{{- end}}
+{{- if .HasREST}}
+import {restRequest, createRESTRequest, restResponse, throwRESTError} from '{{importPath "twirp_rest"}}';
+{{- end}}
+{{- if and .Server .Services}}
+import {createTwirpRouter, ServerRequest, TwirpRouter} from '{{importPath "twirp_server"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Enums}}
+{{jsdoc .Comment ""}}export enum {{.Name}} {
+    {{range .Values -}}
+    {{jsdoc .Comment "    "}}{{.Name}} = {{.Value}},
+    {{end}}
+}
+{{end}}
 {{range .Models}}
 {{- if not .Primitive}}
-export interface {{.Name}} {
+{{- range .Oneofs}}
+{{jsdoc .Comment ""}}export type {{.Type}} =
+    {{- range .Fields}}
+    | {kind: "{{.Name}}"; value: {{.Type}}}
+    {{- end}};
+{{end}}
+{{jsdoc .Comment ""}}export {{if $.Classes}}class{{else}}interface{{end}} {{.Name}} {
     {{range .Fields -}}
-    {{.Name}}: {{.Type}};
+    {{jsdoc .Comment "    "}}{{if .IsReadOnly}}readonly {{end}}{{.Name}}{{if .Optional}}?{{end}}: {{.Type}}{{if .Optional}} | undefined{{end}};
+    {{end -}}
+    {{range .Oneofs -}}
+    {{jsdoc .Comment "    "}}{{.Name}}?: {{.Type}};
     {{end}}
-}
+    {{- if $.Classes}}
+    constructor({{if or .Fields .Oneofs}}init{{else}}_init{{end}}: Partial<{{.Name}}> = {}) {
+        {{- range .Fields}}
+        this.{{.Name}} = {{classInit .}};
+        {{- end}}
+        {{- range .Oneofs}}
+        this.{{.Name}} = init.{{.Name}};
+        {{- end}}
+    }
+
+    // clone returns a deep copy of the {{.Name}}.
+    clone(): {{.Name}} {
+        return new {{.Name}}({
+            {{range .Fields -}}
+            {{.Name}}: cloneValue(this.{{.Name}}),
+            {{end -}}
+            {{range .Oneofs -}}
+            {{.Name}}: cloneValue(this.{{.Name}}),
+            {{end}}
+        });
+    }
+
+    // equals reports if the fields of the {{.Name}} are deeply equal to those of other.
+    equals(other: {{.Name}}): boolean {
+        return {{classEquals .}};
+    }
 
-interface {{.Name}}JSON {
+    static fromJSON(m: {{.Name}}JSON): {{.Name}} {
+        return JSONTo{{.Name}}(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a {{.Name}} is stringified as its proto3 JSON.
+    toJSON(): {{.Name}}JSON {
+        return {{.Name}}ToJSON(this);
+    }
+    {{- end}}
+}
+{{- if .Readonly}}
+{{range .Oneofs}}
+export type Readonly{{.Type}} =
+    {{- range .Fields}}
+    | {readonly kind: "{{.Name}}"; readonly value: {{readonlyType .}}}
+    {{- end}};
+{{end}}
+// Readonly{{.Name}} is the interface of a {{.Name}} returned by the clients, whose fields cannot be changed.
+export interface Readonly{{.Name}} {
     {{range .Fields -}}
-    {{.JSONName}}: {{.JSONType}};
+    {{jsdoc .Comment "    "}}readonly {{.Name}}{{if .Optional}}?{{end}}: {{readonlyType .}}{{if .Optional}} | undefined{{end}};
+    {{end -}}
+    {{range .Oneofs -}}
+    {{jsdoc .Comment "    "}}readonly {{.Name}}?: Readonly{{.Type}};
     {{end}}
 }
+{{- end}}
+
+// {{.Name}}Fields are the numbers and proto types of the fields of {{.Name}} by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const {{.Name}}Fields = {
+    {{- range fieldNumbers .}}
+    {{.Name}}: {number: {{.Number}}, name: "{{.ProtoName}}", type: "{{.Type}}"},
+    {{- end}}
+} as const;
+
+export interface {{.Name}}JSON {
+    {{range .Fields -}}
+    {{.JSONName}}{{if .IsOptional}}?{{end}}: {{.JSONType}};
+    {{end -}}
+    {{range .Oneofs}}{{range .Fields -}}
+    {{.JSONName}}?: {{.JSONType}};
+    {{end}}{{end}}
+}
 
 {{if .CanMarshal}}
-const {{.Name}}ToJSON = (m: {{.Name}}): {{.Name}}JSON => {
+{{- if $.ProtobufMessages}}
+export const {{.Name}}ToProtobuf = (m: {{.Name}}): Uint8Array => {
+    const w = new ProtobufWriter();
+    {{range .Fields -}}
+    {{encodeField .}}
+    {{end -}}
+    {{range $o := .Oneofs}}{{range .Fields -}}
+    {{encodeOneof $o .}}
+    {{end}}{{end}}
+    return w.finish();
+};
+{{- else}}
+export const {{.Name}}ToJSON = (m: {{.Name}}): {{.Name}}JSON => {
     return {
         {{range .Fields -}}
         {{.JSONName}}: {{stringify .}},
-        {{end}}
+        {{end -}}
+        {{range $o := .Oneofs}}{{range .Fields -}}
+        {{.JSONName}}: {{stringifyOneof $o .}},
+        {{end}}{{end}}
     };
 };
+{{- end}}
 {{end -}}
 
 {{if .CanUnmarshal}}
-const JSONTo{{.Name}} = (m: {{.Name}}JSON): {{.Name}} => {
-    return {
+{{- if $.ProtobufMessages}}
+export const ProtobufTo{{.Name}} = (b: Uint8Array): {{.Name}} => {
+    const r = new ProtobufReader(b);
+    const m = { {{- zeroValues . -}} } as {{if .HasReadOnlyFields}}{-readonly [K in keyof {{.Name}}]: {{.Name}}[K]}{{else}}{{.Name}}{{end}};
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            {{range .Fields -}}
+            {{decodeField .}}
+            {{end -}}
+            {{range $o := .Oneofs}}{{range .Fields -}}
+            {{decodeOneof $o .}}
+            {{end}}{{end -}}
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+{{- else}}
+export const JSONTo{{.Name}} = ({{if jsonAliases .}}json{{else}}m{{end}}: {{.Name}}JSON): {{.Name}} => {
+    {{- with jsonAliases .}}
+    const m = jsonAliases(json, {{.}});
+{{end}}
+    return {{if $.Classes}}new {{.Name}}({{end}}{
         {{range .Fields -}}
         {{.Name}}: {{parse .}},
+        {{end -}}
+        {{range .Oneofs -}}
+        {{.Name}}: {{parseOneof .}},
         {{end}}
-    };
+    }{{if $.Classes}}){{end}};
 };
+{{- end}}
 {{end -}}
+{{if .Validate}}
+// validate{{.Name}} checks the protoc-gen-validate rules of a {{.Name}}, and returns the violated rules.
+export const validate{{.Name}} = (m: {{.Name}}): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    {{- range .Validations}}
+    {{.}}
+    {{- end}}
+
+    return errors;
+};
 {{end -}}
+{{if .Redact}}
+// redact{{.Name}} copies a {{.Name}} for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redact{{.Name}} = (m: {{.Name}}): any => {
+    return redactFields(m, { {{- redactions . -}} });
+};
 {{end}}
+// is{{.Name}} reports if a value has the fields of a {{.Name}}, e.g. to check data read from a cache or a websocket.
+export const is{{.Name}} = (value: unknown): value is {{.Name}} => {
+    if ({{if $.Classes}}!(value instanceof {{.Name}}){{else}}typeof value !== "object" || value === null{{end}}) {
+        return false;
+    }
+{{if or .Fields .Oneofs}}
+    const m = value as {[key: string]: unknown};
+{{- end}}
+    return {{guardChecks .}};
+};
+{{- if $.Builders}}
+
+// create{{.Name}} creates a {{.Name}} whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const create{{.Name}} = ({{if or .Fields .Oneofs}}partial{{else}}_partial{{end}}: Partial<{{.Name}}> = {}): {{.Name}} => {
+    {{- if or .Fields .Oneofs}}
+    return {{if $.Classes}}new {{.Name}}({{end}}{
+        {{- range .Fields}}
+        {{.Name}}: {{builderValue .}},
+        {{- end}}
+        {{- range .Oneofs}}
+        {{.Name}}: partial.{{.Name}},
+        {{- end}}
+    }{{if $.Classes}}){{end}};
+    {{- else}}
+    return {{if $.Classes}}new {{.Name}}(){{else}}{}{{end}};
+    {{- end}}
+};
+{{- end}}
+{{- if and $.Builders .HasBuilder}}
+{{- $m := .}}
+
+// {{.Name}}Builder builds a {{.Name}} one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface {{.Name}}Builder<Set extends string = never> {
+    {{- range .Fields}}
+    {{methodKey .Name}}(value: {{.Type}}): {{$m.Name}}Builder<Set | "{{.Name}}">;
+    {{- end}}
+    {{- range .Oneofs}}
+    {{methodKey .Name}}(value: {{.Type}}): {{$m.Name}}Builder<Set | "{{.Name}}">;
+    {{- end}}
+    build: BuildWhenSet<{{.RequiredFields}}, Set, {{.Name}}>;
+}
+
+// build{{.Name}} starts a {{.Name}}Builder, e.g. to build a request whose required fields are checked at compile time.
+export const build{{.Name}} = (): {{.Name}}Builder => messageBuilder<{{.Name}}Builder>(create{{.Name}}, [{{builderFields .}}]);
+{{- end}}
+{{- if $.MergeDiff}}
+
+// merge{{.Name}} copies a {{.Name}} with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the {{.Name}}, while its other fields replace them.
+export const merge{{.Name}} = (base: {{.Name}}, patch: Partial<{{.Name}}>): {{.Name}} => {
+    return {{if $.Classes}}new {{.Name}}({{end}}mergeFields(base, patch, {{mergers .}}){{if $.Classes}}){{end}};
+};
 
-{{range .Services}}
-export interface {{.Name}} {
+// diff{{.Name}} finds the paths of the fields that differ between two {{.Name}}s, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a {{.Name}}.
+export const diff{{.Name}} = (a: {{.Name}}, b: {{.Name}}): string[] => {
+    return diffFields(a, b, {{fieldDiffs .}});
+};
+{{- end}}
+{{end -}}
+{{end}}
+{{range fieldMasks}}
+// {{.Message}}FieldMaskPath is the path of a field of a {{.Message}} or of its nested messages in a FieldMask.
+export type {{.Message}}FieldMaskPath = {{range $i, $p := .Paths}}{{if $i}} | {{end}}"{{$p}}"{{end}};
+
+// {{.Func}} is a FieldMask of the fields of a {{.Message}}, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export const {{.Func}} = (...paths: {{.Message}}FieldMaskPath[]): string[] => paths;
+{{end}}
+{{range $s := .Services}}
+{{jsdoc .Comment ""}}export interface {{.Name}} {
 	{{- range .Methods}}
-    {{.Name}}: ({{.InputArg}}: {{.InputType}}) => Promise<{{.OutputType}}>;
+    {{jsdoc .Comment "    "}}{{.Name}}: ({{.RequestParam}}callOptions?: CallOptions) => Promise<{{.ResponseType}}>;
     {{end}}
 }
 
-export class Default{{.Name}} implements {{.Name}} {
+// {{.Name}}Methods are the Twirp routes of the methods of {{.Name}}, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const {{.Name}}Methods = {
+    {{- range .Methods}}
+    {{.Name}}: {
+        service: "{{$s.FullName}}",
+        method: "{{.Path}}",
+        path: "{{$.TwirpPrefix}}/{{$s.FullName}}/{{.Path}}",
+        inputType: "{{.InputType}}",
+        outputType: "{{.OutputType}}",
+    },
+    {{- end}}
+} as const;
+
+{{- if eq $.ClientStyle "functions"}}
+{{- range .Methods}}
+
+{{jsdoc .Comment ""}}export const {{functionName $s .}} = (client: TwirpClient, {{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}> => {
+    {{- if validates .InputType}}
+    const errors = validate{{.InputType}}({{.InputArg}});
+    if (errors.length > 0) {
+        return Promise.reject(validationError(errors));
+    }
+
+    {{- end}}
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "{{$.TwirpPrefix}}") + "/{{$s.FullName}}/{{.Path}}");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "{{$s.FullName}}",
+            method: "{{.Path}}",
+            url: url,
+            request: {{or .InputArg "{}"}},
+            headers: options.headers || {},
+            signal: options.signal,
+            {{- if redactor .}}
+            redactor: {{redactor .}},
+            {{- end}}
+            {{- if .Idempotency}}
+            idempotency: "{{.Idempotency}}",
+            {{- end}}
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            {{- if eq $.Protocol "grpcweb"}}
+            return {{transport "client" .}}(createGRPCWebRequest(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                return grpcWebResponse(resp).then({{if .EmptyResponse}}() => undefined{{else}}(buf) => ProtobufTo{{.OutputType}}(buf){{end}});
+            });
+            {{- else if eq $.Protocol "protobuf"}}
+            return {{transport "client" .}}(createTwirpProtobufRequest(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.arrayBuffer().then({{if .EmptyResponse}}() => undefined{{else}}(buf) => ProtobufTo{{.OutputType}}(new Uint8Array(buf)){{end}});
+            });
+            {{- else}}
+            return {{transport "client" .}}({{if eq $.Protocol "connect"}}createConnectRequest{{else}}createTwirpRequest{{end}}(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return {{if eq $.Protocol "connect"}}throwConnectError{{else}}throwTwirpError{{end}}(resp);
+                }
+                {{- $json := "JSON.parse(body)"}}{{if .LosslessJSON}}{{$json = "parseLosslessJSON(body)"}}{{end}}
+
+                return resp.text().then({{if .EmptyResponse}}() => undefined{{else}}(body) => JSONTo{{.OutputType}}({{if $.Zod}}parseResponse({{.OutputType}}Schema, {{$json}}){{else}}{{$json}}{{end}}){{end}});
+            });
+            {{- end}}
+        });
+    }));
+};
+{{- if .HTTP}}
+
+// {{functionName $s .}}Rest calls {{$s.Name}}.{{.Path}} with its REST route, {{.HTTP.Method}} {{.HTTP.Path}}
+export const {{functionName $s .}}Rest = (client: TwirpClient, {{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}> => {
+    {{- if validates .InputType}}
+    const errors = validate{{.InputType}}({{.InputArg}});
+    if (errors.length > 0) {
+        return Promise.reject(validationError(errors));
+    }
+
+    {{- end}}
+    const rule = {{.HTTP.Literal}};
+    const rest = restRequest(rule, {{requestBody . .InputArg}});
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "{{$s.FullName}}",
+            method: "{{.Path}}",
+            url: url,
+            request: {{or .InputArg "{}"}},
+            headers: options.headers || {},
+            signal: options.signal,
+            {{- if redactor .}}
+            redactor: {{redactor .}},
+            {{- end}}
+            {{- if .Idempotency}}
+            idempotency: "{{.Idempotency}}",
+            {{- end}}
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return {{transport "client" .}}(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+                {{- $json := "restResponse(rule, body)"}}{{if .LosslessJSON}}{{$json = "restResponse(rule, body, parseLosslessJSON)"}}{{end}}
+
+                return resp.text().then({{if .EmptyResponse}}() => undefined{{else}}(body) => JSONTo{{.OutputType}}({{if $.Zod}}parseResponse({{.OutputType}}Schema, {{$json}}){{else}}{{$json}}{{end}}){{end}});
+            });
+        });
+    }));
+};
+{{- end}}
+{{- end}}
+
+// create{{.Name}}Client creates a {{.Name}} of the rpc functions of {{.Name}}, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const create{{.Name}}Client = (client: TwirpClient): {{.Name}} => {
+    return {
+        {{- range .Methods}}
+        {{.Name}}: ({{.RequestParam}}callOptions?: CallOptions) => {{functionName $s .}}(client, {{.RequestArg}}callOptions),
+        {{- end}}
+    };
+};
+{{- else}}
+
+{{jsdoc .Comment ""}}export class Default{{.Name}} implements {{.Name}} {
     private hostname: string;
-    private fetch: Fetch;
-    private pathPrefix = "/twirp/{{.Package}}.{{.Name}}/";
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport{{if ne $.Target "browser"}}?{{end}}: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport{{if eq $.Target "node"}}: Transport = nodeTransport(){{else if eq $.Target "deno"}}: Transport = fetchTransport(fetch){{else}}?: Transport{{end}}, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "{{$.TwirpPrefix}}") + "/{{.FullName}}/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
 
-    constructor(hostname: string, fetch: Fetch) {
-        this.hostname = hostname;
-        this.fetch = fetch;
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
     }
 
     {{- range .Methods}}
-    {{.Name}}({{.InputArg}}: {{.InputType}}): Promise<{{.OutputType}}> {
-        const url = this.hostname + this.pathPrefix + "{{.Path}}";
-        return this.fetch(createTwirpRequest(url, {{.InputType}}ToJSON({{.InputArg}}))).then((resp) => {
-            if (!resp.ok) {
-                return throwTwirpError(resp);
-            }
-
-            return resp.json().then(JSONTo{{.OutputType}});
-        });
+    {{jsdoc .Comment "    "}}{{.Name}}({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}> {
+        {{- if validates .InputType}}
+        const errors = validate{{.InputType}}({{.InputArg}});
+        if (errors.length > 0) {
+            return Promise.reject(validationError(errors));
+        }
+
+        {{- end}}
+        const url = joinURL(this.hostname, this.pathPrefix + "{{.Path}}");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "{{$s.FullName}}",
+                method: "{{.Path}}",
+                url: url,
+                request: {{or .InputArg "{}"}},
+                headers: options.headers || {},
+                signal: options.signal,
+                {{- if redactor .}}
+                redactor: {{redactor .}},
+                {{- end}}
+                {{- if .Idempotency}}
+                idempotency: "{{.Idempotency}}",
+                {{- end}}
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                {{- if eq $.Protocol "grpcweb"}}
+                return {{transport "this" .}}(createGRPCWebRequest(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    return grpcWebResponse(resp).then({{if .EmptyResponse}}() => undefined{{else}}(buf) => ProtobufTo{{.OutputType}}(buf){{end}});
+                });
+                {{- else if eq $.Protocol "protobuf"}}
+                return {{transport "this" .}}(createTwirpProtobufRequest(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then({{if .EmptyResponse}}() => undefined{{else}}(buf) => ProtobufTo{{.OutputType}}(new Uint8Array(buf)){{end}});
+                });
+                {{- else}}
+                return {{transport "this" .}}({{if eq $.Protocol "connect"}}createConnectRequest{{else}}createTwirpRequest{{end}}(ctx.url, {{requestBody . "ctx.request"}}, ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return {{if eq $.Protocol "connect"}}throwConnectError{{else}}throwTwirpError{{end}}(resp);
+                    }
+                    {{- $json := "JSON.parse(body)"}}{{if .LosslessJSON}}{{$json = "parseLosslessJSON(body)"}}{{end}}
+
+                    return resp.text().then({{if .EmptyResponse}}() => undefined{{else}}(body) => JSONTo{{.OutputType}}({{if $.Zod}}parseResponse({{.OutputType}}Schema, {{$json}}){{else}}{{$json}}{{end}}){{end}});
+                });
+                {{- end}}
+            });
+        }));
+    }
+    {{- if .HTTP}}
+
+    // {{.Name}}Rest calls {{$s.Name}}.{{.Path}} with its REST route, {{.HTTP.Method}} {{.HTTP.Path}}
+    {{.Name}}Rest({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}> {
+        {{- if validates .InputType}}
+        const errors = validate{{.InputType}}({{.InputArg}});
+        if (errors.length > 0) {
+            return Promise.reject(validationError(errors));
+        }
+
+        {{- end}}
+        const rule = {{.HTTP.Literal}};
+        const rest = restRequest(rule, {{requestBody . .InputArg}});
+        const url = joinURL(this.hostname, rest.path);
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "{{$s.FullName}}",
+                method: "{{.Path}}",
+                url: url,
+                request: {{or .InputArg "{}"}},
+                headers: options.headers || {},
+                signal: options.signal,
+                {{- if redactor .}}
+                redactor: {{redactor .}},
+                {{- end}}
+                {{- if .Idempotency}}
+                idempotency: "{{.Idempotency}}",
+                {{- end}}
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return {{transport "this" .}}(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwRESTError(resp);
+                    }
+                    {{- $json := "restResponse(rule, body)"}}{{if .LosslessJSON}}{{$json = "restResponse(rule, body, parseLosslessJSON)"}}{{end}}
+
+                    return resp.text().then({{if .EmptyResponse}}() => undefined{{else}}(body) => JSONTo{{.OutputType}}({{if $.Zod}}parseResponse({{.OutputType}}Schema, {{$json}}){{else}}{{$json}}{{end}}){{end}});
+                });
+            });
+        }));
     }
+    {{- end}}
     {{end}}
 }
+
+// create{{.Name}}Client creates a Default{{.Name}} with the config, which may be shared by the clients of other services.
+export const create{{.Name}}Client = (config: TwirpClientConfig): Default{{.Name}} => {
+    return new Default{{.Name}}(config);
+};
+{{- end}}
+
+// A {{.Name}}MockResponses sets the response of each {{.Name}}MockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface {{.Name}}MockResponses {
+    {{- range .Methods}}
+    {{.Name}}?: {{if not .EmptyResponse}}{{.ResponseType}} | {{end}}(({{.RequestParam}}callOptions?: CallOptions) => {{.ResponseType}} | Promise<{{.ResponseType}}>);
+    {{- end}}
+}
+
+// {{.Name}}MockClient is a {{.Name}} for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class {{.Name}}MockClient implements {{.Name}} {
+    responses: {{.Name}}MockResponses;
+
+    constructor(responses: {{.Name}}MockResponses = {}) {
+        this.responses = responses;
+    }
+
+    {{- range .Methods}}
+    {{.Name}}({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}> {
+        const response = this.responses.{{.Name}};
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for {{$s.Name}}.{{.Path}}"}));
+        }
+
+        return new Promise<{{.ResponseType}}>((resolve) => resolve(typeof response === "function" ? response({{.RequestArg}}callOptions) : response));
+    }
+    {{end}}
+}
+
+export const create{{.Name}}Mock = (overrides: {{.Name}}MockResponses = {}): {{.Name}}MockClient => {
+    return new {{.Name}}MockClient(overrides);
+};
+{{- if $.Server}}
+
+// {{.Name}}Handler implements the {{.Name}} rpc methods for a server created with create{{.Name}}Router.
+export interface {{.Name}}Handler {
+    {{- range .Methods}}
+    {{- $out := .OutputType}}{{if .EmptyResponse}}{{$out = "void"}}{{end}}
+    {{methodKey .Name}}({{.RequestParam}}req: ServerRequest): {{$out}} | Promise<{{$out}}>;
+    {{- end}}
+}
+
+// create{{.Name}}Router serves the {{.Name}} rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(create{{.Name}}Router(handler))
+export const create{{.Name}}Router = (handler: {{.Name}}Handler): TwirpRouter => {
+    return createTwirpRouter("{{$.TwirpPrefix}}/{{.FullName}}/", {
+        {{- range .Methods}}
+        {{- $out := .OutputType}}{{if .EmptyResponse}}{{$out = "void"}}{{end}}
+        {{- if .EmptyRequest}}
+        {{.Path}}: (_, req) => new Promise<{{$out}}>((resolve) => resolve(handler.{{.Name}}(req))).then({{responseBody .}}),
+        {{- else}}
+        {{.Path}}: (body, req) => new Promise<{{$out}}>((resolve) => resolve(handler.{{.Name}}({{unmarshalFunc .InputType}}(body), req))).then({{responseBody .}}),
+        {{- end}}
+        {{- end}}
+    });
+};
+{{- end}}
 {{end}}
 `
 
 type Model struct {
 	Name         string
+	Comment      string
 	Primitive    bool
 	Fields       []ModelField
+	Oneofs       []ModelOneof
 	CanMarshal   bool
 	CanUnmarshal bool
+	// Validate is set for models with PGV rules, or with message fields of such models, see markValidatedModels
+	Validate bool
+	// Validations are the typescript statements of the validate function of the model, see parseValidations
+	Validations []string
+	// Readonly is set for the models of rpc responses, and the models of their fields, with Options.ReadonlyResponses,
+	// which have a deep readonly interface, see readonlyType
+	Readonly bool
+	// Redact is set for the models with sensitive fields, or with message fields of such models, which have a redact
+	// function, see markRedactedModels
+	Redact bool
+	// Request is set for the models of rpc requests, which have a builder with Options.Builders, see HasBuilder
+	Request bool
+	file    string // name of the proto file that declares the message
+
+	validationDisabled bool
+	skipValidation     map[string]bool // names of the message fields that are not validated
+	requiredRules      map[string]bool // names of the fields and oneofs with the PGV message.required or oneof.required rule
+}
+
+// fields returns all fields of the model, including the members of each oneof.
+func (m *Model) fields() []ModelField {
+	fields := append([]ModelField{}, m.Fields...)
+
+	for _, o := range m.Oneofs {
+		fields = append(fields, o.Fields...)
+	}
+
+	// the values of map fields may be messages or enums, which are marshalled and imported like fields
+	for _, f := range m.Fields {
+		if f.IsMap {
+			fields = append(fields, *f.Value)
+		}
+	}
+
+	return fields
+}
+
+// Optional reports if a field is optional in the typescript interface of its message.
+func (f ModelField) Optional() bool {
+	return f.IsOptional && !f.IsRequired
+}
+
+// HasReadOnlyFields reports if a model has readonly fields, which are set by a mutable copy of the model's type
+// while it is decoded.
+func (m *Model) HasReadOnlyFields() bool {
+	for _, f := range m.Fields {
+		if f.IsReadOnly {
+			return true
+		}
+	}
+
+	return false
 }
 
 type ModelField struct {
-	Name       string
-	Type       string
-	JSONName   string
-	JSONType   string
-	IsMessage  bool
-	IsRepeated bool
+	Name    string
+	Comment string
+	Type    string
+	// ProtoName is the name of the field in the proto file, which is used by the field paths of HTTP rules
+	ProtoName string
+	// JSONName is the name of the field in JSON, which is ProtoName or its lowerCamelCase name, see Options.JSONNames
+	JSONName string
+	// JSONAlias is the other name of the field that its JSON is parsed from, or empty when both names are the same
+	JSONAlias string
+	JSONType  string
+	Number    int32
+	ProtoType descriptor.FieldDescriptorProto_Type
+	IsMessage bool
+	IsEnum    bool
+	// EnumNumbers is set for enum fields that are sent as the number of the value in JSON, see Options.Enums
+	EnumNumbers bool
+	IsLong      bool
+	// IsFloat is set for float and double fields, whose non-finite values are strings in JSON
+	IsFloat     bool
+	IsBytes     bool
+	IsWrapper   bool
+	IsDuration  bool
+	IsFieldMask bool
+	IsRepeated  bool
+	IsMap       bool
+	// IsOptional is set for proto3 optional fields, proto2 optional fields without a default value, and recursive message fields, which are undefined when they are not set
+	IsOptional bool
+	// IsRequired is set for fields with the REQUIRED field behavior and the required fields of proto2 files, which are
+	// not optional in typescript when they are proto3 optional fields, and are required by the JSON schema of their
+	// message
+	IsRequired bool
+	// HasPresence is set for the required fields and the fields with a default value of proto2 files, which are
+	// written to protobuf even when they are their zero value, see proto2Field
+	HasPresence bool
+	// Default is the typescript value of the default value of a field of a proto2 file, which replaces its proto3
+	// default value, see defaultLiteral
+	Default string
+	// IsReadOnly is set for fields with the OUTPUT_ONLY or IMMUTABLE field behavior, which are readonly in typescript
+	IsReadOnly bool
+	// IsSensitive is set for fields with the debug_redact option or a custom sensitive option, which are redacted in
+	// logs and error reports, see isSensitive
+	IsSensitive bool
+
+	// Zero is the proto3 default value that is used by JSONTo* when the field is absent from the JSON, see Options.Defaults
+	Zero string
+
+	// Key and Value are the fields of the map entry, when IsMap is set
+	Key   *ModelField
+	Value *ModelField
+
+	// Codec is the name of the runtime protobuf codec for a Google WKT field that is passed through unchanged in JSON
+	Codec string
+}
+
+// ModelOneof is a oneof group, generated as a discriminated union of its member fields,
+// e.g. {kind: "circle"; value: Circle} | {kind: "square"; value: Square}
+type ModelOneof struct {
+	Name    string
+	Comment string
+	Type    string
+	Fields  []ModelField
+}
+
+type Enum struct {
+	Name    string
+	Comment string
+	Values  []EnumValue
+}
+
+type EnumValue struct {
+	Name    string
+	Comment string
+	Value   int32
 }
 
 type Service struct {
 	Name    string
+	Comment string
 	Package string
 	Methods []ServiceMethod
 }
 
+// FullName is the name of the service in the Twirp routes, which is qualified by its proto package, e.g.
+// twitch.twirp.example.Haberdasher, or is only the name of the service when its file has no package.
+func (s Service) FullName() string {
+	if s.Package == "" {
+		return s.Name
+	}
+
+	return s.Package + "." + s.Name
+}
+
 type ServiceMethod struct {
 	Name       string
+	Comment    string
 	Path       string
 	InputArg   string
 	InputType  string
 	OutputType string
+	// ResponseType is the type returned by the client methods, which is the readonly interface of the OutputType
+	// with Options.ReadonlyResponses
+	ResponseType string
+	// HTTP is the REST route of the google.api.http option of the method, which is only set with Options.REST
+	HTTP *HTTPRule
+	// LosslessJSON is set when the JSON of the response is parsed by parseLosslessJSON, which keeps the digits of
+	// integers that JSON.parse would round, for responses with 64 bit fields that are bigints or strings
+	LosslessJSON bool
+	// Subscription is set for the subscription methods, e.g. WatchHats, which also have a subscription client with
+	// Options.Subscriptions, see isSubscription
+	Subscription bool
+	// Pagination is set for the list methods of the AIP-158 conventions with Options.Pagination, see pagination
+	Pagination *Pagination
+	// Idempotency is the idempotency_level of a method that is safe to retry, no_side_effects or idempotent, whose
+	// calls are retried after a network failure, see idempotency
+	Idempotency string
+	// EmptyRequest is set when the input type is google.protobuf.Empty, so the method has no request argument
+	EmptyRequest bool
+	// EmptyResponse is set when the output type is google.protobuf.Empty, so the method resolves to void
+	EmptyResponse bool
+}
+
+// Import is a set of names imported from the module generated for another proto file.
+type Import struct {
+	Module string
+	Names  []string
 }
 
 func NewAPIContext() APIContext {
 	ctx := APIContext{}
 	ctx.modelLookup = make(map[string]*Model)
+	ctx.external = make(map[string]string)
 
 	return ctx
 }
 
+// APIContext is the data of the template of a module, which is also the data of the custom templates of
+// Options.TemplateDir. Its exported fields and methods, and those of the Import, Enum, Model and Service types, are a
+// stable contract of the custom templates.
 type APIContext struct {
+	Options
+	Imports     []*Import
+	Enums       []*Enum
 	Models      []*Model
 	Services    []*Service
 	modelLookup map[string]*Model
+	module      string
+	file        string                          // name of the proto file being generated
+	source      *descriptor.FileDescriptorProto // the proto file being generated, see renderReflection
+	types       typeRegistry
+	external    map[string]string      // typescript names of types declared in other modules => module name
+	templates   []customTemplate       // custom templates of Options.TemplateDir, see parseTemplates
+	sensitive   []*proto.ExtensionDesc // custom options of sensitive fields, see sensitiveOptions
+	runtime     map[string]bool        // names exported by the runtime library, see runtimeNames
 }
 
 func (ctx *APIContext) AddModel(m *Model) {
@@ -135,197 +871,1230 @@ func (ctx *APIContext) AddModel(m *Model) {
 
 // ApplyMarshalFlags will inspect the CanMarshal and CanUnmarshal flags for models where
 // the flags are enabled and recursively set the same values on all the models that are field types.
-func (ctx *APIContext) ApplyMarshalFlags() {
+func (ctx *APIContext) ApplyMarshalFlags() error {
 	for _, m := range ctx.Models {
-		for _, f := range m.Fields {
-			// skip primitive types and WKT Timestamps
-			if !f.IsMessage || f.Type == "Date" {
+		models, err := ctx.fieldModels(m)
+		if err != nil {
+			return err
+		}
+
+		for _, mm := range models {
+			if m.CanMarshal {
+				if err := ctx.enableMarshal(mm); err != nil {
+					return err
+				}
+			}
+
+			if m.CanUnmarshal {
+				if err := ctx.enableUnmarshal(mm); err != nil {
+					return err
+				}
+			}
+
+			if m.Readonly {
+				if err := ctx.enableReadonly(mm); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// enableMarshal sets CanMarshal on a model and the models of its fields. Models that already
+// have the flag are not visited again, so self-referential messages do not recurse forever.
+func (ctx *APIContext) enableMarshal(m *Model) error {
+	if m.CanMarshal {
+		return nil
+	}
+
+	m.CanMarshal = true
+
+	models, err := ctx.fieldModels(m)
+	if err != nil {
+		return err
+	}
+
+	for _, mm := range models {
+		if err := ctx.enableMarshal(mm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enableUnmarshal sets CanUnmarshal on a model and the models of its fields, see enableMarshal.
+func (ctx *APIContext) enableUnmarshal(m *Model) error {
+	if m.CanUnmarshal {
+		return nil
+	}
+
+	m.CanUnmarshal = true
+
+	models, err := ctx.fieldModels(m)
+	if err != nil {
+		return err
+	}
+
+	for _, mm := range models {
+		if err := ctx.enableUnmarshal(mm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// fieldModels returns the models of the message fields of m, including repeated fields and map values.
+// It returns an error naming the file and field of a message type that was not generated.
+func (ctx *APIContext) fieldModels(m *Model) ([]*Model, error) {
+	var models []*Model
+
+	for _, f := range m.fields() {
+		baseType := strings.TrimSuffix(f.Type, "[]")
+
+		// skip primitive types and WKT Timestamps
+		if !f.IsMessage || baseType == "Date" {
+			continue
+		}
+
+		mm, ok := ctx.modelLookup[baseType]
+		if !ok {
+			return nil, fmt.Errorf("%s: could not find the message %s of field %s.%s", m.file, baseType, m.Name, f.JSONName)
+		}
+
+		models = append(models, mm)
+	}
+
+	return models, nil
+}
+
+// markRecursiveFields makes the message fields of a model optional when their message contains
+// the model again through other message fields, e.g. a tree node with a parent node. The message of
+// such a field must be unset at some depth, so the field is undefined when it is not set.
+//
+// Repeated, map and oneof fields are not marked, since they can already be empty.
+func (ctx *APIContext) markRecursiveFields() {
+	for _, m := range ctx.Models {
+		for i, f := range m.Fields {
+			if !f.IsMessage || f.IsRepeated || f.IsOptional || f.Type == "Date" {
 				continue
 			}
 
-			baseType := f.Type
-			if f.IsRepeated {
-				baseType = strings.Trim(baseType, "[]")
+			if ctx.contains(ctx.modelLookup[f.Type], m, map[*Model]bool{}) {
+				m.Fields[i].IsOptional = true
+			}
+		}
+	}
+}
+
+// contains reports if model m is, or always contains, the target model through singular message fields.
+func (ctx *APIContext) contains(m *Model, target *Model, visited map[*Model]bool) bool {
+	if m == nil || visited[m] {
+		return false
+	}
+
+	if m == target {
+		return true
+	}
+
+	visited[m] = true
+
+	for _, f := range m.Fields {
+		if !f.IsMessage || f.IsRepeated || f.IsOptional || f.Type == "Date" {
+			continue
+		}
+
+		if ctx.contains(ctx.modelLookup[f.Type], target, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CreateClientAPIs generates a typescript module for each of the proto files named in generate, which are the
+// file_to_generate of the CodeGeneratorRequest. Types are resolved across all of the given files, so fields and
+// rpc methods that reference a message or enum declared in an imported file will import it from that file's module,
+// which is only generated when the imported file is also named in generate.
+//
+// The opts select the protocol and typescript types of the generated code, see Options.
+func CreateClientAPIs(files []*descriptor.FileDescriptorProto, generate []string, opts Options) ([]*plugin.CodeGeneratorResponse_File, error) {
+	types, err := newTypeRegistry(files, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := loadTemplates(opts.TemplateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := make(map[string]*Model)
+	sensitive := sensitiveOptions(files)
+
+	var ctxs []*APIContext
+	packages := make(map[string]string) // names of the files => their proto packages
+	for _, d := range files {
+		packages[d.GetName()] = d.GetPackage()
+
+		ctx := NewAPIContext()
+		ctx.modelLookup = lookup
+		ctx.templates = templates
+		ctx.sensitive = sensitive
+		ctx.module = tsModuleName(d, opts)
+		ctx.Options = opts
+		ctx.types = types
+
+		if err := ctx.parse(d); err != nil {
+			return nil, err
+		}
+
+		ctxs = append(ctxs, &ctx)
+	}
+
+	for _, ctx := range ctxs {
+		if err := ctx.markServiceModels(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Marshal flags are applied to all files before any are rendered, since a model
+	// may be used as an rpc input or output type of a service in another file.
+	// Recursive fields are also resolved across files, since a cycle may span modules.
+	for _, ctx := range ctxs {
+		ctx.markRecursiveFields()
+	}
+
+	for _, ctx := range ctxs {
+		if err := ctx.ApplyMarshalFlags(); err != nil {
+			return nil, err
+		}
+	}
+
+	markValidatedModels(ctxs)
+	for _, ctx := range ctxs {
+		ctx.nestedValidations()
+	}
+
+	markRedactedModels(ctxs)
+
+	requested := make(map[string]bool)
+	for _, name := range generate {
+		requested[name] = true
+	}
+
+	// the schemas of enum fields list the values of enums declared in any of the files, and the OpenAPI documents
+	// of the services include the schemas of the messages declared in any of the files
+	enums := make(map[string]*Enum)
+	models := make(map[string]*Model)
+	for _, ctx := range ctxs {
+		for _, e := range ctx.Enums {
+			enums[e.Name] = e
+		}
+
+		for _, m := range ctx.Models {
+			models[m.Name] = m
+		}
+	}
+
+	var out []*plugin.CodeGeneratorResponse_File
+	barrels := make(map[string][]string) // proto packages => the modules of their files, see createBarrels
+	for _, ctx := range ctxs {
+		// imported files are parsed for their types, but are only generated when they were requested
+		if !requested[ctx.file] {
+			continue
+		}
+
+		start := len(out)
+
+		if opts.JSONSchema {
+			schemas, err := ctx.renderSchemas(enums)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, schemas...)
+		}
+
+		if opts.OpenAPI {
+			docs, err := ctx.renderOpenAPI(models, enums)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, docs...)
+		}
+
+		if opts.Zod {
+			zod, err := ctx.renderZod(enums)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, zod)
+		}
+
+		if opts.Pact {
+			pact, err := ctx.renderPact()
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, pact)
+		}
+
+		if opts.Fakes {
+			fakes, err := ctx.renderFakes(enums)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, fakes)
+		}
+
+		if opts.Reflection {
+			reflection, err := ctx.renderReflection()
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, reflection)
+		}
+
+		if opts.IOTS {
+			codecs, err := ctx.renderIOTS(enums)
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, codecs)
+		}
+
+		if opts.Interop != InteropNone {
+			interop, err := ctx.renderInterop()
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, interop)
+		}
+
+		modules := []*APIContext{ctx}
+		if opts.ServiceModules {
+			modules = ctx.splitServices()
+		}
+
+		for _, m := range modules {
+			m.resolveImports()
+
+			cf, err := m.render()
+			if err != nil {
+				return nil, err
+			}
+
+			out = append(out, cf)
+			barrels[packages[ctx.file]] = append(barrels[packages[ctx.file]], m.module)
+
+			if opts.ReactHooks && len(m.Services) > 0 {
+				hooks, err := m.renderHooks()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, hooks)
+			}
+
+			if opts.TanStackQuery && len(m.Services) > 0 {
+				queries, err := m.renderQueries()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, queries)
+			}
+
+			if opts.MSW && len(m.Services) > 0 {
+				handlers, err := m.renderMSW()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, handlers)
+			}
+
+			if opts.Pagination && m.hasPagination() {
+				pages, err := m.renderPagination()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, pages)
+			}
+
+			if opts.Cache && m.hasCache() {
+				cache, err := m.renderCache()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, cache)
+			}
+
+			if opts.Subscriptions != SubscriptionsNone && m.hasSubscriptions() {
+				subscriptions, err := m.renderSubscriptions()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, subscriptions)
+			}
+
+			if opts.Angular && len(m.Services) > 0 {
+				services, err := m.renderAngular()
+				if err != nil {
+					return nil, err
+				}
+
+				out = append(out, services)
+			}
+		}
+
+		FormatFiles(out[start:])
+		if err := AddBanners(out[start:], ctx.file, opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.Barrels {
+		indexes, err := createBarrels(barrels, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		// the barrels have no proto file, like the runtime modules
+		FormatFiles(indexes)
+		if err := AddBanners(indexes, "", opts); err != nil {
+			return nil, err
+		}
+
+		out = append(out, indexes...)
+	}
+
+	// the files are sorted by name, so the response does not depend on the order of the files in the request
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].GetName() < out[j].GetName()
+	})
+
+	return out, nil
+}
+
+// splitServices separates each service of a file into its own module, e.g. service_haberdasher.ts,
+// which imports the messages it uses from the module of the file.
+func (ctx *APIContext) splitServices() []*APIContext {
+	models := *ctx
+	models.Services = nil
+	modules := []*APIContext{&models}
+
+	for _, s := range ctx.Services {
+		module := *ctx
+		module.module = ctx.module + "_" + strings.ToLower(s.Name)
+		module.Enums = nil
+		module.Models = nil
+		module.Services = []*Service{s}
+
+		module.external = make(map[string]string)
+		for name, m := range ctx.external {
+			module.external[name] = m
+		}
+
+		for _, m := range ctx.Models {
+			if !m.Primitive {
+				module.external[m.Name] = ctx.module
+			}
+		}
+
+		modules = append(modules, &module)
+	}
+
+	return modules
+}
+
+func (ctx *APIContext) parse(d *descriptor.FileDescriptorProto) error {
+	ctx.file = d.GetName()
+	ctx.source = d
+	pkg := d.GetPackage()
+	docs := newComments(d)
+
+	// Parse all Enums, including those nested inside Messages, for generating typescript enums
+	for i, e := range d.GetEnumType() {
+		ctx.Enums = append(ctx.Enums, newEnum(e, ctx.types.name(fqName(pkg, e.GetName())), docs, []int32{pathEnumType, int32(i)}))
+	}
+
+	for i, m := range d.GetMessageType() {
+		ctx.Enums = append(ctx.Enums, nestedEnums(m, fqName(pkg, m.GetName()), ctx.types, docs, []int32{pathMessageType, int32(i)})...)
+	}
+
+	// Parse all Messages, including those nested inside Messages, for generating typescript interfaces
+	for i, m := range d.GetMessageType() {
+		if err := ctx.parseMessage(m, fqName(pkg, m.GetName()), docs, []int32{pathMessageType, int32(i)}); err != nil {
+			return err
+		}
+	}
+
+	// Parse all Services for generating typescript method interfaces and default client implementations, unless only
+	// the models are generated
+	services := d.GetService()
+	if ctx.ModelsOnly {
+		services = nil
+	}
+
+	for i, s := range services {
+		path := []int32{pathService, int32(i)}
+		service := &Service{
+			Name:    s.GetName(),
+			Comment: docs.get(path),
+			Package: pkg,
+		}
+
+		for j, m := range s.GetMethod() {
+			// Twirp only has unary rpcs, so a streaming rpc would be generated as a call that fails at runtime
+			if m.GetClientStreaming() || m.GetServerStreaming() {
+				return fmt.Errorf("%s: rpc %s.%s is %s, which is not supported by Twirp", ctx.file, s.GetName(), m.GetName(), streaming(m))
+			}
+
+			for _, t := range []string{m.GetInputType(), m.GetOutputType()} {
+				if ref, ok := ctx.types[t]; (!ok || ref.entry != nil) && t != emptyType {
+					return fmt.Errorf("%s: could not find the message %s of rpc %s.%s", ctx.file, t, s.GetName(), m.GetName())
+				}
+			}
+
+			methodPath := m.GetName()
+			methodName := clientMethodName(service, methodPath, ctx.Options)
+			in := ctx.types.name(m.GetInputType())
+			arg := ctx.requestArg(in)
+
+			out := ctx.types.name(m.GetOutputType())
+
+			method := ServiceMethod{
+				Name:         methodName,
+				Comment:      docs.get(path, pathMethod, int32(j)),
+				Path:         methodPath,
+				InputArg:     arg,
+				InputType:    in,
+				OutputType:   out,
+				ResponseType: out,
+				Subscription: ctx.Subscriptions != SubscriptionsNone && isSubscription(methodPath),
+				Idempotency:  idempotency(m),
+			}
+
+			if ctx.ReadonlyResponses {
+				method.ResponseType = readonlyName(out)
+			}
+
+			// Empty has no model, so its name is only the inputType or outputType of the Methods of the service
+			if m.GetInputType() == emptyType {
+				method.EmptyRequest = true
+				method.InputArg = ""
+				method.InputType = emptyType[1:]
+			}
+
+			if m.GetOutputType() == emptyType {
+				method.EmptyResponse = true
+				method.OutputType = emptyType[1:]
+				method.ResponseType = "void"
+			}
+
+			if ctx.REST {
+				rule, err := getHTTPRule(m)
+				if err != nil {
+					return fmt.Errorf("%s: invalid google.api.http option of rpc %s.%s: %v", ctx.file, s.GetName(), m.GetName(), err)
+				}
+
+				method.HTTP = rule
+			}
+
+			ctx.addReference(m.GetInputType())
+			ctx.addReference(m.GetOutputType())
+
+			service.Methods = append(service.Methods, method)
+		}
+
+		ctx.Services = append(ctx.Services, service)
+	}
+
+	ctx.AddModel(&Model{
+		Name:      "Date",
+		Primitive: true,
+	})
+
+	return nil
+}
+
+// markServiceModels sets the marshal flags of the input and output types of the rpc methods, and checks
+// their REST routes. It is called after all of the files are parsed, since the types of an rpc method may be
+// declared in another file, whatever the order of the files in the request.
+func (ctx *APIContext) markServiceModels() error {
+	ctx.markAllModels()
+	ctx.markInteropModels()
+
+	for _, s := range ctx.Services {
+		for i, sm := range s.Methods {
+			s.Methods[i].LosslessJSON = !ctx.ProtobufMessages() && ctx.Int64 != Int64Number && !sm.EmptyResponse && ctx.hasLongFields(sm.OutputType, map[string]bool{})
+
+			if ctx.Pagination {
+				s.Methods[i].Pagination = ctx.pagination(sm)
+			}
+		}
+	}
+
+	// Only include the custom 'ToJSON' and 'JSONTo' methods in generated code
+	// if the Model is part of an rpc method input arg or return type.
+	for _, s := range ctx.Services {
+		for _, sm := range s.Methods {
+			if sm.HTTP != nil {
+				if err := ctx.checkHTTPRule(sm.HTTP, sm); err != nil {
+					return fmt.Errorf("%s: invalid google.api.http option of rpc %s.%s: %v", ctx.file, s.Name, sm.Path, err)
+				}
+			}
+
+			if m, ok := ctx.modelLookup[sm.InputType]; ok {
+				m.CanMarshal = true
+				m.Request = true
+			}
+
+			if m, ok := ctx.modelLookup[sm.OutputType]; ok {
+				m.CanUnmarshal = true
+				m.Readonly = ctx.ReadonlyResponses
+			}
+
+			// pact interactions have the JSON of the responses
+			if m, ok := ctx.modelLookup[sm.OutputType]; ok && ctx.Pact {
+				m.CanMarshal = true
+			}
+
+			// servers and msw handlers decode the requests and encode the responses of the rpc methods
+			if !ctx.Server && !ctx.MSW {
+				continue
+			}
+
+			if m, ok := ctx.modelLookup[sm.InputType]; ok {
+				m.CanUnmarshal = true
+			}
+
+			if m, ok := ctx.modelLookup[sm.OutputType]; ok {
+				m.CanMarshal = true
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseMessage adds the model of a message declared at the path in its file, followed by the models of its nested messages.
+func (ctx *APIContext) parseMessage(m *descriptor.DescriptorProto, typeName string, docs comments, path []int32) error {
+	model := &Model{
+		Name:    ctx.types.name(typeName),
+		Comment: docs.get(path),
+		file:    ctx.file,
+	}
+
+	synthetic := syntheticOneofs(m)
+	for j, o := range m.GetOneofDecl() {
+		if synthetic[int32(j)] {
+			continue
+		}
+
+		name := fieldName(o.GetName(), ctx.Options)
+		typeName := camelCase(o.GetName())
+
+		model.Oneofs = append(model.Oneofs, ModelOneof{
+			Name:    name,
+			Comment: docs.get(path, pathOneof, int32(j)),
+			Type:    model.Name + strings.ToUpper(typeName[0:1]) + typeName[1:],
+		})
+	}
+
+	for j, f := range m.GetField() {
+		if f.GetType() == descriptor.FieldDescriptorProto_TYPE_GROUP {
+			return groupError(ctx.file, m, f)
+		}
+
+		field := ctx.newModelField(f)
+		field.Comment = docs.get(path, pathField, int32(j))
+		field.IsSensitive = ctx.isSensitive(f)
+		ctx.addReference(f.GetTypeName())
+		if entry := ctx.types.mapEntry(f.GetTypeName()); entry != nil {
+			for _, ef := range entry.GetField() {
+				ctx.addReference(ef.GetTypeName())
+			}
+		}
+
+		if f.OneofIndex != nil && !synthetic[f.GetOneofIndex()] {
+			// an absent oneof member is not set, rather than set to its zero value
+			field.Zero = ""
+			o := &model.Oneofs[f.GetOneofIndex()]
+			o.Fields = append(o.Fields, field)
+			continue
+		}
+
+		model.Fields = append(model.Fields, field)
+	}
+
+	if err := ctx.parseValidations(model, m); err != nil {
+		return err
+	}
+
+	ctx.AddModel(model)
+
+	for j, n := range m.GetNestedType() {
+		if n.GetOptions().GetMapEntry() {
+			continue
+		}
+
+		if err := ctx.parseMessage(n, typeName+"."+n.GetName(), docs, append(path[:len(path):len(path)], pathNestedType, int32(j))); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addReference records a message or enum type that is declared in another file's module, so it can be imported.
+func (ctx *APIContext) addReference(typeName string) {
+	ref, ok := ctx.types[typeName]
+	if !ok || ref.Module == ctx.module {
+		return
+	}
+
+	ctx.external[ref.Name] = ref.Module
+}
+
+// resolveImports builds the import statements for all types declared in other modules, including
+// the marshal functions for those types that are needed by this module. This must be called after ApplyMarshalFlags.
+func (ctx *APIContext) resolveImports() {
+	imports := make(map[string]map[string]bool)
+
+	add := func(module string, names ...string) {
+		if imports[module] == nil {
+			imports[module] = make(map[string]bool)
+		}
+
+		for _, n := range names {
+			imports[module][n] = true
+		}
+	}
+
+	for _, m := range ctx.Models {
+		for _, f := range m.fields() {
+			baseType := strings.TrimSuffix(f.Type, "[]")
+
+			module, ok := ctx.external[baseType]
+			if !ok {
+				continue
+			}
+
+			if f.IsEnum {
+				add(module, baseType)
+				continue
+			}
+
+			add(module, baseType, baseType+"JSON")
+
+			if m.CanMarshal {
+				add(module, ctx.marshalFunc(baseType))
+			}
+
+			if m.CanUnmarshal {
+				add(module, ctx.unmarshalFunc(baseType))
+			}
+
+			if m.Validate && ctx.validatesField(m, f) {
+				add(module, "validate"+baseType)
+			}
+
+			if m.Redact && !f.IsMap && ctx.redactsField(f) {
+				add(module, "redact"+baseType)
+			}
+
+			if m.Readonly && f.IsMessage {
+				add(module, readonlyName(baseType))
+			}
+
+			if f.IsMessage {
+				add(module, "is"+baseType)
+			}
+		}
+	}
+
+	// the message fields of a message are made by the create functions of their messages
+	for _, m := range ctx.Models {
+		for _, f := range m.Fields {
+			if module, ok := ctx.external[f.Type]; ok && ctx.Builders && f.IsMessage && !f.IsRepeated && !f.IsMap && !f.Optional() {
+				add(module, "create"+f.Type)
+			}
+		}
+	}
+
+	// likewise the message fields are merged and diffed by the merge and diff functions of their messages
+	for _, m := range ctx.Models {
+		for _, f := range m.Fields {
+			if module, ok := ctx.external[f.Type]; ok && ctx.MergeDiff && mergesMessage(f) {
+				add(module, "merge"+f.Type, "diff"+f.Type)
+			}
+		}
+	}
+
+	for _, s := range ctx.Services {
+		for _, sm := range s.Methods {
+			if module, ok := ctx.external[sm.InputType]; ok {
+				add(module, sm.InputType, ctx.marshalFunc(sm.InputType))
+			}
+
+			if module, ok := ctx.external[sm.InputType]; ok && ctx.validatesRequest(sm.InputType) {
+				add(module, "validate"+sm.InputType)
+			}
+
+			if module, ok := ctx.external[sm.OutputType]; ok {
+				add(module, sm.OutputType, sm.ResponseType, ctx.unmarshalFunc(sm.OutputType))
 			}
 
-			if m.CanMarshal {
-				ctx.enableMarshal(ctx.modelLookup[baseType])
+			// the redact functions of the messages of a call are the Redactor of its InterceptorContext
+			if module, ok := ctx.external[sm.InputType]; ok && ctx.redacts(sm.InputType) {
+				add(module, "redact"+sm.InputType)
 			}
 
-			if m.CanUnmarshal {
-				m, ok := ctx.modelLookup[baseType]
+			if module, ok := ctx.external[sm.OutputType]; ok && ctx.redacts(sm.OutputType) {
+				add(module, "redact"+sm.OutputType)
+			}
+
+			// the zod schema of an output type is imported from the zod module of the file that declares it
+			if ctx.Zod && !sm.EmptyResponse {
+				module, ok := ctx.external[sm.OutputType]
 				if !ok {
-					log.Fatalf("could not find model of type %s for field %s", baseType, f.Name)
+					module = ctx.module
 				}
-				ctx.enableUnmarshal(m)
+
+				add(zodModuleName(module), sm.OutputType+"Schema")
+			}
+
+			if !ctx.Server {
+				continue
+			}
+
+			if module, ok := ctx.external[sm.InputType]; ok {
+				add(module, ctx.unmarshalFunc(sm.InputType))
+			}
+
+			if module, ok := ctx.external[sm.OutputType]; ok {
+				add(module, ctx.marshalFunc(sm.OutputType))
 			}
 		}
 	}
+
+	ctx.Imports = nil
+	for module, names := range imports {
+		imp := &Import{Module: module}
+		for n := range names {
+			imp.Names = append(imp.Names, n)
+		}
+		sort.Strings(imp.Names)
+
+		ctx.Imports = append(ctx.Imports, imp)
+	}
+
+	sort.Slice(ctx.Imports, func(i, j int) bool {
+		return ctx.Imports[i].Module < ctx.Imports[j].Module
+	})
 }
 
-func (ctx *APIContext) enableMarshal(m *Model) {
-	m.CanMarshal = true
+// marshalFunc is the name of the generated function that serializes a model for the selected protocol.
+func (ctx *APIContext) marshalFunc(model string) string {
+	if ctx.ProtobufMessages() {
+		return model + "ToProtobuf"
+	}
 
-	for _, f := range m.Fields {
-		// skip primitive types and WKT Timestamps
-		if !f.IsMessage || f.Type == "Date" {
-			continue
-		}
-		mm, ok := ctx.modelLookup[f.Type]
-		if !ok {
-			log.Fatalf("could not find model of type %s for field %s", f.Type, f.Name)
-		}
-		ctx.enableMarshal(mm)
+	return model + "ToJSON"
+}
+
+// functionName is the name of the rpc function of a method with ClientStyleFunctions, which is the name of the
+// method in the case of Options.MethodCase, unless it is a reserved word, a name that the module declares or imports, or the method of another service
+// of the module has the same name, e.g. haberdasherDelete.
+func (ctx *APIContext) functionName(s *Service, m ServiceMethod) string {
+	name := methodCase(ctx.methodName(s, m), ctx.Options)
+	if reservedWords[name] || ctx.declares(name) {
+		name = methodCase(s.Name+m.Path, ctx.Options)
+	}
+
+	for reservedWords[name] || ctx.declares(name) {
+		name += "_"
 	}
+
+	return name
 }
 
-func (ctx *APIContext) enableUnmarshal(m *Model) {
-	m.CanUnmarshal = true
+// reservedWords are the javascript keywords and literals, which are not valid names of functions
+var reservedWords = map[string]bool{
+	"await": true, "break": true, "case": true, "catch": true, "class": true, "const": true, "continue": true,
+	"debugger": true, "default": true, "delete": true, "do": true, "else": true, "enum": true, "export": true,
+	"extends": true, "false": true, "finally": true, "for": true, "function": true, "if": true, "implements": true,
+	"import": true, "in": true, "instanceof": true, "interface": true, "let": true, "new": true, "null": true,
+	"package": true, "private": true, "protected": true, "public": true, "return": true, "static": true,
+	"super": true, "switch": true, "this": true, "throw": true, "true": true, "try": true, "typeof": true,
+	"var": true, "void": true, "while": true, "with": true, "yield": true,
+}
 
-	for _, f := range m.Fields {
-		// skip primitive types and WKT Timestamps
-		if !f.IsMessage || f.Type == "Date" {
-			continue
-		}
-		mm, ok := ctx.modelLookup[f.Type]
-		if !ok {
-			log.Fatalf("could not find model of type %s for field %s", f.Type, f.Name)
-		}
-		ctx.enableUnmarshal(mm)
+// unmarshalFunc is the name of the generated function that deserializes a model for the selected protocol.
+func (ctx *APIContext) unmarshalFunc(model string) string {
+	if ctx.ProtobufMessages() {
+		return "ProtobufTo" + model
 	}
+
+	return "JSONTo" + model
 }
 
-func CreateClientAPI(d *descriptor.FileDescriptorProto) (*plugin.CodeGeneratorResponse_File, error) {
-	ctx := NewAPIContext()
-	pkg := d.GetPackage()
+func (ctx *APIContext) render() (*plugin.CodeGeneratorResponse_File, error) {
+	funcMap := template.FuncMap{
+		"stringify":      stringify,
+		"methodKey":      methodKey,
+		"stringifyOneof": stringifyOneof,
+		"parse":          parse,
+		"parseOneof":     parseOneof,
+		"jsonAliases":    jsonAliases,
+		"encodeField":    encodeField,
+		"encodeOneof":    encodeOneof,
+		"decodeField":    decodeField,
+		"decodeOneof":    decodeOneof,
+		"zeroValues":     zeroValues,
+		"readonlyType":   readonlyType,
+		"classInit":      classInit,
+		"classEquals":    classEquals,
+		"guardChecks":    guardChecks,
+		"join":           strings.Join,
+		"jsdoc":          jsdoc,
+		"redactions":     ctx.redactions,
+		"redactor":       ctx.redactor,
+		"transport":      transport,
+		"marshalFunc":    ctx.marshalFunc,
+		"builderValue":   builderValue,
+		"fieldNumbers":   fieldNumbers,
+		"fieldMasks":     ctx.fieldMasks,
+		"mergers":        mergers,
+		"fieldDiffs":     fieldDiffs,
+		"builderFields":  func(m *Model) string { return jsStrings(m.builderFields()) },
+		"requestBody":    ctx.requestBody,
+		"responseBody":   ctx.responseBody,
+		"unmarshalFunc":  ctx.unmarshalFunc,
+		"validates":      ctx.validatesRequest,
+		"functionName":   ctx.functionName,
+		"importPath": func(module string) string {
+			return runtimeImportPath(ctx.module, module, ctx.Options)
+		},
+	}
 
-	// Parse all Messages for generating typescript interfaces
-	for _, m := range d.GetMessageType() {
-		model := &Model{
-			Name: m.GetName(),
-		}
+	name, tmpl := "client_api", apiTemplate
+	if ctx.DeclarationOnly {
+		name, tmpl = "declarations", declarationTemplate
+	}
+
+	t, err := ctx.parseTemplates(name, tmpl, funcMap)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, f := range m.GetField() {
-			model.Fields = append(model.Fields, newField(f))
+	b := bytes.NewBufferString("")
+	for _, n := range []string{"header", name, "footer"} {
+		if err := t.ExecuteTemplate(b, n, ctx); err != nil {
+			return nil, err
 		}
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
 
-		ctx.AddModel(model)
+// hasLongFields reports if the JSON of a model may have 64 bit integers, including the models of its message fields.
+// The messages of other proto files and google.protobuf.Any may have them.
+func (ctx *APIContext) hasLongFields(name string, visited map[string]bool) bool {
+	m, ok := ctx.modelLookup[name]
+	if !ok {
+		return true
 	}
 
-	// Parse all Services for generating typescript method interfaces and default client implementations
-	for _, s := range d.GetService() {
-		service := &Service{
-			Name:    s.GetName(),
-			Package: pkg,
+	if visited[name] {
+		return false
+	}
+	visited[name] = true
+
+	for _, f := range m.fields() {
+		if f.IsLong || f.Type == "Any" || f.Type == "Any[]" {
+			return true
 		}
 
-		for _, m := range s.GetMethod() {
-			methodPath := m.GetName()
-			methodName := strings.ToLower(methodPath[0:1]) + methodPath[1:]
-			in := removePkg(m.GetInputType())
-			arg := strings.ToLower(in[0:1]) + in[1:]
+		if f.IsMessage && ctx.hasLongFields(strings.TrimSuffix(f.Type, "[]"), visited) {
+			return true
+		}
+	}
 
-			method := ServiceMethod{
-				Name:       methodName,
-				Path:       methodPath,
-				InputArg:   arg,
-				InputType:  in,
-				OutputType: removePkg(m.GetOutputType()),
+	return false
+}
+
+// LosslessJSON reports if the JSON of a response is parsed by parseLosslessJSON, see ServiceMethod.LosslessJSON.
+func (ctx *APIContext) LosslessJSON() bool {
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			if m.LosslessJSON {
+				return true
 			}
+		}
+	}
 
-			service.Methods = append(service.Methods, method)
+	return false
+}
+
+// Validates reports if the module has validate functions, or clients that validate their requests, which
+// import the validation helpers of the runtime.
+func (ctx *APIContext) Validates() bool {
+	for _, m := range ctx.Models {
+		if m.Validate {
+			return true
 		}
+	}
 
-		ctx.Services = append(ctx.Services, service)
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			if ctx.validatesRequest(m.InputType) {
+				return true
+			}
+		}
 	}
 
-	// Only include the custom 'ToJSON' and 'JSONTo' methods in generated code
-	// if the Model is part of an rpc method input arg or return type.
-	for _, m := range ctx.Models {
-		for _, s := range ctx.Services {
-			for _, sm := range s.Methods {
-				if m.Name == sm.InputType {
-					m.CanMarshal = true
-				}
+	return false
+}
 
-				if m.Name == sm.OutputType {
-					m.CanUnmarshal = true
-				}
+// ProtoFile is the name of the proto file of the module, e.g. example/service.proto
+func (ctx *APIContext) ProtoFile() string {
+	return ctx.file
+}
+
+// ModuleName is the name of the module, without its extension, e.g. service
+func (ctx *APIContext) ModuleName() string {
+	return ctx.module
+}
+
+// HasREST reports if the module has REST methods, which import the REST runtime, see Options.REST.
+func (ctx *APIContext) HasREST() bool {
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			if m.HTTP != nil {
+				return true
 			}
 		}
 	}
 
-	ctx.AddModel(&Model{
-		Name:      "Date",
-		Primitive: true,
-	})
+	return false
+}
 
-	ctx.ApplyMarshalFlags()
+// validatesRequest reports if the clients validate the requests of an input type before sending them, see Options.Validate.
+func (ctx *APIContext) validatesRequest(inputType string) bool {
+	m, ok := ctx.modelLookup[inputType]
 
-	funcMap := template.FuncMap{
-		"stringify": stringify,
-		"parse":     parse,
+	return ctx.Validate && ok && m.Validate
+}
+
+// streaming describes the streams of a streaming rpc method.
+func streaming(m *descriptor.MethodDescriptorProto) string {
+	switch {
+	case m.GetClientStreaming() && m.GetServerStreaming():
+		return "bidirectional streaming"
+	case m.GetClientStreaming():
+		return "client streaming"
+	default:
+		return "server streaming"
 	}
+}
 
-	t, err := template.New("client_api").Funcs(funcMap).Parse(apiTemplate)
-	if err != nil {
-		return nil, err
+// fileExt is the extension of the generated files, which are declaration files when DeclarationOnly is set.
+func (ctx *APIContext) fileExt() string {
+	if ctx.DeclarationOnly {
+		return ".d.ts"
 	}
 
-	b := bytes.NewBufferString("")
-	err = t.Execute(b, ctx)
-	if err != nil {
-		return nil, err
+	return ".ts"
+}
+
+func newEnum(e *descriptor.EnumDescriptorProto, name string, docs comments, path []int32) *Enum {
+	enum := &Enum{
+		Name:    name,
+		Comment: docs.get(path),
 	}
 
-	cf := &plugin.CodeGeneratorResponse_File{}
-	cf.Name = proto.String(tsModuleFilename(d))
-	cf.Content = proto.String(b.String())
+	for i, v := range e.GetValue() {
+		enum.Values = append(enum.Values, EnumValue{
+			Name:    v.GetName(),
+			Comment: docs.get(path, pathEnumValue, int32(i)),
+			Value:   v.GetNumber(),
+		})
+	}
 
-	return cf, nil
+	return enum
+}
+
+// nestedEnums collects the enums declared inside a message and all of its nested messages.
+// Nested enum names are prefixed with the names of their parent messages, e.g. Outer.Inner.Kind => OuterInnerKind.
+func nestedEnums(m *descriptor.DescriptorProto, parent string, types typeRegistry, docs comments, path []int32) []*Enum {
+	var enums []*Enum
+
+	for i, e := range m.GetEnumType() {
+		enums = append(enums, newEnum(e, types.name(parent+"."+e.GetName()), docs, append(path[:len(path):len(path)], pathNestedEnum, int32(i))))
+	}
+
+	for i, n := range m.GetNestedType() {
+		enums = append(enums, nestedEnums(n, parent+"."+n.GetName(), types, docs, append(path[:len(path):len(path)], pathNestedType, int32(i)))...)
+	}
+
+	return enums
 }
 
-func newField(f *descriptor.FieldDescriptorProto) ModelField {
-	tsType, jsonType := protoToTSType(f)
-	jsonName := f.GetName()
-	name := camelCase(jsonName)
+func newField(f *descriptor.FieldDescriptorProto, types typeRegistry, opts Options) ModelField {
+	tsType, jsonType := protoToTSType(f, types, opts)
+	name := fieldName(f.GetName(), opts)
+
+	// the JSON of a field is parsed from both its original proto name and its lowerCamelCase JSON name, and the
+	// name selected by Options.JSONNames is sent
+	jsonName, jsonAlias := f.GetName(), lowerCamelName(f)
+	if opts.JSONNames == JSONNamesCamel {
+		jsonName, jsonAlias = jsonAlias, jsonName
+	}
+
+	if jsonAlias == jsonName {
+		jsonAlias = ""
+	}
 
 	field := ModelField{
-		Name:     name,
-		Type:     tsType,
-		JSONName: jsonName,
-		JSONType: jsonType,
+		Name:      name,
+		Type:      tsType,
+		ProtoName: f.GetName(),
+		JSONName:  jsonName,
+		JSONAlias: jsonAlias,
+		JSONType:  jsonType,
+		Number:    f.GetNumber(),
+		ProtoType: f.GetType(),
 	}
 
 	field.IsMessage = f.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE
+	field.IsEnum = f.GetType() == descriptor.FieldDescriptorProto_TYPE_ENUM
+	field.EnumNumbers = field.IsEnum && opts.Enums == EnumsNumber
+
+	// wrapper fields are treated as nullable scalars, with ProtoType set to the type of the wrapped value
+	if wrapped, ok := wrapperTypes[f.GetTypeName()]; ok && field.IsMessage {
+		field.IsMessage = false
+		field.IsWrapper = true
+		field.ProtoType = wrapped
+	}
+
+	if f.GetTypeName() == ".google.protobuf.Duration" {
+		field.IsMessage = false
+		field.IsDuration = true
+	}
+
+	if f.GetTypeName() == ".google.protobuf.FieldMask" {
+		field.IsMessage = false
+		field.IsFieldMask = true
+	}
+
+	if p, ok := passthroughTypes[f.GetTypeName()]; ok {
+		field.IsMessage = false
+		field.Codec = p.Codec
+	}
+
+	behaviors := getFieldBehaviors(f)
+	field.IsReadOnly = behaviors[fieldBehaviorOutputOnly] || behaviors[fieldBehaviorImmutable]
+
+	field.IsLong = isLong(&descriptor.FieldDescriptorProto{Type: field.ProtoType.Enum()})
+	field.IsFloat = field.ProtoType == descriptor.FieldDescriptorProto_TYPE_DOUBLE || field.ProtoType == descriptor.FieldDescriptorProto_TYPE_FLOAT
+	field.IsBytes = field.ProtoType == descriptor.FieldDescriptorProto_TYPE_BYTES
 	field.IsRepeated = isRepeated(f)
 
+	if entry := types.mapEntry(f.GetTypeName()); entry != nil {
+		return newMapField(field, entry, types, opts)
+	}
+
+	field.IsOptional = isProto3Optional(f)
+	field.IsRequired = behaviors[fieldBehaviorRequired]
+
+	// 64 bit integers and bytes always default to zero, since their conversions cannot handle undefined
+	scalar := !field.IsMessage && !field.IsWrapper && !field.IsDuration && !field.IsFieldMask && field.Codec == ""
+	if opts.Defaults == DefaultsZero && scalar && !field.IsRepeated && !field.IsLong && !field.IsBytes && !field.IsOptional {
+		field.Zero = zeroValue(field)
+	}
+
 	return field
 }
 
 // generates the (Type, JSONType) tuple for a ModelField so marshal/unmarshal functions
 // will work when converting between TS interfaces and protobuf JSON.
-func protoToTSType(f *descriptor.FieldDescriptorProto) (string, string) {
+func protoToTSType(f *descriptor.FieldDescriptorProto, types typeRegistry, opts Options) (string, string) {
 	tsType := "string"
 	jsonType := "string"
 
 	switch f.GetType() {
 	case descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		// proto3 JSON represents NaN and the infinities as the strings "NaN", "Infinity" and "-Infinity"
+		tsType = "number"
+		jsonType = "number | string"
+
+		if isRepeated(f) {
+			jsonType = "(" + jsonType + ")"
+		}
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
 		descriptor.FieldDescriptorProto_TYPE_FIXED32,
-		descriptor.FieldDescriptorProto_TYPE_FIXED64,
-		descriptor.FieldDescriptorProto_TYPE_INT32,
-		descriptor.FieldDescriptorProto_TYPE_INT64:
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		// proto3 JSON represents 32 bit integers as numbers
 		tsType = "number"
 		jsonType = "number"
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		// proto3 JSON represents 64 bit integers as strings, since they can exceed Number.MAX_SAFE_INTEGER
+		tsType = opts.Int64
+		jsonType = "string"
 	case descriptor.FieldDescriptorProto_TYPE_STRING:
 		tsType = "string"
 		jsonType = "string"
 	case descriptor.FieldDescriptorProto_TYPE_BOOL:
 		tsType = "boolean"
 		jsonType = "boolean"
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		// proto3 JSON represents bytes as base64 encoded strings
+		tsType = "Uint8Array"
+		jsonType = "string"
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		// proto3 JSON represents enums by the name of the value, but parsers also accept the number of the value
+		tsType = types.name(f.GetTypeName())
+		jsonType = "string | number"
+
+		if isRepeated(f) {
+			jsonType = "(" + jsonType + ")"
+		}
 	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
 		name := f.GetTypeName()
 
+		// maps are represented by objects in proto3 JSON
+		if entry := types.mapEntry(name); entry != nil {
+			return mapTypes(entry, types, opts)
+		}
+
 		// Google WKT Timestamp is a special case here:
 		//
 		// Currently the value will just be left as jsonpb RFC 3339 string.
@@ -334,9 +2103,35 @@ func protoToTSType(f *descriptor.FieldDescriptorProto) (string, string) {
 		if name == ".google.protobuf.Timestamp" {
 			tsType = "Date"
 			jsonType = "string"
+		} else if name == ".google.protobuf.Duration" {
+			// proto3 JSON represents a Duration as a string of seconds, e.g. "3.5s"
+			if opts.Duration == DurationObject {
+				tsType = "Duration"
+			} else {
+				tsType = "string"
+			}
+			jsonType = "string"
+		} else if name == ".google.protobuf.FieldMask" {
+			// proto3 JSON represents a FieldMask as a comma separated string of its paths
+			tsType = "string[]"
+			jsonType = "string"
+		} else if p, ok := passthroughTypes[name]; ok {
+			// Google WKTs for Any and untyped JSON are represented by the JSON value itself
+			tsType = p.Type
+			jsonType = p.Type
+		} else if wrapped, ok := wrapperTypes[name]; ok {
+			// Google WKT wrappers are represented by their wrapped value in proto3 JSON, or null when unset
+			tsType, jsonType = protoToTSType(&descriptor.FieldDescriptorProto{Type: wrapped.Enum()}, types, opts)
+			tsType = tsType + " | null"
+			jsonType = jsonType + " | null"
+
+			if isRepeated(f) {
+				tsType = "(" + tsType + ")"
+				jsonType = "(" + jsonType + ")"
+			}
 		} else {
-			tsType = removePkg(name)
-			jsonType = removePkg(name) + "JSON"
+			tsType = types.name(name)
+			jsonType = types.name(name) + "JSON"
 		}
 	}
 
@@ -348,6 +2143,19 @@ func protoToTSType(f *descriptor.FieldDescriptorProto) (string, string) {
 	return tsType, jsonType
 }
 
+func isLong(field *descriptor.FieldDescriptorProto) bool {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return true
+	}
+
+	return false
+}
+
 func isRepeated(field *descriptor.FieldDescriptorProto) bool {
 	return field.Label != nil && *field.Label == descriptor.FieldDescriptorProto_LABEL_REPEATED
 }
@@ -357,6 +2165,72 @@ func removePkg(s string) string {
 	return p[len(p)-1]
 }
 
+// nestedTypeName converts a fully qualified type name to the name of the generated typescript type, where
+// nested types are joined to their parent messages by sep, e.g. .my.pkg.Outer.Kind => OuterKind or Outer_Kind
+func nestedTypeName(typeName string, pkg string, sep string) string {
+	name := strings.TrimPrefix(typeName, ".")
+
+	if pkg != "" && strings.HasPrefix(name, pkg+".") {
+		name = name[len(pkg)+1:]
+	} else {
+		name = removePkg(name)
+	}
+
+	return strings.Replace(name, ".", sep, -1)
+}
+
+// lowerCamelName is the lowerCamelCase JSON name of a field, which is the json_name set by protoc, or the name
+// that protoc derives from the proto name when it is not set or is not an identifier, e.g. named_layers is namedLayers
+func lowerCamelName(f *descriptor.FieldDescriptorProto) string {
+	if name := f.GetJsonName(); identifierPattern.MatchString(name) {
+		return name
+	}
+
+	var b strings.Builder
+	upper := false
+
+	for _, c := range f.GetName() {
+		switch {
+		case c == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(c)))
+			upper = false
+		default:
+			b.WriteRune(c)
+		}
+	}
+
+	return b.String()
+}
+
+// jsonAliases generates the object of the aliases of the fields of a model, e.g. {"namedLayers": "named_layers"},
+// which JSONTo* copies to the JSON names of the fields, or an empty string when no field has an alias.
+func jsonAliases(m *Model) string {
+	var aliases []string
+	for _, f := range m.fields() {
+		if f.JSONAlias != "" {
+			aliases = append(aliases, fmt.Sprintf("%q: %q", f.JSONAlias, f.JSONName))
+		}
+	}
+
+	if len(aliases) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(aliases, ", ") + "}"
+}
+
+// fieldName returns the name of the property of a field or oneof, which is its camelCase name, or its original
+// proto name with Options.FieldNames set to proto.
+func fieldName(name string, opts Options) string {
+	if opts.FieldNames == FieldNamesProto {
+		return name
+	}
+
+	return camelCase(name)
+}
+
 func camelCase(s string) string {
 	parts := strings.Split(s, "_")
 
@@ -372,6 +2246,31 @@ func camelCase(s string) string {
 }
 
 func stringify(f ModelField) string {
+	if f.IsOptional {
+		value := f
+		value.IsOptional = false
+
+		if conv := stringify(value); conv != "m."+f.Name {
+			return fmt.Sprintf("m.%s === undefined ? undefined : %s", f.Name, conv)
+		}
+	}
+
+	if f.IsMap {
+		return stringifyMap(f)
+	}
+
+	if f.IsWrapper {
+		return stringifyWrapper(f)
+	}
+
+	if f.IsDuration {
+		return stringifyDuration(f)
+	}
+
+	if f.IsFieldMask {
+		return stringifyFieldMask(f)
+	}
+
 	if f.IsRepeated {
 		singularType := f.Type[0 : len(f.Type)-2] // strip array brackets from type
 
@@ -379,6 +2278,22 @@ func stringify(f ModelField) string {
 			return fmt.Sprintf("m.%s.map((n) => n.toISOString())", f.Name)
 		}
 
+		if f.IsEnum && !f.EnumNumbers {
+			return fmt.Sprintf("m.%s.map((n) => %s[n])", f.Name, singularType)
+		}
+
+		if f.IsLong && singularType != Int64String {
+			return fmt.Sprintf("m.%s.map((n) => %s)", f.Name, longToString(singularType, "n"))
+		}
+
+		if f.IsFloat {
+			return fmt.Sprintf("m.%s.map(floatToJSON)", f.Name)
+		}
+
+		if f.IsBytes {
+			return fmt.Sprintf("m.%s.map(bytesToBase64)", f.Name)
+		}
+
 		if f.IsMessage {
 			return fmt.Sprintf("m.%s.map(%sToJSON)", f.Name, singularType)
 		}
@@ -388,6 +2303,22 @@ func stringify(f ModelField) string {
 		return fmt.Sprintf("m.%s.toISOString()", f.Name)
 	}
 
+	if f.IsEnum && !f.EnumNumbers {
+		return fmt.Sprintf("%s[m.%s]", f.Type, f.Name)
+	}
+
+	if f.IsLong {
+		return longToString(f.Type, "m."+f.Name)
+	}
+
+	if f.IsFloat {
+		return fmt.Sprintf("floatToJSON(m.%s)", f.Name)
+	}
+
+	if f.IsBytes {
+		return fmt.Sprintf("bytesToBase64(m.%s)", f.Name)
+	}
+
 	if f.IsMessage {
 		return fmt.Sprintf("%sToJSON(m.%s)", f.Type, f.Name)
 	}
@@ -396,6 +2327,51 @@ func stringify(f ModelField) string {
 }
 
 func parse(f ModelField) string {
+	// a REQUIRED optional field is typed as set, though the JSON of a message may still omit it
+	if f.IsRequired && f.IsOptional {
+		value := f
+		value.IsRequired = false
+
+		conv := parse(value)
+		if strings.Contains(conv, " ") {
+			conv = "(" + conv + ")"
+		}
+
+		return fmt.Sprintf("%s as %s", conv, f.Type)
+	}
+
+	if f.IsOptional {
+		value := f
+		value.IsOptional = false
+
+		if conv := parse(value); conv != "m."+f.JSONName {
+			return fmt.Sprintf("m.%s === undefined ? undefined : %s", f.JSONName, conv)
+		}
+	}
+
+	if f.Zero != "" {
+		value := f
+		value.Zero = ""
+
+		return fmt.Sprintf("m.%s === undefined ? %s : %s", f.JSONName, f.Zero, parse(value))
+	}
+
+	if f.IsMap {
+		return parseMap(f)
+	}
+
+	if f.IsWrapper {
+		return parseWrapper(f)
+	}
+
+	if f.IsDuration {
+		return parseDuration(f)
+	}
+
+	if f.IsFieldMask {
+		return parseFieldMask(f)
+	}
+
 	if f.IsRepeated {
 		singularType := f.Type[0 : len(f.Type)-2] // strip array brackets from type
 
@@ -403,6 +2379,22 @@ func parse(f ModelField) string {
 			return fmt.Sprintf("m.%s.map((n) => new Date(n))", f.JSONName)
 		}
 
+		if f.IsEnum {
+			return fmt.Sprintf("m.%s.map((n) => enumFromJSON<%s>(%s, n))", f.JSONName, singularType, singularType)
+		}
+
+		if f.IsLong && singularType != Int64String {
+			return fmt.Sprintf("m.%s.map((n) => %s)", f.JSONName, longFromString(singularType, "n"))
+		}
+
+		if f.IsFloat {
+			return fmt.Sprintf("m.%s.map(floatFromJSON)", f.JSONName)
+		}
+
+		if f.IsBytes {
+			return fmt.Sprintf("m.%s.map(base64ToBytes)", f.JSONName)
+		}
+
 		if f.IsMessage {
 			return fmt.Sprintf("m.%s.map(JSONTo%s)", f.JSONName, singularType)
 		}
@@ -412,9 +2404,71 @@ func parse(f ModelField) string {
 		return fmt.Sprintf("new Date(m.%s)", f.JSONName)
 	}
 
+	if f.IsEnum {
+		return fmt.Sprintf("enumFromJSON<%s>(%s, m.%s)", f.Type, f.Type, f.JSONName)
+	}
+
+	if f.IsLong {
+		// absent 64 bit fields are the proto3 default of zero
+		return longFromString(f.Type, fmt.Sprintf("m.%s || \"0\"", f.JSONName))
+	}
+
+	if f.IsFloat {
+		return fmt.Sprintf("floatFromJSON(m.%s)", f.JSONName)
+	}
+
+	if f.IsBytes {
+		// absent bytes fields are the proto3 default of empty bytes
+		return fmt.Sprintf("base64ToBytes(m.%s || \"\")", f.JSONName)
+	}
+
 	if f.IsMessage {
 		return fmt.Sprintf("JSONTo%s(m.%s)", f.Type, f.JSONName)
 	}
 
 	return "m." + f.JSONName
 }
+
+// stringifyOneof marshals a oneof member only when it is the member that is set.
+func stringifyOneof(o ModelOneof, f ModelField) string {
+	value := f
+	value.Name = o.Name + ".value"
+
+	return fmt.Sprintf(`m.%s && m.%s.kind === "%s" ? %s : undefined`, o.Name, o.Name, f.Name, stringify(value))
+}
+
+// parseOneof detects which oneof member is present in the JSON and unmarshals it into the discriminated union.
+func parseOneof(o ModelOneof) string {
+	expr := "undefined"
+
+	for i := len(o.Fields) - 1; i >= 0; i-- {
+		f := o.Fields[i]
+		expr = fmt.Sprintf(`m.%s !== undefined ? {kind: "%s", value: %s} : %s`, f.JSONName, f.Name, parse(f), expr)
+	}
+
+	return expr
+}
+
+// longToString converts a 64 bit integer value in its typescript representation to a decimal string.
+func longToString(int64Type string, value string) string {
+	switch int64Type {
+	case Int64Number:
+		return fmt.Sprintf("String(%s)", value)
+	case Int64BigInt:
+		return fmt.Sprintf("%s.toString()", value)
+	}
+
+	return value
+}
+
+// longFromString converts a decimal string to the typescript representation of a 64 bit integer.
+func longFromString(int64Type string, value string) string {
+	switch int64Type {
+	case Int64Number:
+		return fmt.Sprintf("Number(%s)", value)
+	case Int64BigInt:
+		return fmt.Sprintf("BigInt(%s)", value)
+	}
+
+	return value
+}
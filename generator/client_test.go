@@ -1,6 +1,14 @@
 package generator
 
-import "testing"
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
 
 func TestAPIContext_ApplyMarshalFlags(t *testing.T) {
 	nested := &Model{
@@ -48,9 +56,987 @@ func TestAPIContext_ApplyMarshalFlags(t *testing.T) {
 		t.Error("something went wrong")
 	}
 
-	ctx.ApplyMarshalFlags()
+	if err := ctx.ApplyMarshalFlags(); err != nil {
+		t.Fatal(err)
+	}
 
 	if nested.CanMarshal != true {
 		t.Errorf("expected nested.CanMarshal to be true since it is a field in Bar")
 	}
 }
+
+func TestAPIContext_ApplyMarshalFlags_UnknownType(t *testing.T) {
+	ctx := NewAPIContext()
+	ctx.AddModel(&Model{
+		Name:       "Bar",
+		CanMarshal: true,
+		Fields:     []ModelField{{Name: "nested", JSONName: "nested", Type: "Nested", IsMessage: true}},
+		file:       "bar.proto",
+	})
+
+	err := ctx.ApplyMarshalFlags()
+	if err == nil || err.Error() != "bar.proto: could not find the message Nested of field Bar.nested" {
+		t.Errorf("expected an error naming the file and field of the unknown type, got %v", err)
+	}
+}
+
+func TestAPIContext_RecursiveModels(t *testing.T) {
+	node := &Model{
+		Name:       "Node",
+		CanMarshal: true,
+		Fields: []ModelField{
+			{Name: "parent", JSONName: "parent", Type: "Node", IsMessage: true},
+			{Name: "children", Type: "Node[]", IsMessage: true, IsRepeated: true},
+			{Name: "link", Type: "Link", IsMessage: true},
+		},
+	}
+
+	link := &Model{
+		Name: "Link",
+		Fields: []ModelField{
+			{Name: "target", Type: "Node", IsMessage: true},
+		},
+	}
+
+	ctx := NewAPIContext()
+	ctx.AddModel(node)
+	ctx.AddModel(link)
+
+	ctx.markRecursiveFields()
+	if err := ctx.ApplyMarshalFlags(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !link.CanMarshal {
+		t.Error("expected link.CanMarshal to be true since it is a field in Node")
+	}
+
+	if !node.Fields[0].IsOptional || node.Fields[1].IsOptional || !node.Fields[2].IsOptional {
+		t.Errorf("expected the parent and link fields of Node to be optional, got %+v", node.Fields)
+	}
+
+	if link.Fields[0].IsOptional {
+		t.Error("expected Link.target not to be optional, since the cycle is broken by Node.link")
+	}
+
+	if expected, actual := "m.parent === undefined ? undefined : JSONToNode(m.parent)", parse(node.Fields[0]); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+}
+
+func TestNestedTypeName(t *testing.T) {
+	tests := []struct {
+		typeName string
+		pkg      string
+		sep      string
+		expected string
+	}{
+		{".my.pkg.Color", "my.pkg", "", "Color"},
+		{".my.pkg.Shirt.Size", "my.pkg", "", "ShirtSize"},
+		{".my.pkg.Shirt.Tag.Kind", "my.pkg", "", "ShirtTagKind"},
+		{".my.pkg.Shirt.Tag.Kind", "my.pkg", "_", "Shirt_Tag_Kind"},
+		{".Color", "", "", "Color"},
+	}
+
+	for _, tt := range tests {
+		if actual := nestedTypeName(tt.typeName, tt.pkg, tt.sep); actual != tt.expected {
+			t.Errorf("nestedTypeName(%q, %q, %q) = %q, expected %q", tt.typeName, tt.pkg, tt.sep, actual, tt.expected)
+		}
+	}
+}
+
+func TestCreateClientAPIs_Imports(t *testing.T) {
+	common := &descriptor.FileDescriptorProto{
+		Name:    proto.String("common.proto"),
+		Package: proto.String("common"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Page")},
+		},
+	}
+
+	api := &descriptor.FileDescriptorProto{
+		Name:       proto.String("api.proto"),
+		Package:    proto.String("api"),
+		Dependency: []string{"common.proto"},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("List"),
+						InputType:  proto.String(".common.Page"),
+						OutputType: proto.String(".common.Page"),
+					},
+				},
+			},
+		},
+	}
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{common, api}, []string{"common.proto", "api.proto"}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "import {JSONToPage, Page, PageToJSON} from './common';"
+	if !strings.Contains(files[0].GetContent(), expected) {
+		t.Errorf("expected api.ts to contain %q, got:\n%s", expected, files[0].GetContent())
+	}
+
+	if !strings.Contains(files[1].GetContent(), "export const PageToJSON") {
+		t.Errorf("expected common.ts to export PageToJSON since Page is an rpc input type in api.proto")
+	}
+
+	for _, expected := range []string{"export class ApiMockClient implements Api {", "export const createApiMock = "} {
+		if !strings.Contains(files[0].GetContent(), expected) {
+			t.Errorf("expected api.ts to contain %q", expected)
+		}
+	}
+}
+
+// TestCreateClientAPIs_FileToGenerate generates only the requested files, while the types of their imported
+// files are still resolved and imported from the modules of the imported files.
+func TestCreateClientAPIs_FileToGenerate(t *testing.T) {
+	common := &descriptor.FileDescriptorProto{
+		Name:    proto.String("common.proto"),
+		Package: proto.String("common"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Page")},
+		},
+	}
+
+	api := &descriptor.FileDescriptorProto{
+		Name:       proto.String("api.proto"),
+		Package:    proto.String("api"),
+		Dependency: []string{"common.proto"},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("List"),
+						InputType:  proto.String(".common.Page"),
+						OutputType: proto.String(".common.Page"),
+					},
+				},
+			},
+		},
+	}
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{common, api}, []string{"api.proto"}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 1 || files[0].GetName() != "api.ts" {
+		var names []string
+		for _, f := range files {
+			names = append(names, f.GetName())
+		}
+
+		t.Fatalf("expected only api.ts to be generated, got %v", names)
+	}
+
+	expected := "import {JSONToPage, Page, PageToJSON} from './common';"
+	if !strings.Contains(files[0].GetContent(), expected) {
+		t.Errorf("expected api.ts to contain %q, got:\n%s", expected, files[0].GetContent())
+	}
+}
+
+func TestCreateClientAPIs_UnknownType(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Now"),
+						InputType:  proto.String(".google.protobuf.Timestamp"),
+						OutputType: proto.String(".google.protobuf.Timestamp"),
+					},
+				},
+			},
+		},
+	}
+
+	_, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, DefaultOptions())
+	if err == nil || err.Error() != "api.proto: could not find the message .google.protobuf.Timestamp of rpc Api.Now" {
+		t.Errorf("expected an error naming the file and rpc of the unknown type, got %v", err)
+	}
+}
+
+func TestCreateClientAPIs_Streaming(t *testing.T) {
+	tests := []struct {
+		client   bool
+		server   bool
+		expected string
+	}{
+		{false, true, "api.proto: rpc Api.Watch is server streaming, which is not supported by Twirp"},
+		{true, false, "api.proto: rpc Api.Watch is client streaming, which is not supported by Twirp"},
+		{true, true, "api.proto: rpc Api.Watch is bidirectional streaming, which is not supported by Twirp"},
+	}
+
+	for _, tt := range tests {
+		api := &descriptor.FileDescriptorProto{
+			Name:    proto.String("api.proto"),
+			Package: proto.String("api"),
+			MessageType: []*descriptor.DescriptorProto{
+				{Name: proto.String("Req")},
+				{Name: proto.String("Resp")},
+			},
+			Service: []*descriptor.ServiceDescriptorProto{
+				{
+					Name: proto.String("Api"),
+					Method: []*descriptor.MethodDescriptorProto{
+						{
+							Name:            proto.String("Watch"),
+							InputType:       proto.String(".api.Req"),
+							OutputType:      proto.String(".api.Resp"),
+							ClientStreaming: proto.Bool(tt.client),
+							ServerStreaming: proto.Bool(tt.server),
+						},
+					},
+				},
+			},
+		}
+
+		_, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, DefaultOptions())
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("expected error %q, got %v", tt.expected, err)
+		}
+	}
+}
+
+func TestCreateClientAPIs_Server(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Req")},
+			{Name: proto.String("Resp")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Get"),
+						InputType:  proto.String(".api.Req"),
+						OutputType: proto.String(".api.Resp"),
+					},
+				},
+			},
+		},
+	}
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, Options{Protocol: ProtocolJSON, Int64: Int64Number, Duration: DurationString, Server: true, TwirpPrefix: "/twirp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"export const JSONToReq",
+		"export const RespToJSON",
+		`return createTwirpRouter("/twirp/api.Api/", {`,
+		"Get: (body, req) => new Promise<Resp>((resolve) => resolve(handler.get(JSONToReq(body), req))).then(RespToJSON),",
+	} {
+		if !strings.Contains(files[0].GetContent(), expected) {
+			t.Errorf("expected api.ts to contain %q, got:\n%s", expected, files[0].GetContent())
+		}
+	}
+}
+
+// TestCreateClientAPIs_NestedEnums generates the enums of messages nested in other messages, which are named after
+// all of their parent messages, and are referenced by the fields of their parents, siblings and other messages.
+func TestCreateClientAPIs_NestedEnums(t *testing.T) {
+	enumField := func(name string, number int32, typ string) *descriptor.FieldDescriptorProto {
+		return &descriptor.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     descriptor.FieldDescriptorProto_TYPE_ENUM.Enum(),
+			TypeName: proto.String(typ),
+		}
+	}
+
+	level := &descriptor.EnumDescriptorProto{
+		Name:  proto.String("Level"),
+		Value: []*descriptor.EnumValueDescriptorProto{{Name: proto.String("LEVEL_UNSPECIFIED"), Number: proto.Int32(0)}},
+	}
+
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		Syntax:  proto.String("proto3"),
+		MessageType: []*descriptor.DescriptorProto{
+			{
+				Name: proto.String("Outer"),
+				NestedType: []*descriptor.DescriptorProto{
+					{
+						Name: proto.String("Middle"),
+						NestedType: []*descriptor.DescriptorProto{
+							{
+								Name:     proto.String("Inner"),
+								EnumType: []*descriptor.EnumDescriptorProto{level},
+								Field:    []*descriptor.FieldDescriptorProto{enumField("level", 1, ".api.Outer.Middle.Inner.Level")},
+							},
+						},
+						Field: []*descriptor.FieldDescriptorProto{enumField("level", 1, ".api.Outer.Middle.Inner.Level")},
+					},
+				},
+				Field: []*descriptor.FieldDescriptorProto{enumField("level", 1, ".api.Outer.Middle.Inner.Level")},
+			},
+			{
+				Name:     proto.String("Other"),
+				EnumType: []*descriptor.EnumDescriptorProto{level},
+				Field: []*descriptor.FieldDescriptorProto{
+					enumField("level", 1, ".api.Other.Level"),
+					enumField("deep", 2, ".api.Outer.Middle.Inner.Level"),
+				},
+			},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Get"),
+						InputType:  proto.String(".api.Outer"),
+						OutputType: proto.String(".api.Other"),
+					},
+				},
+			},
+		},
+	}
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"export enum OuterMiddleInnerLevel {",
+		"export enum OtherLevel {",
+		"export interface OuterMiddleInner {\n    level: OuterMiddleInnerLevel;\n}",
+		"export interface OuterMiddle {\n    level: OuterMiddleInnerLevel;\n}",
+		"export interface Other {\n    level: OtherLevel;\n    deep: OuterMiddleInnerLevel;\n}",
+		"deep: enumFromJSON<OuterMiddleInnerLevel>(OuterMiddleInnerLevel, m.deep),",
+	} {
+		if !strings.Contains(files[0].GetContent(), expected) {
+			t.Errorf("expected api.ts to contain %q, got:\n%s", expected, files[0].GetContent())
+		}
+	}
+}
+
+// TestCreateClientAPIs_Empty generates the methods of google.protobuf.Empty without a request argument or a response,
+// since empty.proto is skipped like the other mapped WKTs.
+func TestCreateClientAPIs_Empty(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:        proto.String("api.proto"),
+		Package:     proto.String("api"),
+		Dependency:  []string{"google/protobuf/empty.proto"},
+		MessageType: []*descriptor.DescriptorProto{{Name: proto.String("Req")}},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Ping"),
+						InputType:  proto.String(".google.protobuf.Empty"),
+						OutputType: proto.String(".google.protobuf.Empty"),
+					},
+					{
+						Name:       proto.String("Put"),
+						InputType:  proto.String(".api.Req"),
+						OutputType: proto.String(".google.protobuf.Empty"),
+					},
+				},
+			},
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.Server = true
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := files[0].GetContent()
+	for _, expected := range []string{
+		"ping: (callOptions?: CallOptions) => Promise<void>;",
+		"put: (reqRequest: Req, callOptions?: CallOptions) => Promise<void>;",
+		"return this.transport(createTwirpRequest(ctx.url, {}, ctx)).then((resp) => {",
+		"return resp.text().then(() => undefined);",
+		"Ping: (_, req) => new Promise<void>((resolve) => resolve(handler.ping(req))).then(() => ({})),",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("expected api.ts to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	if strings.Contains(content, "EmptyToJSON") || strings.Contains(content, "JSONToEmpty") {
+		t.Errorf("expected api.ts not to use the converters of Empty, got:\n%s", content)
+	}
+}
+
+// TestCreateClientAPIs_NoPackage generates the routes of the services of a file without a package from the
+// names of the services, e.g. /twirp/Api/Get.
+func TestCreateClientAPIs_NoPackage(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name: proto.String("api.proto"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Req")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Get"),
+						InputType:  proto.String(".Req"),
+						OutputType: proto.String(".Req"),
+					},
+				},
+			},
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.Server = true
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		`this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/Api/";`,
+		`service: "Api",`,
+		`return createTwirpRouter("/twirp/Api/", {`,
+	} {
+		if !strings.Contains(files[0].GetContent(), expected) {
+			t.Errorf("expected api.ts to contain %q, got:\n%s", expected, files[0].GetContent())
+		}
+	}
+}
+
+func TestCreateClientAPIs_ServiceModules(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Req")},
+		},
+		Service: []*descriptor.ServiceDescriptorProto{
+			{
+				Name: proto.String("Api"),
+				Method: []*descriptor.MethodDescriptorProto{
+					{
+						Name:       proto.String("Get"),
+						InputType:  proto.String(".api.Req"),
+						OutputType: proto.String(".api.Req"),
+					},
+				},
+			},
+		},
+	}
+
+	opts := DefaultOptions()
+	opts.ServiceModules = true
+
+	files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 || files[0].GetName() != "api.ts" || files[1].GetName() != "api_api.ts" {
+		t.Fatalf("expected api.ts and api_api.ts to be generated, got %d files", len(files))
+	}
+
+	if strings.Contains(files[0].GetContent(), "export class DefaultApi") {
+		t.Errorf("expected the client to be generated in api_api.ts, not api.ts")
+	}
+
+	expected := "import {JSONToReq, Req, ReqToJSON} from './api';"
+	if !strings.Contains(files[1].GetContent(), expected) {
+		t.Errorf("expected api_api.ts to contain %q, got:\n%s", expected, files[1].GetContent())
+	}
+}
+
+// TestCreateClientAPIs_TemplateDir extends the template of a module with the header and footer templates of
+// template_dir, and replaces it with a client_api template.
+func TestCreateClientAPIs_TemplateDir(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Req")},
+		},
+	}
+
+	write := func(dir, name, text string) {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	generate := func(dir string) (string, error) {
+		opts := DefaultOptions()
+		opts.TemplateDir = dir
+
+		files, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, opts)
+		if err != nil {
+			return "", err
+		}
+
+		return files[0].GetContent(), nil
+	}
+
+	dir := t.TempDir()
+	write(dir, "header.tmpl", "// Copyright Example Corp. {{template \"models\" .}}\n")
+	write(dir, "helpers.tmpl", `{{define "models"}}{{range .Models}}{{if not .Primitive}}{{.Name}}{{end}}{{end}}{{end}}`)
+	write(dir, "footer.tmpl", "// end of {{.ProtoFile}} in {{.ModuleName}}\n")
+
+	content, err := generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(content, "// Copyright Example Corp. Req\n") || !strings.HasSuffix(content, "// end of api.proto in api\n") {
+		t.Errorf("expected api.ts to start with the header and end with the footer, got:\n%s", content)
+	}
+
+	if !strings.Contains(content, "export interface Req {") {
+		t.Errorf("expected api.ts to contain the models of the module template")
+	}
+
+	write(dir, "client_api.tmpl", "{{range .Models}}{{if not .Primitive}}export type {{.Name}} = {};\n{{end}}{{end}}")
+
+	content, err = generate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(content, "export interface Req {") || !strings.Contains(content, "export type Req = {};") {
+		t.Errorf("expected client_api.tmpl to replace the module template, got:\n%s", content)
+	}
+
+	write(dir, "footer.tmpl", "{{.Unknown}}")
+	if _, err := generate(dir); err == nil {
+		t.Errorf("expected an error for a template with an unknown field")
+	}
+
+	write(dir, "footer.tmpl", "{{end}}")
+	if _, err := generate(dir); err == nil || !strings.Contains(err.Error(), "template_dir") {
+		t.Errorf("expected a template_dir error for a template that does not parse, got %v", err)
+	}
+
+	if _, err := generate(t.TempDir()); err == nil || !strings.Contains(err.Error(), "no *.tmpl files") {
+		t.Errorf("expected an error for a template_dir without templates, got %v", err)
+	}
+}
+
+func TestFunctionName(t *testing.T) {
+	books := &Service{Name: "Books", Methods: []ServiceMethod{{Path: "List"}, {Path: "Delete"}, {Path: "GetBook"}, {Path: "JoinURL"}, {Path: "CreateBook"}}}
+	authors := &Service{Name: "Authors", Methods: []ServiceMethod{{Path: "List"}}}
+	ctx := &APIContext{Options: Options{Builders: true}, Services: []*Service{books, authors}, Models: []*Model{{Name: "Book"}}}
+
+	tests := []struct {
+		service  *Service
+		method   ServiceMethod
+		expected string
+	}{
+		{books, books.Methods[2], "getBook"},
+		{books, books.Methods[1], "booksDelete"},
+		{books, books.Methods[0], "booksList"},
+		{authors, authors.Methods[0], "authorsList"},
+		{books, books.Methods[3], "booksJoinURL"},
+		{books, books.Methods[4], "booksCreateBook"},
+	}
+
+	for _, tt := range tests {
+		if actual := ctx.functionName(tt.service, tt.method); actual != tt.expected {
+			t.Errorf("expected the function of %s.%s to be %s, got %s", tt.service.Name, tt.method.Path, tt.expected, actual)
+		}
+	}
+}
+
+func TestIsSubscription(t *testing.T) {
+	tests := map[string]bool{
+		"WatchHats":       true,
+		"SubscribeOrders": true,
+		"Watch":           true,
+		"Watchdog":        false,
+		"Subscriber":      false,
+		"MakeHat":         false,
+	}
+
+	for name, expected := range tests {
+		if actual := isSubscription(name); actual != expected {
+			t.Errorf("expected isSubscription(%q) to be %v, got %v", name, expected, actual)
+		}
+	}
+}
+
+func TestDebugRedact(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []byte
+		expected bool
+	}{
+		{"none", nil, false},
+		{"debug_redact", []byte{0x80, 0x01, 0x01}, true},
+		{"debug_redact false", []byte{0x80, 0x01, 0x00}, false},
+		{"after other fields", []byte{0x9a, 0x05, 0x02, 'h', 'i', 0x80, 0x01, 0x01}, true},
+		{"truncated", []byte{0x80, 0x01}, false},
+	}
+
+	for _, test := range tests {
+		o := &descriptor.FieldOptions{XXX_unrecognized: test.options}
+		if actual := debugRedact(o); actual != test.expected {
+			t.Errorf("%s: expected debugRedact to be %v, got %v", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestIdempotency(t *testing.T) {
+	tests := map[descriptor.MethodOptions_IdempotencyLevel]string{
+		descriptor.MethodOptions_IDEMPOTENCY_UNKNOWN: "",
+		descriptor.MethodOptions_NO_SIDE_EFFECTS:     "no_side_effects",
+		descriptor.MethodOptions_IDEMPOTENT:          "idempotent",
+	}
+
+	for level, expected := range tests {
+		m := &descriptor.MethodDescriptorProto{Options: &descriptor.MethodOptions{IdempotencyLevel: level.Enum()}}
+		if actual := idempotency(m); actual != expected {
+			t.Errorf("expected the idempotency of %v to be %q, got %q", level, expected, actual)
+		}
+	}
+
+	if actual := idempotency(&descriptor.MethodDescriptorProto{}); actual != "" {
+		t.Errorf("expected a method without options to have no idempotency, got %q", actual)
+	}
+}
+
+func TestParseOneof(t *testing.T) {
+	o := ModelOneof{
+		Name: "shape",
+		Type: "DrawingShape",
+		Fields: []ModelField{
+			{Name: "circle", Type: "Circle", JSONName: "circle", IsMessage: true},
+			{Name: "labelText", Type: "string", JSONName: "label_text"},
+		},
+	}
+
+	expected := `m.circle !== undefined ? {kind: "circle", value: JSONToCircle(m.circle)} : ` +
+		`m.label_text !== undefined ? {kind: "labelText", value: m.label_text} : undefined`
+
+	if actual := parseOneof(o); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestNewField_JSONNames(t *testing.T) {
+	tests := []struct {
+		name      string
+		jsonName  string
+		names     string
+		expected  string
+		alias     string
+		protoName string
+	}{
+		{"label_text", "labelText", JSONNamesOriginal, "label_text", "labelText", "label_text"},
+		{"label_text", "labelText", JSONNamesCamel, "labelText", "label_text", "label_text"},
+		{"label_text", "", JSONNamesCamel, "labelText", "label_text", "label_text"},
+		{"title", "title", JSONNamesCamel, "title", "", "title"},
+		{"page_size", "size", JSONNamesCamel, "size", "page_size", "page_size"},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.JSONNames = tt.names
+
+		f := &descriptor.FieldDescriptorProto{
+			Name: proto.String(tt.name),
+			Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+		if tt.jsonName != "" {
+			f.JsonName = proto.String(tt.jsonName)
+		}
+
+		field := newField(f, typeRegistry{}, opts)
+		if field.JSONName != tt.expected || field.JSONAlias != tt.alias || field.ProtoName != tt.protoName {
+			t.Errorf("%s with json_names=%s: expected %s, %s and %s, got %s, %s and %s", tt.name, tt.names,
+				tt.expected, tt.alias, tt.protoName, field.JSONName, field.JSONAlias, field.ProtoName)
+		}
+	}
+}
+
+func TestNewField_FieldNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    string
+		expected string
+	}{
+		{"label_text", FieldNamesCamel, "labelText"},
+		{"label_text", FieldNamesProto, "label_text"},
+		{"title", FieldNamesProto, "title"},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.FieldNames = tt.names
+
+		f := &descriptor.FieldDescriptorProto{
+			Name: proto.String(tt.name),
+			Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum(),
+		}
+
+		field := newField(f, typeRegistry{}, opts)
+		if field.Name != tt.expected || field.ProtoName != tt.name {
+			t.Errorf("%s with field_names=%s: expected %s, got %s", tt.name, tt.names, tt.expected, field.Name)
+		}
+	}
+}
+
+func TestHasLongFields(t *testing.T) {
+	ctx := NewAPIContext()
+	ctx.AddModel(&Model{Name: "Hat", Fields: []ModelField{{Name: "size", Type: "bigint", IsLong: true}}})
+	ctx.AddModel(&Model{Name: "Hats", Fields: []ModelField{{Name: "hats", Type: "Hat[]", IsMessage: true}}})
+	ctx.AddModel(&Model{Name: "Tree", Fields: []ModelField{{Name: "children", Type: "Tree[]", IsMessage: true}, {Name: "name", Type: "string"}}})
+	ctx.AddModel(&Model{Name: "Box", Oneofs: []ModelOneof{{Name: "content", Fields: []ModelField{{Name: "external", Type: "Imported", IsMessage: true}}}}})
+
+	tests := []struct {
+		model    string
+		expected bool
+	}{
+		{"Hat", true},
+		{"Hats", true},
+		{"Tree", false},
+		{"Box", true},
+	}
+
+	for _, tt := range tests {
+		if actual := ctx.hasLongFields(tt.model, map[string]bool{}); actual != tt.expected {
+			t.Errorf("%s: expected %v, got %v", tt.model, tt.expected, actual)
+		}
+	}
+}
+
+func TestHTTPRule_JSONPaths(t *testing.T) {
+	ctx := NewAPIContext()
+	ctx.AddModel(&Model{Name: "Book", Fields: []ModelField{{ProtoName: "author_name", JSONName: "authorName", Type: "string"}}})
+	ctx.AddModel(&Model{Name: "UpdateBookRequest", Fields: []ModelField{
+		{ProtoName: "book_id", JSONName: "bookId", Type: "string"},
+		{ProtoName: "book", JSONName: "book", Type: "Book", IsMessage: true},
+	}})
+
+	rule := &HTTPRule{Method: "PATCH", Path: "/v1/books/{book_id}/{book.author_name=authors/*}", Body: "book"}
+	method := ServiceMethod{InputType: "UpdateBookRequest", OutputType: "Book"}
+
+	if err := ctx.checkHTTPRule(rule, method); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{method: "PATCH", path: "/v1/books/{bookId}/{book.authorName=authors/*}", body: "book"}`
+	if actual := rule.Literal(); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+
+	rule.Path = "/v1/books/{author_name}"
+	if err := ctx.checkHTTPRule(rule, method); err == nil {
+		t.Error("expected an error for a path variable that is not a field")
+	}
+}
+
+func TestProtoToTSType_Scalars(t *testing.T) {
+	tests := []struct {
+		t        descriptor.FieldDescriptorProto_Type
+		int64    string
+		tsType   string
+		jsonType string
+	}{
+		{descriptor.FieldDescriptorProto_TYPE_DOUBLE, Int64Number, "number", "number | string"},
+		{descriptor.FieldDescriptorProto_TYPE_FLOAT, Int64Number, "number", "number | string"},
+		{descriptor.FieldDescriptorProto_TYPE_INT32, Int64Number, "number", "number"},
+		{descriptor.FieldDescriptorProto_TYPE_UINT32, Int64Number, "number", "number"},
+		{descriptor.FieldDescriptorProto_TYPE_SINT32, Int64Number, "number", "number"},
+		{descriptor.FieldDescriptorProto_TYPE_FIXED32, Int64Number, "number", "number"},
+		{descriptor.FieldDescriptorProto_TYPE_SFIXED32, Int64Number, "number", "number"},
+		{descriptor.FieldDescriptorProto_TYPE_INT64, Int64Number, "number", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_UINT64, Int64String, "string", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_SINT64, Int64BigInt, "bigint", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_FIXED64, Int64String, "string", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_SFIXED64, Int64BigInt, "bigint", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_BOOL, Int64Number, "boolean", "boolean"},
+		{descriptor.FieldDescriptorProto_TYPE_STRING, Int64Number, "string", "string"},
+		{descriptor.FieldDescriptorProto_TYPE_BYTES, Int64Number, "Uint8Array", "string"},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Int64 = tt.int64
+
+		tsType, jsonType := protoToTSType(&descriptor.FieldDescriptorProto{Type: tt.t.Enum()}, typeRegistry{}, opts)
+		if tsType != tt.tsType || jsonType != tt.jsonType {
+			t.Errorf("%s: expected types %s and %s, got %s and %s", tt.t, tt.tsType, tt.jsonType, tsType, jsonType)
+		}
+	}
+}
+
+func TestStringifyAndParseLong(t *testing.T) {
+	tests := []struct {
+		field     ModelField
+		stringify string
+		parse     string
+	}{
+		{
+			ModelField{Name: "count", Type: Int64Number, JSONName: "count", IsLong: true},
+			"String(m.count)",
+			`Number(m.count || "0")`,
+		},
+		{
+			ModelField{Name: "count", Type: Int64String, JSONName: "count", IsLong: true},
+			"m.count",
+			`m.count || "0"`,
+		},
+		{
+			ModelField{Name: "counts", Type: Int64BigInt + "[]", JSONName: "counts", IsLong: true, IsRepeated: true},
+			"m.counts.map((n) => n.toString())",
+			"m.counts.map((n) => BigInt(n))",
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := stringify(tt.field); actual != tt.stringify {
+			t.Errorf("expected stringify %s, got %s", tt.stringify, actual)
+		}
+
+		if actual := parse(tt.field); actual != tt.parse {
+			t.Errorf("expected parse %s, got %s", tt.parse, actual)
+		}
+	}
+}
+
+func TestStringifyAndParseFloat(t *testing.T) {
+	tests := []struct {
+		f         *descriptor.FieldDescriptorProto
+		jsonType  string
+		stringify string
+		parse     string
+	}{
+		{
+			&descriptor.FieldDescriptorProto{Name: proto.String("scale"), Type: descriptor.FieldDescriptorProto_TYPE_DOUBLE.Enum()},
+			"number | string",
+			"floatToJSON(m.scale)",
+			"floatFromJSON(m.scale)",
+		},
+		{
+			&descriptor.FieldDescriptorProto{Name: proto.String("weights"), Type: descriptor.FieldDescriptorProto_TYPE_FLOAT.Enum(), Label: descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum()},
+			"(number | string)[]",
+			"m.weights.map(floatToJSON)",
+			"m.weights.map(floatFromJSON)",
+		},
+		{
+			&descriptor.FieldDescriptorProto{Name: proto.String("ratio"), Type: descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".google.protobuf.FloatValue")},
+			"number | string | null",
+			"m.ratio === null ? null : floatToJSON(m.ratio)",
+			"m.ratio === undefined || m.ratio === null ? null : floatFromJSON(m.ratio)",
+		},
+	}
+
+	for _, tt := range tests {
+		f := newField(tt.f, typeRegistry{}, DefaultOptions())
+
+		if f.JSONType != tt.jsonType {
+			t.Errorf("expected JSON type %s, got %s", tt.jsonType, f.JSONType)
+		}
+
+		if actual := stringify(f); actual != tt.stringify {
+			t.Errorf("expected stringify %s, got %s", tt.stringify, actual)
+		}
+
+		if actual := parse(f); actual != tt.parse {
+			t.Errorf("expected parse %s, got %s", tt.parse, actual)
+		}
+	}
+}
+
+func TestStringifyAndParseBytes(t *testing.T) {
+	f := ModelField{Name: "data", Type: "Uint8Array", JSONName: "data", IsBytes: true}
+
+	if expected, actual := "bytesToBase64(m.data)", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := `base64ToBytes(m.data || "")`, parse(f); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	tests := []struct {
+		defaults string
+		f        *descriptor.FieldDescriptorProto
+		expected string
+	}{
+		{DefaultsUndefined, &descriptor.FieldDescriptorProto{Name: proto.String("name"), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum()}, "m.name"},
+		{DefaultsZero, &descriptor.FieldDescriptorProto{Name: proto.String("name"), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum()}, `m.name === undefined ? "" : m.name`},
+		{DefaultsZero, &descriptor.FieldDescriptorProto{Name: proto.String("in_stock"), Type: descriptor.FieldDescriptorProto_TYPE_BOOL.Enum()}, "m.in_stock === undefined ? false : m.in_stock"},
+		{DefaultsZero, &descriptor.FieldDescriptorProto{Name: proto.String("color"), Type: descriptor.FieldDescriptorProto_TYPE_ENUM.Enum(), TypeName: proto.String(".Color")}, "m.color === undefined ? 0 : enumFromJSON<Color>(Color, m.color)"},
+		{DefaultsZero, &descriptor.FieldDescriptorProto{Name: proto.String("count"), Type: descriptor.FieldDescriptorProto_TYPE_INT64.Enum()}, `Number(m.count || "0")`},
+		{DefaultsZero, &descriptor.FieldDescriptorProto{Name: proto.String("hat"), Type: descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".Hat")}, "JSONToHat(m.hat)"},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Defaults = tt.defaults
+
+		if actual := parse(newField(tt.f, typeRegistry{}, opts)); actual != tt.expected {
+			t.Errorf("%s: expected parse %s, got %s", tt.defaults, tt.expected, actual)
+		}
+	}
+}
+
+func TestStringifyAndParseEnums(t *testing.T) {
+	tests := []struct {
+		enums     string
+		label     descriptor.FieldDescriptorProto_Label
+		jsonType  string
+		stringify string
+		parse     string
+	}{
+		{EnumsName, descriptor.FieldDescriptorProto_LABEL_OPTIONAL, "string | number", "Color[m.color]", "enumFromJSON<Color>(Color, m.color)"},
+		{EnumsNumber, descriptor.FieldDescriptorProto_LABEL_OPTIONAL, "string | number", "m.color", "enumFromJSON<Color>(Color, m.color)"},
+		{EnumsName, descriptor.FieldDescriptorProto_LABEL_REPEATED, "(string | number)[]", "m.color.map((n) => Color[n])", "m.color.map((n) => enumFromJSON<Color>(Color, n))"},
+		{EnumsNumber, descriptor.FieldDescriptorProto_LABEL_REPEATED, "(string | number)[]", "m.color", "m.color.map((n) => enumFromJSON<Color>(Color, n))"},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Enums = tt.enums
+
+		f := newField(&descriptor.FieldDescriptorProto{
+			Name:     proto.String("color"),
+			Label:    tt.label.Enum(),
+			Type:     descriptor.FieldDescriptorProto_TYPE_ENUM.Enum(),
+			TypeName: proto.String(".Color"),
+		}, typeRegistry{}, opts)
+
+		if f.JSONType != tt.jsonType {
+			t.Errorf("%s %s: expected JSON type %s, got %s", tt.enums, tt.label, tt.jsonType, f.JSONType)
+		}
+
+		if actual := stringify(f); actual != tt.stringify {
+			t.Errorf("%s %s: expected stringify %s, got %s", tt.enums, tt.label, tt.stringify, actual)
+		}
+
+		if actual := parse(f); actual != tt.parse {
+			t.Errorf("%s %s: expected parse %s, got %s", tt.enums, tt.label, tt.parse, actual)
+		}
+	}
+}
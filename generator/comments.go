@@ -0,0 +1,75 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// field numbers used in the SourceCodeInfo location paths of proto elements, see descriptor.proto
+const (
+	pathMessageType = 4 // FileDescriptorProto.message_type
+	pathEnumType    = 5 // FileDescriptorProto.enum_type
+	pathService     = 6 // FileDescriptorProto.service
+	pathField       = 2 // DescriptorProto.field
+	pathNestedType  = 3 // DescriptorProto.nested_type
+	pathNestedEnum  = 4 // DescriptorProto.enum_type
+	pathOneof       = 8 // DescriptorProto.oneof_decl
+	pathEnumValue   = 2 // EnumDescriptorProto.value
+	pathMethod      = 2 // ServiceDescriptorProto.method
+)
+
+// comments maps the location path of each element of a proto file to its leading comments.
+type comments map[string]string
+
+func newComments(d *descriptor.FileDescriptorProto) comments {
+	c := make(comments)
+
+	for _, l := range d.GetSourceCodeInfo().GetLocation() {
+		if l.LeadingComments == nil {
+			continue
+		}
+
+		c[pathKey(l.GetPath())] = l.GetLeadingComments()
+	}
+
+	return c
+}
+
+// get returns the leading comments of the element at the path, e.g. get([]int32{4, 0}, 2, 1) for the second field of the first message.
+func (c comments) get(path []int32, elem ...int32) string {
+	return c[pathKey(append(append([]int32{}, path...), elem...))]
+}
+
+func pathKey(path []int32) string {
+	return strings.Trim(fmt.Sprint(path), "[]")
+}
+
+// jsdoc formats a comment as a JSDoc block, followed by a newline and the indent of the documented element.
+// An empty comment returns an empty string, so no JSDoc is generated for undocumented elements.
+func jsdoc(comment string, indent string) string {
+	comment = strings.TrimRight(comment, " \n")
+	if comment == "" {
+		return ""
+	}
+
+	// the end of a comment within the text would end the JSDoc block
+	comment = strings.Replace(comment, "*/", "*\\/", -1)
+
+	lines := strings.Split(comment, "\n")
+	if len(lines) == 1 {
+		return fmt.Sprintf("/** %s */\n%s", strings.TrimSpace(lines[0]), indent)
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("/**\n")
+
+	for _, l := range lines {
+		b.WriteString(strings.TrimRight(indent+" * "+strings.TrimPrefix(l, " "), " ") + "\n")
+	}
+
+	b.WriteString(indent + " */\n" + indent)
+
+	return b.String()
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestJSDoc(t *testing.T) {
+	tests := []struct {
+		comment  string
+		expected string
+	}{
+		{"", ""},
+		{" The size of a hat.\n", "/** The size of a hat. */\n    "},
+		{" The color of a hat\n that is */ visible.\n", "/**\n     * The color of a hat\n     * that is *\\/ visible.\n     */\n    "},
+	}
+
+	for _, tt := range tests {
+		if actual := jsdoc(tt.comment, "    "); actual != tt.expected {
+			t.Errorf("expected jsdoc %q, got %q", tt.expected, actual)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	d := &descriptor.FileDescriptorProto{
+		SourceCodeInfo: &descriptor.SourceCodeInfo{
+			Location: []*descriptor.SourceCodeInfo_Location{
+				{Path: []int32{pathMessageType, 0}, LeadingComments: proto.String(" A hat.\n")},
+				{Path: []int32{pathMessageType, 0, pathField, 1}, LeadingComments: proto.String(" Its color.\n")},
+				{Path: []int32{pathMessageType, 0, pathField, 2}, TrailingComments: proto.String(" trailing\n")},
+			},
+		},
+	}
+
+	docs := newComments(d)
+	msg := []int32{pathMessageType, 0}
+
+	if expected, actual := " A hat.\n", docs.get(msg); actual != expected {
+		t.Errorf("expected message comment %q, got %q", expected, actual)
+	}
+
+	if expected, actual := " Its color.\n", docs.get(msg, pathField, 1); actual != expected {
+		t.Errorf("expected field comment %q, got %q", expected, actual)
+	}
+
+	if actual := docs.get(msg, pathField, 2); actual != "" {
+		t.Errorf("expected no comment for a field with only trailing comments, got %q", actual)
+	}
+}
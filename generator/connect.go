@@ -0,0 +1,53 @@
+package generator
+
+// connectRuntime sends the requests and reads the errors of the unary calls of the Connect protocol, which is added
+// to the jsonRuntime with ProtocolConnect. The requests and responses are the same JSON as with Twirp, while the
+// requests have a Connect-Protocol-Version header, and the errors are {code, message, details}.
+const connectRuntime = `
+// createConnectRequest creates the request of a unary call of the Connect protocol, whose body is the JSON of its
+// message.
+export const createConnectRequest = (url: string, body: object, options: CallOptions = {}): TransportRequest => {
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/json",
+            "Connect-Protocol-Version": "1",
+        }),
+        body: JSON.stringify(body),
+        signal: options.signal
+    };
+};
+
+// ConnectErrorJSON is the body of the response of a Connect call that fails, whose code is a Twirp error code too.
+export interface ConnectErrorJSON {
+    code: string;
+    message?: string;
+    details?: {type: string; value: string; debug?: any}[];
+}
+
+// throwConnectError rejects with the TwirpError of the error of a Connect response, whose details are the JSON of
+// its details in meta.details. Errors that are not from a Connect server, e.g. from a proxy, are errors of their
+// HTTP status, as with Twirp.
+export const throwConnectError = (resp: TransportResponse): Promise<never> => {
+    return resp.text().then((body: string) => {
+        let err: ConnectErrorJSON | undefined;
+
+        try {
+            err = JSON.parse(body);
+        } catch (e) {
+            err = undefined;
+        }
+
+        if (!err || typeof err.code !== "string") {
+            throw new TwirpError(intermediaryError(resp.status, body));
+        }
+
+        const meta: {[index:string]: string} = {};
+        if (err.details && err.details.length > 0) {
+            meta.details = JSON.stringify(err.details);
+        }
+
+        throw new TwirpError({code: err.code, msg: err.message || "", meta: meta});
+    });
+};
+`
@@ -0,0 +1,270 @@
+package generator
+
+// declarationTemplate generates a typescript declaration file (.d.ts) with the same exports as apiTemplate,
+// but without any implementations, for clients whose javascript is generated elsewhere.
+const declarationTemplate = `
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider, Any, Duration, BuildWhenSet} from '{{importPath "twirp"}}';
+{{- if .Validates}}
+import {ValidationError} from '{{importPath "twirp"}}';
+{{- end}}
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClient, TwirpClientConfig} from '{{importPath "interceptors"}}';
+{{- if and .Server .Services}}
+import {ServerRequest, TwirpRouter} from '{{importPath "twirp_server"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Enums}}
+{{jsdoc .Comment ""}}export declare enum {{.Name}} {
+    {{range .Values -}}
+    {{jsdoc .Comment "    "}}{{.Name}} = {{.Value}},
+    {{end}}
+}
+{{end}}
+{{range .Models}}
+{{- if not .Primitive}}
+{{- range .Oneofs}}
+{{jsdoc .Comment ""}}export type {{.Type}} =
+    {{- range .Fields}}
+    | {kind: "{{.Name}}"; value: {{.Type}}}
+    {{- end}};
+{{end}}
+{{jsdoc .Comment ""}}export {{if $.Classes}}declare class{{else}}interface{{end}} {{.Name}} {
+    {{range .Fields -}}
+    {{jsdoc .Comment "    "}}{{if .IsReadOnly}}readonly {{end}}{{.Name}}{{if .Optional}}?{{end}}: {{.Type}}{{if .Optional}} | undefined{{end}};
+    {{end -}}
+    {{range .Oneofs -}}
+    {{jsdoc .Comment "    "}}{{.Name}}?: {{.Type}};
+    {{end}}
+    {{- if $.Classes}}
+    constructor(init?: Partial<{{.Name}}>);
+
+    // clone returns a deep copy of the {{.Name}}.
+    clone(): {{.Name}};
+
+    // equals reports if the fields of the {{.Name}} are deeply equal to those of other.
+    equals(other: {{.Name}}): boolean;
+
+    static fromJSON(m: {{.Name}}JSON): {{.Name}};
+
+    // toJSON is also called by JSON.stringify, so a {{.Name}} is stringified as its proto3 JSON.
+    toJSON(): {{.Name}}JSON;
+    {{- end}}
+}
+{{- if .Readonly}}
+{{range .Oneofs}}
+export type Readonly{{.Type}} =
+    {{- range .Fields}}
+    | {readonly kind: "{{.Name}}"; readonly value: {{readonlyType .}}}
+    {{- end}};
+{{end}}
+// Readonly{{.Name}} is the interface of a {{.Name}} returned by the clients, whose fields cannot be changed.
+export interface Readonly{{.Name}} {
+    {{range .Fields -}}
+    {{jsdoc .Comment "    "}}readonly {{.Name}}{{if .Optional}}?{{end}}: {{readonlyType .}}{{if .Optional}} | undefined{{end}};
+    {{end -}}
+    {{range .Oneofs -}}
+    {{jsdoc .Comment "    "}}readonly {{.Name}}?: Readonly{{.Type}};
+    {{end}}
+}
+{{- end}}
+
+// {{.Name}}Fields are the numbers and proto types of the fields of {{.Name}} by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const {{.Name}}Fields: {
+    {{- range fieldNumbers .}}
+    readonly {{.Name}}: {readonly number: {{.Number}}; readonly name: "{{.ProtoName}}"; readonly type: "{{.Type}}"};
+    {{- end}}
+};
+
+export interface {{.Name}}JSON {
+    {{range .Fields -}}
+    {{.JSONName}}{{if .IsOptional}}?{{end}}: {{.JSONType}};
+    {{end -}}
+    {{range .Oneofs}}{{range .Fields -}}
+    {{.JSONName}}?: {{.JSONType}};
+    {{end}}{{end}}
+}
+{{if .CanMarshal}}
+{{- if $.ProtobufMessages}}
+export declare const {{.Name}}ToProtobuf: (m: {{.Name}}) => Uint8Array;
+{{- else}}
+export declare const {{.Name}}ToJSON: (m: {{.Name}}) => {{.Name}}JSON;
+{{- end}}
+{{end -}}
+{{if .CanUnmarshal}}
+{{- if $.ProtobufMessages}}
+export declare const ProtobufTo{{.Name}}: (b: Uint8Array) => {{.Name}};
+{{- else}}
+export declare const JSONTo{{.Name}}: (m: {{.Name}}JSON) => {{.Name}};
+{{- end}}
+{{end -}}
+{{if .Validate}}
+// validate{{.Name}} checks the protoc-gen-validate rules of a {{.Name}}, and returns the violated rules.
+export declare const validate{{.Name}}: (m: {{.Name}}) => ValidationError[];
+{{end -}}
+{{if .Redact}}
+// redact{{.Name}} copies a {{.Name}} for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export declare const redact{{.Name}}: (m: {{.Name}}) => any;
+{{end}}
+// is{{.Name}} reports if a value has the fields of a {{.Name}}, e.g. to check data read from a cache or a websocket.
+export declare const is{{.Name}}: (value: unknown) => value is {{.Name}};
+{{- if $.Builders}}
+
+// create{{.Name}} creates a {{.Name}} whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const create{{.Name}}: (partial?: Partial<{{.Name}}>) => {{.Name}};
+{{- end}}
+{{- if and $.Builders .HasBuilder}}
+{{- $m := .}}
+
+// {{.Name}}Builder builds a {{.Name}} one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface {{.Name}}Builder<Set extends string = never> {
+    {{- range .Fields}}
+    {{methodKey .Name}}(value: {{.Type}}): {{$m.Name}}Builder<Set | "{{.Name}}">;
+    {{- end}}
+    {{- range .Oneofs}}
+    {{methodKey .Name}}(value: {{.Type}}): {{$m.Name}}Builder<Set | "{{.Name}}">;
+    {{- end}}
+    build: BuildWhenSet<{{.RequiredFields}}, Set, {{.Name}}>;
+}
+
+// build{{.Name}} starts a {{.Name}}Builder, e.g. to build a request whose required fields are checked at compile time.
+export declare const build{{.Name}}: () => {{.Name}}Builder;
+{{- end}}
+{{- if $.MergeDiff}}
+
+// merge{{.Name}} copies a {{.Name}} with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the {{.Name}}, while its other fields replace them.
+export declare const merge{{.Name}}: (base: {{.Name}}, patch: Partial<{{.Name}}>) => {{.Name}};
+
+// diff{{.Name}} finds the paths of the fields that differ between two {{.Name}}s, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a {{.Name}}.
+export declare const diff{{.Name}}: (a: {{.Name}}, b: {{.Name}}) => string[];
+{{- end}}
+{{end -}}
+{{end}}
+{{range fieldMasks}}
+// {{.Message}}FieldMaskPath is the path of a field of a {{.Message}} or of its nested messages in a FieldMask.
+export type {{.Message}}FieldMaskPath = {{range $i, $p := .Paths}}{{if $i}} | {{end}}"{{$p}}"{{end}};
+
+// {{.Func}} is a FieldMask of the fields of a {{.Message}}, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export declare const {{.Func}}: (...paths: {{.Message}}FieldMaskPath[]) => string[];
+{{end}}
+{{range $s := .Services}}
+{{jsdoc .Comment ""}}export interface {{.Name}} {
+	{{- range .Methods}}
+    {{jsdoc .Comment "    "}}{{.Name}}: ({{.RequestParam}}callOptions?: CallOptions) => Promise<{{.ResponseType}}>;
+    {{end}}
+}
+
+// {{.Name}}Methods are the Twirp routes of the methods of {{.Name}}, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const {{.Name}}Methods: {
+    {{- range .Methods}}
+    readonly {{.Name}}: {
+        readonly service: "{{$s.FullName}}";
+        readonly method: "{{.Path}}";
+        readonly path: "{{$.TwirpPrefix}}/{{$s.FullName}}/{{.Path}}";
+        readonly inputType: "{{.InputType}}";
+        readonly outputType: "{{.OutputType}}";
+    };
+    {{- end}}
+};
+
+{{- if eq $.ClientStyle "functions"}}
+{{- range .Methods}}
+
+{{jsdoc .Comment ""}}export declare const {{functionName $s .}}: (client: TwirpClient, {{.RequestParam}}callOptions?: CallOptions) => Promise<{{.ResponseType}}>;
+{{- if .HTTP}}
+
+// {{functionName $s .}}Rest calls {{$s.Name}}.{{.Path}} with its REST route, {{.HTTP.Method}} {{.HTTP.Path}}
+export declare const {{functionName $s .}}Rest: (client: TwirpClient, {{.RequestParam}}callOptions?: CallOptions) => Promise<{{.ResponseType}}>;
+{{- end}}
+{{- end}}
+
+// create{{.Name}}Client creates a {{.Name}} of the rpc functions of {{.Name}}, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export declare const create{{.Name}}Client: (client: TwirpClient) => {{.Name}};
+{{- else}}
+
+{{jsdoc .Comment ""}}export declare class Default{{.Name}} implements {{.Name}} {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport{{if ne $.Target "browser"}}?{{end}}: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+{{range .Methods}}
+    {{jsdoc .Comment "    "}}{{.Name}}({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}>;
+    {{- if .HTTP}}
+
+    // {{.Name}}Rest calls {{$s.Name}}.{{.Path}} with its REST route, {{.HTTP.Method}} {{.HTTP.Path}}
+    {{.Name}}Rest({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}>;
+    {{- end}}
+{{- end}}
+}
+
+// create{{.Name}}Client creates a Default{{.Name}} with the config, which may be shared by the clients of other services.
+export declare const create{{.Name}}Client: (config: TwirpClientConfig) => Default{{.Name}};
+{{- end}}
+
+// A {{.Name}}MockResponses sets the response of each {{.Name}}MockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface {{.Name}}MockResponses {
+    {{- range .Methods}}
+    {{.Name}}?: {{if not .EmptyResponse}}{{.ResponseType}} | {{end}}(({{.RequestParam}}callOptions?: CallOptions) => {{.ResponseType}} | Promise<{{.ResponseType}}>);
+    {{- end}}
+}
+
+// {{.Name}}MockClient is a {{.Name}} for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class {{.Name}}MockClient implements {{.Name}} {
+    responses: {{.Name}}MockResponses;
+
+    constructor(responses?: {{.Name}}MockResponses);
+{{range .Methods}}
+    {{.Name}}({{.RequestParam}}callOptions?: CallOptions): Promise<{{.ResponseType}}>;
+{{- end}}
+}
+
+export declare const create{{.Name}}Mock: (overrides?: {{.Name}}MockResponses) => {{.Name}}MockClient;
+{{- if $.Server}}
+
+// {{.Name}}Handler implements the {{.Name}} rpc methods for a server created with create{{.Name}}Router.
+export interface {{.Name}}Handler {
+    {{- range .Methods}}
+    {{- $out := .OutputType}}{{if .EmptyResponse}}{{$out = "void"}}{{end}}
+    {{methodKey .Name}}({{.RequestParam}}req: ServerRequest): {{$out}} | Promise<{{$out}}>;
+    {{- end}}
+}
+
+// create{{.Name}}Router serves the {{.Name}} rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(create{{.Name}}Router(handler))
+export declare const create{{.Name}}Router: (handler: {{.Name}}Handler) => TwirpRouter;
+{{- end}}
+{{end}}
+`
@@ -0,0 +1,64 @@
+package generator
+
+// emptyType is google.protobuf.Empty, which is not generated as a model. The client methods whose input type is
+// Empty have no request argument, and those whose output type is Empty resolve to void, so they do not need its
+// converters.
+const emptyType = ".google.protobuf.Empty"
+
+// RequestParam is the request parameter of a method followed by a comma, e.g. "hat: Hat, ", or empty when its input
+// type is google.protobuf.Empty, so it is followed by the callOptions parameter in the templates.
+func (m ServiceMethod) RequestParam() string {
+	if m.EmptyRequest {
+		return ""
+	}
+
+	return m.InputArg + ": " + m.InputType + ", "
+}
+
+// RequestArg is the request argument of a call of a method followed by a comma, e.g. "hat, ", or empty when its input
+// type is google.protobuf.Empty.
+func (m ServiceMethod) RequestArg() string {
+	if m.EmptyRequest {
+		return ""
+	}
+
+	return m.InputArg + ", "
+}
+
+// requestBody encodes the request of a call of a method for the protocol, e.g. HatToJSON(ctx.request), which is
+// the empty message when its input type is google.protobuf.Empty.
+func (ctx *APIContext) requestBody(m ServiceMethod, request string) string {
+	if m.EmptyRequest && ctx.ProtobufMessages() {
+		return "new Uint8Array(0)"
+	}
+
+	if m.EmptyRequest {
+		return "{}"
+	}
+
+	return ctx.marshalFunc(m.InputType) + "(" + request + ")"
+}
+
+// responseBody is the function that encodes the response of a method for the protocol, e.g. HatToJSON, which returns
+// the empty message when its output type is google.protobuf.Empty.
+func (ctx *APIContext) responseBody(m ServiceMethod) string {
+	if m.EmptyResponse && ctx.ProtobufMessages() {
+		return "() => new Uint8Array(0)"
+	}
+
+	if m.EmptyResponse {
+		return "() => ({})"
+	}
+
+	return ctx.marshalFunc(m.OutputType)
+}
+
+// requestDecoder is the function that decodes the request of a method for the protocol, e.g. JSONToHat, which
+// returns undefined when its input type is google.protobuf.Empty.
+func (ctx *APIContext) requestDecoder(m ServiceMethod) string {
+	if m.EmptyRequest {
+		return "() => undefined"
+	}
+
+	return ctx.unmarshalFunc(m.InputType)
+}
@@ -0,0 +1,323 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// FakesLibrary is the runtime module used by the generated fake factories, see Options.Fakes. The fake values are
+// drawn from a seeded pseudorandom generator, so the same seed always makes the same fakes.
+func FakesLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+// maxDepth is the depth of nested fakes, below which the repeated and map fields are empty, and the optional message
+// fields and oneofs are unset, so the fakes of recursive messages are finite.
+const maxDepth = 3;
+
+let state = 1;
+let depth = 0;
+
+// seedFakes resets the pseudorandom generator of the fakes, e.g. in the beforeEach of a test. The fakes are made
+// with the seed 1 until it is set.
+export const seedFakes = (seed: number): void => {
+    state = seed >>> 0;
+};
+
+// fakeRandom is a mulberry32 pseudorandom number in [0, 1).
+const fakeRandom = (): number => {
+    state = (state + 0x6D2B79F5) >>> 0;
+
+    let t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+};
+
+// nested makes a nested fake one level deeper, or returns empty below maxDepth.
+const nested = <T>(fake: () => T, empty: T): T => {
+    if (depth >= maxDepth) {
+        return empty;
+    }
+
+    depth++;
+    try {
+        return fake();
+    } finally {
+        depth--;
+    }
+};
+
+// fakeInt is an integer in [min, max].
+export const fakeInt = (min: number, max: number): number => {
+    return min + Math.floor(fakeRandom() * (max - min + 1));
+};
+
+// fakeNumber is a number in [0, 1000) with two decimals.
+export const fakeNumber = (): number => {
+    return fakeInt(0, 99999) / 100;
+};
+
+export const fakeBoolean = (): boolean => {
+    return fakeRandom() < 0.5;
+};
+
+// fakeString is the name of a field with a number, e.g. "title-42", which tells the fields apart in a story.
+export const fakeString = (name: string): string => {
+    return name + "-" + fakeInt(1, 9999);
+};
+
+export const fakeBytes = (): Uint8Array => {
+    const b = new Uint8Array(fakeInt(1, 16));
+    for (let i = 0; i < b.length; i++) {
+        b[i] = fakeInt(0, 255);
+    }
+
+    return b;
+};
+
+// fakeDate is a time in 2020, in whole seconds.
+export const fakeDate = (): Date => {
+    return new Date(Date.UTC(2020, 0, 1) + fakeInt(0, 366 * 86400 - 1) * 1000);
+};
+
+// fakeEnum is one of the numbers of the values of an enum.
+export const fakeEnum = (values: number[]): number => {
+    return values[fakeInt(0, values.length - 1)];
+};
+
+// fakeArray is a repeated field of one to three fakes.
+export const fakeArray = <T>(fake: () => T): T[] => {
+    return nested(() => {
+        const items: T[] = [];
+        for (let n = fakeInt(1, 3); n > 0; n--) {
+            items.push(fake());
+        }
+
+        return items;
+    }, []);
+};
+
+// fakeMap is a map field of one to three entries.
+export const fakeMap = <T>(key: () => string, fake: () => T): {[key: string]: T} => {
+    return nested(() => {
+        const entries: {[key: string]: T} = {};
+        for (let n = fakeInt(1, 3); n > 0; n--) {
+            entries[key()] = fake();
+        }
+
+        return entries;
+    }, {});
+};
+
+// fakeOptional is an optional message field, which is set unless it is nested below maxDepth.
+export const fakeOptional = <T>(fake: () => T): T | undefined => {
+    return nested<T | undefined>(fake, undefined);
+};
+
+// fakeOneof is one of the members of a oneof, which is unset when it is nested below maxDepth.
+export const fakeOneof = <T>(members: (() => T)[]): T | undefined => {
+    return nested<T | undefined>(() => members[fakeInt(0, members.length - 1)](), undefined);
+};
+
+// withOverrides copies the overridden fields of a message onto a fake of it.
+export const withOverrides = <T>(fake: T, overrides: Partial<T>): T => {
+    const m: {[key: string]: any} = fake;
+    Object.keys(overrides).forEach((k) => m[k] = (overrides as any)[k]);
+
+    return m as T;
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_fakes.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const fakesTemplate = `
+import {fakeArray, fakeBoolean, fakeBytes, fakeDate, fakeEnum, fakeInt, fakeMap, fakeNumber, fakeOneof, fakeOptional, fakeString, withOverrides} from '{{importPath "twirp_fakes"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// fake{{.Name}} is a fake {{.Name}} message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fake{{.Name}} = (overrides: Partial<{{.Name}}> = {}): {{.Name}} => {
+    return {{if $.Classes}}new {{.Name}}(withOverrides<Partial<{{.Name}}>>{{else}}withOverrides<{{.Name}}>{{end}}({ {{- fakeValues . -}} }, overrides){{if $.Classes}}){{end}};
+};
+{{end}}`
+
+// fakesModuleName is the name of the module of the fake factories of the messages of a module, e.g. service_fakes
+func fakesModuleName(module string) string {
+	return module + "_fakes"
+}
+
+// fakesModule is the module of the fake factories of the messages of a proto file, see renderFakes.
+type fakesModule struct {
+	Classes bool
+	Imports []*Import
+	Models  []*Model
+}
+
+// fakeValues generates the fields of the fake of a model, including one of the members of each oneof.
+func fakeValues(m *Model, enums map[string]*Enum) string {
+	var values []string
+
+	for _, f := range m.Fields {
+		values = append(values, f.Name+": "+fakeValue(f, enums))
+	}
+
+	for _, o := range m.Oneofs {
+		var members []string
+		for _, f := range o.Fields {
+			members = append(members, fmt.Sprintf(`() => ({kind: "%s", value: %s})`, f.Name, fakeValue(f, enums)))
+		}
+
+		values = append(values, fmt.Sprintf("%s: fakeOneof<%s>([%s])", o.Name, o.Type, strings.Join(members, ", ")))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// fakeValue generates the fake value of a field, which is named after the field for strings. Object literals are
+// parenthesized, since they are the bodies of the arrow functions of fakeArray and fakeMap for repeated fields.
+func fakeValue(f ModelField, enums map[string]*Enum) string {
+	switch {
+	case f.IsMap:
+		return fmt.Sprintf("fakeMap(() => %s, () => %s)", fakeKey(f), fakeValue(*f.Value, enums))
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = singularType(f)
+
+		return fmt.Sprintf("fakeArray(() => %s)", fakeValue(item, enums))
+	case f.IsFieldMask:
+		return fmt.Sprintf("fakeArray(() => fakeString(%q))", "path")
+	case f.Type == "Date":
+		return "fakeDate()"
+	case f.IsMessage && f.Optional():
+		return fmt.Sprintf("fakeOptional(() => fake%s())", f.Type)
+	case f.IsMessage:
+		return fmt.Sprintf("fake%s()", f.Type)
+	case f.IsDuration && f.Type == "Duration":
+		return "({seconds: fakeInt(0, 3600), nanos: 0})"
+	case f.IsDuration:
+		return `fakeInt(0, 3600) + "s"`
+	}
+
+	switch f.Codec {
+	case "any":
+		return `({"@type": "type.googleapis.com/google.protobuf.Empty"})`
+	case "struct":
+		return fmt.Sprintf("({%s: fakeString(%q)})", f.Name, f.Name)
+	case "value":
+		return fmt.Sprintf("fakeString(%q)", f.Name)
+	case "listValue":
+		return fmt.Sprintf("fakeArray(() => fakeString(%q))", f.Name)
+	}
+
+	if f.IsEnum {
+		e := enums[singularType(f)]
+		if e == nil {
+			return "0"
+		}
+
+		var numbers []string
+		for _, v := range e.Values {
+			numbers = append(numbers, fmt.Sprint(v.Value))
+		}
+
+		return fmt.Sprintf("fakeEnum([%s])", strings.Join(numbers, ", "))
+	}
+
+	t := wrappedType(f)
+
+	switch {
+	case f.IsBytes:
+		return "fakeBytes()"
+	case f.IsLong && t == Int64BigInt:
+		return "BigInt(fakeInt(0, 1000000))"
+	case f.IsLong && t == Int64String:
+		return "String(fakeInt(0, 1000000))"
+	case f.IsFloat:
+		return "fakeNumber()"
+	case t == "number":
+		return "fakeInt(0, 1000)"
+	case t == "boolean":
+		return "fakeBoolean()"
+	}
+
+	return fmt.Sprintf("fakeString(%q)", f.Name)
+}
+
+// fakeKey generates the fake key of a map field, which is the JSON object key of a key of its proto type.
+func fakeKey(f ModelField) string {
+	switch f.Key.ProtoType {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return fmt.Sprintf("fakeString(%q)", "key")
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "String(fakeBoolean())"
+	}
+
+	return "String(fakeInt(0, 1000))"
+}
+
+// renderFakes generates the fake factories of the messages of the module, e.g. service_fakes.ts, with
+// Options.Fakes. The fakes of the messages of fields that are declared in other modules are imported from their
+// fakes modules.
+func (ctx *APIContext) renderFakes(enums map[string]*Enum) (*plugin.CodeGeneratorResponse_File, error) {
+	module := fakesModule{Classes: ctx.Classes()}
+
+	for _, m := range ctx.Models {
+		if !m.Primitive {
+			module.Models = append(module.Models, m)
+		}
+	}
+
+	module.Imports = ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, m := range module.Models {
+			add(m.Name, m.Name)
+
+			for _, o := range m.Oneofs {
+				add(m.Name, o.Type)
+			}
+		}
+	})
+
+	module.Imports = append(module.Imports, ctx.schemaImports(fakesModuleName, func(name string) []string {
+		return []string{"fake" + name}
+	})...)
+
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"fakeValues": func(m *Model) string {
+			return fakeValues(m, enums)
+		},
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("fakes").Funcs(funcMap).Parse(fakesTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(fakesModuleName(ctx.module) + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
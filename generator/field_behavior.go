@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The google.api.field_behavior option is declared by google/api/field_behavior.proto, whose Go package is not a
+// dependency of the generator, so its values are decoded as the numbers of the FieldBehavior enum.
+// See https://github.com/googleapis/googleapis/blob/master/google/api/field_behavior.proto
+
+var extFieldBehavior = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: ([]int32)(nil),
+	Field:         1052,
+	Name:          "google.api.field_behavior",
+	Tag:           "varint,1052,rep,name=field_behavior",
+}
+
+// values of the FieldBehavior enum that change the typescript type of a field
+const (
+	fieldBehaviorRequired   = 2
+	fieldBehaviorOutputOnly = 3
+	fieldBehaviorImmutable  = 5
+)
+
+// getFieldBehaviors returns the values of the google.api.field_behavior option of a field.
+func getFieldBehaviors(f *descriptor.FieldDescriptorProto) map[int32]bool {
+	behaviors := make(map[int32]bool)
+
+	if f.GetOptions() == nil || !proto.HasExtension(f.GetOptions(), extFieldBehavior) {
+		return behaviors
+	}
+
+	ext, err := proto.GetExtension(f.GetOptions(), extFieldBehavior)
+	if err != nil {
+		return behaviors
+	}
+
+	for _, b := range ext.([]int32) {
+		behaviors[b] = true
+	}
+
+	return behaviors
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"strings"
+)
+
+// FieldMask is the helper of the FieldMasks of the fields of a message, e.g. bookFieldMask("title", "author.name"),
+// which is generated for the messages that are updated by rpc requests with a google.protobuf.FieldMask field, e.g.
+// the book of UpdateBookRequest {Book book; FieldMask update_mask;}, see fieldMasks.
+type FieldMask struct {
+	// Message is the name of the message of the paths
+	Message string
+	// Paths are the paths of the fields of the message and of its nested messages, with their names in the proto file
+	Paths []string
+}
+
+// Func is the name of the function of the helper, e.g. bookFieldMask.
+func (m FieldMask) Func() string {
+	return strings.ToLower(m.Message[0:1]) + m.Message[1:] + "FieldMask"
+}
+
+// fieldMasks are the helpers of the FieldMasks of the message fields of the request models of the module that have a
+// FieldMask field.
+func (ctx *APIContext) fieldMasks() []FieldMask {
+	var masks []FieldMask
+	seen := make(map[string]bool)
+
+	for _, m := range ctx.Models {
+		if !m.Request || !hasFieldMask(m) {
+			continue
+		}
+
+		for _, f := range m.Fields {
+			target, ok := ctx.modelLookup[f.Type]
+			if !ok || !f.IsMessage || f.IsRepeated || f.IsMap || seen[target.Name] {
+				continue
+			}
+
+			seen[target.Name] = true
+			if paths := ctx.maskPaths(target, "", map[string]bool{}); len(paths) > 0 {
+				masks = append(masks, FieldMask{Message: target.Name, Paths: paths})
+			}
+		}
+	}
+
+	return masks
+}
+
+func hasFieldMask(m *Model) bool {
+	for _, f := range m.Fields {
+		if f.IsFieldMask && !f.IsRepeated {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskPaths are the paths of the fields of a model with the prefix, followed by the paths of the fields of its
+// singular message fields. A FieldMask cannot select the fields of the items of repeated and map fields, and the
+// models on the path are not visited again, so the paths of recursive messages are finite.
+func (ctx *APIContext) maskPaths(m *Model, prefix string, visiting map[string]bool) []string {
+	visiting[m.Name] = true
+	defer delete(visiting, m.Name)
+
+	var paths []string
+	var fields []ModelField
+
+	fields = append(fields, m.Fields...)
+	for _, o := range m.Oneofs {
+		fields = append(fields, o.Fields...)
+	}
+
+	for _, f := range fields {
+		path := prefix + f.ProtoName
+		paths = append(paths, path)
+
+		if nested, ok := ctx.modelLookup[f.Type]; ok && f.IsMessage && !f.IsRepeated && !f.IsMap && !visiting[nested.Name] {
+			paths = append(paths, ctx.maskPaths(nested, path+".", visiting)...)
+		}
+	}
+
+	return paths
+}
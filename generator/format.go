@@ -0,0 +1,413 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// namedImportPattern matches the single line named imports of the templates, e.g. import {Hat, Size} from './service';
+var namedImportPattern = regexp.MustCompile(`^import \{([^}]*)\} from ('[^']*');$`)
+
+// identifierTokens matches the identifiers of typescript code, and the words of its comments and strings
+var identifierTokens = regexp.MustCompile(`[A-Za-z_$][A-Za-z0-9_$]*`)
+
+// FormatFiles formats the generated typescript files, like gofmt does for Go, so the output of the templates passes
+// strict lint configs whether or not a feature is used. The JSON files, e.g. package.json, are left unchanged.
+func FormatFiles(files []*plugin.CodeGeneratorResponse_File) {
+	for _, f := range files {
+		if !strings.HasSuffix(f.GetName(), ".ts") {
+			continue
+		}
+
+		f.Content = proto.String(formatTypescript(f.GetContent()))
+	}
+}
+
+// formatTypescript removes the unused names of the imports of a module, and the whitespace that the templates leave
+// where a feature is not used:
+//
+//   - trailing whitespace, and the blank lines at the start and the end of the module
+//   - more than one blank line in a row
+//   - blank lines after the opening and before the closing bracket of a block, and empty blocks are joined, e.g. {}
+//
+// The code is then indented, and the multi-line literals get trailing commas, see indentLines.
+func formatTypescript(content string) string {
+	lines := strings.Split(content, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t\r")
+	}
+
+	lines = removeUnusedImports(lines)
+
+	var out []string
+	for _, l := range lines {
+		blank := l == ""
+		last := ""
+		if len(out) > 0 {
+			last = out[len(out)-1]
+		}
+
+		switch {
+		case blank && (len(out) == 0 || last == "" || opensBlock(last)):
+			continue
+		case closesBlock(l):
+			for len(out) > 0 && out[len(out)-1] == "" {
+				out = out[:len(out)-1]
+			}
+
+			if len(out) > 0 && opensBlock(out[len(out)-1]) && matchingBrackets(out[len(out)-1], strings.TrimSpace(l)) {
+				out[len(out)-1] += strings.TrimSpace(l)
+				continue
+			}
+		}
+
+		out = append(out, l)
+	}
+
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+
+	out = indentLines(out)
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+// opensBlock reports if a line ends with an opening bracket, e.g. export interface Hat {
+func opensBlock(l string) bool {
+	return strings.HasSuffix(l, "{") || strings.HasSuffix(l, "[") || strings.HasSuffix(l, "(")
+}
+
+// closesBlock reports if a line starts with a closing bracket, e.g. };
+func closesBlock(l string) bool {
+	l = strings.TrimSpace(l)
+	return strings.HasPrefix(l, "}") || strings.HasPrefix(l, "]") || strings.HasPrefix(l, ")")
+}
+
+// matchingBrackets reports if the bracket that ends a line is closed by the bracket that starts the next line.
+func matchingBrackets(open, close string) bool {
+	pairs := map[byte]byte{'{': '}', '[': ']', '(': ')'}
+	return pairs[open[len(open)-1]] == close[0]
+}
+
+// removeUnusedImports removes the names of the named imports that are not used by the rest of the module, and the
+// imports without used names. A name that is only used in a comment or a string is kept.
+func removeUnusedImports(lines []string) []string {
+	used := make(map[string]bool)
+	for _, l := range lines {
+		if namedImportPattern.MatchString(l) {
+			continue
+		}
+
+		for _, id := range identifierTokens.FindAllString(l, -1) {
+			used[id] = true
+		}
+	}
+
+	var out []string
+	for _, l := range lines {
+		match := namedImportPattern.FindStringSubmatch(l)
+		if match == nil {
+			out = append(out, l)
+			continue
+		}
+
+		var names []string
+		for _, n := range strings.Split(match[1], ",") {
+			n = strings.TrimSpace(n)
+			if n == "" {
+				continue
+			}
+
+			// import {Hat as HatMessage} is used by its local name
+			local := n
+			if i := strings.LastIndex(n, " as "); i >= 0 {
+				local = strings.TrimSpace(n[i+len(" as "):])
+			}
+
+			if used[local] {
+				names = append(names, n)
+			}
+		}
+
+		if len(names) > 0 {
+			out = append(out, "import {"+strings.Join(names, ", ")+"} from "+match[2]+";")
+		}
+	}
+
+	return out
+}
+
+// indentUnit is the indentation of a level of brackets of the formatted modules.
+const indentUnit = "    "
+
+// continuationPrefixes start the lines that continue the expression of the line before them, which are indented by
+// a level more than it, e.g. && typeof m.color === "string";
+var continuationPrefixes = []string{"&&", "||", "?", ":", ".", "+ ", "| "}
+
+// spreadPrefix starts the lines that spread an iterable or the fields of an object, which are not continuations.
+const spreadPrefix = "..."
+
+// continuationSuffixes end the lines whose expression is continued by the line after them.
+var continuationSuffixes = []string{" =", "=>", "&&", "||", " ?", " +"}
+
+// literalOpener matches the code before the opening bracket of an object or array literal, e.g. return { or = [
+var literalOpener = regexp.MustCompile(`(^|[=(,:\[?]|\breturn|&&|\|\||\benum\s+[A-Za-z_$][A-Za-z0-9_$]*)\s*$`)
+
+// classOpener matches the code before the opening bracket of the body of a class, e.g. export class Hat {
+var classOpener = regexp.MustCompile(`\bclass\b`)
+
+// bracket is an opening bracket of the code of a module, with the indentation level of the line that opens it.
+type bracket struct {
+	level int
+	// literal is an object or array literal, or the members of an enum, whose items are separated by commas
+	literal bool
+	class   bool
+	// cases are the cases of a switch, whose statements are indented by a level more than the cases
+	cases bool
+}
+
+// lexState is the state of the scanner of the code of a module at the end of a line, which is inside of a block
+// comment or a template literal when they continue on the next line.
+type lexState int
+
+const (
+	lexCode lexState = iota
+	lexComment
+	lexTemplate
+)
+
+// scanBrackets finds the brackets of the code of a line, and their offsets, skipping the brackets of its strings,
+// comments and regular expressions.
+func scanBrackets(l string, state lexState) ([]int, lexState) {
+	var offsets []int
+	prev := byte(0)
+
+	for i := 0; i < len(l); i++ {
+		switch state {
+		case lexComment:
+			end := strings.Index(l[i:], "*/")
+			if end < 0 {
+				return offsets, lexComment
+			}
+
+			i += end + 1
+			state = lexCode
+			continue
+		case lexTemplate:
+			for ; i < len(l) && l[i] != '`'; i++ {
+				if l[i] == '\\' {
+					i++
+				}
+			}
+
+			if i >= len(l) {
+				return offsets, lexTemplate
+			}
+
+			state = lexCode
+			prev = 'a'
+			continue
+		}
+
+		c := l[i]
+		switch {
+		case strings.HasPrefix(l[i:], "//"):
+			return offsets, lexCode
+		case strings.HasPrefix(l[i:], "/*"):
+			state = lexComment
+			i++
+			continue
+		case c == '`':
+			state = lexTemplate
+			continue
+		case c == '"' || c == '\'':
+			for i++; i < len(l) && l[i] != c; i++ {
+				if l[i] == '\\' {
+					i++
+				}
+			}
+
+			prev = 'a'
+			continue
+		case c == '/' && (prev == 0 || strings.IndexByte("(,=:[!&|?{};+-*<>~^%", prev) >= 0):
+			// a regular expression, e.g. /[^a-z]/g
+			class := false
+			for i++; i < len(l) && (l[i] != '/' || class); i++ {
+				switch l[i] {
+				case '\\':
+					i++
+				case '[':
+					class = true
+				case ']':
+					class = false
+				}
+			}
+
+			prev = 'a'
+			continue
+		case strings.IndexByte("{[()]}", c) >= 0:
+			offsets = append(offsets, i)
+		}
+
+		if c != ' ' && c != '\t' {
+			prev = c
+		}
+	}
+
+	return offsets, state
+}
+
+// indentLines normalizes the code of the lines of a module like prettier does:
+//
+//   - the lines are indented by 4 spaces for each level of the brackets that are open at their start, and by a
+//     level more when they continue the expression of the line before them or are the statements of a case
+//   - the last item of a multi-line object or array literal, or enum, has a trailing comma
+//   - the multi-line members of a class are followed by a blank line
+//
+// The lines of block comments are aligned with the code, and the lines of multi-line template literals are left
+// unchanged.
+func indentLines(lines []string) []string {
+	var (
+		out        []string
+		stack      []bracket
+		state      lexState
+		memberEnds bool
+		lastCode   string
+	)
+
+	for _, l := range lines {
+		start := state
+		code := strings.TrimLeft(l, " \t")
+		switch {
+		case start == lexTemplate:
+			// the brackets after the end of a template literal are still scanned
+			code = l
+		case code == "":
+			out = append(out, "")
+			memberEnds = false
+			continue
+		case memberEnds && !closesBlock(code):
+			out = append(out, "")
+		}
+
+		memberEnds = false
+
+		offsets, end := scanBrackets(code, start)
+		state = end
+
+		closers := 0
+		for closers < len(code) && strings.IndexByte(")]}", code[closers]) >= 0 {
+			closers++
+		}
+
+		if start != lexCode {
+			closers = 0
+		}
+
+		level := 0
+		switch {
+		case closers > 0 && closers <= len(stack):
+			level = stack[len(stack)-closers].level
+		case closers > 0:
+		case len(stack) > 0:
+			top := stack[len(stack)-1]
+			level = top.level + 1
+			if top.cases && !strings.HasPrefix(code, "case ") && !strings.HasPrefix(code, "default:") {
+				level++
+			}
+		}
+
+		if closers == 0 && start == lexCode && lastCode != "" && continues(lastCode, code) {
+			level++
+		}
+
+		indent := strings.Repeat(indentUnit, level)
+		if start == lexComment && strings.HasPrefix(code, "*") {
+			indent += " "
+		}
+
+		for _, i := range offsets {
+			switch c := code[i]; c {
+			case '{', '[', '(':
+				before := code[:i]
+				if strings.TrimSpace(before) == "" && i == 0 && lastCode != "" {
+					before = lastCode
+				}
+
+				stack = append(stack, bracket{
+					level:   level,
+					literal: c != '(' && literalOpener.MatchString(strings.TrimRight(before, " ")),
+					class:   c == '{' && classOpener.MatchString(before),
+					cases:   c == '{' && strings.HasPrefix(code, "switch "),
+				})
+			default:
+				if len(stack) == 0 {
+					continue
+				}
+
+				closed := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+
+				if i < closers && closed.literal {
+					addTrailingComma(out)
+				}
+
+				if i < closers && len(stack) > 0 && stack[len(stack)-1].class {
+					memberEnds = true
+				}
+			}
+		}
+
+		if start == lexTemplate {
+			out = append(out, l)
+			continue
+		}
+
+		out = append(out, indent+code)
+		if start == lexCode && !strings.HasPrefix(code, "//") && !strings.HasPrefix(code, "/*") {
+			lastCode = code
+		}
+	}
+
+	return out
+}
+
+// continues reports if a line continues the expression of the code line before it.
+func continues(last, code string) bool {
+	for _, p := range continuationPrefixes {
+		if strings.HasPrefix(code, p) && !strings.HasPrefix(code, spreadPrefix) {
+			return true
+		}
+	}
+
+	for _, s := range continuationSuffixes {
+		if strings.HasSuffix(last, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addTrailingComma adds a comma to the last item of a multi-line literal, which is the last line of code before
+// the line that closes the literal, unless it is opened on that line or already ends with a comma or a semicolon.
+func addTrailingComma(out []string) {
+	for i := len(out) - 1; i >= 0; i-- {
+		code := strings.TrimSpace(out[i])
+		if code == "" || strings.HasPrefix(code, "//") || strings.HasPrefix(code, "*") || strings.HasPrefix(code, "/*") {
+			continue
+		}
+
+		// the rest element of a destructuring pattern can not have a trailing comma
+		if strings.Contains(code, "//") || strings.HasPrefix(code, spreadPrefix) || strings.HasSuffix(code, ",") || strings.HasSuffix(code, ";") || opensBlock(code) {
+			return
+		}
+
+		out[i] += ","
+		return
+	}
+}
@@ -2,19 +2,166 @@ package generator
 
 import (
 	"path"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 )
 
-func tsModuleFilename(f *descriptor.FileDescriptorProto) string {
-	name := *f.Name
+// runtimeModules are the modules of the runtime library, which are imported by the generated modules.
+var runtimeModules = map[string]bool{
+	"twirp":               true,
+	"interceptors":        true,
+	"transports":          true,
+	"twirp_server":        true,
+	"twirp_react":         true,
+	"twirp_query":         true,
+	"twirp_angular":       true,
+	"twirp_rest":          true,
+	"twirp_msw":           true,
+	"twirp_pact":          true,
+	"twirp_fakes":         true,
+	"twirp_reflection":    true,
+	"twirp_subscriptions": true,
+	"twirp_pagination":    true,
+	"twirp_cache":         true,
+}
 
-	if ext := path.Ext(name); ext == ".proto" || ext == ".protodevel" {
-		base := path.Base(name)
-		name = base[:len(base)-len(path.Ext(base))]
+// RuntimeLibraries generates the runtime modules that are imported by the generated modules. The runtime is
+// generated once for all of the proto files, or not at all when it is imported from opts.RuntimePackage.
+func RuntimeLibraries(opts Options) []*plugin.CodeGeneratorResponse_File {
+	if opts.RuntimePackage != "" {
+		return nil
+	}
+
+	files := []*plugin.CodeGeneratorResponse_File{RuntimeLibrary(opts.Protocol)}
+
+	// the clients are the only users of the interceptors and transports
+	if !opts.ModelsOnly {
+		files = append(files, InterceptorLibrary(), TransportLibrary(opts.Target))
+	}
+
+	if opts.Server {
+		files = append(files, ServerLibrary(opts.Protocol))
 	}
 
-	name += ".ts"
+	if opts.ReactHooks {
+		files = append(files, ReactLibrary())
+	}
+
+	if opts.TanStackQuery {
+		files = append(files, QueryLibrary())
+	}
+
+	if opts.Angular {
+		files = append(files, AngularLibrary())
+	}
+
+	if opts.MSW {
+		files = append(files, MSWLibrary(opts.Protocol))
+	}
+
+	if opts.Pact {
+		files = append(files, PactLibrary())
+	}
+
+	if opts.Fakes {
+		files = append(files, FakesLibrary())
+	}
+
+	if opts.Reflection {
+		files = append(files, ReflectionLibrary())
+	}
+
+	if opts.Pagination {
+		files = append(files, PaginationLibrary())
+	}
+
+	if opts.Cache {
+		files = append(files, CacheLibrary())
+	}
+
+	if opts.Subscriptions != SubscriptionsNone {
+		files = append(files, SubscriptionsLibrary(opts.Subscriptions))
+	}
+
+	if opts.REST {
+		files = append(files, RESTLibrary())
+	}
+
+	if opts.Target == TargetDeno {
+		for _, f := range files {
+			f.Content = proto.String(denoImports(f.GetContent()))
+		}
+	}
+
+	return files
+}
+
+// runtimeImportPath is the path used to import a runtime module, which is a module of opts.RuntimePackage
+// when it is set, e.g. @acme/twirp-runtime/twirp. With TargetDeno, the relative paths have the .ts extension,
+// e.g. ./twirp.ts
+func runtimeImportPath(from string, module string, opts Options) string {
+	if runtimeModules[module] && opts.RuntimePackage != "" {
+		return opts.RuntimePackage + "/" + module
+	}
+
+	if opts.Target == TargetDeno {
+		return importPath(from, module) + ".ts"
+	}
+
+	return importPath(from, module)
+}
+
+// relativeImport matches the relative imports of the runtime modules, e.g. from './twirp';
+var relativeImport = regexp.MustCompile(`from '(\./[\w/]+)';`)
+
+// denoImports adds the .ts extension to the relative imports of a runtime module, e.g. from './twirp.ts';
+func denoImports(content string) string {
+	return relativeImport.ReplaceAllString(content, "from '$1.ts';")
+}
+
+// tsModuleName is the name used to import the module generated for a proto file, e.g. ./service
+// With PathsSourceRelative, the directory of the proto file is kept, e.g. ./example/service
+// The name set by an M parameter is used as is, see Options.ModuleNames.
+func tsModuleName(f *descriptor.FileDescriptorProto, opts Options) string {
+	name := f.GetName()
+
+	if module, ok := opts.ModuleNames[name]; ok {
+		return module
+	}
+
+	if ext := path.Ext(name); ext == ".proto" || ext == ".protodevel" {
+		if opts.Paths != PathsSourceRelative {
+			name = path.Base(name)
+		}
+		name = name[:len(name)-len(ext)]
+	}
 
 	return name
 }
+
+// importPath is the relative path used to import a module from another module, e.g. ../common/page
+func importPath(from string, to string) string {
+	var dir []string
+	if d := path.Dir(from); d != "." {
+		dir = strings.Split(d, "/")
+	}
+
+	target := strings.Split(to, "/")
+
+	i := 0
+	for i < len(dir) && i < len(target)-1 && dir[i] == target[i] {
+		i++
+	}
+
+	rel := strings.Repeat("../", len(dir)-i) + strings.Join(target[i:], "/")
+	if !strings.HasPrefix(rel, "../") {
+		rel = "./" + rel
+	}
+
+	return rel
+}
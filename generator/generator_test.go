@@ -0,0 +1,395 @@
+package generator
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+func TestImportPath(t *testing.T) {
+	tests := []struct {
+		from     string
+		to       string
+		expected string
+	}{
+		{"service", "twirp", "./twirp"},
+		{"example/service", "twirp", "../twirp"},
+		{"example/service", "example/common", "./common"},
+		{"a/b/service", "a/c/common", "../c/common"},
+		{"service", "example/common", "./example/common"},
+	}
+
+	for _, tt := range tests {
+		if actual := importPath(tt.from, tt.to); actual != tt.expected {
+			t.Errorf("expected import path from %s to %s to be %s, got %s", tt.from, tt.to, tt.expected, actual)
+		}
+	}
+}
+
+func TestRuntimeLibraries(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Server = true
+
+	var names []string
+	for _, f := range RuntimeLibraries(opts) {
+		names = append(names, f.GetName())
+	}
+
+	if expected := "twirp.ts, interceptors.ts, transports.ts, twirp_server.ts"; strings.Join(names, ", ") != expected {
+		t.Errorf("expected runtime modules %s, got %s", expected, strings.Join(names, ", "))
+	}
+
+	models := DefaultOptions()
+	models.ModelsOnly = true
+	if files := RuntimeLibraries(models); len(files) != 1 || files[0].GetName() != "twirp.ts" {
+		t.Errorf("expected only twirp.ts with models_only, got %d runtime modules", len(files))
+	}
+
+	opts.RuntimePackage = "@acme/twirp-runtime"
+	if files := RuntimeLibraries(opts); len(files) != 0 {
+		t.Errorf("expected no runtime modules with a runtime package, got %d", len(files))
+	}
+
+	if expected, actual := "@acme/twirp-runtime/interceptors", runtimeImportPath("example/service", "interceptors", opts); actual != expected {
+		t.Errorf("expected runtime import path %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "./common", runtimeImportPath("example/service", "example/common", opts); actual != expected {
+		t.Errorf("expected import path %s, got %s", expected, actual)
+	}
+}
+
+func TestRuntimeLibraries_Deno(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Target = TargetDeno
+
+	for _, f := range RuntimeLibraries(opts) {
+		if strings.Contains(f.GetContent(), "from './twirp';") {
+			t.Errorf("expected %s to import ./twirp.ts, got:\n%s", f.GetName(), f.GetContent())
+		}
+
+		if strings.Contains(f.GetContent(), "from 'http'") {
+			t.Errorf("expected %s to have no Node imports", f.GetName())
+		}
+	}
+}
+
+func TestCreatePackageIndex(t *testing.T) {
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("twirp.ts")},
+		{Name: proto.String("example/service.ts")},
+		{Name: proto.String("tsconfig.json")},
+	}
+
+	idx, err := CreatePackageIndex(files, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"export * from './example/service';", "export * from './twirp';"} {
+		if !strings.Contains(idx.GetContent(), expected) {
+			t.Errorf("expected index.ts to contain %q, got:\n%s", expected, idx.GetContent())
+		}
+	}
+
+	if strings.Contains(idx.GetContent(), "tsconfig") {
+		t.Errorf("expected index.ts to only export typescript modules, got:\n%s", idx.GetContent())
+	}
+}
+
+func TestCreatePackageIndex_Namespaces(t *testing.T) {
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("twirp.ts")},
+		{Name: proto.String("clients/api.ts")},
+	}
+
+	opts, err := ParseOptions("package_name=api,Mapi/v1/service.proto=clients/api,Napi/v1/service.proto=Api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := CreatePackageIndex(files, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{"import * as Api from './clients/api';\nexport {Api};\n", "export * from './twirp';"} {
+		if !strings.Contains(idx.GetContent(), expected) {
+			t.Errorf("expected index.ts to contain %q, got:\n%s", expected, idx.GetContent())
+		}
+	}
+}
+
+func TestCreatePackageIndex_Sorted(t *testing.T) {
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("twirp.ts")},
+		{Name: proto.String("example/service.ts")},
+		{Name: proto.String("interceptors.ts")},
+	}
+
+	idx, err := CreatePackageIndex(files, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	service := strings.Index(idx.GetContent(), "./example/service")
+	interceptors := strings.Index(idx.GetContent(), "./interceptors")
+	twirp := strings.Index(idx.GetContent(), "./twirp")
+
+	if !(service < interceptors && interceptors < twirp) {
+		t.Errorf("expected index.ts to export the modules in sorted order, got:\n%s", idx.GetContent())
+	}
+}
+
+func TestCreateBarrels(t *testing.T) {
+	packages := map[string][]string{
+		"acme.users.v1": {"users_admin", "users"},
+		"acme.delete":   {"delete"},
+		"":              {"root"},
+	}
+
+	files, err := createBarrels(packages, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	indexes := make(map[string]string)
+	for _, f := range files {
+		indexes[f.GetName()] = f.GetContent()
+	}
+
+	for name, expected := range map[string][]string{
+		"index.ts":               {"import * as acme from './acme/index';", "export * from './root';", "export * from './twirp';"},
+		"acme/index.ts":          {"import * as delete_ from './delete/index';\nexport {delete_};", "import * as users from './users/index';"},
+		"acme/delete/index.ts":   {"export * from '../../delete';"},
+		"acme/users/index.ts":    {"import * as v1 from './v1/index';"},
+		"acme/users/v1/index.ts": {"export * from '../../../users';\n\nexport * from '../../../users_admin';"},
+	} {
+		content, ok := indexes[name]
+		if !ok {
+			t.Errorf("expected %s to be generated", name)
+			continue
+		}
+
+		for _, e := range expected {
+			if !strings.Contains(content, e) {
+				t.Errorf("expected %s to contain %q, got:\n%s", name, e, content)
+			}
+		}
+	}
+
+	if len(files) != 5 {
+		t.Errorf("expected 5 barrels, got %d", len(files))
+	}
+}
+
+// TestCreateClientAPIs_Deterministic generates the same files byte for byte, whatever the order of the files in
+// the request, so regenerating the code does not change it unless the protos change.
+func TestCreateClientAPIs_Deterministic(t *testing.T) {
+	files := readDescriptorSet(t, filepath.Join("testdata", "imports.pb"))
+
+	opts, err := ParseOptions("service_modules=true,server=true,react_hooks=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	render := func(files []*descriptor.FileDescriptorProto) string {
+		out, err := CreateClientAPIs(files, fileNames(files), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var b strings.Builder
+		for _, f := range out {
+			b.WriteString(f.GetName() + "\n" + f.GetContent())
+		}
+
+		return b.String()
+	}
+
+	expected := render(files)
+
+	for i := 0; i < 10; i++ {
+		if actual := render(files); actual != expected {
+			t.Fatalf("expected the same output on every run, got a diff on run %d", i+2)
+		}
+	}
+
+	reversed := make([]*descriptor.FileDescriptorProto, len(files))
+	for i, f := range files {
+		reversed[len(files)-1-i] = f
+	}
+
+	if actual := render(reversed); actual != expected {
+		t.Errorf("expected the same output when the files are in a different order")
+	}
+}
+
+func TestAddBanners(t *testing.T) {
+	license := filepath.Join(t.TempDir(), "LICENSE_HEADER.txt")
+	if err := ioutil.WriteFile(license, []byte("Copyright Example Corp.\r\n\r\nLicensed under the MIT License.\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := ParseOptions("license_file=" + license)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts.PluginVersion = "1a2b3c4"
+	opts.CompilerVersion = CompilerVersion(&plugin.Version{Major: proto.Int32(3), Minor: proto.Int32(21), Patch: proto.Int32(12), Suffix: proto.String("rc1")})
+
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("service.ts"), Content: proto.String("\nimport {Transport} from './twirp';\n")},
+		{Name: proto.String("package.json"), Content: proto.String("{}\n")},
+	}
+
+	if err := AddBanners(files, "example/service.proto", opts); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `// Code generated by protoc-gen-twirp_typescript. DO NOT EDIT.
+// versions:
+//   protoc-gen-twirp_typescript 1a2b3c4
+//   protoc v3.21.12-rc1
+// source: example/service.proto
+//
+// Copyright Example Corp.
+//
+// Licensed under the MIT License.
+
+import {Transport} from './twirp';
+`
+	if files[0].GetContent() != expected {
+		t.Errorf("expected service.ts to be:\n%s\ngot:\n%s", expected, files[0].GetContent())
+	}
+
+	if files[1].GetContent() != "{}\n" {
+		t.Errorf("expected package.json to have no banner, got:\n%s", files[1].GetContent())
+	}
+
+	opts.LicenseFile = filepath.Join(t.TempDir(), "missing.txt")
+	if err := AddBanners(files, "", opts); err == nil || !strings.Contains(err.Error(), "license_file") {
+		t.Errorf("expected a license_file error for a missing license file, got %v", err)
+	}
+}
+
+func TestFormatFiles(t *testing.T) {
+	content := `
+import {Hat, Size, HatToJSON} from './service';
+import {TwirpError as Error, Transport} from './twirp';
+
+
+export interface Empty {
+    
+}
+
+export const make = (size: Size): Hat => {
+
+    const hats: Hat[] = [
+    ];
+
+    throw new Error({code: "unimplemented", msg: ""});   
+};
+
+
+`
+	expected := `import {Hat, Size} from './service';
+import {TwirpError as Error} from './twirp';
+
+export interface Empty {}
+
+export const make = (size: Size): Hat => {
+    const hats: Hat[] = [];
+
+    throw new Error({code: "unimplemented", msg: ""});
+};
+`
+
+	files := []*plugin.CodeGeneratorResponse_File{
+		{Name: proto.String("service.ts"), Content: proto.String(content)},
+		{Name: proto.String("schema.json"), Content: proto.String("{\n\n}\n")},
+	}
+
+	FormatFiles(files)
+
+	if files[0].GetContent() != expected {
+		t.Errorf("expected service.ts to be formatted as:\n%s\ngot:\n%s", expected, files[0].GetContent())
+	}
+
+	if files[1].GetContent() != "{\n\n}\n" {
+		t.Errorf("expected schema.json to be unchanged, got:\n%s", files[1].GetContent())
+	}
+}
+
+func TestFormatFiles_Indentation(t *testing.T) {
+	content := `/**
+* A client of the Haberdasher service.
+*/
+export class HaberdasherClient {
+  private timeoutMs?: number;
+  timeout(ms: number): this {
+    this.timeoutMs = ms;
+    return this;
+  }
+  makeHat(size: Size): Promise<Hat> {
+  const headers = {
+  "Content-Type": "application/json",
+  "X-Brackets": "{[("
+  };
+  const valid = size.inches > 0
+  && size.inches < 100;
+  switch (size.unit) {
+  case "cm":
+  return this.call(headers, [
+  size.inches
+  ]);
+  default:
+  return Promise.reject(` + "`" + `invalid
+  unit ${size.unit}` + "`" + `);
+  }
+  }
+}
+`
+	expected := `/**
+ * A client of the Haberdasher service.
+ */
+export class HaberdasherClient {
+    private timeoutMs?: number;
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    makeHat(size: Size): Promise<Hat> {
+        const headers = {
+            "Content-Type": "application/json",
+            "X-Brackets": "{[(",
+        };
+        const valid = size.inches > 0
+            && size.inches < 100;
+        switch (size.unit) {
+            case "cm":
+                return this.call(headers, [
+                    size.inches,
+                ]);
+            default:
+                return Promise.reject(` + "`" + `invalid
+  unit ${size.unit}` + "`" + `);
+        }
+    }
+}
+`
+
+	files := []*plugin.CodeGeneratorResponse_File{{Name: proto.String("service.ts"), Content: proto.String(content)}}
+
+	FormatFiles(files)
+
+	if files[0].GetContent() != expected {
+		t.Errorf("expected service.ts to be indented as:\n%s\ngot:\n%s", expected, files[0].GetContent())
+	}
+}
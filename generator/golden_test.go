@@ -0,0 +1,314 @@
+package generator
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata/golden")
+
+// goldenTests generate the protos in testdata from their descriptor sets, which are compiled with:
+//
+//	protoc --include_imports --include_source_info -I testdata -o testdata/<proto>.pb testdata/<proto>.proto
+//
+// The generated modules are compared to the golden files in testdata/golden/<name>. After changing the
+// generated code, run `go test ./generator -run TestGolden -update` and review the diff of the golden files.
+var goldenTests = []struct {
+	name   string
+	proto  string
+	params string
+}{
+	{"haberdasher", "haberdasher", ""},
+	{"haberdasher_protobuf", "haberdasher", "protocol=protobuf"},
+	{"haberdasher_grpcweb", "haberdasher", "protocol=grpcweb"},
+	{"haberdasher_connect", "haberdasher", "protocol=connect"},
+	{"haberdasher_runtime_package", "haberdasher", "runtime_package=@acme/twirp-runtime"},
+	{"haberdasher_react_hooks", "haberdasher", "react_hooks=true"},
+	{"imports_react_hooks", "imports", "react_hooks=true,service_modules=true"},
+	{"haberdasher_tanstack_query", "haberdasher", "tanstack_query=true"},
+	{"haberdasher_angular", "haberdasher", "angular=true"},
+	{"haberdasher_node", "haberdasher", "target=node"},
+	{"imports_node_declaration_only", "imports", "target=node,declaration_only=true"},
+	{"imports_deno", "imports", "target=deno,service_modules=true"},
+	{"imports_angular_declaration_only", "imports", "angular=true,declaration_only=true,service_modules=true"},
+	{"imports_tanstack_query_declaration_only", "imports", "tanstack_query=true,declaration_only=true"},
+	{"features", "features", ""},
+	{"features_protobuf", "features", "protocol=protobuf,int64=bigint,defaults=zero"},
+	{"features_underscore", "features", "nested_names=underscore"},
+	{"features_declaration_only", "features", "declaration_only=true"},
+	{"features_enum_numbers", "features", "enums=number"},
+	{"features_readonly_responses", "features", "readonly_responses=true,react_hooks=true"},
+	{"features_classes", "features", "models=classes,readonly_responses=true"},
+	{"features_classes_declaration_only", "features", "models=classes,declaration_only=true"},
+	{"imports_classes", "imports", "models=classes,service_modules=true"},
+	{"imports_readonly_responses", "imports", "readonly_responses=true,service_modules=true,declaration_only=true"},
+	{"features_json_schema", "features", "json_schema=true"},
+	{"features_openapi", "features", "openapi=true"},
+	{"features_merge_diff", "features", "merge_diff=true"},
+	{"features_merge_diff_declaration_only", "features", "merge_diff=true,declaration_only=true"},
+	{"imports_merge_diff", "imports", "merge_diff=true,models=classes"},
+	{"imports_openapi", "imports", "openapi=true,protocol=connect,declaration_only=true"},
+	{"features_zod", "features", "zod=true"},
+	{"imports_zod", "imports", "zod=true,service_modules=true"},
+	{"features_io_ts", "features", "io_ts=true"},
+	{"imports_io_ts", "imports", "io_ts=true,service_modules=true"},
+	{"features_protobufjs", "features", "interop=protobufjs"},
+	{"features_json_names_camel", "features", "json_names=camel,zod=true"},
+	{"rest_json_names_camel", "rest", "rest=true,json_names=camel"},
+	{"features_field_names_proto", "features", "field_names=proto,validate=true"},
+	{"features_int64_bigint", "features", "int64=bigint,zod=true"},
+	{"haberdasher_msw", "haberdasher", "msw=true"},
+	{"haberdasher_msw_protobuf", "haberdasher", "msw=true,protocol=protobuf,readonly_responses=true"},
+	{"imports_msw", "imports", "msw=true,service_modules=true"},
+	{"imports_msw_declaration_only", "imports", "msw=true,declaration_only=true"},
+	{"features_pact", "features", "pact=true,duration=object"},
+	{"imports_pact", "imports", "pact=true,readonly_responses=true"},
+	{"wkt_pact", "wkt", "pact=true,models=classes"},
+	{"features_builders", "features", "builders=true,int64=bigint"},
+	{"features_classes_builders", "features", "builders=true,models=classes,duration=object"},
+	{"imports_builders", "imports", "builders=true,service_modules=true"},
+	{"imports_builders_declaration_only", "imports", "builders=true,declaration_only=true"},
+	{"wkt_builders", "wkt", "builders=true,models_only=true"},
+	{"field_behavior_builders", "field_behavior", "builders=true"},
+	{"field_behavior_builders_declaration_only", "field_behavior", "builders=true,declaration_only=true"},
+	{"validated_builders", "validated", "builders=true,validate=true"},
+	{"features_reflection", "features", "reflection=true,json_names=camel"},
+	{"imports_reflection_declaration_only", "imports", "reflection=true,declaration_only=true"},
+	{"features_fakes", "features", "fakes=true,int64=bigint"},
+	{"imports_fakes", "imports", "fakes=true"},
+	{"wkt_fakes", "wkt", "fakes=true,models=classes,duration=object"},
+	{"repeated_fakes", "repeated", "fakes=true,duration=object"},
+	{"haberdasher_banner", "haberdasher", "banner=true,zod=true"},
+	{"haberdasher_functions", "haberdasher", "client_style=functions"},
+	{"rest_functions", "rest", "client_style=functions,rest=true,validate=true"},
+	{"imports_functions_declaration_only", "imports", "client_style=functions,declaration_only=true"},
+	{"haberdasher_models_only", "haberdasher", "models_only=true"},
+	{"imports_models_only_declaration_only", "imports", "models_only=true,declaration_only=true"},
+	{"features_models_only_protobuf", "features", "models_only=true,protocol=protobuf"},
+	{"subscriptions_sse", "subscriptions", "subscriptions=sse,int64=string"},
+	{"subscriptions_websocket", "subscriptions", "subscriptions=websocket,service_modules=true,readonly_responses=true"},
+	{"subscriptions", "subscriptions", ""},
+	{"pagination", "pagination", "pagination=true"},
+	{"pagination_field_names_proto", "pagination", "pagination=true,field_names=proto,readonly_responses=true,client_style=functions"},
+	{"pagination_declaration_only", "pagination", "pagination=true,declaration_only=true"},
+	{"sensitive", "sensitive", ""},
+	{"sensitive_functions", "sensitive", "client_style=functions,field_names=proto,protocol=protobuf"},
+	{"sensitive_declaration_only", "sensitive", "declaration_only=true"},
+	{"idempotency", "idempotency", ""},
+	{"idempotency_functions", "idempotency", "client_style=functions,rest=true"},
+	{"idempotency_protobuf", "idempotency", "protocol=protobuf"},
+	{"idempotency_declaration_only", "idempotency", "declaration_only=true"},
+	{"idempotency_cache", "idempotency", "cache=true,int64=bigint"},
+	{"idempotency_cache_declaration_only", "idempotency", "cache=true,declaration_only=true,readonly_responses=true"},
+	{"empty", "empty", ""},
+	{"empty_protobuf", "empty", "protocol=protobuf,server=true,msw=true"},
+	{"empty_helpers", "empty", "react_hooks=true,tanstack_query=true,angular=true,cache=true,pact=true,subscriptions=sse,msw=true"},
+	{"empty_functions", "empty", "client_style=functions,server=true"},
+	{"empty_grpcweb", "empty", "protocol=grpcweb,client_style=functions"},
+	{"empty_connect", "empty", "protocol=connect,client_style=functions"},
+	{"empty_declaration_only", "empty", "declaration_only=true,server=true,react_hooks=true,tanstack_query=true,cache=true,angular=true"},
+	{"rest_int64_string", "rest", "rest=true,int64=string"},
+	{"wkt_protobufjs", "wkt", "interop=protobufjs"},
+	{"features_protobuf_ts", "features", "interop=protobuf-ts"},
+	{"haberdasher_protobuf_ts_protobuf", "haberdasher", "interop=protobuf-ts,protocol=protobuf"},
+	{"validated", "validated", ""},
+	{"validated_client", "validated", "validate=true,int64=bigint,service_modules=true"},
+	{"validated_declaration_only", "validated", "validate=true,declaration_only=true"},
+	{"rest", "rest", "rest=true"},
+	{"rest_declaration_only", "rest", "rest=true,declaration_only=true"},
+	{"field_behavior", "field_behavior", ""},
+	{"field_behavior_protobuf", "field_behavior", "protocol=protobuf,server=true"},
+	{"field_behavior_declaration_only", "field_behavior", "declaration_only=true"},
+	{"field_behavior_json_schema", "field_behavior", "json_schema=true,declaration_only=true"},
+	{"wkt", "wkt", ""},
+	{"wkt_protobuf", "wkt", "protocol=protobuf,duration=object"},
+	{"wkt_declaration_only", "wkt", "declaration_only=true"},
+	{"imports", "imports", ""},
+	{"imports_service_modules", "imports", "service_modules=true,server=true"},
+	{"imports_barrels", "imports", "barrels=true,service_modules=true"},
+	{"imports_barrels_declaration_only", "imports", "barrels=true,declaration_only=true,banner=true"},
+	{"imports_declaration_only", "imports", "declaration_only=true,protocol=protobuf,server=true"},
+	{"haberdasher_method_case_pascal", "haberdasher", "method_case=pascal,react_hooks=true,tanstack_query=true,msw=true,server=true"},
+	{"haberdasher_method_case_original", "haberdasher", "method_case=original,client_style=functions,declaration_only=true"},
+	{"keywords", "keywords", "builders=true,server=true"},
+	{"keywords_functions", "keywords", "client_style=functions,builders=true,react_hooks=true,tanstack_query=true,declaration_only=true"},
+	{"keywords_functions_deno", "keywords", "client_style=functions,builders=true,target=deno"},
+	{"keywords_angular", "keywords", "angular=true,react_hooks=true"},
+	{"proto2", "proto2", ""},
+	{"proto2_protobuf", "proto2", "protocol=protobuf,int64=bigint,defaults=zero"},
+	{"proto2_classes", "proto2", "models=classes,int64=string"},
+	{"proto2_builders", "proto2", "builders=true,json_schema=true,declaration_only=true"},
+}
+
+// runtimeGoldens are the goldenTests whose golden files include the runtime libraries of their options, e.g.
+// twirp.ts, interceptors.ts and transports.ts, so each runtime library is covered by at least one of them.
+var runtimeGoldens = map[string]bool{
+	"haberdasher":             true,
+	"haberdasher_protobuf":    true,
+	"haberdasher_grpcweb":     true,
+	"haberdasher_connect":     true,
+	"haberdasher_node":        true,
+	"haberdasher_models_only": true,
+	"haberdasher_banner":      true,
+	"imports_deno":            true,
+	"empty_protobuf":          true,
+	"empty_functions":         true,
+	"empty_helpers":           true,
+	"subscriptions_websocket": true,
+	"pagination":              true,
+	"features_fakes":          true,
+	"features_reflection":     true,
+	"rest":                    true,
+}
+
+func TestGolden(t *testing.T) {
+	for _, tt := range goldenTests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := readDescriptorSet(t, filepath.Join("testdata", tt.proto+".pb"))
+
+			opts, err := ParseOptions(tt.params)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := CreateClientAPIs(files, fileNames(files), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if runtimeGoldens[tt.name] {
+				// the runtime libraries are formatted and have their banners like main does
+				libs := RuntimeLibraries(opts)
+				FormatFiles(libs)
+				if err := AddBanners(libs, "", opts); err != nil {
+					t.Fatal(err)
+				}
+
+				out = append(out, libs...)
+			}
+
+			dir := filepath.Join("testdata", "golden", tt.name)
+
+			if *update {
+				if err := os.RemoveAll(dir); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			for _, f := range out {
+				path := filepath.Join(dir, f.GetName())
+
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+						t.Fatal(err)
+					}
+
+					if err := ioutil.WriteFile(path, []byte(f.GetContent()), 0644); err != nil {
+						t.Fatal(err)
+					}
+
+					continue
+				}
+
+				expected, err := ioutil.ReadFile(path)
+				if err != nil {
+					t.Errorf("%s was generated without a golden file, run with -update to add it", f.GetName())
+					continue
+				}
+
+				if line, ok := diffLines(string(expected), f.GetContent()); !ok {
+					t.Errorf("%s does not match its golden file, run with -update to update it\n%s", f.GetName(), line)
+				}
+			}
+
+			if count := countFiles(t, dir); count != len(out) {
+				t.Errorf("expected %d golden files in %s, found %d, run with -update to remove stale files", len(out), dir, count)
+			}
+		})
+	}
+}
+
+// readDescriptorSet reads the files of a descriptor set, skipping the Google WKTs like main does.
+func readDescriptorSet(t *testing.T, path string) []*descriptor.FileDescriptorProto {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	set := new(descriptor.FileDescriptorSet)
+	if err := proto.Unmarshal(data, set); err != nil {
+		t.Fatal(err)
+	}
+
+	var files []*descriptor.FileDescriptorProto
+	for _, f := range set.GetFile() {
+		if !IsMappedWKT(f) && !IsValidationFile(f) && !IsGoogleAPIFile(f) {
+			files = append(files, f)
+		}
+	}
+
+	return files
+}
+
+// fileNames returns the names of the files, so all of the files of a descriptor set are generated.
+func fileNames(files []*descriptor.FileDescriptorProto) []string {
+	var names []string
+	for _, f := range files {
+		names = append(names, f.GetName())
+	}
+
+	return names
+}
+
+// diffLines describes the first line that differs between the expected and actual content.
+func diffLines(expected string, actual string) (string, bool) {
+	if expected == actual {
+		return "", true
+	}
+
+	e := strings.Split(expected, "\n")
+	a := strings.Split(actual, "\n")
+
+	for i := 0; ; i++ {
+		if i >= len(e) || i >= len(a) || e[i] != a[i] {
+			return fmt.Sprintf("line %d:\n  expected: %s\n  actual:   %s", i+1, lineAt(e, i), lineAt(a, i)), false
+		}
+	}
+}
+
+func lineAt(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+
+	return "<end of file>"
+}
+
+func countFiles(t *testing.T, dir string) int {
+	count := 0
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			count++
+		}
+
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	return count
+}
@@ -0,0 +1,114 @@
+package generator
+
+// ProtobufMessages reports if the messages are sent in the protobuf binary format, which is the encoding of both
+// ProtocolProtobuf and ProtocolGRPCWeb, so their clients share the same ToProtobuf and ProtobufTo functions.
+func (o Options) ProtobufMessages() bool {
+	return o.Protocol == ProtocolProtobuf || o.Protocol == ProtocolGRPCWeb
+}
+
+// grpcWebRuntime frames the requests and reads the responses of the gRPC-web protocol, which is added to the
+// protobufRuntime with ProtocolGRPCWeb. Each message is sent in a frame of a flags byte and its length, and the
+// response is followed by a frame of its trailers, whose grpc-status is the status of the call, unless the status
+// is sent in the headers of a response without a message.
+const grpcWebRuntime = `
+// createGRPCWebRequest creates the gRPC-web request of a call, whose message is sent in an uncompressed frame.
+export const createGRPCWebRequest = (url: string, body: Uint8Array, options: CallOptions = {}): TransportRequest => {
+    const frame = new Uint8Array(5 + body.length);
+    frame[1] = (body.length >>> 24) & 0xff;
+    frame[2] = (body.length >>> 16) & 0xff;
+    frame[3] = (body.length >>> 8) & 0xff;
+    frame[4] = body.length & 0xff;
+    frame.set(body, 5);
+
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/grpc-web+proto",
+            "Accept": "application/grpc-web+proto",
+            "X-Grpc-Web": "1",
+        }),
+        body: frame,
+        signal: options.signal
+    };
+};
+
+// grpcErrorCodes are the Twirp error codes of the gRPC status codes, which Twirp shares.
+const grpcErrorCodes: {[status: string]: TwirpErrorCode} = {
+    "1": TwirpErrorCode.Canceled,
+    "2": TwirpErrorCode.Unknown,
+    "3": TwirpErrorCode.InvalidArgument,
+    "4": TwirpErrorCode.DeadlineExceeded,
+    "5": TwirpErrorCode.NotFound,
+    "6": TwirpErrorCode.AlreadyExists,
+    "7": TwirpErrorCode.PermissionDenied,
+    "8": TwirpErrorCode.ResourceExhausted,
+    "9": TwirpErrorCode.FailedPrecondition,
+    "10": TwirpErrorCode.Aborted,
+    "11": TwirpErrorCode.OutOfRange,
+    "12": TwirpErrorCode.Unimplemented,
+    "13": TwirpErrorCode.Internal,
+    "14": TwirpErrorCode.Unavailable,
+    "15": TwirpErrorCode.DataLoss,
+    "16": TwirpErrorCode.Unauthenticated,
+};
+
+// grpcWebTrailers parses the trailers frame of a gRPC-web response, which are lines of "name: value".
+const grpcWebTrailers = (b: Uint8Array): {[name: string]: string} => {
+    const trailers: {[name: string]: string} = {};
+    utf8Decode(b).split("\r\n").forEach((line) => {
+        const i = line.indexOf(":");
+        if (i > 0) {
+            trailers[line.substring(0, i).trim().toLowerCase()] = line.substring(i + 1).trim();
+        }
+    });
+
+    return trailers;
+};
+
+// grpcWebResponse reads the message of a gRPC-web response, and rejects with a TwirpError of the code of its
+// grpc-status when the call fails. Responses that are not from a gRPC-web server, e.g. from a proxy, are errors of
+// their HTTP status, as with Twirp.
+export const grpcWebResponse = (resp: TransportResponse): Promise<Uint8Array> => {
+    if (!resp.ok) {
+        return throwTwirpError(resp);
+    }
+
+    return resp.arrayBuffer().then((buf) => {
+        const b = new Uint8Array(buf);
+        let message: Uint8Array | undefined;
+        let trailers: {[name: string]: string} = {};
+
+        for (let i = 0; i + 5 <= b.length;) {
+            const length = ((b[i + 1] << 24) | (b[i + 2] << 16) | (b[i + 3] << 8) | b[i + 4]) >>> 0;
+            const frame = b.subarray(i + 5, i + 5 + length);
+
+            if (b[i] & 0x80) {
+                trailers = grpcWebTrailers(frame);
+            } else if (b[i] & 0x01) {
+                throw new TwirpError({code: TwirpErrorCode.Internal, msg: "compressed gRPC-web messages are not supported"});
+            } else if (message === undefined) {
+                message = frame;
+            }
+
+            i += 5 + length;
+        }
+
+        const headers = resp.headers || noHeaders;
+        const status = headers.get("grpc-status") || trailers["grpc-status"];
+        if (!status) {
+            throw new TwirpError({code: TwirpErrorCode.Internal, msg: "gRPC-web response without a grpc-status"});
+        }
+
+        if (status !== "0") {
+            const msg = headers.get("grpc-message") || trailers["grpc-message"] || "";
+            throw new TwirpError({code: grpcErrorCodes[status] || TwirpErrorCode.Unknown, msg: decodeURIComponent(msg), meta: {grpc_status: status}});
+        }
+
+        if (message === undefined) {
+            throw new TwirpError({code: TwirpErrorCode.Internal, msg: "gRPC-web response without a message"});
+        }
+
+        return message;
+    });
+};
+`
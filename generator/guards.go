@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// guardCheck generates the check of the type guard of a message that a value has the typescript type of a field,
+// e.g. everyItem(m.hats, isHat) for a repeated field of Hat messages.
+func guardCheck(f ModelField, v string) string {
+	switch {
+	case f.IsMap:
+		return fmt.Sprintf("everyValue(%s, %s)", v, guardFunc(*f.Value))
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = strings.TrimSuffix(f.Type, "[]")
+
+		return fmt.Sprintf("everyItem(%s, %s)", v, guardFunc(item))
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+		value.Type = wrappedType(f)
+
+		return fmt.Sprintf("(%s === null || %s)", v, guardCheck(value, v))
+	case f.IsFieldMask:
+		return fmt.Sprintf(`everyItem(%s, (v) => typeof v === "string")`, v)
+	case f.IsDuration && f.Type == "Duration":
+		return fmt.Sprintf("isDurationObject(%s)", v)
+	case f.IsMessage && f.Type == "Date":
+		return fmt.Sprintf("%s instanceof Date", v)
+	case f.IsMessage:
+		return fmt.Sprintf("is%s(%s)", f.Type, v)
+	case f.IsBytes:
+		return fmt.Sprintf("%s instanceof Uint8Array", v)
+	case f.IsEnum:
+		return fmt.Sprintf(`typeof %s === "number"`, v)
+	case f.Codec == "listValue":
+		return fmt.Sprintf("Array.isArray(%s)", v)
+	case f.Codec == "value":
+		return fmt.Sprintf("%s !== undefined", v)
+	case f.Codec != "":
+		return fmt.Sprintf(`typeof %s === "object" && %s !== null`, v, v)
+	}
+
+	return fmt.Sprintf("typeof %s === %q", v, f.Type)
+}
+
+// guardFunc generates the function that checks the items of a repeated field, or the values of a map field.
+func guardFunc(f ModelField) string {
+	if f.IsMessage && f.Type != "Date" {
+		return "is" + f.Type
+	}
+
+	return "(v) => " + guardCheck(f, "v")
+}
+
+// guardChecks generates the checks of the type guard of a message, which are joined by &&.
+func guardChecks(m *Model) string {
+	var checks []string
+
+	for _, f := range m.Fields {
+		check := guardCheck(f, "m."+f.Name)
+		if f.Optional() {
+			check = fmt.Sprintf("(m.%s === undefined || %s)", f.Name, check)
+		}
+
+		checks = append(checks, check)
+	}
+
+	for _, o := range m.Oneofs {
+		var members []string
+		for _, f := range o.Fields {
+			members = append(members, f.Name+": "+guardFunc(f))
+		}
+
+		checks = append(checks, fmt.Sprintf("(m.%s === undefined || oneofMember(m.%s, {%s}))", o.Name, o.Name, strings.Join(members, ", ")))
+	}
+
+	if len(checks) == 0 {
+		return "true"
+	}
+
+	return strings.Join(checks, "\n        && ")
+}
@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// idempotency is the idempotency_level option of an rpc method that is safe to retry, which is no_side_effects or
+// idempotent, or empty for the other methods, e.g.
+//
+//	rpc GetHat(GetHatRequest) returns (Hat) { option idempotency_level = NO_SIDE_EFFECTS; }
+func idempotency(m *descriptor.MethodDescriptorProto) string {
+	switch m.GetOptions().GetIdempotencyLevel() {
+	case descriptor.MethodOptions_NO_SIDE_EFFECTS:
+		return "no_side_effects"
+	case descriptor.MethodOptions_IDEMPOTENT:
+		return "idempotent"
+	}
+
+	return ""
+}
+
+// transport generates the transport of a call of an rpc method by a client, e.g. this.transport, which retries the
+// network failures of the methods with an idempotency, see retryNetworkFailures.
+func transport(client string, m ServiceMethod) string {
+	if m.Idempotency == "" {
+		return client + ".transport"
+	}
+
+	return "retryNetworkFailures(ctx, " + client + ".transport)"
+}
@@ -0,0 +1,346 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+const protobufJsTemplate = `import {mapEntries, durationToString, durationFromString, timestampToProtobufJs, timestampFromProtobufJs, durationFromProtobufJs, valueToProtobufJs, valueFromProtobufJs, structToProtobufJs, structFromProtobufJs} from '{{importPath "twirp"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// {{.Name}}ToProtobufJs converts the {{.Name}} model to an object that the fromObject function of its protobuf.js message accepts
+export const {{.Name}}ToProtobufJs = (m: {{.Name}}): {[key: string]: any} => {
+    return {
+        {{- range .Fields}}
+        {{protobufJsName .ProtoName}}: {{toProtobufJs .}},
+        {{- end}}
+        {{- range $o := .Oneofs}}{{range .Fields}}
+        {{protobufJsName .ProtoName}}: {{toProtobufJsOneof $o .}},
+        {{- end}}{{end}}
+    };
+};
+
+// ProtobufJsTo{{.Name}} converts a protobuf.js {{.Name}} message, e.g. from its decode function, to the {{.Name}} model
+export const ProtobufJsTo{{.Name}} = (m: {[key: string]: any}): {{.Name}} => {
+    return {{if $.Classes}}new {{.Name}}({{end}}{
+        {{- range .Fields}}
+        {{.Name}}: {{fromProtobufJs .}},
+        {{- end}}
+        {{- range .Oneofs}}
+        {{.Name}}: {{fromProtobufJsOneof .}},
+        {{- end}}
+    }{{if $.Classes}}){{else}} as {{.Name}}{{end}};
+};
+{{end}}`
+
+const protobufTsTemplate = `import {ProtobufTsType} from '{{importPath "twirp"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// {{.Name}}ToProtobufTs converts the {{.Name}} model to a protobuf-ts message, with the MessageType that protobuf-ts exports for {{.Name}}
+export const {{.Name}}ToProtobufTs = <T>(type: ProtobufTsType<T>, m: {{.Name}}): T => {
+    {{- if ne $.Protocol "json"}}
+    return type.fromBinary({{.Name}}ToProtobuf(m));
+    {{- else}}
+    return type.fromJson({{.Name}}ToJSON(m));
+    {{- end}}
+};
+
+// ProtobufTsTo{{.Name}} converts a protobuf-ts message to the {{.Name}} model, with the MessageType that protobuf-ts exports for {{.Name}}
+export const ProtobufTsTo{{.Name}} = <T>(type: ProtobufTsType<T>, message: T): {{.Name}} => {
+    {{- if ne $.Protocol "json"}}
+    return ProtobufTo{{.Name}}(type.toBinary(message));
+    {{- else}}
+    return JSONTo{{.Name}}(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+    {{- end}}
+};
+{{end}}`
+
+// interopModuleName is the name of the module of the interop functions of the messages of a module with
+// Options.Interop, e.g. service_protobufjs or service_protobuf_ts
+func interopModuleName(module string, interop string) string {
+	return module + "_" + strings.Replace(interop, "-", "_", -1)
+}
+
+// interopModule is the module of the interop functions of the messages of a proto file, see renderInterop.
+type interopModule struct {
+	Protocol string
+	Classes  bool
+	Imports  []*Import
+	Models   []*Model
+}
+
+// markInteropModels sets the marshal flags of every model with Options.Interop set to protobuf-ts, since the
+// messages are converted through their JSON or their binary encoding, which protobuf-ts reads and writes.
+func (ctx *APIContext) markInteropModels() {
+	if ctx.Interop != InteropProtobufTs {
+		return
+	}
+
+	for _, m := range ctx.Models {
+		if m.Primitive {
+			continue
+		}
+
+		m.CanMarshal = true
+		m.CanUnmarshal = true
+	}
+}
+
+// protobufJsName is the name of a field of a protobuf.js message, which is the camel case of its proto name
+// as converted by pbjs, e.g. named_layers is namedLayers
+func protobufJsName(name string) string {
+	var b strings.Builder
+
+	for i := 0; i < len(name); i++ {
+		if i > 0 && name[i] == '_' && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z' {
+			b.WriteByte(name[i+1] - 'a' + 'A')
+			i++
+			continue
+		}
+
+		b.WriteByte(name[i])
+	}
+
+	return b.String()
+}
+
+// toProtobufJs converts a field of a model to the value of the field of a protobuf.js object, which fromObject
+// accepts. The well-known types are converted to their messages, e.g. a Date to a google.protobuf.Timestamp.
+func toProtobufJs(f ModelField) string {
+	return toProtobufJsValue(f, "m."+f.Name, true)
+}
+
+// toProtobufJsValue converts a value of a field of a model, which may be undefined when unset is true and the field
+// is a message, e.g. in a model decoded from the binary encoding of a message where the field is not set.
+func toProtobufJsValue(f ModelField, v string, unset bool) string {
+	switch {
+	case f.IsOptional:
+		value := f
+		value.IsOptional = false
+
+		if conv := toProtobufJsValue(value, v, false); conv != v {
+			return fmt.Sprintf("%s === undefined ? undefined : %s", v, conv)
+		}
+
+		return v
+	case f.IsMap:
+		conv := toProtobufJsValue(*f.Value, "v", false)
+		if conv == "v" {
+			return v
+		}
+
+		return fmt.Sprintf("mapEntries(%s, String, (v) => %s)", v, conv)
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = singularType(f)
+
+		if conv := toProtobufJsValue(item, "v", false); conv != "v" {
+			return fmt.Sprintf("%s.map((v) => %s)", v, conv)
+		}
+
+		return v
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+		value.Type = wrappedType(f)
+
+		return fmt.Sprintf("%s === null ? undefined : {value: %s}", v, toProtobufJsValue(value, v, false))
+	case f.IsDuration:
+		if singularType(f) == DurationString {
+			return fmt.Sprintf("durationFromString(%s)", v)
+		}
+
+		return v
+	case f.IsFieldMask:
+		return fmt.Sprintf("{paths: %s}", v)
+	case f.Codec == "struct":
+		return fmt.Sprintf("structToProtobufJs(%s)", v)
+	case f.Codec == "value":
+		return fmt.Sprintf("valueToProtobufJs(%s)", v)
+	case f.Codec == "listValue":
+		return fmt.Sprintf("{values: %s.map(valueToProtobufJs)}", v)
+	case f.Codec != "":
+		// google.protobuf.Any is passed through in the representation of the protocol
+		return v
+	case f.IsMessage && f.Type == "Date":
+		return fmt.Sprintf("timestampToProtobufJs(%s)", v)
+	case f.IsMessage && unset:
+		return fmt.Sprintf("%s === undefined ? undefined : %sToProtobufJs(%s)", v, f.Type, v)
+	case f.IsMessage:
+		return fmt.Sprintf("%sToProtobufJs(%s)", f.Type, v)
+	case f.IsLong && wrappedType(f) == Int64BigInt:
+		// protobuf.js reads 64 bit integers from numbers and decimal strings
+		return fmt.Sprintf("String(%s)", v)
+	}
+
+	return v
+}
+
+// toProtobufJsOneof converts a member of a oneof to the value of its field of a protobuf.js object.
+func toProtobufJsOneof(o ModelOneof, f ModelField) string {
+	return fmt.Sprintf(`m.%s && m.%s.kind === "%s" ? %s : undefined`, o.Name, o.Name, f.Name, toProtobufJsValue(f, "m."+o.Name+".value", false))
+}
+
+// fromProtobufJs converts the value of the field of a protobuf.js message to a field of a model. Unset message
+// fields are null in protobuf.js, and undefined in the model.
+func fromProtobufJs(f ModelField) string {
+	return fromProtobufJsValue(f, "m."+protobufJsName(f.ProtoName), true)
+}
+
+// fromProtobufJsValue converts a value of a field of a protobuf.js message, which is null when unset is true and
+// the field is not set, unlike the items of repeated and map fields.
+func fromProtobufJsValue(f ModelField, v string, unset bool) string {
+	switch {
+	case f.IsOptional:
+		value := f
+		value.IsOptional = false
+
+		return fmt.Sprintf("%s === null || %s === undefined ? undefined : %s", v, v, fromProtobufJsValue(value, v, false))
+	case f.IsMap:
+		conv := fromProtobufJsValue(*f.Value, "v", false)
+		if conv == "v" && !numericKeys(f) {
+			return fmt.Sprintf("%s || {}", v)
+		}
+
+		key := "String"
+		if numericKeys(f) {
+			key = "Number"
+		}
+
+		return fmt.Sprintf("mapEntries(%s || {}, %s, (v) => %s)", v, key, conv)
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = singularType(f)
+
+		if conv := fromProtobufJsValue(item, "v", false); conv != "v" {
+			return fmt.Sprintf("(%s || []).map((v) => %s)", v, conv)
+		}
+
+		return fmt.Sprintf("%s || []", v)
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+		value.Type = wrappedType(f)
+
+		return fmt.Sprintf("%s ? %s : null", v, fromProtobufJsValue(value, v+".value", false))
+	case f.IsDuration:
+		if singularType(f) == DurationString {
+			return fmt.Sprintf("durationToString(durationFromProtobufJs(%s || {}))", v)
+		}
+
+		return fmt.Sprintf("durationFromProtobufJs(%s || {})", v)
+	case f.IsFieldMask:
+		return fmt.Sprintf("%s ? %s.paths || [] : []", v, v)
+	case f.Codec == "struct":
+		return fmt.Sprintf("structFromProtobufJs(%s)", v)
+	case f.Codec == "value":
+		return fmt.Sprintf("valueFromProtobufJs(%s)", v)
+	case f.Codec == "listValue":
+		return fmt.Sprintf("(%s && %s.values || []).map(valueFromProtobufJs)", v, v)
+	case f.Codec != "":
+		return v
+	case f.IsMessage && f.Type == "Date" && unset:
+		return fmt.Sprintf("%s ? timestampFromProtobufJs(%s) : undefined", v, v)
+	case f.IsMessage && f.Type == "Date":
+		return fmt.Sprintf("timestampFromProtobufJs(%s)", v)
+	case f.IsMessage && unset:
+		return fmt.Sprintf("%s ? ProtobufJsTo%s(%s) : undefined", v, f.Type, v)
+	case f.IsMessage:
+		return fmt.Sprintf("ProtobufJsTo%s(%s)", f.Type, v)
+	case f.IsLong:
+		return longFromString(wrappedType(f), fmt.Sprintf("String(%s)", v))
+	}
+
+	return v
+}
+
+// fromProtobufJsOneof detects which member of a oneof is set in a protobuf.js message, and converts it into the
+// discriminated union.
+func fromProtobufJsOneof(o ModelOneof) string {
+	expr := "undefined"
+
+	for i := len(o.Fields) - 1; i >= 0; i-- {
+		f := o.Fields[i]
+		v := "m." + protobufJsName(f.ProtoName)
+		expr = fmt.Sprintf(`%s !== null && %s !== undefined ? {kind: "%s", value: %s} : %s`, v, v, f.Name, fromProtobufJsValue(f, v, false), expr)
+	}
+
+	return expr
+}
+
+// renderInterop generates the functions that convert the messages of the module to and from the messages of
+// protobuf.js or protobuf-ts with Options.Interop, e.g. HatToProtobufJs and ProtobufJsToHat in service_protobufjs.ts.
+// The protobuf.js functions convert each field to its protobuf.js representation, while the protobuf-ts functions
+// convert the messages through the encoding of the protocol, with the MessageType of the protobuf-ts message.
+func (ctx *APIContext) renderInterop() (*plugin.CodeGeneratorResponse_File, error) {
+	module := interopModule{Protocol: ctx.Protocol, Classes: ctx.Classes()}
+
+	var names []string
+	for _, m := range ctx.Models {
+		if m.Primitive {
+			continue
+		}
+
+		module.Models = append(module.Models, m)
+
+		names = append(names, m.Name)
+		if ctx.Interop == InteropProtobufTs {
+			names = append(names, ctx.marshalFunc(m.Name), ctx.unmarshalFunc(m.Name))
+		}
+	}
+
+	if len(names) > 0 {
+		module.Imports = append(module.Imports, &Import{Module: ctx.module, Names: names})
+	}
+
+	tmpl := protobufTsTemplate
+	if ctx.Interop == InteropProtobufJs {
+		tmpl = protobufJsTemplate
+
+		// the models of the fields that are declared in other modules are converted by their interop modules
+		module.Imports = append(module.Imports, ctx.schemaImports(func(m string) string {
+			return interopModuleName(m, ctx.Interop)
+		}, func(name string) []string {
+			return []string{name + "ToProtobufJs", "ProtobufJsTo" + name}
+		})...)
+	}
+
+	funcMap := template.FuncMap{
+		"join":                strings.Join,
+		"protobufJsName":      protobufJsName,
+		"toProtobufJs":        toProtobufJs,
+		"toProtobufJsOneof":   toProtobufJsOneof,
+		"fromProtobufJs":      fromProtobufJs,
+		"fromProtobufJsOneof": fromProtobufJsOneof,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("interop").Funcs(funcMap).Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(interopModuleName(ctx.module, ctx.Interop) + ".ts")
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
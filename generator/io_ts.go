@@ -0,0 +1,206 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+const ioTSTemplate = `import * as t from '{{if eq .Target "deno"}}npm:io-ts{{else}}io-ts{{end}}';
+import * as E from '{{if eq .Target "deno"}}npm:fp-ts/Either{{else}}fp-ts/Either{{end}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// {{.Name}}Codec decodes the proto3 JSON of the {{.Name}} message, e.g. the body of a response.
+{{- if recursive .}}
+export const {{.Name}}Codec: t.Type<any> = t.recursion("{{.Name}}", () => t.partial({
+    {{- template "fields" .}}
+}));
+{{- else}}
+export const {{.Name}}Codec = t.partial({
+    {{- template "fields" .}}
+});
+{{- end}}
+{{- if .CanUnmarshal}}
+
+// decode{{.Name}} decodes the proto3 JSON of the {{.Name}} message, and returns the errors of JSON that does not match its codec.
+export const decode{{.Name}} = (json: unknown): E.Either<t.Errors, {{.Name}}> => {
+    return E.map((m) => JSONTo{{.Name}}(m as {{.Name}}JSON))({{.Name}}Codec.decode(json));
+};
+{{- end}}
+{{end}}
+{{- define "fields"}}
+    {{- range .Fields}}
+    {{.JSONName}}: {{ioTSField .}},
+    {{- end}}
+    {{- range .Oneofs}}{{range .Fields}}
+    {{.JSONName}}: {{ioTSField .}},
+    {{- end}}{{end}}
+{{- end}}`
+
+// ioTSModuleName is the name of the module of the io-ts codecs of the messages of a module, e.g. service_io_ts
+func ioTSModuleName(module string) string {
+	return module + "_io_ts"
+}
+
+// ioTSModule is the module of the io-ts codecs of the messages of a proto file, see renderIOTS.
+type ioTSModule struct {
+	Target  string
+	Imports []*Import
+	Models  []*Model
+}
+
+// ioTSField generates the io-ts codec of the proto3 JSON value of a field, which accepts the same values as the
+// JSON functions of its message, see zodField.
+func ioTSField(f ModelField, enums map[string]*Enum) string {
+	switch {
+	case f.IsMap:
+		return fmt.Sprintf("t.record(t.string, %s)", ioTSField(*f.Value, enums))
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = strings.TrimSuffix(f.Type, "[]")
+
+		return fmt.Sprintf("t.array(%s)", ioTSField(item, enums))
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+
+		return fmt.Sprintf("t.union([%s, t.null])", ioTSField(value, enums))
+	case f.IsDuration, f.IsFieldMask:
+		return "t.string"
+	case f.Codec == "any":
+		return `t.type({"@type": t.string})`
+	case f.Codec == "struct":
+		return "t.record(t.string, t.unknown)"
+	case f.Codec == "listValue":
+		return "t.array(t.unknown)"
+	case f.Codec == "value":
+		return "t.unknown"
+	case f.IsMessage && f.Type == "Date":
+		return "t.string"
+	case f.IsMessage:
+		return f.Type + "Codec"
+	case f.IsEnum:
+		e := enums[f.Type]
+		if f.EnumNumbers || e == nil {
+			return "t.Int"
+		}
+
+		var names []string
+		for _, v := range e.Values {
+			names = append(names, fmt.Sprintf("%q: null", v.Name))
+		}
+
+		return fmt.Sprintf("t.union([t.keyof({%s}), t.Int])", strings.Join(names, ", "))
+	}
+
+	switch {
+	case f.IsFloat:
+		return "t.union([t.number, t.string])"
+	case f.IsLong:
+		return "t.union([t.string, t.Int])"
+	case f.IsBytes:
+		return "t.string"
+	}
+
+	switch f.ProtoType {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "t.boolean"
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "t.string"
+	}
+
+	return "t.Int"
+}
+
+// ioTSOrder sorts the models so each codec is declared after the codecs of the messages of its fields, since an
+// io-ts codec refers to the codecs of its fields when it is created. The codecs of recursive models are created
+// lazily by t.recursion, so the models of a cycle may be declared in any order.
+func (ctx *APIContext) ioTSOrder() []*Model {
+	local := make(map[*Model]bool)
+	for _, m := range ctx.Models {
+		local[m] = true
+	}
+
+	var ordered []*Model
+	visited := make(map[*Model]bool)
+
+	var visit func(m *Model)
+	visit = func(m *Model) {
+		if visited[m] {
+			return
+		}
+		visited[m] = true
+
+		for _, f := range m.fields() {
+			if next, ok := ctx.modelLookup[strings.TrimSuffix(f.Type, "[]")]; ok && f.IsMessage && local[next] {
+				visit(next)
+			}
+		}
+
+		ordered = append(ordered, m)
+	}
+
+	for _, m := range ctx.Models {
+		if !m.Primitive {
+			visit(m)
+		}
+	}
+
+	return ordered
+}
+
+// renderIOTS generates the io-ts codecs of the messages of the module, e.g. service_io_ts.ts, with Options.IOTS,
+// along with a decode function for each message that is unmarshalled from JSON, e.g. decodeHat.
+func (ctx *APIContext) renderIOTS(enums map[string]*Enum) (*plugin.CodeGeneratorResponse_File, error) {
+	module := ioTSModule{Target: ctx.Target, Models: ctx.ioTSOrder()}
+	module.Imports = ctx.schemaImports(ioTSModuleName, func(name string) []string {
+		return []string{name + "Codec"}
+	})
+
+	// the decode functions convert the JSON with the functions of the module of the proto file
+	var names []string
+	for _, m := range module.Models {
+		if m.CanUnmarshal {
+			names = append(names, m.Name, m.Name+"JSON", ctx.unmarshalFunc(m.Name))
+		}
+	}
+
+	if len(names) > 0 {
+		module.Imports = append([]*Import{{Module: ctx.module, Names: names}}, module.Imports...)
+	}
+
+	funcMap := template.FuncMap{
+		"join":      strings.Join,
+		"recursive": ctx.recursive,
+		"ioTSField": func(f ModelField) string {
+			return ioTSField(f, enums)
+		},
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("io_ts").Funcs(funcMap).Parse(ioTSTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ioTSModuleName(ctx.module) + ".ts")
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// mapValueName is the name given to the value of a map field while generating the conversion of
+// a single value, which is then replaced with the parameter of the mapEntries callback.
+const mapValueName = "__value"
+
+// mapKeyOptions are the options used for the key of a map field. Objects can only be indexed by
+// strings and numbers, so 64 bit integer keys are left as their decimal string when int64=bigint.
+func mapKeyOptions(opts Options) Options {
+	if opts.Int64 == Int64BigInt {
+		opts.Int64 = Int64String
+	}
+
+	return opts
+}
+
+// mapTypes generates the (Type, JSONType) tuple of a map field. Integer keys are numbers in typescript
+// when their declared type is a number, while proto3 JSON always represents the keys as strings.
+func mapTypes(entry *descriptor.DescriptorProto, types typeRegistry, opts Options) (string, string) {
+	keyType := "string"
+	var valueType, valueJSONType string
+
+	for _, f := range entry.GetField() {
+		switch f.GetNumber() {
+		case 1:
+			if t, _ := protoToTSType(f, types, mapKeyOptions(opts)); t == "number" {
+				keyType = t
+			}
+		case 2:
+			valueType, valueJSONType = protoToTSType(f, types, opts)
+		}
+	}
+
+	return "{[key: " + keyType + "]: " + valueType + "}", "{[key: string]: " + valueJSONType + "}"
+}
+
+// newMapField sets the key and value of a map field from its map entry message.
+func newMapField(field ModelField, entry *descriptor.DescriptorProto, types typeRegistry, opts Options) ModelField {
+	for _, f := range entry.GetField() {
+		switch f.GetNumber() {
+		case 1:
+			key := newField(f, types, mapKeyOptions(opts))
+			field.Key = &key
+		case 2:
+			value := newField(f, types, opts)
+			value.Zero = ""
+			field.Value = &value
+		}
+	}
+
+	field.IsMap = true
+	field.IsMessage = false
+	field.IsRepeated = false
+
+	return field
+}
+
+// numericKeys reports if the keys of a map field are numbers in typescript.
+func numericKeys(f ModelField) bool {
+	return f.Key.Type == "number"
+}
+
+// mapValue generates the conversion of a single map value, using the given conversion of a field
+// such as stringify or parse. It returns an empty string when the value does not need a conversion.
+func mapValue(f ModelField, convert func(ModelField) string) string {
+	value := *f.Value
+	value.Name = mapValueName
+	value.JSONName = mapValueName
+
+	conv := strings.Replace(convert(value), "m."+mapValueName, "v", -1)
+	if conv == "v" {
+		return ""
+	}
+
+	return conv
+}
+
+// stringifyMap converts the values of a map field to JSON. Numeric keys are converted to the
+// strings used by proto3 JSON.
+func stringifyMap(f ModelField) string {
+	conv := mapValue(f, stringify)
+	if conv == "" && !numericKeys(f) {
+		return "m." + f.Name
+	}
+
+	if conv == "" {
+		conv = "v"
+	}
+
+	return fmt.Sprintf("mapEntries(m.%s, String, (v) => %s)", f.Name, conv)
+}
+
+// parseMap converts the values of a map field from JSON. The string keys of proto3 JSON are
+// parsed to numbers when the declared key type is a number.
+func parseMap(f ModelField) string {
+	conv := mapValue(f, parse)
+	if conv == "" && !numericKeys(f) {
+		return fmt.Sprintf("m.%s || {}", f.JSONName)
+	}
+
+	if conv == "" {
+		conv = "v"
+	}
+
+	key := "String"
+	if numericKeys(f) {
+		key = "Number"
+	}
+
+	return fmt.Sprintf("mapEntries(m.%s || {}, %s, (v) => %s)", f.JSONName, key, conv)
+}
+
+// encodeMap writes each entry of a map field as a message of its key and value.
+func encodeMap(f ModelField) string {
+	keys := fmt.Sprintf("Object.keys(m.%s)", f.Name)
+	if numericKeys(f) {
+		keys += ".map(Number)"
+	}
+
+	key := writeValue(*f.Key, mapKeyToWire(*f.Key, "k"))
+	value := writeValue(*f.Value, fmt.Sprintf("m.%s[k]", f.Name))
+
+	return fmt.Sprintf("%s.forEach((k) => w.tag(%d, %d).message((w) => { %s; %s; }));", keys, f.Number, wireBytes, key, value)
+}
+
+// decodeMap reads a map entry, using the proto3 default of the key or value when it is not present.
+func decodeMap(f ModelField) string {
+	key := readValue(*f.Key)
+	if f.Key.ProtoType == descriptor.FieldDescriptorProto_TYPE_BOOL {
+		key = fmt.Sprintf("String(%s)", key)
+	}
+
+	value := readValue(*f.Value)
+	zero := zeroValue(*f.Value)
+	if strings.Contains(value, "r.bytes()") {
+		// messages are decoded from empty bytes, so they have the same defaults as an empty message on the wire
+		zero = strings.Replace(value, "r.bytes()", "new Uint8Array(0)", -1)
+	}
+
+	return fmt.Sprintf("case %d: r.entry(%s, %s, (r) => %s, (r) => %s, (k, v) => m.%s[k] = v); break;", f.Number, mapKeyZero(*f.Key), zero, key, value, f.Name)
+}
+
+// mapKeyToWire converts a boolean map key, which is a string in typescript, to the type accepted by the ProtobufWriter.
+func mapKeyToWire(key ModelField, value string) string {
+	if key.ProtoType == descriptor.FieldDescriptorProto_TYPE_BOOL {
+		return fmt.Sprintf(`%s === "true"`, value)
+	}
+
+	return value
+}
+
+// mapKeyZero is the proto3 default of a map key.
+func mapKeyZero(key ModelField) string {
+	if key.ProtoType == descriptor.FieldDescriptorProto_TYPE_BOOL {
+		return `"false"`
+	}
+
+	return zeroValue(key)
+}
@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewField_Map(t *testing.T) {
+	files := []*descriptor.FileDescriptorProto{{
+		Name:    proto.String("closet.proto"),
+		Package: proto.String("closet"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Hat")},
+			{
+				Name: proto.String("Closet"),
+				NestedType: []*descriptor.DescriptorProto{{
+					Name:    proto.String("HatsEntry"),
+					Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+					Field: []*descriptor.FieldDescriptorProto{
+						{Name: proto.String("key"), Number: proto.Int32(1), Type: descriptor.FieldDescriptorProto_TYPE_INT32.Enum()},
+						{Name: proto.String("value"), Number: proto.Int32(2), Type: descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(), TypeName: proto.String(".closet.Hat")},
+					},
+				}},
+			},
+		},
+	}}
+
+	types, err := newTypeRegistry(files, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("hats"),
+		Number:   proto.Int32(1),
+		Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".closet.Closet.HatsEntry"),
+	}, types, DefaultOptions())
+
+	if !f.IsMap || f.IsMessage || f.IsRepeated || !f.Value.IsMessage {
+		t.Fatalf("expected a map of messages, got %+v", f)
+	}
+
+	if f.Type != "{[key: number]: Hat}" || f.JSONType != "{[key: string]: HatJSON}" {
+		t.Errorf("expected map types of number keyed Hat and HatJSON, got %s and %s", f.Type, f.JSONType)
+	}
+
+	if expected, actual := "mapEntries(m.hats, String, (v) => HatToJSON(v))", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "mapEntries(m.hats || {}, Number, (v) => JSONToHat(v))", parse(f); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "Object.keys(m.hats).map(Number).forEach((k) => w.tag(1, 2).message((w) => { w.tag(1, 0).int32(k); w.tag(2, 2).bytes(HatToProtobuf(m.hats[k])); }));", encodeField(f); actual != expected {
+		t.Errorf("expected encodeField %s, got %s", expected, actual)
+	}
+
+	if expected, actual := `case 1: r.entry(0, ProtobufToHat(new Uint8Array(0)), (r) => r.int32(), (r) => ProtobufToHat(r.bytes()), (k, v) => m.hats[k] = v); break;`, decodeField(f); actual != expected {
+		t.Errorf("expected decodeField %s, got %s", expected, actual)
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	tests := []struct {
+		keyType   descriptor.FieldDescriptorProto_Type
+		int64Type string
+		tsType    string
+		stringify string
+		parse     string
+		key       string
+	}{
+		{descriptor.FieldDescriptorProto_TYPE_STRING, Int64Number, "{[key: string]: string}", "m.labels", "m.labels || {}", "w.tag(1, 2).string(k)"},
+		{descriptor.FieldDescriptorProto_TYPE_BOOL, Int64Number, "{[key: string]: string}", "m.labels", "m.labels || {}", `w.tag(1, 0).bool(k === "true")`},
+		{descriptor.FieldDescriptorProto_TYPE_INT64, Int64Number, "{[key: number]: string}", "mapEntries(m.labels, String, (v) => v)", "mapEntries(m.labels || {}, Number, (v) => v)", "w.tag(1, 0).int64(String(k))"},
+		{descriptor.FieldDescriptorProto_TYPE_INT64, Int64BigInt, "{[key: string]: string}", "m.labels", "m.labels || {}", "w.tag(1, 0).int64(k)"},
+	}
+
+	for _, tt := range tests {
+		entry := &descriptor.DescriptorProto{
+			Name:    proto.String("LabelsEntry"),
+			Options: &descriptor.MessageOptions{MapEntry: proto.Bool(true)},
+			Field: []*descriptor.FieldDescriptorProto{
+				{Name: proto.String("key"), Number: proto.Int32(1), Type: tt.keyType.Enum()},
+				{Name: proto.String("value"), Number: proto.Int32(2), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum()},
+			},
+		}
+
+		f := newField(&descriptor.FieldDescriptorProto{
+			Name:     proto.String("labels"),
+			Number:   proto.Int32(1),
+			Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+			Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(".Msg.LabelsEntry"),
+		}, typeRegistry{".Msg.LabelsEntry": {entry: entry}}, Options{Int64: tt.int64Type})
+
+		if f.Type != tt.tsType || f.JSONType != "{[key: string]: string}" {
+			t.Errorf("%s: expected types %s and {[key: string]: string}, got %s and %s", tt.keyType, tt.tsType, f.Type, f.JSONType)
+		}
+
+		if actual := stringify(f); actual != tt.stringify {
+			t.Errorf("%s: expected stringify %s, got %s", tt.keyType, tt.stringify, actual)
+		}
+
+		if actual := parse(f); actual != tt.parse {
+			t.Errorf("%s: expected parse %s, got %s", tt.keyType, tt.parse, actual)
+		}
+
+		if actual := encodeField(f); !strings.Contains(actual, tt.key) {
+			t.Errorf("%s: expected encodeField to write the key with %s, got %s", tt.keyType, tt.key, actual)
+		}
+	}
+}
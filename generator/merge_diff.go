@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mergesMessage reports if a field is a message whose patches are merged into it by the merge function of its
+// message, and whose changes are found by the diff function of its message, with Options.MergeDiff.
+func mergesMessage(f ModelField) bool {
+	return f.IsMessage && !f.IsRepeated && !f.IsMap && f.Type != "Date"
+}
+
+// mergers generates the merge functions of the fields of a model that the fields of a patch are merged into, rather
+// than replacing them, e.g. {layer: mergeDrawingLayer, namedLayers: mergeMap}, see mergeFields.
+func mergers(m *Model) string {
+	var entries []string
+	for _, f := range m.Fields {
+		switch {
+		case f.IsMap:
+			entries = append(entries, f.Name+": mergeMap")
+		case mergesMessage(f):
+			entries = append(entries, f.Name+": merge"+f.Type)
+		}
+	}
+
+	return "{" + strings.Join(entries, ", ") + "}"
+}
+
+// fieldDiffs generates the FieldDiffs of the fields and oneofs of a model, whose paths are their names in the proto
+// file, as in the paths of a FieldMask, e.g. [{name: "createdOn", path: "created_on"}], see diffFields.
+func fieldDiffs(m *Model) string {
+	var entries []string
+	for _, f := range m.Fields {
+		entry := fmt.Sprintf("{name: %s, path: %s", jsString(f.Name), jsString(f.ProtoName))
+		if mergesMessage(f) {
+			entry += ", diff: diff" + f.Type
+		}
+
+		entries = append(entries, entry+"}")
+	}
+
+	for _, o := range m.Oneofs {
+		var members []string
+		for _, f := range o.Fields {
+			members = append(members, fmt.Sprintf("%s: %s", jsString(f.Name), jsString(f.ProtoName)))
+		}
+
+		entries = append(entries, fmt.Sprintf("{name: %s, members: {%s}}", jsString(o.Name), strings.Join(members, ", ")))
+	}
+
+	return "[" + strings.Join(entries, ", ") + "]"
+}
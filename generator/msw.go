@@ -0,0 +1,164 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// MSWLibrary is the runtime module used by the generated msw handlers, see Options.MSW. The handlers read and
+// write the bodies of the selected protocol, like the generated servers.
+func MSWLibrary(protocol string) *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {http, HttpHandler, HttpResponse} from 'msw';
+import {TwirpError, TwirpErrorCode, httpStatus} from './twirp';
+
+// MSWResponse is the response of an rpc method of an msw handler, which is a canned response or a handler that is
+// called with the request, like the responses of a generated mock client.
+export type MSWResponse<Req, Resp> = Resp | ((req: Req) => Resp | Promise<Resp>);
+
+const errorResponse = (err: any): Response => {
+    const te = err instanceof TwirpError ? err : new TwirpError({code: TwirpErrorCode.Internal, msg: String(err && err.message || err)});
+
+    return HttpResponse.json({code: te.code, msg: te.message, meta: te.meta}, {status: httpStatus[te.code] || 500});
+};
+` + mswBodyRuntime[protocol] + `
+// twirpHandler is an msw handler of the Twirp route of an rpc method, which decodes the request, and responds with
+// the encoded response, or with the TwirpError that the response rejects with. The encoder may be called with the
+// readonly interface of the output type, see readonly_responses. A method without a response responds with an
+// unimplemented TwirpError.
+export const twirpHandler = <Req, Resp>(url: string, decode: (body: any) => Req, encode: (resp: any) => any, response: MSWResponse<Req, Resp> | undefined): HttpHandler => {
+    return http.post(url, ({request}) => {
+        if (response === undefined) {
+            return errorResponse(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no response for " + new URL(request.url).pathname}));
+        }
+
+        return readBody(request)
+            .then((body) => {
+                const req = decode(body);
+                return typeof response === "function" ? (response as (req: Req) => Resp | Promise<Resp>)(req) : response;
+            })
+            .then((resp) => writeBody(encode(resp)))
+            .catch(errorResponse);
+    });
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_msw.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+// mswBodyRuntime reads the request bodies and writes the response bodies of the msw handlers for each protocol.
+var mswBodyRuntime = map[string]string{
+	ProtocolJSON: `
+const readBody = (request: Request): Promise<any> => {
+    return request.text().then((body) => {
+        try {
+            return JSON.parse(body || "{}");
+        } catch (e) {
+            throw new TwirpError({code: TwirpErrorCode.Malformed, msg: "the json request could not be decoded"});
+        }
+    });
+};
+
+const writeBody = (body: any): Response => {
+    return HttpResponse.json(body);
+};
+`,
+	ProtocolProtobuf: `
+const readBody = (request: Request): Promise<Uint8Array> => {
+    return request.arrayBuffer().then((body) => new Uint8Array(body));
+};
+
+const writeBody = (body: Uint8Array): Response => {
+    return new HttpResponse(body, {headers: {"Content-Type": "application/protobuf"}});
+};
+`,
+}
+
+const mswTemplate = `
+import {HttpHandler} from 'msw';
+{{- if not .DeclarationOnly}}
+import {joinURL} from '{{importPath "twirp"}}';
+import {twirpHandler} from '{{importPath "twirp_msw"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range $s := .Services}}
+// create{{.Name}}Handlers creates the msw handlers of the Twirp routes of {{.Name}}, which respond with the responses
+// of the methods like the {{.Name}}MockClient, e.g. setupServer(...create{{.Name}}Handlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+{{- if $.DeclarationOnly}}
+export declare const create{{.Name}}Handlers: (responses: {{.Name}}MockResponses, hostname?: string) => HttpHandler[];
+{{- else}}
+export const create{{.Name}}Handlers = (responses: {{.Name}}MockResponses, hostname: string = "*"): HttpHandler[] => {
+    return [
+        {{- range .Methods}}
+        twirpHandler(joinURL(hostname, {{$s.Name}}Methods.{{.Name}}.path), {{requestDecoder .}}, {{responseBody .}}, responses.{{.Name}}),
+        {{- end}}
+    ];
+};
+{{- end}}
+{{end}}
+`
+
+// mswModule is the module of the msw handlers for the services of a generated module, e.g. service_msw.ts
+type mswModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Services        []*Service
+}
+
+// renderMSW generates the msw handlers of the services of the module, which decode the requests and encode the
+// responses with the functions of the generated servers, see Options.MSW.
+func (ctx *APIContext) renderMSW() (*plugin.CodeGeneratorResponse_File, error) {
+	imports := ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, s := range ctx.Services {
+			if ctx.DeclarationOnly {
+				add(s.Name, s.Name+"MockResponses")
+				continue
+			}
+
+			add(s.Name, s.Name+"Methods", s.Name+"MockResponses")
+
+			for _, m := range s.Methods {
+				add(m.InputType, ctx.unmarshalFunc(m.InputType))
+				add(m.OutputType, ctx.marshalFunc(m.OutputType))
+			}
+		}
+	})
+
+	module := mswModule{DeclarationOnly: ctx.DeclarationOnly, Imports: imports, Services: ctx.Services}
+
+	funcMap := template.FuncMap{
+		"join":           strings.Join,
+		"requestDecoder": ctx.requestDecoder,
+		"responseBody":   ctx.responseBody,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("msw").Funcs(funcMap).Parse(mswTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_msw" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+)
+
+// requestArg is the name of the request parameter of the calls of a method, which is named after its input type,
+// e.g. hat for Hat, unless that is a reserved word, a name that the calls use too, or a name exported by the runtime
+// library, e.g. deleteRequest for Delete or joinURLRequest for JoinURL.
+func (ctx *APIContext) requestArg(in string) string {
+	arg := strings.ToLower(in[0:1]) + in[1:]
+	if reservedWords[arg] || callNames[arg] || ctx.runtimeNames()[arg] {
+		arg += "Request"
+	}
+
+	return arg
+}
+
+// callNames are the parameters and variables of the generated calls of the methods, which the request parameter of
+// a call would shadow, or be shadowed by.
+var callNames = map[string]bool{
+	"body": true, "buf": true, "callOptions": true, "client": true, "config": true, "context": true, "ctx": true,
+	"data": true, "endpoint": true, "errors": true, "options": true, "req": true, "resolve": true, "resp": true,
+	"response": true, "rest": true, "rule": true, "url": true,
+}
+
+// clientMembers are the members of the generated clients, mocks and Angular services, besides their rpc methods,
+// e.g. the use method of the interceptors of a client.
+var clientMembers = map[string]bool{
+	"client": true, "constructor": true, "debug": true, "headers": true, "hostname": true, "idempotencyKey": true,
+	"instrument": true, "interceptors": true, "pathPrefix": true, "responses": true, "retry": true, "timeout": true,
+	"timeoutMs": true, "transport": true, "use": true,
+}
+
+// clientMethodName is the name of the method of an rpc in the interface of its service, which is the name of the rpc
+// in the case of Options.MethodCase, unless that is a member of the generated clients, which prefixes it with the name
+// of the service, e.g. haberdasherTimeout.
+func clientMethodName(s *Service, path string, opts Options) string {
+	name := methodCase(path, opts)
+	if clientMembers[name] {
+		name = methodCase(s.Name+path, opts)
+	}
+
+	return name
+}
+
+// methodCase converts the name of an rpc, or of its service and the rpc, to the case of Options.MethodCase, e.g.
+// makeHat or MakeHat for MakeHat.
+func methodCase(name string, opts Options) string {
+	switch opts.MethodCase {
+	case MethodCasePascal:
+		return strings.ToUpper(name[0:1]) + name[1:]
+	case MethodCaseOriginal:
+		return name
+	}
+
+	return strings.ToLower(name[0:1]) + name[1:]
+}
+
+// methodKey is the key of a method signature of an interface, which is quoted when it is new, since new( declares
+// the construct signature of an interface rather than a method named new.
+func methodKey(name string) string {
+	if name == "new" {
+		return jsString(name)
+	}
+
+	return name
+}
+
+// runtimeExport matches the declarations of the names exported by the modules of the runtime library.
+var runtimeExport = regexp.MustCompile(`(?m)^export (?:declare )?(?:abstract )?(?:const|let|function|class|interface|type|enum) ([A-Za-z_$][A-Za-z0-9_$]*)`)
+
+// runtimeNames are the names exported by the modules of the runtime library that are generated with the options of
+// the module, which the generated modules import, along with the fetch function that the deno clients use.
+func (ctx *APIContext) runtimeNames() map[string]bool {
+	if ctx.runtime != nil {
+		return ctx.runtime
+	}
+
+	opts := ctx.Options
+	opts.RuntimePackage = ""
+
+	ctx.runtime = map[string]bool{"fetch": true}
+	for _, f := range RuntimeLibraries(opts) {
+		for _, m := range runtimeExport.FindAllStringSubmatch(f.GetContent(), -1) {
+			ctx.runtime[m[1]] = true
+		}
+	}
+
+	return ctx.runtime
+}
+
+// declares reports if a module declares or imports a name besides its rpc functions, which are the names of the
+// runtime library, the names imported from other modules, its enums and messages, the functions of its messages and
+// the clients of its services, e.g. createHat or createHaberdasherClient.
+func (ctx *APIContext) declares(name string) bool {
+	if ctx.runtimeNames()[name] {
+		return true
+	}
+
+	for _, imp := range ctx.Imports {
+		for _, n := range imp.Names {
+			if n == name {
+				return true
+			}
+		}
+	}
+
+	var names []string
+	for _, e := range ctx.Enums {
+		names = append(names, e.Name)
+	}
+
+	for _, m := range ctx.Models {
+		names = append(names, m.Name)
+		names = append(names, ctx.modelFunctions(m)...)
+	}
+
+	for _, mask := range ctx.fieldMasks() {
+		names = append(names, mask.Func())
+	}
+
+	for _, s := range ctx.Services {
+		for _, suffix := range []string{"Client", "Handlers", "Mock", "Router", "Subscriptions"} {
+			names = append(names, "create"+s.Name+suffix)
+		}
+	}
+
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// modelFunctions are the names of the functions that a module generates for a message with its options.
+func (ctx *APIContext) modelFunctions(m *Model) []string {
+	names := []string{"is" + m.Name}
+
+	if ctx.Builders {
+		names = append(names, "create"+m.Name, "build"+m.Name)
+	}
+
+	if ctx.MergeDiff {
+		names = append(names, "merge"+m.Name, "diff"+m.Name)
+	}
+
+	if m.Validate {
+		names = append(names, "validate"+m.Name)
+	}
+
+	if m.Redact {
+		names = append(names, "redact"+m.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestRequestArg(t *testing.T) {
+	tests := map[string]string{
+		"Hat":      "hat",
+		"Delete":   "deleteRequest",
+		"Function": "functionRequest",
+		"Options":  "optionsRequest",
+		"Req":      "reqRequest",
+		"JoinURL":  "joinURLRequest",
+	}
+
+	for in, expected := range tests {
+		if actual := (&APIContext{}).requestArg(in); actual != expected {
+			t.Errorf("expected the request parameter of %s to be %s, got %s", in, expected, actual)
+		}
+	}
+}
+
+func TestClientMethodName(t *testing.T) {
+	service := &Service{Name: "Haberdasher"}
+	if expected, actual := "haberdasherTimeout", clientMethodName(service, "Timeout", DefaultOptions()); actual != expected {
+		t.Errorf("expected the method of Timeout to be %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "makeHat", clientMethodName(service, "MakeHat", DefaultOptions()); actual != expected {
+		t.Errorf("expected the method of MakeHat to be %s, got %s", expected, actual)
+	}
+}
+
+func TestMethodKey(t *testing.T) {
+	if expected, actual := `"new"`, methodKey("new"); actual != expected {
+		t.Errorf("expected the method key of new to be %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "delete", methodKey("delete"); actual != expected {
+		t.Errorf("expected the method key of delete to be %s, got %s", expected, actual)
+	}
+}
+
+func TestMethodCase(t *testing.T) {
+	tests := []struct {
+		name       string
+		methodCase string
+		expected   string
+	}{
+		{"MakeHat", MethodCaseCamel, "makeHat"},
+		{"MakeHat", MethodCasePascal, "MakeHat"},
+		{"MakeHat", MethodCaseOriginal, "MakeHat"},
+		{"makeHat", MethodCasePascal, "MakeHat"},
+		{"makeHat", MethodCaseOriginal, "makeHat"},
+		{"make_hat", MethodCaseCamel, "make_hat"},
+	}
+
+	for _, tt := range tests {
+		if actual := methodCase(tt.name, Options{MethodCase: tt.methodCase}); actual != tt.expected {
+			t.Errorf("expected %s in the %s case to be %s, got %s", tt.name, tt.methodCase, tt.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// openAPIDir is the directory of the output directory that the OpenAPI documents of the services are generated into,
+// with Options.OpenAPI, e.g. openapi/twitch.twirp.example.Haberdasher.json.
+const openAPIDir = "openapi"
+
+// openAPIVersion is the version of OpenAPI of the documents, whose schemas are the JSON schemas of messageSchema.
+const openAPIVersion = "3.1.0"
+
+// twirpErrorCodes are the codes of the errors of the Twirp spec, which the Connect protocol shares.
+var twirpErrorCodes = []interface{}{
+	"canceled", "unknown", "invalid_argument", "malformed", "deadline_exceeded", "not_found", "bad_route",
+	"already_exists", "permission_denied", "unauthenticated", "resource_exhausted", "failed_precondition", "aborted",
+	"out_of_range", "unimplemented", "internal", "unavailable", "data_loss",
+}
+
+type openAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       openAPIInfo                 `json:"info"`
+	Paths      map[string]openAPIPathItem  `json:"paths"`
+	Components map[string]schemaProperties `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// Version is the version of the document, which protos do not declare
+	Version string `json:"version"`
+}
+
+// openAPIPathItem is the route of an rpc method, which is always called with POST.
+type openAPIPathItem struct {
+	Post openAPIOperation `json:"post"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Description string                     `json:"description,omitempty"`
+	RequestBody openAPIBody                `json:"requestBody"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIBody struct {
+	Required bool                        `json:"required"`
+	Content  map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+// walk calls visit with the schema and each of its subschemas.
+func (s *jsonSchema) walk(visit func(*jsonSchema)) {
+	if s == nil {
+		return
+	}
+
+	visit(s)
+
+	for _, sub := range []*jsonSchema{s.Items, s.PropertyNames, s.AdditionalProperties, s.Not} {
+		sub.walk(visit)
+	}
+
+	for _, p := range s.Properties {
+		p.Schema.walk(visit)
+	}
+
+	for _, list := range [][]*jsonSchema{s.AnyOf, s.OneOf, s.AllOf} {
+		for _, sub := range list {
+			sub.walk(visit)
+		}
+	}
+}
+
+// openAPIComponents collects the schemas of the messages of the requests and responses of a document, along with
+// the schemas of the messages of their fields, whose $refs are rewritten to the components of the document.
+type openAPIComponents struct {
+	models  map[string]*Model
+	enums   map[string]*Enum
+	seen    map[string]bool
+	schemas schemaProperties
+}
+
+// ref adds the schema of a message to the components, and returns the $ref of the schema.
+func (c *openAPIComponents) ref(name string) *jsonSchema {
+	if !c.seen[name] {
+		c.seen[name] = true
+
+		schema := &jsonSchema{Type: "object"}
+		if m, ok := c.models[name]; ok {
+			schema = messageSchema(m, c.enums)
+			schema.Schema = ""
+		}
+
+		c.schemas = append(c.schemas, schemaProperty{name, schema})
+
+		schema.walk(func(s *jsonSchema) {
+			if s.Ref != "" {
+				s.Ref = c.ref(strings.TrimSuffix(s.Ref, ".json")).Ref
+			}
+		})
+	}
+
+	return &jsonSchema{Ref: "#/components/schemas/" + name}
+}
+
+// errorSchema is the name and the schema of the JSON of the errors of the protocol, which are the errors of the Twirp
+// spec, or the errors of the Connect protocol with ProtocolConnect.
+func errorSchema(protocol string) (string, *jsonSchema) {
+	if protocol == ProtocolConnect {
+		return "ConnectError", &jsonSchema{
+			Type: "object",
+			Properties: schemaProperties{
+				{"code", &jsonSchema{Type: "string", Enum: twirpErrorCodes}},
+				{"message", &jsonSchema{Type: "string"}},
+				{"details", &jsonSchema{Type: "array", Items: &jsonSchema{
+					Type: "object",
+					Properties: schemaProperties{
+						{"type", &jsonSchema{Type: "string"}},
+						{"value", &jsonSchema{Type: "string", ContentEncoding: "base64"}},
+						{"debug", &jsonSchema{}},
+					},
+				}}},
+			},
+			Required: []string{"code"},
+		}
+	}
+
+	return "TwirpError", &jsonSchema{
+		Type: "object",
+		Properties: schemaProperties{
+			{"code", &jsonSchema{Type: "string", Enum: twirpErrorCodes}},
+			{"msg", &jsonSchema{Type: "string"}},
+			{"meta", &jsonSchema{Type: "object", AdditionalProperties: &jsonSchema{Type: "string"}}},
+		},
+		Required: []string{"code", "msg"},
+	}
+}
+
+// openAPIDocument generates the OpenAPI document of the JSON routes of a service, whose requests and responses are
+// the JSON of its messages, and whose errors are the errors of the protocol. The schemas of the messages are the
+// JSON schemas of messageSchema, and are found in models and enums, which are the messages and enums of all of the
+// files, since the messages of a service may be declared in the files it imports.
+func (ctx *APIContext) openAPIDocument(s *Service, models map[string]*Model, enums map[string]*Enum) openAPIDocument {
+	components := &openAPIComponents{models: models, enums: enums, seen: make(map[string]bool)}
+	errorName, errorBody := errorSchema(ctx.Protocol)
+
+	body := func(empty bool, name string) map[string]openAPIMediaType {
+		// google.protobuf.Empty is not a model, and is sent as {}
+		schema := &jsonSchema{Type: "object"}
+		if !empty {
+			schema = components.ref(name)
+		}
+
+		return map[string]openAPIMediaType{"application/json": {Schema: schema}}
+	}
+
+	doc := openAPIDocument{
+		OpenAPI: openAPIVersion,
+		Info: openAPIInfo{
+			Title:       s.FullName(),
+			Description: strings.TrimSpace(s.Comment),
+			Version:     "1.0.0",
+		},
+		Paths: make(map[string]openAPIPathItem),
+	}
+
+	for _, m := range s.Methods {
+		doc.Paths[ctx.TwirpPrefix+"/"+s.FullName()+"/"+m.Path] = openAPIPathItem{Post: openAPIOperation{
+			OperationID: s.Name + "_" + m.Path,
+			Description: strings.TrimSpace(m.Comment),
+			RequestBody: openAPIBody{Required: true, Content: body(m.EmptyRequest, m.InputType)},
+			Responses: map[string]openAPIResponse{
+				"200": {Description: "the response of " + m.Path, Content: body(m.EmptyResponse, m.OutputType)},
+				"default": {Description: "the error of a call that failed", Content: map[string]openAPIMediaType{
+					"application/json": {Schema: &jsonSchema{Ref: "#/components/schemas/" + errorName}},
+				}},
+			},
+		}}
+	}
+
+	components.schemas = append(components.schemas, schemaProperty{errorName, errorBody})
+	doc.Components = map[string]schemaProperties{"schemas": components.schemas}
+
+	return doc
+}
+
+// renderOpenAPI generates the OpenAPI document of each service of the file, see openAPIDocument.
+func (ctx *APIContext) renderOpenAPI(models map[string]*Model, enums map[string]*Enum) ([]*plugin.CodeGeneratorResponse_File, error) {
+	var files []*plugin.CodeGeneratorResponse_File
+
+	for _, s := range ctx.Services {
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(ctx.openAPIDocument(s, models, enums)); err != nil {
+			return nil, err
+		}
+
+		cf := &plugin.CodeGeneratorResponse_File{}
+		cf.Name = proto.String(openAPIDir + "/" + s.FullName() + ".json")
+		cf.Content = proto.String(b.String())
+
+		files = append(files, cf)
+	}
+
+	return files, nil
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// FeatureProto3Optional is the CodeGeneratorResponse feature flag reported by plugins that support proto3 optional fields.
+const FeatureProto3Optional = 1
+
+// number of the proto3_optional field of FieldDescriptorProto, which is newer than the descriptors of golang/protobuf v1.3
+const fieldProto3Optional = 17
+
+// isProto3Optional reports if a field is marked optional in a proto3 file. The proto3_optional field is
+// not known to this version of golang/protobuf, so it is read from the unrecognized fields of the descriptor.
+func isProto3Optional(f *descriptor.FieldDescriptorProto) bool {
+	b := proto.NewBuffer(f.XXX_unrecognized)
+
+	for {
+		key, err := b.DecodeVarint()
+		if err != nil {
+			return false
+		}
+
+		switch key & 7 {
+		case wireVarint:
+			v, err := b.DecodeVarint()
+			if err != nil {
+				return false
+			}
+
+			if key>>3 == fieldProto3Optional {
+				return v != 0
+			}
+		case wireFixed64:
+			_, err = b.DecodeFixed64()
+		case wireBytes:
+			_, err = b.DecodeRawBytes(false)
+		case wireFixed32:
+			_, err = b.DecodeFixed32()
+		default:
+			return false
+		}
+
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// syntheticOneofs returns the indexes of the oneofs that protoc adds for each proto3 optional field,
+// which are not generated as oneofs. Synthetic oneofs are always declared after all other oneofs.
+func syntheticOneofs(m *descriptor.DescriptorProto) map[int32]bool {
+	synthetic := make(map[int32]bool)
+
+	for _, f := range m.GetField() {
+		if f.OneofIndex != nil && isProto3Optional(f) {
+			synthetic[f.GetOneofIndex()] = true
+		}
+	}
+
+	return synthetic
+}
+
+// SupportedFeatures encodes the supported_features field of a CodeGeneratorResponse, which is also
+// newer than golang/protobuf v1.3, so it can be added to the unrecognized fields of the response.
+func SupportedFeatures() []byte {
+	b := proto.NewBuffer(nil)
+	_ = b.EncodeVarint(2<<3 | wireVarint)
+	_ = b.EncodeVarint(FeatureProto3Optional)
+
+	return b.Bytes()
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewField_Optional(t *testing.T) {
+	b := proto.NewBuffer(nil)
+	_ = b.EncodeVarint(fieldProto3Optional<<3 | wireVarint)
+	_ = b.EncodeVarint(1)
+
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:             proto.String("color"),
+		Number:           proto.Int32(3),
+		Type:             descriptor.FieldDescriptorProto_TYPE_ENUM.Enum(),
+		TypeName:         proto.String(".Color"),
+		OneofIndex:       proto.Int32(0),
+		XXX_unrecognized: b.Bytes(),
+	}, typeRegistry{}, Options{Int64: Int64Number, Defaults: DefaultsZero})
+
+	if !f.IsOptional || f.Zero != "" {
+		t.Fatalf("expected an optional field without a default, got %+v", f)
+	}
+
+	if expected, actual := "m.color === undefined ? undefined : Color[m.color]", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "m.color === undefined ? undefined : enumFromJSON<Color>(Color, m.color)", parse(f); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "if (m.color !== undefined) { w.tag(3, 0).int32(m.color); }", encodeField(f); actual != expected {
+		t.Errorf("expected encodeField %s, got %s", expected, actual)
+	}
+
+	if isProto3Optional(&descriptor.FieldDescriptorProto{Name: proto.String("plain")}) {
+		t.Error("expected a field without proto3_optional not to be optional")
+	}
+}
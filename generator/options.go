@@ -0,0 +1,825 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	ProtocolJSON     = "json"
+	ProtocolProtobuf = "protobuf"
+	// ProtocolGRPCWeb sends the messages in the protobuf binary format with the gRPC-web protocol, rather than Twirp
+	ProtocolGRPCWeb = "grpcweb"
+	// ProtocolConnect sends the messages in JSON with the unary calls of the Connect protocol, rather than Twirp
+	ProtocolConnect = "connect"
+)
+
+// typescript representations of 64 bit integers
+const (
+	Int64Number = "number"
+	Int64String = "string"
+	Int64BigInt = "bigint"
+)
+
+// typescript representations of google.protobuf.Duration
+const (
+	DurationString = "string"
+	DurationObject = "object"
+)
+
+// JSON representations of enum values
+const (
+	EnumsName   = "name"
+	EnumsNumber = "number"
+)
+
+// names of the fields in JSON
+const (
+	JSONNamesOriginal = "original"
+	JSONNamesCamel    = "camel"
+)
+
+// names of the properties of the fields of messages
+const (
+	FieldNamesCamel = "camel"
+	FieldNamesProto = "proto"
+)
+
+// names of the methods of the services
+const (
+	MethodCaseCamel    = "camel"
+	MethodCasePascal   = "pascal"
+	MethodCaseOriginal = "original"
+)
+
+// values of absent scalar fields when unmarshalling proto3 JSON
+const (
+	DefaultsUndefined = "undefined"
+	DefaultsZero      = "zero"
+)
+
+// separators of the names of nested types and their parent messages
+const (
+	NestedNamesConcat     = "concat"
+	NestedNamesUnderscore = "underscore"
+)
+
+var nestedSeparators = map[string]string{
+	NestedNamesConcat:     "",
+	NestedNamesUnderscore: "_",
+}
+
+// typescript representations of messages
+const (
+	ModelsInterfaces = "interfaces"
+	ModelsClasses    = "classes"
+)
+
+// styles of the generated clients, see Options.ClientStyle
+const (
+	ClientStyleClass     = "class"
+	ClientStyleFunctions = "functions"
+)
+
+// message libraries that adapter functions are generated for, see Options.Interop
+const (
+	InteropNone       = "none"
+	InteropProtobufJs = "protobufjs"
+	InteropProtobufTs = "protobuf-ts"
+)
+
+// transports of the subscription clients, see Options.Subscriptions
+const (
+	SubscriptionsNone      = "none"
+	SubscriptionsSSE       = "sse"
+	SubscriptionsWebSocket = "websocket"
+)
+
+// module systems of the generated package
+const (
+	ModuleCommonJS = "commonjs"
+	ModuleES6      = "es6"
+	ModuleUMD      = "umd"
+)
+
+// runtimes that the generated clients send their requests from
+const (
+	TargetBrowser = "browser"
+	TargetNode    = "node"
+	TargetDeno    = "deno"
+)
+
+// layouts of the generated modules in the output directory
+const (
+	PathsFlat           = "flat"
+	PathsSourceRelative = "source_relative"
+)
+
+// Options are set by the plugin parameter, as comma separated key=value pairs, e.g.
+//
+//	protoc --twirp_typescript_out=protocol=protobuf,int64=string:./ts_client ./service.proto
+//
+// See the README for the documentation of each option.
+type Options struct {
+	// PackageName is the name of the npm package, when the generated code is published as a package
+	PackageName string
+	// Module is ModuleCommonJS, ModuleES6, or ModuleUMD, and selects the module system that the generated package is compiled to
+	Module string
+	// Target is TargetBrowser, TargetNode, or TargetDeno, and selects the runtime of the generated clients. Node clients
+	// send their requests with nodeTransport by default, see TransportLibrary, and Deno clients with the global fetch.
+	// Deno modules are imported with their .ts extension, see runtimeImportPath
+	Target string
+	// Protocol is ProtocolJSON or ProtocolProtobuf, and selects the Twirp content type used by the generated clients,
+	// or ProtocolGRPCWeb and ProtocolConnect, whose clients call the gRPC-web or Connect routes of the services, e.g.
+	// of a gRPC server behind a proxy, or of a connect-go server
+	Protocol string
+	// Int64 is Int64Number, Int64String, or Int64BigInt, and selects the typescript type of 64 bit integer fields
+	Int64 string
+	// Duration is DurationString or DurationObject, and selects the typescript type of google.protobuf.Duration fields
+	Duration string
+	// Server generates a handler interface and router for each service, see ServerLibrary
+	Server bool
+	// Paths is PathsFlat or PathsSourceRelative, and selects if the directories of the proto files are kept in the output directory
+	Paths string
+	// ServiceModules generates each service into its own module, which imports the messages from the module of its proto file
+	ServiceModules bool
+	// Barrels generates an index.ts for each proto package, which exports its modules and the packages nested in it as
+	// namespaces, and an index.ts of the output directory that exports the top-level packages, see createBarrels
+	Barrels bool
+	// DeclarationOnly generates a declaration file (.d.ts) for each proto file instead of a module, see declarationTemplate
+	DeclarationOnly bool
+	// Angular generates a module of Angular services for the services of each proto file, see renderAngular
+	Angular bool
+	// ReactHooks generates a module of React hooks for the services of each proto file, see renderHooks
+	ReactHooks bool
+	// TanStackQuery generates a module of TanStack Query options for the services of each proto file, see renderQueries
+	TanStackQuery bool
+	// MSW generates a module of msw handlers of the Twirp routes of the services of each proto file, e.g.
+	// service_msw.ts, which respond with the responses of the methods like the mock clients, see renderMSW
+	MSW bool
+	// Pact generates a module of contract test helpers for each proto file, e.g. service_pact.ts, with an example
+	// builder for each message and a pact interaction for each rpc method, see renderPact
+	Pact bool
+	// Builders generates a create function for each message, e.g. createHat, which makes a message whose fields are
+	// their proto3 default values unless they are set by its argument, see builderValue, and a builder for each rpc
+	// request, e.g. buildHatRequest, whose build method typechecks once the required fields are set, see HasBuilder
+	Builders bool
+	// MergeDiff generates a merge and a diff function for each message, e.g. mergeHat and diffHat, which merge a patch
+	// into a message and find the FieldMask paths of the fields that differ between two messages, see mergeFields
+	MergeDiff bool
+	// Reflection generates a module of the descriptor of each proto file, e.g. service_reflection.ts, with the field
+	// numbers, types and labels of its messages and the methods of its services, see renderReflection
+	Reflection bool
+	// Fakes generates a module of fake factories for the messages of each proto file, e.g. service_fakes.ts, which
+	// make messages with deterministic fake values for stories and tests, see renderFakes
+	Fakes bool
+	// Pagination generates a module of pagination helpers for the list methods of the services of each proto file,
+	// e.g. service_pagination.ts, which iterate over the pages of the results of a method, see renderPagination
+	Pagination bool
+	// Cache generates a module of caching clients for the services of each proto file with methods without side
+	// effects, e.g. service_cache.ts, which cache the responses of the methods for a ttl, see renderCache
+	Cache bool
+	// Subscriptions is SubscriptionsNone, SubscriptionsSSE or SubscriptionsWebSocket, and generates a module of
+	// subscription clients for the subscription methods of the services of each proto file, e.g.
+	// service_subscriptions.ts, which receive the messages of a connection to an endpoint, see renderSubscriptions
+	Subscriptions string
+	// Validate makes the generated clients check the protoc-gen-validate rules of a request before sending it, and
+	// reject calls with an invalid request with an invalid_argument TwirpError, see parseValidations
+	Validate bool
+	// REST generates a method that calls the REST route of the google.api.http option of an rpc method, alongside
+	// the method that calls it with Twirp, e.g. makeHatRest, see RESTLibrary
+	REST bool
+	// ReadonlyResponses makes the client methods return a deep readonly interface of their response messages, e.g.
+	// ReadonlyHat, whose fields are readonly and whose arrays and maps cannot be changed, see readonlyType
+	ReadonlyResponses bool
+	// JSONSchema generates a JSON schema of the proto3 JSON of each message into the schemas directory, see messageSchema
+	JSONSchema bool
+	// OpenAPI generates an OpenAPI document of the JSON routes of each service into the openapi directory, see
+	// openAPIDocument
+	OpenAPI bool
+	// Zod generates a module of zod schemas of the proto3 JSON of the messages of each proto file, e.g. service_zod.ts,
+	// which the clients check the JSON of their responses with, see renderZod
+	Zod bool
+	// IOTS generates a module of io-ts codecs of the proto3 JSON of the messages of each proto file, e.g.
+	// service_io_ts.ts, with a decode function for each message that returns the errors of invalid JSON, see renderIOTS
+	IOTS bool
+	// Interop is InteropNone, InteropProtobufJs or InteropProtobufTs, and generates a module of functions that convert
+	// the messages to and from the messages of that library, e.g. service_protobufjs.ts, see renderInterop
+	Interop string
+	// ModelsOnly generates the messages and their JSON functions without the services, e.g. for messages that are
+	// sent over websockets, see markAllModels
+	ModelsOnly bool
+	// ClientStyle is ClientStyleClass or ClientStyleFunctions, and selects if the clients are generated as a class for
+	// each service, or as a function for each rpc method that bundlers can drop when it is not used
+	ClientStyle string
+	// MessageModels is ModelsInterfaces or ModelsClasses, and selects if messages are generated as interfaces, or as
+	// classes with a constructor and clone, equals, fromJSON and toJSON methods
+	MessageModels string
+	// TwirpPrefix is the path prefix of the Twirp routes, e.g. /twirp for /twirp/<package>.<Service>/<Method>, or of the
+	// gRPC-web and Connect routes with ProtocolGRPCWeb and ProtocolConnect, which have no prefix by default
+	TwirpPrefix string
+	// Enums is EnumsName or EnumsNumber, and selects if enum values are sent as their name or number in JSON
+	Enums string
+	// JSONNames is JSONNamesOriginal or JSONNamesCamel, and selects if the fields of messages are sent with their
+	// original proto names or their lowerCamelCase JSON names. The JSON of a message is parsed from either name.
+	JSONNames string
+	// FieldNames is FieldNamesCamel or FieldNamesProto, and selects if the properties of the fields of messages are
+	// named in camelCase or with the original proto names
+	FieldNames string
+	// MethodCase is MethodCaseCamel, MethodCasePascal or MethodCaseOriginal, and selects if the methods of the services
+	// and their rpc functions are named in camelCase, in PascalCase, or with the names of the rpcs in the proto file
+	MethodCase string
+	// Defaults is DefaultsUndefined or DefaultsZero, and selects if the JSON unmarshal functions fill in the
+	// proto3 zero value of scalar fields that are absent from the JSON
+	Defaults string
+	// RuntimePackage is the npm package of a shared runtime, which is imported instead of generating the runtime modules, see RuntimeLibraries
+	RuntimePackage string
+	// TemplateDir is a directory of *.tmpl files that override or extend the template of each module, see
+	// parseTemplates
+	TemplateDir string
+	// Banner prepends a comment with the generation metadata to each generated typescript file, see Banner
+	Banner bool
+	// LicenseFile is a file of license text that is added to the banners, e.g. LICENSE_HEADER.txt
+	LicenseFile string
+	// PluginVersion and CompilerVersion are the versions of the plugin and protoc in the banners, which are set by
+	// the plugin from its build and from the request instead of the parameters
+	PluginVersion   string
+	CompilerVersion string
+	// NestedNames is NestedNamesConcat or NestedNamesUnderscore, and selects how the names of nested messages and
+	// enums are joined to the names of their parent messages, e.g. OuterInner or Outer_Inner
+	NestedNames string
+	// ModuleNames maps the names of proto files to the names of their generated modules, e.g. api/v1/service.proto
+	// to clients/api, which are set by the M parameters, e.g. Mapi/v1/service.proto=clients/api, see tsModuleName
+	ModuleNames map[string]string
+	// Namespaces maps the names of proto files to the namespaces that the index.ts of the package exports their
+	// modules as, which are set by the N parameters, e.g. Napi/v1/service.proto=Api, see CreatePackageIndex
+	Namespaces map[string]string
+}
+
+// DefaultOptions are used for each option that is not set by the plugin parameter.
+func DefaultOptions() Options {
+	return Options{
+		Module:        ModuleCommonJS,
+		Protocol:      ProtocolJSON,
+		Target:        TargetBrowser,
+		Int64:         Int64Number,
+		MessageModels: ModelsInterfaces,
+		ClientStyle:   ClientStyleClass,
+		Duration:      DurationString,
+		Paths:         PathsFlat,
+		TwirpPrefix:   "/twirp",
+		Enums:         EnumsName,
+		Defaults:      DefaultsUndefined,
+		JSONNames:     JSONNamesOriginal,
+		FieldNames:    FieldNamesCamel,
+		MethodCase:    MethodCaseCamel,
+		NestedNames:   NestedNamesConcat,
+		Interop:       InteropNone,
+		Subscriptions: SubscriptionsNone,
+	}
+}
+
+// identifierPattern matches the typescript identifiers that a module may be exported as
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// packageNamePattern matches the names of npm packages, which may have a scope, e.g. @org/rpc-client
+var packageNamePattern = regexp.MustCompile(`^(@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+
+// option is a plugin parameter, with a description for Usage, and the values it accepts, or nil when any non-empty value is accepted.
+// Values that are not from a fixed set may be validated by check.
+type option struct {
+	usage  string
+	values []string
+	check  func(v string) error
+	set    func(o *Options, v string)
+}
+
+var options = map[string]option{
+	"pact": {
+		usage:  "generate a module of example builders of the messages and pact interactions of the rpc methods of each proto file",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Pact = v == "true" },
+	},
+	"package_name": {
+		usage: "name of the npm package of the generated code, which adds an index.ts, package.json and tsconfig.json",
+		check: func(v string) error {
+			if !packageNamePattern.MatchString(v) {
+				return fmt.Errorf("invalid package_name %q, must be a lowercase npm package name, e.g. rpc-client or @org/rpc-client", v)
+			}
+			return nil
+		},
+		set: func(o *Options, v string) { o.PackageName = v },
+	},
+	"merge_diff": {
+		usage:  "generate a merge function of each message, which merges a patch into a message, and a diff function, which finds the FieldMask paths of the fields that differ between two messages",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.MergeDiff = v == "true" },
+	},
+	"method_case": {
+		usage:  "names of the methods of the services, camelCase, PascalCase or the names of the rpcs in the proto file",
+		values: []string{MethodCaseCamel, MethodCasePascal, MethodCaseOriginal},
+		set:    func(o *Options, v string) { o.MethodCase = v },
+	},
+	"models": {
+		usage:  "typescript representation of messages, classes have clone, equals, fromJSON and toJSON methods",
+		values: []string{ModelsInterfaces, ModelsClasses},
+		set:    func(o *Options, v string) { o.MessageModels = v },
+	},
+	"models_only": {
+		usage:  "generate the messages and their JSON functions of each proto file without its services",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.ModelsOnly = v == "true" },
+	},
+	"client_style": {
+		usage:  "style of the generated clients, functions generates a tree-shakable function for each rpc method",
+		values: []string{ClientStyleClass, ClientStyleFunctions},
+		set:    func(o *Options, v string) { o.ClientStyle = v },
+	},
+	"interop": {
+		usage:  "generate a module of functions that convert the messages to and from the messages of protobuf.js or protobuf-ts",
+		values: []string{InteropNone, InteropProtobufJs, InteropProtobufTs},
+		set:    func(o *Options, v string) { o.Interop = v },
+	},
+	"io_ts": {
+		usage:  "generate a module of io-ts codecs of the JSON of the messages of each proto file, with a decode function for each message",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.IOTS = v == "true" },
+	},
+	"json_names": {
+		usage:  "names of the fields in the JSON that is sent, the JSON that is received may use either name",
+		values: []string{JSONNamesOriginal, JSONNamesCamel},
+		set:    func(o *Options, v string) { o.JSONNames = v },
+	},
+	"reflection": {
+		usage:  "generate a module of the descriptor of the messages, enums and services of each proto file, e.g. service_reflection.ts, for runtime reflection",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Reflection = v == "true" },
+	},
+	"fakes": {
+		usage:  "generate a module of fake factories of the messages of each proto file",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Fakes = v == "true" },
+	},
+	"field_names": {
+		usage:  "names of the properties of the fields of messages, camelCase or the original proto names",
+		values: []string{FieldNamesCamel, FieldNamesProto},
+		set:    func(o *Options, v string) { o.FieldNames = v },
+	},
+	"json_schema": {
+		usage:  "generate a JSON schema of the JSON of each message into the schemas directory",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.JSONSchema = v == "true" },
+	},
+	"openapi": {
+		usage:  "generate an OpenAPI document of the JSON routes of each service into the openapi directory",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.OpenAPI = v == "true" },
+	},
+	"license_file": {
+		usage: "file of license text that is added to the banner of each generated file, which implies banner=true",
+		set: func(o *Options, v string) {
+			o.LicenseFile = v
+			o.Banner = true
+		},
+	},
+	"module": {
+		usage:  "module system that the generated package is compiled to, requires package_name",
+		values: []string{ModuleCommonJS, ModuleES6, ModuleUMD},
+		set:    func(o *Options, v string) { o.Module = v },
+	},
+	"protocol": {
+		usage:  "content type of the Twirp requests of the generated clients, or grpcweb or connect for gRPC-web or Connect requests",
+		values: []string{ProtocolJSON, ProtocolProtobuf, ProtocolGRPCWeb, ProtocolConnect},
+		set:    func(o *Options, v string) { o.Protocol = v },
+	},
+	"enums": {
+		usage:  "JSON representation of enum values",
+		values: []string{EnumsName, EnumsNumber},
+		set:    func(o *Options, v string) { o.Enums = v },
+	},
+	"int64": {
+		usage:  "typescript type of 64 bit integer fields",
+		values: []string{Int64Number, Int64String, Int64BigInt},
+		set:    func(o *Options, v string) { o.Int64 = v },
+	},
+	"duration": {
+		usage:  "typescript type of google.protobuf.Duration fields",
+		values: []string{DurationString, DurationObject},
+		set:    func(o *Options, v string) { o.Duration = v },
+	},
+	"angular": {
+		usage:  "generate a module of Angular services for each proto file with services",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Angular = v == "true" },
+	},
+	"declaration_only": {
+		usage:  "generate declaration files (.d.ts) instead of modules",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.DeclarationOnly = v == "true" },
+	},
+	"barrels": {
+		usage:  "generate an index.ts of each proto package, and an index.ts that exports the packages as namespaces, e.g. import {users} from './gen'",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Barrels = v == "true" },
+	},
+	"banner": {
+		usage:  "prepend a comment with the plugin and protoc versions, the proto file and a DO NOT EDIT marker to each generated file",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Banner = v == "true" },
+	},
+	"defaults": {
+		usage:  "value of scalar fields that are absent from the JSON of a response, zero fills in the proto3 zero value",
+		values: []string{DefaultsUndefined, DefaultsZero},
+		set:    func(o *Options, v string) { o.Defaults = v },
+	},
+	"msw": {
+		usage:  "generate a module of msw request handlers for each proto file with services",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.MSW = v == "true" },
+	},
+	"subscriptions": {
+		usage:  "transport of the subscription clients of the rpc methods named Subscribe* or Watch*, none generates no subscription clients",
+		values: []string{SubscriptionsNone, SubscriptionsSSE, SubscriptionsWebSocket},
+		set:    func(o *Options, v string) { o.Subscriptions = v },
+	},
+	"nested_names": {
+		usage:  "separator of the names of nested messages and enums and the names of their parent messages",
+		values: []string{NestedNamesConcat, NestedNamesUnderscore},
+		set:    func(o *Options, v string) { o.NestedNames = v },
+	},
+	"builders": {
+		usage:  "generate a create function of each message, which makes a message whose fields are their proto3 default values unless they are set, and a builder of each request, which checks that its required fields are set at compile time",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Builders = v == "true" },
+	},
+	"cache": {
+		usage:  "generate a module of clients that cache the responses of the methods whose idempotency_level is NO_SIDE_EFFECTS for each proto file",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Cache = v == "true" },
+	},
+	"pagination": {
+		usage:  "generate a module of async iterators of the pages of the list methods that follow the AIP-158 conventions for each proto file",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Pagination = v == "true" },
+	},
+	"paths": {
+		usage:  "layout of the generated modules, source_relative keeps the directories of the proto files",
+		values: []string{PathsFlat, PathsSourceRelative},
+		set:    func(o *Options, v string) { o.Paths = v },
+	},
+	"react_hooks": {
+		usage:  "generate a module of React hooks for each proto file with services",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.ReactHooks = v == "true" },
+	},
+	"readonly_responses": {
+		usage:  "return deep readonly interfaces of the responses from the clients",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.ReadonlyResponses = v == "true" },
+	},
+	"rest": {
+		usage:  "generate methods that call the google.api.http routes of the rpc methods",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.REST = v == "true" },
+	},
+	"runtime_package": {
+		usage: "npm package of a shared runtime, which is imported instead of generating the runtime modules",
+		set:   func(o *Options, v string) { o.RuntimePackage = v },
+	},
+	"server": {
+		usage:  "generate a handler interface and router for each service",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Server = v == "true" },
+	},
+	"service_modules": {
+		usage:  "generate each service into its own module",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.ServiceModules = v == "true" },
+	},
+	"tanstack_query": {
+		usage:  "generate a module of TanStack Query options for each proto file with services",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.TanStackQuery = v == "true" },
+	},
+	"target": {
+		usage:  "runtime that the generated clients send their requests from",
+		values: []string{TargetBrowser, TargetNode, TargetDeno},
+		set:    func(o *Options, v string) { o.Target = v },
+	},
+	"validate": {
+		usage:  "check the protoc-gen-validate rules of requests before sending them",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Validate = v == "true" },
+	},
+	"zod": {
+		usage:  "generate a module of zod schemas of the JSON of the messages of each proto file, which check the responses of the clients",
+		values: []string{"true", "false"},
+		set:    func(o *Options, v string) { o.Zod = v == "true" },
+	},
+	"template_dir": {
+		usage: "directory of text/template files that override or extend the template of each module, e.g. header.tmpl",
+		set:   func(o *Options, v string) { o.TemplateDir = v },
+	},
+	"twirp_prefix": {
+		usage: "path prefix of the Twirp routes, /twirp by default",
+		check: func(v string) error {
+			if !strings.HasPrefix(v, "/") {
+				return fmt.Errorf("invalid twirp_prefix %q, must start with /", v)
+			}
+			return nil
+		},
+		set: func(o *Options, v string) { o.TwirpPrefix = strings.TrimRight(v, "/") },
+	},
+}
+
+// ParseOptions parses the parameter of a CodeGeneratorRequest. Unknown keys and invalid values
+// are errors, since a mistyped option would otherwise silently generate different code.
+func ParseOptions(parameter string) (Options, error) {
+	opts := DefaultOptions()
+	seen := make(map[string]bool)
+
+	if strings.TrimSpace(parameter) == "" {
+		return opts, nil
+	}
+
+	for _, pair := range strings.Split(parameter, ",") {
+		// buf joins the opt list of a plugin with commas, which may leave empty pairs, e.g. for a trailing comma
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return opts, fmt.Errorf("invalid parameter %q, expected key=value", pair)
+		}
+
+		key := strings.TrimSpace(kv[0])
+		value := strings.TrimSpace(kv[1])
+
+		// the M and N parameters are named by a proto file, e.g. Mapi/v1/service.proto=clients/api
+		if len(key) > 1 && (key[0] == 'M' || key[0] == 'N') {
+			if seen[key] {
+				return opts, fmt.Errorf("parameter %q is set more than once", key)
+			}
+			seen[key] = true
+
+			if err := setFileMapping(&opts, key[:1], key[1:], value); err != nil {
+				return opts, err
+			}
+
+			continue
+		}
+
+		opt, ok := options[key]
+		if !ok {
+			return opts, fmt.Errorf("unknown parameter %q, must be one of %s", key, strings.Join(optionNames(), ", "))
+		}
+
+		if seen[key] {
+			return opts, fmt.Errorf("parameter %q is set more than once", key)
+		}
+		seen[key] = true
+
+		if value == "" {
+			return opts, fmt.Errorf("parameter %q has no value", key)
+		}
+
+		if opt.values != nil && !contains(opt.values, value) {
+			return opts, fmt.Errorf("invalid %s %q, must be one of %q", key, value, opt.values)
+		}
+
+		if opt.check != nil {
+			if err := opt.check(value); err != nil {
+				return opts, err
+			}
+		}
+
+		opt.set(&opts, value)
+	}
+
+	// the module system is only used by the tsconfig.json of the generated package
+	if seen["module"] && !seen["package_name"] {
+		return opts, fmt.Errorf("parameter \"module\" requires package_name")
+	}
+
+	// the namespaces are only exported by the index.ts of the generated package
+	if len(opts.Namespaces) > 0 && !seen["package_name"] {
+		return opts, fmt.Errorf("parameter \"N\" requires package_name")
+	}
+
+	// the index.ts of the barrels replaces the index.ts of the package, and exports the modules by their packages
+	if len(opts.Namespaces) > 0 && opts.Barrels {
+		return opts, fmt.Errorf("parameter \"N\" is not supported with barrels=true")
+	}
+
+	// Deno imports the generated typescript modules, rather than a package compiled to javascript
+	if opts.Target == TargetDeno && seen["package_name"] {
+		return opts, fmt.Errorf("parameter \"package_name\" is not supported with target=deno")
+	}
+
+	// REST routes send and receive JSON, whose functions are not generated for the protobuf protocol
+	if opts.REST && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"rest\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	// the fromJSON and toJSON methods of the classes call the JSON functions of the messages
+	if opts.MessageModels == ModelsClasses && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"models=classes\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	// the zod schemas check the JSON of the responses, and are values that a declaration file cannot declare
+	if opts.Zod && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"zod\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	if opts.Zod && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"zod\" is not supported with declaration_only=true")
+	}
+
+	// likewise the decode functions of the io-ts codecs convert the JSON with the JSON functions of the messages
+	if opts.IOTS && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"io_ts\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	if opts.IOTS && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"io_ts\" is not supported with declaration_only=true")
+	}
+
+	// pact interactions are the JSON of the requests and responses
+	if opts.Pact && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"pact\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	if opts.Pact && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"pact\" is not supported with declaration_only=true")
+	}
+
+	// the helpers of the services have no services to generate without them
+	if opts.ModelsOnly {
+		for _, o := range []struct {
+			name string
+			set  bool
+		}{
+			{"server", opts.Server},
+			{"react_hooks", opts.ReactHooks},
+			{"tanstack_query", opts.TanStackQuery},
+			{"angular", opts.Angular},
+			{"msw", opts.MSW},
+			{"rest", opts.REST},
+			{"subscriptions", opts.Subscriptions != SubscriptionsNone},
+			{"pagination", opts.Pagination},
+			{"cache", opts.Cache},
+			{"openapi", opts.OpenAPI},
+		} {
+			if o.set {
+				return opts, fmt.Errorf("parameter %q is not supported with models_only=true", o.name)
+			}
+		}
+	}
+
+	// the Angular services wrap the generated client classes
+	if opts.Angular && opts.ClientStyle == ClientStyleFunctions {
+		return opts, fmt.Errorf("parameter \"angular\" is not supported with client_style=functions")
+	}
+
+	if opts.Fakes && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"fakes\" is not supported with declaration_only=true")
+	}
+
+	if opts.Interop != InteropNone && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"interop\" is not supported with declaration_only=true")
+	}
+
+	// the messages of the subscriptions are the JSON of the output types
+	if opts.Subscriptions != SubscriptionsNone && opts.ProtobufMessages() {
+		return opts, fmt.Errorf("parameter \"subscriptions\" is not supported with protocol=%s", opts.Protocol)
+	}
+
+	if opts.Subscriptions != SubscriptionsNone && opts.DeclarationOnly {
+		return opts, fmt.Errorf("parameter \"subscriptions\" is not supported with declaration_only=true")
+	}
+
+	// the gRPC-web routes have no JSON to document
+	if opts.OpenAPI && opts.Protocol == ProtocolGRPCWeb {
+		return opts, fmt.Errorf("parameter \"openapi\" is not supported with protocol=grpcweb")
+	}
+
+	// the routers, mock handlers and pact interactions are of Twirp routes, rather than gRPC-web or Connect routes
+	if opts.Protocol == ProtocolGRPCWeb || opts.Protocol == ProtocolConnect {
+		for _, o := range []struct {
+			name string
+			set  bool
+		}{
+			{"server", opts.Server},
+			{"msw", opts.MSW},
+			{"pact", opts.Pact},
+		} {
+			if o.set {
+				return opts, fmt.Errorf("parameter %q is not supported with protocol=%s", o.name, opts.Protocol)
+			}
+		}
+	}
+
+	// the gRPC-web and Connect routes are /<package>.<Service>/<Method>, unless a proxy serves them with a prefix
+	if (opts.Protocol == ProtocolGRPCWeb || opts.Protocol == ProtocolConnect) && !seen["twirp_prefix"] {
+		opts.TwirpPrefix = ""
+	}
+
+	return opts, nil
+}
+
+// setFileMapping sets the module name (M) or namespace (N) of a proto file.
+func setFileMapping(o *Options, kind string, file string, v string) error {
+	if v == "" {
+		return fmt.Errorf("parameter \"%s%s\" has no value", kind, file)
+	}
+
+	if kind == "N" {
+		if !identifierPattern.MatchString(v) {
+			return fmt.Errorf("invalid namespace %q of %s, must be a typescript identifier, e.g. Api", v, file)
+		}
+
+		if o.Namespaces == nil {
+			o.Namespaces = make(map[string]string)
+		}
+		o.Namespaces[file] = v
+
+		return nil
+	}
+
+	v = strings.TrimSuffix(v, ".ts")
+	if path.IsAbs(v) || path.Clean(v) != v || v == ".." || strings.HasPrefix(v, "../") {
+		return fmt.Errorf("invalid module %q of %s, must be a relative path in the output directory, e.g. clients/api", v, file)
+	}
+
+	if o.ModuleNames == nil {
+		o.ModuleNames = make(map[string]string)
+	}
+	o.ModuleNames[file] = v
+
+	return nil
+}
+
+// Usage describes each plugin parameter with the values it accepts and its default value, which is printed by the
+// -help flag of the plugin.
+func Usage() string {
+	var b strings.Builder
+
+	for _, name := range optionNames() {
+		opt := options[name]
+
+		values := "<value>"
+		if opt.values != nil {
+			values = strings.Join(opt.values, "|")
+		}
+
+		fmt.Fprintf(&b, "  %s=%s", name, values)
+		if def := defaultValue(opt); def != "" {
+			fmt.Fprintf(&b, " (default %s)", def)
+		}
+		fmt.Fprintf(&b, "\n        %s\n", opt.usage)
+	}
+
+	b.WriteString("  M<proto file>=<module>\n        name of the module generated for a proto file, e.g. Mapi/v1/service.proto=clients/api\n")
+	b.WriteString("  N<proto file>=<namespace>\n        namespace that index.ts exports the module of a proto file as, requires package_name\n")
+
+	return b.String()
+}
+
+// defaultValue returns the value of an option that leaves DefaultOptions unchanged, or "" for options without a fixed set of values.
+func defaultValue(opt option) string {
+	for _, v := range opt.values {
+		o := DefaultOptions()
+		opt.set(&o, v)
+
+		if reflect.DeepEqual(o, DefaultOptions()) {
+			return v
+		}
+	}
+
+	return ""
+}
+
+func optionNames() []string {
+	var names []string
+	for name := range options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+
+	return false
+}
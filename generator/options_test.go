@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestParseOptions(t *testing.T) {
+	opts, err := ParseOptions("angular=true,target=node,package_name=@twitch/haberdasher,module=es6, protocol=protobuf,int64=bigint,server=true,twirp_prefix=/api/rpc/,paths=source_relative,defaults=zero,nested_names=underscore,runtime_package=@acme/twirp,declaration_only=true,enums=number,react_hooks=true,tanstack_query=true,validate=true,readonly_responses=true,json_schema=true,json_names=camel,field_names=proto,method_case=pascal,msw=true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Options{
+		PackageName:       "@twitch/haberdasher",
+		Module:            ModuleES6,
+		Protocol:          ProtocolProtobuf,
+		Int64:             Int64BigInt,
+		Duration:          DurationString,
+		MessageModels:     ModelsInterfaces,
+		ClientStyle:       ClientStyleClass,
+		Server:            true,
+		Paths:             PathsSourceRelative,
+		TwirpPrefix:       "/api/rpc",
+		Defaults:          DefaultsZero,
+		NestedNames:       NestedNamesUnderscore,
+		RuntimePackage:    "@acme/twirp",
+		DeclarationOnly:   true,
+		Enums:             EnumsNumber,
+		ReactHooks:        true,
+		Angular:           true,
+		Target:            TargetNode,
+		TanStackQuery:     true,
+		Validate:          true,
+		ReadonlyResponses: true,
+		JSONSchema:        true,
+		Interop:           InteropNone,
+		Subscriptions:     SubscriptionsNone,
+		JSONNames:         JSONNamesCamel,
+		FieldNames:        FieldNamesProto,
+		MethodCase:        MethodCasePascal,
+		MSW:               true,
+	}
+
+	if !reflect.DeepEqual(opts, expected) {
+		t.Errorf("expected options %+v, got %+v", expected, opts)
+	}
+
+	if opts, err := ParseOptions(""); err != nil || !reflect.DeepEqual(opts, DefaultOptions()) {
+		t.Errorf("expected default options for an empty parameter, got %+v, %v", opts, err)
+	}
+
+	// the opt list of buf.gen.yaml may leave empty pairs in the parameter
+	if opts, err := ParseOptions("protocol=protobuf,,server=true,"); err != nil || opts.Protocol != ProtocolProtobuf || !opts.Server {
+		t.Errorf("expected empty pairs to be ignored, got %+v, %v", opts, err)
+	}
+
+	// the gRPC-web routes have no prefix, unless it is set
+	if opts, err := ParseOptions("protocol=grpcweb"); err != nil || opts.TwirpPrefix != "" || !opts.ProtobufMessages() {
+		t.Errorf("expected gRPC-web routes without a prefix, got %+v, %v", opts, err)
+	}
+
+	if opts, err := ParseOptions("protocol=grpcweb,twirp_prefix=/grpc"); err != nil || opts.TwirpPrefix != "/grpc" {
+		t.Errorf("expected gRPC-web routes with the /grpc prefix, got %+v, %v", opts, err)
+	}
+
+	if opts, err := ParseOptions("protocol=connect"); err != nil || opts.TwirpPrefix != "" || opts.ProtobufMessages() {
+		t.Errorf("expected Connect routes without a prefix, got %+v, %v", opts, err)
+	}
+}
+
+func TestParseOptions_FileMappings(t *testing.T) {
+	opts, err := ParseOptions("Mapi/v1/service.proto=clients/api.ts,Napi/v1/service.proto=Api,package_name=api")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := map[string]string{"api/v1/service.proto": "clients/api"}; !reflect.DeepEqual(opts.ModuleNames, expected) {
+		t.Errorf("expected module names %v, got %v", expected, opts.ModuleNames)
+	}
+
+	if expected := map[string]string{"api/v1/service.proto": "Api"}; !reflect.DeepEqual(opts.Namespaces, expected) {
+		t.Errorf("expected namespaces %v, got %v", expected, opts.Namespaces)
+	}
+
+	api := &descriptor.FileDescriptorProto{Name: proto.String("api/v1/service.proto")}
+	if name := tsModuleName(api, opts); name != "clients/api" {
+		t.Errorf("expected the module of api/v1/service.proto to be clients/api, got %s", name)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	usage := Usage()
+
+	for _, name := range optionNames() {
+		if options[name].usage == "" {
+			t.Errorf("expected parameter %q to have a usage", name)
+		}
+	}
+
+	for _, expected := range []string{
+		"  int64=number|string|bigint (default number)\n        typescript type of 64 bit integer fields\n",
+		"  server=true|false (default false)\n",
+		"  twirp_prefix=<value>\n",
+	} {
+		if !strings.Contains(usage, expected) {
+			t.Errorf("expected the usage to contain %q, got:\n%s", expected, usage)
+		}
+	}
+}
+
+func TestParseOptions_Errors(t *testing.T) {
+	tests := []struct {
+		parameter string
+		expected  string
+	}{
+		{"protocol", `invalid parameter "protocol", expected key=value`},
+		{"protcol=json", `unknown parameter "protcol", must be one of angular, banner, barrels, builders, cache, client_style, declaration_only, defaults, duration, enums, fakes, field_names, int64, interop, io_ts, json_names, json_schema, license_file, merge_diff, method_case, models, models_only, module, msw, nested_names, openapi, package_name, pact, pagination, paths, protocol, react_hooks, readonly_responses, reflection, rest, runtime_package, server, service_modules, subscriptions, tanstack_query, target, template_dir, twirp_prefix, validate, zod`},
+		{"protocol=xml", `invalid protocol "xml", must be one of ["json" "protobuf" "grpcweb" "connect"]`},
+		{"int64=string,int64=bigint", `parameter "int64" is set more than once`},
+		{"package_name=", `parameter "package_name" has no value`},
+		{"twirp_prefix=api", `invalid twirp_prefix "api", must start with /`},
+		{"module=es6", `parameter "module" requires package_name`},
+		{"target=deno,package_name=haberdasher", `parameter "package_name" is not supported with target=deno`},
+		{"rest=true,protocol=protobuf", `parameter "rest" is not supported with protocol=protobuf`},
+		{"models=classes,protocol=protobuf", `parameter "models=classes" is not supported with protocol=protobuf`},
+		{"Mapi.proto=../api", `invalid module "../api" of api.proto, must be a relative path in the output directory, e.g. clients/api`},
+		{"Mapi.proto=/api", `invalid module "/api" of api.proto, must be a relative path in the output directory, e.g. clients/api`},
+		{"Mapi.proto=a,Mapi.proto=b", `parameter "Mapi.proto" is set more than once`},
+		{"Mapi.proto=", `parameter "Mapi.proto" has no value`},
+		{"Napi.proto=api-v1,package_name=api", `invalid namespace "api-v1" of api.proto, must be a typescript identifier, e.g. Api`},
+		{"Napi.proto=Api", `parameter "N" requires package_name`},
+		{"Napi.proto=Api,package_name=api,barrels=true", `parameter "N" is not supported with barrels=true`},
+		{"zod=true,protocol=protobuf", `parameter "zod" is not supported with protocol=protobuf`},
+		{"zod=true,declaration_only=true", `parameter "zod" is not supported with declaration_only=true`},
+		{"io_ts=true,protocol=protobuf", `parameter "io_ts" is not supported with protocol=protobuf`},
+		{"io_ts=true,declaration_only=true", `parameter "io_ts" is not supported with declaration_only=true`},
+		{"pact=true,protocol=protobuf", `parameter "pact" is not supported with protocol=protobuf`},
+		{"pact=true,declaration_only=true", `parameter "pact" is not supported with declaration_only=true`},
+		{"fakes=true,declaration_only=true", `parameter "fakes" is not supported with declaration_only=true`},
+		{"angular=true,client_style=functions", `parameter "angular" is not supported with client_style=functions`},
+		{"models_only=true,server=true", `parameter "server" is not supported with models_only=true`},
+		{"msw=true,models_only=true", `parameter "msw" is not supported with models_only=true`},
+		{"models_only=true,pagination=true", `parameter "pagination" is not supported with models_only=true`},
+		{"models_only=true,cache=true", `parameter "cache" is not supported with models_only=true`},
+		{"interop=protobufjs,declaration_only=true", `parameter "interop" is not supported with declaration_only=true`},
+		{"subscriptions=sse,protocol=protobuf", `parameter "subscriptions" is not supported with protocol=protobuf`},
+		{"subscriptions=websocket,declaration_only=true", `parameter "subscriptions" is not supported with declaration_only=true`},
+		{"rest=true,protocol=grpcweb", `parameter "rest" is not supported with protocol=grpcweb`},
+		{"server=true,protocol=grpcweb", `parameter "server" is not supported with protocol=grpcweb`},
+		{"msw=true,protocol=grpcweb", `parameter "msw" is not supported with protocol=grpcweb`},
+		{"openapi=true,protocol=grpcweb", `parameter "openapi" is not supported with protocol=grpcweb`},
+		{"models_only=true,openapi=true", `parameter "openapi" is not supported with models_only=true`},
+		{"server=true,protocol=connect", `parameter "server" is not supported with protocol=connect`},
+		{"pact=true,protocol=connect", `parameter "pact" is not supported with protocol=connect`},
+		{"interop=protobufts", `invalid interop "protobufts", must be one of ["none" "protobufjs" "protobuf-ts"]`},
+		{"package_name=Haberdasher", `invalid package_name "Haberdasher", must be a lowercase npm package name, e.g. rpc-client or @org/rpc-client`},
+	}
+
+	for _, tt := range tests {
+		_, err := ParseOptions(tt.parameter)
+		if err == nil {
+			t.Errorf("expected an error for %q", tt.parameter)
+			continue
+		}
+
+		if err.Error() != tt.expected {
+			t.Errorf("expected error %q, got %q", tt.expected, err.Error())
+		}
+	}
+}
@@ -2,39 +2,70 @@ package generator
 
 import (
 	"bytes"
-	"html/template"
 	"path"
+	"sort"
+	"strings"
+	"text/template"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 )
 
 const indexTemplate = `
 {{- range .}}
-export * from './{{.}}';
+{{- if .Namespace}}
+import * as {{.Namespace}} from '{{.Module}}';
+export {{"{"}}{{.Namespace}}{{"}"}};
+{{- else}}
+export * from '{{.Module}}';
+{{- end}}
 {{end}}
 `
 
-func CreatePackageIndex(files []*plugin.CodeGeneratorResponse_File) (*plugin.CodeGeneratorResponse_File, error) {
+// indexExport is a module exported by the index.ts of the package, which is exported as a namespace when
+// its proto file has an N parameter, see Options.Namespaces. Module is its import path, e.g. ./service.
+type indexExport struct {
+	Module    string
+	Namespace string
+}
+
+// CreatePackageIndex generates the index.ts of the package, which exports each of the generated modules.
+func CreatePackageIndex(files []*plugin.CodeGeneratorResponse_File, opts Options) (*plugin.CodeGeneratorResponse_File, error) {
 	var names []string
 
+	namespaces := make(map[string]string)
+	for file, namespace := range opts.Namespaces {
+		namespaces[tsModuleName(&descriptor.FileDescriptorProto{Name: proto.String(file)}, opts)] = namespace
+	}
+
 	for _, f := range files {
 		filename := *f.Name
 
-		// myModule.ts => myModule
+		// myModule.ts => myModule, and myModule.d.ts => myModule
 		if path.Ext(filename) == ".ts" {
-			moduleName := filename[:len(filename)-len(path.Ext(filename))]
+			moduleName := strings.TrimSuffix(strings.TrimSuffix(filename, ".ts"), ".d")
 			names = append(names, moduleName)
 		}
 	}
 
+	// the modules are exported in sorted order, so the index is the same however the files were generated
+	sort.Strings(names)
+
 	t, err := template.New("index.ts").Parse(indexTemplate)
 	if err != nil {
 		return nil, err
 	}
 
+	var exports []indexExport
+	for _, name := range names {
+		exports = append(exports, indexExport{Module: "./" + name, Namespace: namespaces[name]})
+	}
+
 	b := bytes.NewBufferString("")
-	t.Execute(b, names)
+	if err := t.Execute(b, exports); err != nil {
+		return nil, err
+	}
 
 	cf := &plugin.CodeGeneratorResponse_File{}
 	cf.Name = proto.String("index.ts")
@@ -2,31 +2,85 @@ package generator
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 )
 
-func CreatePackageJSON(projectName string) *plugin.CodeGeneratorResponse_File {
+// CreatePackageJSON generates the package.json of the generated package. A package compiled to ES6 modules
+// is only imported by bundlers, which use the module field instead of main.
+//
+// React is a peer dependency of a package with React hooks, so the application's copy of React is used, and
+// likewise Angular and RxJS are peer dependencies of a package with Angular services, zod of a package with
+// zod schemas, io-ts and fp-ts of a package with io-ts codecs, and msw of a package with msw handlers. The peer
+// dependencies are also dev dependencies, since the prepare script compiles the package against them.
+func CreatePackageJSON(opts Options) *plugin.CodeGeneratorResponse_File {
+	entry := `"main": "index.js"`
+	if opts.Module == ModuleES6 {
+		entry = `"module": "index.js"`
+	}
+
+	var peers []string
+	var types []string
+	if opts.ReactHooks {
+		peers = append(peers, `"react": ">=16.8.0"`)
+		types = append(types, `"@types/react": "^18.0.0"`)
+	}
+
+	if opts.Target == TargetNode {
+		types = append(types, `"@types/node": "^18.0.0"`)
+	}
+
+	if opts.Angular {
+		peers = append(peers, `"@angular/common": ">=12.0.0"`, `"@angular/core": ">=12.0.0"`, `"rxjs": ">=6.5.0"`)
+	}
+
+	if opts.Zod {
+		peers = append(peers, `"zod": "^3.22.0"`)
+	}
+
+	if opts.IOTS {
+		peers = append(peers, `"fp-ts": "^2.16.0"`, `"io-ts": "^2.2.20"`)
+	}
+
+	if opts.MSW {
+		peers = append(peers, `"msw": "^2.0.0"`)
+	}
+
+	var peerDependencies string
+	if len(peers) > 0 {
+		peerDependencies = `
+  "peerDependencies": {
+    ` + strings.Join(peers, ",\n    ") + `
+  },`
+	}
+
+	// the dev dependencies are sorted by name like npm sorts them
+	devs := append(append([]string{`"typescript": "^5.4.0"`}, peers...), types...)
+	sort.Strings(devs)
+
 	content := fmt.Sprintf(`{
   "name": "%s",
   "version": "1.0.0",
-  "main": "index",
+  %s,
+  "types": "index.d.ts",
+  "sideEffects": false,
   "scripts": {
-    "prepare": "tsc"  
+    "prepare": "tsc"
   },
   "files": [
-    "*.js",
-    "*.d.ts"
+    "**/*.js",
+    "**/*.d.ts"
   ],
   "dependencies": {
-    "tslib": "^1.9.0"
-  },
+    "tslib": "^2.6.0"
+  },%s
   "devDependencies": {
-    "isomorphic-fetch": "^2.2.1",
-    "typescript": "^2.7.1"
+    %s
   }
 }
-`, projectName)
+`, opts.PackageName, entry, peerDependencies, strings.Join(devs, ",\n    "))
 
 	fileName := "package.json"
 	cf := &plugin.CodeGeneratorResponse_File{}
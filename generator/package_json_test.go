@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCreatePackageJSON(t *testing.T) {
+	tests := []struct {
+		params string
+		peers  []string
+	}{
+		{"package_name=hats", nil},
+		{"package_name=hats,react_hooks=true", []string{"react"}},
+		{"package_name=hats,angular=true,msw=true", []string{"@angular/common", "@angular/core", "rxjs", "msw"}},
+	}
+
+	for _, tt := range tests {
+		opts, err := ParseOptions(tt.params)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cf := CreatePackageJSON(opts)
+
+		var pkg struct {
+			PeerDependencies map[string]string `json:"peerDependencies"`
+			DevDependencies  map[string]string `json:"devDependencies"`
+		}
+		if err := json.Unmarshal([]byte(cf.GetContent()), &pkg); err != nil {
+			t.Fatalf("%s: invalid package.json: %v\n%s", tt.params, err, cf.GetContent())
+		}
+
+		if len(pkg.PeerDependencies) != len(tt.peers) {
+			t.Errorf("%s: expected the peer dependencies %v, got %v", tt.params, tt.peers, pkg.PeerDependencies)
+		}
+
+		// the prepare script compiles the package against its peers, so they are also dev dependencies
+		for _, peer := range tt.peers {
+			if pkg.DevDependencies[peer] == "" || pkg.DevDependencies[peer] != pkg.PeerDependencies[peer] {
+				t.Errorf("%s: expected %s to be a peer and dev dependency, got:\n%s", tt.params, peer, cf.GetContent())
+			}
+		}
+
+		if pkg.DevDependencies["typescript"] == "" {
+			t.Errorf("%s: expected typescript to be a dev dependency, got:\n%s", tt.params, cf.GetContent())
+		}
+	}
+}
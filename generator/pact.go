@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// PactLibrary is the runtime module used by the generated contract test helpers, see Options.Pact. The
+// interactions are plain objects of the pact specification, so they can be added to the mock provider of
+// @pact-foundation/pact without depending on it.
+func PactLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {TwirpErrorJSON, TwirpHeaders, httpStatus} from './twirp';
+
+// PactInteraction is a pact interaction of an rpc method, e.g. provider.addInteraction(makeHatInteraction(options)).
+export interface PactInteraction {
+    state?: string;
+    uponReceiving: string;
+    withRequest: {method: "POST"; path: string; headers: TwirpHeaders; body: any};
+    willRespondWith: {status: number; headers: TwirpHeaders; body: any};
+}
+
+// PactInteractionOptions are the request of a pact interaction, and its response or Twirp error.
+export interface PactInteractionOptions<Req, Resp> {
+    // state is the provider state of the interaction, e.g. "a hat exists"
+    state?: string;
+    // uponReceiving describes the interaction, which is the rpc method by default
+    uponReceiving?: string;
+    // headers are the headers of the request, in addition to its Content-Type
+    headers?: TwirpHeaders;
+    request: Req;
+    // response is the response of the interaction, unless it has an error
+    response?: Resp;
+    // error is the Twirp error of the interaction, e.g. {code: "not_found", msg: "no such hat"}
+    error?: TwirpErrorJSON;
+}
+
+// pactInteraction is the pact interaction of a Twirp route, whose request and response are the proto3 JSON of the
+// messages. The encoder of the response may be called with the readonly interface of the output type.
+export const pactInteraction = <Req, Resp>(path: string, description: string, options: PactInteractionOptions<Req, Resp>, encodeRequest: (req: Req) => any, encodeResponse: (resp: any) => any): PactInteraction => {
+    const headers: TwirpHeaders = {};
+    Object.keys(options.headers || {}).forEach((k) => headers[k] = (options.headers as TwirpHeaders)[k]);
+    headers["Content-Type"] = "application/json";
+
+    const interaction: PactInteraction = {
+        uponReceiving: options.uponReceiving || description,
+        withRequest: {method: "POST", path: path, headers: headers, body: encodeRequest(options.request)},
+        willRespondWith: options.error
+            ? {status: httpStatus[options.error.code] || 500, headers: {"Content-Type": "application/json"}, body: options.error}
+            : {status: 200, headers: {"Content-Type": "application/json"}, body: encodeResponse(options.response)},
+    };
+
+    if (options.state !== undefined) {
+        interaction.state = options.state;
+    }
+
+    return interaction;
+};
+
+// withOverrides copies the overridden fields of a message onto an example of it.
+export const withOverrides = <T>(example: T, overrides: Partial<T>): T => {
+    const m: {[key: string]: any} = example;
+    Object.keys(overrides).forEach((k) => m[k] = (overrides as any)[k]);
+
+    return m as T;
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_pact.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const pactTemplate = `
+import {PactInteraction, PactInteractionOptions, pactInteraction, withOverrides} from '{{importPath "twirp_pact"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// example{{.Name}} is an example of the {{.Name}} message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const example{{.Name}} = (overrides: Partial<{{.Name}}> = {}): {{.Name}} => {
+    return {{if $.Classes}}new {{.Name}}(withOverrides<Partial<{{.Name}}>>{{else}}withOverrides<{{.Name}}>{{end}}({ {{- exampleValues . -}} }, overrides){{if $.Classes}}){{end}};
+};
+{{end}}
+{{- range .Interactions}}
+{{- $request := printf "example%s()" .Method.InputType}}{{$requestType := .Method.InputType}}{{$encodeRequest := printf "%sToJSON" .Method.InputType}}
+{{- if .Method.EmptyRequest}}{{$request = "{}"}}{{$requestType = "{}"}}{{$encodeRequest = "() => ({})"}}{{end}}
+// {{.Name}}Interaction is a pact interaction of {{.Service.Name}}.{{.Method.Path}}, e.g.
+// provider.addInteraction({{.Name}}Interaction({request: {{$request}}{{if not .Method.EmptyResponse}}, response: example{{.Method.OutputType}}(){{end}}}))
+export const {{.Name}}Interaction = (options: PactInteractionOptions<{{$requestType}}, {{.Method.ResponseType}}>): PactInteraction => {
+    return pactInteraction("{{$.TwirpPrefix}}/{{.Service.FullName}}/{{.Method.Path}}", "a request to {{.Service.Name}}.{{.Method.Path}}", options, {{$encodeRequest}}, {{if .Method.EmptyResponse}}() => ({}){{else}}{{.Method.OutputType}}ToJSON{{end}});
+};
+{{end}}`
+
+// pactModuleName is the name of the module of the contract test helpers of a module, e.g. service_pact
+func pactModuleName(module string) string {
+	return module + "_pact"
+}
+
+// pactModule is the module of the contract test helpers of a proto file, see renderPact.
+type pactModule struct {
+	Classes      bool
+	TwirpPrefix  string
+	Imports      []*Import
+	Models       []*Model
+	Interactions []Query
+}
+
+// exampleValues generates the fields of the example of a model, which are the proto3 default values of its
+// fields, and the examples of the messages of its singular message fields. Optional fields, which include the
+// recursive message fields, are not set, so the examples of recursive messages are finite.
+func exampleValues(m *Model) string {
+	var values []string
+
+	for _, f := range m.Fields {
+		if f.IsOptional {
+			continue
+		}
+
+		values = append(values, f.Name+": "+fieldDefault(f, "example"))
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// renderPact generates the contract test helpers of the module, e.g. service_pact.ts, with Options.Pact. Each
+// message has an example builder, and each rpc method has a pact interaction, which are named after the rpc
+// methods like the TanStack Query helpers, e.g. makeHatInteraction.
+func (ctx *APIContext) renderPact() (*plugin.CodeGeneratorResponse_File, error) {
+	module := pactModule{Classes: ctx.Classes(), TwirpPrefix: ctx.TwirpPrefix}
+
+	for _, m := range ctx.Models {
+		if !m.Primitive {
+			module.Models = append(module.Models, m)
+		}
+	}
+
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			name := ctx.methodName(s, m)
+			module.Interactions = append(module.Interactions, Query{Name: strings.ToLower(name[0:1]) + name[1:], Service: s, Method: m})
+		}
+	}
+
+	module.Imports = ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, m := range module.Models {
+			add(m.Name, m.Name)
+		}
+
+		for _, q := range module.Interactions {
+			add(q.Method.InputType, q.Method.InputType, q.Method.InputType+"ToJSON")
+			add(q.Method.OutputType, q.Method.ResponseType, q.Method.OutputType+"ToJSON")
+		}
+	})
+
+	module.Imports = append(module.Imports, ctx.schemaImports(pactModuleName, func(name string) []string {
+		return []string{"example" + name}
+	})...)
+
+	funcMap := template.FuncMap{
+		"join":          strings.Join,
+		"exampleValues": exampleValues,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("pact").Funcs(funcMap).Parse(pactTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(pactModuleName(ctx.module) + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
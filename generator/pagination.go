@@ -0,0 +1,220 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// PaginationLibrary is the runtime module used by the generated pagination helpers, see Options.Pagination.
+func PaginationLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+// paginate calls list for each page of the results of a list method, starting with the page token of the request,
+// until a response has no next page token, or has the token of the page it is the response to. The pages are
+// requested one at a time, when the next page is read, and an error ends the iteration after it is thrown.
+export const paginate = <Req, Resp>(request: Req, pageToken: keyof Req, nextPageToken: keyof Resp, list: (req: Req) => Promise<Resp>): AsyncIterableIterator<Resp> => {
+    let token: any = request[pageToken];
+    let done = false;
+    let last: Promise<any> = Promise.resolve();
+
+    const next = (): Promise<IteratorResult<Resp>> => {
+        const page = last.then((): IteratorResult<Resp> | Promise<IteratorResult<Resp>> => {
+            if (done) {
+                return {value: undefined, done: true};
+            }
+
+            const req: any = {};
+            Object.keys(request).forEach((k) => req[k] = (request as any)[k]);
+            req[pageToken] = token;
+
+            return list(req as Req).then((resp) => {
+                const next: any = resp[nextPageToken];
+                done = !next || next === token;
+                token = next;
+
+                return {value: resp, done: false};
+            }, (err) => {
+                done = true;
+                throw err;
+            });
+        });
+
+        // the next page is requested after this one, whether or not it fails
+        last = page.catch(() => undefined);
+
+        return page;
+    };
+
+    const pages: AsyncIterableIterator<Resp> = {
+        next: next,
+        return: () => {
+            done = true;
+            return Promise.resolve<IteratorResult<Resp>>({value: undefined, done: true});
+        },
+        [Symbol.asyncIterator]: () => pages,
+    };
+
+    return pages;
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_pagination.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const paginationTemplate = `
+{{- if not .DeclarationOnly}}
+import {paginate} from '{{importPath "twirp_pagination"}}';
+{{- end}}
+import {CallOptions} from '{{importPath "twirp"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Pages}}
+// {{.Name}}Pages calls {{.Service.Name}}.{{.Method.Path}} for each page of its results, starting with the
+// {{.Pagination.PageToken}} of the request, until a response has no {{.Pagination.NextPageToken}}, e.g.
+// for await (const page of {{.Name}}Pages(client, {{.Method.InputArg}})) { ... page.{{.Pagination.Items}} ... }
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}Pages: (client: {{.Service.Name}}, {{.Method.InputArg}}: {{.Method.InputType}}, callOptions?: CallOptions) => AsyncIterableIterator<{{.Method.ResponseType}}>;
+{{- else}}
+export const {{.Name}}Pages = (client: {{.Service.Name}}, {{.Method.InputArg}}: {{.Method.InputType}}, callOptions?: CallOptions): AsyncIterableIterator<{{.Method.ResponseType}}> => {
+    return paginate({{.Method.InputArg}}, "{{.Pagination.PageToken}}", "{{.Pagination.NextPageToken}}", (req) => client.{{.Method.Name}}(req, callOptions));
+};
+{{- end}}
+{{end}}`
+
+// Pagination are the fields of the AIP-158 conventions of a list method, e.g. ListHats, whose request has a
+// page_token, and whose response has a next_page_token and the repeated field of the results. The names are the
+// names of the fields in typescript.
+type Pagination struct {
+	PageToken     string
+	NextPageToken string
+	Items         string
+}
+
+// pagination is the Pagination of an rpc method, or nil when its messages do not follow the AIP-158 conventions.
+// The results are the first repeated field of the response.
+func (ctx *APIContext) pagination(m ServiceMethod) *Pagination {
+	in, ok := ctx.modelLookup[m.InputType]
+	if !ok {
+		return nil
+	}
+
+	out, ok := ctx.modelLookup[m.OutputType]
+	if !ok {
+		return nil
+	}
+
+	p := &Pagination{}
+	for _, f := range in.Fields {
+		if f.ProtoName == "page_token" && isStringField(f) {
+			p.PageToken = f.Name
+		}
+	}
+
+	for _, f := range out.Fields {
+		switch {
+		case f.ProtoName == "next_page_token" && isStringField(f):
+			p.NextPageToken = f.Name
+		case f.IsRepeated && !f.IsMap && p.Items == "":
+			p.Items = f.Name
+		}
+	}
+
+	if p.PageToken == "" || p.NextPageToken == "" || p.Items == "" {
+		return nil
+	}
+
+	return p
+}
+
+// isStringField reports if a field is a singular string field.
+func isStringField(f ModelField) bool {
+	return f.ProtoType == descriptor.FieldDescriptorProto_TYPE_STRING && !f.IsRepeated
+}
+
+// hasPagination reports if any of the services of the module has a list method, see pagination.
+func (ctx *APIContext) hasPagination() bool {
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			if m.Pagination != nil {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Pages is the pagination helper generated for a list method.
+type Pages struct {
+	Name       string
+	Service    *Service
+	Method     ServiceMethod
+	Pagination *Pagination
+}
+
+// paginationModule is the module of the pagination helpers for the services of a generated module, e.g.
+// service_pagination.ts
+type paginationModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Pages           []Pages
+}
+
+// renderPagination generates the pagination helpers of the list methods of the services of the module, with
+// Options.Pagination, which are named after the rpc methods like the TanStack Query helpers, e.g. listHatsPages.
+// The helpers call the methods of a service interface, so they page through the responses of a client, a mock
+// client, or a client of the functions of client_style=functions.
+func (ctx *APIContext) renderPagination() (*plugin.CodeGeneratorResponse_File, error) {
+	module := paginationModule{DeclarationOnly: ctx.DeclarationOnly}
+
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			if m.Pagination == nil {
+				continue
+			}
+
+			name := ctx.methodName(s, m)
+			module.Pages = append(module.Pages, Pages{Name: strings.ToLower(name[0:1]) + name[1:], Service: s, Method: m, Pagination: m.Pagination})
+		}
+	}
+
+	module.Imports = ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, p := range module.Pages {
+			add(p.Service.Name, p.Service.Name)
+			add(p.Method.InputType, p.Method.InputType)
+			add(p.Method.OutputType, p.Method.ResponseType)
+		}
+	})
+
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("pagination").Funcs(funcMap).Parse(paginationTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_pagination" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
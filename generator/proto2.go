@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// isProto2 reports if a file has the proto2 syntax, which protoc leaves empty in the descriptors of proto2 files.
+func isProto2(d *descriptor.FileDescriptorProto) bool {
+	return d.GetSyntax() == "" || d.GetSyntax() == "proto2"
+}
+
+// newModelField makes the ModelField of a field of a message of the file being generated, whose optional, required
+// and default values follow the proto2 labels of the field when the file has the proto2 syntax, see proto2Field.
+func (ctx *APIContext) newModelField(f *descriptor.FieldDescriptorProto) ModelField {
+	field := newField(f, ctx.types, ctx.Options)
+	if ctx.source != nil && isProto2(ctx.source) {
+		proto2Field(&field, f)
+	}
+
+	return field
+}
+
+// proto2Field sets the presence of a singular scalar or enum field of a proto2 file. A required field is required
+// as with the REQUIRED field behavior, and an optional field is undefined when it is not set, like a proto3 optional
+// field, unless it has a default value, which is the value of the field when it is not set instead of its proto3
+// default value. Required fields and fields with a default value are written to protobuf even when they are zero,
+// since a proto2 reader tells them apart from fields that are not set.
+func proto2Field(field *ModelField, f *descriptor.FieldDescriptorProto) {
+	scalar := !field.IsMessage && !field.IsWrapper && !field.IsDuration && !field.IsFieldMask && field.Codec == ""
+	if !scalar || field.IsRepeated || field.IsMap || f.OneofIndex != nil {
+		return
+	}
+
+	switch {
+	case f.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REQUIRED:
+		field.IsRequired = true
+		field.HasPresence = true
+	case f.DefaultValue != nil:
+		field.Default = defaultLiteral(*field, f.GetDefaultValue())
+		field.Zero = field.Default
+		field.HasPresence = true
+	default:
+		field.IsOptional = true
+		field.Zero = ""
+	}
+}
+
+// defaultLiteral generates the typescript value of the default value of a proto2 field, which protoc writes as the
+// name of an enum value, the C escaped text of a bytes value, or the text of any other value, with inf, -inf and
+// nan for the non-finite floats.
+func defaultLiteral(f ModelField, value string) string {
+	switch {
+	case f.IsEnum:
+		return f.Type + "." + value
+	case f.IsLong && wrappedType(f) == Int64Number:
+		return value
+	case f.IsLong:
+		return longFromString(wrappedType(f), jsString(value))
+	case f.IsBytes:
+		b := unescapeBytes(value)
+		if len(b) == 0 {
+			return "new Uint8Array(0)"
+		}
+
+		var values []string
+		for _, c := range b {
+			values = append(values, strconv.Itoa(int(c)))
+		}
+
+		return "new Uint8Array([" + strings.Join(values, ", ") + "])"
+	case f.IsFloat:
+		switch value {
+		case "inf":
+			return "Infinity"
+		case "-inf":
+			return "-Infinity"
+		case "nan":
+			return "NaN"
+		}
+
+		return value
+	case f.ProtoType == descriptor.FieldDescriptorProto_TYPE_STRING:
+		return jsString(value)
+	}
+
+	return value
+}
+
+// unescapeBytes decodes the C escaped default value of a bytes field, whose escapes are \n, \r, \t, \", \', \\ and
+// the octal \ooo of the other bytes that are not printable.
+func unescapeBytes(s string) []byte {
+	var b []byte
+
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b = append(b, s[i])
+			continue
+		}
+
+		i++
+		switch c := s[i]; c {
+		case 'n':
+			b = append(b, '\n')
+		case 'r':
+			b = append(b, '\r')
+		case 't':
+			b = append(b, '\t')
+		case '0', '1', '2', '3', '4', '5', '6', '7':
+			n := 0
+			for j := 0; j < 3 && i < len(s) && s[i] >= '0' && s[i] <= '7'; j++ {
+				n = n*8 + int(s[i]-'0')
+				i++
+			}
+
+			i--
+			b = append(b, byte(n))
+		default:
+			b = append(b, c)
+		}
+	}
+
+	return b
+}
+
+// groupError is the error of a proto2 group field, whose message is declared inside of the field, and which is
+// written to protobuf with start and end group tags rather than as a message.
+func groupError(file string, m *descriptor.DescriptorProto, f *descriptor.FieldDescriptorProto) error {
+	group := f.GetTypeName()[strings.LastIndex(f.GetTypeName(), ".")+1:]
+
+	return fmt.Errorf("%s: field %s.%s is a proto2 group, which is not supported, declare %s as a message instead", file, m.GetName(), f.GetName(), group)
+}
@@ -0,0 +1,89 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestCreateClientAPIs_Group(t *testing.T) {
+	api := &descriptor.FileDescriptorProto{
+		Name:    proto.String("api.proto"),
+		Package: proto.String("api"),
+		MessageType: []*descriptor.DescriptorProto{
+			{
+				Name: proto.String("Search"),
+				Field: []*descriptor.FieldDescriptorProto{
+					{
+						Name:     proto.String("result"),
+						Number:   proto.Int32(1),
+						Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+						Type:     descriptor.FieldDescriptorProto_TYPE_GROUP.Enum(),
+						TypeName: proto.String(".api.Search.Result"),
+					},
+				},
+				NestedType: []*descriptor.DescriptorProto{{Name: proto.String("Result")}},
+			},
+		},
+	}
+
+	_, err := CreateClientAPIs([]*descriptor.FileDescriptorProto{api}, []string{"api.proto"}, DefaultOptions())
+	if expected := "api.proto: field Search.result is a proto2 group, which is not supported, declare Result as a message instead"; err == nil || err.Error() != expected {
+		t.Errorf("expected %q, got %v", expected, err)
+	}
+}
+
+func TestProto2Field(t *testing.T) {
+	tests := []struct {
+		f        *descriptor.FieldDescriptorProto
+		opts     Options
+		optional bool
+		required bool
+		zero     string
+	}{
+		{
+			f:        &descriptor.FieldDescriptorProto{Name: proto.String("name"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum()},
+			opts:     Options{Defaults: DefaultsZero},
+			optional: true,
+		},
+		{
+			f:        &descriptor.FieldDescriptorProto{Name: proto.String("inches"), Label: descriptor.FieldDescriptorProto_LABEL_REQUIRED.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_INT32.Enum()},
+			required: true,
+		},
+		{
+			f:    &descriptor.FieldDescriptorProto{Name: proto.String("color"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_STRING.Enum(), DefaultValue: proto.String(`say "hi"`)},
+			zero: `"say \"hi\""`,
+		},
+		{
+			f:    &descriptor.FieldDescriptorProto{Name: proto.String("fabric"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_ENUM.Enum(), TypeName: proto.String(".Fabric"), DefaultValue: proto.String("FABRIC_FELT")},
+			zero: "Fabric.FABRIC_FELT",
+		},
+		{
+			f:    &descriptor.FieldDescriptorProto{Name: proto.String("stock"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_INT64.Enum(), DefaultValue: proto.String("-3")},
+			opts: Options{Int64: Int64BigInt},
+			zero: `BigInt("-3")`,
+		},
+		{
+			f:    &descriptor.FieldDescriptorProto{Name: proto.String("ratio"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_FLOAT.Enum(), DefaultValue: proto.String("-inf")},
+			zero: "-Infinity",
+		},
+		{
+			f:    &descriptor.FieldDescriptorProto{Name: proto.String("tag"), Label: descriptor.FieldDescriptorProto_LABEL_OPTIONAL.Enum(), Type: descriptor.FieldDescriptorProto_TYPE_BYTES.Enum(), DefaultValue: proto.String(`a\n\001\\\377`)},
+			zero: "new Uint8Array([97, 10, 1, 92, 255])",
+		},
+	}
+
+	for _, tt := range tests {
+		field := newField(tt.f, typeRegistry{}, tt.opts)
+		proto2Field(&field, tt.f)
+
+		if field.IsOptional != tt.optional || field.IsRequired != tt.required || field.Zero != tt.zero {
+			t.Errorf("%s: expected optional %v, required %v and zero %s, got %+v", tt.f.GetName(), tt.optional, tt.required, tt.zero, field)
+		}
+
+		if expected := tt.required || tt.zero != ""; field.HasPresence != expected {
+			t.Errorf("%s: expected presence %v, got %v", tt.f.GetName(), expected, field.HasPresence)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// wireCodec returns the ProtobufWriter/ProtobufReader method and wire type used to encode a scalar field.
+func wireCodec(t descriptor.FieldDescriptorProto_Type) (string, int) {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return "double", wireFixed64
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return "float", wireFixed32
+	case descriptor.FieldDescriptorProto_TYPE_INT64:
+		return "int64", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_UINT64:
+		return "uint64", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_ENUM:
+		return "int32", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return "fixed64", wireFixed64
+	case descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return "fixed32", wireFixed32
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "bool", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_UINT32:
+		return "uint32", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return "sfixed32", wireFixed32
+	case descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return "sfixed64", wireFixed64
+	case descriptor.FieldDescriptorProto_TYPE_SINT32:
+		return "sint32", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_SINT64:
+		return "sint64", wireVarint
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return "bytes", wireBytes
+	}
+
+	return "string", wireBytes
+}
+
+func singularType(f ModelField) string {
+	return strings.TrimSuffix(f.Type, "[]")
+}
+
+// writeValue generates the ProtobufWriter calls for a single value of the field.
+func writeValue(f ModelField, value string) string {
+	if f.Codec != "" {
+		return fmt.Sprintf("w.tag(%d, %d).bytes(%sToProtobuf(%s))", f.Number, wireBytes, f.Codec, value)
+	}
+
+	if f.IsFieldMask {
+		return fmt.Sprintf("w.tag(%d, %d).bytes(fieldMaskToProtobuf(%s))", f.Number, wireBytes, value)
+	}
+
+	if f.IsDuration {
+		if singularType(f) == DurationString {
+			value = fmt.Sprintf("durationFromString(%s)", value)
+		}
+
+		return fmt.Sprintf("w.tag(%d, %d).bytes(durationToProtobuf(%s))", f.Number, wireBytes, value)
+	}
+
+	if f.IsMessage {
+		if singularType(f) == "Date" {
+			return fmt.Sprintf("w.tag(%d, %d).bytes(timestampToProtobuf(%s))", f.Number, wireBytes, value)
+		}
+
+		return fmt.Sprintf("w.tag(%d, %d).bytes(%sToProtobuf(%s))", f.Number, wireBytes, singularType(f), value)
+	}
+
+	codec, wireType := wireCodec(f.ProtoType)
+
+	if f.IsWrapper {
+		return fmt.Sprintf("w.tag(%d, %d).bytes(new ProtobufWriter().tag(1, %d).%s(%s).finish())", f.Number, wireBytes, wireType, codec, toWire(f, value))
+	}
+
+	return fmt.Sprintf("w.tag(%d, %d).%s(%s)", f.Number, wireType, codec, toWire(f, value))
+}
+
+// toWire converts a scalar value to the type accepted by the ProtobufWriter, which writes 64 bit integers from decimal strings.
+func toWire(f ModelField, value string) string {
+	if f.IsLong {
+		return longToString(wrappedType(f), value)
+	}
+
+	return value
+}
+
+// readValue generates the ProtobufReader calls to read a single value of the field.
+func readValue(f ModelField) string {
+	if f.Codec != "" {
+		return fmt.Sprintf("protobufTo%s%s(r.bytes())", strings.ToUpper(f.Codec[0:1]), f.Codec[1:])
+	}
+
+	if f.IsFieldMask {
+		return "protobufToFieldMask(r.bytes())"
+	}
+
+	if f.IsDuration {
+		if singularType(f) == DurationString {
+			return "durationToString(protobufToDuration(r.bytes()))"
+		}
+
+		return "protobufToDuration(r.bytes())"
+	}
+
+	if f.IsMessage {
+		if singularType(f) == "Date" {
+			return "protobufToTimestamp(r.bytes())"
+		}
+
+		return fmt.Sprintf("ProtobufTo%s(r.bytes())", singularType(f))
+	}
+
+	codec, _ := wireCodec(f.ProtoType)
+	value := fmt.Sprintf("r.%s()", codec)
+
+	if f.IsLong {
+		value = longFromString(wrappedType(f), value)
+	}
+
+	if f.IsWrapper {
+		return fmt.Sprintf("unwrapValue(r.bytes(), %s, (r) => %s)", zeroValue(f), value)
+	}
+
+	return value
+}
+
+// packable reports if a repeated field uses the proto3 packed encoding.
+func packable(f ModelField) bool {
+	if f.IsMessage || f.IsWrapper || f.IsDuration || f.IsFieldMask || f.Codec != "" {
+		return false
+	}
+
+	_, wireType := wireCodec(f.ProtoType)
+
+	return wireType != wireBytes
+}
+
+// encodeField generates the statement that writes a field to a ProtobufWriter named w.
+// Fields with proto3 default values are not written.
+func encodeField(f ModelField) string {
+	if f.IsMap {
+		return encodeMap(f)
+	}
+
+	if f.IsRepeated {
+		if packable(f) {
+			codec, _ := wireCodec(f.ProtoType)
+			return fmt.Sprintf("if (m.%s.length) { w.tag(%d, %d).packed(m.%s, (w, v) => w.%s(%s)); }", f.Name, f.Number, wireBytes, f.Name, codec, toWire(f, "v"))
+		}
+
+		return fmt.Sprintf("m.%s.forEach((v) => %s);", f.Name, writeValue(f, "v"))
+	}
+
+	if f.IsOptional {
+		// optional fields are written when they are set, including to their default value
+		return fmt.Sprintf("if (m.%s !== undefined) { %s; }", f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.HasPresence {
+		// the required and default valued fields of proto2 files are written when they are zero
+		return fmt.Sprintf("if (m.%s !== undefined) { %s; }", f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.Codec != "" {
+		// a Value may be null or another falsy JSON value, which is still set
+		return fmt.Sprintf("if (m.%s !== undefined) { %s; }", f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.IsWrapper {
+		return fmt.Sprintf("if (m.%s !== null && m.%s !== undefined) { %s; }", f.Name, f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.IsBytes || f.IsFieldMask {
+		return fmt.Sprintf("if (m.%s && m.%s.length) { %s; }", f.Name, f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.IsLong && f.Type == Int64String {
+		return fmt.Sprintf(`if (m.%s && m.%s !== "0") { %s; }`, f.Name, f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	if f.IsFloat {
+		// NaN is falsy, but it is not the default value of a float
+		return fmt.Sprintf("if (m.%s !== 0) { %s; }", f.Name, writeValue(f, "m."+f.Name))
+	}
+
+	return fmt.Sprintf("if (m.%s) { %s; }", f.Name, writeValue(f, "m."+f.Name))
+}
+
+// encodeOneof generates the statement that writes a oneof member when it is the member that is set.
+func encodeOneof(o ModelOneof, f ModelField) string {
+	return fmt.Sprintf(`if (m.%s && m.%s.kind === "%s") { %s; }`, o.Name, o.Name, f.Name, writeValue(f, "m."+o.Name+".value"))
+}
+
+// decodeField generates the switch case that reads a field from a ProtobufReader named r.
+func decodeField(f ModelField) string {
+	if f.IsMap {
+		return decodeMap(f)
+	}
+
+	if f.IsRepeated {
+		if packable(f) {
+			return fmt.Sprintf("case %d: r.repeated(tag, () => m.%s.push(%s)); break;", f.Number, f.Name, readValue(f))
+		}
+
+		return fmt.Sprintf("case %d: m.%s.push(%s); break;", f.Number, f.Name, readValue(f))
+	}
+
+	return fmt.Sprintf("case %d: m.%s = %s; break;", f.Number, f.Name, readValue(f))
+}
+
+// decodeOneof generates the switch case that reads a oneof member into the discriminated union.
+func decodeOneof(o ModelOneof, f ModelField) string {
+	return fmt.Sprintf(`case %d: m.%s = {kind: "%s", value: %s}; break;`, f.Number, o.Name, f.Name, readValue(f))
+}
+
+// zeroValues generates the proto3 default values for the fields of a model, which are
+// omitted from the binary encoding. Message fields have no default and are left unset.
+func zeroValues(m *Model) string {
+	var values []string
+
+	for _, f := range m.Fields {
+		switch {
+		case f.IsOptional:
+			continue
+		case f.IsMap:
+			values = append(values, f.Name+": {}")
+		case f.IsRepeated:
+			values = append(values, f.Name+": []")
+		case f.IsMessage, f.IsDuration, f.Codec != "":
+			continue
+		case f.IsFieldMask:
+			values = append(values, f.Name+": []")
+		case f.IsWrapper:
+			values = append(values, f.Name+": null")
+		default:
+			values = append(values, f.Name+": "+zeroValue(f))
+		}
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// zeroValue generates the proto3 default value of a scalar field, or of the value held by a wrapper field, unless
+// the field of a proto2 file has a default value.
+func zeroValue(f ModelField) string {
+	if f.Default != "" {
+		return f.Default
+	}
+
+	t := wrappedType(f)
+
+	switch {
+	case f.IsBytes:
+		return "new Uint8Array(0)"
+	case f.IsLong && t == Int64BigInt:
+		return "BigInt(0)"
+	case f.IsLong && t == Int64String:
+		return `"0"`
+	case t == "number" || f.IsEnum:
+		return "0"
+	case t == "boolean":
+		return "false"
+	}
+
+	return `""`
+}
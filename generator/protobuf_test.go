@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestEncodeField(t *testing.T) {
+	tests := []struct {
+		field    ModelField
+		expected string
+	}{
+		{
+			ModelField{Name: "size", Type: "number", Number: 1, ProtoType: descriptor.FieldDescriptorProto_TYPE_INT32},
+			"if (m.size) { w.tag(1, 0).int32(m.size); }",
+		},
+		{
+			ModelField{Name: "price", Type: "number", Number: 5, ProtoType: descriptor.FieldDescriptorProto_TYPE_DOUBLE, IsFloat: true},
+			"if (m.price !== 0) { w.tag(5, 1).double(m.price); }",
+		},
+		{
+			ModelField{Name: "sizes", Type: "number[]", Number: 2, ProtoType: descriptor.FieldDescriptorProto_TYPE_DOUBLE, IsRepeated: true},
+			"if (m.sizes.length) { w.tag(2, 2).packed(m.sizes, (w, v) => w.double(v)); }",
+		},
+		{
+			ModelField{Name: "hats", Type: "Hat[]", Number: 3, ProtoType: descriptor.FieldDescriptorProto_TYPE_MESSAGE, IsMessage: true, IsRepeated: true},
+			"m.hats.forEach((v) => w.tag(3, 2).bytes(HatToProtobuf(v)));",
+		},
+		{
+			ModelField{Name: "createdOn", Type: "Date", Number: 4, ProtoType: descriptor.FieldDescriptorProto_TYPE_MESSAGE, IsMessage: true},
+			"if (m.createdOn) { w.tag(4, 2).bytes(timestampToProtobuf(m.createdOn)); }",
+		},
+	}
+
+	for _, tt := range tests {
+		if actual := encodeField(tt.field); actual != tt.expected {
+			t.Errorf("expected %s, got %s", tt.expected, actual)
+		}
+	}
+}
+
+func TestDecodeField(t *testing.T) {
+	f := ModelField{Name: "sizes", Type: "number[]", Number: 2, ProtoType: descriptor.FieldDescriptorProto_TYPE_SINT32, IsRepeated: true}
+
+	expected := "case 2: r.repeated(tag, () => m.sizes.push(r.sint32())); break;"
+	if actual := decodeField(f); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
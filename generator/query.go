@@ -0,0 +1,144 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// QueryLibrary is the runtime module used by the generated TanStack Query helpers, see Options.TanStackQuery.
+// The options are plain objects, so they can be used with any of the TanStack Query adapters without
+// depending on one, e.g. useQuery(makeHatQuery(client, size)) with @tanstack/react-query.
+func QueryLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+// RpcQueryOptions are the query options of an rpc method, which call the rpc method with the request of the query key.
+export interface RpcQueryOptions<T, K extends readonly unknown[]> {
+    queryKey: K;
+    // queryKeyHashFn hashes the query key with canonicalJSON, which also hashes the bigints and bytes of requests
+    queryKeyHashFn: (queryKey: K) => string;
+    queryFn: (context: {signal?: AbortSignal}) => Promise<T>;
+}
+
+// RpcMutationOptions are the mutation options of an rpc method, which call the rpc method with the mutation variables.
+export interface RpcMutationOptions<T, Req> {
+    mutationKey: readonly [string, string];
+    mutationFn: (req: Req) => Promise<T>;
+}
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_query.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const queriesTemplate = `
+import {RpcQueryOptions, RpcMutationOptions} from '{{importPath "twirp_query"}}';
+{{- if not .DeclarationOnly}}
+import {canonicalJSON} from '{{importPath "twirp"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Queries}}
+// {{.Name}}QueryKey is the query key of {{.Service.Name}}.{{.Method.Path}} queries, e.g. to invalidate the cached response of a request.
+{{- if .Method.EmptyRequest}}
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}QueryKey: () => readonly ["{{.Service.FullName}}", "{{.Method.Path}}"];
+{{- else}}
+export const {{.Name}}QueryKey = (): readonly ["{{.Service.FullName}}", "{{.Method.Path}}"] => {
+    return ["{{.Service.FullName}}", "{{.Method.Path}}"];
+};
+{{- end}}
+{{- else}}
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}QueryKey: ({{.Method.InputArg}}: {{.Method.InputType}}) => readonly ["{{.Service.FullName}}", "{{.Method.Path}}", {{.Method.InputType}}];
+{{- else}}
+export const {{.Name}}QueryKey = ({{.Method.InputArg}}: {{.Method.InputType}}): readonly ["{{.Service.FullName}}", "{{.Method.Path}}", {{.Method.InputType}}] => {
+    return ["{{.Service.FullName}}", "{{.Method.Path}}", {{.Method.InputArg}}];
+};
+{{- end}}
+{{- end}}
+
+// {{.Name}}Query are the query options of {{.Service.Name}}.{{.Method.Path}}, e.g. useQuery({{.Name}}Query(client{{if not .Method.EmptyRequest}}, {{.Method.InputArg}}{{end}}))
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}Query: (client: {{.Service.Name}}{{if not .Method.EmptyRequest}}, {{.Method.InputArg}}: {{.Method.InputType}}{{end}}) => RpcQueryOptions<{{.Method.ResponseType}}, ReturnType<typeof {{.Name}}QueryKey>>;
+{{- else}}
+export const {{.Name}}Query = (client: {{.Service.Name}}{{if not .Method.EmptyRequest}}, {{.Method.InputArg}}: {{.Method.InputType}}{{end}}): RpcQueryOptions<{{.Method.ResponseType}}, ReturnType<typeof {{.Name}}QueryKey>> => {
+    return {
+        queryKey: {{.Name}}QueryKey({{.Method.InputArg}}),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.{{.Method.Name}}({{.Method.RequestArg}}{signal: context.signal}),
+    };
+};
+{{- end}}
+
+// {{.Name}}Mutation are the mutation options of {{.Service.Name}}.{{.Method.Path}}, e.g. useMutation({{.Name}}Mutation(client))
+{{- $input := .Method.InputType}}{{if .Method.EmptyRequest}}{{$input = "void"}}{{end}}
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}Mutation: (client: {{.Service.Name}}) => RpcMutationOptions<{{.Method.ResponseType}}, {{$input}}>;
+{{- else}}
+export const {{.Name}}Mutation = (client: {{.Service.Name}}): RpcMutationOptions<{{.Method.ResponseType}}, {{$input}}> => {
+    return {
+        mutationKey: ["{{.Service.FullName}}", "{{.Method.Path}}"],
+        mutationFn: ({{.Method.InputArg}}) => client.{{.Method.Name}}({{.Method.InputArg}}),
+    };
+};
+{{- end}}
+{{end}}
+`
+
+// Query is the TanStack Query helpers generated for an rpc method.
+type Query struct {
+	Name    string
+	Service *Service
+	Method  ServiceMethod
+}
+
+// queriesModule is the module of the TanStack Query helpers for the services of a generated module, e.g. service_query.ts
+type queriesModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Queries         []Query
+}
+
+// renderQueries generates the TanStack Query helpers for the services of the module, which are named after the
+// rpc methods, e.g. makeHatQuery, see methodName. The query keys are [<package>.<Service>, <Method>, <request>],
+// so the cached responses of a service or an rpc method can be invalidated by a prefix of the key.
+func (ctx *APIContext) renderQueries() (*plugin.CodeGeneratorResponse_File, error) {
+	module := queriesModule{DeclarationOnly: ctx.DeclarationOnly, Imports: ctx.serviceImports("")}
+
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			name := ctx.methodName(s, m)
+			module.Queries = append(module.Queries, Query{Name: strings.ToLower(name[0:1]) + name[1:], Service: s, Method: m})
+		}
+	}
+
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("queries").Funcs(funcMap).Parse(queriesTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_query" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
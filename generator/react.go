@@ -0,0 +1,250 @@
+package generator
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// ReactLibrary is the runtime module used by the generated React hooks, see Options.ReactHooks.
+func ReactLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {useCallback, useEffect, useRef, useState} from 'react';
+import {CallOptions, TwirpHeaders, canonicalJSON} from './twirp';
+
+// RpcHookOptions are the options of a generated hook, which are passed to every call of the rpc method.
+export interface RpcHookOptions {
+    headers?: TwirpHeaders;
+    timeoutMs?: number;
+    // the rpc method is not called while enabled is false, e.g. until the request is ready
+    enabled?: boolean;
+}
+
+// RpcHookResult is the state of the latest call of the rpc method of a generated hook.
+export interface RpcHookResult<T> {
+    data: T | undefined;
+    error: Error | undefined;
+    loading: boolean;
+    // refetch calls the rpc method again with the same request
+    refetch: () => void;
+}
+
+interface RpcState<T> {
+    data?: T;
+    error?: Error;
+    loading: boolean;
+}
+
+// useRpc calls an rpc method with the request when the component mounts, and again when the request changes.
+// The call is aborted when the component unmounts, or when it is superseded by a call with a new request.
+export const useRpc = <Req, Resp>(call: (req: Req, options: CallOptions) => Promise<Resp>, req: Req, options: RpcHookOptions = {}): RpcHookResult<Resp> => {
+    const enabled = options.enabled !== false;
+    const [state, setState] = useState<RpcState<Resp>>({loading: enabled});
+    const [attempt, setAttempt] = useState(0);
+
+    // the latest arguments are used by the effect, which only runs again when the request key changes
+    const latest = useRef({call: call, req: req, options: options});
+    latest.current = {call: call, req: req, options: options};
+
+    // the request is identified by its content, so a hook only calls the rpc method again when the request changes,
+    // rather than whenever a component renders a new request object
+    const key = canonicalJSON(req);
+
+    useEffect(() => {
+        if (!enabled) {
+            return;
+        }
+
+        const controller = new AbortController();
+        const current = latest.current;
+
+        setState((s) => ({data: s.data, loading: true}));
+
+        current.call(current.req, {headers: current.options.headers, timeoutMs: current.options.timeoutMs, signal: controller.signal}).then(
+            (data) => {
+                if (!controller.signal.aborted) {
+                    setState({data: data, loading: false});
+                }
+            },
+            (error) => {
+                if (!controller.signal.aborted) {
+                    setState({error: error, loading: false});
+                }
+            },
+        );
+
+        return () => controller.abort();
+    }, [key, enabled, attempt]);
+
+    const refetch = useCallback(() => setAttempt((n) => n + 1), []);
+
+    return {data: state.data, error: state.error, loading: state.loading, refetch: refetch};
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_react.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const hooksTemplate = `
+{{- if not .DeclarationOnly}}
+import {useRpc, RpcHookOptions, RpcHookResult} from '{{importPath "twirp_react"}}';
+{{- else}}
+import {RpcHookOptions, RpcHookResult} from '{{importPath "twirp_react"}}';
+{{- end}}
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Hooks}}
+{{- if .Method.EmptyRequest}}
+// {{.Name}} calls {{.Service.Name}}.{{.Method.Path}} when the component mounts.
+{{- else}}
+// {{.Name}} calls {{.Service.Name}}.{{.Method.Path}} with the request when the component mounts, and again when the request changes.
+{{- end}}
+{{- if $.DeclarationOnly}}
+export declare const {{.Name}}: (client: {{.Service.Name}}, {{.Method.RequestParam}}options?: RpcHookOptions) => RpcHookResult<{{.Method.ResponseType}}>;
+{{- else}}
+export const {{.Name}} = (client: {{.Service.Name}}, {{.Method.RequestParam}}options?: RpcHookOptions): RpcHookResult<{{.Method.ResponseType}}> => {
+    {{- if .Method.EmptyRequest}}
+    return useRpc((_, callOptions) => client.{{.Method.Name}}(callOptions), {}, options);
+    {{- else}}
+    return useRpc((req, callOptions) => client.{{.Method.Name}}(req, callOptions), {{.Method.InputArg}}, options);
+    {{- end}}
+};
+{{- end}}
+{{end}}
+`
+
+// Hook is the React hook generated for an rpc method.
+type Hook struct {
+	Name    string
+	Service *Service
+	Method  ServiceMethod
+}
+
+// hooksModule is the module of the React hooks for the services of a generated module, e.g. service_hooks.ts
+type hooksModule struct {
+	DeclarationOnly bool
+	Imports         []*Import
+	Hooks           []Hook
+}
+
+// renderHooks generates the React hooks for the services of the module, which are named after the rpc methods,
+// e.g. useMakeHat, see methodName.
+func (ctx *APIContext) renderHooks() (*plugin.CodeGeneratorResponse_File, error) {
+	module := hooksModule{DeclarationOnly: ctx.DeclarationOnly, Imports: ctx.serviceImports("")}
+
+	for _, s := range ctx.Services {
+		for _, m := range s.Methods {
+			module.Hooks = append(module.Hooks, Hook{Name: "use" + ctx.methodName(s, m), Service: s, Method: m})
+		}
+	}
+
+	funcMap := template.FuncMap{
+		"join": strings.Join,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("hooks").Funcs(funcMap).Parse(hooksTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(ctx.module + "_hooks" + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
+
+// methodName is the name of an rpc method used by the helpers generated for it, e.g. MakeHat for useMakeHat.
+// The name of the service is included when services of the module have methods of the same name, e.g. HaberdasherMakeHat.
+func (ctx *APIContext) methodName(s *Service, m ServiceMethod) string {
+	for _, other := range ctx.Services {
+		if other == s {
+			continue
+		}
+
+		for _, om := range other.Methods {
+			if om.Path == m.Path {
+				return s.Name + m.Path
+			}
+		}
+	}
+
+	return m.Path
+}
+
+// serviceImports are the imports of a module of helpers for the services of the module, which use the service
+// interfaces and the input and output types of the rpc methods. The imported names of the services have the
+// prefix, e.g. Default for the generated clients.
+func (ctx *APIContext) serviceImports(prefix string) []*Import {
+	return ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, s := range ctx.Services {
+			add(prefix+s.Name, prefix+s.Name)
+
+			for _, m := range s.Methods {
+				add(m.InputType, m.InputType)
+				add(m.OutputType, m.ResponseType)
+			}
+		}
+	})
+}
+
+// helperImports are the imports of the names that are added by names, which are imported from the module that
+// declares the type that they are added with.
+func (ctx *APIContext) helperImports(names func(add func(typ string, names ...string))) []*Import {
+	imports := make(map[string]map[string]bool)
+
+	// add imports the names from the module of the type, which declares all of them
+	names(func(typ string, names ...string) {
+		// google.protobuf.Empty has no model to import, see emptyType
+		if typ == emptyType[1:] {
+			return
+		}
+
+		m, ok := ctx.external[typ]
+		if !ok {
+			m = ctx.module
+		}
+
+		if imports[m] == nil {
+			imports[m] = make(map[string]bool)
+		}
+
+		for _, n := range names {
+			imports[m][n] = true
+		}
+	})
+
+	var result []*Import
+	for m, names := range imports {
+		imp := &Import{Module: m}
+		for n := range names {
+			imp.Names = append(imp.Names, n)
+		}
+		sort.Strings(imp.Names)
+
+		result = append(result, imp)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Module < result[j].Module
+	})
+
+	return result
+}
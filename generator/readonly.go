@@ -0,0 +1,53 @@
+package generator
+
+import "strings"
+
+// readonlyName is the name of the deep readonly interface of a model, which is returned by the client methods
+// with Options.ReadonlyResponses.
+func readonlyName(name string) string {
+	return "Readonly" + name
+}
+
+// readonlyType generates the type of a field in the deep readonly interface of its message, where arrays are
+// ReadonlyArray, maps are objects with readonly keys, and messages are their own readonly interfaces.
+// Dates and byte arrays keep their types, since they are not generated messages.
+func readonlyType(f ModelField) string {
+	switch {
+	case f.IsMap:
+		return "{readonly [key: " + f.Key.Type + "]: " + readonlyType(*f.Value) + "}"
+	case f.IsFieldMask:
+		return "ReadonlyArray<string>"
+	case f.IsRepeated:
+		value := f
+		value.IsRepeated = false
+		value.Type = strings.TrimSuffix(f.Type, "[]")
+
+		return "ReadonlyArray<" + readonlyType(value) + ">"
+	case f.IsMessage:
+		return readonlyName(f.Type)
+	}
+
+	return f.Type
+}
+
+// enableReadonly sets Readonly on a model and the models of its fields, see enableMarshal.
+func (ctx *APIContext) enableReadonly(m *Model) error {
+	if m.Readonly {
+		return nil
+	}
+
+	m.Readonly = true
+
+	models, err := ctx.fieldModels(m)
+	if err != nil {
+		return err
+	}
+
+	for _, mm := range models {
+		if err := ctx.enableReadonly(mm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,379 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// ReflectionLibrary is the runtime module of the descriptors of the proto files, see Options.Reflection, which
+// declares their types and a registry that looks them up by their full names.
+func ReflectionLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+// FieldDescriptor describes a field of a message.
+export interface FieldDescriptor {
+    // name is the name of the field in the proto file, e.g. "display_name"
+    name: string;
+    // localName is the name of the property of the field in the typescript interface of its message, e.g. "displayName"
+    localName: string;
+    // jsonName is the name of the field in the JSON of its message
+    jsonName: string;
+    number: number;
+    // type is the proto type of the field, e.g. "int32", "string", "message" or "enum", or "map" for a map field
+    type: string;
+    label: "optional" | "required" | "repeated";
+    // typeName is the full name of the message or enum of a message or enum field, or of the values of a map field
+    typeName?: string;
+    // keyType and valueType are the proto types of the keys and the values of a map field
+    keyType?: string;
+    valueType?: string;
+    // oneof is the name of the oneof of a member of a oneof
+    oneof?: string;
+    // proto3Optional is set for the fields that are marked optional in proto3, which are unset when they are undefined
+    proto3Optional?: boolean;
+}
+
+// MessageDescriptor describes a message, whose name is its full name, e.g. "twitch.twirp.example.Hat".
+export interface MessageDescriptor {
+    name: string;
+    fields: FieldDescriptor[];
+}
+
+export interface EnumValueDescriptor {
+    name: string;
+    number: number;
+}
+
+// EnumDescriptor describes an enum, whose name is its full name, e.g. "twitch.twirp.example.Color".
+export interface EnumDescriptor {
+    name: string;
+    values: EnumValueDescriptor[];
+}
+
+// MethodDescriptor describes an rpc method, whose input and output types are the full names of their messages.
+export interface MethodDescriptor {
+    name: string;
+    inputType: string;
+    outputType: string;
+}
+
+// ServiceDescriptor describes a service, whose name is its full name, e.g. "twitch.twirp.example.Haberdasher".
+export interface ServiceDescriptor {
+    name: string;
+    methods: MethodDescriptor[];
+}
+
+// FileDescriptor describes the messages, enums and services of a proto file, including its nested messages and
+// enums, which are named after their parent messages, e.g. "twitch.twirp.example.Hat.Style".
+export interface FileDescriptor {
+    name: string;
+    package: string;
+    messages: MessageDescriptor[];
+    enums: EnumDescriptor[];
+    services: ServiceDescriptor[];
+}
+
+// SchemaRegistry looks up the descriptors of the messages, enums and services of proto files by their full names,
+// e.g. to generate a form for a message, or to list the methods of a service in an admin UI.
+export class SchemaRegistry {
+    private messages: {[name: string]: MessageDescriptor} = {};
+    private enums: {[name: string]: EnumDescriptor} = {};
+    private services: {[name: string]: ServiceDescriptor} = {};
+
+    constructor(files: FileDescriptor[] = []) {
+        files.forEach((file) => this.add(file));
+    }
+
+    // add adds the descriptors of a proto file, e.g. the descriptor of its generated _reflection module.
+    add(file: FileDescriptor): this {
+        file.messages.forEach((m) => { this.messages[m.name] = m; });
+        file.enums.forEach((e) => { this.enums[e.name] = e; });
+        file.services.forEach((s) => { this.services[s.name] = s; });
+        return this;
+    }
+
+    message(name: string): MessageDescriptor | undefined {
+        return this.messages[name];
+    }
+
+    enum(name: string): EnumDescriptor | undefined {
+        return this.enums[name];
+    }
+
+    service(name: string): ServiceDescriptor | undefined {
+        return this.services[name];
+    }
+
+    // field looks up a field of a message by its proto, local or JSON name, or by its number.
+    field(message: string, field: string | number): FieldDescriptor | undefined {
+        const m = this.messages[message];
+        if (!m) {
+            return undefined;
+        }
+
+        return m.fields.filter((f) => typeof field === "number" ? f.number === field : f.name === field || f.localName === field || f.jsonName === field)[0];
+    }
+
+    // enumName is the name of the value of an enum with the number, or undefined when the enum has no such value.
+    enumName(name: string, number: number): string | undefined {
+        const e = this.enums[name];
+        const value = e ? e.values.filter((v) => v.number === number)[0] : undefined;
+        return value ? value.name : undefined;
+    }
+}
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_reflection.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+const reflectionTemplate = `
+import {FileDescriptor} from '{{importPath "twirp_reflection"}}';
+
+// descriptor describes the messages, enums and services of {{.File}}, which a SchemaRegistry looks up by their
+// full names, e.g. new SchemaRegistry([descriptor]).
+{{- if .DeclarationOnly}}
+export declare const descriptor: FileDescriptor;
+{{- else}}
+export const descriptor: FileDescriptor = {{.Descriptor}};
+{{- end}}
+`
+
+// reflectionModule is the module of the descriptor of a proto file, see renderReflection.
+type reflectionModule struct {
+	File            string
+	Descriptor      string
+	DeclarationOnly bool
+}
+
+// reflectionFile and the types of its fields are the JSON of the FileDescriptor of a proto file, see the
+// FileDescriptor interface of ReflectionLibrary.
+type reflectionFile struct {
+	Name     string              `json:"name"`
+	Package  string              `json:"package"`
+	Messages []reflectionMessage `json:"messages"`
+	Enums    []reflectionEnum    `json:"enums"`
+	Services []reflectionService `json:"services"`
+}
+
+type reflectionMessage struct {
+	Name   string            `json:"name"`
+	Fields []reflectionField `json:"fields"`
+}
+
+type reflectionField struct {
+	Name           string `json:"name"`
+	LocalName      string `json:"localName"`
+	JSONName       string `json:"jsonName"`
+	Number         int32  `json:"number"`
+	Type           string `json:"type"`
+	Label          string `json:"label"`
+	TypeName       string `json:"typeName,omitempty"`
+	KeyType        string `json:"keyType,omitempty"`
+	ValueType      string `json:"valueType,omitempty"`
+	Oneof          string `json:"oneof,omitempty"`
+	Proto3Optional bool   `json:"proto3Optional,omitempty"`
+}
+
+type reflectionEnum struct {
+	Name   string                `json:"name"`
+	Values []reflectionEnumValue `json:"values"`
+}
+
+type reflectionEnumValue struct {
+	Name   string `json:"name"`
+	Number int32  `json:"number"`
+}
+
+type reflectionService struct {
+	Name    string             `json:"name"`
+	Methods []reflectionMethod `json:"methods"`
+}
+
+type reflectionMethod struct {
+	Name       string `json:"name"`
+	InputType  string `json:"inputType"`
+	OutputType string `json:"outputType"`
+}
+
+// reflectionModuleName is the name of the module of the descriptor of a module, e.g. service_reflection
+func reflectionModuleName(module string) string {
+	return module + "_reflection"
+}
+
+// renderReflection generates the module of the descriptor of the proto file of the module, e.g.
+// service_reflection.ts, with Options.Reflection.
+func (ctx *APIContext) renderReflection() (*plugin.CodeGeneratorResponse_File, error) {
+	d := ctx.source
+	file := reflectionFile{
+		Name:     d.GetName(),
+		Package:  d.GetPackage(),
+		Messages: []reflectionMessage{},
+		Enums:    []reflectionEnum{},
+		Services: []reflectionService{},
+	}
+
+	for _, e := range d.GetEnumType() {
+		file.Enums = append(file.Enums, reflectEnum(e, d.GetPackage()))
+	}
+
+	for _, m := range d.GetMessageType() {
+		ctx.reflectMessage(&file, m, d.GetPackage())
+	}
+
+	for _, s := range d.GetService() {
+		service := reflectionService{Name: qualifiedName(d.GetPackage(), s.GetName()), Methods: []reflectionMethod{}}
+		for _, m := range s.GetMethod() {
+			service.Methods = append(service.Methods, reflectionMethod{
+				Name:       m.GetName(),
+				InputType:  strings.TrimPrefix(m.GetInputType(), "."),
+				OutputType: strings.TrimPrefix(m.GetOutputType(), "."),
+			})
+		}
+
+		file.Services = append(file.Services, service)
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return nil, err
+	}
+
+	funcMap := template.FuncMap{
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("reflection").Funcs(funcMap).Parse(reflectionTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, reflectionModule{File: d.GetName(), Descriptor: string(data), DeclarationOnly: ctx.DeclarationOnly}); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(reflectionModuleName(ctx.module) + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
+
+// reflectMessage adds the descriptors of a message, and of the messages and enums nested in it, to the file. The
+// map entries are not messages of the file, but describe the keys and values of their map fields.
+func (ctx *APIContext) reflectMessage(file *reflectionFile, m *descriptor.DescriptorProto, scope string) {
+	name := qualifiedName(scope, m.GetName())
+	message := reflectionMessage{Name: name, Fields: []reflectionField{}}
+
+	synthetic := syntheticOneofs(m)
+	for _, f := range m.GetField() {
+		field := newField(f, ctx.types, ctx.Options)
+		r := reflectionField{
+			Name:           f.GetName(),
+			LocalName:      field.Name,
+			JSONName:       field.JSONName,
+			Number:         f.GetNumber(),
+			Type:           protoTypeName(f.GetType()),
+			Label:          strings.ToLower(strings.TrimPrefix(f.GetLabel().String(), "LABEL_")),
+			TypeName:       strings.TrimPrefix(f.GetTypeName(), "."),
+			Proto3Optional: isProto3Optional(f),
+		}
+
+		if entry := ctx.types.mapEntry(f.GetTypeName()); entry != nil {
+			key, value := entry.GetField()[0], entry.GetField()[1]
+			r.Type = "map"
+			r.KeyType = protoTypeName(key.GetType())
+			r.ValueType = protoTypeName(value.GetType())
+			r.TypeName = strings.TrimPrefix(value.GetTypeName(), ".")
+		}
+
+		if f.OneofIndex != nil && !synthetic[f.GetOneofIndex()] {
+			r.Oneof = m.GetOneofDecl()[f.GetOneofIndex()].GetName()
+		}
+
+		message.Fields = append(message.Fields, r)
+	}
+
+	file.Messages = append(file.Messages, message)
+
+	for _, e := range m.GetEnumType() {
+		file.Enums = append(file.Enums, reflectEnum(e, name))
+	}
+
+	for _, n := range m.GetNestedType() {
+		if !n.GetOptions().GetMapEntry() {
+			ctx.reflectMessage(file, n, name)
+		}
+	}
+}
+
+func reflectEnum(e *descriptor.EnumDescriptorProto, scope string) reflectionEnum {
+	enum := reflectionEnum{Name: qualifiedName(scope, e.GetName()), Values: []reflectionEnumValue{}}
+	for _, v := range e.GetValue() {
+		enum.Values = append(enum.Values, reflectionEnumValue{Name: v.GetName(), Number: v.GetNumber()})
+	}
+
+	return enum
+}
+
+// fieldNumber is a field of the <Message>Fields constant of a message, whose Type is its proto type like in a
+// FieldDescriptor, e.g. int32, message or map.
+type fieldNumber struct {
+	Name      string
+	ProtoName string
+	Number    int32
+	Type      string
+}
+
+// fieldNumbers are the fields of the <Message>Fields constant of a model, which are its fields and the members of
+// its oneofs.
+func fieldNumbers(m *Model) []fieldNumber {
+	var numbers []fieldNumber
+
+	add := func(f ModelField) {
+		typ := protoTypeName(f.ProtoType)
+
+		// the ProtoType of a wrapper field is the type of its wrapped value
+		switch {
+		case f.IsMap:
+			typ = "map"
+		case f.IsWrapper:
+			typ = "message"
+		}
+
+		numbers = append(numbers, fieldNumber{Name: f.Name, ProtoName: f.ProtoName, Number: f.Number, Type: typ})
+	}
+
+	for _, f := range m.Fields {
+		add(f)
+	}
+
+	for _, o := range m.Oneofs {
+		for _, f := range o.Fields {
+			add(f)
+		}
+	}
+
+	return numbers
+}
+
+// protoTypeName is the name of a proto type in a FieldDescriptor, e.g. TYPE_INT32 is int32.
+func protoTypeName(t descriptor.FieldDescriptorProto_Type) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "TYPE_"))
+}
+
+// qualifiedName is the full name of a type or service declared in a package or message, e.g. my.pkg.Hat
+func qualifiedName(scope string, name string) string {
+	return strings.TrimPrefix(fqName(scope, name), ".")
+}
@@ -0,0 +1,386 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// RESTLibrary is the runtime module used by the REST methods of the generated clients, see Options.REST.
+// The requests are mapped to REST routes as described by google/api/http.proto, e.g. by grpc-gateway.
+func RESTLibrary() *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {CallOptions, TransportRequest, TransportResponse, TwirpError, TwirpErrorCode, TwirpHeaders, throwTwirpError} from './twirp';
+
+// HttpRule is the google.api.http binding of an rpc method, e.g. {method: "GET", path: "/v1/{name=shelves/*}"}
+export interface HttpRule {
+    method: string;
+    path: string;
+    // body is the field of the request that is sent as the body, or * for all of the fields that are not in the path.
+    // The fields that are neither in the path nor the body are sent as query parameters.
+    body?: string;
+    // responseBody is the field of the response that is read from the body, rather than the whole response
+    responseBody?: string;
+}
+
+// RESTRequest is the method, path, and body of a request mapped by an HttpRule, see restRequest.
+export interface RESTRequest {
+    method: string;
+    path: string;
+    body?: string;
+}
+
+const getField = (m: any, path: string): any => {
+    return path.split(".").reduce((v, name) => (v === undefined || v === null ? undefined : v[name]), m);
+};
+
+const deleteField = (m: any, path: string): void => {
+    const names = path.split(".");
+    const parent = getField(m, names.slice(0, -1).join("."));
+
+    if (names.length === 1) {
+        delete m[names[0]];
+    } else if (parent !== undefined && parent !== null) {
+        delete parent[names[names.length - 1]];
+    }
+};
+
+// queryParams adds the query parameters of a value, whose nested fields are joined with a dot and whose
+// repeated fields are repeated parameters, e.g. filter.tags=a&filter.tags=b
+const queryParams = (params: string[], name: string, v: any): void => {
+    if (v === undefined || v === null) {
+        return;
+    }
+
+    if (Array.isArray(v)) {
+        v.forEach((item) => queryParams(params, name, item));
+    } else if (typeof v === "object") {
+        Object.keys(v).forEach((k) => queryParams(params, name ? name + "." + k : k, v[k]));
+    } else {
+        params.push(encodeURIComponent(name) + "=" + encodeURIComponent(String(v)));
+    }
+};
+
+// pathVariable matches the variables of a path template, e.g. {name} or {name=shelves/*}
+const pathVariable = /\{([^}=]+)(=[^}]*)?\}/g;
+
+// restRequest maps the JSON of a request to a REST request. The fields of the path variables are removed from
+// the JSON, and the fields of a variable with a pattern may have slashes, e.g. shelves/1 for {name=shelves/*}.
+export const restRequest = (rule: HttpRule, message: object): RESTRequest => {
+    // the JSON of a request is copied, since the fields of the path and body are removed from it
+    const m = JSON.parse(JSON.stringify(message));
+
+    const path = rule.path.replace(pathVariable, (_, field: string, pattern?: string) => {
+        const v = getField(m, field);
+        const value = v === undefined || v === null ? "" : String(v);
+        deleteField(m, field);
+
+        return pattern ? value.split("/").map(encodeURIComponent).join("/") : encodeURIComponent(value);
+    });
+
+    if (rule.body === "*") {
+        return {method: rule.method, path: path, body: JSON.stringify(m)};
+    }
+
+    let body: string | undefined;
+    if (rule.body) {
+        body = JSON.stringify(getField(m, rule.body) || {});
+        deleteField(m, rule.body);
+    }
+
+    const params: string[] = [];
+    queryParams(params, "", m);
+
+    return {method: rule.method, path: params.length > 0 ? path + "?" + params.join("&") : path, body: body};
+};
+
+export const createRESTRequest = (url: string, req: RESTRequest, options: CallOptions = {}): TransportRequest => {
+    const headers: TwirpHeaders = {};
+    Object.keys(options.headers || {}).forEach((k) => { headers[k] = (options.headers || {})[k]; });
+
+    if (req.body !== undefined) {
+        headers["Content-Type"] = "application/json";
+    }
+
+    return {
+        method: req.method,
+        url: url,
+        headers: headers,
+        body: req.body === undefined ? "" : req.body,
+        signal: options.signal
+    };
+};
+
+// restResponse is the JSON of a response, which is the field of the responseBody of the rule when it is set. The body
+// is parsed by parse, e.g. parseLosslessJSON for responses with 64 bit fields.
+export const restResponse = (rule: HttpRule, body: string, parse: (body: string) => any = JSON.parse): any => {
+    const m = parse(body);
+
+    if (rule.responseBody) {
+        const wrapped: {[key: string]: any} = {};
+        wrapped[rule.responseBody] = m;
+
+        return wrapped;
+    }
+
+    return m;
+};
+
+// grpcCodes are the Twirp error codes of the gRPC status codes, which are the codes of the errors of a REST gateway.
+const grpcCodes = [
+    TwirpErrorCode.Unknown,
+    TwirpErrorCode.Canceled,
+    TwirpErrorCode.Unknown,
+    TwirpErrorCode.InvalidArgument,
+    TwirpErrorCode.DeadlineExceeded,
+    TwirpErrorCode.NotFound,
+    TwirpErrorCode.AlreadyExists,
+    TwirpErrorCode.PermissionDenied,
+    TwirpErrorCode.ResourceExhausted,
+    TwirpErrorCode.FailedPrecondition,
+    TwirpErrorCode.Aborted,
+    TwirpErrorCode.OutOfRange,
+    TwirpErrorCode.Unimplemented,
+    TwirpErrorCode.Internal,
+    TwirpErrorCode.Unavailable,
+    TwirpErrorCode.DataLoss,
+    TwirpErrorCode.Unauthenticated,
+];
+
+// throwRESTError rejects with the TwirpError of an error response of a REST route. The errors of a REST gateway
+// have the gRPC status code, e.g. {"code": 5, "message": "book not found"}, while other errors are read as Twirp errors.
+export const throwRESTError = (resp: TransportResponse): Promise<never> => {
+    return resp.text().then((body: string) => {
+        let status: {code?: any; message?: string} | undefined;
+
+        try {
+            status = JSON.parse(body);
+        } catch (e) {
+            status = undefined;
+        }
+
+        if (!status || typeof status.code !== "number" || !grpcCodes[status.code]) {
+            return throwTwirpError({
+                ok: resp.ok,
+                status: resp.status,
+                text: () => Promise.resolve(body),
+                arrayBuffer: () => resp.arrayBuffer(),
+            });
+        }
+
+        throw new TwirpError({code: grpcCodes[status.code], msg: status.message || ""});
+    });
+};
+`
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_rest.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+// The google.api.http option is declared by google/api/annotations.proto, whose Go package is not a dependency
+// of the generator. The rules are decoded into the messages below, which have the same field numbers.
+// See https://github.com/googleapis/googleapis/blob/master/google/api/http.proto
+
+var extHTTP = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MethodOptions)(nil),
+	ExtensionType: (*httpRule)(nil),
+	Field:         72295728,
+	Name:          "google.api.http",
+	Tag:           "bytes,72295728,opt,name=http",
+}
+
+type httpRule struct {
+	Get          *string            `protobuf:"bytes,2,opt,name=get"`
+	Put          *string            `protobuf:"bytes,3,opt,name=put"`
+	Post         *string            `protobuf:"bytes,4,opt,name=post"`
+	Delete       *string            `protobuf:"bytes,5,opt,name=delete"`
+	Patch        *string            `protobuf:"bytes,6,opt,name=patch"`
+	Body         *string            `protobuf:"bytes,7,opt,name=body"`
+	Custom       *customHTTPPattern `protobuf:"bytes,8,opt,name=custom"`
+	ResponseBody *string            `protobuf:"bytes,12,opt,name=response_body"`
+}
+
+type customHTTPPattern struct {
+	Kind *string `protobuf:"bytes,1,opt,name=kind"`
+	Path *string `protobuf:"bytes,2,opt,name=path"`
+}
+
+func (m *httpRule) Reset()         { *m = httpRule{} }
+func (m *httpRule) String() string { return proto.CompactTextString(m) }
+func (*httpRule) ProtoMessage()    {}
+
+func (m *customHTTPPattern) Reset()         { *m = customHTTPPattern{} }
+func (m *customHTTPPattern) String() string { return proto.CompactTextString(m) }
+func (*customHTTPPattern) ProtoMessage()    {}
+
+// googleAPIFiles are the files of the google.api.http and google.api.field_behavior options, which declare no
+// messages of an API.
+var googleAPIFiles = map[string]bool{
+	"google/api/annotations.proto":     true,
+	"google/api/field_behavior.proto":  true,
+	"google/api/http.proto":            true,
+	"google/protobuf/descriptor.proto": true,
+}
+
+// IsGoogleAPIFile reports if a file declares the google.api options, so no module needs to be generated for it.
+func IsGoogleAPIFile(f *descriptor.FileDescriptorProto) bool {
+	return googleAPIFiles[f.GetName()]
+}
+
+// HTTPRule is the REST route of an rpc method, see HttpRule in the REST runtime.
+type HTTPRule struct {
+	Method       string
+	Path         string
+	Body         string
+	ResponseBody string
+
+	jsonPaths map[string]string // JSON names of the field paths of the rule, see checkHTTPRule
+}
+
+// Literal is the typescript object of the HttpRule of the REST runtime, e.g. {method: "GET", path: "/v1/{name=shelves/*}"}
+// The field paths of the rule are the JSON names of the fields.
+func (r *HTTPRule) Literal() string {
+	path := pathVariable.ReplaceAllStringFunc(r.Path, func(v string) string {
+		m := pathVariable.FindStringSubmatch(v)
+		return "{" + r.jsonPath(m[1]) + m[2] + "}"
+	})
+
+	fields := []string{"method: " + jsString(r.Method), "path: " + jsString(path)}
+
+	if r.Body != "" {
+		fields = append(fields, "body: "+jsString(r.jsonPath(r.Body)))
+	}
+
+	if r.ResponseBody != "" {
+		fields = append(fields, "responseBody: "+jsString(r.jsonPath(r.ResponseBody)))
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+// jsonPath is the JSON names of a field path of the rule, or the path itself when the rule has not been checked.
+func (r *HTTPRule) jsonPath(path string) string {
+	if p, ok := r.jsonPaths[path]; ok {
+		return p
+	}
+
+	return path
+}
+
+// pathVariable matches the variables of a path template, e.g. {name} or {name=shelves/*}
+var pathVariable = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// getHTTPRule returns the REST route of the google.api.http option of an rpc method, or nil when it has none.
+// Only the primary binding is used, so the additional bindings of the option are ignored.
+func getHTTPRule(m *descriptor.MethodDescriptorProto) (*HTTPRule, error) {
+	if m.GetOptions() == nil || !proto.HasExtension(m.GetOptions(), extHTTP) {
+		return nil, nil
+	}
+
+	ext, err := proto.GetExtension(m.GetOptions(), extHTTP)
+	if err != nil {
+		return nil, err
+	}
+
+	r := ext.(*httpRule)
+	rule := &HTTPRule{}
+
+	if r.Body != nil {
+		rule.Body = *r.Body
+	}
+
+	if r.ResponseBody != nil {
+		rule.ResponseBody = *r.ResponseBody
+	}
+
+	switch {
+	case r.Get != nil:
+		rule.Method, rule.Path = "GET", *r.Get
+	case r.Put != nil:
+		rule.Method, rule.Path = "PUT", *r.Put
+	case r.Post != nil:
+		rule.Method, rule.Path = "POST", *r.Post
+	case r.Delete != nil:
+		rule.Method, rule.Path = "DELETE", *r.Delete
+	case r.Patch != nil:
+		rule.Method, rule.Path = "PATCH", *r.Patch
+	case r.Custom != nil && r.Custom.Kind != nil && r.Custom.Path != nil:
+		rule.Method, rule.Path = *r.Custom.Kind, *r.Custom.Path
+	default:
+		return nil, fmt.Errorf("no pattern")
+	}
+
+	return rule, nil
+}
+
+// checkHTTPRule checks that the fields of the path variables and the body of a REST route are fields of the input
+// type of the rpc method, and the response body is a field of the output type. The JSON names of the field paths
+// are recorded in the rule, since the REST runtime reads the fields from the JSON of the messages.
+func (ctx *APIContext) checkHTTPRule(rule *HTTPRule, method ServiceMethod) error {
+	rule.jsonPaths = make(map[string]string)
+
+	for _, v := range pathVariable.FindAllStringSubmatch(rule.Path, -1) {
+		path, ok := ctx.jsonFieldPath(method.InputType, v[1])
+		if !ok {
+			return fmt.Errorf("path variable %s is not a field of %s", v[1], method.InputType)
+		}
+
+		rule.jsonPaths[v[1]] = path
+	}
+
+	if rule.Body != "" && rule.Body != "*" {
+		path, ok := ctx.jsonFieldPath(method.InputType, rule.Body)
+		if !ok {
+			return fmt.Errorf("body %s is not a field of %s", rule.Body, method.InputType)
+		}
+
+		rule.jsonPaths[rule.Body] = path
+	}
+
+	if rule.ResponseBody != "" {
+		path, ok := ctx.jsonFieldPath(method.OutputType, rule.ResponseBody)
+		if !ok {
+			return fmt.Errorf("response_body %s is not a field of %s", rule.ResponseBody, method.OutputType)
+		}
+
+		rule.jsonPaths[rule.ResponseBody] = path
+	}
+
+	return nil
+}
+
+// jsonFieldPath returns the path of the JSON names of a field path of a model, e.g. book.author_name is
+// book.authorName with Options.JSONNames set to camel, and reports if the path is a field of the model. The names
+// of a field path are the proto field names.
+func (ctx *APIContext) jsonFieldPath(model string, path string) (string, bool) {
+	var names []string
+
+	for _, name := range strings.Split(path, ".") {
+		m, ok := ctx.modelLookup[model]
+		if !ok {
+			return "", false
+		}
+
+		model = ""
+		for _, f := range m.fields() {
+			if f.ProtoName == name {
+				model = f.Type
+				names = append(names, f.JSONName)
+				break
+			}
+		}
+
+		if model == "" {
+			return "", false
+		}
+	}
+
+	return strings.Join(names, "."), true
+}
@@ -0,0 +1,269 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// schemaDir is the directory of the output directory that the JSON schemas of the messages are generated into,
+// with Options.JSONSchema. The typescript names of the messages are unique, so the schemas are not nested.
+const schemaDir = "schemas"
+
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchema is a JSON Schema document, or a subschema of one, with the keywords used by the schemas of messages.
+// The keywords are written in the order of the fields, so the schema of a message reads from its title down.
+type jsonSchema struct {
+	Schema               string           `json:"$schema,omitempty"`
+	Ref                  string           `json:"$ref,omitempty"`
+	Title                string           `json:"title,omitempty"`
+	Description          string           `json:"description,omitempty"`
+	Type                 interface{}      `json:"type,omitempty"`
+	Format               string           `json:"format,omitempty"`
+	ContentEncoding      string           `json:"contentEncoding,omitempty"`
+	Pattern              string           `json:"pattern,omitempty"`
+	Enum                 []interface{}    `json:"enum,omitempty"`
+	ReadOnly             bool             `json:"readOnly,omitempty"`
+	Items                *jsonSchema      `json:"items,omitempty"`
+	Properties           schemaProperties `json:"properties,omitempty"`
+	PropertyNames        *jsonSchema      `json:"propertyNames,omitempty"`
+	AdditionalProperties *jsonSchema      `json:"additionalProperties,omitempty"`
+	Required             []string         `json:"required,omitempty"`
+	AnyOf                []*jsonSchema    `json:"anyOf,omitempty"`
+	OneOf                []*jsonSchema    `json:"oneOf,omitempty"`
+	AllOf                []*jsonSchema    `json:"allOf,omitempty"`
+	Not                  *jsonSchema      `json:"not,omitempty"`
+}
+
+// schemaProperty is a property of the schema of a message, which is the JSON name of a field and its schema.
+type schemaProperty struct {
+	Name   string
+	Schema *jsonSchema
+}
+
+// schemaProperties are written as a JSON object in the order of the fields of the message.
+type schemaProperties []schemaProperty
+
+func (p schemaProperties) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{")
+
+	for i, prop := range p {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		name, err := json.Marshal(prop.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := json.Marshal(prop.Schema)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(name)
+		b.WriteString(":")
+		b.Write(value)
+	}
+
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
+
+// schemaFileName is the name of the file of the JSON schema of a message, e.g. schemas/Hat.json, which is also
+// the $ref of the fields of other messages, relative to the schemas directory.
+func schemaFileName(name string) string {
+	return name + ".json"
+}
+
+// messageSchema generates the JSON schema of the proto3 JSON of a message, whose properties are the JSON names
+// of its fields. Fields with the REQUIRED field behavior are required, and at most one member of a oneof is set.
+func messageSchema(m *Model, enums map[string]*Enum) *jsonSchema {
+	schema := &jsonSchema{
+		Schema:      jsonSchemaDraft,
+		Title:       m.Name,
+		Description: strings.TrimSpace(m.Comment),
+		Type:        "object",
+	}
+
+	for _, f := range m.Fields {
+		prop := fieldSchema(f, enums)
+		prop.Description = strings.TrimSpace(f.Comment)
+		prop.ReadOnly = f.IsReadOnly
+
+		schema.Properties = append(schema.Properties, schemaProperty{f.JSONName, prop})
+
+		if f.IsRequired {
+			schema.Required = append(schema.Required, f.JSONName)
+		}
+	}
+
+	for _, o := range m.Oneofs {
+		var members []*jsonSchema
+
+		for _, f := range o.Fields {
+			prop := fieldSchema(f, enums)
+			prop.Description = strings.TrimSpace(f.Comment)
+
+			schema.Properties = append(schema.Properties, schemaProperty{f.JSONName, prop})
+			members = append(members, &jsonSchema{Required: []string{f.JSONName}})
+		}
+
+		if len(members) < 2 {
+			continue
+		}
+
+		// exactly one of the members is set, or none of them is
+		schema.AllOf = append(schema.AllOf, &jsonSchema{
+			OneOf: append(members, &jsonSchema{Not: &jsonSchema{AnyOf: members}}),
+		})
+	}
+
+	return schema
+}
+
+// fieldSchema generates the JSON schema of the proto3 JSON value of a field.
+func fieldSchema(f ModelField, enums map[string]*Enum) *jsonSchema {
+	switch {
+	case f.IsMap:
+		return &jsonSchema{
+			Type:                 "object",
+			PropertyNames:        mapKeySchema(*f.Key),
+			AdditionalProperties: fieldSchema(*f.Value, enums),
+		}
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = strings.TrimSuffix(f.Type, "[]")
+
+		return &jsonSchema{Type: "array", Items: fieldSchema(item, enums)}
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+
+		return &jsonSchema{AnyOf: []*jsonSchema{fieldSchema(value, enums), {Type: "null"}}}
+	case f.IsDuration:
+		return &jsonSchema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]{1,9})?s$`}
+	case f.IsFieldMask:
+		return &jsonSchema{Type: "string"}
+	case f.Codec == "any":
+		return &jsonSchema{
+			Type:       "object",
+			Properties: schemaProperties{{"@type", &jsonSchema{Type: "string"}}},
+			Required:   []string{"@type"},
+		}
+	case f.Codec == "struct":
+		return &jsonSchema{Type: "object"}
+	case f.Codec == "listValue":
+		return &jsonSchema{Type: "array"}
+	case f.Codec == "value":
+		return &jsonSchema{}
+	case f.IsMessage && f.Type == "Date":
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case f.IsMessage:
+		return &jsonSchema{Ref: schemaFileName(f.Type)}
+	case f.IsEnum:
+		return enumSchema(enums[f.Type], f.EnumNumbers)
+	}
+
+	return scalarSchema(f.ProtoType)
+}
+
+// enumSchema generates the JSON schema of an enum field, whose values are sent as their names, or their numbers
+// with Options.Enums set to number.
+func enumSchema(e *Enum, numbers bool) *jsonSchema {
+	schema := &jsonSchema{Type: "string"}
+	if numbers {
+		schema.Type = "integer"
+	}
+
+	if e == nil {
+		return schema
+	}
+
+	for _, v := range e.Values {
+		if numbers {
+			schema.Enum = append(schema.Enum, v.Value)
+		} else {
+			schema.Enum = append(schema.Enum, v.Name)
+		}
+	}
+
+	return schema
+}
+
+// mapKeySchema generates the schema of the keys of a map field, which are strings in JSON, or nil for string keys.
+func mapKeySchema(key ModelField) *jsonSchema {
+	switch key.ProtoType {
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return nil
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return &jsonSchema{Enum: []interface{}{"true", "false"}}
+	}
+
+	return &jsonSchema{Pattern: "^-?[0-9]+$"}
+}
+
+// scalarSchema generates the JSON schema of a scalar field. 64 bit integers may be strings, as in the proto3 JSON
+// mapping, and floating point numbers may be the strings of their non-finite values.
+func scalarSchema(t descriptor.FieldDescriptorProto_Type) *jsonSchema {
+	switch t {
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+		descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return &jsonSchema{AnyOf: []*jsonSchema{{Type: "number"}, {Enum: []interface{}{"NaN", "Infinity", "-Infinity"}}}}
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		return &jsonSchema{Type: []string{"string", "integer"}, Pattern: "^-?[0-9]+$"}
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return &jsonSchema{Type: "integer"}
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return &jsonSchema{Type: "boolean"}
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+	}
+
+	return &jsonSchema{Type: "string"}
+}
+
+// renderSchemas generates the JSON schema of each message of the file, see messageSchema.
+func (ctx *APIContext) renderSchemas(enums map[string]*Enum) ([]*plugin.CodeGeneratorResponse_File, error) {
+	var files []*plugin.CodeGeneratorResponse_File
+
+	for _, m := range ctx.Models {
+		if m.Primitive {
+			continue
+		}
+
+		var b bytes.Buffer
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(messageSchema(m, enums)); err != nil {
+			return nil, err
+		}
+
+		cf := &plugin.CodeGeneratorResponse_File{}
+		cf.Name = proto.String(schemaDir + "/" + schemaFileName(m.Name))
+		cf.Content = proto.String(b.String())
+
+		files = append(files, cf)
+	}
+
+	return files, nil
+}
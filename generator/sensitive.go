@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The debug_redact option of google.protobuf.FieldOptions is newer than the descriptor of the generator, so it is
+// decoded from the unrecognized fields of the options.
+// See https://github.com/protocolbuffers/protobuf/blob/main/src/google/protobuf/descriptor.proto
+const fieldOptionsDebugRedact = 16
+
+// sensitiveOptions are the custom field options that mark sensitive fields, which are the bool extensions of
+// google.protobuf.FieldOptions named sensitive declared by the files of a request, e.g.
+//
+//	extend google.protobuf.FieldOptions { bool sensitive = 50000; }
+func sensitiveOptions(files []*descriptor.FileDescriptorProto) []*proto.ExtensionDesc {
+	var exts []*proto.ExtensionDesc
+
+	add := func(scope string, fields []*descriptor.FieldDescriptorProto) {
+		for _, e := range fields {
+			if e.GetExtendee() != ".google.protobuf.FieldOptions" || e.GetName() != "sensitive" || e.GetType() != descriptor.FieldDescriptorProto_TYPE_BOOL {
+				continue
+			}
+
+			exts = append(exts, &proto.ExtensionDesc{
+				ExtendedType:  (*descriptor.FieldOptions)(nil),
+				ExtensionType: (*bool)(nil),
+				Field:         e.GetNumber(),
+				Name:          scopedName(scope, e.GetName()),
+				Tag:           fmt.Sprintf("varint,%d,opt,name=%s", e.GetNumber(), e.GetName()),
+			})
+		}
+	}
+
+	var messages func(scope string, ms []*descriptor.DescriptorProto)
+	messages = func(scope string, ms []*descriptor.DescriptorProto) {
+		for _, m := range ms {
+			name := scopedName(scope, m.GetName())
+			add(name, m.GetExtension())
+			messages(name, m.GetNestedType())
+		}
+	}
+
+	for _, f := range files {
+		add(f.GetPackage(), f.GetExtension())
+		messages(f.GetPackage(), f.GetMessageType())
+	}
+
+	return exts
+}
+
+// scopedName is the full name of a declaration in a package or a message, e.g. acme.options.sensitive
+func scopedName(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+
+	return scope + "." + name
+}
+
+// isSensitive reports if a field is marked as sensitive by the debug_redact option, or by a custom option of
+// sensitiveOptions, e.g. string password = 1 [(sensitive) = true];
+func (ctx *APIContext) isSensitive(f *descriptor.FieldDescriptorProto) bool {
+	o := f.GetOptions()
+	if o == nil {
+		return false
+	}
+
+	if debugRedact(o) {
+		return true
+	}
+
+	for _, e := range ctx.sensitive {
+		if !proto.HasExtension(o, e) {
+			continue
+		}
+
+		if v, err := proto.GetExtension(o, e); err == nil && *v.(*bool) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// debugRedact decodes the debug_redact option from the unrecognized fields of the options of a field.
+func debugRedact(o *descriptor.FieldOptions) bool {
+	redact := false
+
+	for b := o.XXX_unrecognized; len(b) > 0; {
+		key, n := proto.DecodeVarint(b)
+		if n == 0 {
+			return false
+		}
+		b = b[n:]
+
+		var size int
+		switch key & 7 {
+		case proto.WireVarint:
+			v, n := proto.DecodeVarint(b)
+			if n == 0 {
+				return false
+			}
+
+			if key>>3 == fieldOptionsDebugRedact {
+				redact = v != 0
+			}
+			size = n
+		case proto.WireFixed64:
+			size = 8
+		case proto.WireBytes:
+			l, n := proto.DecodeVarint(b)
+			if n == 0 {
+				return false
+			}
+			size = n + int(l)
+		case proto.WireFixed32:
+			size = 4
+		default:
+			return redact
+		}
+
+		if size > len(b) {
+			return false
+		}
+		b = b[size:]
+	}
+
+	return redact
+}
+
+// markRedactedModels marks the models with sensitive fields, and the models with message fields of such models,
+// which have a redact function, see redactions. Like markValidatedModels, it is called with all of the files,
+// since the model of a field may be declared in another file.
+func markRedactedModels(ctxs []*APIContext) {
+	for changed := true; changed; {
+		changed = false
+
+		for _, ctx := range ctxs {
+			for _, m := range ctx.Models {
+				if m.Redact {
+					continue
+				}
+
+				for _, f := range m.fields() {
+					if f.IsSensitive || ctx.redactsField(f) {
+						m.Redact = true
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// redactsField reports if a message field, or the value of a map field, is a message with a redact function.
+func (ctx *APIContext) redactsField(f ModelField) bool {
+	if f.IsMap {
+		return ctx.redactsField(*f.Value)
+	}
+
+	target, ok := ctx.modelLookup[strings.TrimSuffix(f.Type, "[]")]
+
+	return ok && f.IsMessage && target.Redact
+}
+
+// redacts reports if a message has a redact function.
+func (ctx *APIContext) redacts(model string) bool {
+	m, ok := ctx.modelLookup[model]
+
+	return ok && m.Redact
+}
+
+// redaction is the function that redacts the value of a field, or empty when it has no sensitive data.
+func (ctx *APIContext) redaction(f ModelField) string {
+	switch {
+	case f.IsSensitive:
+		return "redacted"
+	case f.IsMap && ctx.redactsField(f):
+		return "redactMap(" + ctx.redaction(*f.Value) + ")"
+	case f.IsRepeated && ctx.redactsField(f):
+		return "redactList(redact" + strings.TrimSuffix(f.Type, "[]") + ")"
+	case ctx.redactsField(f):
+		return "redact" + f.Type
+	}
+
+	return ""
+}
+
+// redactions generates the argument of redactFields for the redact function of a model, which has the redactions
+// of its fields and oneofs.
+func (ctx *APIContext) redactions(m *Model) string {
+	var redactions []string
+
+	for _, f := range m.Fields {
+		if r := ctx.redaction(f); r != "" {
+			redactions = append(redactions, f.Name+": "+r)
+		}
+	}
+
+	for _, o := range m.Oneofs {
+		var members []string
+		for _, f := range o.Fields {
+			if r := ctx.redaction(f); r != "" {
+				members = append(members, f.Name+": "+r)
+			}
+		}
+
+		if len(members) > 0 {
+			redactions = append(redactions, fmt.Sprintf("%s: redactOneof({%s})", o.Name, strings.Join(members, ", ")))
+		}
+	}
+
+	return strings.Join(redactions, ", ")
+}
+
+// redactor generates the Redactor of the InterceptorContext of a call of an rpc method, e.g.
+// {request: redactLogin}, or empty when neither its request nor its response have sensitive fields.
+func (ctx *APIContext) redactor(m ServiceMethod) string {
+	var parts []string
+
+	if ctx.redacts(m.InputType) {
+		parts = append(parts, "request: redact"+m.InputType)
+	}
+
+	if ctx.redacts(m.OutputType) {
+		parts = append(parts, "response: redact"+m.OutputType)
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return "{" + strings.Join(parts, ", ") + "}"
+}
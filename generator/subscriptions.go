@@ -0,0 +1,343 @@
+package generator
+
+import (
+	"bytes"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// subscriptionPrefixes are the prefixes of the names of the subscription methods, e.g. WatchHats
+var subscriptionPrefixes = []string{"Subscribe", "Watch"}
+
+// isSubscription reports if an rpc method is a subscription method, which is named Subscribe or Watch, or starts
+// with either of them followed by an uppercase letter, e.g. SubscribeHats but not Watchdog.
+func isSubscription(name string) bool {
+	for _, p := range subscriptionPrefixes {
+		if !strings.HasPrefix(name, p) {
+			continue
+		}
+
+		rest := name[len(p):]
+		if rest == "" || (rest[0] >= 'A' && rest[0] <= 'Z') {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SubscriptionsLibrary is the runtime module used by the generated subscription clients, see Options.Subscriptions.
+// The messages of a connection are queued until they are read, so none are lost while a message is handled.
+func SubscriptionsLibrary(transport string) *plugin.CodeGeneratorResponse_File {
+	tmpl := `
+import {TwirpError, TwirpErrorCode} from './twirp';
+
+// Subscription is an async iterator of the messages of a subscription, e.g.
+// for await (const hat of subscriptions.watchHats(size)) { ... }
+// Breaking out of the loop closes the connection.
+export interface Subscription<T> extends AsyncIterableIterator<T> {
+    // close closes the connection, and ends the iteration after the messages that were already received
+    close(): void;
+}
+
+// SubscriptionOptions are the options of the connections of the subscriptions.
+export interface SubscriptionOptions {
+` + subscriptionsRuntime[transport].options + `}
+
+interface Reader<T> {
+    resolve: (result: IteratorResult<T>) => void;
+    reject: (err: any) => void;
+}
+
+// subscription is the Subscription of the messages that a connection pushes. The connection is opened by open,
+// which returns the function that closes it, and ends the subscription by calling end, with the error that failed
+// it, if any.
+const subscription = <T>(open: (push: (value: T) => void, end: (err?: any) => void) => () => void): Subscription<T> => {
+    const values: T[] = [];
+    const readers: Reader<T>[] = [];
+    let done = false;
+    let error: any;
+    let close = () => {};
+
+    // flush resolves the pending reads with the received messages, and then with the end of the subscription
+    const flush = () => {
+        while (readers.length > 0 && (values.length > 0 || done)) {
+            const reader = readers.shift() as Reader<T>;
+            if (values.length > 0) {
+                reader.resolve({value: values.shift() as T, done: false});
+            } else if (error !== undefined) {
+                reader.reject(error);
+                error = undefined;
+            } else {
+                reader.resolve({value: undefined, done: true});
+            }
+        }
+    };
+
+    const end = (err?: any) => {
+        if (done) {
+            return;
+        }
+
+        done = true;
+        error = err;
+        close();
+        flush();
+    };
+
+    const closeConnection = open((value) => {
+        if (!done) {
+            values.push(value);
+            flush();
+        }
+    }, end);
+
+    // the connection may fail while it is opened
+    if (done) {
+        closeConnection();
+    } else {
+        close = closeConnection;
+    }
+
+    const sub: Subscription<T> = {
+        next: () => new Promise<IteratorResult<T>>((resolve, reject) => {
+            readers.push({resolve: resolve, reject: reject});
+            flush();
+        }),
+        return: () => {
+            values.length = 0;
+            end();
+
+            return Promise.resolve<IteratorResult<T>>({value: undefined, done: true});
+        },
+        close: () => end(),
+        [Symbol.asyncIterator]: () => sub,
+    };
+
+    return sub;
+};
+
+// connectionError is the error of a connection that failed, or was closed by the server without ending the
+// subscription.
+const connectionError = (url: string): TwirpError => {
+    return new TwirpError({code: TwirpErrorCode.Unavailable, msg: "the connection to " + url + " failed"});
+};
+` + subscriptionsRuntime[transport].subscribe
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("twirp_subscriptions.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+// subscriptionsRuntime are the options of the connections of the subscription clients, and the function that opens
+// them, for each transport.
+var subscriptionsRuntime = map[string]struct {
+	options   string
+	subscribe string
+}{
+	SubscriptionsSSE: {
+		options: `    // withCredentials sends the cookies of the page to an endpoint of another origin
+    withCredentials?: boolean;
+    // EventSource is the constructor of the connections, e.g. from the eventsource package in Node
+    EventSource?: {new (url: string, init?: EventSourceInit): EventSource};
+`,
+		subscribe: `
+// subscribe opens the server-sent events of a subscription method, whose request is the JSON of the request query
+// parameter. The server sends a message event with the JSON of each message, an end event to end the subscription,
+// or a twirp_error event with the JSON of the Twirp error that fails it. The connection is not reopened when it
+// fails.
+export const subscribe = <T>(url: string, request: object, decode: (data: string) => T, options: SubscriptionOptions): Subscription<T> => {
+    return subscription<T>((push, end) => {
+        const source = new (options.EventSource || EventSource)(url + (url.indexOf("?") < 0 ? "?" : "&") + "request=" + encodeURIComponent(JSON.stringify(request)), {
+            withCredentials: !!options.withCredentials,
+        });
+
+        source.onmessage = (e: MessageEvent) => {
+            try {
+                push(decode(e.data));
+            } catch (err) {
+                end(err);
+            }
+        };
+        source.addEventListener("end", () => end());
+        source.addEventListener("twirp_error", (e: Event) => {
+            try {
+                end(new TwirpError(JSON.parse((e as MessageEvent).data)));
+            } catch (err) {
+                end(connectionError(url));
+            }
+        });
+        source.onerror = () => end(connectionError(url));
+
+        return () => source.close();
+    });
+};
+`,
+	},
+	SubscriptionsWebSocket: {
+		options: `    // protocols are the subprotocols of the connections, e.g. to send a token
+    protocols?: string | string[];
+    // WebSocket is the constructor of the connections, e.g. from the ws package in Node
+    WebSocket?: {new (url: string, protocols?: string | string[]): WebSocket};
+`,
+		subscribe: `
+// subscribe opens the WebSocket of a subscription method, which sends the JSON of the request when it is open. The
+// server sends a text message with the JSON of each message, and closes the WebSocket with the code 1000 to end the
+// subscription, or with the JSON of a Twirp error as the reason to fail it.
+export const subscribe = <T>(url: string, request: object, decode: (data: string) => T, options: SubscriptionOptions): Subscription<T> => {
+    return subscription<T>((push, end) => {
+        const socket = new (options.WebSocket || WebSocket)(url, options.protocols);
+
+        socket.onopen = () => socket.send(JSON.stringify(request));
+        socket.onmessage = (e: MessageEvent) => {
+            try {
+                push(decode(String(e.data)));
+            } catch (err) {
+                end(err);
+            }
+        };
+        socket.onclose = (e: CloseEvent) => {
+            if (e.code === 1000) {
+                end();
+                return;
+            }
+
+            try {
+                end(new TwirpError(JSON.parse(e.reason)));
+            } catch (err) {
+                end(connectionError(url));
+            }
+        };
+
+        return () => socket.close();
+    });
+};
+`,
+	},
+}
+
+const subscriptionsTemplate = `
+import {joinURL, parseLosslessJSON} from '{{importPath "twirp"}}';
+import {Subscription, SubscriptionOptions, subscribe} from '{{importPath "twirp_subscriptions"}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range $s := .Services}}
+// {{.Name}}Subscriptions are the subscriptions to the subscription methods of {{.Name}}, which receive the messages
+// of a connection to the route of the method.
+export interface {{.Name}}Subscriptions {
+    {{- range .Methods}}{{if .Subscription}}
+    {{jsdoc .Comment "    "}}{{.Name}}: ({{if not .EmptyRequest}}{{.InputArg}}: {{.InputType}}{{end}}) => Subscription<{{.ResponseType}}>;
+    {{- end}}{{end}}
+}
+
+// create{{.Name}}Subscriptions creates the subscriptions of {{.Name}}, which connect to the routes of the methods
+// of the endpoint, e.g. {{$.Example}}/{{.FullName}}/{{(firstSubscription .).Path}}
+export const create{{.Name}}Subscriptions = (endpoint: string, options: SubscriptionOptions = {}): {{.Name}}Subscriptions => {
+    return {
+        {{- range .Methods}}{{if .Subscription}}
+        {{- $json := "JSON.parse(data)"}}{{if .LosslessJSON}}{{$json = "parseLosslessJSON(data)"}}{{end}}
+        {{.Name}}: ({{if not .EmptyRequest}}{{.InputArg}}: {{.InputType}}{{end}}): Subscription<{{.ResponseType}}> => {
+            return subscribe(joinURL(endpoint, "/{{$s.FullName}}/{{.Path}}"), {{if .EmptyRequest}}{}{{else}}{{.InputType}}ToJSON({{.InputArg}}){{end}}, {{if .EmptyResponse}}() => undefined{{else}}(data) => JSONTo{{.OutputType}}({{$json}}){{end}}, options);
+        },
+        {{- end}}{{end}}
+    };
+};
+{{end}}`
+
+// subscriptionsModuleName is the name of the module of the subscription clients of a module, e.g.
+// service_subscriptions
+func subscriptionsModuleName(module string) string {
+	return module + "_subscriptions"
+}
+
+// subscriptionsModule is the module of the subscription clients of the services of a generated module, see
+// renderSubscriptions.
+type subscriptionsModule struct {
+	// Example is an example endpoint of the transport, e.g. wss://example.com/subscriptions
+	Example  string
+	Imports  []*Import
+	Services []*Service
+}
+
+// hasSubscriptions reports if any of the services of the module has a subscription method, see isSubscription.
+func (ctx *APIContext) hasSubscriptions() bool {
+	for _, s := range ctx.Services {
+		if firstSubscription(s) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// firstSubscription is the first subscription method of a service, or nil when it has none.
+func firstSubscription(s *Service) *ServiceMethod {
+	for i := range s.Methods {
+		if s.Methods[i].Subscription {
+			return &s.Methods[i]
+		}
+	}
+
+	return nil
+}
+
+// renderSubscriptions generates the subscription clients of the services of the module, e.g.
+// service_subscriptions.ts, with Options.Subscriptions. The subscription methods are also called by the clients
+// like the other rpc methods, so a service may implement both, e.g. to get the current state of a resource and then
+// watch it. The messages are decoded by the JSON functions of the output types.
+func (ctx *APIContext) renderSubscriptions() (*plugin.CodeGeneratorResponse_File, error) {
+	module := subscriptionsModule{Example: "https://example.com/subscriptions"}
+	if ctx.Subscriptions == SubscriptionsWebSocket {
+		module.Example = "wss://example.com/subscriptions"
+	}
+
+	for _, s := range ctx.Services {
+		if firstSubscription(s) != nil {
+			module.Services = append(module.Services, s)
+		}
+	}
+
+	module.Imports = ctx.helperImports(func(add func(typ string, names ...string)) {
+		for _, s := range module.Services {
+			for _, m := range s.Methods {
+				if !m.Subscription {
+					continue
+				}
+
+				add(m.InputType, m.InputType, m.InputType+"ToJSON")
+				add(m.OutputType, m.ResponseType, "JSONTo"+m.OutputType)
+			}
+		}
+	})
+
+	funcMap := template.FuncMap{
+		"join":              strings.Join,
+		"jsdoc":             jsdoc,
+		"firstSubscription": firstSubscription,
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("subscriptions").Funcs(funcMap).Parse(subscriptionsTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(subscriptionsModuleName(ctx.module) + ctx.fileExt())
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// customTemplate is a template file of Options.TemplateDir, which is named after the file without its extension,
+// e.g. header for header.tmpl.
+type customTemplate struct {
+	name string
+	text string
+}
+
+// loadTemplates reads the *.tmpl files of Options.TemplateDir in the order of their names, so a template that is
+// defined by more than one file is defined by the last of them.
+func loadTemplates(dir string) ([]customTemplate, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("template_dir: %v", err)
+	}
+
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("template_dir: no *.tmpl files in %s", dir)
+	}
+	sort.Strings(paths)
+
+	var templates []customTemplate
+	for _, p := range paths {
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("template_dir: %v", err)
+		}
+
+		templates = append(templates, customTemplate{name: strings.TrimSuffix(filepath.Base(p), ".tmpl"), text: string(b)})
+	}
+
+	return templates, nil
+}
+
+// parseTemplates parses the module template, and the custom templates that override or extend it. The header and
+// footer templates are empty unless they are defined by a custom template, e.g. header.tmpl with a license header,
+// and a custom template named after the module template replaces it, e.g. client_api.tmpl.
+func (ctx *APIContext) parseTemplates(name, text string, funcMap template.FuncMap) (*template.Template, error) {
+	t, err := template.New(name).Funcs(funcMap).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, n := range []string{"header", "footer"} {
+		if _, err := t.New(n).Parse(""); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, c := range ctx.templates {
+		if _, err := t.New(c.name).Parse(c.text); err != nil {
+			return nil, fmt.Errorf("template_dir: %v", err)
+		}
+	}
+
+	return t, nil
+}
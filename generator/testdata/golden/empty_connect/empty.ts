@@ -0,0 +1,352 @@
+import {createConnectRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwConnectError, TwirpError, TwirpErrorCode, CallOptions} from './twirp';
+import {InterceptorContext, retryNetworkFailures, TwirpClient, runInterceptors} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** A Fitting is a fitting of a hat, whose notes are not recorded yet. */
+export interface Fitting {
+    hat: Hat;
+    notes: {[key: string]: any};
+}
+
+// FittingFields are the numbers and proto types of the fields of Fitting by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const FittingFields = {
+    hat: {number: 1, name: "hat", type: "message"},
+    notes: {number: 2, name: "notes", type: "message"},
+} as const;
+
+export interface FittingJSON {
+    hat: HatJSON;
+    notes: {[key: string]: any};
+}
+
+export const FittingToJSON = (m: Fitting): FittingJSON => {
+    return {
+        hat: HatToJSON(m.hat),
+        notes: m.notes,
+    };
+};
+
+// isFitting reports if a value has the fields of a Fitting, e.g. to check data read from a cache or a websocket.
+export const isFitting = (value: unknown): value is Fitting => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isHat(m.hat)
+        && typeof m.notes === "object" && m.notes !== null;
+};
+
+/** Haberdasher makes hats, and has methods without a request or a response. */
+export interface Haberdasher {
+    /** Ping checks that the Haberdasher is serving. */
+    ping: (callOptions?: CallOptions) => Promise<void>;
+
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat: (callOptions?: CallOptions) => Promise<Hat>;
+
+    /** DiscardHat throws a hat away. */
+    discardHat: (hat: Hat, callOptions?: CallOptions) => Promise<void>;
+
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting: (fitting: Fitting, callOptions?: CallOptions) => Promise<void>;
+
+    /** WatchHats receives the hats as they are made. */
+    watchHats: (callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    ping: {
+        service: "empty.Haberdasher",
+        method: "Ping",
+        path: "/empty.Haberdasher/Ping",
+        inputType: "google.protobuf.Empty",
+        outputType: "google.protobuf.Empty",
+    },
+    getFeaturedHat: {
+        service: "empty.Haberdasher",
+        method: "GetFeaturedHat",
+        path: "/empty.Haberdasher/GetFeaturedHat",
+        inputType: "google.protobuf.Empty",
+        outputType: "Hat",
+    },
+    discardHat: {
+        service: "empty.Haberdasher",
+        method: "DiscardHat",
+        path: "/empty.Haberdasher/DiscardHat",
+        inputType: "Hat",
+        outputType: "google.protobuf.Empty",
+    },
+    recordFitting: {
+        service: "empty.Haberdasher",
+        method: "RecordFitting",
+        path: "/empty.Haberdasher/RecordFitting",
+        inputType: "Fitting",
+        outputType: "google.protobuf.Empty",
+    },
+    watchHats: {
+        service: "empty.Haberdasher",
+        method: "WatchHats",
+        path: "/empty.Haberdasher/WatchHats",
+        inputType: "google.protobuf.Empty",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Ping checks that the Haberdasher is serving. */
+export const ping = (client: TwirpClient, callOptions?: CallOptions): Promise<void> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "") + "/empty.Haberdasher/Ping");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "empty.Haberdasher",
+            method: "Ping",
+            url: url,
+            request: {},
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createConnectRequest(ctx.url, {}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwConnectError(resp);
+                }
+
+                return resp.text().then(() => undefined);
+            });
+        });
+    }));
+};
+
+/** GetFeaturedHat returns the hat of the day. */
+export const getFeaturedHat = (client: TwirpClient, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "") + "/empty.Haberdasher/GetFeaturedHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "empty.Haberdasher",
+            method: "GetFeaturedHat",
+            url: url,
+            request: {},
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "no_side_effects",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createConnectRequest(ctx.url, {}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwConnectError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+/** DiscardHat throws a hat away. */
+export const discardHat = (client: TwirpClient, hat: Hat, callOptions?: CallOptions): Promise<void> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "") + "/empty.Haberdasher/DiscardHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "empty.Haberdasher",
+            method: "DiscardHat",
+            url: url,
+            request: hat,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createConnectRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwConnectError(resp);
+                }
+
+                return resp.text().then(() => undefined);
+            });
+        });
+    }));
+};
+
+/** RecordFitting records the fitting of a hat. */
+export const recordFitting = (client: TwirpClient, fitting: Fitting, callOptions?: CallOptions): Promise<void> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "") + "/empty.Haberdasher/RecordFitting");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "empty.Haberdasher",
+            method: "RecordFitting",
+            url: url,
+            request: fitting,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "idempotent",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createConnectRequest(ctx.url, FittingToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwConnectError(resp);
+                }
+
+                return resp.text().then(() => undefined);
+            });
+        });
+    }));
+};
+
+/** WatchHats receives the hats as they are made. */
+export const watchHats = (client: TwirpClient, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "") + "/empty.Haberdasher/WatchHats");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "empty.Haberdasher",
+            method: "WatchHats",
+            url: url,
+            request: {},
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createConnectRequest(ctx.url, {}, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwConnectError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createHaberdasherClient creates a Haberdasher of the rpc functions of Haberdasher, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createHaberdasherClient = (client: TwirpClient): Haberdasher => {
+    return {
+        ping: (callOptions?: CallOptions) => ping(client, callOptions),
+        getFeaturedHat: (callOptions?: CallOptions) => getFeaturedHat(client, callOptions),
+        discardHat: (hat: Hat, callOptions?: CallOptions) => discardHat(client, hat, callOptions),
+        recordFitting: (fitting: Fitting, callOptions?: CallOptions) => recordFitting(client, fitting, callOptions),
+        watchHats: (callOptions?: CallOptions) => watchHats(client, callOptions),
+    };
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    ping?: ((callOptions?: CallOptions) => void | Promise<void>);
+    getFeaturedHat?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+    discardHat?: ((hat: Hat, callOptions?: CallOptions) => void | Promise<void>);
+    recordFitting?: ((fitting: Fitting, callOptions?: CallOptions) => void | Promise<void>);
+    watchHats?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    ping(callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.ping;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.Ping"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+
+    getFeaturedHat(callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.getFeaturedHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.GetFeaturedHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+
+    discardHat(hat: Hat, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.discardHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.DiscardHat"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.recordFitting;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.RecordFitting"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(fitting, callOptions) : response));
+    }
+
+    watchHats(callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.watchHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.WatchHats"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
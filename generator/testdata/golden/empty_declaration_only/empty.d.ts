@@ -0,0 +1,195 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+import {ServerRequest, TwirpRouter} from './twirp_server';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly size: {readonly number: 1; readonly name: "size"; readonly type: "int32"};
+    readonly color: {readonly number: 2; readonly name: "color"; readonly type: "string"};
+};
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export declare const HatToJSON: (m: Hat) => HatJSON;
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+/** A Fitting is a fitting of a hat, whose notes are not recorded yet. */
+export interface Fitting {
+    hat: Hat;
+    notes: {[key: string]: any};
+}
+
+// FittingFields are the numbers and proto types of the fields of Fitting by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const FittingFields: {
+    readonly hat: {readonly number: 1; readonly name: "hat"; readonly type: "message"};
+    readonly notes: {readonly number: 2; readonly name: "notes"; readonly type: "message"};
+};
+
+export interface FittingJSON {
+    hat: HatJSON;
+    notes: {[key: string]: any};
+}
+
+export declare const FittingToJSON: (m: Fitting) => FittingJSON;
+
+export declare const JSONToFitting: (m: FittingJSON) => Fitting;
+
+// isFitting reports if a value has the fields of a Fitting, e.g. to check data read from a cache or a websocket.
+export declare const isFitting: (value: unknown) => value is Fitting;
+
+/** Haberdasher makes hats, and has methods without a request or a response. */
+export interface Haberdasher {
+    /** Ping checks that the Haberdasher is serving. */
+    ping: (callOptions?: CallOptions) => Promise<void>;
+
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat: (callOptions?: CallOptions) => Promise<Hat>;
+
+    /** DiscardHat throws a hat away. */
+    discardHat: (hat: Hat, callOptions?: CallOptions) => Promise<void>;
+
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting: (fitting: Fitting, callOptions?: CallOptions) => Promise<void>;
+
+    /** WatchHats receives the hats as they are made. */
+    watchHats: (callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const HaberdasherMethods: {
+    readonly ping: {
+        readonly service: "empty.Haberdasher";
+        readonly method: "Ping";
+        readonly path: "/twirp/empty.Haberdasher/Ping";
+        readonly inputType: "google.protobuf.Empty";
+        readonly outputType: "google.protobuf.Empty";
+    };
+    readonly getFeaturedHat: {
+        readonly service: "empty.Haberdasher";
+        readonly method: "GetFeaturedHat";
+        readonly path: "/twirp/empty.Haberdasher/GetFeaturedHat";
+        readonly inputType: "google.protobuf.Empty";
+        readonly outputType: "Hat";
+    };
+    readonly discardHat: {
+        readonly service: "empty.Haberdasher";
+        readonly method: "DiscardHat";
+        readonly path: "/twirp/empty.Haberdasher/DiscardHat";
+        readonly inputType: "Hat";
+        readonly outputType: "google.protobuf.Empty";
+    };
+    readonly recordFitting: {
+        readonly service: "empty.Haberdasher";
+        readonly method: "RecordFitting";
+        readonly path: "/twirp/empty.Haberdasher/RecordFitting";
+        readonly inputType: "Fitting";
+        readonly outputType: "google.protobuf.Empty";
+    };
+    readonly watchHats: {
+        readonly service: "empty.Haberdasher";
+        readonly method: "WatchHats";
+        readonly path: "/twirp/empty.Haberdasher/WatchHats";
+        readonly inputType: "google.protobuf.Empty";
+        readonly outputType: "Hat";
+    };
+};
+
+/** Haberdasher makes hats, and has methods without a request or a response. */
+export declare class DefaultHaberdasher implements Haberdasher {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    /** Ping checks that the Haberdasher is serving. */
+    ping(callOptions?: CallOptions): Promise<void>;
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat(callOptions?: CallOptions): Promise<Hat>;
+    /** DiscardHat throws a hat away. */
+    discardHat(hat: Hat, callOptions?: CallOptions): Promise<void>;
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Promise<void>;
+    /** WatchHats receives the hats as they are made. */
+    watchHats(callOptions?: CallOptions): Promise<Hat>;
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export declare const createHaberdasherClient: (config: TwirpClientConfig) => DefaultHaberdasher;
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    ping?: ((callOptions?: CallOptions) => void | Promise<void>);
+    getFeaturedHat?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+    discardHat?: ((hat: Hat, callOptions?: CallOptions) => void | Promise<void>);
+    recordFitting?: ((fitting: Fitting, callOptions?: CallOptions) => void | Promise<void>);
+    watchHats?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses?: HaberdasherMockResponses);
+
+    ping(callOptions?: CallOptions): Promise<void>;
+    getFeaturedHat(callOptions?: CallOptions): Promise<Hat>;
+    discardHat(hat: Hat, callOptions?: CallOptions): Promise<void>;
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Promise<void>;
+    watchHats(callOptions?: CallOptions): Promise<Hat>;
+}
+
+export declare const createHaberdasherMock: (overrides?: HaberdasherMockResponses) => HaberdasherMockClient;
+
+// HaberdasherHandler implements the Haberdasher rpc methods for a server created with createHaberdasherRouter.
+export interface HaberdasherHandler {
+    ping(req: ServerRequest): void | Promise<void>;
+    getFeaturedHat(req: ServerRequest): Hat | Promise<Hat>;
+    discardHat(hat: Hat, req: ServerRequest): void | Promise<void>;
+    recordFitting(fitting: Fitting, req: ServerRequest): void | Promise<void>;
+    watchHats(req: ServerRequest): Hat | Promise<Hat>;
+}
+
+// createHaberdasherRouter serves the Haberdasher rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(createHaberdasherRouter(handler))
+export declare const createHaberdasherRouter: (handler: HaberdasherHandler) => TwirpRouter;
@@ -0,0 +1,23 @@
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {DefaultHaberdasher, Fitting, Hat} from './empty';
+
+// HaberdasherService is an Angular service of the Haberdasher rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+export declare class HaberdasherService {
+    readonly client: DefaultHaberdasher;
+
+    constructor(http: HttpClient, hostname: string, prefix?: string | null);
+
+    /** Ping checks that the Haberdasher is serving. */
+    ping(callOptions?: CallOptions): Observable<void>;
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat(callOptions?: CallOptions): Observable<Hat>;
+    /** DiscardHat throws a hat away. */
+    discardHat(hat: Hat, callOptions?: CallOptions): Observable<void>;
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Observable<void>;
+    /** WatchHats receives the hats as they are made. */
+    watchHats(callOptions?: CallOptions): Observable<Hat>;
+}
@@ -0,0 +1,17 @@
+import {RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Fitting, Haberdasher, Hat} from './empty';
+
+// usePing calls Haberdasher.Ping when the component mounts.
+export declare const usePing: (client: Haberdasher, options?: RpcHookOptions) => RpcHookResult<void>;
+
+// useGetFeaturedHat calls Haberdasher.GetFeaturedHat when the component mounts.
+export declare const useGetFeaturedHat: (client: Haberdasher, options?: RpcHookOptions) => RpcHookResult<Hat>;
+
+// useDiscardHat calls Haberdasher.DiscardHat with the request when the component mounts, and again when the request changes.
+export declare const useDiscardHat: (client: Haberdasher, hat: Hat, options?: RpcHookOptions) => RpcHookResult<void>;
+
+// useRecordFitting calls Haberdasher.RecordFitting with the request when the component mounts, and again when the request changes.
+export declare const useRecordFitting: (client: Haberdasher, fitting: Fitting, options?: RpcHookOptions) => RpcHookResult<void>;
+
+// useWatchHats calls Haberdasher.WatchHats when the component mounts.
+export declare const useWatchHats: (client: Haberdasher, options?: RpcHookOptions) => RpcHookResult<Hat>;
@@ -0,0 +1,47 @@
+import {RpcQueryOptions, RpcMutationOptions} from './twirp_query';
+import {Fitting, Haberdasher, Hat} from './empty';
+
+// pingQueryKey is the query key of Haberdasher.Ping queries, e.g. to invalidate the cached response of a request.
+export declare const pingQueryKey: () => readonly ["empty.Haberdasher", "Ping"];
+
+// pingQuery are the query options of Haberdasher.Ping, e.g. useQuery(pingQuery(client))
+export declare const pingQuery: (client: Haberdasher) => RpcQueryOptions<void, ReturnType<typeof pingQueryKey>>;
+
+// pingMutation are the mutation options of Haberdasher.Ping, e.g. useMutation(pingMutation(client))
+export declare const pingMutation: (client: Haberdasher) => RpcMutationOptions<void, void>;
+
+// getFeaturedHatQueryKey is the query key of Haberdasher.GetFeaturedHat queries, e.g. to invalidate the cached response of a request.
+export declare const getFeaturedHatQueryKey: () => readonly ["empty.Haberdasher", "GetFeaturedHat"];
+
+// getFeaturedHatQuery are the query options of Haberdasher.GetFeaturedHat, e.g. useQuery(getFeaturedHatQuery(client))
+export declare const getFeaturedHatQuery: (client: Haberdasher) => RpcQueryOptions<Hat, ReturnType<typeof getFeaturedHatQueryKey>>;
+
+// getFeaturedHatMutation are the mutation options of Haberdasher.GetFeaturedHat, e.g. useMutation(getFeaturedHatMutation(client))
+export declare const getFeaturedHatMutation: (client: Haberdasher) => RpcMutationOptions<Hat, void>;
+
+// discardHatQueryKey is the query key of Haberdasher.DiscardHat queries, e.g. to invalidate the cached response of a request.
+export declare const discardHatQueryKey: (hat: Hat) => readonly ["empty.Haberdasher", "DiscardHat", Hat];
+
+// discardHatQuery are the query options of Haberdasher.DiscardHat, e.g. useQuery(discardHatQuery(client, hat))
+export declare const discardHatQuery: (client: Haberdasher, hat: Hat) => RpcQueryOptions<void, ReturnType<typeof discardHatQueryKey>>;
+
+// discardHatMutation are the mutation options of Haberdasher.DiscardHat, e.g. useMutation(discardHatMutation(client))
+export declare const discardHatMutation: (client: Haberdasher) => RpcMutationOptions<void, Hat>;
+
+// recordFittingQueryKey is the query key of Haberdasher.RecordFitting queries, e.g. to invalidate the cached response of a request.
+export declare const recordFittingQueryKey: (fitting: Fitting) => readonly ["empty.Haberdasher", "RecordFitting", Fitting];
+
+// recordFittingQuery are the query options of Haberdasher.RecordFitting, e.g. useQuery(recordFittingQuery(client, fitting))
+export declare const recordFittingQuery: (client: Haberdasher, fitting: Fitting) => RpcQueryOptions<void, ReturnType<typeof recordFittingQueryKey>>;
+
+// recordFittingMutation are the mutation options of Haberdasher.RecordFitting, e.g. useMutation(recordFittingMutation(client))
+export declare const recordFittingMutation: (client: Haberdasher) => RpcMutationOptions<void, Fitting>;
+
+// watchHatsQueryKey is the query key of Haberdasher.WatchHats queries, e.g. to invalidate the cached response of a request.
+export declare const watchHatsQueryKey: () => readonly ["empty.Haberdasher", "WatchHats"];
+
+// watchHatsQuery are the query options of Haberdasher.WatchHats, e.g. useQuery(watchHatsQuery(client))
+export declare const watchHatsQuery: (client: Haberdasher) => RpcQueryOptions<Hat, ReturnType<typeof watchHatsQueryKey>>;
+
+// watchHatsMutation are the mutation options of Haberdasher.WatchHats, e.g. useMutation(watchHatsMutation(client))
+export declare const watchHatsMutation: (client: Haberdasher) => RpcMutationOptions<Hat, void>;
@@ -0,0 +1,96 @@
+import {TwirpError, TwirpErrorCode, httpStatus} from './twirp';
+
+// ServerRequest is the subset of a Node http.IncomingMessage used by the router.
+export interface ServerRequest {
+    method?: string;
+    url?: string;
+    headers: {[key: string]: string | string[] | undefined};
+    // the request body, when already parsed by middleware such as express.json()
+    body?: any;
+    setEncoding(encoding: string): any;
+    on(event: string, listener: (chunk?: any) => void): any;
+}
+
+// ServerResponse is the subset of a Node http.ServerResponse used by the router.
+export interface ServerResponse {
+    statusCode: number;
+    setHeader(name: string, value: string): any;
+    end(body?: any): any;
+}
+
+// TwirpRoute decodes the request body of an rpc method, calls the handler, and encodes the response.
+export type TwirpRoute = (body: any, req: ServerRequest) => Promise<any>;
+
+// TwirpRouter handles the requests for a service, and calls next (if given) for requests to other paths.
+export type TwirpRouter = (req: ServerRequest, res: ServerResponse, next?: (err?: any) => void) => void;
+
+const contentType = "application/json";
+
+const writeError = (res: ServerResponse, err: any) => {
+    const te = err instanceof TwirpError ? err : new TwirpError({code: TwirpErrorCode.Internal, msg: String(err && err.message || err)});
+
+    res.statusCode = httpStatus[te.code] || 500;
+    res.setHeader("Content-Type", "application/json");
+    res.end(JSON.stringify({code: te.code, msg: te.message, meta: te.meta}));
+};
+
+const badRoute = (msg: string): TwirpError => {
+    return new TwirpError({code: TwirpErrorCode.BadRoute, msg: msg});
+};
+
+const readBody = (req: ServerRequest): Promise<any> => {
+    if (req.body !== undefined) {
+        return Promise.resolve(req.body);
+    }
+
+    return new Promise((resolve, reject) => {
+        let body = "";
+
+        req.setEncoding("utf8");
+        req.on("data", (chunk) => body += chunk);
+        req.on("error", reject);
+        req.on("end", () => {
+            try {
+                resolve(JSON.parse(body || "{}"));
+            } catch (e) {
+                reject(new TwirpError({code: TwirpErrorCode.Malformed, msg: "the json request could not be decoded"}));
+            }
+        });
+    });
+};
+
+const writeBody = (out: any): string => {
+    return JSON.stringify(out);
+};
+
+export const createTwirpRouter = (prefix: string, routes: {[method: string]: TwirpRoute}): TwirpRouter => {
+    return (req, res, next) => {
+        const path = (req.url || "").split("?")[0];
+        if (path.indexOf(prefix) !== 0 && next) {
+            return next();
+        }
+
+        const route = routes[path.substring(prefix.length)];
+        if (path.indexOf(prefix) !== 0 || !route) {
+            return writeError(res, badRoute("no handler for path " + path));
+        }
+
+        if (req.method !== "POST") {
+            return writeError(res, badRoute("unsupported method " + req.method));
+        }
+
+        const requestType = String(req.headers["content-type"] || "").split(";")[0].trim();
+        if (requestType !== contentType) {
+            return writeError(res, badRoute("unexpected Content-Type: " + requestType));
+        }
+
+        readBody(req)
+            .then((body) => route(body, req))
+            .then((out) => {
+                res.statusCode = 200;
+                res.setHeader("Content-Type", contentType);
+                res.end(writeBody(out));
+            })
+            .catch((err) => writeError(res, err));
+    };
+};
@@ -0,0 +1,43 @@
+import {Inject, Injectable, Optional} from '@angular/core';
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {TWIRP_HOSTNAME, TWIRP_PREFIX, httpClientTransport, observeCall} from './twirp_angular';
+import {DefaultHaberdasher, Fitting, Hat} from './empty';
+
+// HaberdasherService is an Angular service of the Haberdasher rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+@Injectable({providedIn: "root"})
+export class HaberdasherService {
+    // client is the Haberdasher client of the service, e.g. to add interceptors or a retry policy
+    readonly client: DefaultHaberdasher;
+
+    constructor(http: HttpClient, @Inject(TWIRP_HOSTNAME) hostname: string, @Optional() @Inject(TWIRP_PREFIX) prefix?: string | null) {
+        this.client = new DefaultHaberdasher(hostname, httpClientTransport(http), {}, prefix || undefined);
+    }
+
+    /** Ping checks that the Haberdasher is serving. */
+    ping(callOptions?: CallOptions): Observable<void> {
+        return observeCall(callOptions, (options) => this.client.ping(options));
+    }
+
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat(callOptions?: CallOptions): Observable<Hat> {
+        return observeCall(callOptions, (options) => this.client.getFeaturedHat(options));
+    }
+
+    /** DiscardHat throws a hat away. */
+    discardHat(hat: Hat, callOptions?: CallOptions): Observable<void> {
+        return observeCall(callOptions, (options) => this.client.discardHat(hat, options));
+    }
+
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Observable<void> {
+        return observeCall(callOptions, (options) => this.client.recordFitting(fitting, options));
+    }
+
+    /** WatchHats receives the hats as they are made. */
+    watchHats(callOptions?: CallOptions): Observable<Hat> {
+        return observeCall(callOptions, (options) => this.client.watchHats(options));
+    }
+}
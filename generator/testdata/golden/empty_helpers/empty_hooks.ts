@@ -0,0 +1,27 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Fitting, Haberdasher, Hat} from './empty';
+
+// usePing calls Haberdasher.Ping when the component mounts.
+export const usePing = (client: Haberdasher, options?: RpcHookOptions): RpcHookResult<void> => {
+    return useRpc((_, callOptions) => client.ping(callOptions), {}, options);
+};
+
+// useGetFeaturedHat calls Haberdasher.GetFeaturedHat when the component mounts.
+export const useGetFeaturedHat = (client: Haberdasher, options?: RpcHookOptions): RpcHookResult<Hat> => {
+    return useRpc((_, callOptions) => client.getFeaturedHat(callOptions), {}, options);
+};
+
+// useDiscardHat calls Haberdasher.DiscardHat with the request when the component mounts, and again when the request changes.
+export const useDiscardHat = (client: Haberdasher, hat: Hat, options?: RpcHookOptions): RpcHookResult<void> => {
+    return useRpc((req, callOptions) => client.discardHat(req, callOptions), hat, options);
+};
+
+// useRecordFitting calls Haberdasher.RecordFitting with the request when the component mounts, and again when the request changes.
+export const useRecordFitting = (client: Haberdasher, fitting: Fitting, options?: RpcHookOptions): RpcHookResult<void> => {
+    return useRpc((req, callOptions) => client.recordFitting(req, callOptions), fitting, options);
+};
+
+// useWatchHats calls Haberdasher.WatchHats when the component mounts.
+export const useWatchHats = (client: Haberdasher, options?: RpcHookOptions): RpcHookResult<Hat> => {
+    return useRpc((_, callOptions) => client.watchHats(callOptions), {}, options);
+};
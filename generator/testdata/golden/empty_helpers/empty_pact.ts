@@ -0,0 +1,44 @@
+import {PactInteraction, PactInteractionOptions, pactInteraction, withOverrides} from './twirp_pact';
+import {Fitting, FittingToJSON, Hat, HatToJSON} from './empty';
+
+// exampleHat is an example of the Hat message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleHat = (overrides: Partial<Hat> = {}): Hat => {
+    return withOverrides<Hat>({size: 0, color: ""}, overrides);
+};
+
+// exampleFitting is an example of the Fitting message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleFitting = (overrides: Partial<Fitting> = {}): Fitting => {
+    return withOverrides<Fitting>({hat: exampleHat(), notes: {}}, overrides);
+};
+
+// pingInteraction is a pact interaction of Haberdasher.Ping, e.g.
+// provider.addInteraction(pingInteraction({request: {}}))
+export const pingInteraction = (options: PactInteractionOptions<{}, void>): PactInteraction => {
+    return pactInteraction("/twirp/empty.Haberdasher/Ping", "a request to Haberdasher.Ping", options, () => ({}), () => ({}));
+};
+
+// getFeaturedHatInteraction is a pact interaction of Haberdasher.GetFeaturedHat, e.g.
+// provider.addInteraction(getFeaturedHatInteraction({request: {}, response: exampleHat()}))
+export const getFeaturedHatInteraction = (options: PactInteractionOptions<{}, Hat>): PactInteraction => {
+    return pactInteraction("/twirp/empty.Haberdasher/GetFeaturedHat", "a request to Haberdasher.GetFeaturedHat", options, () => ({}), HatToJSON);
+};
+
+// discardHatInteraction is a pact interaction of Haberdasher.DiscardHat, e.g.
+// provider.addInteraction(discardHatInteraction({request: exampleHat()}))
+export const discardHatInteraction = (options: PactInteractionOptions<Hat, void>): PactInteraction => {
+    return pactInteraction("/twirp/empty.Haberdasher/DiscardHat", "a request to Haberdasher.DiscardHat", options, HatToJSON, () => ({}));
+};
+
+// recordFittingInteraction is a pact interaction of Haberdasher.RecordFitting, e.g.
+// provider.addInteraction(recordFittingInteraction({request: exampleFitting()}))
+export const recordFittingInteraction = (options: PactInteractionOptions<Fitting, void>): PactInteraction => {
+    return pactInteraction("/twirp/empty.Haberdasher/RecordFitting", "a request to Haberdasher.RecordFitting", options, FittingToJSON, () => ({}));
+};
+
+// watchHatsInteraction is a pact interaction of Haberdasher.WatchHats, e.g.
+// provider.addInteraction(watchHatsInteraction({request: {}, response: exampleHat()}))
+export const watchHatsInteraction = (options: PactInteractionOptions<{}, Hat>): PactInteraction => {
+    return pactInteraction("/twirp/empty.Haberdasher/WatchHats", "a request to Haberdasher.WatchHats", options, () => ({}), HatToJSON);
+};
@@ -0,0 +1,113 @@
+import {RpcQueryOptions, RpcMutationOptions} from './twirp_query';
+import {canonicalJSON} from './twirp';
+import {Fitting, Haberdasher, Hat} from './empty';
+
+// pingQueryKey is the query key of Haberdasher.Ping queries, e.g. to invalidate the cached response of a request.
+export const pingQueryKey = (): readonly ["empty.Haberdasher", "Ping"] => {
+    return ["empty.Haberdasher", "Ping"];
+};
+
+// pingQuery are the query options of Haberdasher.Ping, e.g. useQuery(pingQuery(client))
+export const pingQuery = (client: Haberdasher): RpcQueryOptions<void, ReturnType<typeof pingQueryKey>> => {
+    return {
+        queryKey: pingQueryKey(),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.ping({signal: context.signal}),
+    };
+};
+
+// pingMutation are the mutation options of Haberdasher.Ping, e.g. useMutation(pingMutation(client))
+export const pingMutation = (client: Haberdasher): RpcMutationOptions<void, void> => {
+    return {
+        mutationKey: ["empty.Haberdasher", "Ping"],
+        mutationFn: () => client.ping(),
+    };
+};
+
+// getFeaturedHatQueryKey is the query key of Haberdasher.GetFeaturedHat queries, e.g. to invalidate the cached response of a request.
+export const getFeaturedHatQueryKey = (): readonly ["empty.Haberdasher", "GetFeaturedHat"] => {
+    return ["empty.Haberdasher", "GetFeaturedHat"];
+};
+
+// getFeaturedHatQuery are the query options of Haberdasher.GetFeaturedHat, e.g. useQuery(getFeaturedHatQuery(client))
+export const getFeaturedHatQuery = (client: Haberdasher): RpcQueryOptions<Hat, ReturnType<typeof getFeaturedHatQueryKey>> => {
+    return {
+        queryKey: getFeaturedHatQueryKey(),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.getFeaturedHat({signal: context.signal}),
+    };
+};
+
+// getFeaturedHatMutation are the mutation options of Haberdasher.GetFeaturedHat, e.g. useMutation(getFeaturedHatMutation(client))
+export const getFeaturedHatMutation = (client: Haberdasher): RpcMutationOptions<Hat, void> => {
+    return {
+        mutationKey: ["empty.Haberdasher", "GetFeaturedHat"],
+        mutationFn: () => client.getFeaturedHat(),
+    };
+};
+
+// discardHatQueryKey is the query key of Haberdasher.DiscardHat queries, e.g. to invalidate the cached response of a request.
+export const discardHatQueryKey = (hat: Hat): readonly ["empty.Haberdasher", "DiscardHat", Hat] => {
+    return ["empty.Haberdasher", "DiscardHat", hat];
+};
+
+// discardHatQuery are the query options of Haberdasher.DiscardHat, e.g. useQuery(discardHatQuery(client, hat))
+export const discardHatQuery = (client: Haberdasher, hat: Hat): RpcQueryOptions<void, ReturnType<typeof discardHatQueryKey>> => {
+    return {
+        queryKey: discardHatQueryKey(hat),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.discardHat(hat, {signal: context.signal}),
+    };
+};
+
+// discardHatMutation are the mutation options of Haberdasher.DiscardHat, e.g. useMutation(discardHatMutation(client))
+export const discardHatMutation = (client: Haberdasher): RpcMutationOptions<void, Hat> => {
+    return {
+        mutationKey: ["empty.Haberdasher", "DiscardHat"],
+        mutationFn: (hat) => client.discardHat(hat),
+    };
+};
+
+// recordFittingQueryKey is the query key of Haberdasher.RecordFitting queries, e.g. to invalidate the cached response of a request.
+export const recordFittingQueryKey = (fitting: Fitting): readonly ["empty.Haberdasher", "RecordFitting", Fitting] => {
+    return ["empty.Haberdasher", "RecordFitting", fitting];
+};
+
+// recordFittingQuery are the query options of Haberdasher.RecordFitting, e.g. useQuery(recordFittingQuery(client, fitting))
+export const recordFittingQuery = (client: Haberdasher, fitting: Fitting): RpcQueryOptions<void, ReturnType<typeof recordFittingQueryKey>> => {
+    return {
+        queryKey: recordFittingQueryKey(fitting),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.recordFitting(fitting, {signal: context.signal}),
+    };
+};
+
+// recordFittingMutation are the mutation options of Haberdasher.RecordFitting, e.g. useMutation(recordFittingMutation(client))
+export const recordFittingMutation = (client: Haberdasher): RpcMutationOptions<void, Fitting> => {
+    return {
+        mutationKey: ["empty.Haberdasher", "RecordFitting"],
+        mutationFn: (fitting) => client.recordFitting(fitting),
+    };
+};
+
+// watchHatsQueryKey is the query key of Haberdasher.WatchHats queries, e.g. to invalidate the cached response of a request.
+export const watchHatsQueryKey = (): readonly ["empty.Haberdasher", "WatchHats"] => {
+    return ["empty.Haberdasher", "WatchHats"];
+};
+
+// watchHatsQuery are the query options of Haberdasher.WatchHats, e.g. useQuery(watchHatsQuery(client))
+export const watchHatsQuery = (client: Haberdasher): RpcQueryOptions<Hat, ReturnType<typeof watchHatsQueryKey>> => {
+    return {
+        queryKey: watchHatsQueryKey(),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.watchHats({signal: context.signal}),
+    };
+};
+
+// watchHatsMutation are the mutation options of Haberdasher.WatchHats, e.g. useMutation(watchHatsMutation(client))
+export const watchHatsMutation = (client: Haberdasher): RpcMutationOptions<Hat, void> => {
+    return {
+        mutationKey: ["empty.Haberdasher", "WatchHats"],
+        mutationFn: () => client.watchHats(),
+    };
+};
@@ -0,0 +1,20 @@
+import {joinURL} from './twirp';
+import {Subscription, SubscriptionOptions, subscribe} from './twirp_subscriptions';
+import {Hat, JSONToHat} from './empty';
+
+// HaberdasherSubscriptions are the subscriptions to the subscription methods of Haberdasher, which receive the messages
+// of a connection to the route of the method.
+export interface HaberdasherSubscriptions {
+    /** WatchHats receives the hats as they are made. */
+    watchHats: () => Subscription<Hat>;
+}
+
+// createHaberdasherSubscriptions creates the subscriptions of Haberdasher, which connect to the routes of the methods
+// of the endpoint, e.g. https://example.com/subscriptions/empty.Haberdasher/WatchHats
+export const createHaberdasherSubscriptions = (endpoint: string, options: SubscriptionOptions = {}): HaberdasherSubscriptions => {
+    return {
+        watchHats: (): Subscription<Hat> => {
+            return subscribe(joinURL(endpoint, "/empty.Haberdasher/WatchHats"), {}, (data) => JSONToHat(JSON.parse(data)), options);
+        },
+    };
+};
@@ -0,0 +1,81 @@
+import {InjectionToken} from '@angular/core';
+import {HttpClient, HttpErrorResponse, HttpHeaders} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions, Transport, TransportResponse} from './twirp';
+
+// TWIRP_HOSTNAME is the hostname of the Twirp server called by the generated Angular services,
+// e.g. {provide: TWIRP_HOSTNAME, useValue: "https://api.example.com"}
+export const TWIRP_HOSTNAME = new InjectionToken<string>("TWIRP_HOSTNAME");
+
+// TWIRP_PREFIX is the path prefix of the Twirp routes, when the server is not mounted at the default prefix.
+export const TWIRP_PREFIX = new InjectionToken<string>("TWIRP_PREFIX");
+
+const bufferResponse = (status: number, buf: ArrayBuffer | null, headers: HttpHeaders): TransportResponse => {
+    const body = buf || new ArrayBuffer(0);
+
+    return {
+        ok: status >= 200 && status < 300,
+        status: status,
+        headers: headers,
+        text: () => Promise.resolve(new TextDecoder().decode(body)),
+        arrayBuffer: () => Promise.resolve(body),
+    };
+};
+
+// httpClientTransport sends requests with Angular's HttpClient, so they pass through the HttpInterceptors
+// of the application.
+export const httpClientTransport = (http: HttpClient): Transport => {
+    return (req) => new Promise<TransportResponse>((resolve, reject) => {
+        if (req.signal && req.signal.aborted) {
+            return reject(new DOMException("Aborted", "AbortError"));
+        }
+
+        const subscription = http.request(req.method || "POST", req.url, {
+            body: req.body === "" ? null : req.body,
+            headers: new HttpHeaders(req.headers),
+            observe: "response",
+            responseType: "arraybuffer",
+        }).subscribe(
+            (resp) => resolve(bufferResponse(resp.status, resp.body, resp.headers)),
+            (err) => {
+                // Twirp errors are read from the response, while a status of 0 is a network error
+                if (err instanceof HttpErrorResponse && err.status !== 0) {
+                    return resolve(bufferResponse(err.status, err.error instanceof ArrayBuffer ? err.error : null, err.headers));
+                }
+
+                reject(err);
+            },
+        );
+
+        if (req.signal) {
+            req.signal.addEventListener("abort", () => {
+                subscription.unsubscribe();
+                reject(new DOMException("Aborted", "AbortError"));
+            });
+        }
+    });
+};
+
+// observeCall makes an Observable of a call, which is made when the Observable is subscribed, and is
+// aborted when it is unsubscribed before the call completes.
+export const observeCall = <T>(callOptions: CallOptions | undefined, call: (callOptions: CallOptions) => Promise<T>): Observable<T> => {
+    return new Observable<T>((subscriber) => {
+        const controller = new AbortController();
+        const options = callOptions || {};
+
+        if (options.signal) {
+            const signal = options.signal;
+            signal.aborted ? controller.abort() : signal.addEventListener("abort", () => controller.abort());
+        }
+
+        call({headers: options.headers, timeoutMs: options.timeoutMs, signal: controller.signal}).then(
+            (resp) => {
+                subscriber.next(resp);
+                subscriber.complete();
+            },
+            (err) => subscriber.error(err),
+        );
+
+        return () => controller.abort();
+    });
+};
@@ -0,0 +1,67 @@
+import {canonicalJSON} from './twirp';
+
+export interface CacheOptions {
+    // ttl is the time in milliseconds that a response is cached
+    ttl: number;
+}
+
+interface CacheEntry {
+    expires: number;
+    response: Promise<any>;
+}
+
+// ResponseCache caches the responses of the calls of rpc methods for the ttl of its options, by method and
+// canonicalJSON of the request. The calls of the same request share a pending response, and a call that fails is not cached.
+export class ResponseCache {
+    private ttl: number;
+    private entries: {[key: string]: CacheEntry} = {};
+
+    constructor(options: CacheOptions) {
+        this.ttl = options.ttl;
+    }
+
+    // get resolves to the cached response of a request of a method, or to the response of call, which is cached
+    get<T>(method: string, request: object, call: () => Promise<T>): Promise<T> {
+        const key = method + " " + canonicalJSON(request);
+        const now = Date.now();
+        const cached = this.entries[key];
+        if (cached && cached.expires > now) {
+            return cached.response;
+        }
+
+        this.removeExpired(now);
+
+        const entry: CacheEntry = {expires: now + this.ttl, response: call()};
+        this.entries[key] = entry;
+        entry.response.catch(() => {
+            if (this.entries[key] === entry) {
+                delete this.entries[key];
+            }
+        });
+
+        return entry.response;
+    }
+
+    // invalidate removes the cached response of a request of a method, the cached responses of a method, or all of
+    // the cached responses
+    invalidate(method?: string, request?: object) {
+        if (method !== undefined && request !== undefined) {
+            delete this.entries[method + " " + canonicalJSON(request)];
+            return;
+        }
+
+        Object.keys(this.entries).forEach((key) => {
+            if (method === undefined || key.indexOf(method + " ") === 0) {
+                delete this.entries[key];
+            }
+        });
+    }
+
+    private removeExpired(now: number) {
+        Object.keys(this.entries).forEach((key) => {
+            if (this.entries[key].expires <= now) {
+                delete this.entries[key];
+            }
+        });
+    }
+}
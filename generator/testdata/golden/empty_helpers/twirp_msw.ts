@@ -0,0 +1,46 @@
+import {http, HttpHandler, HttpResponse} from 'msw';
+import {TwirpError, TwirpErrorCode, httpStatus} from './twirp';
+
+// MSWResponse is the response of an rpc method of an msw handler, which is a canned response or a handler that is
+// called with the request, like the responses of a generated mock client.
+export type MSWResponse<Req, Resp> = Resp | ((req: Req) => Resp | Promise<Resp>);
+
+const errorResponse = (err: any): Response => {
+    const te = err instanceof TwirpError ? err : new TwirpError({code: TwirpErrorCode.Internal, msg: String(err && err.message || err)});
+
+    return HttpResponse.json({code: te.code, msg: te.message, meta: te.meta}, {status: httpStatus[te.code] || 500});
+};
+
+const readBody = (request: Request): Promise<any> => {
+    return request.text().then((body) => {
+        try {
+            return JSON.parse(body || "{}");
+        } catch (e) {
+            throw new TwirpError({code: TwirpErrorCode.Malformed, msg: "the json request could not be decoded"});
+        }
+    });
+};
+
+const writeBody = (body: any): Response => {
+    return HttpResponse.json(body);
+};
+
+// twirpHandler is an msw handler of the Twirp route of an rpc method, which decodes the request, and responds with
+// the encoded response, or with the TwirpError that the response rejects with. The encoder may be called with the
+// readonly interface of the output type, see readonly_responses. A method without a response responds with an
+// unimplemented TwirpError.
+export const twirpHandler = <Req, Resp>(url: string, decode: (body: any) => Req, encode: (resp: any) => any, response: MSWResponse<Req, Resp> | undefined): HttpHandler => {
+    return http.post(url, ({request}) => {
+        if (response === undefined) {
+            return errorResponse(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no response for " + new URL(request.url).pathname}));
+        }
+
+        return readBody(request)
+            .then((body) => {
+                const req = decode(body);
+                return typeof response === "function" ? (response as (req: Req) => Resp | Promise<Resp>)(req) : response;
+            })
+            .then((resp) => writeBody(encode(resp)))
+            .catch(errorResponse);
+    });
+};
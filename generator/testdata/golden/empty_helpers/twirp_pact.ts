@@ -0,0 +1,54 @@
+import {TwirpErrorJSON, TwirpHeaders, httpStatus} from './twirp';
+
+// PactInteraction is a pact interaction of an rpc method, e.g. provider.addInteraction(makeHatInteraction(options)).
+export interface PactInteraction {
+    state?: string;
+    uponReceiving: string;
+    withRequest: {method: "POST"; path: string; headers: TwirpHeaders; body: any};
+    willRespondWith: {status: number; headers: TwirpHeaders; body: any};
+}
+
+// PactInteractionOptions are the request of a pact interaction, and its response or Twirp error.
+export interface PactInteractionOptions<Req, Resp> {
+    // state is the provider state of the interaction, e.g. "a hat exists"
+    state?: string;
+    // uponReceiving describes the interaction, which is the rpc method by default
+    uponReceiving?: string;
+    // headers are the headers of the request, in addition to its Content-Type
+    headers?: TwirpHeaders;
+    request: Req;
+    // response is the response of the interaction, unless it has an error
+    response?: Resp;
+    // error is the Twirp error of the interaction, e.g. {code: "not_found", msg: "no such hat"}
+    error?: TwirpErrorJSON;
+}
+
+// pactInteraction is the pact interaction of a Twirp route, whose request and response are the proto3 JSON of the
+// messages. The encoder of the response may be called with the readonly interface of the output type.
+export const pactInteraction = <Req, Resp>(path: string, description: string, options: PactInteractionOptions<Req, Resp>, encodeRequest: (req: Req) => any, encodeResponse: (resp: any) => any): PactInteraction => {
+    const headers: TwirpHeaders = {};
+    Object.keys(options.headers || {}).forEach((k) => headers[k] = (options.headers as TwirpHeaders)[k]);
+    headers["Content-Type"] = "application/json";
+
+    const interaction: PactInteraction = {
+        uponReceiving: options.uponReceiving || description,
+        withRequest: {method: "POST", path: path, headers: headers, body: encodeRequest(options.request)},
+        willRespondWith: options.error
+            ? {status: httpStatus[options.error.code] || 500, headers: {"Content-Type": "application/json"}, body: options.error}
+            : {status: 200, headers: {"Content-Type": "application/json"}, body: encodeResponse(options.response)},
+    };
+
+    if (options.state !== undefined) {
+        interaction.state = options.state;
+    }
+
+    return interaction;
+};
+
+// withOverrides copies the overridden fields of a message onto an example of it.
+export const withOverrides = <T>(example: T, overrides: Partial<T>): T => {
+    const m: {[key: string]: any} = example;
+    Object.keys(overrides).forEach((k) => m[k] = (overrides as any)[k]);
+
+    return m as T;
+};
@@ -0,0 +1,13 @@
+// RpcQueryOptions are the query options of an rpc method, which call the rpc method with the request of the query key.
+export interface RpcQueryOptions<T, K extends readonly unknown[]> {
+    queryKey: K;
+    // queryKeyHashFn hashes the query key with canonicalJSON, which also hashes the bigints and bytes of requests
+    queryKeyHashFn: (queryKey: K) => string;
+    queryFn: (context: {signal?: AbortSignal}) => Promise<T>;
+}
+
+// RpcMutationOptions are the mutation options of an rpc method, which call the rpc method with the mutation variables.
+export interface RpcMutationOptions<T, Req> {
+    mutationKey: readonly [string, string];
+    mutationFn: (req: Req) => Promise<T>;
+}
@@ -0,0 +1,71 @@
+import {useCallback, useEffect, useRef, useState} from 'react';
+import {CallOptions, TwirpHeaders, canonicalJSON} from './twirp';
+
+// RpcHookOptions are the options of a generated hook, which are passed to every call of the rpc method.
+export interface RpcHookOptions {
+    headers?: TwirpHeaders;
+    timeoutMs?: number;
+    // the rpc method is not called while enabled is false, e.g. until the request is ready
+    enabled?: boolean;
+}
+
+// RpcHookResult is the state of the latest call of the rpc method of a generated hook.
+export interface RpcHookResult<T> {
+    data: T | undefined;
+    error: Error | undefined;
+    loading: boolean;
+    // refetch calls the rpc method again with the same request
+    refetch: () => void;
+}
+
+interface RpcState<T> {
+    data?: T;
+    error?: Error;
+    loading: boolean;
+}
+
+// useRpc calls an rpc method with the request when the component mounts, and again when the request changes.
+// The call is aborted when the component unmounts, or when it is superseded by a call with a new request.
+export const useRpc = <Req, Resp>(call: (req: Req, options: CallOptions) => Promise<Resp>, req: Req, options: RpcHookOptions = {}): RpcHookResult<Resp> => {
+    const enabled = options.enabled !== false;
+    const [state, setState] = useState<RpcState<Resp>>({loading: enabled});
+    const [attempt, setAttempt] = useState(0);
+
+    // the latest arguments are used by the effect, which only runs again when the request key changes
+    const latest = useRef({call: call, req: req, options: options});
+    latest.current = {call: call, req: req, options: options};
+
+    // the request is identified by its content, so a hook only calls the rpc method again when the request changes,
+    // rather than whenever a component renders a new request object
+    const key = canonicalJSON(req);
+
+    useEffect(() => {
+        if (!enabled) {
+            return;
+        }
+
+        const controller = new AbortController();
+        const current = latest.current;
+
+        setState((s) => ({data: s.data, loading: true}));
+
+        current.call(current.req, {headers: current.options.headers, timeoutMs: current.options.timeoutMs, signal: controller.signal}).then(
+            (data) => {
+                if (!controller.signal.aborted) {
+                    setState({data: data, loading: false});
+                }
+            },
+            (error) => {
+                if (!controller.signal.aborted) {
+                    setState({error: error, loading: false});
+                }
+            },
+        );
+
+        return () => controller.abort();
+    }, [key, enabled, attempt]);
+
+    const refetch = useCallback(() => setAttempt((n) => n + 1), []);
+
+    return {data: state.data, error: state.error, loading: state.loading, refetch: refetch};
+};
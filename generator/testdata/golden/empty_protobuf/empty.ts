@@ -0,0 +1,450 @@
+import {createTwirpProtobufRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, ProtobufReader, ProtobufWriter, structToProtobuf, protobufToStruct} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, retryNetworkFailures, TwirpClientConfig, clientConfig} from './interceptors';
+import {createTwirpRouter, ServerRequest, TwirpRouter} from './twirp_server';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToProtobuf = (m: Hat): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.size) { w.tag(1, 0).int32(m.size); }
+    if (m.color) { w.tag(2, 2).string(m.color); }
+
+    return w.finish();
+};
+
+export const ProtobufToHat = (b: Uint8Array): Hat => {
+    const r = new ProtobufReader(b);
+    const m = {size: 0, color: ""} as Hat;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.size = r.int32(); break;
+            case 2: m.color = r.string(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** A Fitting is a fitting of a hat, whose notes are not recorded yet. */
+export interface Fitting {
+    hat: Hat;
+    notes: {[key: string]: any};
+}
+
+// FittingFields are the numbers and proto types of the fields of Fitting by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const FittingFields = {
+    hat: {number: 1, name: "hat", type: "message"},
+    notes: {number: 2, name: "notes", type: "message"},
+} as const;
+
+export interface FittingJSON {
+    hat: HatJSON;
+    notes: {[key: string]: any};
+}
+
+export const FittingToProtobuf = (m: Fitting): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.hat) { w.tag(1, 2).bytes(HatToProtobuf(m.hat)); }
+    if (m.notes !== undefined) { w.tag(2, 2).bytes(structToProtobuf(m.notes)); }
+
+    return w.finish();
+};
+
+export const ProtobufToFitting = (b: Uint8Array): Fitting => {
+    const r = new ProtobufReader(b);
+    const m = {} as Fitting;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.hat = ProtobufToHat(r.bytes()); break;
+            case 2: m.notes = protobufToStruct(r.bytes()); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isFitting reports if a value has the fields of a Fitting, e.g. to check data read from a cache or a websocket.
+export const isFitting = (value: unknown): value is Fitting => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isHat(m.hat)
+        && typeof m.notes === "object" && m.notes !== null;
+};
+
+/** Haberdasher makes hats, and has methods without a request or a response. */
+export interface Haberdasher {
+    /** Ping checks that the Haberdasher is serving. */
+    ping: (callOptions?: CallOptions) => Promise<void>;
+
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat: (callOptions?: CallOptions) => Promise<Hat>;
+
+    /** DiscardHat throws a hat away. */
+    discardHat: (hat: Hat, callOptions?: CallOptions) => Promise<void>;
+
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting: (fitting: Fitting, callOptions?: CallOptions) => Promise<void>;
+
+    /** WatchHats receives the hats as they are made. */
+    watchHats: (callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    ping: {
+        service: "empty.Haberdasher",
+        method: "Ping",
+        path: "/twirp/empty.Haberdasher/Ping",
+        inputType: "google.protobuf.Empty",
+        outputType: "google.protobuf.Empty",
+    },
+    getFeaturedHat: {
+        service: "empty.Haberdasher",
+        method: "GetFeaturedHat",
+        path: "/twirp/empty.Haberdasher/GetFeaturedHat",
+        inputType: "google.protobuf.Empty",
+        outputType: "Hat",
+    },
+    discardHat: {
+        service: "empty.Haberdasher",
+        method: "DiscardHat",
+        path: "/twirp/empty.Haberdasher/DiscardHat",
+        inputType: "Hat",
+        outputType: "google.protobuf.Empty",
+    },
+    recordFitting: {
+        service: "empty.Haberdasher",
+        method: "RecordFitting",
+        path: "/twirp/empty.Haberdasher/RecordFitting",
+        inputType: "Fitting",
+        outputType: "google.protobuf.Empty",
+    },
+    watchHats: {
+        service: "empty.Haberdasher",
+        method: "WatchHats",
+        path: "/twirp/empty.Haberdasher/WatchHats",
+        inputType: "google.protobuf.Empty",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Haberdasher makes hats, and has methods without a request or a response. */
+export class DefaultHaberdasher implements Haberdasher {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/empty.Haberdasher/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** Ping checks that the Haberdasher is serving. */
+    ping(callOptions?: CallOptions): Promise<void> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Ping");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "empty.Haberdasher",
+                method: "Ping",
+                url: url,
+                request: {},
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, new Uint8Array(0), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then(() => undefined);
+                });
+            });
+        }));
+    }
+
+    /** GetFeaturedHat returns the hat of the day. */
+    getFeaturedHat(callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "GetFeaturedHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "empty.Haberdasher",
+                method: "GetFeaturedHat",
+                url: url,
+                request: {},
+                headers: options.headers || {},
+                signal: options.signal,
+                idempotency: "no_side_effects",
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return retryNetworkFailures(ctx, this.transport)(createTwirpProtobufRequest(ctx.url, new Uint8Array(0), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToHat(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+
+    /** DiscardHat throws a hat away. */
+    discardHat(hat: Hat, callOptions?: CallOptions): Promise<void> {
+        const url = joinURL(this.hostname, this.pathPrefix + "DiscardHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "empty.Haberdasher",
+                method: "DiscardHat",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, HatToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then(() => undefined);
+                });
+            });
+        }));
+    }
+
+    /** RecordFitting records the fitting of a hat. */
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Promise<void> {
+        const url = joinURL(this.hostname, this.pathPrefix + "RecordFitting");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "empty.Haberdasher",
+                method: "RecordFitting",
+                url: url,
+                request: fitting,
+                headers: options.headers || {},
+                signal: options.signal,
+                idempotency: "idempotent",
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return retryNetworkFailures(ctx, this.transport)(createTwirpProtobufRequest(ctx.url, FittingToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then(() => undefined);
+                });
+            });
+        }));
+    }
+
+    /** WatchHats receives the hats as they are made. */
+    watchHats(callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "WatchHats");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "empty.Haberdasher",
+                method: "WatchHats",
+                url: url,
+                request: {},
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, new Uint8Array(0), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToHat(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export const createHaberdasherClient = (config: TwirpClientConfig): DefaultHaberdasher => {
+    return new DefaultHaberdasher(config);
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    ping?: ((callOptions?: CallOptions) => void | Promise<void>);
+    getFeaturedHat?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+    discardHat?: ((hat: Hat, callOptions?: CallOptions) => void | Promise<void>);
+    recordFitting?: ((fitting: Fitting, callOptions?: CallOptions) => void | Promise<void>);
+    watchHats?: Hat | ((callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    ping(callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.ping;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.Ping"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+
+    getFeaturedHat(callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.getFeaturedHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.GetFeaturedHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+
+    discardHat(hat: Hat, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.discardHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.DiscardHat"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+
+    recordFitting(fitting: Fitting, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.recordFitting;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.RecordFitting"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(fitting, callOptions) : response));
+    }
+
+    watchHats(callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.watchHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.WatchHats"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
+
+// HaberdasherHandler implements the Haberdasher rpc methods for a server created with createHaberdasherRouter.
+export interface HaberdasherHandler {
+    ping(req: ServerRequest): void | Promise<void>;
+    getFeaturedHat(req: ServerRequest): Hat | Promise<Hat>;
+    discardHat(hat: Hat, req: ServerRequest): void | Promise<void>;
+    recordFitting(fitting: Fitting, req: ServerRequest): void | Promise<void>;
+    watchHats(req: ServerRequest): Hat | Promise<Hat>;
+}
+
+// createHaberdasherRouter serves the Haberdasher rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(createHaberdasherRouter(handler))
+export const createHaberdasherRouter = (handler: HaberdasherHandler): TwirpRouter => {
+    return createTwirpRouter("/twirp/empty.Haberdasher/", {
+        Ping: (_, req) => new Promise<void>((resolve) => resolve(handler.ping(req))).then(() => new Uint8Array(0)),
+        GetFeaturedHat: (_, req) => new Promise<Hat>((resolve) => resolve(handler.getFeaturedHat(req))).then(HatToProtobuf),
+        DiscardHat: (body, req) => new Promise<void>((resolve) => resolve(handler.discardHat(ProtobufToHat(body), req))).then(() => new Uint8Array(0)),
+        RecordFitting: (body, req) => new Promise<void>((resolve) => resolve(handler.recordFitting(ProtobufToFitting(body), req))).then(() => new Uint8Array(0)),
+        WatchHats: (_, req) => new Promise<Hat>((resolve) => resolve(handler.watchHats(req))).then(HatToProtobuf),
+    });
+};
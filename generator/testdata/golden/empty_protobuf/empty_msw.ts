@@ -0,0 +1,17 @@
+import {HttpHandler} from 'msw';
+import {joinURL} from './twirp';
+import {twirpHandler} from './twirp_msw';
+import {HaberdasherMethods, HaberdasherMockResponses, HatToProtobuf, ProtobufToFitting, ProtobufToHat} from './empty';
+
+// createHaberdasherHandlers creates the msw handlers of the Twirp routes of Haberdasher, which respond with the responses
+// of the methods like the HaberdasherMockClient, e.g. setupServer(...createHaberdasherHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export const createHaberdasherHandlers = (responses: HaberdasherMockResponses, hostname: string = "*"): HttpHandler[] => {
+    return [
+        twirpHandler(joinURL(hostname, HaberdasherMethods.ping.path), () => undefined, () => new Uint8Array(0), responses.ping),
+        twirpHandler(joinURL(hostname, HaberdasherMethods.getFeaturedHat.path), () => undefined, HatToProtobuf, responses.getFeaturedHat),
+        twirpHandler(joinURL(hostname, HaberdasherMethods.discardHat.path), ProtobufToHat, () => new Uint8Array(0), responses.discardHat),
+        twirpHandler(joinURL(hostname, HaberdasherMethods.recordFitting.path), ProtobufToFitting, () => new Uint8Array(0), responses.recordFitting),
+        twirpHandler(joinURL(hostname, HaberdasherMethods.watchHats.path), () => undefined, HatToProtobuf, responses.watchHats),
+    ];
+};
@@ -0,0 +1,994 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, bytesToBase64, base64ToBytes, mapEntries, enumFromJSON, floatToJSON, floatFromJSON, jsonAliases, everyItem, everyValue, oneofMember} from './twirp';
+import {cloneValue, valuesEqual} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** Shape is the kind of a Drawing. */
+export enum Shape {
+    SHAPE_UNSPECIFIED = 0,
+    SHAPE_CIRCLE = 1,
+    SHAPE_SQUARE = 2,
+}
+
+export enum DrawingLayerBlend {
+    BLEND_NORMAL = 0,
+    BLEND_MULTIPLY = 1,
+}
+
+/** The content of a Drawing is either text or an image. */
+export type DrawingContent =
+    | {kind: "text"; value: string}
+    | {kind: "image"; value: Image};
+
+/** A Drawing uses every kind of field that is supported by the generator. */
+export class Drawing {
+    title: string;
+    id: number;
+    revisions: number[];
+    thumbnail: Uint8Array;
+    tiles: Uint8Array[];
+    published: boolean;
+    scale: number;
+    shape: Shape;
+    shapes: Shape[];
+    layer: DrawingLayer;
+    layers: DrawingLayer[];
+    namedLayers: {[key: string]: DrawingLayer};
+    labels: {[key: number]: string};
+    flags: {[key: string]: Shape};
+    opacity?: number | undefined;
+    caption?: string | undefined;
+    scalars: Scalars;
+    /** The content of a Drawing is either text or an image. */
+    content?: DrawingContent;
+
+    constructor(init: Partial<Drawing> = {}) {
+        this.title = init.title !== undefined ? init.title : "";
+        this.id = init.id !== undefined ? init.id : 0;
+        this.revisions = init.revisions !== undefined ? init.revisions : [];
+        this.thumbnail = init.thumbnail !== undefined ? init.thumbnail : new Uint8Array(0);
+        this.tiles = init.tiles !== undefined ? init.tiles : [];
+        this.published = init.published !== undefined ? init.published : false;
+        this.scale = init.scale !== undefined ? init.scale : 0;
+        this.shape = init.shape !== undefined ? init.shape : 0;
+        this.shapes = init.shapes !== undefined ? init.shapes : [];
+        this.layer = init.layer as DrawingLayer;
+        this.layers = init.layers !== undefined ? init.layers : [];
+        this.namedLayers = init.namedLayers !== undefined ? init.namedLayers : {};
+        this.labels = init.labels !== undefined ? init.labels : {};
+        this.flags = init.flags !== undefined ? init.flags : {};
+        this.opacity = init.opacity;
+        this.caption = init.caption;
+        this.scalars = init.scalars as Scalars;
+        this.content = init.content;
+    }
+
+    // clone returns a deep copy of the Drawing.
+    clone(): Drawing {
+        return new Drawing({
+            title: cloneValue(this.title),
+            id: cloneValue(this.id),
+            revisions: cloneValue(this.revisions),
+            thumbnail: cloneValue(this.thumbnail),
+            tiles: cloneValue(this.tiles),
+            published: cloneValue(this.published),
+            scale: cloneValue(this.scale),
+            shape: cloneValue(this.shape),
+            shapes: cloneValue(this.shapes),
+            layer: cloneValue(this.layer),
+            layers: cloneValue(this.layers),
+            namedLayers: cloneValue(this.namedLayers),
+            labels: cloneValue(this.labels),
+            flags: cloneValue(this.flags),
+            opacity: cloneValue(this.opacity),
+            caption: cloneValue(this.caption),
+            scalars: cloneValue(this.scalars),
+            content: cloneValue(this.content),
+        });
+    }
+
+    // equals reports if the fields of the Drawing are deeply equal to those of other.
+    equals(other: Drawing): boolean {
+        return valuesEqual(this.title, other.title)
+            && valuesEqual(this.id, other.id)
+            && valuesEqual(this.revisions, other.revisions)
+            && valuesEqual(this.thumbnail, other.thumbnail)
+            && valuesEqual(this.tiles, other.tiles)
+            && valuesEqual(this.published, other.published)
+            && valuesEqual(this.scale, other.scale)
+            && valuesEqual(this.shape, other.shape)
+            && valuesEqual(this.shapes, other.shapes)
+            && valuesEqual(this.layer, other.layer)
+            && valuesEqual(this.layers, other.layers)
+            && valuesEqual(this.namedLayers, other.namedLayers)
+            && valuesEqual(this.labels, other.labels)
+            && valuesEqual(this.flags, other.flags)
+            && valuesEqual(this.opacity, other.opacity)
+            && valuesEqual(this.caption, other.caption)
+            && valuesEqual(this.scalars, other.scalars)
+            && valuesEqual(this.content, other.content);
+    }
+
+    static fromJSON(m: DrawingJSON): Drawing {
+        return JSONToDrawing(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Drawing is stringified as its proto3 JSON.
+    toJSON(): DrawingJSON {
+        return DrawingToJSON(this);
+    }
+}
+
+export type ReadonlyDrawingContent =
+    | {readonly kind: "text"; readonly value: string}
+    | {readonly kind: "image"; readonly value: ReadonlyImage};
+
+// ReadonlyDrawing is the interface of a Drawing returned by the clients, whose fields cannot be changed.
+export interface ReadonlyDrawing {
+    readonly title: string;
+    readonly id: number;
+    readonly revisions: ReadonlyArray<number>;
+    readonly thumbnail: Uint8Array;
+    readonly tiles: ReadonlyArray<Uint8Array>;
+    readonly published: boolean;
+    readonly scale: number;
+    readonly shape: Shape;
+    readonly shapes: ReadonlyArray<Shape>;
+    readonly layer: ReadonlyDrawingLayer;
+    readonly layers: ReadonlyArray<ReadonlyDrawingLayer>;
+    readonly namedLayers: {readonly [key: string]: ReadonlyDrawingLayer};
+    readonly labels: {readonly [key: number]: string};
+    readonly flags: {readonly [key: boolean]: Shape};
+    readonly opacity?: number | undefined;
+    readonly caption?: string | undefined;
+    readonly scalars: ReadonlyScalars;
+    /** The content of a Drawing is either text or an image. */
+    readonly content?: ReadonlyDrawingContent;
+}
+
+// DrawingFields are the numbers and proto types of the fields of Drawing by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingFields = {
+    title: {number: 1, name: "title", type: "string"},
+    id: {number: 2, name: "id", type: "int64"},
+    revisions: {number: 3, name: "revisions", type: "uint64"},
+    thumbnail: {number: 4, name: "thumbnail", type: "bytes"},
+    tiles: {number: 5, name: "tiles", type: "bytes"},
+    published: {number: 6, name: "published", type: "bool"},
+    scale: {number: 7, name: "scale", type: "double"},
+    shape: {number: 8, name: "shape", type: "enum"},
+    shapes: {number: 9, name: "shapes", type: "enum"},
+    layer: {number: 10, name: "layer", type: "message"},
+    layers: {number: 11, name: "layers", type: "message"},
+    namedLayers: {number: 12, name: "named_layers", type: "map"},
+    labels: {number: 13, name: "labels", type: "map"},
+    flags: {number: 14, name: "flags", type: "map"},
+    opacity: {number: 15, name: "opacity", type: "int32"},
+    caption: {number: 16, name: "caption", type: "string"},
+    scalars: {number: 19, name: "scalars", type: "message"},
+    text: {number: 17, name: "text", type: "string"},
+    image: {number: 18, name: "image", type: "message"},
+} as const;
+
+export interface DrawingJSON {
+    title: string;
+    id: string;
+    revisions: string[];
+    thumbnail: string;
+    tiles: string[];
+    published: boolean;
+    scale: number | string;
+    shape: string | number;
+    shapes: (string | number)[];
+    layer: DrawingLayerJSON;
+    layers: DrawingLayerJSON[];
+    named_layers: {[key: string]: DrawingLayerJSON};
+    labels: {[key: string]: string};
+    flags: {[key: string]: string | number};
+    opacity?: number;
+    caption?: string;
+    scalars: ScalarsJSON;
+    text?: string;
+    image?: ImageJSON;
+}
+
+export const DrawingToJSON = (m: Drawing): DrawingJSON => {
+    return {
+        title: m.title,
+        id: String(m.id),
+        revisions: m.revisions.map((n) => String(n)),
+        thumbnail: bytesToBase64(m.thumbnail),
+        tiles: m.tiles.map(bytesToBase64),
+        published: m.published,
+        scale: floatToJSON(m.scale),
+        shape: Shape[m.shape],
+        shapes: m.shapes.map((n) => Shape[n]),
+        layer: DrawingLayerToJSON(m.layer),
+        layers: m.layers.map(DrawingLayerToJSON),
+        named_layers: mapEntries(m.namedLayers, String, (v) => DrawingLayerToJSON(v)),
+        labels: mapEntries(m.labels, String, (v) => v),
+        flags: mapEntries(m.flags, String, (v) => Shape[v]),
+        opacity: m.opacity,
+        caption: m.caption,
+        scalars: ScalarsToJSON(m.scalars),
+        text: m.content && m.content.kind === "text" ? m.content.value : undefined,
+        image: m.content && m.content.kind === "image" ? ImageToJSON(m.content.value) : undefined,
+    };
+};
+
+export const JSONToDrawing = (json: DrawingJSON): Drawing => {
+    const m = jsonAliases(json, {"namedLayers": "named_layers"});
+
+    return new Drawing({
+        title: m.title,
+        id: Number(m.id || "0"),
+        revisions: m.revisions.map((n) => Number(n)),
+        thumbnail: base64ToBytes(m.thumbnail || ""),
+        tiles: m.tiles.map(base64ToBytes),
+        published: m.published,
+        scale: floatFromJSON(m.scale),
+        shape: enumFromJSON<Shape>(Shape, m.shape),
+        shapes: m.shapes.map((n) => enumFromJSON<Shape>(Shape, n)),
+        layer: JSONToDrawingLayer(m.layer),
+        layers: m.layers.map(JSONToDrawingLayer),
+        namedLayers: mapEntries(m.named_layers || {}, String, (v) => JSONToDrawingLayer(v)),
+        labels: mapEntries(m.labels || {}, Number, (v) => v),
+        flags: mapEntries(m.flags || {}, String, (v) => enumFromJSON<Shape>(Shape, v)),
+        opacity: m.opacity,
+        caption: m.caption,
+        scalars: JSONToScalars(m.scalars),
+        content: m.text !== undefined ? {kind: "text", value: m.text} : m.image !== undefined ? {kind: "image", value: JSONToImage(m.image)} : undefined,
+    });
+};
+
+// isDrawing reports if a value has the fields of a Drawing, e.g. to check data read from a cache or a websocket.
+export const isDrawing = (value: unknown): value is Drawing => {
+    if (!(value instanceof Drawing)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.title === "string"
+        && typeof m.id === "number"
+        && everyItem(m.revisions, (v) => typeof v === "number")
+        && m.thumbnail instanceof Uint8Array
+        && everyItem(m.tiles, (v) => v instanceof Uint8Array)
+        && typeof m.published === "boolean"
+        && typeof m.scale === "number"
+        && typeof m.shape === "number"
+        && everyItem(m.shapes, (v) => typeof v === "number")
+        && isDrawingLayer(m.layer)
+        && everyItem(m.layers, isDrawingLayer)
+        && everyValue(m.namedLayers, isDrawingLayer)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyValue(m.flags, (v) => typeof v === "number")
+        && (m.opacity === undefined || typeof m.opacity === "number")
+        && (m.caption === undefined || typeof m.caption === "string")
+        && isScalars(m.scalars)
+        && (m.content === undefined || oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage}));
+};
+
+/** Layer is the position of a Drawing in a Canvas. */
+export class DrawingLayer {
+    index: number;
+    blend: DrawingLayerBlend;
+
+    constructor(init: Partial<DrawingLayer> = {}) {
+        this.index = init.index !== undefined ? init.index : 0;
+        this.blend = init.blend !== undefined ? init.blend : 0;
+    }
+
+    // clone returns a deep copy of the DrawingLayer.
+    clone(): DrawingLayer {
+        return new DrawingLayer({
+            index: cloneValue(this.index),
+            blend: cloneValue(this.blend),
+        });
+    }
+
+    // equals reports if the fields of the DrawingLayer are deeply equal to those of other.
+    equals(other: DrawingLayer): boolean {
+        return valuesEqual(this.index, other.index)
+            && valuesEqual(this.blend, other.blend);
+    }
+
+    static fromJSON(m: DrawingLayerJSON): DrawingLayer {
+        return JSONToDrawingLayer(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a DrawingLayer is stringified as its proto3 JSON.
+    toJSON(): DrawingLayerJSON {
+        return DrawingLayerToJSON(this);
+    }
+}
+
+// ReadonlyDrawingLayer is the interface of a DrawingLayer returned by the clients, whose fields cannot be changed.
+export interface ReadonlyDrawingLayer {
+    readonly index: number;
+    readonly blend: DrawingLayerBlend;
+}
+
+// DrawingLayerFields are the numbers and proto types of the fields of DrawingLayer by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingLayerFields = {
+    index: {number: 1, name: "index", type: "int32"},
+    blend: {number: 2, name: "blend", type: "enum"},
+} as const;
+
+export interface DrawingLayerJSON {
+    index: number;
+    blend: string | number;
+}
+
+export const DrawingLayerToJSON = (m: DrawingLayer): DrawingLayerJSON => {
+    return {
+        index: m.index,
+        blend: DrawingLayerBlend[m.blend],
+    };
+};
+
+export const JSONToDrawingLayer = (m: DrawingLayerJSON): DrawingLayer => {
+    return new DrawingLayer({
+        index: m.index,
+        blend: enumFromJSON<DrawingLayerBlend>(DrawingLayerBlend, m.blend),
+    });
+};
+
+// isDrawingLayer reports if a value has the fields of a DrawingLayer, e.g. to check data read from a cache or a websocket.
+export const isDrawingLayer = (value: unknown): value is DrawingLayer => {
+    if (!(value instanceof DrawingLayer)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.index === "number"
+        && typeof m.blend === "number";
+};
+
+/** Scalars has a field of each scalar type. */
+export class Scalars {
+    doubleValue: number;
+    floatValue: number;
+    int32Value: number;
+    int64Value: number;
+    uint32Value: number;
+    uint64Value: number;
+    sint32Value: number;
+    sint64Value: number;
+    fixed32Value: number;
+    fixed64Value: number;
+    sfixed32Value: number;
+    sfixed64Value: number;
+    boolValue: boolean;
+    stringValue: string;
+    bytesValue: Uint8Array;
+    floatValues: number[];
+    sint32Values: number[];
+
+    constructor(init: Partial<Scalars> = {}) {
+        this.doubleValue = init.doubleValue !== undefined ? init.doubleValue : 0;
+        this.floatValue = init.floatValue !== undefined ? init.floatValue : 0;
+        this.int32Value = init.int32Value !== undefined ? init.int32Value : 0;
+        this.int64Value = init.int64Value !== undefined ? init.int64Value : 0;
+        this.uint32Value = init.uint32Value !== undefined ? init.uint32Value : 0;
+        this.uint64Value = init.uint64Value !== undefined ? init.uint64Value : 0;
+        this.sint32Value = init.sint32Value !== undefined ? init.sint32Value : 0;
+        this.sint64Value = init.sint64Value !== undefined ? init.sint64Value : 0;
+        this.fixed32Value = init.fixed32Value !== undefined ? init.fixed32Value : 0;
+        this.fixed64Value = init.fixed64Value !== undefined ? init.fixed64Value : 0;
+        this.sfixed32Value = init.sfixed32Value !== undefined ? init.sfixed32Value : 0;
+        this.sfixed64Value = init.sfixed64Value !== undefined ? init.sfixed64Value : 0;
+        this.boolValue = init.boolValue !== undefined ? init.boolValue : false;
+        this.stringValue = init.stringValue !== undefined ? init.stringValue : "";
+        this.bytesValue = init.bytesValue !== undefined ? init.bytesValue : new Uint8Array(0);
+        this.floatValues = init.floatValues !== undefined ? init.floatValues : [];
+        this.sint32Values = init.sint32Values !== undefined ? init.sint32Values : [];
+    }
+
+    // clone returns a deep copy of the Scalars.
+    clone(): Scalars {
+        return new Scalars({
+            doubleValue: cloneValue(this.doubleValue),
+            floatValue: cloneValue(this.floatValue),
+            int32Value: cloneValue(this.int32Value),
+            int64Value: cloneValue(this.int64Value),
+            uint32Value: cloneValue(this.uint32Value),
+            uint64Value: cloneValue(this.uint64Value),
+            sint32Value: cloneValue(this.sint32Value),
+            sint64Value: cloneValue(this.sint64Value),
+            fixed32Value: cloneValue(this.fixed32Value),
+            fixed64Value: cloneValue(this.fixed64Value),
+            sfixed32Value: cloneValue(this.sfixed32Value),
+            sfixed64Value: cloneValue(this.sfixed64Value),
+            boolValue: cloneValue(this.boolValue),
+            stringValue: cloneValue(this.stringValue),
+            bytesValue: cloneValue(this.bytesValue),
+            floatValues: cloneValue(this.floatValues),
+            sint32Values: cloneValue(this.sint32Values),
+        });
+    }
+
+    // equals reports if the fields of the Scalars are deeply equal to those of other.
+    equals(other: Scalars): boolean {
+        return valuesEqual(this.doubleValue, other.doubleValue)
+            && valuesEqual(this.floatValue, other.floatValue)
+            && valuesEqual(this.int32Value, other.int32Value)
+            && valuesEqual(this.int64Value, other.int64Value)
+            && valuesEqual(this.uint32Value, other.uint32Value)
+            && valuesEqual(this.uint64Value, other.uint64Value)
+            && valuesEqual(this.sint32Value, other.sint32Value)
+            && valuesEqual(this.sint64Value, other.sint64Value)
+            && valuesEqual(this.fixed32Value, other.fixed32Value)
+            && valuesEqual(this.fixed64Value, other.fixed64Value)
+            && valuesEqual(this.sfixed32Value, other.sfixed32Value)
+            && valuesEqual(this.sfixed64Value, other.sfixed64Value)
+            && valuesEqual(this.boolValue, other.boolValue)
+            && valuesEqual(this.stringValue, other.stringValue)
+            && valuesEqual(this.bytesValue, other.bytesValue)
+            && valuesEqual(this.floatValues, other.floatValues)
+            && valuesEqual(this.sint32Values, other.sint32Values);
+    }
+
+    static fromJSON(m: ScalarsJSON): Scalars {
+        return JSONToScalars(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Scalars is stringified as its proto3 JSON.
+    toJSON(): ScalarsJSON {
+        return ScalarsToJSON(this);
+    }
+}
+
+// ReadonlyScalars is the interface of a Scalars returned by the clients, whose fields cannot be changed.
+export interface ReadonlyScalars {
+    readonly doubleValue: number;
+    readonly floatValue: number;
+    readonly int32Value: number;
+    readonly int64Value: number;
+    readonly uint32Value: number;
+    readonly uint64Value: number;
+    readonly sint32Value: number;
+    readonly sint64Value: number;
+    readonly fixed32Value: number;
+    readonly fixed64Value: number;
+    readonly sfixed32Value: number;
+    readonly sfixed64Value: number;
+    readonly boolValue: boolean;
+    readonly stringValue: string;
+    readonly bytesValue: Uint8Array;
+    readonly floatValues: ReadonlyArray<number>;
+    readonly sint32Values: ReadonlyArray<number>;
+}
+
+// ScalarsFields are the numbers and proto types of the fields of Scalars by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ScalarsFields = {
+    doubleValue: {number: 1, name: "double_value", type: "double"},
+    floatValue: {number: 2, name: "float_value", type: "float"},
+    int32Value: {number: 3, name: "int32_value", type: "int32"},
+    int64Value: {number: 4, name: "int64_value", type: "int64"},
+    uint32Value: {number: 5, name: "uint32_value", type: "uint32"},
+    uint64Value: {number: 6, name: "uint64_value", type: "uint64"},
+    sint32Value: {number: 7, name: "sint32_value", type: "sint32"},
+    sint64Value: {number: 8, name: "sint64_value", type: "sint64"},
+    fixed32Value: {number: 9, name: "fixed32_value", type: "fixed32"},
+    fixed64Value: {number: 10, name: "fixed64_value", type: "fixed64"},
+    sfixed32Value: {number: 11, name: "sfixed32_value", type: "sfixed32"},
+    sfixed64Value: {number: 12, name: "sfixed64_value", type: "sfixed64"},
+    boolValue: {number: 13, name: "bool_value", type: "bool"},
+    stringValue: {number: 14, name: "string_value", type: "string"},
+    bytesValue: {number: 15, name: "bytes_value", type: "bytes"},
+    floatValues: {number: 16, name: "float_values", type: "float"},
+    sint32Values: {number: 17, name: "sint32_values", type: "sint32"},
+} as const;
+
+export interface ScalarsJSON {
+    double_value: number | string;
+    float_value: number | string;
+    int32_value: number;
+    int64_value: string;
+    uint32_value: number;
+    uint64_value: string;
+    sint32_value: number;
+    sint64_value: string;
+    fixed32_value: number;
+    fixed64_value: string;
+    sfixed32_value: number;
+    sfixed64_value: string;
+    bool_value: boolean;
+    string_value: string;
+    bytes_value: string;
+    float_values: (number | string)[];
+    sint32_values: number[];
+}
+
+export const ScalarsToJSON = (m: Scalars): ScalarsJSON => {
+    return {
+        double_value: floatToJSON(m.doubleValue),
+        float_value: floatToJSON(m.floatValue),
+        int32_value: m.int32Value,
+        int64_value: String(m.int64Value),
+        uint32_value: m.uint32Value,
+        uint64_value: String(m.uint64Value),
+        sint32_value: m.sint32Value,
+        sint64_value: String(m.sint64Value),
+        fixed32_value: m.fixed32Value,
+        fixed64_value: String(m.fixed64Value),
+        sfixed32_value: m.sfixed32Value,
+        sfixed64_value: String(m.sfixed64Value),
+        bool_value: m.boolValue,
+        string_value: m.stringValue,
+        bytes_value: bytesToBase64(m.bytesValue),
+        float_values: m.floatValues.map(floatToJSON),
+        sint32_values: m.sint32Values,
+    };
+};
+
+export const JSONToScalars = (json: ScalarsJSON): Scalars => {
+    const m = jsonAliases(json, {"doubleValue": "double_value", "floatValue": "float_value", "int32Value": "int32_value", "int64Value": "int64_value", "uint32Value": "uint32_value", "uint64Value": "uint64_value", "sint32Value": "sint32_value", "sint64Value": "sint64_value", "fixed32Value": "fixed32_value", "fixed64Value": "fixed64_value", "sfixed32Value": "sfixed32_value", "sfixed64Value": "sfixed64_value", "boolValue": "bool_value", "stringValue": "string_value", "bytesValue": "bytes_value", "floatValues": "float_values", "sint32Values": "sint32_values"});
+
+    return new Scalars({
+        doubleValue: floatFromJSON(m.double_value),
+        floatValue: floatFromJSON(m.float_value),
+        int32Value: m.int32_value,
+        int64Value: Number(m.int64_value || "0"),
+        uint32Value: m.uint32_value,
+        uint64Value: Number(m.uint64_value || "0"),
+        sint32Value: m.sint32_value,
+        sint64Value: Number(m.sint64_value || "0"),
+        fixed32Value: m.fixed32_value,
+        fixed64Value: Number(m.fixed64_value || "0"),
+        sfixed32Value: m.sfixed32_value,
+        sfixed64Value: Number(m.sfixed64_value || "0"),
+        boolValue: m.bool_value,
+        stringValue: m.string_value,
+        bytesValue: base64ToBytes(m.bytes_value || ""),
+        floatValues: m.float_values.map(floatFromJSON),
+        sint32Values: m.sint32_values,
+    });
+};
+
+// isScalars reports if a value has the fields of a Scalars, e.g. to check data read from a cache or a websocket.
+export const isScalars = (value: unknown): value is Scalars => {
+    if (!(value instanceof Scalars)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.doubleValue === "number"
+        && typeof m.floatValue === "number"
+        && typeof m.int32Value === "number"
+        && typeof m.int64Value === "number"
+        && typeof m.uint32Value === "number"
+        && typeof m.uint64Value === "number"
+        && typeof m.sint32Value === "number"
+        && typeof m.sint64Value === "number"
+        && typeof m.fixed32Value === "number"
+        && typeof m.fixed64Value === "number"
+        && typeof m.sfixed32Value === "number"
+        && typeof m.sfixed64Value === "number"
+        && typeof m.boolValue === "boolean"
+        && typeof m.stringValue === "string"
+        && m.bytesValue instanceof Uint8Array
+        && everyItem(m.floatValues, (v) => typeof v === "number")
+        && everyItem(m.sint32Values, (v) => typeof v === "number");
+};
+
+export class Image {
+    url: string;
+    width: number;
+    height: number;
+
+    constructor(init: Partial<Image> = {}) {
+        this.url = init.url !== undefined ? init.url : "";
+        this.width = init.width !== undefined ? init.width : 0;
+        this.height = init.height !== undefined ? init.height : 0;
+    }
+
+    // clone returns a deep copy of the Image.
+    clone(): Image {
+        return new Image({
+            url: cloneValue(this.url),
+            width: cloneValue(this.width),
+            height: cloneValue(this.height),
+        });
+    }
+
+    // equals reports if the fields of the Image are deeply equal to those of other.
+    equals(other: Image): boolean {
+        return valuesEqual(this.url, other.url)
+            && valuesEqual(this.width, other.width)
+            && valuesEqual(this.height, other.height);
+    }
+
+    static fromJSON(m: ImageJSON): Image {
+        return JSONToImage(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Image is stringified as its proto3 JSON.
+    toJSON(): ImageJSON {
+        return ImageToJSON(this);
+    }
+}
+
+// ReadonlyImage is the interface of a Image returned by the clients, whose fields cannot be changed.
+export interface ReadonlyImage {
+    readonly url: string;
+    readonly width: number;
+    readonly height: number;
+}
+
+// ImageFields are the numbers and proto types of the fields of Image by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImageFields = {
+    url: {number: 1, name: "url", type: "string"},
+    width: {number: 2, name: "width", type: "int32"},
+    height: {number: 3, name: "height", type: "int32"},
+} as const;
+
+export interface ImageJSON {
+    url: string;
+    width: number;
+    height: number;
+}
+
+export const ImageToJSON = (m: Image): ImageJSON => {
+    return {
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    };
+};
+
+export const JSONToImage = (m: ImageJSON): Image => {
+    return new Image({
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    });
+};
+
+// isImage reports if a value has the fields of a Image, e.g. to check data read from a cache or a websocket.
+export const isImage = (value: unknown): value is Image => {
+    if (!(value instanceof Image)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.url === "string"
+        && typeof m.width === "number"
+        && typeof m.height === "number";
+};
+
+/** A Group is a tree of drawings. */
+export class Group {
+    name: string;
+    parent?: Group | undefined;
+    children: Group[];
+    drawings: Drawing[];
+
+    constructor(init: Partial<Group> = {}) {
+        this.name = init.name !== undefined ? init.name : "";
+        this.parent = init.parent;
+        this.children = init.children !== undefined ? init.children : [];
+        this.drawings = init.drawings !== undefined ? init.drawings : [];
+    }
+
+    // clone returns a deep copy of the Group.
+    clone(): Group {
+        return new Group({
+            name: cloneValue(this.name),
+            parent: cloneValue(this.parent),
+            children: cloneValue(this.children),
+            drawings: cloneValue(this.drawings),
+        });
+    }
+
+    // equals reports if the fields of the Group are deeply equal to those of other.
+    equals(other: Group): boolean {
+        return valuesEqual(this.name, other.name)
+            && valuesEqual(this.parent, other.parent)
+            && valuesEqual(this.children, other.children)
+            && valuesEqual(this.drawings, other.drawings);
+    }
+
+    static fromJSON(m: GroupJSON): Group {
+        return JSONToGroup(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Group is stringified as its proto3 JSON.
+    toJSON(): GroupJSON {
+        return GroupToJSON(this);
+    }
+}
+
+// ReadonlyGroup is the interface of a Group returned by the clients, whose fields cannot be changed.
+export interface ReadonlyGroup {
+    readonly name: string;
+    readonly parent?: ReadonlyGroup | undefined;
+    readonly children: ReadonlyArray<ReadonlyGroup>;
+    readonly drawings: ReadonlyArray<ReadonlyDrawing>;
+}
+
+// GroupFields are the numbers and proto types of the fields of Group by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GroupFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    children: {number: 3, name: "children", type: "message"},
+    drawings: {number: 4, name: "drawings", type: "message"},
+} as const;
+
+export interface GroupJSON {
+    name: string;
+    parent?: GroupJSON;
+    children: GroupJSON[];
+    drawings: DrawingJSON[];
+}
+
+export const GroupToJSON = (m: Group): GroupJSON => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : GroupToJSON(m.parent),
+        children: m.children.map(GroupToJSON),
+        drawings: m.drawings.map(DrawingToJSON),
+    };
+};
+
+export const JSONToGroup = (m: GroupJSON): Group => {
+    return new Group({
+        name: m.name,
+        parent: m.parent === undefined ? undefined : JSONToGroup(m.parent),
+        children: m.children.map(JSONToGroup),
+        drawings: m.drawings.map(JSONToDrawing),
+    });
+};
+
+// isGroup reports if a value has the fields of a Group, e.g. to check data read from a cache or a websocket.
+export const isGroup = (value: unknown): value is Group => {
+    if (!(value instanceof Group)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isGroup(m.parent))
+        && everyItem(m.children, isGroup)
+        && everyItem(m.drawings, isDrawing);
+};
+
+export class GetDrawingRequest {
+    id: number;
+
+    constructor(init: Partial<GetDrawingRequest> = {}) {
+        this.id = init.id !== undefined ? init.id : 0;
+    }
+
+    // clone returns a deep copy of the GetDrawingRequest.
+    clone(): GetDrawingRequest {
+        return new GetDrawingRequest({
+            id: cloneValue(this.id),
+        });
+    }
+
+    // equals reports if the fields of the GetDrawingRequest are deeply equal to those of other.
+    equals(other: GetDrawingRequest): boolean {
+        return valuesEqual(this.id, other.id);
+    }
+
+    static fromJSON(m: GetDrawingRequestJSON): GetDrawingRequest {
+        return JSONToGetDrawingRequest(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a GetDrawingRequest is stringified as its proto3 JSON.
+    toJSON(): GetDrawingRequestJSON {
+        return GetDrawingRequestToJSON(this);
+    }
+}
+
+// GetDrawingRequestFields are the numbers and proto types of the fields of GetDrawingRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetDrawingRequestFields = {
+    id: {number: 1, name: "id", type: "int64"},
+} as const;
+
+export interface GetDrawingRequestJSON {
+    id: string;
+}
+
+export const GetDrawingRequestToJSON = (m: GetDrawingRequest): GetDrawingRequestJSON => {
+    return {
+        id: String(m.id),
+    };
+};
+
+export const JSONToGetDrawingRequest = (m: GetDrawingRequestJSON): GetDrawingRequest => {
+    return new GetDrawingRequest({
+        id: Number(m.id || "0"),
+    });
+};
+
+// isGetDrawingRequest reports if a value has the fields of a GetDrawingRequest, e.g. to check data read from a cache or a websocket.
+export const isGetDrawingRequest = (value: unknown): value is GetDrawingRequest => {
+    if (!(value instanceof GetDrawingRequest)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "number";
+};
+
+/** Canvas stores drawings. */
+export interface Canvas {
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing: (getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Promise<ReadonlyDrawing>;
+
+    saveGroup: (group: Group, callOptions?: CallOptions) => Promise<ReadonlyGroup>;
+}
+
+// CanvasMethods are the Twirp routes of the methods of Canvas, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const CanvasMethods = {
+    getDrawing: {
+        service: "features.v1.Canvas",
+        method: "GetDrawing",
+        path: "/twirp/features.v1.Canvas/GetDrawing",
+        inputType: "GetDrawingRequest",
+        outputType: "Drawing",
+    },
+    saveGroup: {
+        service: "features.v1.Canvas",
+        method: "SaveGroup",
+        path: "/twirp/features.v1.Canvas/SaveGroup",
+        inputType: "Group",
+        outputType: "Group",
+    },
+} as const;
+
+/** Canvas stores drawings. */
+export class DefaultCanvas implements Canvas {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/features.v1.Canvas/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<ReadonlyDrawing> {
+        const url = joinURL(this.hostname, this.pathPrefix + "GetDrawing");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "GetDrawing",
+                url: url,
+                request: getDrawingRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, GetDrawingRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToDrawing(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<ReadonlyGroup> {
+        const url = joinURL(this.hostname, this.pathPrefix + "SaveGroup");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "SaveGroup",
+                url: url,
+                request: group,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, GroupToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToGroup(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createCanvasClient creates a DefaultCanvas with the config, which may be shared by the clients of other services.
+export const createCanvasClient = (config: TwirpClientConfig): DefaultCanvas => {
+    return new DefaultCanvas(config);
+};
+
+// A CanvasMockResponses sets the response of each CanvasMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CanvasMockResponses {
+    getDrawing?: ReadonlyDrawing | ((getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => ReadonlyDrawing | Promise<ReadonlyDrawing>);
+    saveGroup?: ReadonlyGroup | ((group: Group, callOptions?: CallOptions) => ReadonlyGroup | Promise<ReadonlyGroup>);
+}
+
+// CanvasMockClient is a Canvas for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class CanvasMockClient implements Canvas {
+    responses: CanvasMockResponses;
+
+    constructor(responses: CanvasMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<ReadonlyDrawing> {
+        const response = this.responses.getDrawing;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.GetDrawing"}));
+        }
+
+        return new Promise<ReadonlyDrawing>((resolve) => resolve(typeof response === "function" ? response(getDrawingRequest, callOptions) : response));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<ReadonlyGroup> {
+        const response = this.responses.saveGroup;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.SaveGroup"}));
+        }
+
+        return new Promise<ReadonlyGroup>((resolve) => resolve(typeof response === "function" ? response(group, callOptions) : response));
+    }
+}
+
+export const createCanvasMock = (overrides: CanvasMockResponses = {}): CanvasMockClient => {
+    return new CanvasMockClient(overrides);
+};
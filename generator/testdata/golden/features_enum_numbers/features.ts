@@ -0,0 +1,651 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, bytesToBase64, base64ToBytes, mapEntries, enumFromJSON, floatToJSON, floatFromJSON, jsonAliases, everyItem, everyValue, oneofMember} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** Shape is the kind of a Drawing. */
+export enum Shape {
+    SHAPE_UNSPECIFIED = 0,
+    SHAPE_CIRCLE = 1,
+    SHAPE_SQUARE = 2,
+}
+
+export enum DrawingLayerBlend {
+    BLEND_NORMAL = 0,
+    BLEND_MULTIPLY = 1,
+}
+
+/** The content of a Drawing is either text or an image. */
+export type DrawingContent =
+    | {kind: "text"; value: string}
+    | {kind: "image"; value: Image};
+
+/** A Drawing uses every kind of field that is supported by the generator. */
+export interface Drawing {
+    title: string;
+    id: number;
+    revisions: number[];
+    thumbnail: Uint8Array;
+    tiles: Uint8Array[];
+    published: boolean;
+    scale: number;
+    shape: Shape;
+    shapes: Shape[];
+    layer: DrawingLayer;
+    layers: DrawingLayer[];
+    namedLayers: {[key: string]: DrawingLayer};
+    labels: {[key: number]: string};
+    flags: {[key: string]: Shape};
+    opacity?: number | undefined;
+    caption?: string | undefined;
+    scalars: Scalars;
+    /** The content of a Drawing is either text or an image. */
+    content?: DrawingContent;
+}
+
+// DrawingFields are the numbers and proto types of the fields of Drawing by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingFields = {
+    title: {number: 1, name: "title", type: "string"},
+    id: {number: 2, name: "id", type: "int64"},
+    revisions: {number: 3, name: "revisions", type: "uint64"},
+    thumbnail: {number: 4, name: "thumbnail", type: "bytes"},
+    tiles: {number: 5, name: "tiles", type: "bytes"},
+    published: {number: 6, name: "published", type: "bool"},
+    scale: {number: 7, name: "scale", type: "double"},
+    shape: {number: 8, name: "shape", type: "enum"},
+    shapes: {number: 9, name: "shapes", type: "enum"},
+    layer: {number: 10, name: "layer", type: "message"},
+    layers: {number: 11, name: "layers", type: "message"},
+    namedLayers: {number: 12, name: "named_layers", type: "map"},
+    labels: {number: 13, name: "labels", type: "map"},
+    flags: {number: 14, name: "flags", type: "map"},
+    opacity: {number: 15, name: "opacity", type: "int32"},
+    caption: {number: 16, name: "caption", type: "string"},
+    scalars: {number: 19, name: "scalars", type: "message"},
+    text: {number: 17, name: "text", type: "string"},
+    image: {number: 18, name: "image", type: "message"},
+} as const;
+
+export interface DrawingJSON {
+    title: string;
+    id: string;
+    revisions: string[];
+    thumbnail: string;
+    tiles: string[];
+    published: boolean;
+    scale: number | string;
+    shape: string | number;
+    shapes: (string | number)[];
+    layer: DrawingLayerJSON;
+    layers: DrawingLayerJSON[];
+    named_layers: {[key: string]: DrawingLayerJSON};
+    labels: {[key: string]: string};
+    flags: {[key: string]: string | number};
+    opacity?: number;
+    caption?: string;
+    scalars: ScalarsJSON;
+    text?: string;
+    image?: ImageJSON;
+}
+
+export const DrawingToJSON = (m: Drawing): DrawingJSON => {
+    return {
+        title: m.title,
+        id: String(m.id),
+        revisions: m.revisions.map((n) => String(n)),
+        thumbnail: bytesToBase64(m.thumbnail),
+        tiles: m.tiles.map(bytesToBase64),
+        published: m.published,
+        scale: floatToJSON(m.scale),
+        shape: m.shape,
+        shapes: m.shapes,
+        layer: DrawingLayerToJSON(m.layer),
+        layers: m.layers.map(DrawingLayerToJSON),
+        named_layers: mapEntries(m.namedLayers, String, (v) => DrawingLayerToJSON(v)),
+        labels: mapEntries(m.labels, String, (v) => v),
+        flags: m.flags,
+        opacity: m.opacity,
+        caption: m.caption,
+        scalars: ScalarsToJSON(m.scalars),
+        text: m.content && m.content.kind === "text" ? m.content.value : undefined,
+        image: m.content && m.content.kind === "image" ? ImageToJSON(m.content.value) : undefined,
+    };
+};
+
+export const JSONToDrawing = (json: DrawingJSON): Drawing => {
+    const m = jsonAliases(json, {"namedLayers": "named_layers"});
+
+    return {
+        title: m.title,
+        id: Number(m.id || "0"),
+        revisions: m.revisions.map((n) => Number(n)),
+        thumbnail: base64ToBytes(m.thumbnail || ""),
+        tiles: m.tiles.map(base64ToBytes),
+        published: m.published,
+        scale: floatFromJSON(m.scale),
+        shape: enumFromJSON<Shape>(Shape, m.shape),
+        shapes: m.shapes.map((n) => enumFromJSON<Shape>(Shape, n)),
+        layer: JSONToDrawingLayer(m.layer),
+        layers: m.layers.map(JSONToDrawingLayer),
+        namedLayers: mapEntries(m.named_layers || {}, String, (v) => JSONToDrawingLayer(v)),
+        labels: mapEntries(m.labels || {}, Number, (v) => v),
+        flags: mapEntries(m.flags || {}, String, (v) => enumFromJSON<Shape>(Shape, v)),
+        opacity: m.opacity,
+        caption: m.caption,
+        scalars: JSONToScalars(m.scalars),
+        content: m.text !== undefined ? {kind: "text", value: m.text} : m.image !== undefined ? {kind: "image", value: JSONToImage(m.image)} : undefined,
+    };
+};
+
+// isDrawing reports if a value has the fields of a Drawing, e.g. to check data read from a cache or a websocket.
+export const isDrawing = (value: unknown): value is Drawing => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.title === "string"
+        && typeof m.id === "number"
+        && everyItem(m.revisions, (v) => typeof v === "number")
+        && m.thumbnail instanceof Uint8Array
+        && everyItem(m.tiles, (v) => v instanceof Uint8Array)
+        && typeof m.published === "boolean"
+        && typeof m.scale === "number"
+        && typeof m.shape === "number"
+        && everyItem(m.shapes, (v) => typeof v === "number")
+        && isDrawingLayer(m.layer)
+        && everyItem(m.layers, isDrawingLayer)
+        && everyValue(m.namedLayers, isDrawingLayer)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyValue(m.flags, (v) => typeof v === "number")
+        && (m.opacity === undefined || typeof m.opacity === "number")
+        && (m.caption === undefined || typeof m.caption === "string")
+        && isScalars(m.scalars)
+        && (m.content === undefined || oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage}));
+};
+
+/** Layer is the position of a Drawing in a Canvas. */
+export interface DrawingLayer {
+    index: number;
+    blend: DrawingLayerBlend;
+}
+
+// DrawingLayerFields are the numbers and proto types of the fields of DrawingLayer by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingLayerFields = {
+    index: {number: 1, name: "index", type: "int32"},
+    blend: {number: 2, name: "blend", type: "enum"},
+} as const;
+
+export interface DrawingLayerJSON {
+    index: number;
+    blend: string | number;
+}
+
+export const DrawingLayerToJSON = (m: DrawingLayer): DrawingLayerJSON => {
+    return {
+        index: m.index,
+        blend: m.blend,
+    };
+};
+
+export const JSONToDrawingLayer = (m: DrawingLayerJSON): DrawingLayer => {
+    return {
+        index: m.index,
+        blend: enumFromJSON<DrawingLayerBlend>(DrawingLayerBlend, m.blend),
+    };
+};
+
+// isDrawingLayer reports if a value has the fields of a DrawingLayer, e.g. to check data read from a cache or a websocket.
+export const isDrawingLayer = (value: unknown): value is DrawingLayer => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.index === "number"
+        && typeof m.blend === "number";
+};
+
+/** Scalars has a field of each scalar type. */
+export interface Scalars {
+    doubleValue: number;
+    floatValue: number;
+    int32Value: number;
+    int64Value: number;
+    uint32Value: number;
+    uint64Value: number;
+    sint32Value: number;
+    sint64Value: number;
+    fixed32Value: number;
+    fixed64Value: number;
+    sfixed32Value: number;
+    sfixed64Value: number;
+    boolValue: boolean;
+    stringValue: string;
+    bytesValue: Uint8Array;
+    floatValues: number[];
+    sint32Values: number[];
+}
+
+// ScalarsFields are the numbers and proto types of the fields of Scalars by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ScalarsFields = {
+    doubleValue: {number: 1, name: "double_value", type: "double"},
+    floatValue: {number: 2, name: "float_value", type: "float"},
+    int32Value: {number: 3, name: "int32_value", type: "int32"},
+    int64Value: {number: 4, name: "int64_value", type: "int64"},
+    uint32Value: {number: 5, name: "uint32_value", type: "uint32"},
+    uint64Value: {number: 6, name: "uint64_value", type: "uint64"},
+    sint32Value: {number: 7, name: "sint32_value", type: "sint32"},
+    sint64Value: {number: 8, name: "sint64_value", type: "sint64"},
+    fixed32Value: {number: 9, name: "fixed32_value", type: "fixed32"},
+    fixed64Value: {number: 10, name: "fixed64_value", type: "fixed64"},
+    sfixed32Value: {number: 11, name: "sfixed32_value", type: "sfixed32"},
+    sfixed64Value: {number: 12, name: "sfixed64_value", type: "sfixed64"},
+    boolValue: {number: 13, name: "bool_value", type: "bool"},
+    stringValue: {number: 14, name: "string_value", type: "string"},
+    bytesValue: {number: 15, name: "bytes_value", type: "bytes"},
+    floatValues: {number: 16, name: "float_values", type: "float"},
+    sint32Values: {number: 17, name: "sint32_values", type: "sint32"},
+} as const;
+
+export interface ScalarsJSON {
+    double_value: number | string;
+    float_value: number | string;
+    int32_value: number;
+    int64_value: string;
+    uint32_value: number;
+    uint64_value: string;
+    sint32_value: number;
+    sint64_value: string;
+    fixed32_value: number;
+    fixed64_value: string;
+    sfixed32_value: number;
+    sfixed64_value: string;
+    bool_value: boolean;
+    string_value: string;
+    bytes_value: string;
+    float_values: (number | string)[];
+    sint32_values: number[];
+}
+
+export const ScalarsToJSON = (m: Scalars): ScalarsJSON => {
+    return {
+        double_value: floatToJSON(m.doubleValue),
+        float_value: floatToJSON(m.floatValue),
+        int32_value: m.int32Value,
+        int64_value: String(m.int64Value),
+        uint32_value: m.uint32Value,
+        uint64_value: String(m.uint64Value),
+        sint32_value: m.sint32Value,
+        sint64_value: String(m.sint64Value),
+        fixed32_value: m.fixed32Value,
+        fixed64_value: String(m.fixed64Value),
+        sfixed32_value: m.sfixed32Value,
+        sfixed64_value: String(m.sfixed64Value),
+        bool_value: m.boolValue,
+        string_value: m.stringValue,
+        bytes_value: bytesToBase64(m.bytesValue),
+        float_values: m.floatValues.map(floatToJSON),
+        sint32_values: m.sint32Values,
+    };
+};
+
+export const JSONToScalars = (json: ScalarsJSON): Scalars => {
+    const m = jsonAliases(json, {"doubleValue": "double_value", "floatValue": "float_value", "int32Value": "int32_value", "int64Value": "int64_value", "uint32Value": "uint32_value", "uint64Value": "uint64_value", "sint32Value": "sint32_value", "sint64Value": "sint64_value", "fixed32Value": "fixed32_value", "fixed64Value": "fixed64_value", "sfixed32Value": "sfixed32_value", "sfixed64Value": "sfixed64_value", "boolValue": "bool_value", "stringValue": "string_value", "bytesValue": "bytes_value", "floatValues": "float_values", "sint32Values": "sint32_values"});
+
+    return {
+        doubleValue: floatFromJSON(m.double_value),
+        floatValue: floatFromJSON(m.float_value),
+        int32Value: m.int32_value,
+        int64Value: Number(m.int64_value || "0"),
+        uint32Value: m.uint32_value,
+        uint64Value: Number(m.uint64_value || "0"),
+        sint32Value: m.sint32_value,
+        sint64Value: Number(m.sint64_value || "0"),
+        fixed32Value: m.fixed32_value,
+        fixed64Value: Number(m.fixed64_value || "0"),
+        sfixed32Value: m.sfixed32_value,
+        sfixed64Value: Number(m.sfixed64_value || "0"),
+        boolValue: m.bool_value,
+        stringValue: m.string_value,
+        bytesValue: base64ToBytes(m.bytes_value || ""),
+        floatValues: m.float_values.map(floatFromJSON),
+        sint32Values: m.sint32_values,
+    };
+};
+
+// isScalars reports if a value has the fields of a Scalars, e.g. to check data read from a cache or a websocket.
+export const isScalars = (value: unknown): value is Scalars => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.doubleValue === "number"
+        && typeof m.floatValue === "number"
+        && typeof m.int32Value === "number"
+        && typeof m.int64Value === "number"
+        && typeof m.uint32Value === "number"
+        && typeof m.uint64Value === "number"
+        && typeof m.sint32Value === "number"
+        && typeof m.sint64Value === "number"
+        && typeof m.fixed32Value === "number"
+        && typeof m.fixed64Value === "number"
+        && typeof m.sfixed32Value === "number"
+        && typeof m.sfixed64Value === "number"
+        && typeof m.boolValue === "boolean"
+        && typeof m.stringValue === "string"
+        && m.bytesValue instanceof Uint8Array
+        && everyItem(m.floatValues, (v) => typeof v === "number")
+        && everyItem(m.sint32Values, (v) => typeof v === "number");
+};
+
+export interface Image {
+    url: string;
+    width: number;
+    height: number;
+}
+
+// ImageFields are the numbers and proto types of the fields of Image by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImageFields = {
+    url: {number: 1, name: "url", type: "string"},
+    width: {number: 2, name: "width", type: "int32"},
+    height: {number: 3, name: "height", type: "int32"},
+} as const;
+
+export interface ImageJSON {
+    url: string;
+    width: number;
+    height: number;
+}
+
+export const ImageToJSON = (m: Image): ImageJSON => {
+    return {
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    };
+};
+
+export const JSONToImage = (m: ImageJSON): Image => {
+    return {
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    };
+};
+
+// isImage reports if a value has the fields of a Image, e.g. to check data read from a cache or a websocket.
+export const isImage = (value: unknown): value is Image => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.url === "string"
+        && typeof m.width === "number"
+        && typeof m.height === "number";
+};
+
+/** A Group is a tree of drawings. */
+export interface Group {
+    name: string;
+    parent?: Group | undefined;
+    children: Group[];
+    drawings: Drawing[];
+}
+
+// GroupFields are the numbers and proto types of the fields of Group by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GroupFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    children: {number: 3, name: "children", type: "message"},
+    drawings: {number: 4, name: "drawings", type: "message"},
+} as const;
+
+export interface GroupJSON {
+    name: string;
+    parent?: GroupJSON;
+    children: GroupJSON[];
+    drawings: DrawingJSON[];
+}
+
+export const GroupToJSON = (m: Group): GroupJSON => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : GroupToJSON(m.parent),
+        children: m.children.map(GroupToJSON),
+        drawings: m.drawings.map(DrawingToJSON),
+    };
+};
+
+export const JSONToGroup = (m: GroupJSON): Group => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : JSONToGroup(m.parent),
+        children: m.children.map(JSONToGroup),
+        drawings: m.drawings.map(JSONToDrawing),
+    };
+};
+
+// isGroup reports if a value has the fields of a Group, e.g. to check data read from a cache or a websocket.
+export const isGroup = (value: unknown): value is Group => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isGroup(m.parent))
+        && everyItem(m.children, isGroup)
+        && everyItem(m.drawings, isDrawing);
+};
+
+export interface GetDrawingRequest {
+    id: number;
+}
+
+// GetDrawingRequestFields are the numbers and proto types of the fields of GetDrawingRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetDrawingRequestFields = {
+    id: {number: 1, name: "id", type: "int64"},
+} as const;
+
+export interface GetDrawingRequestJSON {
+    id: string;
+}
+
+export const GetDrawingRequestToJSON = (m: GetDrawingRequest): GetDrawingRequestJSON => {
+    return {
+        id: String(m.id),
+    };
+};
+
+// isGetDrawingRequest reports if a value has the fields of a GetDrawingRequest, e.g. to check data read from a cache or a websocket.
+export const isGetDrawingRequest = (value: unknown): value is GetDrawingRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "number";
+};
+
+/** Canvas stores drawings. */
+export interface Canvas {
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing: (getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Promise<Drawing>;
+
+    saveGroup: (group: Group, callOptions?: CallOptions) => Promise<Group>;
+}
+
+// CanvasMethods are the Twirp routes of the methods of Canvas, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const CanvasMethods = {
+    getDrawing: {
+        service: "features.v1.Canvas",
+        method: "GetDrawing",
+        path: "/twirp/features.v1.Canvas/GetDrawing",
+        inputType: "GetDrawingRequest",
+        outputType: "Drawing",
+    },
+    saveGroup: {
+        service: "features.v1.Canvas",
+        method: "SaveGroup",
+        path: "/twirp/features.v1.Canvas/SaveGroup",
+        inputType: "Group",
+        outputType: "Group",
+    },
+} as const;
+
+/** Canvas stores drawings. */
+export class DefaultCanvas implements Canvas {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/features.v1.Canvas/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing> {
+        const url = joinURL(this.hostname, this.pathPrefix + "GetDrawing");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "GetDrawing",
+                url: url,
+                request: getDrawingRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, GetDrawingRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToDrawing(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group> {
+        const url = joinURL(this.hostname, this.pathPrefix + "SaveGroup");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "SaveGroup",
+                url: url,
+                request: group,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, GroupToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToGroup(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createCanvasClient creates a DefaultCanvas with the config, which may be shared by the clients of other services.
+export const createCanvasClient = (config: TwirpClientConfig): DefaultCanvas => {
+    return new DefaultCanvas(config);
+};
+
+// A CanvasMockResponses sets the response of each CanvasMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CanvasMockResponses {
+    getDrawing?: Drawing | ((getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Drawing | Promise<Drawing>);
+    saveGroup?: Group | ((group: Group, callOptions?: CallOptions) => Group | Promise<Group>);
+}
+
+// CanvasMockClient is a Canvas for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class CanvasMockClient implements Canvas {
+    responses: CanvasMockResponses;
+
+    constructor(responses: CanvasMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing> {
+        const response = this.responses.getDrawing;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.GetDrawing"}));
+        }
+
+        return new Promise<Drawing>((resolve) => resolve(typeof response === "function" ? response(getDrawingRequest, callOptions) : response));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group> {
+        const response = this.responses.saveGroup;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.SaveGroup"}));
+        }
+
+        return new Promise<Group>((resolve) => resolve(typeof response === "function" ? response(group, callOptions) : response));
+    }
+}
+
+export const createCanvasMock = (overrides: CanvasMockResponses = {}): CanvasMockClient => {
+    return new CanvasMockClient(overrides);
+};
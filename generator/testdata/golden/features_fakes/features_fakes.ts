@@ -0,0 +1,38 @@
+import {fakeArray, fakeBoolean, fakeBytes, fakeEnum, fakeInt, fakeMap, fakeNumber, fakeOneof, fakeOptional, fakeString, withOverrides} from './twirp_fakes';
+import {Drawing, DrawingContent, DrawingLayer, GetDrawingRequest, Group, Image, Scalars} from './features';
+
+// fakeDrawing is a fake Drawing message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeDrawing = (overrides: Partial<Drawing> = {}): Drawing => {
+    return withOverrides<Drawing>({title: fakeString("title"), id: BigInt(fakeInt(0, 1000000)), revisions: fakeArray(() => BigInt(fakeInt(0, 1000000))), thumbnail: fakeBytes(), tiles: fakeArray(() => fakeBytes()), published: fakeBoolean(), scale: fakeNumber(), shape: fakeEnum([0, 1, 2]), shapes: fakeArray(() => fakeEnum([0, 1, 2])), layer: fakeDrawingLayer(), layers: fakeArray(() => fakeDrawingLayer()), namedLayers: fakeMap(() => fakeString("key"), () => fakeDrawingLayer()), labels: fakeMap(() => String(fakeInt(0, 1000)), () => fakeString("value")), flags: fakeMap(() => String(fakeBoolean()), () => fakeEnum([0, 1, 2])), opacity: fakeInt(0, 1000), caption: fakeString("caption"), scalars: fakeScalars(), content: fakeOneof<DrawingContent>([() => ({kind: "text", value: fakeString("text")}), () => ({kind: "image", value: fakeImage()})])}, overrides);
+};
+
+// fakeDrawingLayer is a fake DrawingLayer message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeDrawingLayer = (overrides: Partial<DrawingLayer> = {}): DrawingLayer => {
+    return withOverrides<DrawingLayer>({index: fakeInt(0, 1000), blend: fakeEnum([0, 1])}, overrides);
+};
+
+// fakeScalars is a fake Scalars message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeScalars = (overrides: Partial<Scalars> = {}): Scalars => {
+    return withOverrides<Scalars>({doubleValue: fakeNumber(), floatValue: fakeNumber(), int32Value: fakeInt(0, 1000), int64Value: BigInt(fakeInt(0, 1000000)), uint32Value: fakeInt(0, 1000), uint64Value: BigInt(fakeInt(0, 1000000)), sint32Value: fakeInt(0, 1000), sint64Value: BigInt(fakeInt(0, 1000000)), fixed32Value: fakeInt(0, 1000), fixed64Value: BigInt(fakeInt(0, 1000000)), sfixed32Value: fakeInt(0, 1000), sfixed64Value: BigInt(fakeInt(0, 1000000)), boolValue: fakeBoolean(), stringValue: fakeString("stringValue"), bytesValue: fakeBytes(), floatValues: fakeArray(() => fakeNumber()), sint32Values: fakeArray(() => fakeInt(0, 1000))}, overrides);
+};
+
+// fakeImage is a fake Image message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeImage = (overrides: Partial<Image> = {}): Image => {
+    return withOverrides<Image>({url: fakeString("url"), width: fakeInt(0, 1000), height: fakeInt(0, 1000)}, overrides);
+};
+
+// fakeGroup is a fake Group message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeGroup = (overrides: Partial<Group> = {}): Group => {
+    return withOverrides<Group>({name: fakeString("name"), parent: fakeOptional(() => fakeGroup()), children: fakeArray(() => fakeGroup()), drawings: fakeArray(() => fakeDrawing())}, overrides);
+};
+
+// fakeGetDrawingRequest is a fake GetDrawingRequest message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeGetDrawingRequest = (overrides: Partial<GetDrawingRequest> = {}): GetDrawingRequest => {
+    return withOverrides<GetDrawingRequest>({id: BigInt(fakeInt(0, 1000000))}, overrides);
+};
@@ -0,0 +1,117 @@
+// maxDepth is the depth of nested fakes, below which the repeated and map fields are empty, and the optional message
+// fields and oneofs are unset, so the fakes of recursive messages are finite.
+const maxDepth = 3;
+
+let state = 1;
+let depth = 0;
+
+// seedFakes resets the pseudorandom generator of the fakes, e.g. in the beforeEach of a test. The fakes are made
+// with the seed 1 until it is set.
+export const seedFakes = (seed: number): void => {
+    state = seed >>> 0;
+};
+
+// fakeRandom is a mulberry32 pseudorandom number in [0, 1).
+const fakeRandom = (): number => {
+    state = (state + 0x6D2B79F5) >>> 0;
+
+    let t = state;
+    t = Math.imul(t ^ (t >>> 15), t | 1);
+    t ^= t + Math.imul(t ^ (t >>> 7), t | 61);
+
+    return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+};
+
+// nested makes a nested fake one level deeper, or returns empty below maxDepth.
+const nested = <T>(fake: () => T, empty: T): T => {
+    if (depth >= maxDepth) {
+        return empty;
+    }
+
+    depth++;
+    try {
+        return fake();
+    } finally {
+        depth--;
+    }
+};
+
+// fakeInt is an integer in [min, max].
+export const fakeInt = (min: number, max: number): number => {
+    return min + Math.floor(fakeRandom() * (max - min + 1));
+};
+
+// fakeNumber is a number in [0, 1000) with two decimals.
+export const fakeNumber = (): number => {
+    return fakeInt(0, 99999) / 100;
+};
+
+export const fakeBoolean = (): boolean => {
+    return fakeRandom() < 0.5;
+};
+
+// fakeString is the name of a field with a number, e.g. "title-42", which tells the fields apart in a story.
+export const fakeString = (name: string): string => {
+    return name + "-" + fakeInt(1, 9999);
+};
+
+export const fakeBytes = (): Uint8Array => {
+    const b = new Uint8Array(fakeInt(1, 16));
+    for (let i = 0; i < b.length; i++) {
+        b[i] = fakeInt(0, 255);
+    }
+
+    return b;
+};
+
+// fakeDate is a time in 2020, in whole seconds.
+export const fakeDate = (): Date => {
+    return new Date(Date.UTC(2020, 0, 1) + fakeInt(0, 366 * 86400 - 1) * 1000);
+};
+
+// fakeEnum is one of the numbers of the values of an enum.
+export const fakeEnum = (values: number[]): number => {
+    return values[fakeInt(0, values.length - 1)];
+};
+
+// fakeArray is a repeated field of one to three fakes.
+export const fakeArray = <T>(fake: () => T): T[] => {
+    return nested(() => {
+        const items: T[] = [];
+        for (let n = fakeInt(1, 3); n > 0; n--) {
+            items.push(fake());
+        }
+
+        return items;
+    }, []);
+};
+
+// fakeMap is a map field of one to three entries.
+export const fakeMap = <T>(key: () => string, fake: () => T): {[key: string]: T} => {
+    return nested(() => {
+        const entries: {[key: string]: T} = {};
+        for (let n = fakeInt(1, 3); n > 0; n--) {
+            entries[key()] = fake();
+        }
+
+        return entries;
+    }, {});
+};
+
+// fakeOptional is an optional message field, which is set unless it is nested below maxDepth.
+export const fakeOptional = <T>(fake: () => T): T | undefined => {
+    return nested<T | undefined>(fake, undefined);
+};
+
+// fakeOneof is one of the members of a oneof, which is unset when it is nested below maxDepth.
+export const fakeOneof = <T>(members: (() => T)[]): T | undefined => {
+    return nested<T | undefined>(() => members[fakeInt(0, members.length - 1)](), undefined);
+};
+
+// withOverrides copies the overridden fields of a message onto a fake of it.
+export const withOverrides = <T>(fake: T, overrides: Partial<T>): T => {
+    const m: {[key: string]: any} = fake;
+    Object.keys(overrides).forEach((k) => m[k] = (overrides as any)[k]);
+
+    return m as T;
+};
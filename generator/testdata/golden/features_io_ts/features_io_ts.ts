@@ -0,0 +1,98 @@
+import * as t from 'io-ts';
+import * as E from 'fp-ts/Either';
+import {DrawingLayer, DrawingLayerJSON, JSONToDrawingLayer, Scalars, ScalarsJSON, JSONToScalars, Image, ImageJSON, JSONToImage, Drawing, DrawingJSON, JSONToDrawing, Group, GroupJSON, JSONToGroup} from './features';
+
+// DrawingLayerCodec decodes the proto3 JSON of the DrawingLayer message, e.g. the body of a response.
+export const DrawingLayerCodec = t.partial({
+    index: t.Int,
+    blend: t.union([t.keyof({"BLEND_NORMAL": null, "BLEND_MULTIPLY": null}), t.Int]),
+});
+
+// decodeDrawingLayer decodes the proto3 JSON of the DrawingLayer message, and returns the errors of JSON that does not match its codec.
+export const decodeDrawingLayer = (json: unknown): E.Either<t.Errors, DrawingLayer> => {
+    return E.map((m) => JSONToDrawingLayer(m as DrawingLayerJSON))(DrawingLayerCodec.decode(json));
+};
+
+// ScalarsCodec decodes the proto3 JSON of the Scalars message, e.g. the body of a response.
+export const ScalarsCodec = t.partial({
+    double_value: t.union([t.number, t.string]),
+    float_value: t.union([t.number, t.string]),
+    int32_value: t.Int,
+    int64_value: t.union([t.string, t.Int]),
+    uint32_value: t.Int,
+    uint64_value: t.union([t.string, t.Int]),
+    sint32_value: t.Int,
+    sint64_value: t.union([t.string, t.Int]),
+    fixed32_value: t.Int,
+    fixed64_value: t.union([t.string, t.Int]),
+    sfixed32_value: t.Int,
+    sfixed64_value: t.union([t.string, t.Int]),
+    bool_value: t.boolean,
+    string_value: t.string,
+    bytes_value: t.string,
+    float_values: t.array(t.union([t.number, t.string])),
+    sint32_values: t.array(t.Int),
+});
+
+// decodeScalars decodes the proto3 JSON of the Scalars message, and returns the errors of JSON that does not match its codec.
+export const decodeScalars = (json: unknown): E.Either<t.Errors, Scalars> => {
+    return E.map((m) => JSONToScalars(m as ScalarsJSON))(ScalarsCodec.decode(json));
+};
+
+// ImageCodec decodes the proto3 JSON of the Image message, e.g. the body of a response.
+export const ImageCodec = t.partial({
+    url: t.string,
+    width: t.Int,
+    height: t.Int,
+});
+
+// decodeImage decodes the proto3 JSON of the Image message, and returns the errors of JSON that does not match its codec.
+export const decodeImage = (json: unknown): E.Either<t.Errors, Image> => {
+    return E.map((m) => JSONToImage(m as ImageJSON))(ImageCodec.decode(json));
+};
+
+// DrawingCodec decodes the proto3 JSON of the Drawing message, e.g. the body of a response.
+export const DrawingCodec = t.partial({
+    title: t.string,
+    id: t.union([t.string, t.Int]),
+    revisions: t.array(t.union([t.string, t.Int])),
+    thumbnail: t.string,
+    tiles: t.array(t.string),
+    published: t.boolean,
+    scale: t.union([t.number, t.string]),
+    shape: t.union([t.keyof({"SHAPE_UNSPECIFIED": null, "SHAPE_CIRCLE": null, "SHAPE_SQUARE": null}), t.Int]),
+    shapes: t.array(t.union([t.keyof({"SHAPE_UNSPECIFIED": null, "SHAPE_CIRCLE": null, "SHAPE_SQUARE": null}), t.Int])),
+    layer: DrawingLayerCodec,
+    layers: t.array(DrawingLayerCodec),
+    named_layers: t.record(t.string, DrawingLayerCodec),
+    labels: t.record(t.string, t.string),
+    flags: t.record(t.string, t.union([t.keyof({"SHAPE_UNSPECIFIED": null, "SHAPE_CIRCLE": null, "SHAPE_SQUARE": null}), t.Int])),
+    opacity: t.Int,
+    caption: t.string,
+    scalars: ScalarsCodec,
+    text: t.string,
+    image: ImageCodec,
+});
+
+// decodeDrawing decodes the proto3 JSON of the Drawing message, and returns the errors of JSON that does not match its codec.
+export const decodeDrawing = (json: unknown): E.Either<t.Errors, Drawing> => {
+    return E.map((m) => JSONToDrawing(m as DrawingJSON))(DrawingCodec.decode(json));
+};
+
+// GroupCodec decodes the proto3 JSON of the Group message, e.g. the body of a response.
+export const GroupCodec: t.Type<any> = t.recursion("Group", () => t.partial({
+    name: t.string,
+    parent: GroupCodec,
+    children: t.array(GroupCodec),
+    drawings: t.array(DrawingCodec),
+}));
+
+// decodeGroup decodes the proto3 JSON of the Group message, and returns the errors of JSON that does not match its codec.
+export const decodeGroup = (json: unknown): E.Either<t.Errors, Group> => {
+    return E.map((m) => JSONToGroup(m as GroupJSON))(GroupCodec.decode(json));
+};
+
+// GetDrawingRequestCodec decodes the proto3 JSON of the GetDrawingRequest message, e.g. the body of a response.
+export const GetDrawingRequestCodec = t.partial({
+    id: t.union([t.string, t.Int]),
+});
@@ -0,0 +1,89 @@
+import {z} from 'zod';
+
+// DrawingSchema checks the proto3 JSON of the Drawing message, e.g. the body of a response.
+export const DrawingSchema = z.object({
+    title: z.string().optional(),
+    id: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    revisions: z.array(z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()])).optional(),
+    thumbnail: z.string().optional(),
+    tiles: z.array(z.string()).optional(),
+    published: z.boolean().optional(),
+    scale: z.union([z.number(), z.string()]).optional(),
+    shape: z.union([z.enum(["SHAPE_UNSPECIFIED", "SHAPE_CIRCLE", "SHAPE_SQUARE"]), z.number().int()]).optional(),
+    shapes: z.array(z.union([z.enum(["SHAPE_UNSPECIFIED", "SHAPE_CIRCLE", "SHAPE_SQUARE"]), z.number().int()])).optional(),
+    layer: z.lazy(() => DrawingLayerSchema).optional(),
+    layers: z.array(z.lazy(() => DrawingLayerSchema)).optional(),
+    namedLayers: z.record(z.string(), z.lazy(() => DrawingLayerSchema)).optional(),
+    named_layers: z.record(z.string(), z.lazy(() => DrawingLayerSchema)).optional(),
+    labels: z.record(z.string().regex(/^-?[0-9]+$/), z.string()).optional(),
+    flags: z.record(z.string().regex(/^(true|false)$/), z.union([z.enum(["SHAPE_UNSPECIFIED", "SHAPE_CIRCLE", "SHAPE_SQUARE"]), z.number().int()])).optional(),
+    opacity: z.number().int().optional(),
+    caption: z.string().optional(),
+    scalars: z.lazy(() => ScalarsSchema).optional(),
+    text: z.string().optional(),
+    image: z.lazy(() => ImageSchema).optional(),
+});
+
+// DrawingLayerSchema checks the proto3 JSON of the DrawingLayer message, e.g. the body of a response.
+export const DrawingLayerSchema = z.object({
+    index: z.number().int().optional(),
+    blend: z.union([z.enum(["BLEND_NORMAL", "BLEND_MULTIPLY"]), z.number().int()]).optional(),
+});
+
+// ScalarsSchema checks the proto3 JSON of the Scalars message, e.g. the body of a response.
+export const ScalarsSchema = z.object({
+    doubleValue: z.union([z.number(), z.string()]).optional(),
+    double_value: z.union([z.number(), z.string()]).optional(),
+    floatValue: z.union([z.number(), z.string()]).optional(),
+    float_value: z.union([z.number(), z.string()]).optional(),
+    int32Value: z.number().int().optional(),
+    int32_value: z.number().int().optional(),
+    int64Value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    int64_value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    uint32Value: z.number().int().optional(),
+    uint32_value: z.number().int().optional(),
+    uint64Value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    uint64_value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    sint32Value: z.number().int().optional(),
+    sint32_value: z.number().int().optional(),
+    sint64Value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    sint64_value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    fixed32Value: z.number().int().optional(),
+    fixed32_value: z.number().int().optional(),
+    fixed64Value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    fixed64_value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    sfixed32Value: z.number().int().optional(),
+    sfixed32_value: z.number().int().optional(),
+    sfixed64Value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    sfixed64_value: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+    boolValue: z.boolean().optional(),
+    bool_value: z.boolean().optional(),
+    stringValue: z.string().optional(),
+    string_value: z.string().optional(),
+    bytesValue: z.string().optional(),
+    bytes_value: z.string().optional(),
+    floatValues: z.array(z.union([z.number(), z.string()])).optional(),
+    float_values: z.array(z.union([z.number(), z.string()])).optional(),
+    sint32Values: z.array(z.number().int()).optional(),
+    sint32_values: z.array(z.number().int()).optional(),
+});
+
+// ImageSchema checks the proto3 JSON of the Image message, e.g. the body of a response.
+export const ImageSchema = z.object({
+    url: z.string().optional(),
+    width: z.number().int().optional(),
+    height: z.number().int().optional(),
+});
+
+// GroupSchema checks the proto3 JSON of the Group message, e.g. the body of a response.
+export const GroupSchema: z.ZodType<any> = z.object({
+    name: z.string().optional(),
+    parent: z.lazy(() => GroupSchema).optional(),
+    children: z.array(z.lazy(() => GroupSchema)).optional(),
+    drawings: z.array(z.lazy(() => DrawingSchema)).optional(),
+});
+
+// GetDrawingRequestSchema checks the proto3 JSON of the GetDrawingRequest message, e.g. the body of a response.
+export const GetDrawingRequestSchema = z.object({
+    id: z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()]).optional(),
+});
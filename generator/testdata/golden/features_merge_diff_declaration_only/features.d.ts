@@ -0,0 +1,400 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+/** Shape is the kind of a Drawing. */
+export declare enum Shape {
+    SHAPE_UNSPECIFIED = 0,
+    SHAPE_CIRCLE = 1,
+    SHAPE_SQUARE = 2,
+}
+
+export declare enum DrawingLayerBlend {
+    BLEND_NORMAL = 0,
+    BLEND_MULTIPLY = 1,
+}
+
+/** The content of a Drawing is either text or an image. */
+export type DrawingContent =
+    | {kind: "text"; value: string}
+    | {kind: "image"; value: Image};
+
+/** A Drawing uses every kind of field that is supported by the generator. */
+export interface Drawing {
+    title: string;
+    id: number;
+    revisions: number[];
+    thumbnail: Uint8Array;
+    tiles: Uint8Array[];
+    published: boolean;
+    scale: number;
+    shape: Shape;
+    shapes: Shape[];
+    layer: DrawingLayer;
+    layers: DrawingLayer[];
+    namedLayers: {[key: string]: DrawingLayer};
+    labels: {[key: number]: string};
+    flags: {[key: string]: Shape};
+    opacity?: number | undefined;
+    caption?: string | undefined;
+    scalars: Scalars;
+    /** The content of a Drawing is either text or an image. */
+    content?: DrawingContent;
+}
+
+// DrawingFields are the numbers and proto types of the fields of Drawing by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DrawingFields: {
+    readonly title: {readonly number: 1; readonly name: "title"; readonly type: "string"};
+    readonly id: {readonly number: 2; readonly name: "id"; readonly type: "int64"};
+    readonly revisions: {readonly number: 3; readonly name: "revisions"; readonly type: "uint64"};
+    readonly thumbnail: {readonly number: 4; readonly name: "thumbnail"; readonly type: "bytes"};
+    readonly tiles: {readonly number: 5; readonly name: "tiles"; readonly type: "bytes"};
+    readonly published: {readonly number: 6; readonly name: "published"; readonly type: "bool"};
+    readonly scale: {readonly number: 7; readonly name: "scale"; readonly type: "double"};
+    readonly shape: {readonly number: 8; readonly name: "shape"; readonly type: "enum"};
+    readonly shapes: {readonly number: 9; readonly name: "shapes"; readonly type: "enum"};
+    readonly layer: {readonly number: 10; readonly name: "layer"; readonly type: "message"};
+    readonly layers: {readonly number: 11; readonly name: "layers"; readonly type: "message"};
+    readonly namedLayers: {readonly number: 12; readonly name: "named_layers"; readonly type: "map"};
+    readonly labels: {readonly number: 13; readonly name: "labels"; readonly type: "map"};
+    readonly flags: {readonly number: 14; readonly name: "flags"; readonly type: "map"};
+    readonly opacity: {readonly number: 15; readonly name: "opacity"; readonly type: "int32"};
+    readonly caption: {readonly number: 16; readonly name: "caption"; readonly type: "string"};
+    readonly scalars: {readonly number: 19; readonly name: "scalars"; readonly type: "message"};
+    readonly text: {readonly number: 17; readonly name: "text"; readonly type: "string"};
+    readonly image: {readonly number: 18; readonly name: "image"; readonly type: "message"};
+};
+
+export interface DrawingJSON {
+    title: string;
+    id: string;
+    revisions: string[];
+    thumbnail: string;
+    tiles: string[];
+    published: boolean;
+    scale: number | string;
+    shape: string | number;
+    shapes: (string | number)[];
+    layer: DrawingLayerJSON;
+    layers: DrawingLayerJSON[];
+    named_layers: {[key: string]: DrawingLayerJSON};
+    labels: {[key: string]: string};
+    flags: {[key: string]: string | number};
+    opacity?: number;
+    caption?: string;
+    scalars: ScalarsJSON;
+    text?: string;
+    image?: ImageJSON;
+}
+
+export declare const DrawingToJSON: (m: Drawing) => DrawingJSON;
+
+export declare const JSONToDrawing: (m: DrawingJSON) => Drawing;
+
+// isDrawing reports if a value has the fields of a Drawing, e.g. to check data read from a cache or a websocket.
+export declare const isDrawing: (value: unknown) => value is Drawing;
+
+// mergeDrawing copies a Drawing with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the Drawing, while its other fields replace them.
+export declare const mergeDrawing: (base: Drawing, patch: Partial<Drawing>) => Drawing;
+
+// diffDrawing finds the paths of the fields that differ between two Drawings, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a Drawing.
+export declare const diffDrawing: (a: Drawing, b: Drawing) => string[];
+
+/** Layer is the position of a Drawing in a Canvas. */
+export interface DrawingLayer {
+    index: number;
+    blend: DrawingLayerBlend;
+}
+
+// DrawingLayerFields are the numbers and proto types of the fields of DrawingLayer by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DrawingLayerFields: {
+    readonly index: {readonly number: 1; readonly name: "index"; readonly type: "int32"};
+    readonly blend: {readonly number: 2; readonly name: "blend"; readonly type: "enum"};
+};
+
+export interface DrawingLayerJSON {
+    index: number;
+    blend: string | number;
+}
+
+export declare const DrawingLayerToJSON: (m: DrawingLayer) => DrawingLayerJSON;
+
+export declare const JSONToDrawingLayer: (m: DrawingLayerJSON) => DrawingLayer;
+
+// isDrawingLayer reports if a value has the fields of a DrawingLayer, e.g. to check data read from a cache or a websocket.
+export declare const isDrawingLayer: (value: unknown) => value is DrawingLayer;
+
+// mergeDrawingLayer copies a DrawingLayer with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the DrawingLayer, while its other fields replace them.
+export declare const mergeDrawingLayer: (base: DrawingLayer, patch: Partial<DrawingLayer>) => DrawingLayer;
+
+// diffDrawingLayer finds the paths of the fields that differ between two DrawingLayers, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a DrawingLayer.
+export declare const diffDrawingLayer: (a: DrawingLayer, b: DrawingLayer) => string[];
+
+/** Scalars has a field of each scalar type. */
+export interface Scalars {
+    doubleValue: number;
+    floatValue: number;
+    int32Value: number;
+    int64Value: number;
+    uint32Value: number;
+    uint64Value: number;
+    sint32Value: number;
+    sint64Value: number;
+    fixed32Value: number;
+    fixed64Value: number;
+    sfixed32Value: number;
+    sfixed64Value: number;
+    boolValue: boolean;
+    stringValue: string;
+    bytesValue: Uint8Array;
+    floatValues: number[];
+    sint32Values: number[];
+}
+
+// ScalarsFields are the numbers and proto types of the fields of Scalars by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ScalarsFields: {
+    readonly doubleValue: {readonly number: 1; readonly name: "double_value"; readonly type: "double"};
+    readonly floatValue: {readonly number: 2; readonly name: "float_value"; readonly type: "float"};
+    readonly int32Value: {readonly number: 3; readonly name: "int32_value"; readonly type: "int32"};
+    readonly int64Value: {readonly number: 4; readonly name: "int64_value"; readonly type: "int64"};
+    readonly uint32Value: {readonly number: 5; readonly name: "uint32_value"; readonly type: "uint32"};
+    readonly uint64Value: {readonly number: 6; readonly name: "uint64_value"; readonly type: "uint64"};
+    readonly sint32Value: {readonly number: 7; readonly name: "sint32_value"; readonly type: "sint32"};
+    readonly sint64Value: {readonly number: 8; readonly name: "sint64_value"; readonly type: "sint64"};
+    readonly fixed32Value: {readonly number: 9; readonly name: "fixed32_value"; readonly type: "fixed32"};
+    readonly fixed64Value: {readonly number: 10; readonly name: "fixed64_value"; readonly type: "fixed64"};
+    readonly sfixed32Value: {readonly number: 11; readonly name: "sfixed32_value"; readonly type: "sfixed32"};
+    readonly sfixed64Value: {readonly number: 12; readonly name: "sfixed64_value"; readonly type: "sfixed64"};
+    readonly boolValue: {readonly number: 13; readonly name: "bool_value"; readonly type: "bool"};
+    readonly stringValue: {readonly number: 14; readonly name: "string_value"; readonly type: "string"};
+    readonly bytesValue: {readonly number: 15; readonly name: "bytes_value"; readonly type: "bytes"};
+    readonly floatValues: {readonly number: 16; readonly name: "float_values"; readonly type: "float"};
+    readonly sint32Values: {readonly number: 17; readonly name: "sint32_values"; readonly type: "sint32"};
+};
+
+export interface ScalarsJSON {
+    double_value: number | string;
+    float_value: number | string;
+    int32_value: number;
+    int64_value: string;
+    uint32_value: number;
+    uint64_value: string;
+    sint32_value: number;
+    sint64_value: string;
+    fixed32_value: number;
+    fixed64_value: string;
+    sfixed32_value: number;
+    sfixed64_value: string;
+    bool_value: boolean;
+    string_value: string;
+    bytes_value: string;
+    float_values: (number | string)[];
+    sint32_values: number[];
+}
+
+export declare const ScalarsToJSON: (m: Scalars) => ScalarsJSON;
+
+export declare const JSONToScalars: (m: ScalarsJSON) => Scalars;
+
+// isScalars reports if a value has the fields of a Scalars, e.g. to check data read from a cache or a websocket.
+export declare const isScalars: (value: unknown) => value is Scalars;
+
+// mergeScalars copies a Scalars with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the Scalars, while its other fields replace them.
+export declare const mergeScalars: (base: Scalars, patch: Partial<Scalars>) => Scalars;
+
+// diffScalars finds the paths of the fields that differ between two Scalarss, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a Scalars.
+export declare const diffScalars: (a: Scalars, b: Scalars) => string[];
+
+export interface Image {
+    url: string;
+    width: number;
+    height: number;
+}
+
+// ImageFields are the numbers and proto types of the fields of Image by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ImageFields: {
+    readonly url: {readonly number: 1; readonly name: "url"; readonly type: "string"};
+    readonly width: {readonly number: 2; readonly name: "width"; readonly type: "int32"};
+    readonly height: {readonly number: 3; readonly name: "height"; readonly type: "int32"};
+};
+
+export interface ImageJSON {
+    url: string;
+    width: number;
+    height: number;
+}
+
+export declare const ImageToJSON: (m: Image) => ImageJSON;
+
+export declare const JSONToImage: (m: ImageJSON) => Image;
+
+// isImage reports if a value has the fields of a Image, e.g. to check data read from a cache or a websocket.
+export declare const isImage: (value: unknown) => value is Image;
+
+// mergeImage copies a Image with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the Image, while its other fields replace them.
+export declare const mergeImage: (base: Image, patch: Partial<Image>) => Image;
+
+// diffImage finds the paths of the fields that differ between two Images, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a Image.
+export declare const diffImage: (a: Image, b: Image) => string[];
+
+/** A Group is a tree of drawings. */
+export interface Group {
+    name: string;
+    parent?: Group | undefined;
+    children: Group[];
+    drawings: Drawing[];
+}
+
+// GroupFields are the numbers and proto types of the fields of Group by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const GroupFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+    readonly parent: {readonly number: 2; readonly name: "parent"; readonly type: "message"};
+    readonly children: {readonly number: 3; readonly name: "children"; readonly type: "message"};
+    readonly drawings: {readonly number: 4; readonly name: "drawings"; readonly type: "message"};
+};
+
+export interface GroupJSON {
+    name: string;
+    parent?: GroupJSON;
+    children: GroupJSON[];
+    drawings: DrawingJSON[];
+}
+
+export declare const GroupToJSON: (m: Group) => GroupJSON;
+
+export declare const JSONToGroup: (m: GroupJSON) => Group;
+
+// isGroup reports if a value has the fields of a Group, e.g. to check data read from a cache or a websocket.
+export declare const isGroup: (value: unknown) => value is Group;
+
+// mergeGroup copies a Group with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the Group, while its other fields replace them.
+export declare const mergeGroup: (base: Group, patch: Partial<Group>) => Group;
+
+// diffGroup finds the paths of the fields that differ between two Groups, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a Group.
+export declare const diffGroup: (a: Group, b: Group) => string[];
+
+export interface GetDrawingRequest {
+    id: number;
+}
+
+// GetDrawingRequestFields are the numbers and proto types of the fields of GetDrawingRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const GetDrawingRequestFields: {
+    readonly id: {readonly number: 1; readonly name: "id"; readonly type: "int64"};
+};
+
+export interface GetDrawingRequestJSON {
+    id: string;
+}
+
+export declare const GetDrawingRequestToJSON: (m: GetDrawingRequest) => GetDrawingRequestJSON;
+
+// isGetDrawingRequest reports if a value has the fields of a GetDrawingRequest, e.g. to check data read from a cache or a websocket.
+export declare const isGetDrawingRequest: (value: unknown) => value is GetDrawingRequest;
+
+// mergeGetDrawingRequest copies a GetDrawingRequest with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the GetDrawingRequest, while its other fields replace them.
+export declare const mergeGetDrawingRequest: (base: GetDrawingRequest, patch: Partial<GetDrawingRequest>) => GetDrawingRequest;
+
+// diffGetDrawingRequest finds the paths of the fields that differ between two GetDrawingRequests, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a GetDrawingRequest.
+export declare const diffGetDrawingRequest: (a: GetDrawingRequest, b: GetDrawingRequest) => string[];
+
+/** Canvas stores drawings. */
+export interface Canvas {
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing: (getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Promise<Drawing>;
+
+    saveGroup: (group: Group, callOptions?: CallOptions) => Promise<Group>;
+}
+
+// CanvasMethods are the Twirp routes of the methods of Canvas, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const CanvasMethods: {
+    readonly getDrawing: {
+        readonly service: "features.v1.Canvas";
+        readonly method: "GetDrawing";
+        readonly path: "/twirp/features.v1.Canvas/GetDrawing";
+        readonly inputType: "GetDrawingRequest";
+        readonly outputType: "Drawing";
+    };
+    readonly saveGroup: {
+        readonly service: "features.v1.Canvas";
+        readonly method: "SaveGroup";
+        readonly path: "/twirp/features.v1.Canvas/SaveGroup";
+        readonly inputType: "Group";
+        readonly outputType: "Group";
+    };
+};
+
+/** Canvas stores drawings. */
+export declare class DefaultCanvas implements Canvas {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing>;
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group>;
+}
+
+// createCanvasClient creates a DefaultCanvas with the config, which may be shared by the clients of other services.
+export declare const createCanvasClient: (config: TwirpClientConfig) => DefaultCanvas;
+
+// A CanvasMockResponses sets the response of each CanvasMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CanvasMockResponses {
+    getDrawing?: Drawing | ((getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Drawing | Promise<Drawing>);
+    saveGroup?: Group | ((group: Group, callOptions?: CallOptions) => Group | Promise<Group>);
+}
+
+// CanvasMockClient is a Canvas for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class CanvasMockClient implements Canvas {
+    responses: CanvasMockResponses;
+
+    constructor(responses?: CanvasMockResponses);
+
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing>;
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group>;
+}
+
+export declare const createCanvasMock: (overrides?: CanvasMockResponses) => CanvasMockClient;
@@ -0,0 +1,543 @@
+import {ProtobufReader, ProtobufWriter, everyItem, everyValue, oneofMember} from './twirp';
+
+/** Shape is the kind of a Drawing. */
+export enum Shape {
+    SHAPE_UNSPECIFIED = 0,
+    SHAPE_CIRCLE = 1,
+    SHAPE_SQUARE = 2,
+}
+
+export enum DrawingLayerBlend {
+    BLEND_NORMAL = 0,
+    BLEND_MULTIPLY = 1,
+}
+
+/** The content of a Drawing is either text or an image. */
+export type DrawingContent =
+    | {kind: "text"; value: string}
+    | {kind: "image"; value: Image};
+
+/** A Drawing uses every kind of field that is supported by the generator. */
+export interface Drawing {
+    title: string;
+    id: number;
+    revisions: number[];
+    thumbnail: Uint8Array;
+    tiles: Uint8Array[];
+    published: boolean;
+    scale: number;
+    shape: Shape;
+    shapes: Shape[];
+    layer: DrawingLayer;
+    layers: DrawingLayer[];
+    namedLayers: {[key: string]: DrawingLayer};
+    labels: {[key: number]: string};
+    flags: {[key: string]: Shape};
+    opacity?: number | undefined;
+    caption?: string | undefined;
+    scalars: Scalars;
+    /** The content of a Drawing is either text or an image. */
+    content?: DrawingContent;
+}
+
+// DrawingFields are the numbers and proto types of the fields of Drawing by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingFields = {
+    title: {number: 1, name: "title", type: "string"},
+    id: {number: 2, name: "id", type: "int64"},
+    revisions: {number: 3, name: "revisions", type: "uint64"},
+    thumbnail: {number: 4, name: "thumbnail", type: "bytes"},
+    tiles: {number: 5, name: "tiles", type: "bytes"},
+    published: {number: 6, name: "published", type: "bool"},
+    scale: {number: 7, name: "scale", type: "double"},
+    shape: {number: 8, name: "shape", type: "enum"},
+    shapes: {number: 9, name: "shapes", type: "enum"},
+    layer: {number: 10, name: "layer", type: "message"},
+    layers: {number: 11, name: "layers", type: "message"},
+    namedLayers: {number: 12, name: "named_layers", type: "map"},
+    labels: {number: 13, name: "labels", type: "map"},
+    flags: {number: 14, name: "flags", type: "map"},
+    opacity: {number: 15, name: "opacity", type: "int32"},
+    caption: {number: 16, name: "caption", type: "string"},
+    scalars: {number: 19, name: "scalars", type: "message"},
+    text: {number: 17, name: "text", type: "string"},
+    image: {number: 18, name: "image", type: "message"},
+} as const;
+
+export interface DrawingJSON {
+    title: string;
+    id: string;
+    revisions: string[];
+    thumbnail: string;
+    tiles: string[];
+    published: boolean;
+    scale: number | string;
+    shape: string | number;
+    shapes: (string | number)[];
+    layer: DrawingLayerJSON;
+    layers: DrawingLayerJSON[];
+    named_layers: {[key: string]: DrawingLayerJSON};
+    labels: {[key: string]: string};
+    flags: {[key: string]: string | number};
+    opacity?: number;
+    caption?: string;
+    scalars: ScalarsJSON;
+    text?: string;
+    image?: ImageJSON;
+}
+
+export const DrawingToProtobuf = (m: Drawing): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.title) { w.tag(1, 2).string(m.title); }
+    if (m.id) { w.tag(2, 0).int64(String(m.id)); }
+    if (m.revisions.length) { w.tag(3, 2).packed(m.revisions, (w, v) => w.uint64(String(v))); }
+    if (m.thumbnail && m.thumbnail.length) { w.tag(4, 2).bytes(m.thumbnail); }
+    m.tiles.forEach((v) => w.tag(5, 2).bytes(v));
+    if (m.published) { w.tag(6, 0).bool(m.published); }
+    if (m.scale !== 0) { w.tag(7, 1).double(m.scale); }
+    if (m.shape) { w.tag(8, 0).int32(m.shape); }
+    if (m.shapes.length) { w.tag(9, 2).packed(m.shapes, (w, v) => w.int32(v)); }
+    if (m.layer) { w.tag(10, 2).bytes(DrawingLayerToProtobuf(m.layer)); }
+    m.layers.forEach((v) => w.tag(11, 2).bytes(DrawingLayerToProtobuf(v)));
+    Object.keys(m.namedLayers).forEach((k) => w.tag(12, 2).message((w) => { w.tag(1, 2).string(k); w.tag(2, 2).bytes(DrawingLayerToProtobuf(m.namedLayers[k])); }));
+    Object.keys(m.labels).map(Number).forEach((k) => w.tag(13, 2).message((w) => { w.tag(1, 0).int32(k); w.tag(2, 2).string(m.labels[k]); }));
+    Object.keys(m.flags).forEach((k) => w.tag(14, 2).message((w) => { w.tag(1, 0).bool(k === "true"); w.tag(2, 0).int32(m.flags[k]); }));
+    if (m.opacity !== undefined) { w.tag(15, 0).int32(m.opacity); }
+    if (m.caption !== undefined) { w.tag(16, 2).string(m.caption); }
+    if (m.scalars) { w.tag(19, 2).bytes(ScalarsToProtobuf(m.scalars)); }
+    if (m.content && m.content.kind === "text") { w.tag(17, 2).string(m.content.value); }
+    if (m.content && m.content.kind === "image") { w.tag(18, 2).bytes(ImageToProtobuf(m.content.value)); }
+
+    return w.finish();
+};
+
+export const ProtobufToDrawing = (b: Uint8Array): Drawing => {
+    const r = new ProtobufReader(b);
+    const m = {title: "", id: 0, revisions: [], thumbnail: new Uint8Array(0), tiles: [], published: false, scale: 0, shape: 0, shapes: [], layers: [], namedLayers: {}, labels: {}, flags: {}} as Drawing;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.title = r.string(); break;
+            case 2: m.id = Number(r.int64()); break;
+            case 3: r.repeated(tag, () => m.revisions.push(Number(r.uint64()))); break;
+            case 4: m.thumbnail = r.bytes(); break;
+            case 5: m.tiles.push(r.bytes()); break;
+            case 6: m.published = r.bool(); break;
+            case 7: m.scale = r.double(); break;
+            case 8: m.shape = r.int32(); break;
+            case 9: r.repeated(tag, () => m.shapes.push(r.int32())); break;
+            case 10: m.layer = ProtobufToDrawingLayer(r.bytes()); break;
+            case 11: m.layers.push(ProtobufToDrawingLayer(r.bytes())); break;
+            case 12: r.entry("", ProtobufToDrawingLayer(new Uint8Array(0)), (r) => r.string(), (r) => ProtobufToDrawingLayer(r.bytes()), (k, v) => m.namedLayers[k] = v); break;
+            case 13: r.entry(0, "", (r) => r.int32(), (r) => r.string(), (k, v) => m.labels[k] = v); break;
+            case 14: r.entry("false", 0, (r) => String(r.bool()), (r) => r.int32(), (k, v) => m.flags[k] = v); break;
+            case 15: m.opacity = r.int32(); break;
+            case 16: m.caption = r.string(); break;
+            case 19: m.scalars = ProtobufToScalars(r.bytes()); break;
+            case 17: m.content = {kind: "text", value: r.string()}; break;
+            case 18: m.content = {kind: "image", value: ProtobufToImage(r.bytes())}; break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isDrawing reports if a value has the fields of a Drawing, e.g. to check data read from a cache or a websocket.
+export const isDrawing = (value: unknown): value is Drawing => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.title === "string"
+        && typeof m.id === "number"
+        && everyItem(m.revisions, (v) => typeof v === "number")
+        && m.thumbnail instanceof Uint8Array
+        && everyItem(m.tiles, (v) => v instanceof Uint8Array)
+        && typeof m.published === "boolean"
+        && typeof m.scale === "number"
+        && typeof m.shape === "number"
+        && everyItem(m.shapes, (v) => typeof v === "number")
+        && isDrawingLayer(m.layer)
+        && everyItem(m.layers, isDrawingLayer)
+        && everyValue(m.namedLayers, isDrawingLayer)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyValue(m.flags, (v) => typeof v === "number")
+        && (m.opacity === undefined || typeof m.opacity === "number")
+        && (m.caption === undefined || typeof m.caption === "string")
+        && isScalars(m.scalars)
+        && (m.content === undefined || oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage}));
+};
+
+/** Layer is the position of a Drawing in a Canvas. */
+export interface DrawingLayer {
+    index: number;
+    blend: DrawingLayerBlend;
+}
+
+// DrawingLayerFields are the numbers and proto types of the fields of DrawingLayer by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingLayerFields = {
+    index: {number: 1, name: "index", type: "int32"},
+    blend: {number: 2, name: "blend", type: "enum"},
+} as const;
+
+export interface DrawingLayerJSON {
+    index: number;
+    blend: string | number;
+}
+
+export const DrawingLayerToProtobuf = (m: DrawingLayer): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.index) { w.tag(1, 0).int32(m.index); }
+    if (m.blend) { w.tag(2, 0).int32(m.blend); }
+
+    return w.finish();
+};
+
+export const ProtobufToDrawingLayer = (b: Uint8Array): DrawingLayer => {
+    const r = new ProtobufReader(b);
+    const m = {index: 0, blend: 0} as DrawingLayer;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.index = r.int32(); break;
+            case 2: m.blend = r.int32(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isDrawingLayer reports if a value has the fields of a DrawingLayer, e.g. to check data read from a cache or a websocket.
+export const isDrawingLayer = (value: unknown): value is DrawingLayer => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.index === "number"
+        && typeof m.blend === "number";
+};
+
+/** Scalars has a field of each scalar type. */
+export interface Scalars {
+    doubleValue: number;
+    floatValue: number;
+    int32Value: number;
+    int64Value: number;
+    uint32Value: number;
+    uint64Value: number;
+    sint32Value: number;
+    sint64Value: number;
+    fixed32Value: number;
+    fixed64Value: number;
+    sfixed32Value: number;
+    sfixed64Value: number;
+    boolValue: boolean;
+    stringValue: string;
+    bytesValue: Uint8Array;
+    floatValues: number[];
+    sint32Values: number[];
+}
+
+// ScalarsFields are the numbers and proto types of the fields of Scalars by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ScalarsFields = {
+    doubleValue: {number: 1, name: "double_value", type: "double"},
+    floatValue: {number: 2, name: "float_value", type: "float"},
+    int32Value: {number: 3, name: "int32_value", type: "int32"},
+    int64Value: {number: 4, name: "int64_value", type: "int64"},
+    uint32Value: {number: 5, name: "uint32_value", type: "uint32"},
+    uint64Value: {number: 6, name: "uint64_value", type: "uint64"},
+    sint32Value: {number: 7, name: "sint32_value", type: "sint32"},
+    sint64Value: {number: 8, name: "sint64_value", type: "sint64"},
+    fixed32Value: {number: 9, name: "fixed32_value", type: "fixed32"},
+    fixed64Value: {number: 10, name: "fixed64_value", type: "fixed64"},
+    sfixed32Value: {number: 11, name: "sfixed32_value", type: "sfixed32"},
+    sfixed64Value: {number: 12, name: "sfixed64_value", type: "sfixed64"},
+    boolValue: {number: 13, name: "bool_value", type: "bool"},
+    stringValue: {number: 14, name: "string_value", type: "string"},
+    bytesValue: {number: 15, name: "bytes_value", type: "bytes"},
+    floatValues: {number: 16, name: "float_values", type: "float"},
+    sint32Values: {number: 17, name: "sint32_values", type: "sint32"},
+} as const;
+
+export interface ScalarsJSON {
+    double_value: number | string;
+    float_value: number | string;
+    int32_value: number;
+    int64_value: string;
+    uint32_value: number;
+    uint64_value: string;
+    sint32_value: number;
+    sint64_value: string;
+    fixed32_value: number;
+    fixed64_value: string;
+    sfixed32_value: number;
+    sfixed64_value: string;
+    bool_value: boolean;
+    string_value: string;
+    bytes_value: string;
+    float_values: (number | string)[];
+    sint32_values: number[];
+}
+
+export const ScalarsToProtobuf = (m: Scalars): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.doubleValue !== 0) { w.tag(1, 1).double(m.doubleValue); }
+    if (m.floatValue !== 0) { w.tag(2, 5).float(m.floatValue); }
+    if (m.int32Value) { w.tag(3, 0).int32(m.int32Value); }
+    if (m.int64Value) { w.tag(4, 0).int64(String(m.int64Value)); }
+    if (m.uint32Value) { w.tag(5, 0).uint32(m.uint32Value); }
+    if (m.uint64Value) { w.tag(6, 0).uint64(String(m.uint64Value)); }
+    if (m.sint32Value) { w.tag(7, 0).sint32(m.sint32Value); }
+    if (m.sint64Value) { w.tag(8, 0).sint64(String(m.sint64Value)); }
+    if (m.fixed32Value) { w.tag(9, 5).fixed32(m.fixed32Value); }
+    if (m.fixed64Value) { w.tag(10, 1).fixed64(String(m.fixed64Value)); }
+    if (m.sfixed32Value) { w.tag(11, 5).sfixed32(m.sfixed32Value); }
+    if (m.sfixed64Value) { w.tag(12, 1).sfixed64(String(m.sfixed64Value)); }
+    if (m.boolValue) { w.tag(13, 0).bool(m.boolValue); }
+    if (m.stringValue) { w.tag(14, 2).string(m.stringValue); }
+    if (m.bytesValue && m.bytesValue.length) { w.tag(15, 2).bytes(m.bytesValue); }
+    if (m.floatValues.length) { w.tag(16, 2).packed(m.floatValues, (w, v) => w.float(v)); }
+    if (m.sint32Values.length) { w.tag(17, 2).packed(m.sint32Values, (w, v) => w.sint32(v)); }
+
+    return w.finish();
+};
+
+export const ProtobufToScalars = (b: Uint8Array): Scalars => {
+    const r = new ProtobufReader(b);
+    const m = {doubleValue: 0, floatValue: 0, int32Value: 0, int64Value: 0, uint32Value: 0, uint64Value: 0, sint32Value: 0, sint64Value: 0, fixed32Value: 0, fixed64Value: 0, sfixed32Value: 0, sfixed64Value: 0, boolValue: false, stringValue: "", bytesValue: new Uint8Array(0), floatValues: [], sint32Values: []} as Scalars;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.doubleValue = r.double(); break;
+            case 2: m.floatValue = r.float(); break;
+            case 3: m.int32Value = r.int32(); break;
+            case 4: m.int64Value = Number(r.int64()); break;
+            case 5: m.uint32Value = r.uint32(); break;
+            case 6: m.uint64Value = Number(r.uint64()); break;
+            case 7: m.sint32Value = r.sint32(); break;
+            case 8: m.sint64Value = Number(r.sint64()); break;
+            case 9: m.fixed32Value = r.fixed32(); break;
+            case 10: m.fixed64Value = Number(r.fixed64()); break;
+            case 11: m.sfixed32Value = r.sfixed32(); break;
+            case 12: m.sfixed64Value = Number(r.sfixed64()); break;
+            case 13: m.boolValue = r.bool(); break;
+            case 14: m.stringValue = r.string(); break;
+            case 15: m.bytesValue = r.bytes(); break;
+            case 16: r.repeated(tag, () => m.floatValues.push(r.float())); break;
+            case 17: r.repeated(tag, () => m.sint32Values.push(r.sint32())); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isScalars reports if a value has the fields of a Scalars, e.g. to check data read from a cache or a websocket.
+export const isScalars = (value: unknown): value is Scalars => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.doubleValue === "number"
+        && typeof m.floatValue === "number"
+        && typeof m.int32Value === "number"
+        && typeof m.int64Value === "number"
+        && typeof m.uint32Value === "number"
+        && typeof m.uint64Value === "number"
+        && typeof m.sint32Value === "number"
+        && typeof m.sint64Value === "number"
+        && typeof m.fixed32Value === "number"
+        && typeof m.fixed64Value === "number"
+        && typeof m.sfixed32Value === "number"
+        && typeof m.sfixed64Value === "number"
+        && typeof m.boolValue === "boolean"
+        && typeof m.stringValue === "string"
+        && m.bytesValue instanceof Uint8Array
+        && everyItem(m.floatValues, (v) => typeof v === "number")
+        && everyItem(m.sint32Values, (v) => typeof v === "number");
+};
+
+export interface Image {
+    url: string;
+    width: number;
+    height: number;
+}
+
+// ImageFields are the numbers and proto types of the fields of Image by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImageFields = {
+    url: {number: 1, name: "url", type: "string"},
+    width: {number: 2, name: "width", type: "int32"},
+    height: {number: 3, name: "height", type: "int32"},
+} as const;
+
+export interface ImageJSON {
+    url: string;
+    width: number;
+    height: number;
+}
+
+export const ImageToProtobuf = (m: Image): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.url) { w.tag(1, 2).string(m.url); }
+    if (m.width) { w.tag(2, 0).int32(m.width); }
+    if (m.height) { w.tag(3, 0).int32(m.height); }
+
+    return w.finish();
+};
+
+export const ProtobufToImage = (b: Uint8Array): Image => {
+    const r = new ProtobufReader(b);
+    const m = {url: "", width: 0, height: 0} as Image;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.url = r.string(); break;
+            case 2: m.width = r.int32(); break;
+            case 3: m.height = r.int32(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isImage reports if a value has the fields of a Image, e.g. to check data read from a cache or a websocket.
+export const isImage = (value: unknown): value is Image => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.url === "string"
+        && typeof m.width === "number"
+        && typeof m.height === "number";
+};
+
+/** A Group is a tree of drawings. */
+export interface Group {
+    name: string;
+    parent?: Group | undefined;
+    children: Group[];
+    drawings: Drawing[];
+}
+
+// GroupFields are the numbers and proto types of the fields of Group by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GroupFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    children: {number: 3, name: "children", type: "message"},
+    drawings: {number: 4, name: "drawings", type: "message"},
+} as const;
+
+export interface GroupJSON {
+    name: string;
+    parent?: GroupJSON;
+    children: GroupJSON[];
+    drawings: DrawingJSON[];
+}
+
+export const GroupToProtobuf = (m: Group): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.name) { w.tag(1, 2).string(m.name); }
+    if (m.parent !== undefined) { w.tag(2, 2).bytes(GroupToProtobuf(m.parent)); }
+    m.children.forEach((v) => w.tag(3, 2).bytes(GroupToProtobuf(v)));
+    m.drawings.forEach((v) => w.tag(4, 2).bytes(DrawingToProtobuf(v)));
+
+    return w.finish();
+};
+
+export const ProtobufToGroup = (b: Uint8Array): Group => {
+    const r = new ProtobufReader(b);
+    const m = {name: "", children: [], drawings: []} as Group;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.name = r.string(); break;
+            case 2: m.parent = ProtobufToGroup(r.bytes()); break;
+            case 3: m.children.push(ProtobufToGroup(r.bytes())); break;
+            case 4: m.drawings.push(ProtobufToDrawing(r.bytes())); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isGroup reports if a value has the fields of a Group, e.g. to check data read from a cache or a websocket.
+export const isGroup = (value: unknown): value is Group => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isGroup(m.parent))
+        && everyItem(m.children, isGroup)
+        && everyItem(m.drawings, isDrawing);
+};
+
+export interface GetDrawingRequest {
+    id: number;
+}
+
+// GetDrawingRequestFields are the numbers and proto types of the fields of GetDrawingRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetDrawingRequestFields = {
+    id: {number: 1, name: "id", type: "int64"},
+} as const;
+
+export interface GetDrawingRequestJSON {
+    id: string;
+}
+
+export const GetDrawingRequestToProtobuf = (m: GetDrawingRequest): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.id) { w.tag(1, 0).int64(String(m.id)); }
+
+    return w.finish();
+};
+
+export const ProtobufToGetDrawingRequest = (b: Uint8Array): GetDrawingRequest => {
+    const r = new ProtobufReader(b);
+    const m = {id: 0} as GetDrawingRequest;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.id = Number(r.int64()); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isGetDrawingRequest reports if a value has the fields of a GetDrawingRequest, e.g. to check data read from a cache or a websocket.
+export const isGetDrawingRequest = (value: unknown): value is GetDrawingRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "number";
+};
@@ -0,0 +1,50 @@
+import {PactInteraction, PactInteractionOptions, pactInteraction, withOverrides} from './twirp_pact';
+import {Drawing, DrawingLayer, DrawingToJSON, GetDrawingRequest, GetDrawingRequestToJSON, Group, GroupToJSON, Image, Scalars} from './features';
+
+// exampleDrawing is an example of the Drawing message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleDrawing = (overrides: Partial<Drawing> = {}): Drawing => {
+    return withOverrides<Drawing>({title: "", id: 0, revisions: [], thumbnail: new Uint8Array(0), tiles: [], published: false, scale: 0, shape: 0, shapes: [], layer: exampleDrawingLayer(), layers: [], namedLayers: {}, labels: {}, flags: {}, scalars: exampleScalars()}, overrides);
+};
+
+// exampleDrawingLayer is an example of the DrawingLayer message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleDrawingLayer = (overrides: Partial<DrawingLayer> = {}): DrawingLayer => {
+    return withOverrides<DrawingLayer>({index: 0, blend: 0}, overrides);
+};
+
+// exampleScalars is an example of the Scalars message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleScalars = (overrides: Partial<Scalars> = {}): Scalars => {
+    return withOverrides<Scalars>({doubleValue: 0, floatValue: 0, int32Value: 0, int64Value: 0, uint32Value: 0, uint64Value: 0, sint32Value: 0, sint64Value: 0, fixed32Value: 0, fixed64Value: 0, sfixed32Value: 0, sfixed64Value: 0, boolValue: false, stringValue: "", bytesValue: new Uint8Array(0), floatValues: [], sint32Values: []}, overrides);
+};
+
+// exampleImage is an example of the Image message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleImage = (overrides: Partial<Image> = {}): Image => {
+    return withOverrides<Image>({url: "", width: 0, height: 0}, overrides);
+};
+
+// exampleGroup is an example of the Group message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleGroup = (overrides: Partial<Group> = {}): Group => {
+    return withOverrides<Group>({name: "", children: [], drawings: []}, overrides);
+};
+
+// exampleGetDrawingRequest is an example of the GetDrawingRequest message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleGetDrawingRequest = (overrides: Partial<GetDrawingRequest> = {}): GetDrawingRequest => {
+    return withOverrides<GetDrawingRequest>({id: 0}, overrides);
+};
+
+// getDrawingInteraction is a pact interaction of Canvas.GetDrawing, e.g.
+// provider.addInteraction(getDrawingInteraction({request: exampleGetDrawingRequest(), response: exampleDrawing()}))
+export const getDrawingInteraction = (options: PactInteractionOptions<GetDrawingRequest, Drawing>): PactInteraction => {
+    return pactInteraction("/twirp/features.v1.Canvas/GetDrawing", "a request to Canvas.GetDrawing", options, GetDrawingRequestToJSON, DrawingToJSON);
+};
+
+// saveGroupInteraction is a pact interaction of Canvas.SaveGroup, e.g.
+// provider.addInteraction(saveGroupInteraction({request: exampleGroup(), response: exampleGroup()}))
+export const saveGroupInteraction = (options: PactInteractionOptions<Group, Group>): PactInteraction => {
+    return pactInteraction("/twirp/features.v1.Canvas/SaveGroup", "a request to Canvas.SaveGroup", options, GroupToJSON, GroupToJSON);
+};
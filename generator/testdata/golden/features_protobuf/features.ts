@@ -0,0 +1,704 @@
+import {createTwirpProtobufRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, ProtobufReader, ProtobufWriter, everyItem, everyValue, oneofMember} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** Shape is the kind of a Drawing. */
+export enum Shape {
+    SHAPE_UNSPECIFIED = 0,
+    SHAPE_CIRCLE = 1,
+    SHAPE_SQUARE = 2,
+}
+
+export enum DrawingLayerBlend {
+    BLEND_NORMAL = 0,
+    BLEND_MULTIPLY = 1,
+}
+
+/** The content of a Drawing is either text or an image. */
+export type DrawingContent =
+    | {kind: "text"; value: string}
+    | {kind: "image"; value: Image};
+
+/** A Drawing uses every kind of field that is supported by the generator. */
+export interface Drawing {
+    title: string;
+    id: bigint;
+    revisions: bigint[];
+    thumbnail: Uint8Array;
+    tiles: Uint8Array[];
+    published: boolean;
+    scale: number;
+    shape: Shape;
+    shapes: Shape[];
+    layer: DrawingLayer;
+    layers: DrawingLayer[];
+    namedLayers: {[key: string]: DrawingLayer};
+    labels: {[key: number]: string};
+    flags: {[key: string]: Shape};
+    opacity?: number | undefined;
+    caption?: string | undefined;
+    scalars: Scalars;
+    /** The content of a Drawing is either text or an image. */
+    content?: DrawingContent;
+}
+
+// DrawingFields are the numbers and proto types of the fields of Drawing by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingFields = {
+    title: {number: 1, name: "title", type: "string"},
+    id: {number: 2, name: "id", type: "int64"},
+    revisions: {number: 3, name: "revisions", type: "uint64"},
+    thumbnail: {number: 4, name: "thumbnail", type: "bytes"},
+    tiles: {number: 5, name: "tiles", type: "bytes"},
+    published: {number: 6, name: "published", type: "bool"},
+    scale: {number: 7, name: "scale", type: "double"},
+    shape: {number: 8, name: "shape", type: "enum"},
+    shapes: {number: 9, name: "shapes", type: "enum"},
+    layer: {number: 10, name: "layer", type: "message"},
+    layers: {number: 11, name: "layers", type: "message"},
+    namedLayers: {number: 12, name: "named_layers", type: "map"},
+    labels: {number: 13, name: "labels", type: "map"},
+    flags: {number: 14, name: "flags", type: "map"},
+    opacity: {number: 15, name: "opacity", type: "int32"},
+    caption: {number: 16, name: "caption", type: "string"},
+    scalars: {number: 19, name: "scalars", type: "message"},
+    text: {number: 17, name: "text", type: "string"},
+    image: {number: 18, name: "image", type: "message"},
+} as const;
+
+export interface DrawingJSON {
+    title: string;
+    id: string;
+    revisions: string[];
+    thumbnail: string;
+    tiles: string[];
+    published: boolean;
+    scale: number | string;
+    shape: string | number;
+    shapes: (string | number)[];
+    layer: DrawingLayerJSON;
+    layers: DrawingLayerJSON[];
+    named_layers: {[key: string]: DrawingLayerJSON};
+    labels: {[key: string]: string};
+    flags: {[key: string]: string | number};
+    opacity?: number;
+    caption?: string;
+    scalars: ScalarsJSON;
+    text?: string;
+    image?: ImageJSON;
+}
+
+export const DrawingToProtobuf = (m: Drawing): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.title) { w.tag(1, 2).string(m.title); }
+    if (m.id) { w.tag(2, 0).int64(m.id.toString()); }
+    if (m.revisions.length) { w.tag(3, 2).packed(m.revisions, (w, v) => w.uint64(v.toString())); }
+    if (m.thumbnail && m.thumbnail.length) { w.tag(4, 2).bytes(m.thumbnail); }
+    m.tiles.forEach((v) => w.tag(5, 2).bytes(v));
+    if (m.published) { w.tag(6, 0).bool(m.published); }
+    if (m.scale !== 0) { w.tag(7, 1).double(m.scale); }
+    if (m.shape) { w.tag(8, 0).int32(m.shape); }
+    if (m.shapes.length) { w.tag(9, 2).packed(m.shapes, (w, v) => w.int32(v)); }
+    if (m.layer) { w.tag(10, 2).bytes(DrawingLayerToProtobuf(m.layer)); }
+    m.layers.forEach((v) => w.tag(11, 2).bytes(DrawingLayerToProtobuf(v)));
+    Object.keys(m.namedLayers).forEach((k) => w.tag(12, 2).message((w) => { w.tag(1, 2).string(k); w.tag(2, 2).bytes(DrawingLayerToProtobuf(m.namedLayers[k])); }));
+    Object.keys(m.labels).map(Number).forEach((k) => w.tag(13, 2).message((w) => { w.tag(1, 0).int32(k); w.tag(2, 2).string(m.labels[k]); }));
+    Object.keys(m.flags).forEach((k) => w.tag(14, 2).message((w) => { w.tag(1, 0).bool(k === "true"); w.tag(2, 0).int32(m.flags[k]); }));
+    if (m.opacity !== undefined) { w.tag(15, 0).int32(m.opacity); }
+    if (m.caption !== undefined) { w.tag(16, 2).string(m.caption); }
+    if (m.scalars) { w.tag(19, 2).bytes(ScalarsToProtobuf(m.scalars)); }
+    if (m.content && m.content.kind === "text") { w.tag(17, 2).string(m.content.value); }
+    if (m.content && m.content.kind === "image") { w.tag(18, 2).bytes(ImageToProtobuf(m.content.value)); }
+
+    return w.finish();
+};
+
+export const ProtobufToDrawing = (b: Uint8Array): Drawing => {
+    const r = new ProtobufReader(b);
+    const m = {title: "", id: BigInt(0), revisions: [], thumbnail: new Uint8Array(0), tiles: [], published: false, scale: 0, shape: 0, shapes: [], layers: [], namedLayers: {}, labels: {}, flags: {}} as Drawing;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.title = r.string(); break;
+            case 2: m.id = BigInt(r.int64()); break;
+            case 3: r.repeated(tag, () => m.revisions.push(BigInt(r.uint64()))); break;
+            case 4: m.thumbnail = r.bytes(); break;
+            case 5: m.tiles.push(r.bytes()); break;
+            case 6: m.published = r.bool(); break;
+            case 7: m.scale = r.double(); break;
+            case 8: m.shape = r.int32(); break;
+            case 9: r.repeated(tag, () => m.shapes.push(r.int32())); break;
+            case 10: m.layer = ProtobufToDrawingLayer(r.bytes()); break;
+            case 11: m.layers.push(ProtobufToDrawingLayer(r.bytes())); break;
+            case 12: r.entry("", ProtobufToDrawingLayer(new Uint8Array(0)), (r) => r.string(), (r) => ProtobufToDrawingLayer(r.bytes()), (k, v) => m.namedLayers[k] = v); break;
+            case 13: r.entry(0, "", (r) => r.int32(), (r) => r.string(), (k, v) => m.labels[k] = v); break;
+            case 14: r.entry("false", 0, (r) => String(r.bool()), (r) => r.int32(), (k, v) => m.flags[k] = v); break;
+            case 15: m.opacity = r.int32(); break;
+            case 16: m.caption = r.string(); break;
+            case 19: m.scalars = ProtobufToScalars(r.bytes()); break;
+            case 17: m.content = {kind: "text", value: r.string()}; break;
+            case 18: m.content = {kind: "image", value: ProtobufToImage(r.bytes())}; break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isDrawing reports if a value has the fields of a Drawing, e.g. to check data read from a cache or a websocket.
+export const isDrawing = (value: unknown): value is Drawing => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.title === "string"
+        && typeof m.id === "bigint"
+        && everyItem(m.revisions, (v) => typeof v === "bigint")
+        && m.thumbnail instanceof Uint8Array
+        && everyItem(m.tiles, (v) => v instanceof Uint8Array)
+        && typeof m.published === "boolean"
+        && typeof m.scale === "number"
+        && typeof m.shape === "number"
+        && everyItem(m.shapes, (v) => typeof v === "number")
+        && isDrawingLayer(m.layer)
+        && everyItem(m.layers, isDrawingLayer)
+        && everyValue(m.namedLayers, isDrawingLayer)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyValue(m.flags, (v) => typeof v === "number")
+        && (m.opacity === undefined || typeof m.opacity === "number")
+        && (m.caption === undefined || typeof m.caption === "string")
+        && isScalars(m.scalars)
+        && (m.content === undefined || oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage}));
+};
+
+/** Layer is the position of a Drawing in a Canvas. */
+export interface DrawingLayer {
+    index: number;
+    blend: DrawingLayerBlend;
+}
+
+// DrawingLayerFields are the numbers and proto types of the fields of DrawingLayer by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DrawingLayerFields = {
+    index: {number: 1, name: "index", type: "int32"},
+    blend: {number: 2, name: "blend", type: "enum"},
+} as const;
+
+export interface DrawingLayerJSON {
+    index: number;
+    blend: string | number;
+}
+
+export const DrawingLayerToProtobuf = (m: DrawingLayer): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.index) { w.tag(1, 0).int32(m.index); }
+    if (m.blend) { w.tag(2, 0).int32(m.blend); }
+
+    return w.finish();
+};
+
+export const ProtobufToDrawingLayer = (b: Uint8Array): DrawingLayer => {
+    const r = new ProtobufReader(b);
+    const m = {index: 0, blend: 0} as DrawingLayer;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.index = r.int32(); break;
+            case 2: m.blend = r.int32(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isDrawingLayer reports if a value has the fields of a DrawingLayer, e.g. to check data read from a cache or a websocket.
+export const isDrawingLayer = (value: unknown): value is DrawingLayer => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.index === "number"
+        && typeof m.blend === "number";
+};
+
+/** Scalars has a field of each scalar type. */
+export interface Scalars {
+    doubleValue: number;
+    floatValue: number;
+    int32Value: number;
+    int64Value: bigint;
+    uint32Value: number;
+    uint64Value: bigint;
+    sint32Value: number;
+    sint64Value: bigint;
+    fixed32Value: number;
+    fixed64Value: bigint;
+    sfixed32Value: number;
+    sfixed64Value: bigint;
+    boolValue: boolean;
+    stringValue: string;
+    bytesValue: Uint8Array;
+    floatValues: number[];
+    sint32Values: number[];
+}
+
+// ScalarsFields are the numbers and proto types of the fields of Scalars by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ScalarsFields = {
+    doubleValue: {number: 1, name: "double_value", type: "double"},
+    floatValue: {number: 2, name: "float_value", type: "float"},
+    int32Value: {number: 3, name: "int32_value", type: "int32"},
+    int64Value: {number: 4, name: "int64_value", type: "int64"},
+    uint32Value: {number: 5, name: "uint32_value", type: "uint32"},
+    uint64Value: {number: 6, name: "uint64_value", type: "uint64"},
+    sint32Value: {number: 7, name: "sint32_value", type: "sint32"},
+    sint64Value: {number: 8, name: "sint64_value", type: "sint64"},
+    fixed32Value: {number: 9, name: "fixed32_value", type: "fixed32"},
+    fixed64Value: {number: 10, name: "fixed64_value", type: "fixed64"},
+    sfixed32Value: {number: 11, name: "sfixed32_value", type: "sfixed32"},
+    sfixed64Value: {number: 12, name: "sfixed64_value", type: "sfixed64"},
+    boolValue: {number: 13, name: "bool_value", type: "bool"},
+    stringValue: {number: 14, name: "string_value", type: "string"},
+    bytesValue: {number: 15, name: "bytes_value", type: "bytes"},
+    floatValues: {number: 16, name: "float_values", type: "float"},
+    sint32Values: {number: 17, name: "sint32_values", type: "sint32"},
+} as const;
+
+export interface ScalarsJSON {
+    double_value: number | string;
+    float_value: number | string;
+    int32_value: number;
+    int64_value: string;
+    uint32_value: number;
+    uint64_value: string;
+    sint32_value: number;
+    sint64_value: string;
+    fixed32_value: number;
+    fixed64_value: string;
+    sfixed32_value: number;
+    sfixed64_value: string;
+    bool_value: boolean;
+    string_value: string;
+    bytes_value: string;
+    float_values: (number | string)[];
+    sint32_values: number[];
+}
+
+export const ScalarsToProtobuf = (m: Scalars): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.doubleValue !== 0) { w.tag(1, 1).double(m.doubleValue); }
+    if (m.floatValue !== 0) { w.tag(2, 5).float(m.floatValue); }
+    if (m.int32Value) { w.tag(3, 0).int32(m.int32Value); }
+    if (m.int64Value) { w.tag(4, 0).int64(m.int64Value.toString()); }
+    if (m.uint32Value) { w.tag(5, 0).uint32(m.uint32Value); }
+    if (m.uint64Value) { w.tag(6, 0).uint64(m.uint64Value.toString()); }
+    if (m.sint32Value) { w.tag(7, 0).sint32(m.sint32Value); }
+    if (m.sint64Value) { w.tag(8, 0).sint64(m.sint64Value.toString()); }
+    if (m.fixed32Value) { w.tag(9, 5).fixed32(m.fixed32Value); }
+    if (m.fixed64Value) { w.tag(10, 1).fixed64(m.fixed64Value.toString()); }
+    if (m.sfixed32Value) { w.tag(11, 5).sfixed32(m.sfixed32Value); }
+    if (m.sfixed64Value) { w.tag(12, 1).sfixed64(m.sfixed64Value.toString()); }
+    if (m.boolValue) { w.tag(13, 0).bool(m.boolValue); }
+    if (m.stringValue) { w.tag(14, 2).string(m.stringValue); }
+    if (m.bytesValue && m.bytesValue.length) { w.tag(15, 2).bytes(m.bytesValue); }
+    if (m.floatValues.length) { w.tag(16, 2).packed(m.floatValues, (w, v) => w.float(v)); }
+    if (m.sint32Values.length) { w.tag(17, 2).packed(m.sint32Values, (w, v) => w.sint32(v)); }
+
+    return w.finish();
+};
+
+export const ProtobufToScalars = (b: Uint8Array): Scalars => {
+    const r = new ProtobufReader(b);
+    const m = {doubleValue: 0, floatValue: 0, int32Value: 0, int64Value: BigInt(0), uint32Value: 0, uint64Value: BigInt(0), sint32Value: 0, sint64Value: BigInt(0), fixed32Value: 0, fixed64Value: BigInt(0), sfixed32Value: 0, sfixed64Value: BigInt(0), boolValue: false, stringValue: "", bytesValue: new Uint8Array(0), floatValues: [], sint32Values: []} as Scalars;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.doubleValue = r.double(); break;
+            case 2: m.floatValue = r.float(); break;
+            case 3: m.int32Value = r.int32(); break;
+            case 4: m.int64Value = BigInt(r.int64()); break;
+            case 5: m.uint32Value = r.uint32(); break;
+            case 6: m.uint64Value = BigInt(r.uint64()); break;
+            case 7: m.sint32Value = r.sint32(); break;
+            case 8: m.sint64Value = BigInt(r.sint64()); break;
+            case 9: m.fixed32Value = r.fixed32(); break;
+            case 10: m.fixed64Value = BigInt(r.fixed64()); break;
+            case 11: m.sfixed32Value = r.sfixed32(); break;
+            case 12: m.sfixed64Value = BigInt(r.sfixed64()); break;
+            case 13: m.boolValue = r.bool(); break;
+            case 14: m.stringValue = r.string(); break;
+            case 15: m.bytesValue = r.bytes(); break;
+            case 16: r.repeated(tag, () => m.floatValues.push(r.float())); break;
+            case 17: r.repeated(tag, () => m.sint32Values.push(r.sint32())); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isScalars reports if a value has the fields of a Scalars, e.g. to check data read from a cache or a websocket.
+export const isScalars = (value: unknown): value is Scalars => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.doubleValue === "number"
+        && typeof m.floatValue === "number"
+        && typeof m.int32Value === "number"
+        && typeof m.int64Value === "bigint"
+        && typeof m.uint32Value === "number"
+        && typeof m.uint64Value === "bigint"
+        && typeof m.sint32Value === "number"
+        && typeof m.sint64Value === "bigint"
+        && typeof m.fixed32Value === "number"
+        && typeof m.fixed64Value === "bigint"
+        && typeof m.sfixed32Value === "number"
+        && typeof m.sfixed64Value === "bigint"
+        && typeof m.boolValue === "boolean"
+        && typeof m.stringValue === "string"
+        && m.bytesValue instanceof Uint8Array
+        && everyItem(m.floatValues, (v) => typeof v === "number")
+        && everyItem(m.sint32Values, (v) => typeof v === "number");
+};
+
+export interface Image {
+    url: string;
+    width: number;
+    height: number;
+}
+
+// ImageFields are the numbers and proto types of the fields of Image by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImageFields = {
+    url: {number: 1, name: "url", type: "string"},
+    width: {number: 2, name: "width", type: "int32"},
+    height: {number: 3, name: "height", type: "int32"},
+} as const;
+
+export interface ImageJSON {
+    url: string;
+    width: number;
+    height: number;
+}
+
+export const ImageToProtobuf = (m: Image): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.url) { w.tag(1, 2).string(m.url); }
+    if (m.width) { w.tag(2, 0).int32(m.width); }
+    if (m.height) { w.tag(3, 0).int32(m.height); }
+
+    return w.finish();
+};
+
+export const ProtobufToImage = (b: Uint8Array): Image => {
+    const r = new ProtobufReader(b);
+    const m = {url: "", width: 0, height: 0} as Image;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.url = r.string(); break;
+            case 2: m.width = r.int32(); break;
+            case 3: m.height = r.int32(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isImage reports if a value has the fields of a Image, e.g. to check data read from a cache or a websocket.
+export const isImage = (value: unknown): value is Image => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.url === "string"
+        && typeof m.width === "number"
+        && typeof m.height === "number";
+};
+
+/** A Group is a tree of drawings. */
+export interface Group {
+    name: string;
+    parent?: Group | undefined;
+    children: Group[];
+    drawings: Drawing[];
+}
+
+// GroupFields are the numbers and proto types of the fields of Group by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GroupFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    children: {number: 3, name: "children", type: "message"},
+    drawings: {number: 4, name: "drawings", type: "message"},
+} as const;
+
+export interface GroupJSON {
+    name: string;
+    parent?: GroupJSON;
+    children: GroupJSON[];
+    drawings: DrawingJSON[];
+}
+
+export const GroupToProtobuf = (m: Group): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.name) { w.tag(1, 2).string(m.name); }
+    if (m.parent !== undefined) { w.tag(2, 2).bytes(GroupToProtobuf(m.parent)); }
+    m.children.forEach((v) => w.tag(3, 2).bytes(GroupToProtobuf(v)));
+    m.drawings.forEach((v) => w.tag(4, 2).bytes(DrawingToProtobuf(v)));
+
+    return w.finish();
+};
+
+export const ProtobufToGroup = (b: Uint8Array): Group => {
+    const r = new ProtobufReader(b);
+    const m = {name: "", children: [], drawings: []} as Group;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.name = r.string(); break;
+            case 2: m.parent = ProtobufToGroup(r.bytes()); break;
+            case 3: m.children.push(ProtobufToGroup(r.bytes())); break;
+            case 4: m.drawings.push(ProtobufToDrawing(r.bytes())); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isGroup reports if a value has the fields of a Group, e.g. to check data read from a cache or a websocket.
+export const isGroup = (value: unknown): value is Group => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isGroup(m.parent))
+        && everyItem(m.children, isGroup)
+        && everyItem(m.drawings, isDrawing);
+};
+
+export interface GetDrawingRequest {
+    id: bigint;
+}
+
+// GetDrawingRequestFields are the numbers and proto types of the fields of GetDrawingRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetDrawingRequestFields = {
+    id: {number: 1, name: "id", type: "int64"},
+} as const;
+
+export interface GetDrawingRequestJSON {
+    id: string;
+}
+
+export const GetDrawingRequestToProtobuf = (m: GetDrawingRequest): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.id) { w.tag(1, 0).int64(m.id.toString()); }
+
+    return w.finish();
+};
+
+// isGetDrawingRequest reports if a value has the fields of a GetDrawingRequest, e.g. to check data read from a cache or a websocket.
+export const isGetDrawingRequest = (value: unknown): value is GetDrawingRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "bigint";
+};
+
+/** Canvas stores drawings. */
+export interface Canvas {
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing: (getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Promise<Drawing>;
+
+    saveGroup: (group: Group, callOptions?: CallOptions) => Promise<Group>;
+}
+
+// CanvasMethods are the Twirp routes of the methods of Canvas, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const CanvasMethods = {
+    getDrawing: {
+        service: "features.v1.Canvas",
+        method: "GetDrawing",
+        path: "/twirp/features.v1.Canvas/GetDrawing",
+        inputType: "GetDrawingRequest",
+        outputType: "Drawing",
+    },
+    saveGroup: {
+        service: "features.v1.Canvas",
+        method: "SaveGroup",
+        path: "/twirp/features.v1.Canvas/SaveGroup",
+        inputType: "Group",
+        outputType: "Group",
+    },
+} as const;
+
+/** Canvas stores drawings. */
+export class DefaultCanvas implements Canvas {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/features.v1.Canvas/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** GetDrawing finds a drawing by its id. */
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing> {
+        const url = joinURL(this.hostname, this.pathPrefix + "GetDrawing");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "GetDrawing",
+                url: url,
+                request: getDrawingRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, GetDrawingRequestToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToDrawing(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group> {
+        const url = joinURL(this.hostname, this.pathPrefix + "SaveGroup");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "features.v1.Canvas",
+                method: "SaveGroup",
+                url: url,
+                request: group,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, GroupToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToGroup(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+}
+
+// createCanvasClient creates a DefaultCanvas with the config, which may be shared by the clients of other services.
+export const createCanvasClient = (config: TwirpClientConfig): DefaultCanvas => {
+    return new DefaultCanvas(config);
+};
+
+// A CanvasMockResponses sets the response of each CanvasMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CanvasMockResponses {
+    getDrawing?: Drawing | ((getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions) => Drawing | Promise<Drawing>);
+    saveGroup?: Group | ((group: Group, callOptions?: CallOptions) => Group | Promise<Group>);
+}
+
+// CanvasMockClient is a Canvas for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class CanvasMockClient implements Canvas {
+    responses: CanvasMockResponses;
+
+    constructor(responses: CanvasMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    getDrawing(getDrawingRequest: GetDrawingRequest, callOptions?: CallOptions): Promise<Drawing> {
+        const response = this.responses.getDrawing;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.GetDrawing"}));
+        }
+
+        return new Promise<Drawing>((resolve) => resolve(typeof response === "function" ? response(getDrawingRequest, callOptions) : response));
+    }
+
+    saveGroup(group: Group, callOptions?: CallOptions): Promise<Group> {
+        const response = this.responses.saveGroup;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Canvas.SaveGroup"}));
+        }
+
+        return new Promise<Group>((resolve) => resolve(typeof response === "function" ? response(group, callOptions) : response));
+    }
+}
+
+export const createCanvasMock = (overrides: CanvasMockResponses = {}): CanvasMockClient => {
+    return new CanvasMockClient(overrides);
+};
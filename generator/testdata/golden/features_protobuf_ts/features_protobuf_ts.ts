@@ -0,0 +1,62 @@
+import {ProtobufTsType} from './twirp';
+import {Drawing, DrawingToJSON, JSONToDrawing, DrawingLayer, DrawingLayerToJSON, JSONToDrawingLayer, Scalars, ScalarsToJSON, JSONToScalars, Image, ImageToJSON, JSONToImage, Group, GroupToJSON, JSONToGroup, GetDrawingRequest, GetDrawingRequestToJSON, JSONToGetDrawingRequest} from './features';
+
+// DrawingToProtobufTs converts the Drawing model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Drawing
+export const DrawingToProtobufTs = <T>(type: ProtobufTsType<T>, m: Drawing): T => {
+    return type.fromJson(DrawingToJSON(m));
+};
+
+// ProtobufTsToDrawing converts a protobuf-ts message to the Drawing model, with the MessageType that protobuf-ts exports for Drawing
+export const ProtobufTsToDrawing = <T>(type: ProtobufTsType<T>, message: T): Drawing => {
+    return JSONToDrawing(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
+
+// DrawingLayerToProtobufTs converts the DrawingLayer model to a protobuf-ts message, with the MessageType that protobuf-ts exports for DrawingLayer
+export const DrawingLayerToProtobufTs = <T>(type: ProtobufTsType<T>, m: DrawingLayer): T => {
+    return type.fromJson(DrawingLayerToJSON(m));
+};
+
+// ProtobufTsToDrawingLayer converts a protobuf-ts message to the DrawingLayer model, with the MessageType that protobuf-ts exports for DrawingLayer
+export const ProtobufTsToDrawingLayer = <T>(type: ProtobufTsType<T>, message: T): DrawingLayer => {
+    return JSONToDrawingLayer(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
+
+// ScalarsToProtobufTs converts the Scalars model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Scalars
+export const ScalarsToProtobufTs = <T>(type: ProtobufTsType<T>, m: Scalars): T => {
+    return type.fromJson(ScalarsToJSON(m));
+};
+
+// ProtobufTsToScalars converts a protobuf-ts message to the Scalars model, with the MessageType that protobuf-ts exports for Scalars
+export const ProtobufTsToScalars = <T>(type: ProtobufTsType<T>, message: T): Scalars => {
+    return JSONToScalars(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
+
+// ImageToProtobufTs converts the Image model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Image
+export const ImageToProtobufTs = <T>(type: ProtobufTsType<T>, m: Image): T => {
+    return type.fromJson(ImageToJSON(m));
+};
+
+// ProtobufTsToImage converts a protobuf-ts message to the Image model, with the MessageType that protobuf-ts exports for Image
+export const ProtobufTsToImage = <T>(type: ProtobufTsType<T>, message: T): Image => {
+    return JSONToImage(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
+
+// GroupToProtobufTs converts the Group model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Group
+export const GroupToProtobufTs = <T>(type: ProtobufTsType<T>, m: Group): T => {
+    return type.fromJson(GroupToJSON(m));
+};
+
+// ProtobufTsToGroup converts a protobuf-ts message to the Group model, with the MessageType that protobuf-ts exports for Group
+export const ProtobufTsToGroup = <T>(type: ProtobufTsType<T>, message: T): Group => {
+    return JSONToGroup(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
+
+// GetDrawingRequestToProtobufTs converts the GetDrawingRequest model to a protobuf-ts message, with the MessageType that protobuf-ts exports for GetDrawingRequest
+export const GetDrawingRequestToProtobufTs = <T>(type: ProtobufTsType<T>, m: GetDrawingRequest): T => {
+    return type.fromJson(GetDrawingRequestToJSON(m));
+};
+
+// ProtobufTsToGetDrawingRequest converts a protobuf-ts message to the GetDrawingRequest model, with the MessageType that protobuf-ts exports for GetDrawingRequest
+export const ProtobufTsToGetDrawingRequest = <T>(type: ProtobufTsType<T>, message: T): GetDrawingRequest => {
+    return JSONToGetDrawingRequest(type.toJson(message, {useProtoFieldName: true, emitDefaultValues: true}));
+};
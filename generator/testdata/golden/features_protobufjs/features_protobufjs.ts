@@ -0,0 +1,165 @@
+import {mapEntries} from './twirp';
+import {Drawing, DrawingLayer, Scalars, Image, Group, GetDrawingRequest} from './features';
+
+// DrawingToProtobufJs converts the Drawing model to an object that the fromObject function of its protobuf.js message accepts
+export const DrawingToProtobufJs = (m: Drawing): {[key: string]: any} => {
+    return {
+        title: m.title,
+        id: m.id,
+        revisions: m.revisions,
+        thumbnail: m.thumbnail,
+        tiles: m.tiles,
+        published: m.published,
+        scale: m.scale,
+        shape: m.shape,
+        shapes: m.shapes,
+        layer: m.layer === undefined ? undefined : DrawingLayerToProtobufJs(m.layer),
+        layers: m.layers.map((v) => DrawingLayerToProtobufJs(v)),
+        namedLayers: mapEntries(m.namedLayers, String, (v) => DrawingLayerToProtobufJs(v)),
+        labels: m.labels,
+        flags: m.flags,
+        opacity: m.opacity,
+        caption: m.caption,
+        scalars: m.scalars === undefined ? undefined : ScalarsToProtobufJs(m.scalars),
+        text: m.content && m.content.kind === "text" ? m.content.value : undefined,
+        image: m.content && m.content.kind === "image" ? ImageToProtobufJs(m.content.value) : undefined,
+    };
+};
+
+// ProtobufJsToDrawing converts a protobuf.js Drawing message, e.g. from its decode function, to the Drawing model
+export const ProtobufJsToDrawing = (m: {[key: string]: any}): Drawing => {
+    return {
+        title: m.title,
+        id: Number(String(m.id)),
+        revisions: (m.revisions || []).map((v) => Number(String(v))),
+        thumbnail: m.thumbnail,
+        tiles: m.tiles || [],
+        published: m.published,
+        scale: m.scale,
+        shape: m.shape,
+        shapes: m.shapes || [],
+        layer: m.layer ? ProtobufJsToDrawingLayer(m.layer) : undefined,
+        layers: (m.layers || []).map((v) => ProtobufJsToDrawingLayer(v)),
+        namedLayers: mapEntries(m.namedLayers || {}, String, (v) => ProtobufJsToDrawingLayer(v)),
+        labels: mapEntries(m.labels || {}, Number, (v) => v),
+        flags: m.flags || {},
+        opacity: m.opacity === null || m.opacity === undefined ? undefined : m.opacity,
+        caption: m.caption === null || m.caption === undefined ? undefined : m.caption,
+        scalars: m.scalars ? ProtobufJsToScalars(m.scalars) : undefined,
+        content: m.text !== null && m.text !== undefined ? {kind: "text", value: m.text} : m.image !== null && m.image !== undefined ? {kind: "image", value: ProtobufJsToImage(m.image)} : undefined,
+    } as Drawing;
+};
+
+// DrawingLayerToProtobufJs converts the DrawingLayer model to an object that the fromObject function of its protobuf.js message accepts
+export const DrawingLayerToProtobufJs = (m: DrawingLayer): {[key: string]: any} => {
+    return {
+        index: m.index,
+        blend: m.blend,
+    };
+};
+
+// ProtobufJsToDrawingLayer converts a protobuf.js DrawingLayer message, e.g. from its decode function, to the DrawingLayer model
+export const ProtobufJsToDrawingLayer = (m: {[key: string]: any}): DrawingLayer => {
+    return {
+        index: m.index,
+        blend: m.blend,
+    } as DrawingLayer;
+};
+
+// ScalarsToProtobufJs converts the Scalars model to an object that the fromObject function of its protobuf.js message accepts
+export const ScalarsToProtobufJs = (m: Scalars): {[key: string]: any} => {
+    return {
+        doubleValue: m.doubleValue,
+        floatValue: m.floatValue,
+        int32Value: m.int32Value,
+        int64Value: m.int64Value,
+        uint32Value: m.uint32Value,
+        uint64Value: m.uint64Value,
+        sint32Value: m.sint32Value,
+        sint64Value: m.sint64Value,
+        fixed32Value: m.fixed32Value,
+        fixed64Value: m.fixed64Value,
+        sfixed32Value: m.sfixed32Value,
+        sfixed64Value: m.sfixed64Value,
+        boolValue: m.boolValue,
+        stringValue: m.stringValue,
+        bytesValue: m.bytesValue,
+        floatValues: m.floatValues,
+        sint32Values: m.sint32Values,
+    };
+};
+
+// ProtobufJsToScalars converts a protobuf.js Scalars message, e.g. from its decode function, to the Scalars model
+export const ProtobufJsToScalars = (m: {[key: string]: any}): Scalars => {
+    return {
+        doubleValue: m.doubleValue,
+        floatValue: m.floatValue,
+        int32Value: m.int32Value,
+        int64Value: Number(String(m.int64Value)),
+        uint32Value: m.uint32Value,
+        uint64Value: Number(String(m.uint64Value)),
+        sint32Value: m.sint32Value,
+        sint64Value: Number(String(m.sint64Value)),
+        fixed32Value: m.fixed32Value,
+        fixed64Value: Number(String(m.fixed64Value)),
+        sfixed32Value: m.sfixed32Value,
+        sfixed64Value: Number(String(m.sfixed64Value)),
+        boolValue: m.boolValue,
+        stringValue: m.stringValue,
+        bytesValue: m.bytesValue,
+        floatValues: m.floatValues || [],
+        sint32Values: m.sint32Values || [],
+    } as Scalars;
+};
+
+// ImageToProtobufJs converts the Image model to an object that the fromObject function of its protobuf.js message accepts
+export const ImageToProtobufJs = (m: Image): {[key: string]: any} => {
+    return {
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    };
+};
+
+// ProtobufJsToImage converts a protobuf.js Image message, e.g. from its decode function, to the Image model
+export const ProtobufJsToImage = (m: {[key: string]: any}): Image => {
+    return {
+        url: m.url,
+        width: m.width,
+        height: m.height,
+    } as Image;
+};
+
+// GroupToProtobufJs converts the Group model to an object that the fromObject function of its protobuf.js message accepts
+export const GroupToProtobufJs = (m: Group): {[key: string]: any} => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : GroupToProtobufJs(m.parent),
+        children: m.children.map((v) => GroupToProtobufJs(v)),
+        drawings: m.drawings.map((v) => DrawingToProtobufJs(v)),
+    };
+};
+
+// ProtobufJsToGroup converts a protobuf.js Group message, e.g. from its decode function, to the Group model
+export const ProtobufJsToGroup = (m: {[key: string]: any}): Group => {
+    return {
+        name: m.name,
+        parent: m.parent === null || m.parent === undefined ? undefined : ProtobufJsToGroup(m.parent),
+        children: (m.children || []).map((v) => ProtobufJsToGroup(v)),
+        drawings: (m.drawings || []).map((v) => ProtobufJsToDrawing(v)),
+    } as Group;
+};
+
+// GetDrawingRequestToProtobufJs converts the GetDrawingRequest model to an object that the fromObject function of its protobuf.js message accepts
+export const GetDrawingRequestToProtobufJs = (m: GetDrawingRequest): {[key: string]: any} => {
+    return {
+        id: m.id,
+    };
+};
+
+// ProtobufJsToGetDrawingRequest converts a protobuf.js GetDrawingRequest message, e.g. from its decode function, to the GetDrawingRequest model
+export const ProtobufJsToGetDrawingRequest = (m: {[key: string]: any}): GetDrawingRequest => {
+    return {
+        id: Number(String(m.id)),
+    } as GetDrawingRequest;
+};
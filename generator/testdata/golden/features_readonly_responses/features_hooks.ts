@@ -0,0 +1,12 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Canvas, GetDrawingRequest, Group, ReadonlyDrawing, ReadonlyGroup} from './features';
+
+// useGetDrawing calls Canvas.GetDrawing with the request when the component mounts, and again when the request changes.
+export const useGetDrawing = (client: Canvas, getDrawingRequest: GetDrawingRequest, options?: RpcHookOptions): RpcHookResult<ReadonlyDrawing> => {
+    return useRpc((req, callOptions) => client.getDrawing(req, callOptions), getDrawingRequest, options);
+};
+
+// useSaveGroup calls Canvas.SaveGroup with the request when the component mounts, and again when the request changes.
+export const useSaveGroup = (client: Canvas, group: Group, options?: RpcHookOptions): RpcHookResult<ReadonlyGroup> => {
+    return useRpc((req, callOptions) => client.saveGroup(req, callOptions), group, options);
+};
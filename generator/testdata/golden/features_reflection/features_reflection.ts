@@ -0,0 +1,5 @@
+import {FileDescriptor} from './twirp_reflection';
+
+// descriptor describes the messages, enums and services of features.proto, which a SchemaRegistry looks up by their
+// full names, e.g. new SchemaRegistry([descriptor]).
+export const descriptor: FileDescriptor = {"name":"features.proto","package":"features.v1","messages":[{"name":"features.v1.Drawing","fields":[{"name":"title","localName":"title","jsonName":"title","number":1,"type":"string","label":"optional"},{"name":"id","localName":"id","jsonName":"id","number":2,"type":"int64","label":"optional"},{"name":"revisions","localName":"revisions","jsonName":"revisions","number":3,"type":"uint64","label":"repeated"},{"name":"thumbnail","localName":"thumbnail","jsonName":"thumbnail","number":4,"type":"bytes","label":"optional"},{"name":"tiles","localName":"tiles","jsonName":"tiles","number":5,"type":"bytes","label":"repeated"},{"name":"published","localName":"published","jsonName":"published","number":6,"type":"bool","label":"optional"},{"name":"scale","localName":"scale","jsonName":"scale","number":7,"type":"double","label":"optional"},{"name":"shape","localName":"shape","jsonName":"shape","number":8,"type":"enum","label":"optional","typeName":"features.v1.Shape"},{"name":"shapes","localName":"shapes","jsonName":"shapes","number":9,"type":"enum","label":"repeated","typeName":"features.v1.Shape"},{"name":"layer","localName":"layer","jsonName":"layer","number":10,"type":"message","label":"optional","typeName":"features.v1.Drawing.Layer"},{"name":"layers","localName":"layers","jsonName":"layers","number":11,"type":"message","label":"repeated","typeName":"features.v1.Drawing.Layer"},{"name":"named_layers","localName":"namedLayers","jsonName":"namedLayers","number":12,"type":"map","label":"repeated","typeName":"features.v1.Drawing.Layer","keyType":"string","valueType":"message"},{"name":"labels","localName":"labels","jsonName":"labels","number":13,"type":"map","label":"repeated","keyType":"int32","valueType":"string"},{"name":"flags","localName":"flags","jsonName":"flags","number":14,"type":"map","label":"repeated","typeName":"features.v1.Shape","keyType":"bool","valueType":"enum"},{"name":"opacity","localName":"opacity","jsonName":"opacity","number":15,"type":"int32","label":"optional","proto3Optional":true},{"name":"caption","localName":"caption","jsonName":"caption","number":16,"type":"string","label":"optional","proto3Optional":true},{"name":"text","localName":"text","jsonName":"text","number":17,"type":"string","label":"optional","oneof":"content"},{"name":"image","localName":"image","jsonName":"image","number":18,"type":"message","label":"optional","typeName":"features.v1.Image","oneof":"content"},{"name":"scalars","localName":"scalars","jsonName":"scalars","number":19,"type":"message","label":"optional","typeName":"features.v1.Scalars"}]},{"name":"features.v1.Drawing.Layer","fields":[{"name":"index","localName":"index","jsonName":"index","number":1,"type":"int32","label":"optional"},{"name":"blend","localName":"blend","jsonName":"blend","number":2,"type":"enum","label":"optional","typeName":"features.v1.Drawing.Layer.Blend"}]},{"name":"features.v1.Scalars","fields":[{"name":"double_value","localName":"doubleValue","jsonName":"doubleValue","number":1,"type":"double","label":"optional"},{"name":"float_value","localName":"floatValue","jsonName":"floatValue","number":2,"type":"float","label":"optional"},{"name":"int32_value","localName":"int32Value","jsonName":"int32Value","number":3,"type":"int32","label":"optional"},{"name":"int64_value","localName":"int64Value","jsonName":"int64Value","number":4,"type":"int64","label":"optional"},{"name":"uint32_value","localName":"uint32Value","jsonName":"uint32Value","number":5,"type":"uint32","label":"optional"},{"name":"uint64_value","localName":"uint64Value","jsonName":"uint64Value","number":6,"type":"uint64","label":"optional"},{"name":"sint32_value","localName":"sint32Value","jsonName":"sint32Value","number":7,"type":"sint32","label":"optional"},{"name":"sint64_value","localName":"sint64Value","jsonName":"sint64Value","number":8,"type":"sint64","label":"optional"},{"name":"fixed32_value","localName":"fixed32Value","jsonName":"fixed32Value","number":9,"type":"fixed32","label":"optional"},{"name":"fixed64_value","localName":"fixed64Value","jsonName":"fixed64Value","number":10,"type":"fixed64","label":"optional"},{"name":"sfixed32_value","localName":"sfixed32Value","jsonName":"sfixed32Value","number":11,"type":"sfixed32","label":"optional"},{"name":"sfixed64_value","localName":"sfixed64Value","jsonName":"sfixed64Value","number":12,"type":"sfixed64","label":"optional"},{"name":"bool_value","localName":"boolValue","jsonName":"boolValue","number":13,"type":"bool","label":"optional"},{"name":"string_value","localName":"stringValue","jsonName":"stringValue","number":14,"type":"string","label":"optional"},{"name":"bytes_value","localName":"bytesValue","jsonName":"bytesValue","number":15,"type":"bytes","label":"optional"},{"name":"float_values","localName":"floatValues","jsonName":"floatValues","number":16,"type":"float","label":"repeated"},{"name":"sint32_values","localName":"sint32Values","jsonName":"sint32Values","number":17,"type":"sint32","label":"repeated"}]},{"name":"features.v1.Image","fields":[{"name":"url","localName":"url","jsonName":"url","number":1,"type":"string","label":"optional"},{"name":"width","localName":"width","jsonName":"width","number":2,"type":"int32","label":"optional"},{"name":"height","localName":"height","jsonName":"height","number":3,"type":"int32","label":"optional"}]},{"name":"features.v1.Group","fields":[{"name":"name","localName":"name","jsonName":"name","number":1,"type":"string","label":"optional"},{"name":"parent","localName":"parent","jsonName":"parent","number":2,"type":"message","label":"optional","typeName":"features.v1.Group"},{"name":"children","localName":"children","jsonName":"children","number":3,"type":"message","label":"repeated","typeName":"features.v1.Group"},{"name":"drawings","localName":"drawings","jsonName":"drawings","number":4,"type":"message","label":"repeated","typeName":"features.v1.Drawing"}]},{"name":"features.v1.GetDrawingRequest","fields":[{"name":"id","localName":"id","jsonName":"id","number":1,"type":"int64","label":"optional"}]}],"enums":[{"name":"features.v1.Shape","values":[{"name":"SHAPE_UNSPECIFIED","number":0},{"name":"SHAPE_CIRCLE","number":1},{"name":"SHAPE_SQUARE","number":2}]},{"name":"features.v1.Drawing.Layer.Blend","values":[{"name":"BLEND_NORMAL","number":0},{"name":"BLEND_MULTIPLY","number":1}]}],"services":[{"name":"features.v1.Canvas","methods":[{"name":"GetDrawing","inputType":"features.v1.GetDrawingRequest","outputType":"features.v1.Drawing"},{"name":"SaveGroup","inputType":"features.v1.Group","outputType":"features.v1.Group"}]}]};
@@ -0,0 +1,111 @@
+// FieldDescriptor describes a field of a message.
+export interface FieldDescriptor {
+    // name is the name of the field in the proto file, e.g. "display_name"
+    name: string;
+    // localName is the name of the property of the field in the typescript interface of its message, e.g. "displayName"
+    localName: string;
+    // jsonName is the name of the field in the JSON of its message
+    jsonName: string;
+    number: number;
+    // type is the proto type of the field, e.g. "int32", "string", "message" or "enum", or "map" for a map field
+    type: string;
+    label: "optional" | "required" | "repeated";
+    // typeName is the full name of the message or enum of a message or enum field, or of the values of a map field
+    typeName?: string;
+    // keyType and valueType are the proto types of the keys and the values of a map field
+    keyType?: string;
+    valueType?: string;
+    // oneof is the name of the oneof of a member of a oneof
+    oneof?: string;
+    // proto3Optional is set for the fields that are marked optional in proto3, which are unset when they are undefined
+    proto3Optional?: boolean;
+}
+
+// MessageDescriptor describes a message, whose name is its full name, e.g. "twitch.twirp.example.Hat".
+export interface MessageDescriptor {
+    name: string;
+    fields: FieldDescriptor[];
+}
+
+export interface EnumValueDescriptor {
+    name: string;
+    number: number;
+}
+
+// EnumDescriptor describes an enum, whose name is its full name, e.g. "twitch.twirp.example.Color".
+export interface EnumDescriptor {
+    name: string;
+    values: EnumValueDescriptor[];
+}
+
+// MethodDescriptor describes an rpc method, whose input and output types are the full names of their messages.
+export interface MethodDescriptor {
+    name: string;
+    inputType: string;
+    outputType: string;
+}
+
+// ServiceDescriptor describes a service, whose name is its full name, e.g. "twitch.twirp.example.Haberdasher".
+export interface ServiceDescriptor {
+    name: string;
+    methods: MethodDescriptor[];
+}
+
+// FileDescriptor describes the messages, enums and services of a proto file, including its nested messages and
+// enums, which are named after their parent messages, e.g. "twitch.twirp.example.Hat.Style".
+export interface FileDescriptor {
+    name: string;
+    package: string;
+    messages: MessageDescriptor[];
+    enums: EnumDescriptor[];
+    services: ServiceDescriptor[];
+}
+
+// SchemaRegistry looks up the descriptors of the messages, enums and services of proto files by their full names,
+// e.g. to generate a form for a message, or to list the methods of a service in an admin UI.
+export class SchemaRegistry {
+    private messages: {[name: string]: MessageDescriptor} = {};
+    private enums: {[name: string]: EnumDescriptor} = {};
+    private services: {[name: string]: ServiceDescriptor} = {};
+
+    constructor(files: FileDescriptor[] = []) {
+        files.forEach((file) => this.add(file));
+    }
+
+    // add adds the descriptors of a proto file, e.g. the descriptor of its generated _reflection module.
+    add(file: FileDescriptor): this {
+        file.messages.forEach((m) => { this.messages[m.name] = m; });
+        file.enums.forEach((e) => { this.enums[e.name] = e; });
+        file.services.forEach((s) => { this.services[s.name] = s; });
+        return this;
+    }
+
+    message(name: string): MessageDescriptor | undefined {
+        return this.messages[name];
+    }
+
+    enum(name: string): EnumDescriptor | undefined {
+        return this.enums[name];
+    }
+
+    service(name: string): ServiceDescriptor | undefined {
+        return this.services[name];
+    }
+
+    // field looks up a field of a message by its proto, local or JSON name, or by its number.
+    field(message: string, field: string | number): FieldDescriptor | undefined {
+        const m = this.messages[message];
+        if (!m) {
+            return undefined;
+        }
+
+        return m.fields.filter((f) => typeof field === "number" ? f.number === field : f.name === field || f.localName === field || f.jsonName === field)[0];
+    }
+
+    // enumName is the name of the value of an enum with the number, or undefined when the enum has no such value.
+    enumName(name: string, number: number): string | undefined {
+        const e = this.enums[name];
+        const value = e ? e.values.filter((v) => v.number === number)[0] : undefined;
+        return value ? value.name : undefined;
+    }
+}
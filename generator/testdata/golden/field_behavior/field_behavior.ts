@@ -0,0 +1,253 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, jsonAliases, everyItem, everyValue} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+export interface Book {
+    /** name is set by the server when the book is created. */
+    readonly name: string;
+    title: string;
+    subtitle?: string | undefined;
+    readonly isbn: string;
+    readonly createTime: Date;
+    readonly revisions: string[];
+    readonly labels: {[key: string]: string};
+    readonly pages: number;
+    author: Author;
+}
+
+// BookFields are the numbers and proto types of the fields of Book by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const BookFields = {
+    name: {number: 1, name: "name", type: "string"},
+    title: {number: 2, name: "title", type: "string"},
+    subtitle: {number: 3, name: "subtitle", type: "string"},
+    isbn: {number: 4, name: "isbn", type: "string"},
+    createTime: {number: 5, name: "create_time", type: "message"},
+    revisions: {number: 6, name: "revisions", type: "string"},
+    labels: {number: 7, name: "labels", type: "map"},
+    pages: {number: 8, name: "pages", type: "int32"},
+    author: {number: 9, name: "author", type: "message"},
+} as const;
+
+export interface BookJSON {
+    name: string;
+    title?: string;
+    subtitle?: string;
+    isbn: string;
+    create_time: string;
+    revisions: string[];
+    labels: {[key: string]: string};
+    pages?: number;
+    author: AuthorJSON;
+}
+
+export const BookToJSON = (m: Book): BookJSON => {
+    return {
+        name: m.name,
+        title: m.title,
+        subtitle: m.subtitle,
+        isbn: m.isbn,
+        create_time: m.createTime.toISOString(),
+        revisions: m.revisions,
+        labels: m.labels,
+        pages: m.pages,
+        author: AuthorToJSON(m.author),
+    };
+};
+
+export const JSONToBook = (json: BookJSON): Book => {
+    const m = jsonAliases(json, {"createTime": "create_time"});
+
+    return {
+        name: m.name,
+        title: m.title as string,
+        subtitle: m.subtitle,
+        isbn: m.isbn,
+        createTime: new Date(m.create_time),
+        revisions: m.revisions,
+        labels: m.labels || {},
+        pages: m.pages as number,
+        author: JSONToAuthor(m.author),
+    };
+};
+
+// isBook reports if a value has the fields of a Book, e.g. to check data read from a cache or a websocket.
+export const isBook = (value: unknown): value is Book => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && typeof m.title === "string"
+        && (m.subtitle === undefined || typeof m.subtitle === "string")
+        && typeof m.isbn === "string"
+        && m.createTime instanceof Date
+        && everyItem(m.revisions, (v) => typeof v === "string")
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && typeof m.pages === "number"
+        && isAuthor(m.author);
+};
+
+export interface Author {
+    name: string;
+}
+
+// AuthorFields are the numbers and proto types of the fields of Author by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AuthorFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface AuthorJSON {
+    name: string;
+}
+
+export const AuthorToJSON = (m: Author): AuthorJSON => {
+    return {
+        name: m.name,
+    };
+};
+
+export const JSONToAuthor = (m: AuthorJSON): Author => {
+    return {
+        name: m.name,
+    };
+};
+
+// isAuthor reports if a value has the fields of a Author, e.g. to check data read from a cache or a websocket.
+export const isAuthor = (value: unknown): value is Author => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
+
+export interface Books {
+    createBook: (book: Book, callOptions?: CallOptions) => Promise<Book>;
+}
+
+// BooksMethods are the Twirp routes of the methods of Books, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const BooksMethods = {
+    createBook: {
+        service: "behavior.Books",
+        method: "CreateBook",
+        path: "/twirp/behavior.Books/CreateBook",
+        inputType: "Book",
+        outputType: "Book",
+    },
+} as const;
+
+export class DefaultBooks implements Books {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/behavior.Books/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book> {
+        const url = joinURL(this.hostname, this.pathPrefix + "CreateBook");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "behavior.Books",
+                method: "CreateBook",
+                url: url,
+                request: book,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, BookToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToBook(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createBooksClient creates a DefaultBooks with the config, which may be shared by the clients of other services.
+export const createBooksClient = (config: TwirpClientConfig): DefaultBooks => {
+    return new DefaultBooks(config);
+};
+
+// A BooksMockResponses sets the response of each BooksMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface BooksMockResponses {
+    createBook?: Book | ((book: Book, callOptions?: CallOptions) => Book | Promise<Book>);
+}
+
+// BooksMockClient is a Books for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class BooksMockClient implements Books {
+    responses: BooksMockResponses;
+
+    constructor(responses: BooksMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.createBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Books.CreateBook"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(book, callOptions) : response));
+    }
+}
+
+export const createBooksMock = (overrides: BooksMockResponses = {}): BooksMockClient => {
+    return new BooksMockClient(overrides);
+};
@@ -0,0 +1,164 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider, BuildWhenSet} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+export interface Book {
+    /** name is set by the server when the book is created. */
+    readonly name: string;
+    title: string;
+    subtitle?: string | undefined;
+    readonly isbn: string;
+    readonly createTime: Date;
+    readonly revisions: string[];
+    readonly labels: {[key: string]: string};
+    readonly pages: number;
+    author: Author;
+}
+
+// BookFields are the numbers and proto types of the fields of Book by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const BookFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+    readonly title: {readonly number: 2; readonly name: "title"; readonly type: "string"};
+    readonly subtitle: {readonly number: 3; readonly name: "subtitle"; readonly type: "string"};
+    readonly isbn: {readonly number: 4; readonly name: "isbn"; readonly type: "string"};
+    readonly createTime: {readonly number: 5; readonly name: "create_time"; readonly type: "message"};
+    readonly revisions: {readonly number: 6; readonly name: "revisions"; readonly type: "string"};
+    readonly labels: {readonly number: 7; readonly name: "labels"; readonly type: "map"};
+    readonly pages: {readonly number: 8; readonly name: "pages"; readonly type: "int32"};
+    readonly author: {readonly number: 9; readonly name: "author"; readonly type: "message"};
+};
+
+export interface BookJSON {
+    name: string;
+    title?: string;
+    subtitle?: string;
+    isbn: string;
+    create_time: string;
+    revisions: string[];
+    labels: {[key: string]: string};
+    pages?: number;
+    author: AuthorJSON;
+}
+
+export declare const BookToJSON: (m: Book) => BookJSON;
+
+export declare const JSONToBook: (m: BookJSON) => Book;
+
+// isBook reports if a value has the fields of a Book, e.g. to check data read from a cache or a websocket.
+export declare const isBook: (value: unknown) => value is Book;
+
+// createBook creates a Book whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createBook: (partial?: Partial<Book>) => Book;
+
+// BookBuilder builds a Book one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface BookBuilder<Set extends string = never> {
+    name(value: string): BookBuilder<Set | "name">;
+    title(value: string): BookBuilder<Set | "title">;
+    subtitle(value: string): BookBuilder<Set | "subtitle">;
+    isbn(value: string): BookBuilder<Set | "isbn">;
+    createTime(value: Date): BookBuilder<Set | "createTime">;
+    revisions(value: string[]): BookBuilder<Set | "revisions">;
+    labels(value: {[key: string]: string}): BookBuilder<Set | "labels">;
+    pages(value: number): BookBuilder<Set | "pages">;
+    author(value: Author): BookBuilder<Set | "author">;
+    build: BuildWhenSet<"title" | "isbn" | "pages", Set, Book>;
+}
+
+// buildBook starts a BookBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildBook: () => BookBuilder;
+
+export interface Author {
+    name: string;
+}
+
+// AuthorFields are the numbers and proto types of the fields of Author by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const AuthorFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+};
+
+export interface AuthorJSON {
+    name: string;
+}
+
+export declare const AuthorToJSON: (m: Author) => AuthorJSON;
+
+export declare const JSONToAuthor: (m: AuthorJSON) => Author;
+
+// isAuthor reports if a value has the fields of a Author, e.g. to check data read from a cache or a websocket.
+export declare const isAuthor: (value: unknown) => value is Author;
+
+// createAuthor creates a Author whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createAuthor: (partial?: Partial<Author>) => Author;
+
+export interface Books {
+    createBook: (book: Book, callOptions?: CallOptions) => Promise<Book>;
+}
+
+// BooksMethods are the Twirp routes of the methods of Books, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const BooksMethods: {
+    readonly createBook: {
+        readonly service: "behavior.Books";
+        readonly method: "CreateBook";
+        readonly path: "/twirp/behavior.Books/CreateBook";
+        readonly inputType: "Book";
+        readonly outputType: "Book";
+    };
+};
+
+export declare class DefaultBooks implements Books {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book>;
+}
+
+// createBooksClient creates a DefaultBooks with the config, which may be shared by the clients of other services.
+export declare const createBooksClient: (config: TwirpClientConfig) => DefaultBooks;
+
+// A BooksMockResponses sets the response of each BooksMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface BooksMockResponses {
+    createBook?: Book | ((book: Book, callOptions?: CallOptions) => Book | Promise<Book>);
+}
+
+// BooksMockClient is a Books for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class BooksMockClient implements Books {
+    responses: BooksMockResponses;
+
+    constructor(responses?: BooksMockResponses);
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book>;
+}
+
+export declare const createBooksMock: (overrides?: BooksMockResponses) => BooksMockClient;
@@ -0,0 +1,287 @@
+import {createTwirpProtobufRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, ProtobufReader, ProtobufWriter, timestampToProtobuf, protobufToTimestamp, everyItem, everyValue} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {createTwirpRouter, ServerRequest, TwirpRouter} from './twirp_server';
+
+export interface Book {
+    /** name is set by the server when the book is created. */
+    readonly name: string;
+    title: string;
+    subtitle?: string | undefined;
+    readonly isbn: string;
+    readonly createTime: Date;
+    readonly revisions: string[];
+    readonly labels: {[key: string]: string};
+    readonly pages: number;
+    author: Author;
+}
+
+// BookFields are the numbers and proto types of the fields of Book by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const BookFields = {
+    name: {number: 1, name: "name", type: "string"},
+    title: {number: 2, name: "title", type: "string"},
+    subtitle: {number: 3, name: "subtitle", type: "string"},
+    isbn: {number: 4, name: "isbn", type: "string"},
+    createTime: {number: 5, name: "create_time", type: "message"},
+    revisions: {number: 6, name: "revisions", type: "string"},
+    labels: {number: 7, name: "labels", type: "map"},
+    pages: {number: 8, name: "pages", type: "int32"},
+    author: {number: 9, name: "author", type: "message"},
+} as const;
+
+export interface BookJSON {
+    name: string;
+    title?: string;
+    subtitle?: string;
+    isbn: string;
+    create_time: string;
+    revisions: string[];
+    labels: {[key: string]: string};
+    pages?: number;
+    author: AuthorJSON;
+}
+
+export const BookToProtobuf = (m: Book): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.name) { w.tag(1, 2).string(m.name); }
+    if (m.title !== undefined) { w.tag(2, 2).string(m.title); }
+    if (m.subtitle !== undefined) { w.tag(3, 2).string(m.subtitle); }
+    if (m.isbn) { w.tag(4, 2).string(m.isbn); }
+    if (m.createTime) { w.tag(5, 2).bytes(timestampToProtobuf(m.createTime)); }
+    m.revisions.forEach((v) => w.tag(6, 2).string(v));
+    Object.keys(m.labels).forEach((k) => w.tag(7, 2).message((w) => { w.tag(1, 2).string(k); w.tag(2, 2).string(m.labels[k]); }));
+    if (m.pages !== undefined) { w.tag(8, 0).int32(m.pages); }
+    if (m.author) { w.tag(9, 2).bytes(AuthorToProtobuf(m.author)); }
+
+    return w.finish();
+};
+
+export const ProtobufToBook = (b: Uint8Array): Book => {
+    const r = new ProtobufReader(b);
+    const m = {name: "", isbn: "", revisions: [], labels: {}} as {-readonly [K in keyof Book]: Book[K]};
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.name = r.string(); break;
+            case 2: m.title = r.string(); break;
+            case 3: m.subtitle = r.string(); break;
+            case 4: m.isbn = r.string(); break;
+            case 5: m.createTime = protobufToTimestamp(r.bytes()); break;
+            case 6: m.revisions.push(r.string()); break;
+            case 7: r.entry("", "", (r) => r.string(), (r) => r.string(), (k, v) => m.labels[k] = v); break;
+            case 8: m.pages = r.int32(); break;
+            case 9: m.author = ProtobufToAuthor(r.bytes()); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isBook reports if a value has the fields of a Book, e.g. to check data read from a cache or a websocket.
+export const isBook = (value: unknown): value is Book => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && typeof m.title === "string"
+        && (m.subtitle === undefined || typeof m.subtitle === "string")
+        && typeof m.isbn === "string"
+        && m.createTime instanceof Date
+        && everyItem(m.revisions, (v) => typeof v === "string")
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && typeof m.pages === "number"
+        && isAuthor(m.author);
+};
+
+export interface Author {
+    name: string;
+}
+
+// AuthorFields are the numbers and proto types of the fields of Author by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AuthorFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface AuthorJSON {
+    name: string;
+}
+
+export const AuthorToProtobuf = (m: Author): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.name) { w.tag(1, 2).string(m.name); }
+
+    return w.finish();
+};
+
+export const ProtobufToAuthor = (b: Uint8Array): Author => {
+    const r = new ProtobufReader(b);
+    const m = {name: ""} as Author;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.name = r.string(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isAuthor reports if a value has the fields of a Author, e.g. to check data read from a cache or a websocket.
+export const isAuthor = (value: unknown): value is Author => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
+
+export interface Books {
+    createBook: (book: Book, callOptions?: CallOptions) => Promise<Book>;
+}
+
+// BooksMethods are the Twirp routes of the methods of Books, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const BooksMethods = {
+    createBook: {
+        service: "behavior.Books",
+        method: "CreateBook",
+        path: "/twirp/behavior.Books/CreateBook",
+        inputType: "Book",
+        outputType: "Book",
+    },
+} as const;
+
+export class DefaultBooks implements Books {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/behavior.Books/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book> {
+        const url = joinURL(this.hostname, this.pathPrefix + "CreateBook");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "behavior.Books",
+                method: "CreateBook",
+                url: url,
+                request: book,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, BookToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToBook(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+}
+
+// createBooksClient creates a DefaultBooks with the config, which may be shared by the clients of other services.
+export const createBooksClient = (config: TwirpClientConfig): DefaultBooks => {
+    return new DefaultBooks(config);
+};
+
+// A BooksMockResponses sets the response of each BooksMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface BooksMockResponses {
+    createBook?: Book | ((book: Book, callOptions?: CallOptions) => Book | Promise<Book>);
+}
+
+// BooksMockClient is a Books for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class BooksMockClient implements Books {
+    responses: BooksMockResponses;
+
+    constructor(responses: BooksMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    createBook(book: Book, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.createBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Books.CreateBook"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(book, callOptions) : response));
+    }
+}
+
+export const createBooksMock = (overrides: BooksMockResponses = {}): BooksMockClient => {
+    return new BooksMockClient(overrides);
+};
+
+// BooksHandler implements the Books rpc methods for a server created with createBooksRouter.
+export interface BooksHandler {
+    createBook(book: Book, req: ServerRequest): Book | Promise<Book>;
+}
+
+// createBooksRouter serves the Books rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(createBooksRouter(handler))
+export const createBooksRouter = (handler: BooksHandler): TwirpRouter => {
+    return createTwirpRouter("/twirp/behavior.Books/", {
+        CreateBook: (body, req) => new Promise<Book>((resolve) => resolve(handler.createBook(ProtobufToBook(body), req))).then(BookToProtobuf),
+    });
+};
@@ -0,0 +1,222 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, jsonAliases} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    /** The size of a hat should always be in inches. */
+    size: number;
+    /**
+     * The color of a hat will never be 'invisible', but other than
+     * that, anything is fair game.
+     */
+    color: string;
+    /** The name of a hat is it's type. Like, 'bowler', or something. */
+    name: string;
+    createdOn: Date;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+    name: {number: 3, name: "name", type: "string"},
+    createdOn: {number: 4, name: "created_on", type: "message"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+    name: string;
+    created_on: string;
+}
+
+export const JSONToHat = (json: HatJSON): Hat => {
+    const m = jsonAliases(json, {"createdOn": "created_on"});
+
+    return {
+        size: m.size,
+        color: m.color,
+        name: m.name,
+        createdOn: new Date(m.created_on),
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string"
+        && typeof m.name === "string"
+        && m.createdOn instanceof Date;
+};
+
+/**
+ * Size is passed when requesting a new hat to be made. It's always
+ * measured in inches.
+ */
+export interface Size {
+    inches: number;
+}
+
+// SizeFields are the numbers and proto types of the fields of Size by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SizeFields = {
+    inches: {number: 1, name: "inches", type: "int32"},
+} as const;
+
+export interface SizeJSON {
+    inches: number;
+}
+
+export const SizeToJSON = (m: Size): SizeJSON => {
+    return {
+        inches: m.inches,
+    };
+};
+
+// isSize reports if a value has the fields of a Size, e.g. to check data read from a cache or a websocket.
+export const isSize = (value: unknown): value is Size => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.inches === "number";
+};
+
+/** A Haberdasher makes hats for clients. */
+export interface Haberdasher {
+    /** MakeHat produces a hat of mysterious, randomly-selected color! */
+    makeHat: (size: Size, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    makeHat: {
+        service: "twitch.twirp.example.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/twitch.twirp.example.Haberdasher/MakeHat",
+        inputType: "Size",
+        outputType: "Hat",
+    },
+} as const;
+
+/** A Haberdasher makes hats for clients. */
+export class DefaultHaberdasher implements Haberdasher {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/twitch.twirp.example.Haberdasher/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** MakeHat produces a hat of mysterious, randomly-selected color! */
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "twitch.twirp.example.Haberdasher",
+                method: "MakeHat",
+                url: url,
+                request: size,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SizeToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export const createHaberdasherClient = (config: TwirpClientConfig): DefaultHaberdasher => {
+    return new DefaultHaberdasher(config);
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    makeHat?: Hat | ((size: Size, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(size, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
@@ -0,0 +1,23 @@
+import {Inject, Injectable, Optional} from '@angular/core';
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {TWIRP_HOSTNAME, TWIRP_PREFIX, httpClientTransport, observeCall} from './twirp_angular';
+import {DefaultHaberdasher, Hat, Size} from './haberdasher';
+
+// HaberdasherService is an Angular service of the Haberdasher rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+@Injectable({providedIn: "root"})
+export class HaberdasherService {
+    // client is the Haberdasher client of the service, e.g. to add interceptors or a retry policy
+    readonly client: DefaultHaberdasher;
+
+    constructor(http: HttpClient, @Inject(TWIRP_HOSTNAME) hostname: string, @Optional() @Inject(TWIRP_PREFIX) prefix?: string | null) {
+        this.client = new DefaultHaberdasher(hostname, httpClientTransport(http), {}, prefix || undefined);
+    }
+
+    /** MakeHat produces a hat of mysterious, randomly-selected color! */
+    makeHat(size: Size, callOptions?: CallOptions): Observable<Hat> {
+        return observeCall(callOptions, (options) => this.client.makeHat(size, options));
+    }
+}
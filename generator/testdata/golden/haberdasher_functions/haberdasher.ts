@@ -0,0 +1,171 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, CallOptions, jsonAliases} from './twirp';
+import {InterceptorContext, TwirpClient, runInterceptors} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    /** The size of a hat should always be in inches. */
+    size: number;
+    /**
+     * The color of a hat will never be 'invisible', but other than
+     * that, anything is fair game.
+     */
+    color: string;
+    /** The name of a hat is it's type. Like, 'bowler', or something. */
+    name: string;
+    createdOn: Date;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+    name: {number: 3, name: "name", type: "string"},
+    createdOn: {number: 4, name: "created_on", type: "message"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+    name: string;
+    created_on: string;
+}
+
+export const JSONToHat = (json: HatJSON): Hat => {
+    const m = jsonAliases(json, {"createdOn": "created_on"});
+
+    return {
+        size: m.size,
+        color: m.color,
+        name: m.name,
+        createdOn: new Date(m.created_on),
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string"
+        && typeof m.name === "string"
+        && m.createdOn instanceof Date;
+};
+
+/**
+ * Size is passed when requesting a new hat to be made. It's always
+ * measured in inches.
+ */
+export interface Size {
+    inches: number;
+}
+
+// SizeFields are the numbers and proto types of the fields of Size by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SizeFields = {
+    inches: {number: 1, name: "inches", type: "int32"},
+} as const;
+
+export interface SizeJSON {
+    inches: number;
+}
+
+export const SizeToJSON = (m: Size): SizeJSON => {
+    return {
+        inches: m.inches,
+    };
+};
+
+// isSize reports if a value has the fields of a Size, e.g. to check data read from a cache or a websocket.
+export const isSize = (value: unknown): value is Size => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.inches === "number";
+};
+
+/** A Haberdasher makes hats for clients. */
+export interface Haberdasher {
+    /** MakeHat produces a hat of mysterious, randomly-selected color! */
+    makeHat: (size: Size, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    makeHat: {
+        service: "twitch.twirp.example.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/twitch.twirp.example.Haberdasher/MakeHat",
+        inputType: "Size",
+        outputType: "Hat",
+    },
+} as const;
+
+/** MakeHat produces a hat of mysterious, randomly-selected color! */
+export const makeHat = (client: TwirpClient, size: Size, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/twitch.twirp.example.Haberdasher/MakeHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "twitch.twirp.example.Haberdasher",
+            method: "MakeHat",
+            url: url,
+            request: size,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, SizeToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createHaberdasherClient creates a Haberdasher of the rpc functions of Haberdasher, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createHaberdasherClient = (client: TwirpClient): Haberdasher => {
+    return {
+        makeHat: (size: Size, callOptions?: CallOptions) => makeHat(client, size, callOptions),
+    };
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    makeHat?: Hat | ((size: Size, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(size, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
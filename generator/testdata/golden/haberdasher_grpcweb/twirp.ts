@@ -0,0 +1,1611 @@
+// Error codes defined by the Twirp spec: https://twitchtv.github.io/twirp/docs/spec_v5.html#error-codes
+export enum TwirpErrorCode {
+    Canceled = "canceled",
+    Unknown = "unknown",
+    InvalidArgument = "invalid_argument",
+    Malformed = "malformed",
+    DeadlineExceeded = "deadline_exceeded",
+    NotFound = "not_found",
+    BadRoute = "bad_route",
+    AlreadyExists = "already_exists",
+    PermissionDenied = "permission_denied",
+    Unauthenticated = "unauthenticated",
+    ResourceExhausted = "resource_exhausted",
+    FailedPrecondition = "failed_precondition",
+    Aborted = "aborted",
+    OutOfRange = "out_of_range",
+    Unimplemented = "unimplemented",
+    Internal = "internal",
+    Unavailable = "unavailable",
+    DataLoss = "data_loss",
+}
+
+export interface TwirpErrorJSON {
+    code: string;
+    msg: string;
+    meta?: {[index:string]: string};
+}
+
+export class TwirpError extends Error {
+    code: TwirpErrorCode;
+    meta: {[index:string]: string};
+
+    constructor(te: TwirpErrorJSON) {
+        super(te.msg);
+
+        // restore the prototype chain so instanceof works when targeting ES5
+        (Object as any).setPrototypeOf(this, TwirpError.prototype);
+
+        this.name = "TwirpError";
+        this.code = te.code as TwirpErrorCode;
+        this.meta = te.meta || {};
+    }
+}
+
+// httpStatus is the HTTP status of each error code, as defined by the Twirp spec.
+export const httpStatus: {[code: string]: number} = {
+    canceled: 408,
+    unknown: 500,
+    invalid_argument: 400,
+    malformed: 400,
+    deadline_exceeded: 408,
+    not_found: 404,
+    bad_route: 404,
+    already_exists: 409,
+    permission_denied: 403,
+    unauthenticated: 401,
+    resource_exhausted: 429,
+    failed_precondition: 412,
+    aborted: 409,
+    out_of_range: 400,
+    unimplemented: 501,
+    internal: 500,
+    unavailable: 503,
+    data_loss: 500,
+};
+
+// Errors that do not come from a Twirp server (e.g. a proxy or load balancer) are mapped
+// to a Twirp error code based on the HTTP status, as described in the Twirp spec.
+const intermediaryError = (status: number, body: string): TwirpErrorJSON => {
+    let code = TwirpErrorCode.Unknown;
+
+    if (status >= 300 && status < 400) {
+        code = TwirpErrorCode.Internal;
+    } else if (status === 400) {
+        code = TwirpErrorCode.Internal;
+    } else if (status === 401) {
+        code = TwirpErrorCode.Unauthenticated;
+    } else if (status === 403) {
+        code = TwirpErrorCode.PermissionDenied;
+    } else if (status === 404) {
+        code = TwirpErrorCode.BadRoute;
+    } else if (status === 429 || status === 502 || status === 503 || status === 504) {
+        code = TwirpErrorCode.Unavailable;
+    }
+
+    return {
+        code: code,
+        msg: "Error from intermediary with HTTP status code " + status,
+        meta: {
+            http_error_from_intermediary: "true",
+            status_code: String(status),
+            body: body,
+        },
+    };
+};
+
+export const throwTwirpError = (resp: TransportResponse): Promise<never> => {
+    return resp.text().then((body: string) => {
+        let err: TwirpErrorJSON;
+
+        try {
+            err = JSON.parse(body);
+        } catch (e) {
+            err = intermediaryError(resp.status, body);
+        }
+
+        if (!err || typeof err.code !== "string") {
+            err = intermediaryError(resp.status, body);
+        }
+
+        throw new TwirpError(err);
+    });
+};
+
+export type TwirpHeaders = {[index:string]: string};
+
+// HeadersProvider is called before every request, e.g. to attach a fresh Authorization header.
+export type HeadersProvider = () => TwirpHeaders | Promise<TwirpHeaders>;
+
+// CallOptions are the optional per-call settings accepted by every generated client method.
+export interface CallOptions {
+    // signal cancels the request when aborted, e.g. when a component unmounts
+    signal?: AbortSignal;
+    // headers are merged into the request, overriding headers with the same name set on the client
+    headers?: TwirpHeaders;
+    // timeoutMs is the deadline of the call, after which it is cancelled and rejects with a deadline_exceeded TwirpError
+    timeoutMs?: number;
+    // onResponse is called with the status and headers of each response of the call, including error responses,
+    // e.g. to read rate limits or request IDs
+    onResponse?: (response: ResponseMetadata) => void;
+}
+
+// ResponseHeaders are the headers of a response, e.g. the Headers of a fetch Response, which are looked up case
+// insensitively.
+export interface ResponseHeaders {
+    get(name: string): string | null;
+}
+
+// ResponseMetadata is the status and headers of the response of a call.
+export interface ResponseMetadata {
+    status: number;
+    headers: ResponseHeaders;
+}
+
+// WithResponse is the result of a call along with the status and headers of its response, see withResponse.
+export interface WithResponse<T> extends ResponseMetadata {
+    data: T;
+}
+
+const noHeaders: ResponseHeaders = {get: () => null};
+
+// reportResponse calls the onResponse of the options of a call with the status and headers of a response.
+export const reportResponse = (options: CallOptions, resp: TransportResponse): void => {
+    if (options.onResponse) {
+        options.onResponse({status: resp.status, headers: resp.headers || noHeaders});
+    }
+};
+
+// withResponse makes a call with the CallOptions passed to call, and resolves with its result along with the status
+// and headers of its response, e.g. withResponse((options) => client.makeHat(size, options)). The options of the
+// call are the caller's options, so options added to CallOptions are passed on too.
+export const withResponse = <T>(call: (options: CallOptions) => Promise<T>, options: CallOptions = {}): Promise<WithResponse<T>> => {
+    let response: ResponseMetadata = {status: 0, headers: noHeaders};
+
+    return call({
+        ...options,
+        onResponse: (resp) => {
+            response = resp;
+            if (options.onResponse) {
+                options.onResponse(resp);
+            }
+        },
+    }).then((data) => ({data: data, status: response.status, headers: response.headers}));
+};
+
+const mergeHeaders = (...all: (TwirpHeaders | undefined)[]): TwirpHeaders => {
+    const merged: TwirpHeaders = {};
+
+    all.forEach((headers) => {
+        if (headers) {
+            Object.keys(headers).forEach((k) => { merged[k] = headers[k]; });
+        }
+    });
+
+    return merged;
+};
+
+// resolveCallOptions merges the headers and timeout configured on a client with the per-call options.
+export const resolveCallOptions = (clientHeaders?: TwirpHeaders | HeadersProvider, options: CallOptions = {}, timeoutMs?: number): Promise<CallOptions> => {
+    const headers = typeof clientHeaders === "function" ? clientHeaders() : clientHeaders;
+
+    return Promise.resolve(headers).then((headers) => {
+        return {
+            signal: options.signal,
+            headers: mergeHeaders(headers, options.headers),
+            timeoutMs: options.timeoutMs === undefined ? timeoutMs : options.timeoutMs,
+            onResponse: options.onResponse,
+        };
+    });
+};
+
+// withDeadline runs a call with the signal of an AbortController that is aborted after options.timeoutMs,
+// or when options.signal is aborted. A call that times out rejects with a deadline_exceeded TwirpError.
+export const withDeadline = <T>(options: CallOptions, call: (options: CallOptions) => Promise<T>): Promise<T> => {
+    const timeoutMs = options.timeoutMs;
+    if (!timeoutMs) {
+        return call(options);
+    }
+
+    const controller = new AbortController();
+    const signal = options.signal;
+    const abort = () => controller.abort();
+
+    if (signal) {
+        if (signal.aborted) {
+            abort();
+        }
+
+        signal.addEventListener("abort", abort);
+    }
+
+    return new Promise<T>((resolve, reject) => {
+        const timer = setTimeout(() => {
+            abort();
+            reject(new TwirpError({code: TwirpErrorCode.DeadlineExceeded, msg: "the call did not complete within " + timeoutMs + "ms"}));
+        }, timeoutMs);
+
+        const done = () => {
+            clearTimeout(timer);
+            if (signal) {
+                signal.removeEventListener("abort", abort);
+            }
+        };
+
+        call({signal: controller.signal, headers: options.headers, timeoutMs: timeoutMs, onResponse: options.onResponse}).then((resp) => {
+            done();
+            resolve(resp);
+        }, (err) => {
+            done();
+            reject(err);
+        });
+    });
+};
+
+// TransportRequest is a Twirp request, which is always sent with the POST method, or a request of a REST route.
+export interface TransportRequest {
+    // method is the HTTP method of a REST request, and is POST when it is not set
+    method?: string;
+    url: string;
+    headers: TwirpHeaders;
+    // body is empty for a REST request without a body, which is sent without one
+    body: string | Uint8Array;
+    signal?: AbortSignal;
+}
+
+// TransportResponse is the subset of a fetch Response read by the generated clients.
+export interface TransportResponse {
+    ok: boolean;
+    status: number;
+    // headers are the headers of the response, which are not reported by transports that do not read them
+    headers?: ResponseHeaders;
+    text(): Promise<string>;
+    arrayBuffer(): Promise<ArrayBuffer>;
+}
+
+// Transport sends the requests of the generated clients, see the adapters in transports.ts.
+export type Transport = (req: TransportRequest) => Promise<TransportResponse>;
+
+// joinURL joins the hostname of a client to the path of a Twirp route, with a single slash between them,
+// e.g. joinURL("http://localhost:8080/", "/twirp/twitch.twirp.example.Haberdasher/MakeHat")
+export const joinURL = (base: string, path: string): string => {
+    return base.replace(/\/+$/, "") + "/" + path.replace(/^\/+/, "");
+};
+
+export const createTwirpRequest = (url: string, body: object, options: CallOptions = {}): TransportRequest => {
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/json",
+        }),
+        body: JSON.stringify(body),
+        signal: options.signal,
+    };
+};
+
+export type Fetch = (input: RequestInfo, init?: RequestInit) => Promise<Response>;
+
+const base64Chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/";
+
+// bytesToBase64 encodes bytes fields using standard base64 with padding, as described by the proto3 JSON mapping.
+export const bytesToBase64 = (b: Uint8Array): string => {
+    let s = "";
+
+    for (let i = 0; i < b.length; i += 3) {
+        const n = (b[i] << 16) | ((i + 1 < b.length ? b[i + 1] : 0) << 8) | (i + 2 < b.length ? b[i + 2] : 0);
+
+        s += base64Chars.charAt(n >> 18) + base64Chars.charAt((n >> 12) & 63);
+        s += i + 1 < b.length ? base64Chars.charAt((n >> 6) & 63) : "=";
+        s += i + 2 < b.length ? base64Chars.charAt(n & 63) : "=";
+    }
+
+    return s;
+};
+
+// base64ToBytes decodes both standard and URL safe base64, with or without padding.
+export const base64ToBytes = (s: string): Uint8Array => {
+    s = s.replace(/-/g, "+").replace(/_/g, "/").replace(/=+$/, "");
+
+    const b = new Uint8Array(Math.floor(s.length * 3 / 4));
+    let n = 0;
+    let bits = 0;
+    let j = 0;
+
+    for (let i = 0; i < s.length; i++) {
+        n = (n << 6) | base64Chars.indexOf(s.charAt(i));
+        bits += 6;
+
+        if (bits >= 8) {
+            bits -= 8;
+            b[j++] = (n >> bits) & 255;
+        }
+    }
+
+    return b;
+};
+
+// ValidationError is a protoc-gen-validate rule that is violated by a field, e.g.
+// {field: "address.street", rule: "string.min_len", message: "must be at least 1 characters"}
+export interface ValidationError {
+    field: string;
+    rule: string;
+    message: string;
+}
+
+// validationError is the invalid_argument TwirpError of a request that violates its validation rules,
+// whose meta has the field of the first violated rule as the argument, as in the errors of a Twirp server.
+export const validationError = (errors: ValidationError[]): TwirpError => {
+    return new TwirpError({
+        code: TwirpErrorCode.InvalidArgument,
+        msg: errors[0].field + " " + errors[0].message,
+        meta: {argument: errors[0].field},
+    });
+};
+
+export const checkRule = (errors: ValidationError[], field: string, rule: string, valid: boolean, message: string): void => {
+    if (!valid) {
+        errors.push({field: field, rule: rule, message: message});
+    }
+};
+
+const addNestedErrors = (errors: ValidationError[], field: string, nested: ValidationError[]): void => {
+    nested.forEach((e) => errors.push({field: field + (e.field.charAt(0) === "[" ? "" : ".") + e.field, rule: e.rule, message: e.message}));
+};
+
+// validateMessage, validateList, and validateMap validate the messages of a field, and add their errors
+// with the name of the field as a prefix, e.g. address.street or previous[0].street
+export const validateMessage = <T>(errors: ValidationError[], field: string, m: T | undefined | null, validate: (m: T) => ValidationError[]): void => {
+    if (m !== undefined && m !== null) {
+        addNestedErrors(errors, field, validate(m));
+    }
+};
+
+export const validateList = <T>(errors: ValidationError[], field: string, list: T[] | undefined, validate: (m: T) => ValidationError[]): void => {
+    (list || []).forEach((m, i) => addNestedErrors(errors, field + "[" + i + "]", validate(m)));
+};
+
+export const validateMap = <T>(errors: ValidationError[], field: string, map: {[key: number]: T} | undefined, validate: (m: T) => ValidationError[]): void => {
+    const values = (map || {}) as {[key: string]: T};
+    Object.keys(values).forEach((k) => addNestedErrors(errors, field + "[" + k + "]", validate(values[k])));
+};
+
+// runeCount is the number of unicode code points of a string, which are counted by the length rules of strings
+export const runeCount = (s: string): number => {
+    return s.replace(/[\uD800-\uDBFF][\uDC00-\uDFFF]/g, "_").length;
+};
+
+export const utf8Length = (s: string): number => {
+    return new TextEncoder().encode(s).length;
+};
+
+export const isUnique = (list: any[]): boolean => {
+    return list.every((v, i) => list.indexOf(v) === i);
+};
+
+export const isEmail = (s: string): boolean => {
+    return /^[^@\s]+@[^@\s]+$/.test(s) && isHostname(s.slice(s.lastIndexOf("@") + 1));
+};
+
+export const isHostname = (s: string): boolean => {
+    return s.length <= 253 && /^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.?$/.test(s);
+};
+
+export const isURI = (s: string): boolean => {
+    return /^[a-zA-Z][a-zA-Z0-9+.-]*:[^\s]*$/.test(s);
+};
+
+export const isUUID = (s: string): boolean => {
+    return /^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$/.test(s);
+};
+
+// fieldMaskToString formats the paths of a google.protobuf.FieldMask as in the proto3 JSON mapping,
+// e.g. ["user.display_name", "photo"] => "user.displayName,photo"
+export const fieldMaskToString = (paths: string[]): string => {
+    return paths.map((p) => p.replace(/_([a-z])/g, (_, c) => c.toUpperCase())).join(",");
+};
+
+// mapEntries converts the keys and values of a map field, e.g. mapEntries(m.hats, String, HatToJSON).
+// Maps with number keys are accepted, since their keys are strings at runtime.
+export const mapEntries = <K extends string | number, T, U>(m: {[key: number]: T}, key: (k: string) => K, value: (v: T) => U): {[key: string]: U} => {
+    const out: {[key: string]: U} = {};
+    Object.keys(m).forEach((k) => out[key(k)] = value((m as {[key: string]: T})[k]));
+    return out;
+};
+
+// enumFromJSON converts an enum value from proto3 JSON, which may be either the name or the number of the value,
+// e.g. enumFromJSON<Color>(Color, "RED") and enumFromJSON<Color>(Color, 1) are both Color.RED
+export const enumFromJSON = <T>(e: object, v: string | number): T => {
+    return (typeof v === "number" ? v : (e as {[key: string]: number})[v]) as any as T;
+};
+
+// floatToJSON converts a float or double value to proto3 JSON, where NaN and the infinities are the strings
+// "NaN", "Infinity" and "-Infinity", since they are not JSON numbers.
+export const floatToJSON = (n: number): number | string => {
+    return isFinite(n) ? n : String(n);
+};
+
+// floatFromJSON converts a float or double value from proto3 JSON, which is either a number or a string,
+// e.g. "NaN", "Infinity" or "1.5"
+export const floatFromJSON = (v: number | string): number => {
+    return typeof v === "string" ? Number(v) : v;
+};
+
+// ResponseSchema is a schema that checks the JSON of a response, such as the zod schemas generated with zod=true.
+export interface ResponseSchema {
+    safeParse(data: unknown): {success: true; data: any} | {success: false; error: {message: string}};
+}
+
+// parseResponse checks the JSON of a response with the schema of its message, and throws an internal TwirpError
+// for a response that does not match, so the client never returns a message of the wrong shape.
+export const parseResponse = (schema: ResponseSchema, json: unknown): any => {
+    const result = schema.safeParse(json);
+    if (!result.success) {
+        throw new TwirpError({code: TwirpErrorCode.Internal, msg: "invalid response: " + result.error.message});
+    }
+
+    return result.data;
+};
+
+export const fieldMaskFromString = (s: string): string[] => {
+    if (!s) {
+        return [];
+    }
+
+    return s.split(",").map((p) => p.replace(/[A-Z]/g, (c) => "_" + c.toLowerCase()));
+};
+
+// cloneValue deeply copies the value of a field for the clone method of a message class, where
+// messages are copied by their own clone method.
+export const cloneValue = <T>(v: T): T => {
+    const value: any = v;
+    if (value === null || typeof value !== "object") {
+        return v;
+    }
+
+    if (typeof value.clone === "function") {
+        return value.clone();
+    }
+
+    if (value instanceof Date) {
+        return new Date(value.getTime()) as any;
+    }
+
+    if (value instanceof Uint8Array) {
+        return value.slice() as any;
+    }
+
+    if (Array.isArray(value)) {
+        return value.map(cloneValue) as any;
+    }
+
+    const copy: any = {};
+    Object.keys(value).forEach((k) => copy[k] = cloneValue(value[k]));
+    return copy;
+};
+
+// valuesEqual reports if the values of a field are deeply equal for the equals method of a message class.
+// A key whose value is undefined is the same as a key that is not set, as for unset optional fields.
+export const valuesEqual = (a: any, b: any): boolean => {
+    if (a === b) {
+        return true;
+    }
+
+    if (a === null || b === null || typeof a !== "object" || typeof b !== "object") {
+        return false;
+    }
+
+    if (typeof a.equals === "function") {
+        return a.equals(b);
+    }
+
+    if (a instanceof Date || b instanceof Date) {
+        return a instanceof Date && b instanceof Date && a.getTime() === b.getTime();
+    }
+
+    if (Array.isArray(a) || a instanceof Uint8Array) {
+        return a.length === b.length && Array.prototype.every.call(a, (v: any, i: number) => valuesEqual(v, b[i]));
+    }
+
+    const keys = (o: any) => Object.keys(o).filter((k) => o[k] !== undefined);
+    const aKeys = keys(a);
+
+    return aKeys.length === keys(b).length && aKeys.every((k) => valuesEqual(a[k], b[k]));
+};
+
+// mergeFields copies a message with the fields of a patch that are set for the merge function of the message, e.g.
+// mergeHat(hat, {color: "red"}). The fields of merge are merged with the fields of the patch, e.g. the nested
+// messages and the maps, while the other fields of the patch replace the fields of the message.
+export const mergeFields = <T>(base: T, patch: Partial<T>, merge: {[field: string]: (base: any, patch: any) => any}): T => {
+    const m: any = {};
+    Object.keys(base).forEach((k) => m[k] = (base as any)[k]);
+    Object.keys(patch).forEach((k) => {
+        const v = (patch as any)[k];
+        if (v === undefined) {
+            return;
+        }
+
+        m[k] = merge[k] && m[k] !== undefined && m[k] !== null ? merge[k](m[k], v) : v;
+    });
+
+    return m;
+};
+
+// mergeMap merges the entries of the map field of a patch into the map field of a message, which replace its
+// entries with the same keys.
+export const mergeMap = <T>(base: {[key: string]: T}, patch: {[key: string]: T}): {[key: string]: T} => {
+    const m: {[key: string]: T} = {};
+    Object.keys(base).forEach((k) => m[k] = base[k]);
+    Object.keys(patch).forEach((k) => m[k] = patch[k]);
+
+    return m;
+};
+
+// FieldDiff is a field of a message for diffFields, with its path in a FieldMask, and the diff function of its
+// message, or a oneof with the paths of its members by their kinds.
+export interface FieldDiff {
+    name: string;
+    path?: string;
+    diff?: (a: any, b: any) => string[];
+    members?: {[kind: string]: string};
+}
+
+// diffFields finds the paths of the fields that differ between two messages for the diff function of the messages,
+// e.g. diffHat(a, b) => ["color"]. The fields of nested messages that are set in both are compared by their diff
+// functions, e.g. "size.inches", while repeated and map fields differ as a whole, and a oneof differs by the paths
+// of the members that are set.
+export const diffFields = <T>(a: T, b: T, fields: FieldDiff[]): string[] => {
+    const paths: string[] = [];
+
+    fields.forEach((f) => {
+        const x = (a as any)[f.name];
+        const y = (b as any)[f.name];
+
+        if (f.diff && x !== undefined && x !== null && y !== undefined && y !== null) {
+            f.diff(x, y).forEach((p) => paths.push(f.path + "." + p));
+        } else if (valuesEqual(x, y)) {
+            return;
+        } else if (f.members) {
+            const members = f.members;
+            [x, y].forEach((o) => {
+                if (o && paths.indexOf(members[o.kind]) < 0) {
+                    paths.push(members[o.kind]);
+                }
+            });
+        } else {
+            paths.push(f.path as string);
+        }
+    });
+
+    return paths;
+};
+
+// canonicalJSON serializes a message, or a value of its fields, to JSON whose object keys are sorted, so equal
+// messages have the same JSON whatever order their properties were set in, e.g. canonicalJSON(user) for a cache key,
+// an ETag or a dedupe key. The properties that are undefined are left out, as for unset optional fields, and bigints
+// are their digits, bytes are their base64 and Dates are their ISO strings. The messages of models=classes are their
+// sorted proto3 JSON.
+export const canonicalJSON = (value: unknown): string => {
+    return JSON.stringify(value, (_, v) => {
+        if (typeof v === "bigint") {
+            return v.toString();
+        }
+
+        if (v instanceof Uint8Array) {
+            return bytesToBase64(v);
+        }
+
+        if (v === null || typeof v !== "object" || Array.isArray(v)) {
+            return v;
+        }
+
+        const sorted: {[key: string]: any} = {};
+        Object.keys(v).sort().forEach((k) => sorted[k] = v[k]);
+
+        return sorted;
+    });
+};
+
+// everyItem and everyValue check the items of a repeated field and the values of a map field for the type guard
+// of a message, e.g. everyItem(m.hats, isHat)
+export const everyItem = (v: unknown, check: (v: unknown) => boolean): boolean => {
+    return Array.isArray(v) && v.every((item) => check(item));
+};
+
+export const everyValue = (v: unknown, check: (v: unknown) => boolean): boolean => {
+    if (typeof v !== "object" || v === null || Array.isArray(v)) {
+        return false;
+    }
+
+    const values = v as {[key: string]: unknown};
+    return Object.keys(values).every((k) => check(values[k]));
+};
+
+// oneofMember checks the discriminated union of a oneof for the type guard of a message, with the check of
+// the value of each member, e.g. oneofMember(m.content, {text: (v) => typeof v === "string", image: isImage})
+export const oneofMember = (v: unknown, members: {[kind: string]: (v: unknown) => boolean}): boolean => {
+    if (typeof v !== "object" || v === null) {
+        return false;
+    }
+
+    const member = v as {kind: unknown, value: unknown};
+    return typeof member.kind === "string" && members.hasOwnProperty(member.kind) && members[member.kind](member.value);
+};
+
+export const isDurationObject = (v: unknown): boolean => {
+    const d = v as Duration;
+    return typeof v === "object" && v !== null && typeof d.seconds === "number" && typeof d.nanos === "number";
+};
+
+// BuildWhenSet is the build method of the builder of a message, which can only be called once the Required fields
+// are Set. Until then it is not callable, and the compile error names the missing fields, e.g.
+// BuildWhenSet<"title" | "isbn", "title", Book> is {missingRequiredFields: "isbn"}.
+export type BuildWhenSet<Required extends string, Set extends string, T> = [Exclude<Required, Set>] extends [never] ? () => T : {missingRequiredFields: Exclude<Required, Set>};
+
+// messageBuilder makes the builder of a message, whose methods set the fields and return a new builder, and whose
+// build method creates the message from the fields that are set, e.g. messageBuilder(createBook, ["title", "isbn"]).
+export const messageBuilder = <B>(create: (partial: any) => unknown, fields: string[], partial: {[field: string]: unknown} = {}): B => {
+    const builder: {[method: string]: unknown} = {build: () => create(partial)};
+
+    fields.forEach((field) => {
+        builder[field] = (value: unknown) => messageBuilder<B>(create, fields, {...partial, [field]: value});
+    });
+
+    return builder as unknown as B;
+};
+
+// Duration is a google.protobuf.Duration, where nanos has the same sign as seconds.
+export interface Duration {
+    seconds: number;
+    nanos: number;
+}
+
+// durationToString formats a Duration as in the proto3 JSON mapping, e.g. "3.5s"
+export const durationToString = (d: Duration): string => {
+    const negative = d.seconds < 0 || d.nanos < 0;
+    let s = (negative ? "-" : "") + Math.abs(d.seconds);
+
+    if (d.nanos) {
+        s += "." + String(Math.abs(d.nanos) + 1000000000).substring(1).replace(/(000)+$/, "");
+    }
+
+    return s + "s";
+};
+
+export const durationFromString = (s: string): Duration => {
+    const negative = s.charAt(0) === "-";
+    const parts = s.replace(/^-|s$/g, "").split(".");
+    const seconds = Number(parts[0]);
+    const nanos = parts[1] ? Number((parts[1] + "00000000").substring(0, 9)) : 0;
+
+    return {
+        seconds: negative && seconds ? -seconds : seconds,
+        nanos: negative && nanos ? -nanos : nanos,
+    };
+};
+
+// Redaction redacts a field of a message in the copies made for logs and error reports, see redactFields.
+export type Redaction = (value: any) => any;
+
+// redacted replaces the value of a sensitive field, e.g. a field with the debug_redact option.
+export const redacted: Redaction = () => "[REDACTED]";
+
+// redactFields copies a message for logs and error reports, whose fields with a redaction are replaced by the
+// redaction of their value, e.g. redacted for the sensitive fields, and the redact functions of the messages of
+// message fields. Unset fields are copied as is.
+export const redactFields = (m: any, redactions: {[field: string]: Redaction}): any => {
+    const copy: {[key: string]: any} = {};
+    Object.keys(m).forEach((k) => {
+        const v = m[k];
+        copy[k] = redactions.hasOwnProperty(k) && v !== undefined && v !== null ? redactions[k](v) : v;
+    });
+
+    return copy;
+};
+
+// redactList redacts each item of a repeated field.
+export const redactList = (redact: Redaction): Redaction => {
+    return (values: any[]) => values.map(redact);
+};
+
+// redactMap redacts each value of a map field.
+export const redactMap = (redact: Redaction): Redaction => {
+    return (values: {[key: string]: any}) => {
+        const copy: {[key: string]: any} = {};
+        Object.keys(values).forEach((k) => copy[k] = redact(values[k]));
+
+        return copy;
+    };
+};
+
+// redactOneof redacts the value of the members of a oneof with a redaction, e.g. redactOneof({password: redacted}).
+export const redactOneof = (members: {[kind: string]: Redaction}): Redaction => {
+    return (oneof: {kind: string; value: any}) => {
+        return members.hasOwnProperty(oneof.kind) ? {kind: oneof.kind, value: members[oneof.kind](oneof.value)} : oneof;
+    };
+};
+
+// ProtobufJsLong is a 64 bit integer of a protobuf.js message, which is a Long when long.js is installed
+export type ProtobufJsLong = number | string | {toString(): string};
+
+// ProtobufJsSeconds is a google.protobuf.Timestamp or google.protobuf.Duration of a protobuf.js message
+export interface ProtobufJsSeconds {
+    seconds?: ProtobufJsLong | null;
+    nanos?: number | null;
+}
+
+// timestampToProtobufJs converts a Date to the object of a protobuf.js google.protobuf.Timestamp
+export const timestampToProtobufJs = (d: Date): ProtobufJsSeconds => {
+    const ms = d.getTime();
+    const seconds = Math.floor(ms / 1000);
+    return {seconds: seconds, nanos: (ms - seconds * 1000) * 1000000};
+};
+
+// timestampFromProtobufJs converts a protobuf.js google.protobuf.Timestamp to a Date, which is truncated to milliseconds
+export const timestampFromProtobufJs = (t: ProtobufJsSeconds): Date => {
+    return new Date(Number(String(t.seconds || 0)) * 1000 + Math.floor((t.nanos || 0) / 1000000));
+};
+
+// durationFromProtobufJs converts a protobuf.js google.protobuf.Duration to a Duration
+export const durationFromProtobufJs = (d: ProtobufJsSeconds): Duration => {
+    return {seconds: Number(String(d.seconds || 0)), nanos: d.nanos || 0};
+};
+
+// valueToProtobufJs converts a JSON value to the object of a protobuf.js google.protobuf.Value
+export const valueToProtobufJs = (v: any): {[key: string]: any} => {
+    if (v === null || v === undefined) {
+        return {nullValue: 0};
+    } else if (typeof v === "number") {
+        return {numberValue: v};
+    } else if (typeof v === "string") {
+        return {stringValue: v};
+    } else if (typeof v === "boolean") {
+        return {boolValue: v};
+    } else if (Array.isArray(v)) {
+        return {listValue: {values: v.map(valueToProtobufJs)}};
+    }
+
+    return {structValue: structToProtobufJs(v)};
+};
+
+// valueFromProtobufJs converts a protobuf.js google.protobuf.Value to a JSON value
+export const valueFromProtobufJs = (v: {[key: string]: any} | null | undefined): any => {
+    if (!v) {
+        return null;
+    } else if (v.numberValue !== null && v.numberValue !== undefined) {
+        return v.numberValue;
+    } else if (v.stringValue !== null && v.stringValue !== undefined) {
+        return v.stringValue;
+    } else if (v.boolValue !== null && v.boolValue !== undefined) {
+        return v.boolValue;
+    } else if (v.listValue) {
+        return (v.listValue.values || []).map(valueFromProtobufJs);
+    } else if (v.structValue) {
+        return structFromProtobufJs(v.structValue);
+    }
+
+    return null;
+};
+
+// structToProtobufJs converts a JSON object to the object of a protobuf.js google.protobuf.Struct
+export const structToProtobufJs = (s: {[key: string]: any}): {[key: string]: any} => {
+    return {fields: mapEntries(s, String, valueToProtobufJs)};
+};
+
+// structFromProtobufJs converts a protobuf.js google.protobuf.Struct to a JSON object
+export const structFromProtobufJs = (s: {[key: string]: any} | null | undefined): {[key: string]: any} => {
+    return mapEntries(s && s.fields || {}, String, valueFromProtobufJs);
+};
+
+// ProtobufTsType is the MessageType of a message generated by protobuf-ts, e.g. the Hat exported by service.ts
+export interface ProtobufTsType<T> {
+    fromJson(json: any): T;
+    toJson(message: T, options?: {useProtoFieldName?: boolean; emitDefaultValues?: boolean}): any;
+    fromBinary(data: Uint8Array): T;
+    toBinary(message: T): Uint8Array;
+}
+
+export const createTwirpProtobufRequest = (url: string, body: Uint8Array, options: CallOptions = {}): TransportRequest => {
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/protobuf",
+        }),
+        body: body,
+        signal: options.signal,
+    };
+};
+
+const utf8Encode = (s: string): number[] => {
+    const out: number[] = [];
+
+    for (let i = 0; i < s.length; i++) {
+        let c = s.charCodeAt(i);
+
+        if (c >= 0xd800 && c < 0xdc00 && i + 1 < s.length) {
+            const next = s.charCodeAt(i + 1);
+            if (next >= 0xdc00 && next < 0xe000) {
+                c = 0x10000 + ((c - 0xd800) << 10) + (next - 0xdc00);
+                i++;
+            }
+        }
+
+        if (c < 0x80) {
+            out.push(c);
+        } else if (c < 0x800) {
+            out.push(0xc0 | (c >> 6), 0x80 | (c & 63));
+        } else if (c < 0x10000) {
+            out.push(0xe0 | (c >> 12), 0x80 | ((c >> 6) & 63), 0x80 | (c & 63));
+        } else {
+            out.push(0xf0 | (c >> 18), 0x80 | ((c >> 12) & 63), 0x80 | ((c >> 6) & 63), 0x80 | (c & 63));
+        }
+    }
+
+    return out;
+};
+
+const utf8Decode = (b: Uint8Array): string => {
+    let s = "";
+
+    for (let i = 0; i < b.length;) {
+        let c = b[i++];
+
+        if (c >= 0xf0) {
+            c = ((c & 7) << 18) | ((b[i++] & 63) << 12) | ((b[i++] & 63) << 6) | (b[i++] & 63);
+        } else if (c >= 0xe0) {
+            c = ((c & 15) << 12) | ((b[i++] & 63) << 6) | (b[i++] & 63);
+        } else if (c >= 0xc0) {
+            c = ((c & 31) << 6) | (b[i++] & 63);
+        }
+
+        if (c >= 0x10000) {
+            c -= 0x10000;
+            s += String.fromCharCode(0xd800 + (c >> 10), 0xdc00 + (c & 1023));
+        } else {
+            s += String.fromCharCode(c);
+        }
+    }
+
+    return s;
+};
+
+// 64 bit integers are represented as decimal strings, since they can't be represented exactly by a number.
+// They are split into low and high 32 bit halves of their two's complement value for encoding.
+const splitInt64 = (s: string): [number, number] => {
+    const negative = s.charAt(0) === "-";
+    if (negative) {
+        s = s.slice(1);
+    }
+
+    let lo = 0;
+    let hi = 0;
+
+    for (let i = 0; i < s.length; i += 6) {
+        const chunk = s.slice(i, i + 6);
+        const scale = Math.pow(10, chunk.length);
+        const low = lo * scale + parseInt(chunk, 10);
+
+        lo = low % 4294967296;
+        hi = (hi * scale + Math.floor(low / 4294967296)) % 4294967296;
+    }
+
+    if (negative) {
+        lo = (~lo + 1) >>> 0;
+        hi = (~hi + (lo === 0 ? 1 : 0)) >>> 0;
+    }
+
+    return [lo, hi];
+};
+
+const joinInt64 = (lo: number, hi: number, signed: boolean): string => {
+    if (signed && hi & 0x80000000) {
+        lo = (~lo + 1) >>> 0;
+        hi = (~hi + (lo === 0 ? 1 : 0)) >>> 0;
+        return "-" + joinInt64(lo, hi, false);
+    }
+
+    if (hi <= 0x1fffff) {
+        return String(hi * 4294967296 + lo);
+    }
+
+    // split into 24 bit digits and convert to base 1e7
+    const low = lo & 0xffffff;
+    const mid = ((lo >>> 24) | (hi << 8)) & 0xffffff;
+    const high = (hi >>> 16) & 0xffff;
+
+    let a = low + mid * 6777216 + high * 6710656;
+    let b = mid + high * 8147497;
+    let c = high * 2;
+
+    if (a >= 10000000) {
+        b += Math.floor(a / 10000000);
+        a %= 10000000;
+    }
+
+    if (b >= 10000000) {
+        c += Math.floor(b / 10000000);
+        b %= 10000000;
+    }
+
+    const pad = (n: number): string => ("0000000" + n).slice(-7);
+
+    return c > 0 ? String(c) + pad(b) + pad(a) : String(b) + pad(a);
+};
+
+export class ProtobufWriter {
+    private buf: number[] = [];
+
+    tag(field: number, wireType: number): ProtobufWriter {
+        return this.uint32(((field << 3) | wireType) >>> 0);
+    }
+
+    uint32(v: number): ProtobufWriter {
+        v = v >>> 0;
+        while (v > 127) {
+            this.buf.push((v & 127) | 128);
+            v = v >>> 7;
+        }
+        this.buf.push(v);
+        return this;
+    }
+
+    int32(v: number): ProtobufWriter {
+        // negative values are sign extended to 64 bits
+        return v < 0 ? this.varint64(v >>> 0, 0xffffffff) : this.uint32(v);
+    }
+
+    sint32(v: number): ProtobufWriter {
+        return this.uint32(((v << 1) ^ (v >> 31)) >>> 0);
+    }
+
+    int64(v: string): ProtobufWriter {
+        const [lo, hi] = splitInt64(v);
+        return this.varint64(lo, hi);
+    }
+
+    uint64(v: string): ProtobufWriter {
+        return this.int64(v);
+    }
+
+    sint64(v: string): ProtobufWriter {
+        const [lo, hi] = splitInt64(v);
+        const sign = hi >> 31;
+        return this.varint64(((lo << 1) ^ sign) >>> 0, (((hi << 1) | (lo >>> 31)) ^ sign) >>> 0);
+    }
+
+    bool(v: boolean): ProtobufWriter {
+        return this.uint32(v ? 1 : 0);
+    }
+
+    fixed32(v: number): ProtobufWriter {
+        v = v >>> 0;
+        this.buf.push(v & 255, (v >>> 8) & 255, (v >>> 16) & 255, v >>> 24);
+        return this;
+    }
+
+    sfixed32(v: number): ProtobufWriter {
+        return this.fixed32(v);
+    }
+
+    fixed64(v: string): ProtobufWriter {
+        const [lo, hi] = splitInt64(v);
+        return this.fixed32(lo).fixed32(hi);
+    }
+
+    sfixed64(v: string): ProtobufWriter {
+        return this.fixed64(v);
+    }
+
+    float(v: number): ProtobufWriter {
+        const view = new DataView(new ArrayBuffer(4));
+        view.setFloat32(0, v, true);
+        return this.raw(new Uint8Array(view.buffer));
+    }
+
+    double(v: number): ProtobufWriter {
+        const view = new DataView(new ArrayBuffer(8));
+        view.setFloat64(0, v, true);
+        return this.raw(new Uint8Array(view.buffer));
+    }
+
+    bytes(v: Uint8Array): ProtobufWriter {
+        return this.uint32(v.length).raw(v);
+    }
+
+    string(v: string): ProtobufWriter {
+        return this.bytes(new Uint8Array(utf8Encode(v)));
+    }
+
+    packed<T>(values: T[], write: (w: ProtobufWriter, v: T) => ProtobufWriter): ProtobufWriter {
+        const inner = new ProtobufWriter();
+        values.forEach((v) => write(inner, v));
+        return this.bytes(inner.finish());
+    }
+
+    // message writes the fields of an embedded message, such as a map entry, as length delimited bytes
+    message(write: (w: ProtobufWriter) => void): ProtobufWriter {
+        const w = new ProtobufWriter();
+        write(w);
+        return this.bytes(w.finish());
+    }
+
+    finish(): Uint8Array {
+        return new Uint8Array(this.buf);
+    }
+
+    private varint64(lo: number, hi: number): ProtobufWriter {
+        while (hi > 0 || lo > 127) {
+            this.buf.push((lo & 127) | 128);
+            lo = ((lo >>> 7) | (hi << 25)) >>> 0;
+            hi = hi >>> 7;
+        }
+        this.buf.push(lo);
+        return this;
+    }
+
+    private raw(b: Uint8Array): ProtobufWriter {
+        for (let i = 0; i < b.length; i++) {
+            this.buf.push(b[i]);
+        }
+        return this;
+    }
+}
+
+export class ProtobufReader {
+    private buf: Uint8Array;
+    private pos = 0;
+
+    constructor(buf: Uint8Array) {
+        this.buf = buf;
+    }
+
+    done(): boolean {
+        return this.pos >= this.buf.length;
+    }
+
+    uint32(): number {
+        let v = 0;
+        let shift = 0;
+        let b: number;
+
+        do {
+            b = this.buf[this.pos++];
+            if (shift < 32) {
+                v |= (b & 127) << shift;
+            }
+            shift += 7;
+        } while (b & 128);
+
+        return v >>> 0;
+    }
+
+    int32(): number {
+        return this.uint32() | 0;
+    }
+
+    sint32(): number {
+        const v = this.uint32();
+        return (v >>> 1) ^ -(v & 1);
+    }
+
+    int64(): string {
+        const [lo, hi] = this.varint64();
+        return joinInt64(lo, hi, true);
+    }
+
+    uint64(): string {
+        const [lo, hi] = this.varint64();
+        return joinInt64(lo, hi, false);
+    }
+
+    sint64(): string {
+        const [lo, hi] = this.varint64();
+        const sign = -(lo & 1);
+        return joinInt64((((lo >>> 1) | (hi << 31)) ^ sign) >>> 0, ((hi >>> 1) ^ sign) >>> 0, true);
+    }
+
+    bool(): boolean {
+        return this.uint32() !== 0;
+    }
+
+    fixed32(): number {
+        const b = this.buf;
+        const v = b[this.pos] | (b[this.pos + 1] << 8) | (b[this.pos + 2] << 16) | (b[this.pos + 3] << 24);
+        this.pos += 4;
+        return v >>> 0;
+    }
+
+    sfixed32(): number {
+        return this.fixed32() | 0;
+    }
+
+    fixed64(): string {
+        const lo = this.fixed32();
+        return joinInt64(lo, this.fixed32(), false);
+    }
+
+    sfixed64(): string {
+        const lo = this.fixed32();
+        return joinInt64(lo, this.fixed32(), true);
+    }
+
+    float(): number {
+        const v = new DataView(this.buf.buffer, this.buf.byteOffset + this.pos, 4).getFloat32(0, true);
+        this.pos += 4;
+        return v;
+    }
+
+    double(): number {
+        const v = new DataView(this.buf.buffer, this.buf.byteOffset + this.pos, 8).getFloat64(0, true);
+        this.pos += 8;
+        return v;
+    }
+
+    bytes(): Uint8Array {
+        const length = this.uint32();
+        const b = this.buf.subarray(this.pos, this.pos + length);
+        this.pos += length;
+        return b;
+    }
+
+    string(): string {
+        return utf8Decode(this.bytes());
+    }
+
+    // repeated reads a repeated scalar field, which may be encoded either packed or unpacked.
+    repeated(tag: number, read: () => void) {
+        if ((tag & 7) !== 2) {
+            read();
+            return;
+        }
+
+        const end = this.uint32() + this.pos;
+        while (this.pos < end) {
+            read();
+        }
+    }
+
+    // entry reads a map entry, with the given defaults for a key or value that is not present, and sets it with set
+    entry<K, V>(key: K, value: V, readKey: (r: ProtobufReader) => K, readValue: (r: ProtobufReader) => V, set: (k: K, v: V) => void) {
+        const r = new ProtobufReader(this.bytes());
+
+        while (!r.done()) {
+            const tag = r.uint32();
+            switch (tag >>> 3) {
+                case 1: key = readKey(r); break;
+                case 2: value = readValue(r); break;
+                default: r.skip(tag & 7);
+            }
+        }
+
+        set(key, value);
+    }
+
+    skip(wireType: number) {
+        switch (wireType) {
+            case 0:
+                this.varint64();
+                break;
+            case 1:
+                this.pos += 8;
+                break;
+            case 2:
+                this.pos += this.uint32();
+                break;
+            case 5:
+                this.pos += 4;
+                break;
+            default:
+                throw new Error("unsupported wire type " + wireType);
+        }
+    }
+
+    private varint64(): [number, number] {
+        let lo = 0;
+        let hi = 0;
+        let shift = 0;
+        let b: number;
+
+        do {
+            b = this.buf[this.pos++];
+            if (shift < 28) {
+                lo |= (b & 127) << shift;
+            } else if (shift === 28) {
+                lo |= (b & 15) << 28;
+                hi |= (b & 127) >> 4;
+            } else {
+                hi |= (b & 127) << (shift - 32);
+            }
+            shift += 7;
+        } while (b & 128);
+
+        return [lo >>> 0, hi >>> 0];
+    }
+}
+
+export const timestampToProtobuf = (d: Date): Uint8Array => {
+    const ms = d.getTime();
+    const seconds = Math.floor(ms / 1000);
+    const nanos = (ms - seconds * 1000) * 1000000;
+    const w = new ProtobufWriter();
+
+    if (seconds) {
+        w.tag(1, 0).int64(String(seconds));
+    }
+
+    if (nanos) {
+        w.tag(2, 0).int32(nanos);
+    }
+
+    return w.finish();
+};
+
+export const protobufToTimestamp = (b: Uint8Array): Date => {
+    const r = new ProtobufReader(b);
+    let seconds = 0;
+    let nanos = 0;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1:
+                seconds = Number(r.int64());
+                break;
+            case 2:
+                nanos = r.int32();
+                break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return new Date(seconds * 1000 + nanos / 1000000);
+};
+
+export const fieldMaskToProtobuf = (paths: string[]): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    paths.forEach((p) => w.tag(1, 2).string(p));
+
+    return w.finish();
+};
+
+export const protobufToFieldMask = (b: Uint8Array): string[] => {
+    const r = new ProtobufReader(b);
+    const paths: string[] = [];
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        if (tag >>> 3 === 1) {
+            paths.push(r.string());
+        } else {
+            r.skip(tag & 7);
+        }
+    }
+
+    return paths;
+};
+
+export const durationToProtobuf = (d: Duration): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    if (d.seconds) {
+        w.tag(1, 0).int64(String(d.seconds));
+    }
+
+    if (d.nanos) {
+        w.tag(2, 0).int32(d.nanos);
+    }
+
+    return w.finish();
+};
+
+export const protobufToDuration = (b: Uint8Array): Duration => {
+    const r = new ProtobufReader(b);
+    const d = {seconds: 0, nanos: 0};
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1:
+                d.seconds = Number(r.int64());
+                break;
+            case 2:
+                d.nanos = r.int32();
+                break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return d;
+};
+
+// Any is a google.protobuf.Any, which is the binary encoding of the packed message along with its type URL
+export interface Any {
+    typeUrl: string;
+    value: Uint8Array;
+}
+
+// packAny packs an encoded message, e.g. packAny(HatToProtobuf(hat), "type.googleapis.com/twitch.twirp.example.Hat")
+export const packAny = (message: Uint8Array, typeUrl: string): Any => {
+    return {typeUrl: typeUrl, value: message};
+};
+
+// unpackAny unpacks a message using its protobuf decoder, e.g. unpackAny(any, ProtobufToHat)
+export const unpackAny = <T>(any: Any, decoder: (b: Uint8Array) => T): T => {
+    return decoder(any.value);
+};
+
+export const anyToProtobuf = (a: Any): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    if (a.typeUrl) {
+        w.tag(1, 2).string(a.typeUrl);
+    }
+
+    if (a.value.length) {
+        w.tag(2, 2).bytes(a.value);
+    }
+
+    return w.finish();
+};
+
+export const protobufToAny = (b: Uint8Array): Any => {
+    const r = new ProtobufReader(b);
+    const a = {typeUrl: "", value: new Uint8Array(0)};
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1:
+                a.typeUrl = r.string();
+                break;
+            case 2:
+                a.value = r.bytes();
+                break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return a;
+};
+
+export const valueToProtobuf = (v: any): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    if (v === null || v === undefined) {
+        w.tag(1, 0).int32(0);
+    } else if (typeof v === "number") {
+        w.tag(2, 1).double(v);
+    } else if (typeof v === "string") {
+        w.tag(3, 2).string(v);
+    } else if (typeof v === "boolean") {
+        w.tag(4, 0).bool(v);
+    } else if (Array.isArray(v)) {
+        w.tag(6, 2).bytes(listValueToProtobuf(v));
+    } else {
+        w.tag(5, 2).bytes(structToProtobuf(v));
+    }
+
+    return w.finish();
+};
+
+export const structToProtobuf = (s: {[key: string]: any}): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    Object.keys(s).forEach((k) => {
+        w.tag(1, 2).bytes(new ProtobufWriter().tag(1, 2).string(k).tag(2, 2).bytes(valueToProtobuf(s[k])).finish());
+    });
+
+    return w.finish();
+};
+
+export const listValueToProtobuf = (l: any[]): Uint8Array => {
+    const w = new ProtobufWriter();
+
+    l.forEach((v) => w.tag(1, 2).bytes(valueToProtobuf(v)));
+
+    return w.finish();
+};
+
+export const protobufToValue = (b: Uint8Array): any => {
+    const r = new ProtobufReader(b);
+    let v: any = null;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1:
+                r.int32();
+                v = null;
+                break;
+            case 2:
+                v = r.double();
+                break;
+            case 3:
+                v = r.string();
+                break;
+            case 4:
+                v = r.bool();
+                break;
+            case 5:
+                v = protobufToStruct(r.bytes());
+                break;
+            case 6:
+                v = protobufToListValue(r.bytes());
+                break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return v;
+};
+
+export const protobufToStruct = (b: Uint8Array): {[key: string]: any} => {
+    const r = new ProtobufReader(b);
+    const s: {[key: string]: any} = {};
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        if (tag >>> 3 !== 1) {
+            r.skip(tag & 7);
+            continue;
+        }
+
+        const entry = new ProtobufReader(r.bytes());
+        let key = "";
+        let value: any = null;
+
+        while (!entry.done()) {
+            const t = entry.uint32();
+            switch (t >>> 3) {
+                case 1:
+                    key = entry.string();
+                    break;
+                case 2:
+                    value = protobufToValue(entry.bytes());
+                    break;
+                default:
+                    entry.skip(t & 7);
+            }
+        }
+
+        s[key] = value;
+    }
+
+    return s;
+};
+
+export const protobufToListValue = (b: Uint8Array): any[] => {
+    const r = new ProtobufReader(b);
+    const l: any[] = [];
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        if (tag >>> 3 === 1) {
+            l.push(protobufToValue(r.bytes()));
+        } else {
+            r.skip(tag & 7);
+        }
+    }
+
+    return l;
+};
+
+export const unwrapValue = <T>(b: Uint8Array, value: T, read: (r: ProtobufReader) => T): T => {
+    const r = new ProtobufReader(b);
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        if (tag >>> 3 === 1) {
+            value = read(r);
+        } else {
+            r.skip(tag & 7);
+        }
+    }
+
+    return value;
+};
+
+// createGRPCWebRequest creates the gRPC-web request of a call, whose message is sent in an uncompressed frame.
+export const createGRPCWebRequest = (url: string, body: Uint8Array, options: CallOptions = {}): TransportRequest => {
+    const frame = new Uint8Array(5 + body.length);
+    frame[1] = (body.length >>> 24) & 0xff;
+    frame[2] = (body.length >>> 16) & 0xff;
+    frame[3] = (body.length >>> 8) & 0xff;
+    frame[4] = body.length & 0xff;
+    frame.set(body, 5);
+
+    return {
+        url: url,
+        headers: mergeHeaders(options.headers, {
+            "Content-Type": "application/grpc-web+proto",
+            "Accept": "application/grpc-web+proto",
+            "X-Grpc-Web": "1",
+        }),
+        body: frame,
+        signal: options.signal,
+    };
+};
+
+// grpcErrorCodes are the Twirp error codes of the gRPC status codes, which Twirp shares.
+const grpcErrorCodes: {[status: string]: TwirpErrorCode} = {
+    "1": TwirpErrorCode.Canceled,
+    "2": TwirpErrorCode.Unknown,
+    "3": TwirpErrorCode.InvalidArgument,
+    "4": TwirpErrorCode.DeadlineExceeded,
+    "5": TwirpErrorCode.NotFound,
+    "6": TwirpErrorCode.AlreadyExists,
+    "7": TwirpErrorCode.PermissionDenied,
+    "8": TwirpErrorCode.ResourceExhausted,
+    "9": TwirpErrorCode.FailedPrecondition,
+    "10": TwirpErrorCode.Aborted,
+    "11": TwirpErrorCode.OutOfRange,
+    "12": TwirpErrorCode.Unimplemented,
+    "13": TwirpErrorCode.Internal,
+    "14": TwirpErrorCode.Unavailable,
+    "15": TwirpErrorCode.DataLoss,
+    "16": TwirpErrorCode.Unauthenticated,
+};
+
+// grpcWebTrailers parses the trailers frame of a gRPC-web response, which are lines of "name: value".
+const grpcWebTrailers = (b: Uint8Array): {[name: string]: string} => {
+    const trailers: {[name: string]: string} = {};
+    utf8Decode(b).split("\r\n").forEach((line) => {
+        const i = line.indexOf(":");
+        if (i > 0) {
+            trailers[line.substring(0, i).trim().toLowerCase()] = line.substring(i + 1).trim();
+        }
+    });
+
+    return trailers;
+};
+
+// grpcWebResponse reads the message of a gRPC-web response, and rejects with a TwirpError of the code of its
+// grpc-status when the call fails. Responses that are not from a gRPC-web server, e.g. from a proxy, are errors of
+// their HTTP status, as with Twirp.
+export const grpcWebResponse = (resp: TransportResponse): Promise<Uint8Array> => {
+    if (!resp.ok) {
+        return throwTwirpError(resp);
+    }
+
+    return resp.arrayBuffer().then((buf) => {
+        const b = new Uint8Array(buf);
+        let message: Uint8Array | undefined;
+        let trailers: {[name: string]: string} = {};
+
+        for (let i = 0; i + 5 <= b.length;) {
+            const length = ((b[i + 1] << 24) | (b[i + 2] << 16) | (b[i + 3] << 8) | b[i + 4]) >>> 0;
+            const frame = b.subarray(i + 5, i + 5 + length);
+
+            if (b[i] & 0x80) {
+                trailers = grpcWebTrailers(frame);
+            } else if (b[i] & 0x01) {
+                throw new TwirpError({code: TwirpErrorCode.Internal, msg: "compressed gRPC-web messages are not supported"});
+            } else if (message === undefined) {
+                message = frame;
+            }
+
+            i += 5 + length;
+        }
+
+        const headers = resp.headers || noHeaders;
+        const status = headers.get("grpc-status") || trailers["grpc-status"];
+        if (!status) {
+            throw new TwirpError({code: TwirpErrorCode.Internal, msg: "gRPC-web response without a grpc-status"});
+        }
+
+        if (status !== "0") {
+            const msg = headers.get("grpc-message") || trailers["grpc-message"] || "";
+            throw new TwirpError({code: grpcErrorCodes[status] || TwirpErrorCode.Unknown, msg: decodeURIComponent(msg), meta: {grpc_status: status}});
+        }
+
+        if (message === undefined) {
+            throw new TwirpError({code: TwirpErrorCode.Internal, msg: "gRPC-web response without a message"});
+        }
+
+        return message;
+    });
+};
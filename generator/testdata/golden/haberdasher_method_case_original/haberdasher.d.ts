@@ -0,0 +1,103 @@
+import {CallOptions} from './twirp';
+import {TwirpClient} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    /** The size of a hat should always be in inches. */
+    size: number;
+    /**
+     * The color of a hat will never be 'invisible', but other than
+     * that, anything is fair game.
+     */
+    color: string;
+    /** The name of a hat is it's type. Like, 'bowler', or something. */
+    name: string;
+    createdOn: Date;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly size: {readonly number: 1; readonly name: "size"; readonly type: "int32"};
+    readonly color: {readonly number: 2; readonly name: "color"; readonly type: "string"};
+    readonly name: {readonly number: 3; readonly name: "name"; readonly type: "string"};
+    readonly createdOn: {readonly number: 4; readonly name: "created_on"; readonly type: "message"};
+};
+
+export interface HatJSON {
+    size: number;
+    color: string;
+    name: string;
+    created_on: string;
+}
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+/**
+ * Size is passed when requesting a new hat to be made. It's always
+ * measured in inches.
+ */
+export interface Size {
+    inches: number;
+}
+
+// SizeFields are the numbers and proto types of the fields of Size by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SizeFields: {
+    readonly inches: {readonly number: 1; readonly name: "inches"; readonly type: "int32"};
+};
+
+export interface SizeJSON {
+    inches: number;
+}
+
+export declare const SizeToJSON: (m: Size) => SizeJSON;
+
+// isSize reports if a value has the fields of a Size, e.g. to check data read from a cache or a websocket.
+export declare const isSize: (value: unknown) => value is Size;
+
+/** A Haberdasher makes hats for clients. */
+export interface Haberdasher {
+    /** MakeHat produces a hat of mysterious, randomly-selected color! */
+    MakeHat: (size: Size, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const HaberdasherMethods: {
+    readonly MakeHat: {
+        readonly service: "twitch.twirp.example.Haberdasher";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/twitch.twirp.example.Haberdasher/MakeHat";
+        readonly inputType: "Size";
+        readonly outputType: "Hat";
+    };
+};
+
+/** MakeHat produces a hat of mysterious, randomly-selected color! */
+export declare const MakeHat: (client: TwirpClient, size: Size, callOptions?: CallOptions) => Promise<Hat>;
+
+// createHaberdasherClient creates a Haberdasher of the rpc functions of Haberdasher, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export declare const createHaberdasherClient: (client: TwirpClient) => Haberdasher;
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    MakeHat?: Hat | ((size: Size, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses?: HaberdasherMockResponses);
+
+    MakeHat(size: Size, callOptions?: CallOptions): Promise<Hat>;
+}
+
+export declare const createHaberdasherMock: (overrides?: HaberdasherMockResponses) => HaberdasherMockClient;
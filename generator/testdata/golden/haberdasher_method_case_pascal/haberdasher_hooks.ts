@@ -0,0 +1,7 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Haberdasher, Hat, Size} from './haberdasher';
+
+// useMakeHat calls Haberdasher.MakeHat with the request when the component mounts, and again when the request changes.
+export const useMakeHat = (client: Haberdasher, size: Size, options?: RpcHookOptions): RpcHookResult<Hat> => {
+    return useRpc((req, callOptions) => client.MakeHat(req, callOptions), size, options);
+};
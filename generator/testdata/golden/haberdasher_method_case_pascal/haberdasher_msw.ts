@@ -0,0 +1,13 @@
+import {HttpHandler} from 'msw';
+import {joinURL} from './twirp';
+import {twirpHandler} from './twirp_msw';
+import {HaberdasherMethods, HaberdasherMockResponses, HatToJSON, JSONToSize} from './haberdasher';
+
+// createHaberdasherHandlers creates the msw handlers of the Twirp routes of Haberdasher, which respond with the responses
+// of the methods like the HaberdasherMockClient, e.g. setupServer(...createHaberdasherHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export const createHaberdasherHandlers = (responses: HaberdasherMockResponses, hostname: string = "*"): HttpHandler[] => {
+    return [
+        twirpHandler(joinURL(hostname, HaberdasherMethods.MakeHat.path), JSONToSize, HatToJSON, responses.MakeHat),
+    ];
+};
@@ -0,0 +1,22 @@
+import {ProtobufTsType} from './twirp';
+import {Hat, HatToProtobuf, ProtobufToHat, Size, SizeToProtobuf, ProtobufToSize} from './haberdasher';
+
+// HatToProtobufTs converts the Hat model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Hat
+export const HatToProtobufTs = <T>(type: ProtobufTsType<T>, m: Hat): T => {
+    return type.fromBinary(HatToProtobuf(m));
+};
+
+// ProtobufTsToHat converts a protobuf-ts message to the Hat model, with the MessageType that protobuf-ts exports for Hat
+export const ProtobufTsToHat = <T>(type: ProtobufTsType<T>, message: T): Hat => {
+    return ProtobufToHat(type.toBinary(message));
+};
+
+// SizeToProtobufTs converts the Size model to a protobuf-ts message, with the MessageType that protobuf-ts exports for Size
+export const SizeToProtobufTs = <T>(type: ProtobufTsType<T>, m: Size): T => {
+    return type.fromBinary(SizeToProtobuf(m));
+};
+
+// ProtobufTsToSize converts a protobuf-ts message to the Size model, with the MessageType that protobuf-ts exports for Size
+export const ProtobufTsToSize = <T>(type: ProtobufTsType<T>, message: T): Size => {
+    return ProtobufToSize(type.toBinary(message));
+};
@@ -0,0 +1,25 @@
+import {RpcQueryOptions, RpcMutationOptions} from './twirp_query';
+import {canonicalJSON} from './twirp';
+import {Haberdasher, Hat, Size} from './haberdasher';
+
+// makeHatQueryKey is the query key of Haberdasher.MakeHat queries, e.g. to invalidate the cached response of a request.
+export const makeHatQueryKey = (size: Size): readonly ["twitch.twirp.example.Haberdasher", "MakeHat", Size] => {
+    return ["twitch.twirp.example.Haberdasher", "MakeHat", size];
+};
+
+// makeHatQuery are the query options of Haberdasher.MakeHat, e.g. useQuery(makeHatQuery(client, size))
+export const makeHatQuery = (client: Haberdasher, size: Size): RpcQueryOptions<Hat, ReturnType<typeof makeHatQueryKey>> => {
+    return {
+        queryKey: makeHatQueryKey(size),
+        queryKeyHashFn: canonicalJSON,
+        queryFn: (context) => client.makeHat(size, {signal: context.signal}),
+    };
+};
+
+// makeHatMutation are the mutation options of Haberdasher.MakeHat, e.g. useMutation(makeHatMutation(client))
+export const makeHatMutation = (client: Haberdasher): RpcMutationOptions<Hat, Size> => {
+    return {
+        mutationKey: ["twitch.twirp.example.Haberdasher", "MakeHat"],
+        mutationFn: (size) => client.makeHat(size),
+    };
+};
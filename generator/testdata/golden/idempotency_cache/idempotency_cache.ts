@@ -0,0 +1,37 @@
+import {CacheOptions, ResponseCache} from './twirp_cache';
+import {CallOptions} from './twirp';
+import {DeleteHatRequest, DeleteHatResponse, GetHatRequest, Haberdasher, Hat, ListHatsRequest, ListHatsResponse} from './idempotency';
+
+// CachedHaberdasherMethod is a method of Haberdasher without side effects, whose responses are cached by the clients of
+// createCachedHaberdasherClient.
+export type CachedHaberdasherMethod = "getHat" | "listHats";
+
+// CachedHaberdasher is a Haberdasher that caches the responses of its methods without side effects.
+export interface CachedHaberdasher extends Haberdasher {
+    // invalidate removes the cached response of a request of a method, the cached responses of a method, or all of
+    // the cached responses
+    invalidate(method?: CachedHaberdasherMethod, request?: object): void;
+}
+
+// createCachedHaberdasherClient wraps a Haberdasher in a client that caches the responses of the methods whose
+// idempotency_level is NO_SIDE_EFFECTS, e.g. createCachedHaberdasherClient(client, {ttl: 60000}). The other methods
+// are called by the client. The cached responses are shared by the calls, so they must not be changed.
+export const createCachedHaberdasherClient = (client: Haberdasher, options: CacheOptions): CachedHaberdasher => {
+    const cache = new ResponseCache(options);
+
+    return {
+        getHat: (request: GetHatRequest, callOptions?: CallOptions): Promise<Hat> => {
+            return cache.get("getHat", request, () => client.getHat(request, callOptions));
+        },
+        listHats: (request: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> => {
+            return cache.get("listHats", request, () => client.listHats(request, callOptions));
+        },
+        deleteHat: (request: DeleteHatRequest, callOptions?: CallOptions): Promise<DeleteHatResponse> => {
+            return client.deleteHat(request, callOptions);
+        },
+        makeHat: (request: Hat, callOptions?: CallOptions): Promise<Hat> => {
+            return client.makeHat(request, callOptions);
+        },
+        invalidate: (method?: CachedHaberdasherMethod, request?: object) => cache.invalidate(method, request),
+    };
+};
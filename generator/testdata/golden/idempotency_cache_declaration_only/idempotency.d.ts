@@ -0,0 +1,321 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    id: string;
+    size: number;
+    color: string;
+}
+
+// ReadonlyHat is the interface of a Hat returned by the clients, whose fields cannot be changed.
+export interface ReadonlyHat {
+    readonly id: string;
+    readonly size: number;
+    readonly color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly id: {readonly number: 1; readonly name: "id"; readonly type: "string"};
+    readonly size: {readonly number: 2; readonly name: "size"; readonly type: "int32"};
+    readonly color: {readonly number: 3; readonly name: "color"; readonly type: "string"};
+};
+
+export interface HatJSON {
+    id: string;
+    size: number;
+    color: string;
+}
+
+export declare const HatToJSON: (m: Hat) => HatJSON;
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+export interface GetHatRequest {
+    id: string;
+}
+
+// GetHatRequestFields are the numbers and proto types of the fields of GetHatRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const GetHatRequestFields: {
+    readonly id: {readonly number: 1; readonly name: "id"; readonly type: "string"};
+};
+
+export interface GetHatRequestJSON {
+    id: string;
+}
+
+export declare const GetHatRequestToJSON: (m: GetHatRequest) => GetHatRequestJSON;
+
+// isGetHatRequest reports if a value has the fields of a GetHatRequest, e.g. to check data read from a cache or a websocket.
+export declare const isGetHatRequest: (value: unknown) => value is GetHatRequest;
+
+export interface ListHatsRequest {
+    size: number;
+    minPrice: number;
+}
+
+// ListHatsRequestFields are the numbers and proto types of the fields of ListHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListHatsRequestFields: {
+    readonly size: {readonly number: 1; readonly name: "size"; readonly type: "int32"};
+    readonly minPrice: {readonly number: 2; readonly name: "min_price"; readonly type: "int64"};
+};
+
+export interface ListHatsRequestJSON {
+    size: number;
+    min_price: string;
+}
+
+export declare const ListHatsRequestToJSON: (m: ListHatsRequest) => ListHatsRequestJSON;
+
+// isListHatsRequest reports if a value has the fields of a ListHatsRequest, e.g. to check data read from a cache or a websocket.
+export declare const isListHatsRequest: (value: unknown) => value is ListHatsRequest;
+
+export interface ListHatsResponse {
+    hats: Hat[];
+}
+
+// ReadonlyListHatsResponse is the interface of a ListHatsResponse returned by the clients, whose fields cannot be changed.
+export interface ReadonlyListHatsResponse {
+    readonly hats: ReadonlyArray<ReadonlyHat>;
+}
+
+// ListHatsResponseFields are the numbers and proto types of the fields of ListHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListHatsResponseFields: {
+    readonly hats: {readonly number: 1; readonly name: "hats"; readonly type: "message"};
+};
+
+export interface ListHatsResponseJSON {
+    hats: HatJSON[];
+}
+
+export declare const JSONToListHatsResponse: (m: ListHatsResponseJSON) => ListHatsResponse;
+
+// isListHatsResponse reports if a value has the fields of a ListHatsResponse, e.g. to check data read from a cache or a websocket.
+export declare const isListHatsResponse: (value: unknown) => value is ListHatsResponse;
+
+export interface DeleteHatRequest {
+    id: string;
+}
+
+// DeleteHatRequestFields are the numbers and proto types of the fields of DeleteHatRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DeleteHatRequestFields: {
+    readonly id: {readonly number: 1; readonly name: "id"; readonly type: "string"};
+};
+
+export interface DeleteHatRequestJSON {
+    id: string;
+}
+
+export declare const DeleteHatRequestToJSON: (m: DeleteHatRequest) => DeleteHatRequestJSON;
+
+// isDeleteHatRequest reports if a value has the fields of a DeleteHatRequest, e.g. to check data read from a cache or a websocket.
+export declare const isDeleteHatRequest: (value: unknown) => value is DeleteHatRequest;
+
+export interface DeleteHatResponse {}
+
+// ReadonlyDeleteHatResponse is the interface of a DeleteHatResponse returned by the clients, whose fields cannot be changed.
+export interface ReadonlyDeleteHatResponse {}
+
+// DeleteHatResponseFields are the numbers and proto types of the fields of DeleteHatResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DeleteHatResponseFields: {};
+
+export interface DeleteHatResponseJSON {}
+
+export declare const JSONToDeleteHatResponse: (m: DeleteHatResponseJSON) => DeleteHatResponse;
+
+// isDeleteHatResponse reports if a value has the fields of a DeleteHatResponse, e.g. to check data read from a cache or a websocket.
+export declare const isDeleteHatResponse: (value: unknown) => value is DeleteHatResponse;
+
+/** A Haberdasher makes hats. */
+export interface Haberdasher {
+    /** GetHat only reads a hat, so its calls are retried after a network failure. */
+    getHat: (getHatRequest: GetHatRequest, callOptions?: CallOptions) => Promise<ReadonlyHat>;
+
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ReadonlyListHatsResponse>;
+
+    /** DeleteHat has the effect of a single call when it is repeated. */
+    deleteHat: (deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions) => Promise<ReadonlyDeleteHatResponse>;
+
+    /** MakeHat makes a new hat for each call, so its calls are not retried. */
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<ReadonlyHat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const HaberdasherMethods: {
+    readonly getHat: {
+        readonly service: "idempotency.Haberdasher";
+        readonly method: "GetHat";
+        readonly path: "/twirp/idempotency.Haberdasher/GetHat";
+        readonly inputType: "GetHatRequest";
+        readonly outputType: "Hat";
+    };
+    readonly listHats: {
+        readonly service: "idempotency.Haberdasher";
+        readonly method: "ListHats";
+        readonly path: "/twirp/idempotency.Haberdasher/ListHats";
+        readonly inputType: "ListHatsRequest";
+        readonly outputType: "ListHatsResponse";
+    };
+    readonly deleteHat: {
+        readonly service: "idempotency.Haberdasher";
+        readonly method: "DeleteHat";
+        readonly path: "/twirp/idempotency.Haberdasher/DeleteHat";
+        readonly inputType: "DeleteHatRequest";
+        readonly outputType: "DeleteHatResponse";
+    };
+    readonly makeHat: {
+        readonly service: "idempotency.Haberdasher";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/idempotency.Haberdasher/MakeHat";
+        readonly inputType: "Hat";
+        readonly outputType: "Hat";
+    };
+};
+
+/** A Haberdasher makes hats. */
+export declare class DefaultHaberdasher implements Haberdasher {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    /** GetHat only reads a hat, so its calls are retried after a network failure. */
+    getHat(getHatRequest: GetHatRequest, callOptions?: CallOptions): Promise<ReadonlyHat>;
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse>;
+    /** DeleteHat has the effect of a single call when it is repeated. */
+    deleteHat(deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions): Promise<ReadonlyDeleteHatResponse>;
+    /** MakeHat makes a new hat for each call, so its calls are not retried. */
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat>;
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export declare const createHaberdasherClient: (config: TwirpClientConfig) => DefaultHaberdasher;
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    getHat?: ReadonlyHat | ((getHatRequest: GetHatRequest, callOptions?: CallOptions) => ReadonlyHat | Promise<ReadonlyHat>);
+    listHats?: ReadonlyListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ReadonlyListHatsResponse | Promise<ReadonlyListHatsResponse>);
+    deleteHat?: ReadonlyDeleteHatResponse | ((deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions) => ReadonlyDeleteHatResponse | Promise<ReadonlyDeleteHatResponse>);
+    makeHat?: ReadonlyHat | ((hat: Hat, callOptions?: CallOptions) => ReadonlyHat | Promise<ReadonlyHat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses?: HaberdasherMockResponses);
+
+    getHat(getHatRequest: GetHatRequest, callOptions?: CallOptions): Promise<ReadonlyHat>;
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse>;
+    deleteHat(deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions): Promise<ReadonlyDeleteHatResponse>;
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat>;
+}
+
+export declare const createHaberdasherMock: (overrides?: HaberdasherMockResponses) => HaberdasherMockClient;
+
+/** A Milliner has no methods without side effects, so it has no caching client. */
+export interface Milliner {
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<ReadonlyHat>;
+}
+
+// MillinerMethods are the Twirp routes of the methods of Milliner, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const MillinerMethods: {
+    readonly makeHat: {
+        readonly service: "idempotency.Milliner";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/idempotency.Milliner/MakeHat";
+        readonly inputType: "Hat";
+        readonly outputType: "Hat";
+    };
+};
+
+/** A Milliner has no methods without side effects, so it has no caching client. */
+export declare class DefaultMilliner implements Milliner {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat>;
+}
+
+// createMillinerClient creates a DefaultMilliner with the config, which may be shared by the clients of other services.
+export declare const createMillinerClient: (config: TwirpClientConfig) => DefaultMilliner;
+
+// A MillinerMockResponses sets the response of each MillinerMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface MillinerMockResponses {
+    makeHat?: ReadonlyHat | ((hat: Hat, callOptions?: CallOptions) => ReadonlyHat | Promise<ReadonlyHat>);
+}
+
+// MillinerMockClient is a Milliner for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class MillinerMockClient implements Milliner {
+    responses: MillinerMockResponses;
+
+    constructor(responses?: MillinerMockResponses);
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat>;
+}
+
+export declare const createMillinerMock: (overrides?: MillinerMockResponses) => MillinerMockClient;
@@ -0,0 +1,18 @@
+import {CacheOptions} from './twirp_cache';
+import {Haberdasher} from './idempotency';
+
+// CachedHaberdasherMethod is a method of Haberdasher without side effects, whose responses are cached by the clients of
+// createCachedHaberdasherClient.
+export type CachedHaberdasherMethod = "getHat" | "listHats";
+
+// CachedHaberdasher is a Haberdasher that caches the responses of its methods without side effects.
+export interface CachedHaberdasher extends Haberdasher {
+    // invalidate removes the cached response of a request of a method, the cached responses of a method, or all of
+    // the cached responses
+    invalidate(method?: CachedHaberdasherMethod, request?: object): void;
+}
+
+// createCachedHaberdasherClient wraps a Haberdasher in a client that caches the responses of the methods whose
+// idempotency_level is NO_SIDE_EFFECTS, e.g. createCachedHaberdasherClient(client, {ttl: 60000}). The other methods
+// are called by the client. The cached responses are shared by the calls, so they must not be changed.
+export declare const createCachedHaberdasherClient: (client: Haberdasher, options: CacheOptions) => CachedHaberdasher;
@@ -0,0 +1,530 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, CallOptions, everyItem} from './twirp';
+import {InterceptorContext, retryNetworkFailures, TwirpClient, runInterceptors} from './interceptors';
+import {restRequest, createRESTRequest, restResponse, throwRESTError} from './twirp_rest';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    id: string;
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    id: {number: 1, name: "id", type: "string"},
+    size: {number: 2, name: "size", type: "int32"},
+    color: {number: 3, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    id: string;
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        id: m.id,
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        id: m.id,
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "string"
+        && typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+export interface GetHatRequest {
+    id: string;
+}
+
+// GetHatRequestFields are the numbers and proto types of the fields of GetHatRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetHatRequestFields = {
+    id: {number: 1, name: "id", type: "string"},
+} as const;
+
+export interface GetHatRequestJSON {
+    id: string;
+}
+
+export const GetHatRequestToJSON = (m: GetHatRequest): GetHatRequestJSON => {
+    return {
+        id: m.id,
+    };
+};
+
+// isGetHatRequest reports if a value has the fields of a GetHatRequest, e.g. to check data read from a cache or a websocket.
+export const isGetHatRequest = (value: unknown): value is GetHatRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "string";
+};
+
+export interface ListHatsRequest {
+    size: number;
+    minPrice: number;
+}
+
+// ListHatsRequestFields are the numbers and proto types of the fields of ListHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsRequestFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    minPrice: {number: 2, name: "min_price", type: "int64"},
+} as const;
+
+export interface ListHatsRequestJSON {
+    size: number;
+    min_price: string;
+}
+
+export const ListHatsRequestToJSON = (m: ListHatsRequest): ListHatsRequestJSON => {
+    return {
+        size: m.size,
+        min_price: String(m.minPrice),
+    };
+};
+
+// isListHatsRequest reports if a value has the fields of a ListHatsRequest, e.g. to check data read from a cache or a websocket.
+export const isListHatsRequest = (value: unknown): value is ListHatsRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.minPrice === "number";
+};
+
+export interface ListHatsResponse {
+    hats: Hat[];
+}
+
+// ListHatsResponseFields are the numbers and proto types of the fields of ListHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsResponseFields = {
+    hats: {number: 1, name: "hats", type: "message"},
+} as const;
+
+export interface ListHatsResponseJSON {
+    hats: HatJSON[];
+}
+
+export const JSONToListHatsResponse = (m: ListHatsResponseJSON): ListHatsResponse => {
+    return {
+        hats: m.hats.map(JSONToHat),
+    };
+};
+
+// isListHatsResponse reports if a value has the fields of a ListHatsResponse, e.g. to check data read from a cache or a websocket.
+export const isListHatsResponse = (value: unknown): value is ListHatsResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.hats, isHat);
+};
+
+export interface DeleteHatRequest {
+    id: string;
+}
+
+// DeleteHatRequestFields are the numbers and proto types of the fields of DeleteHatRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DeleteHatRequestFields = {
+    id: {number: 1, name: "id", type: "string"},
+} as const;
+
+export interface DeleteHatRequestJSON {
+    id: string;
+}
+
+export const DeleteHatRequestToJSON = (m: DeleteHatRequest): DeleteHatRequestJSON => {
+    return {
+        id: m.id,
+    };
+};
+
+// isDeleteHatRequest reports if a value has the fields of a DeleteHatRequest, e.g. to check data read from a cache or a websocket.
+export const isDeleteHatRequest = (value: unknown): value is DeleteHatRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "string";
+};
+
+export interface DeleteHatResponse {}
+
+// DeleteHatResponseFields are the numbers and proto types of the fields of DeleteHatResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DeleteHatResponseFields = {} as const;
+
+export interface DeleteHatResponseJSON {}
+
+export const JSONToDeleteHatResponse = (m: DeleteHatResponseJSON): DeleteHatResponse => {
+    return {};
+};
+
+// isDeleteHatResponse reports if a value has the fields of a DeleteHatResponse, e.g. to check data read from a cache or a websocket.
+export const isDeleteHatResponse = (value: unknown): value is DeleteHatResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    return true;
+};
+
+/** A Haberdasher makes hats. */
+export interface Haberdasher {
+    /** GetHat only reads a hat, so its calls are retried after a network failure. */
+    getHat: (getHatRequest: GetHatRequest, callOptions?: CallOptions) => Promise<Hat>;
+
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ListHatsResponse>;
+
+    /** DeleteHat has the effect of a single call when it is repeated. */
+    deleteHat: (deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions) => Promise<DeleteHatResponse>;
+
+    /** MakeHat makes a new hat for each call, so its calls are not retried. */
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    getHat: {
+        service: "idempotency.Haberdasher",
+        method: "GetHat",
+        path: "/twirp/idempotency.Haberdasher/GetHat",
+        inputType: "GetHatRequest",
+        outputType: "Hat",
+    },
+    listHats: {
+        service: "idempotency.Haberdasher",
+        method: "ListHats",
+        path: "/twirp/idempotency.Haberdasher/ListHats",
+        inputType: "ListHatsRequest",
+        outputType: "ListHatsResponse",
+    },
+    deleteHat: {
+        service: "idempotency.Haberdasher",
+        method: "DeleteHat",
+        path: "/twirp/idempotency.Haberdasher/DeleteHat",
+        inputType: "DeleteHatRequest",
+        outputType: "DeleteHatResponse",
+    },
+    makeHat: {
+        service: "idempotency.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/idempotency.Haberdasher/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** GetHat only reads a hat, so its calls are retried after a network failure. */
+export const getHat = (client: TwirpClient, getHatRequest: GetHatRequest, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/idempotency.Haberdasher/GetHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Haberdasher",
+            method: "GetHat",
+            url: url,
+            request: getHatRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "no_side_effects",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createTwirpRequest(ctx.url, GetHatRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// getHatRest calls Haberdasher.GetHat with its REST route, GET /v1/hats/{id}
+export const getHatRest = (client: TwirpClient, getHatRequest: GetHatRequest, callOptions?: CallOptions): Promise<Hat> => {
+    const rule = {method: "GET", path: "/v1/hats/{id}"};
+    const rest = restRequest(rule, GetHatRequestToJSON(getHatRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Haberdasher",
+            method: "GetHat",
+            url: url,
+            request: getHatRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "no_side_effects",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const listHats = (client: TwirpClient, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/idempotency.Haberdasher/ListHats");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Haberdasher",
+            method: "ListHats",
+            url: url,
+            request: listHatsRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "no_side_effects",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToListHatsResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+/** DeleteHat has the effect of a single call when it is repeated. */
+export const deleteHat = (client: TwirpClient, deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions): Promise<DeleteHatResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/idempotency.Haberdasher/DeleteHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Haberdasher",
+            method: "DeleteHat",
+            url: url,
+            request: deleteHatRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "idempotent",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createTwirpRequest(ctx.url, DeleteHatRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToDeleteHatResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+/** MakeHat makes a new hat for each call, so its calls are not retried. */
+export const haberdasherMakeHat = (client: TwirpClient, hat: Hat, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/idempotency.Haberdasher/MakeHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Haberdasher",
+            method: "MakeHat",
+            url: url,
+            request: hat,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createHaberdasherClient creates a Haberdasher of the rpc functions of Haberdasher, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createHaberdasherClient = (client: TwirpClient): Haberdasher => {
+    return {
+        getHat: (getHatRequest: GetHatRequest, callOptions?: CallOptions) => getHat(client, getHatRequest, callOptions),
+        listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => listHats(client, listHatsRequest, callOptions),
+        deleteHat: (deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions) => deleteHat(client, deleteHatRequest, callOptions),
+        makeHat: (hat: Hat, callOptions?: CallOptions) => haberdasherMakeHat(client, hat, callOptions),
+    };
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    getHat?: Hat | ((getHatRequest: GetHatRequest, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    listHats?: ListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ListHatsResponse | Promise<ListHatsResponse>);
+    deleteHat?: DeleteHatResponse | ((deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions) => DeleteHatResponse | Promise<DeleteHatResponse>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    getHat(getHatRequest: GetHatRequest, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.getHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.GetHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(getHatRequest, callOptions) : response));
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> {
+        const response = this.responses.listHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.ListHats"}));
+        }
+
+        return new Promise<ListHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+
+    deleteHat(deleteHatRequest: DeleteHatRequest, callOptions?: CallOptions): Promise<DeleteHatResponse> {
+        const response = this.responses.deleteHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.DeleteHat"}));
+        }
+
+        return new Promise<DeleteHatResponse>((resolve) => resolve(typeof response === "function" ? response(deleteHatRequest, callOptions) : response));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
+
+/** A Milliner has no methods without side effects, so it has no caching client. */
+export interface Milliner {
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// MillinerMethods are the Twirp routes of the methods of Milliner, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const MillinerMethods = {
+    makeHat: {
+        service: "idempotency.Milliner",
+        method: "MakeHat",
+        path: "/twirp/idempotency.Milliner/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+export const millinerMakeHat = (client: TwirpClient, hat: Hat, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/idempotency.Milliner/MakeHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "idempotency.Milliner",
+            method: "MakeHat",
+            url: url,
+            request: hat,
+            headers: options.headers || {},
+            signal: options.signal,
+            idempotency: "idempotent",
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return retryNetworkFailures(ctx, client.transport)(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createMillinerClient creates a Milliner of the rpc functions of Milliner, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createMillinerClient = (client: TwirpClient): Milliner => {
+    return {
+        makeHat: (hat: Hat, callOptions?: CallOptions) => millinerMakeHat(client, hat, callOptions),
+    };
+};
+
+// A MillinerMockResponses sets the response of each MillinerMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface MillinerMockResponses {
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// MillinerMockClient is a Milliner for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class MillinerMockClient implements Milliner {
+    responses: MillinerMockResponses;
+
+    constructor(responses: MillinerMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Milliner.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createMillinerMock = (overrides: MillinerMockResponses = {}): MillinerMockClient => {
+    return new MillinerMockClient(overrides);
+};
@@ -0,0 +1,72 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+import {SharedPage} from './common';
+
+export interface Admin {
+    reset: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+}
+
+// AdminMethods are the Twirp routes of the methods of Admin, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const AdminMethods: {
+    readonly reset: {
+        readonly service: "imports.Admin";
+        readonly method: "Reset";
+        readonly path: "/twirp/imports.Admin/Reset";
+        readonly inputType: "SharedPage";
+        readonly outputType: "SharedPage";
+    };
+};
+
+export declare class DefaultAdmin implements Admin {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage>;
+}
+
+// createAdminClient creates a DefaultAdmin with the config, which may be shared by the clients of other services.
+export declare const createAdminClient: (config: TwirpClientConfig) => DefaultAdmin;
+
+// A AdminMockResponses sets the response of each AdminMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AdminMockResponses {
+    reset?: SharedPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => SharedPage | Promise<SharedPage>);
+}
+
+// AdminMockClient is a Admin for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class AdminMockClient implements Admin {
+    responses: AdminMockResponses;
+
+    constructor(responses?: AdminMockResponses);
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage>;
+}
+
+export declare const createAdminMock: (overrides?: AdminMockResponses) => AdminMockClient;
@@ -0,0 +1,15 @@
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {SharedPage} from './common';
+import {DefaultAdmin} from './imports_admin';
+
+// AdminService is an Angular service of the Admin rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+export declare class AdminService {
+    readonly client: DefaultAdmin;
+
+    constructor(http: HttpClient, hostname: string, prefix?: string | null);
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Observable<SharedPage>;
+}
@@ -0,0 +1,73 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+import {SharedPage} from './common';
+import {ImportsPage} from './imports';
+
+export interface Catalog {
+    list: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+}
+
+// CatalogMethods are the Twirp routes of the methods of Catalog, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const CatalogMethods: {
+    readonly list: {
+        readonly service: "imports.Catalog";
+        readonly method: "List";
+        readonly path: "/twirp/imports.Catalog/List";
+        readonly inputType: "SharedPage";
+        readonly outputType: "ImportsPage";
+    };
+};
+
+export declare class DefaultCatalog implements Catalog {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage>;
+}
+
+// createCatalogClient creates a DefaultCatalog with the config, which may be shared by the clients of other services.
+export declare const createCatalogClient: (config: TwirpClientConfig) => DefaultCatalog;
+
+// A CatalogMockResponses sets the response of each CatalogMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CatalogMockResponses {
+    list?: ImportsPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => ImportsPage | Promise<ImportsPage>);
+}
+
+// CatalogMockClient is a Catalog for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class CatalogMockClient implements Catalog {
+    responses: CatalogMockResponses;
+
+    constructor(responses?: CatalogMockResponses);
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage>;
+}
+
+export declare const createCatalogMock: (overrides?: CatalogMockResponses) => CatalogMockClient;
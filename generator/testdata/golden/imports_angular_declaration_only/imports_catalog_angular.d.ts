@@ -0,0 +1,16 @@
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {SharedPage} from './common';
+import {ImportsPage} from './imports';
+import {DefaultCatalog} from './imports_catalog';
+
+// CatalogService is an Angular service of the Catalog rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+export declare class CatalogService {
+    readonly client: DefaultCatalog;
+
+    constructor(http: HttpClient, hostname: string, prefix?: string | null);
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Observable<ImportsPage>;
+}
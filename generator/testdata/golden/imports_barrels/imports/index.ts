@@ -0,0 +1,5 @@
+export * from '../imports';
+
+export * from '../imports_admin';
+
+export * from '../imports_catalog';
@@ -0,0 +1,11 @@
+export * from './interceptors';
+
+export * from './transports';
+
+export * from './twirp';
+
+import * as imports from './imports/index';
+export {imports};
+
+import * as shared from './shared/index';
+export {shared};
@@ -0,0 +1,69 @@
+import {BuildWhenSet, messageBuilder} from './twirp';
+
+export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export interface SharedPage {
+    offset: number;
+    limit: number;
+}
+
+// SharedPageFields are the numbers and proto types of the fields of SharedPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SharedPageFields = {
+    offset: {number: 1, name: "offset", type: "int32"},
+    limit: {number: 2, name: "limit", type: "int32"},
+} as const;
+
+export interface SharedPageJSON {
+    offset: number;
+    limit: number;
+}
+
+export const SharedPageToJSON = (m: SharedPage): SharedPageJSON => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+export const JSONToSharedPage = (m: SharedPageJSON): SharedPage => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+// isSharedPage reports if a value has the fields of a SharedPage, e.g. to check data read from a cache or a websocket.
+export const isSharedPage = (value: unknown): value is SharedPage => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.offset === "number"
+        && typeof m.limit === "number";
+};
+
+// createSharedPage creates a SharedPage whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createSharedPage = (partial: Partial<SharedPage> = {}): SharedPage => {
+    return {
+        offset: partial.offset !== undefined ? partial.offset : 0,
+        limit: partial.limit !== undefined ? partial.limit : 0,
+    };
+};
+
+// SharedPageBuilder builds a SharedPage one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface SharedPageBuilder<Set extends string = never> {
+    offset(value: number): SharedPageBuilder<Set | "offset">;
+    limit(value: number): SharedPageBuilder<Set | "limit">;
+    build: BuildWhenSet<never, Set, SharedPage>;
+}
+
+// buildSharedPage starts a SharedPageBuilder, e.g. to build a request whose required fields are checked at compile time.
+export const buildSharedPage = (): SharedPageBuilder => messageBuilder<SharedPageBuilder>(createSharedPage, ["offset", "limit"]);
@@ -0,0 +1,46 @@
+import {BuildWhenSet} from './twirp';
+
+export declare enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export interface SharedPage {
+    offset: number;
+    limit: number;
+}
+
+// SharedPageFields are the numbers and proto types of the fields of SharedPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SharedPageFields: {
+    readonly offset: {readonly number: 1; readonly name: "offset"; readonly type: "int32"};
+    readonly limit: {readonly number: 2; readonly name: "limit"; readonly type: "int32"};
+};
+
+export interface SharedPageJSON {
+    offset: number;
+    limit: number;
+}
+
+export declare const SharedPageToJSON: (m: SharedPage) => SharedPageJSON;
+
+export declare const JSONToSharedPage: (m: SharedPageJSON) => SharedPage;
+
+// isSharedPage reports if a value has the fields of a SharedPage, e.g. to check data read from a cache or a websocket.
+export declare const isSharedPage: (value: unknown) => value is SharedPage;
+
+// createSharedPage creates a SharedPage whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createSharedPage: (partial?: Partial<SharedPage>) => SharedPage;
+
+// SharedPageBuilder builds a SharedPage one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface SharedPageBuilder<Set extends string = never> {
+    offset(value: number): SharedPageBuilder<Set | "offset">;
+    limit(value: number): SharedPageBuilder<Set | "limit">;
+    build: BuildWhenSet<never, Set, SharedPage>;
+}
+
+// buildSharedPage starts a SharedPageBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildSharedPage: () => SharedPageBuilder;
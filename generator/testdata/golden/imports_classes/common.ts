@@ -0,0 +1,77 @@
+import {cloneValue, valuesEqual} from './twirp';
+
+export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export class SharedPage {
+    offset: number;
+    limit: number;
+
+    constructor(init: Partial<SharedPage> = {}) {
+        this.offset = init.offset !== undefined ? init.offset : 0;
+        this.limit = init.limit !== undefined ? init.limit : 0;
+    }
+
+    // clone returns a deep copy of the SharedPage.
+    clone(): SharedPage {
+        return new SharedPage({
+            offset: cloneValue(this.offset),
+            limit: cloneValue(this.limit),
+        });
+    }
+
+    // equals reports if the fields of the SharedPage are deeply equal to those of other.
+    equals(other: SharedPage): boolean {
+        return valuesEqual(this.offset, other.offset)
+            && valuesEqual(this.limit, other.limit);
+    }
+
+    static fromJSON(m: SharedPageJSON): SharedPage {
+        return JSONToSharedPage(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a SharedPage is stringified as its proto3 JSON.
+    toJSON(): SharedPageJSON {
+        return SharedPageToJSON(this);
+    }
+}
+
+// SharedPageFields are the numbers and proto types of the fields of SharedPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SharedPageFields = {
+    offset: {number: 1, name: "offset", type: "int32"},
+    limit: {number: 2, name: "limit", type: "int32"},
+} as const;
+
+export interface SharedPageJSON {
+    offset: number;
+    limit: number;
+}
+
+export const SharedPageToJSON = (m: SharedPage): SharedPageJSON => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+export const JSONToSharedPage = (m: SharedPageJSON): SharedPage => {
+    return new SharedPage({
+        offset: m.offset,
+        limit: m.limit,
+    });
+};
+
+// isSharedPage reports if a value has the fields of a SharedPage, e.g. to check data read from a cache or a websocket.
+export const isSharedPage = (value: unknown): value is SharedPage => {
+    if (!(value instanceof SharedPage)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.offset === "number"
+        && typeof m.limit === "number";
+};
@@ -0,0 +1,74 @@
+import {enumFromJSON, everyItem} from './twirp';
+import {cloneValue, valuesEqual} from './twirp';
+import {Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export class ImportsPage {
+    items: string[];
+    status: Status;
+
+    constructor(init: Partial<ImportsPage> = {}) {
+        this.items = init.items !== undefined ? init.items : [];
+        this.status = init.status !== undefined ? init.status : 0;
+    }
+
+    // clone returns a deep copy of the ImportsPage.
+    clone(): ImportsPage {
+        return new ImportsPage({
+            items: cloneValue(this.items),
+            status: cloneValue(this.status),
+        });
+    }
+
+    // equals reports if the fields of the ImportsPage are deeply equal to those of other.
+    equals(other: ImportsPage): boolean {
+        return valuesEqual(this.items, other.items)
+            && valuesEqual(this.status, other.status);
+    }
+
+    static fromJSON(m: ImportsPageJSON): ImportsPage {
+        return JSONToImportsPage(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a ImportsPage is stringified as its proto3 JSON.
+    toJSON(): ImportsPageJSON {
+        return ImportsPageToJSON(this);
+    }
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImportsPageFields = {
+    items: {number: 1, name: "items", type: "string"},
+    status: {number: 2, name: "status", type: "enum"},
+} as const;
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export const ImportsPageToJSON = (m: ImportsPage): ImportsPageJSON => {
+    return {
+        items: m.items,
+        status: Status[m.status],
+    };
+};
+
+export const JSONToImportsPage = (m: ImportsPageJSON): ImportsPage => {
+    return new ImportsPage({
+        items: m.items,
+        status: enumFromJSON<Status>(Status, m.status),
+    });
+};
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export const isImportsPage = (value: unknown): value is ImportsPage => {
+    if (!(value instanceof ImportsPage)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.items, (v) => typeof v === "string")
+        && typeof m.status === "number";
+};
@@ -0,0 +1,131 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {JSONToSharedPage, SharedPage, SharedPageToJSON} from './common';
+
+export interface Admin {
+    reset: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+}
+
+// AdminMethods are the Twirp routes of the methods of Admin, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AdminMethods = {
+    reset: {
+        service: "imports.Admin",
+        method: "Reset",
+        path: "/twirp/imports.Admin/Reset",
+        inputType: "SharedPage",
+        outputType: "SharedPage",
+    },
+} as const;
+
+export class DefaultAdmin implements Admin {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/imports.Admin/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Reset");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "imports.Admin",
+                method: "Reset",
+                url: url,
+                request: sharedPage,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SharedPageToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToSharedPage(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createAdminClient creates a DefaultAdmin with the config, which may be shared by the clients of other services.
+export const createAdminClient = (config: TwirpClientConfig): DefaultAdmin => {
+    return new DefaultAdmin(config);
+};
+
+// A AdminMockResponses sets the response of each AdminMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AdminMockResponses {
+    reset?: SharedPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => SharedPage | Promise<SharedPage>);
+}
+
+// AdminMockClient is a Admin for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AdminMockClient implements Admin {
+    responses: AdminMockResponses;
+
+    constructor(responses: AdminMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage> {
+        const response = this.responses.reset;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Admin.Reset"}));
+        }
+
+        return new Promise<SharedPage>((resolve) => resolve(typeof response === "function" ? response(sharedPage, callOptions) : response));
+    }
+}
+
+export const createAdminMock = (overrides: AdminMockResponses = {}): AdminMockClient => {
+    return new AdminMockClient(overrides);
+};
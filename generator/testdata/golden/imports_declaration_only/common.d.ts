@@ -0,0 +1,29 @@
+export declare enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export interface SharedPage {
+    offset: number;
+    limit: number;
+}
+
+// SharedPageFields are the numbers and proto types of the fields of SharedPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SharedPageFields: {
+    readonly offset: {readonly number: 1; readonly name: "offset"; readonly type: "int32"};
+    readonly limit: {readonly number: 2; readonly name: "limit"; readonly type: "int32"};
+};
+
+export interface SharedPageJSON {
+    offset: number;
+    limit: number;
+}
+
+export declare const SharedPageToProtobuf: (m: SharedPage) => Uint8Array;
+
+export declare const ProtobufToSharedPage: (b: Uint8Array) => SharedPage;
+
+// isSharedPage reports if a value has the fields of a SharedPage, e.g. to check data read from a cache or a websocket.
+export declare const isSharedPage: (value: unknown) => value is SharedPage;
@@ -0,0 +1,185 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+import {ServerRequest, TwirpRouter} from './twirp_server';
+import {SharedPage, Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export interface ImportsPage {
+    items: string[];
+    status: Status;
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ImportsPageFields: {
+    readonly items: {readonly number: 1; readonly name: "items"; readonly type: "string"};
+    readonly status: {readonly number: 2; readonly name: "status"; readonly type: "enum"};
+};
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export declare const ImportsPageToProtobuf: (m: ImportsPage) => Uint8Array;
+
+export declare const ProtobufToImportsPage: (b: Uint8Array) => ImportsPage;
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export declare const isImportsPage: (value: unknown) => value is ImportsPage;
+
+export interface Catalog {
+    list: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+}
+
+// CatalogMethods are the Twirp routes of the methods of Catalog, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const CatalogMethods: {
+    readonly list: {
+        readonly service: "imports.Catalog";
+        readonly method: "List";
+        readonly path: "/twirp/imports.Catalog/List";
+        readonly inputType: "SharedPage";
+        readonly outputType: "ImportsPage";
+    };
+};
+
+export declare class DefaultCatalog implements Catalog {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage>;
+}
+
+// createCatalogClient creates a DefaultCatalog with the config, which may be shared by the clients of other services.
+export declare const createCatalogClient: (config: TwirpClientConfig) => DefaultCatalog;
+
+// A CatalogMockResponses sets the response of each CatalogMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CatalogMockResponses {
+    list?: ImportsPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => ImportsPage | Promise<ImportsPage>);
+}
+
+// CatalogMockClient is a Catalog for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class CatalogMockClient implements Catalog {
+    responses: CatalogMockResponses;
+
+    constructor(responses?: CatalogMockResponses);
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage>;
+}
+
+export declare const createCatalogMock: (overrides?: CatalogMockResponses) => CatalogMockClient;
+
+// CatalogHandler implements the Catalog rpc methods for a server created with createCatalogRouter.
+export interface CatalogHandler {
+    list(sharedPage: SharedPage, req: ServerRequest): ImportsPage | Promise<ImportsPage>;
+}
+
+// createCatalogRouter serves the Catalog rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(createCatalogRouter(handler))
+export declare const createCatalogRouter: (handler: CatalogHandler) => TwirpRouter;
+
+export interface Admin {
+    reset: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+}
+
+// AdminMethods are the Twirp routes of the methods of Admin, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const AdminMethods: {
+    readonly reset: {
+        readonly service: "imports.Admin";
+        readonly method: "Reset";
+        readonly path: "/twirp/imports.Admin/Reset";
+        readonly inputType: "SharedPage";
+        readonly outputType: "SharedPage";
+    };
+};
+
+export declare class DefaultAdmin implements Admin {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage>;
+}
+
+// createAdminClient creates a DefaultAdmin with the config, which may be shared by the clients of other services.
+export declare const createAdminClient: (config: TwirpClientConfig) => DefaultAdmin;
+
+// A AdminMockResponses sets the response of each AdminMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AdminMockResponses {
+    reset?: SharedPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => SharedPage | Promise<SharedPage>);
+}
+
+// AdminMockClient is a Admin for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class AdminMockClient implements Admin {
+    responses: AdminMockResponses;
+
+    constructor(responses?: AdminMockResponses);
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage>;
+}
+
+export declare const createAdminMock: (overrides?: AdminMockResponses) => AdminMockClient;
+
+// AdminHandler implements the Admin rpc methods for a server created with createAdminRouter.
+export interface AdminHandler {
+    reset(sharedPage: SharedPage, req: ServerRequest): SharedPage | Promise<SharedPage>;
+}
+
+// createAdminRouter serves the Admin rpc methods with the handler. The router can be used as a
+// Node http request listener, or as Express middleware, e.g. app.use(createAdminRouter(handler))
+export declare const createAdminRouter: (handler: AdminHandler) => TwirpRouter;
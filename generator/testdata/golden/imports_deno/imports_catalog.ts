@@ -0,0 +1,133 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp.ts';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors.ts';
+import {fetchTransport} from './transports.ts';
+import {SharedPage, SharedPageToJSON} from './common.ts';
+import {ImportsPage, JSONToImportsPage} from './imports.ts';
+
+export interface Catalog {
+    list: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+}
+
+// CatalogMethods are the Twirp routes of the methods of Catalog, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const CatalogMethods = {
+    list: {
+        service: "imports.Catalog",
+        method: "List",
+        path: "/twirp/imports.Catalog/List",
+        inputType: "SharedPage",
+        outputType: "ImportsPage",
+    },
+} as const;
+
+export class DefaultCatalog implements Catalog {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport: Transport = fetchTransport(fetch), headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/imports.Catalog/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage> {
+        const url = joinURL(this.hostname, this.pathPrefix + "List");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "imports.Catalog",
+                method: "List",
+                url: url,
+                request: sharedPage,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SharedPageToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToImportsPage(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createCatalogClient creates a DefaultCatalog with the config, which may be shared by the clients of other services.
+export const createCatalogClient = (config: TwirpClientConfig): DefaultCatalog => {
+    return new DefaultCatalog(config);
+};
+
+// A CatalogMockResponses sets the response of each CatalogMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CatalogMockResponses {
+    list?: ImportsPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => ImportsPage | Promise<ImportsPage>);
+}
+
+// CatalogMockClient is a Catalog for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class CatalogMockClient implements Catalog {
+    responses: CatalogMockResponses;
+
+    constructor(responses: CatalogMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage> {
+        const response = this.responses.list;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Catalog.List"}));
+        }
+
+        return new Promise<ImportsPage>((resolve) => resolve(typeof response === "function" ? response(sharedPage, callOptions) : response));
+    }
+}
+
+export const createCatalogMock = (overrides: CatalogMockResponses = {}): CatalogMockClient => {
+    return new CatalogMockClient(overrides);
+};
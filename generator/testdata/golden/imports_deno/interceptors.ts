@@ -0,0 +1,454 @@
+import {TwirpError, TwirpErrorCode, TwirpHeaders, HeadersProvider, Transport, TransportResponse} from './twirp.ts';
+
+export interface InterceptorContext {
+    // fully qualified service name, e.g. twitch.twirp.example.Haberdasher
+    service: string;
+    // rpc method name, e.g. MakeHat
+    method: string;
+    url: string;
+    // the request message
+    request: any;
+    // headers sent with the request, which can be modified by interceptors
+    headers: TwirpHeaders;
+    signal?: AbortSignal;
+    // redactor redacts the sensitive fields of the request and the response of the call, e.g. for logs and error
+    // reports, and is only set for the calls of messages with sensitive fields, see redactRequest
+    redactor?: Redactor;
+    // idempotency is the idempotency_level of the method, which is only set for the methods that are safe to retry,
+    // see retryNetworkFailures
+    idempotency?: Idempotency;
+}
+
+// Idempotency is the idempotency_level of an rpc method that is safe to retry, which is no_side_effects for a method
+// that only reads, and idempotent for a method whose calls have the effect of a single call when they are repeated.
+export type Idempotency = "no_side_effects" | "idempotent";
+
+// Redactor has the redact functions of the messages of a call with sensitive fields, e.g. redactLoginRequest.
+export interface Redactor {
+    request?: (m: any) => any;
+    response?: (m: any) => any;
+}
+
+// redactRequest copies the request of a call for logs and error reports, whose sensitive fields are redacted.
+export const redactRequest = (ctx: InterceptorContext): any => {
+    return ctx.redactor && ctx.redactor.request ? ctx.redactor.request(ctx.request) : ctx.request;
+};
+
+// redactResponse copies the response of a call for logs and error reports, whose sensitive fields are redacted.
+export const redactResponse = (ctx: InterceptorContext, resp: any): any => {
+    return ctx.redactor && ctx.redactor.response ? ctx.redactor.response(resp) : resp;
+};
+
+// Next continues the call with the next interceptor, resolving to the response message.
+export type Next = (ctx: InterceptorContext) => Promise<any>;
+
+// Interceptor wraps an rpc call, e.g. for logging, auth refresh, or tracing.
+export type Interceptor = (ctx: InterceptorContext, next: Next) => Promise<any>;
+
+export class InterceptorChain {
+    private interceptors: Interceptor[] = [];
+
+    use(interceptor: Interceptor) {
+        this.interceptors.push(interceptor);
+    }
+
+    run<T>(ctx: InterceptorContext, call: (ctx: InterceptorContext) => Promise<T>): Promise<T> {
+        const dispatch = (i: number, ctx: InterceptorContext): Promise<T> => {
+            if (i >= this.interceptors.length) {
+                return call(ctx);
+            }
+
+            return this.interceptors[i](ctx, (next) => dispatch(i + 1, next));
+        };
+
+        return dispatch(0, ctx);
+    }
+}
+
+// TwirpClientConfig configures the clients of the services, e.g. createHaberdasherClient(config), so the clients of
+// many services can share the hostname of their Twirp server, their transport and their headers.
+export interface TwirpClientConfig {
+    hostname: string;
+    transport: Transport;
+    headers?: TwirpHeaders | HeadersProvider;
+    // prefix is the path prefix of the Twirp routes, which is the twirp_prefix of the generated code by default
+    prefix?: string;
+    // interceptors wrap every call of the client, in order, e.g. [retryInterceptor(policy)]
+    interceptors?: Interceptor[];
+    // timeoutMs is the timeout of every call, unless it is set by the CallOptions of the call
+    timeoutMs?: number;
+}
+
+// TwirpClient is the client of the rpc functions that are generated with client_style=functions, e.g.
+// makeHat(client, size), which call the Twirp server at its hostname with its transport.
+export type TwirpClient = TwirpClientConfig;
+
+// clientConfig is the TwirpClientConfig of a client that is constructed with a config, or with the positional
+// arguments of its constructor, which are its hostname, transport, headers and prefix.
+export const clientConfig = (hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string): TwirpClientConfig => {
+    if (typeof hostname !== "string") {
+        return hostname;
+    }
+
+    return {hostname: hostname, transport: transport as Transport, headers: headers, prefix: prefix};
+};
+
+// runInterceptors runs a call of an rpc function through the interceptors of its TwirpClient.
+export const runInterceptors = <T>(interceptors: Interceptor[] | undefined, ctx: InterceptorContext, call: (ctx: InterceptorContext) => Promise<T>): Promise<T> => {
+    const chain = new InterceptorChain();
+    (interceptors || []).forEach((interceptor) => chain.use(interceptor));
+
+    return chain.run(ctx, call);
+};
+
+export interface RetryPolicy {
+    // retries is the maximum number of times a call is retried after the first attempt
+    retries: number;
+    // backoff is the delay in milliseconds before the first retry, which doubles for each retry (default 100)
+    backoff?: number;
+    // retryableCodes are the Twirp error codes that are retried (default unavailable and deadline_exceeded)
+    retryableCodes?: TwirpErrorCode[];
+}
+
+const defaultRetryableCodes = [TwirpErrorCode.Unavailable, TwirpErrorCode.DeadlineExceeded];
+
+// isRetryable reports if a call that failed with err should be retried. Errors that are not a TwirpError
+// are network failures, except for cancelled requests, which are never retried.
+const isRetryable = (err: any, codes: TwirpErrorCode[]): boolean => {
+    if (err instanceof TwirpError) {
+        return codes.indexOf(err.code) !== -1;
+    }
+
+    return !(err && err.name === "AbortError");
+};
+
+const sleep = (ms: number): Promise<void> => new Promise((resolve) => setTimeout(resolve, ms));
+
+// backoffDelay is the delay before the retry n of a call, which doubles for each retry and is jittered by up to half,
+// so clients do not retry in lockstep.
+const backoffDelay = (backoff: number, n: number): number => backoff * Math.pow(2, n) * (0.5 + Math.random() / 2);
+
+// networkRetries is the number of times a call of a method with an idempotency is retried after a network failure
+const networkRetries = 2;
+
+// retryNetworkFailures wraps the transport of a call of a method whose idempotency_level is no_side_effects or
+// idempotent, which is retried after a network failure, since the server may not have received it. It is not retried
+// after a Twirp error, which is retried by retryInterceptor, when it is cancelled, or when an interceptor removes the
+// idempotency of its context.
+export const retryNetworkFailures = (ctx: InterceptorContext, transport: Transport): Transport => {
+    return (req) => {
+        const attempt = (n: number): Promise<TransportResponse> => {
+            return transport(req).catch((err) => {
+                if (!ctx.idempotency || n >= networkRetries || !isRetryable(err, []) || (ctx.signal && ctx.signal.aborted)) {
+                    throw err;
+                }
+
+                return sleep(backoffDelay(100, n)).then(() => attempt(n + 1));
+            });
+        };
+
+        return attempt(0);
+    };
+};
+
+// randomKey is a random UUID, e.g. 3b241101-e2bb-4255-8caf-4136c566a962
+const randomKey = (): string => {
+    if (typeof crypto !== "undefined" && (crypto as any).randomUUID) {
+        return (crypto as any).randomUUID();
+    }
+
+    return "xxxxxxxx-xxxx-4xxx-yxxx-xxxxxxxxxxxx".replace(/[xy]/g, (c) => {
+        const r = Math.random() * 16 | 0;
+        return (c === "x" ? r : r & 0x3 | 0x8).toString(16);
+    });
+};
+
+// idempotencyKeyInterceptor sends an Idempotency-Key header with the calls of the methods with an idempotency, whose
+// value is a random UUID unless it is generated by generate, e.g. from the request. The key of a call is the same for
+// all of its retries, so a server can recognize them, and is not replaced when it is set by the CallOptions.
+export const idempotencyKeyInterceptor = (generate: (ctx: InterceptorContext) => string = randomKey): Interceptor => {
+    return (ctx, next) => {
+        if (ctx.idempotency && ctx.headers["Idempotency-Key"] === undefined) {
+            ctx.headers["Idempotency-Key"] = generate(ctx);
+        }
+
+        return next(ctx);
+    };
+};
+
+// retryInterceptor retries calls that fail with a retryable Twirp error or a network failure,
+// waiting with jittered exponential backoff between attempts.
+export const retryInterceptor = (policy: RetryPolicy): Interceptor => {
+    const backoff = policy.backoff === undefined ? 100 : policy.backoff;
+    const codes = policy.retryableCodes || defaultRetryableCodes;
+
+    return (ctx, next) => {
+        const attempt = (n: number): Promise<any> => {
+            return next(ctx).catch((err) => {
+                if (n >= policy.retries || !isRetryable(err, codes) || (ctx.signal && ctx.signal.aborted)) {
+                    throw err;
+                }
+
+                return sleep(backoffDelay(backoff, n)).then(() => attempt(n + 1));
+            });
+        };
+
+        return attempt(0);
+    };
+};
+
+// InstrumentationEvent describes an rpc call to the hooks of InstrumentationHooks.
+export interface InstrumentationEvent {
+    // fully qualified service name, e.g. twitch.twirp.example.Haberdasher
+    service: string;
+    // rpc method name, e.g. MakeHat
+    method: string;
+    url: string;
+    // durationMs is the time in milliseconds since the call started, which is 0 for onRequestStart
+    durationMs: number;
+    // status is "ok" for a call that succeeded, or the Twirp error code of a call that failed
+    status: "ok" | TwirpErrorCode;
+    // error is the error of a call that failed
+    error?: any;
+    // request is the request of a call that failed, whose sensitive fields are redacted, e.g. for error reports
+    request?: any;
+}
+
+// InstrumentationHooks are called around every rpc call of a client, e.g. to record metrics. A hook that
+// throws does not fail the call.
+export interface InstrumentationHooks {
+    onRequestStart?: (event: InstrumentationEvent) => void;
+    // onRequestEnd is called when a call succeeds or fails
+    onRequestEnd?: (event: InstrumentationEvent) => void;
+    // onError is called when a call fails, before onRequestEnd
+    onError?: (event: InstrumentationEvent) => void;
+}
+
+// errorStatus is the Twirp error code of a failed call, which is canceled for a cancelled request, and
+// unavailable for a network failure.
+const errorStatus = (err: any): TwirpErrorCode => {
+    if (err instanceof TwirpError) {
+        return err.code;
+    }
+
+    return err && err.name === "AbortError" ? TwirpErrorCode.Canceled : TwirpErrorCode.Unavailable;
+};
+
+const callHook = (hook: ((event: InstrumentationEvent) => void) | undefined, event: InstrumentationEvent) => {
+    if (!hook) {
+        return;
+    }
+
+    try {
+        hook(event);
+    } catch (err) {
+        // the hooks only observe the call
+    }
+};
+
+// instrumentationInterceptor calls the hooks around every call, see InstrumentationHooks.
+export const instrumentationInterceptor = (hooks: InstrumentationHooks): Interceptor => {
+    return (ctx, next) => {
+        const start = Date.now();
+        const event = (status: "ok" | TwirpErrorCode, error?: any): InstrumentationEvent => {
+            const e: InstrumentationEvent = {service: ctx.service, method: ctx.method, url: ctx.url, durationMs: Date.now() - start, status: status, error: error};
+            if (status !== "ok") {
+                e.request = redactRequest(ctx);
+            }
+
+            return e;
+        };
+
+        callHook(hooks.onRequestStart, {service: ctx.service, method: ctx.method, url: ctx.url, durationMs: 0, status: "ok"});
+
+        return next(ctx).then((resp) => {
+            callHook(hooks.onRequestEnd, event("ok"));
+            return resp;
+        }, (err) => {
+            const e = event(errorStatus(err), err);
+            callHook(hooks.onError, e);
+            callHook(hooks.onRequestEnd, e);
+            throw err;
+        });
+    };
+};
+
+// DebugEntry is an rpc call logged by debugInterceptor, whose messages have their sensitive fields redacted.
+export interface DebugEntry {
+    // fully qualified service name, e.g. twitch.twirp.example.Haberdasher
+    service: string;
+    // rpc method name, e.g. MakeHat
+    method: string;
+    url: string;
+    durationMs: number;
+    // status is "ok" for a call that succeeded, or the Twirp error code of a call that failed
+    status: "ok" | TwirpErrorCode;
+    request: any;
+    // response is the response of a call that succeeded
+    response?: any;
+    // error is the error of a call that failed
+    error?: any;
+}
+
+// DebugLogger logs the calls of debugInterceptor, e.g. to a logger of the application.
+export type DebugLogger = (entry: DebugEntry) => void;
+
+// logDebug logs a call to the console, e.g. twitch.twirp.example.Haberdasher/MakeHat ok 12ms, with its request
+// and its response or error.
+const logDebug: DebugLogger = (entry) => {
+    console.debug(entry.service + "/" + entry.method + " " + entry.status + " " + entry.durationMs + "ms", entry.request, entry.status === "ok" ? entry.response : entry.error);
+};
+
+// debugInterceptor logs every call with its latency, and its request and response, whose sensitive fields are
+// redacted, see Redactor. The calls are logged to the console unless log is set. A logger that throws does not fail
+// the call.
+export const debugInterceptor = (log: DebugLogger = logDebug): Interceptor => {
+    return (ctx, next) => {
+        const start = Date.now();
+        const request = redactRequest(ctx);
+        const write = (entry: DebugEntry) => {
+            try {
+                log(entry);
+            } catch (err) {
+                // the logger only observes the call
+            }
+        };
+
+        return next(ctx).then((resp) => {
+            write({service: ctx.service, method: ctx.method, url: ctx.url, durationMs: Date.now() - start, status: "ok", request: request, response: redactResponse(ctx, resp)});
+            return resp;
+        }, (err) => {
+            write({service: ctx.service, method: ctx.method, url: ctx.url, durationMs: Date.now() - start, status: errorStatus(err), request: request, error: err});
+            throw err;
+        });
+    };
+};
+
+// TraceContext is the W3C trace context of a call, which is sent in the traceparent and tracestate headers.
+export interface TraceContext {
+    // traceparent is the version, trace id, parent span id and flags, e.g. 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01
+    traceparent: string;
+    tracestate?: string;
+}
+
+// traceContextInterceptor sends the trace context returned by getContext with every call, so the spans of the
+// server continue the trace of the client. No headers are sent when getContext returns undefined.
+export const traceContextInterceptor = (getContext: (ctx: InterceptorContext) => TraceContext | undefined): Interceptor => {
+    return (ctx, next) => {
+        const trace = getContext(ctx);
+        if (trace) {
+            setTraceContext(ctx, trace);
+        }
+
+        return next(ctx);
+    };
+};
+
+const setTraceContext = (ctx: InterceptorContext, trace: TraceContext) => {
+    ctx.headers["traceparent"] = trace.traceparent;
+    if (trace.tracestate) {
+        ctx.headers["tracestate"] = trace.tracestate;
+    }
+};
+
+// OpenTelemetryPropagation is the part of the propagation API of @opentelemetry/api used by openTelemetryTraceContext.
+export interface OpenTelemetryPropagation {
+    inject(context: any, carrier: {[key: string]: string}): void;
+}
+
+// OpenTelemetryContext is the part of the context API of @opentelemetry/api used by openTelemetryTraceContext.
+export interface OpenTelemetryContext {
+    active(): any;
+}
+
+// openTelemetryTraceContext returns the trace context of the active OpenTelemetry context for traceContextInterceptor,
+// e.g. traceContextInterceptor(openTelemetryTraceContext(propagation, context)).
+export const openTelemetryTraceContext = (propagation: OpenTelemetryPropagation, context: OpenTelemetryContext): (() => TraceContext | undefined) => {
+    return () => {
+        const carrier: {[key: string]: string} = {};
+        propagation.inject(context.active(), carrier);
+
+        if (!carrier["traceparent"]) {
+            return undefined;
+        }
+
+        return {traceparent: carrier["traceparent"], tracestate: carrier["tracestate"]};
+    };
+};
+
+// OpenTelemetrySpanContext is the part of the SpanContext of @opentelemetry/api used by openTelemetryInterceptor.
+export interface OpenTelemetrySpanContext {
+    traceId: string;
+    spanId: string;
+    traceFlags: number;
+    traceState?: {serialize(): string};
+}
+
+const invalidTraceId = "00000000000000000000000000000000";
+
+// spanTraceContext is the W3C trace context of a span, or undefined for the invalid context of a span that is not recorded.
+const spanTraceContext = (sc: OpenTelemetrySpanContext): TraceContext | undefined => {
+    if (!sc.traceId || sc.traceId === invalidTraceId) {
+        return undefined;
+    }
+
+    const flags = ("0" + (sc.traceFlags & 0xff).toString(16)).slice(-2);
+    const tracestate = sc.traceState ? sc.traceState.serialize() : "";
+
+    return {traceparent: "00-" + sc.traceId + "-" + sc.spanId + "-" + flags, tracestate: tracestate || undefined};
+};
+
+// OpenTelemetrySpan is the part of the Span of @opentelemetry/api used by openTelemetryInterceptor.
+export interface OpenTelemetrySpan {
+    setAttribute(key: string, value: string | number): any;
+    setStatus(status: {code: number; message?: string}): any;
+    recordException(exception: any): any;
+    end(): void;
+    spanContext?(): OpenTelemetrySpanContext;
+}
+
+// OpenTelemetryTracer is the part of the Tracer of @opentelemetry/api used by openTelemetryInterceptor,
+// e.g. trace.getTracer('rpc-client').
+export interface OpenTelemetryTracer {
+    startSpan(name: string, options?: {kind?: number; attributes?: {[key: string]: string | number}}): OpenTelemetrySpan;
+}
+
+// the values of the SpanKind and SpanStatusCode enums of @opentelemetry/api
+const spanKindClient = 2;
+const spanStatusOk = 1;
+const spanStatusError = 2;
+
+// openTelemetryInterceptor traces every call with a client span named after the rpc method, e.g.
+// twitch.twirp.example.Haberdasher/MakeHat, with the rpc attributes of the OpenTelemetry semantic conventions.
+// The trace context of the span is sent in the traceparent and tracestate headers, so the spans of the server
+// are its children.
+export const openTelemetryInterceptor = (tracer: OpenTelemetryTracer): Interceptor => {
+    return (ctx, next) => {
+        const span = tracer.startSpan(ctx.service + "/" + ctx.method, {
+            kind: spanKindClient,
+            attributes: {
+                "rpc.system": "twirp",
+                "rpc.service": ctx.service,
+                "rpc.method": ctx.method,
+                "url.full": ctx.url,
+            },
+        });
+
+        const trace = span.spanContext ? spanTraceContext(span.spanContext()) : undefined;
+        if (trace) {
+            setTraceContext(ctx, trace);
+        }
+
+        return next(ctx).then((resp) => {
+            span.setStatus({code: spanStatusOk});
+            span.end();
+            return resp;
+        }, (err) => {
+            span.setAttribute("rpc.twirp.error_code", errorStatus(err));
+            span.recordException(err);
+            span.setStatus({code: spanStatusError, message: err && err.message});
+            span.end();
+            throw err;
+        });
+    };
+};
@@ -0,0 +1,129 @@
+import {Fetch, ResponseHeaders, Transport, TransportRequest, TransportResponse} from './twirp.ts';
+
+// bufferHeaders are the ResponseHeaders of the headers of a response that were read into an object.
+const bufferHeaders = (headers: {[key: string]: string | string[] | undefined}): ResponseHeaders => {
+    const lower: {[key: string]: string} = {};
+
+    Object.keys(headers).forEach((k) => {
+        const v = headers[k];
+        if (v !== undefined) {
+            lower[k.toLowerCase()] = Array.isArray(v) ? v.join(", ") : String(v);
+        }
+    });
+
+    return {get: (name) => lower.hasOwnProperty(name.toLowerCase()) ? lower[name.toLowerCase()] : null};
+};
+
+// bufferResponse is the TransportResponse of a request that was read into a buffer.
+const bufferResponse = (status: number, buf: ArrayBuffer, headers: {[key: string]: string | string[] | undefined} = {}): TransportResponse => {
+    return {
+        ok: status >= 200 && status < 300,
+        status: status,
+        headers: bufferHeaders(headers),
+        text: () => Promise.resolve(new TextDecoder().decode(buf)),
+        arrayBuffer: () => Promise.resolve(buf),
+    };
+};
+
+// requestBody is the body of a request, which is not sent for a REST request without a body, e.g. a GET request.
+const requestBody = (req: TransportRequest): string | Uint8Array | undefined => {
+    return req.body === "" ? undefined : req.body;
+};
+
+// xhrHeaders parses the headers of an XMLHttpRequest response, which are lines of "name: value".
+const xhrHeaders = (xhr: XMLHttpRequest): {[key: string]: string} => {
+    const headers: {[key: string]: string} = {};
+
+    xhr.getAllResponseHeaders().split("\r\n").forEach((line) => {
+        const i = line.indexOf(":");
+        if (i > 0) {
+            headers[line.substring(0, i).trim()] = line.substring(i + 1).trim();
+        }
+    });
+
+    return headers;
+};
+
+// fetchTransport sends requests with a fetch implementation, e.g. window.fetch.bind(window) or isomorphic-fetch.
+export const fetchTransport = (fetch: Fetch): Transport => {
+    return (req) => fetch(req.url, {
+        method: req.method || "POST",
+        headers: req.headers,
+        body: requestBody(req),
+        signal: req.signal,
+    });
+};
+
+// NodeFetch is the fetch function of node-fetch, whose typings differ from the DOM fetch.
+export type NodeFetch = (url: string, init?: any) => Promise<any>;
+
+// nodeFetchTransport sends requests with node-fetch, e.g. nodeFetchTransport(require("node-fetch")).
+export const nodeFetchTransport = (fetch: NodeFetch): Transport => {
+    return (req) => fetch(req.url, {
+        method: req.method || "POST",
+        headers: req.headers,
+        body: requestBody(req),
+        signal: req.signal,
+    });
+};
+
+export interface XHRTransportOptions {
+    // onUploadProgress is called as the request body is sent, e.g. to show the progress of a large upload
+    onUploadProgress?: (loaded: number, total: number) => void;
+    // withCredentials sends cookies with cross origin requests
+    withCredentials?: boolean;
+}
+
+// xhrTransport sends requests with XMLHttpRequest, for browsers without fetch or to report upload progress.
+export const xhrTransport = (options: XHRTransportOptions = {}): Transport => {
+    return (req) => new Promise<TransportResponse>((resolve, reject) => {
+        const xhr = new XMLHttpRequest();
+        xhr.open(req.method || "POST", req.url);
+        xhr.responseType = "arraybuffer";
+        xhr.withCredentials = !!options.withCredentials;
+
+        Object.keys(req.headers).forEach((k) => xhr.setRequestHeader(k, req.headers[k]));
+
+        if (options.onUploadProgress) {
+            const onUploadProgress = options.onUploadProgress;
+            xhr.upload.onprogress = (e) => onUploadProgress(e.loaded, e.total);
+        }
+
+        xhr.onload = () => resolve(bufferResponse(xhr.status, xhr.response, xhrHeaders(xhr)));
+        xhr.onerror = () => reject(new TypeError("Network request failed"));
+        xhr.onabort = () => reject(new DOMException("Aborted", "AbortError"));
+
+        if (req.signal) {
+            if (req.signal.aborted) {
+                return reject(new DOMException("Aborted", "AbortError"));
+            }
+
+            req.signal.addEventListener("abort", () => xhr.abort());
+        }
+
+        xhr.send(requestBody(req) || null);
+    });
+};
+
+// Axios is the subset of an axios instance used by axiosTransport.
+export interface Axios {
+    request(config: any): Promise<{status: number; data: any; headers?: any}>;
+}
+
+// axiosTransport sends requests with axios, e.g. axiosTransport(axios.create({timeout: 5000})).
+export const axiosTransport = (axios: Axios): Transport => {
+    return (req) => axios.request({
+        url: req.url,
+        method: req.method || "POST",
+        headers: req.headers,
+        data: requestBody(req),
+        signal: req.signal,
+        responseType: "arraybuffer",
+        // Twirp errors are read from the response, rather than rejected by axios
+        validateStatus: () => true,
+    }).then((resp) => {
+        // axios reads an arraybuffer response into a Buffer in node
+        const data = resp.data instanceof ArrayBuffer ? resp.data : new Uint8Array(resp.data).slice().buffer;
+        return bufferResponse(resp.status, data, resp.headers || {});
+    });
+};
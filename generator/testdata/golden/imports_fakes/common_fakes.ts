@@ -0,0 +1,8 @@
+import {fakeInt, withOverrides} from './twirp_fakes';
+import {SharedPage} from './common';
+
+// fakeSharedPage is a fake SharedPage message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeSharedPage = (overrides: Partial<SharedPage> = {}): SharedPage => {
+    return withOverrides<SharedPage>({offset: fakeInt(0, 1000), limit: fakeInt(0, 1000)}, overrides);
+};
@@ -0,0 +1,8 @@
+import {fakeArray, fakeEnum, fakeString, withOverrides} from './twirp_fakes';
+import {ImportsPage} from './imports';
+
+// fakeImportsPage is a fake ImportsPage message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeImportsPage = (overrides: Partial<ImportsPage> = {}): ImportsPage => {
+    return withOverrides<ImportsPage>({items: fakeArray(() => fakeString("items")), status: fakeEnum([0, 1])}, overrides);
+};
@@ -0,0 +1,106 @@
+import {CallOptions} from './twirp';
+import {TwirpClient} from './interceptors';
+import {SharedPage, Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export interface ImportsPage {
+    items: string[];
+    status: Status;
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ImportsPageFields: {
+    readonly items: {readonly number: 1; readonly name: "items"; readonly type: "string"};
+    readonly status: {readonly number: 2; readonly name: "status"; readonly type: "enum"};
+};
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export declare const JSONToImportsPage: (m: ImportsPageJSON) => ImportsPage;
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export declare const isImportsPage: (value: unknown) => value is ImportsPage;
+
+export interface Catalog {
+    list: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+}
+
+// CatalogMethods are the Twirp routes of the methods of Catalog, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const CatalogMethods: {
+    readonly list: {
+        readonly service: "imports.Catalog";
+        readonly method: "List";
+        readonly path: "/twirp/imports.Catalog/List";
+        readonly inputType: "SharedPage";
+        readonly outputType: "ImportsPage";
+    };
+};
+
+export declare const list: (client: TwirpClient, sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+
+// createCatalogClient creates a Catalog of the rpc functions of Catalog, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export declare const createCatalogClient: (client: TwirpClient) => Catalog;
+
+// A CatalogMockResponses sets the response of each CatalogMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CatalogMockResponses {
+    list?: ImportsPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => ImportsPage | Promise<ImportsPage>);
+}
+
+// CatalogMockClient is a Catalog for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class CatalogMockClient implements Catalog {
+    responses: CatalogMockResponses;
+
+    constructor(responses?: CatalogMockResponses);
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage>;
+}
+
+export declare const createCatalogMock: (overrides?: CatalogMockResponses) => CatalogMockClient;
+
+export interface Admin {
+    reset: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+}
+
+// AdminMethods are the Twirp routes of the methods of Admin, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const AdminMethods: {
+    readonly reset: {
+        readonly service: "imports.Admin";
+        readonly method: "Reset";
+        readonly path: "/twirp/imports.Admin/Reset";
+        readonly inputType: "SharedPage";
+        readonly outputType: "SharedPage";
+    };
+};
+
+export declare const reset: (client: TwirpClient, sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+
+// createAdminClient creates a Admin of the rpc functions of Admin, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export declare const createAdminClient: (client: TwirpClient) => Admin;
+
+// A AdminMockResponses sets the response of each AdminMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AdminMockResponses {
+    reset?: SharedPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => SharedPage | Promise<SharedPage>);
+}
+
+// AdminMockClient is a Admin for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class AdminMockClient implements Admin {
+    responses: AdminMockResponses;
+
+    constructor(responses?: AdminMockResponses);
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage>;
+}
+
+export declare const createAdminMock: (overrides?: AdminMockResponses) => AdminMockClient;
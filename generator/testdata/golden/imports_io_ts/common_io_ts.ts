@@ -0,0 +1,14 @@
+import * as t from 'io-ts';
+import * as E from 'fp-ts/Either';
+import {SharedPage, SharedPageJSON, JSONToSharedPage} from './common';
+
+// SharedPageCodec decodes the proto3 JSON of the SharedPage message, e.g. the body of a response.
+export const SharedPageCodec = t.partial({
+    offset: t.Int,
+    limit: t.Int,
+});
+
+// decodeSharedPage decodes the proto3 JSON of the SharedPage message, and returns the errors of JSON that does not match its codec.
+export const decodeSharedPage = (json: unknown): E.Either<t.Errors, SharedPage> => {
+    return E.map((m) => JSONToSharedPage(m as SharedPageJSON))(SharedPageCodec.decode(json));
+};
@@ -0,0 +1,38 @@
+import {enumFromJSON, everyItem} from './twirp';
+import {Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export interface ImportsPage {
+    items: string[];
+    status: Status;
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImportsPageFields = {
+    items: {number: 1, name: "items", type: "string"},
+    status: {number: 2, name: "status", type: "enum"},
+} as const;
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export const JSONToImportsPage = (m: ImportsPageJSON): ImportsPage => {
+    return {
+        items: m.items,
+        status: enumFromJSON<Status>(Status, m.status),
+    };
+};
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export const isImportsPage = (value: unknown): value is ImportsPage => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.items, (v) => typeof v === "string")
+        && typeof m.status === "number";
+};
@@ -0,0 +1,14 @@
+import * as t from 'io-ts';
+import * as E from 'fp-ts/Either';
+import {ImportsPage, ImportsPageJSON, JSONToImportsPage} from './imports';
+
+// ImportsPageCodec decodes the proto3 JSON of the ImportsPage message, e.g. the body of a response.
+export const ImportsPageCodec = t.partial({
+    items: t.array(t.string),
+    status: t.union([t.keyof({"STATUS_UNKNOWN": null, "STATUS_ACTIVE": null}), t.Int]),
+});
+
+// decodeImportsPage decodes the proto3 JSON of the ImportsPage message, and returns the errors of JSON that does not match its codec.
+export const decodeImportsPage = (json: unknown): E.Either<t.Errors, ImportsPage> => {
+    return E.map((m) => JSONToImportsPage(m as ImportsPageJSON))(ImportsPageCodec.decode(json));
+};
@@ -0,0 +1,343 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, enumFromJSON, everyItem, mergeFields, diffFields} from './twirp';
+import {cloneValue, valuesEqual} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {JSONToSharedPage, SharedPage, SharedPageToJSON, Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export class ImportsPage {
+    items: string[];
+    status: Status;
+
+    constructor(init: Partial<ImportsPage> = {}) {
+        this.items = init.items !== undefined ? init.items : [];
+        this.status = init.status !== undefined ? init.status : 0;
+    }
+
+    // clone returns a deep copy of the ImportsPage.
+    clone(): ImportsPage {
+        return new ImportsPage({
+            items: cloneValue(this.items),
+            status: cloneValue(this.status),
+        });
+    }
+
+    // equals reports if the fields of the ImportsPage are deeply equal to those of other.
+    equals(other: ImportsPage): boolean {
+        return valuesEqual(this.items, other.items)
+            && valuesEqual(this.status, other.status);
+    }
+
+    static fromJSON(m: ImportsPageJSON): ImportsPage {
+        return JSONToImportsPage(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a ImportsPage is stringified as its proto3 JSON.
+    toJSON(): ImportsPageJSON {
+        return ImportsPageToJSON(this);
+    }
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ImportsPageFields = {
+    items: {number: 1, name: "items", type: "string"},
+    status: {number: 2, name: "status", type: "enum"},
+} as const;
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export const ImportsPageToJSON = (m: ImportsPage): ImportsPageJSON => {
+    return {
+        items: m.items,
+        status: Status[m.status],
+    };
+};
+
+export const JSONToImportsPage = (m: ImportsPageJSON): ImportsPage => {
+    return new ImportsPage({
+        items: m.items,
+        status: enumFromJSON<Status>(Status, m.status),
+    });
+};
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export const isImportsPage = (value: unknown): value is ImportsPage => {
+    if (!(value instanceof ImportsPage)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.items, (v) => typeof v === "string")
+        && typeof m.status === "number";
+};
+
+// mergeImportsPage copies a ImportsPage with the fields of a patch that are set, e.g. for an optimistic update. The nested
+// messages and maps of the patch are merged into the ones of the ImportsPage, while its other fields replace them.
+export const mergeImportsPage = (base: ImportsPage, patch: Partial<ImportsPage>): ImportsPage => {
+    return new ImportsPage(mergeFields(base, patch, {}));
+};
+
+// diffImportsPage finds the paths of the fields that differ between two ImportsPages, including the fields of their nested
+// messages, e.g. for the FieldMask of a request that updates the changes to a ImportsPage.
+export const diffImportsPage = (a: ImportsPage, b: ImportsPage): string[] => {
+    return diffFields(a, b, [{name: "items", path: "items"}, {name: "status", path: "status"}]);
+};
+
+export interface Catalog {
+    list: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<ImportsPage>;
+}
+
+// CatalogMethods are the Twirp routes of the methods of Catalog, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const CatalogMethods = {
+    list: {
+        service: "imports.Catalog",
+        method: "List",
+        path: "/twirp/imports.Catalog/List",
+        inputType: "SharedPage",
+        outputType: "ImportsPage",
+    },
+} as const;
+
+export class DefaultCatalog implements Catalog {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/imports.Catalog/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage> {
+        const url = joinURL(this.hostname, this.pathPrefix + "List");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "imports.Catalog",
+                method: "List",
+                url: url,
+                request: sharedPage,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SharedPageToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToImportsPage(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createCatalogClient creates a DefaultCatalog with the config, which may be shared by the clients of other services.
+export const createCatalogClient = (config: TwirpClientConfig): DefaultCatalog => {
+    return new DefaultCatalog(config);
+};
+
+// A CatalogMockResponses sets the response of each CatalogMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface CatalogMockResponses {
+    list?: ImportsPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => ImportsPage | Promise<ImportsPage>);
+}
+
+// CatalogMockClient is a Catalog for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class CatalogMockClient implements Catalog {
+    responses: CatalogMockResponses;
+
+    constructor(responses: CatalogMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    list(sharedPage: SharedPage, callOptions?: CallOptions): Promise<ImportsPage> {
+        const response = this.responses.list;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Catalog.List"}));
+        }
+
+        return new Promise<ImportsPage>((resolve) => resolve(typeof response === "function" ? response(sharedPage, callOptions) : response));
+    }
+}
+
+export const createCatalogMock = (overrides: CatalogMockResponses = {}): CatalogMockClient => {
+    return new CatalogMockClient(overrides);
+};
+
+export interface Admin {
+    reset: (sharedPage: SharedPage, callOptions?: CallOptions) => Promise<SharedPage>;
+}
+
+// AdminMethods are the Twirp routes of the methods of Admin, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AdminMethods = {
+    reset: {
+        service: "imports.Admin",
+        method: "Reset",
+        path: "/twirp/imports.Admin/Reset",
+        inputType: "SharedPage",
+        outputType: "SharedPage",
+    },
+} as const;
+
+export class DefaultAdmin implements Admin {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/imports.Admin/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Reset");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "imports.Admin",
+                method: "Reset",
+                url: url,
+                request: sharedPage,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SharedPageToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToSharedPage(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createAdminClient creates a DefaultAdmin with the config, which may be shared by the clients of other services.
+export const createAdminClient = (config: TwirpClientConfig): DefaultAdmin => {
+    return new DefaultAdmin(config);
+};
+
+// A AdminMockResponses sets the response of each AdminMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AdminMockResponses {
+    reset?: SharedPage | ((sharedPage: SharedPage, callOptions?: CallOptions) => SharedPage | Promise<SharedPage>);
+}
+
+// AdminMockClient is a Admin for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AdminMockClient implements Admin {
+    responses: AdminMockResponses;
+
+    constructor(responses: AdminMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    reset(sharedPage: SharedPage, callOptions?: CallOptions): Promise<SharedPage> {
+        const response = this.responses.reset;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Admin.Reset"}));
+        }
+
+        return new Promise<SharedPage>((resolve) => resolve(typeof response === "function" ? response(sharedPage, callOptions) : response));
+    }
+}
+
+export const createAdminMock = (overrides: AdminMockResponses = {}): AdminMockClient => {
+    return new AdminMockClient(overrides);
+};
@@ -0,0 +1,26 @@
+import {Status} from './common';
+
+/** Page has the same name as shared.Page, so both are prefixed with their package. */
+export interface ImportsPage {
+    items: string[];
+    status: Status;
+}
+
+// ImportsPageFields are the numbers and proto types of the fields of ImportsPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ImportsPageFields: {
+    readonly items: {readonly number: 1; readonly name: "items"; readonly type: "string"};
+    readonly status: {readonly number: 2; readonly name: "status"; readonly type: "enum"};
+};
+
+export interface ImportsPageJSON {
+    items: string[];
+    status: string | number;
+}
+
+export declare const ImportsPageToJSON: (m: ImportsPage) => ImportsPageJSON;
+
+export declare const JSONToImportsPage: (m: ImportsPageJSON) => ImportsPage;
+
+// isImportsPage reports if a value has the fields of a ImportsPage, e.g. to check data read from a cache or a websocket.
+export declare const isImportsPage: (value: unknown) => value is ImportsPage;
@@ -0,0 +1,14 @@
+import {HttpHandler} from 'msw';
+import {joinURL} from './twirp';
+import {twirpHandler} from './twirp_msw';
+import {JSONToSharedPage, SharedPageToJSON} from './common';
+import {AdminMethods, AdminMockResponses} from './imports_admin';
+
+// createAdminHandlers creates the msw handlers of the Twirp routes of Admin, which respond with the responses
+// of the methods like the AdminMockClient, e.g. setupServer(...createAdminHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export const createAdminHandlers = (responses: AdminMockResponses, hostname: string = "*"): HttpHandler[] => {
+    return [
+        twirpHandler(joinURL(hostname, AdminMethods.reset.path), JSONToSharedPage, SharedPageToJSON, responses.reset),
+    ];
+};
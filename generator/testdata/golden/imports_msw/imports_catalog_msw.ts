@@ -0,0 +1,15 @@
+import {HttpHandler} from 'msw';
+import {joinURL} from './twirp';
+import {twirpHandler} from './twirp_msw';
+import {JSONToSharedPage} from './common';
+import {ImportsPageToJSON} from './imports';
+import {CatalogMethods, CatalogMockResponses} from './imports_catalog';
+
+// createCatalogHandlers creates the msw handlers of the Twirp routes of Catalog, which respond with the responses
+// of the methods like the CatalogMockClient, e.g. setupServer(...createCatalogHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export const createCatalogHandlers = (responses: CatalogMockResponses, hostname: string = "*"): HttpHandler[] => {
+    return [
+        twirpHandler(joinURL(hostname, CatalogMethods.list.path), JSONToSharedPage, ImportsPageToJSON, responses.list),
+    ];
+};
@@ -0,0 +1,12 @@
+import {HttpHandler} from 'msw';
+import {AdminMockResponses, CatalogMockResponses} from './imports';
+
+// createCatalogHandlers creates the msw handlers of the Twirp routes of Catalog, which respond with the responses
+// of the methods like the CatalogMockClient, e.g. setupServer(...createCatalogHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export declare const createCatalogHandlers: (responses: CatalogMockResponses, hostname?: string) => HttpHandler[];
+
+// createAdminHandlers creates the msw handlers of the Twirp routes of Admin, which respond with the responses
+// of the methods like the AdminMockClient, e.g. setupServer(...createAdminHandlers(responses)). The handlers
+// match the requests to any hostname, unless it is set.
+export declare const createAdminHandlers: (responses: AdminMockResponses, hostname?: string) => HttpHandler[];
@@ -0,0 +1,53 @@
+export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export interface SharedPage {
+    offset: number;
+    limit: number;
+}
+
+// ReadonlySharedPage is the interface of a SharedPage returned by the clients, whose fields cannot be changed.
+export interface ReadonlySharedPage {
+    readonly offset: number;
+    readonly limit: number;
+}
+
+// SharedPageFields are the numbers and proto types of the fields of SharedPage by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SharedPageFields = {
+    offset: {number: 1, name: "offset", type: "int32"},
+    limit: {number: 2, name: "limit", type: "int32"},
+} as const;
+
+export interface SharedPageJSON {
+    offset: number;
+    limit: number;
+}
+
+export const SharedPageToJSON = (m: SharedPage): SharedPageJSON => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+export const JSONToSharedPage = (m: SharedPageJSON): SharedPage => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+// isSharedPage reports if a value has the fields of a SharedPage, e.g. to check data read from a cache or a websocket.
+export const isSharedPage = (value: unknown): value is SharedPage => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.offset === "number"
+        && typeof m.limit === "number";
+};
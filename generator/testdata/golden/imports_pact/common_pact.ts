@@ -0,0 +1,8 @@
+import {withOverrides} from './twirp_pact';
+import {SharedPage} from './common';
+
+// exampleSharedPage is an example of the SharedPage message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleSharedPage = (overrides: Partial<SharedPage> = {}): SharedPage => {
+    return withOverrides<SharedPage>({offset: 0, limit: 0}, overrides);
+};
@@ -0,0 +1,21 @@
+import {PactInteraction, PactInteractionOptions, pactInteraction, withOverrides} from './twirp_pact';
+import {ReadonlySharedPage, SharedPage, SharedPageToJSON} from './common';
+import {ImportsPage, ImportsPageToJSON, ReadonlyImportsPage} from './imports';
+
+// exampleImportsPage is an example of the ImportsPage message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleImportsPage = (overrides: Partial<ImportsPage> = {}): ImportsPage => {
+    return withOverrides<ImportsPage>({items: [], status: 0}, overrides);
+};
+
+// listInteraction is a pact interaction of Catalog.List, e.g.
+// provider.addInteraction(listInteraction({request: exampleSharedPage(), response: exampleImportsPage()}))
+export const listInteraction = (options: PactInteractionOptions<SharedPage, ReadonlyImportsPage>): PactInteraction => {
+    return pactInteraction("/twirp/imports.Catalog/List", "a request to Catalog.List", options, SharedPageToJSON, ImportsPageToJSON);
+};
+
+// resetInteraction is a pact interaction of Admin.Reset, e.g.
+// provider.addInteraction(resetInteraction({request: exampleSharedPage(), response: exampleSharedPage()}))
+export const resetInteraction = (options: PactInteractionOptions<SharedPage, ReadonlySharedPage>): PactInteraction => {
+    return pactInteraction("/twirp/imports.Admin/Reset", "a request to Admin.Reset", options, SharedPageToJSON, SharedPageToJSON);
+};
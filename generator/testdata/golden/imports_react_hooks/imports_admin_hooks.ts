@@ -0,0 +1,8 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {SharedPage} from './common';
+import {Admin} from './imports_admin';
+
+// useReset calls Admin.Reset with the request when the component mounts, and again when the request changes.
+export const useReset = (client: Admin, sharedPage: SharedPage, options?: RpcHookOptions): RpcHookResult<SharedPage> => {
+    return useRpc((req, callOptions) => client.reset(req, callOptions), sharedPage, options);
+};
@@ -0,0 +1,9 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {SharedPage} from './common';
+import {ImportsPage} from './imports';
+import {Catalog} from './imports_catalog';
+
+// useList calls Catalog.List with the request when the component mounts, and again when the request changes.
+export const useList = (client: Catalog, sharedPage: SharedPage, options?: RpcHookOptions): RpcHookResult<ImportsPage> => {
+    return useRpc((req, callOptions) => client.list(req, callOptions), sharedPage, options);
+};
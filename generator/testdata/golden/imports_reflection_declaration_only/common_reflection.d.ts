@@ -0,0 +1,5 @@
+import {FileDescriptor} from './twirp_reflection';
+
+// descriptor describes the messages, enums and services of shared/common.proto, which a SchemaRegistry looks up by their
+// full names, e.g. new SchemaRegistry([descriptor]).
+export declare const descriptor: FileDescriptor;
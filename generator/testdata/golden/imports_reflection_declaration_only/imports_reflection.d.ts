@@ -0,0 +1,5 @@
+import {FileDescriptor} from './twirp_reflection';
+
+// descriptor describes the messages, enums and services of imports.proto, which a SchemaRegistry looks up by their
+// full names, e.g. new SchemaRegistry([descriptor]).
+export declare const descriptor: FileDescriptor;
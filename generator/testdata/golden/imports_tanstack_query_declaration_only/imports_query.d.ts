@@ -0,0 +1,21 @@
+import {RpcQueryOptions, RpcMutationOptions} from './twirp_query';
+import {SharedPage} from './common';
+import {Admin, Catalog, ImportsPage} from './imports';
+
+// listQueryKey is the query key of Catalog.List queries, e.g. to invalidate the cached response of a request.
+export declare const listQueryKey: (sharedPage: SharedPage) => readonly ["imports.Catalog", "List", SharedPage];
+
+// listQuery are the query options of Catalog.List, e.g. useQuery(listQuery(client, sharedPage))
+export declare const listQuery: (client: Catalog, sharedPage: SharedPage) => RpcQueryOptions<ImportsPage, ReturnType<typeof listQueryKey>>;
+
+// listMutation are the mutation options of Catalog.List, e.g. useMutation(listMutation(client))
+export declare const listMutation: (client: Catalog) => RpcMutationOptions<ImportsPage, SharedPage>;
+
+// resetQueryKey is the query key of Admin.Reset queries, e.g. to invalidate the cached response of a request.
+export declare const resetQueryKey: (sharedPage: SharedPage) => readonly ["imports.Admin", "Reset", SharedPage];
+
+// resetQuery are the query options of Admin.Reset, e.g. useQuery(resetQuery(client, sharedPage))
+export declare const resetQuery: (client: Admin, sharedPage: SharedPage) => RpcQueryOptions<SharedPage, ReturnType<typeof resetQueryKey>>;
+
+// resetMutation are the mutation options of Admin.Reset, e.g. useMutation(resetMutation(client))
+export declare const resetMutation: (client: Admin) => RpcMutationOptions<SharedPage, SharedPage>;
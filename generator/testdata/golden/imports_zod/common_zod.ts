@@ -0,0 +1,7 @@
+import {z} from 'zod';
+
+// SharedPageSchema checks the proto3 JSON of the SharedPage message, e.g. the body of a response.
+export const SharedPageSchema = z.object({
+    offset: z.number().int().optional(),
+    limit: z.number().int().optional(),
+});
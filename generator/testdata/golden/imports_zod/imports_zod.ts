@@ -0,0 +1,7 @@
+import {z} from 'zod';
+
+// ImportsPageSchema checks the proto3 JSON of the ImportsPage message, e.g. the body of a response.
+export const ImportsPageSchema = z.object({
+    items: z.array(z.string()).optional(),
+    status: z.union([z.enum(["STATUS_UNKNOWN", "STATUS_ACTIVE"]), z.number().int()]).optional(),
+});
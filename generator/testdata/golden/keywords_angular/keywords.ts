@@ -0,0 +1,462 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, enumFromJSON, everyItem, everyValue, oneofMember} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** in is renamed, since a keyword is not a valid name of an enum. */
+export enum Keywordsin {
+    IN_UNSPECIFIED = 0,
+}
+
+export type DeleteCase =
+    | {kind: "if"; value: string}
+    | {kind: "else"; value: number};
+
+/** Delete has fields that are named after javascript keywords, which are valid property names. */
+export interface Delete {
+    delete: string;
+    new: number;
+    default: boolean;
+    function: string;
+    class: string[];
+    var: {[key: string]: string};
+    this?: string | undefined;
+    in: Keywordsin;
+    case?: DeleteCase;
+}
+
+// DeleteFields are the numbers and proto types of the fields of Delete by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DeleteFields = {
+    delete: {number: 1, name: "delete", type: "string"},
+    new: {number: 2, name: "new", type: "int32"},
+    default: {number: 3, name: "default", type: "bool"},
+    function: {number: 4, name: "function", type: "string"},
+    class: {number: 5, name: "class", type: "string"},
+    var: {number: 6, name: "var", type: "map"},
+    this: {number: 7, name: "this", type: "string"},
+    in: {number: 10, name: "in", type: "enum"},
+    if: {number: 8, name: "if", type: "string"},
+    else: {number: 9, name: "else", type: "int32"},
+} as const;
+
+export interface DeleteJSON {
+    delete: string;
+    new: number;
+    default: boolean;
+    function: string;
+    class: string[];
+    var: {[key: string]: string};
+    this?: string;
+    in: string | number;
+    if?: string;
+    else?: number;
+}
+
+export const DeleteToJSON = (m: Delete): DeleteJSON => {
+    return {
+        delete: m.delete,
+        new: m.new,
+        default: m.default,
+        function: m.function,
+        class: m.class,
+        var: m.var,
+        this: m.this,
+        in: Keywordsin[m.in],
+        if: m.case && m.case.kind === "if" ? m.case.value : undefined,
+        else: m.case && m.case.kind === "else" ? m.case.value : undefined,
+    };
+};
+
+export const JSONToDelete = (m: DeleteJSON): Delete => {
+    return {
+        delete: m.delete,
+        new: m.new,
+        default: m.default,
+        function: m.function,
+        class: m.class,
+        var: m.var || {},
+        this: m.this,
+        in: enumFromJSON<Keywordsin>(Keywordsin, m.in),
+        case: m.if !== undefined ? {kind: "if", value: m.if} : m.else !== undefined ? {kind: "else", value: m.else} : undefined,
+    };
+};
+
+// isDelete reports if a value has the fields of a Delete, e.g. to check data read from a cache or a websocket.
+export const isDelete = (value: unknown): value is Delete => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.delete === "string"
+        && typeof m.new === "number"
+        && typeof m.default === "boolean"
+        && typeof m.function === "string"
+        && everyItem(m.class, (v) => typeof v === "string")
+        && everyValue(m.var, (v) => typeof v === "string")
+        && (m.this === undefined || typeof m.this === "string")
+        && typeof m.in === "number"
+        && (m.case === undefined || oneofMember(m.case, {if: (v) => typeof v === "string", else: (v) => typeof v === "number"}));
+};
+
+/** function is renamed, since a keyword is not a valid name of an interface. */
+export interface Keywordsfunction {
+    delete: Delete;
+}
+
+// KeywordsfunctionFields are the numbers and proto types of the fields of Keywordsfunction by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const KeywordsfunctionFields = {
+    delete: {number: 1, name: "delete", type: "message"},
+} as const;
+
+export interface KeywordsfunctionJSON {
+    delete: DeleteJSON;
+}
+
+export const KeywordsfunctionToJSON = (m: Keywordsfunction): KeywordsfunctionJSON => {
+    return {
+        delete: DeleteToJSON(m.delete),
+    };
+};
+
+export const JSONToKeywordsfunction = (m: KeywordsfunctionJSON): Keywordsfunction => {
+    return {
+        delete: JSONToDelete(m.delete),
+    };
+};
+
+// isKeywordsfunction reports if a value has the fields of a Keywordsfunction, e.g. to check data read from a cache or a websocket.
+export const isKeywordsfunction = (value: unknown): value is Keywordsfunction => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isDelete(m.delete);
+};
+
+/** Options is the request parameter of calls, which is renamed since the calls have options. */
+export interface Options {
+    id: string;
+}
+
+// OptionsFields are the numbers and proto types of the fields of Options by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const OptionsFields = {
+    id: {number: 1, name: "id", type: "string"},
+} as const;
+
+export interface OptionsJSON {
+    id: string;
+}
+
+export const OptionsToJSON = (m: Options): OptionsJSON => {
+    return {
+        id: m.id,
+    };
+};
+
+// isOptions reports if a value has the fields of a Options, e.g. to check data read from a cache or a websocket.
+export const isOptions = (value: unknown): value is Options => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "string";
+};
+
+/** JoinURL is the request parameter of calls, which is renamed since the calls import the joinURL function. */
+export interface JoinURL {
+    url: string;
+}
+
+// JoinURLFields are the numbers and proto types of the fields of JoinURL by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const JoinURLFields = {
+    url: {number: 1, name: "url", type: "string"},
+} as const;
+
+export interface JoinURLJSON {
+    url: string;
+}
+
+export const JoinURLToJSON = (m: JoinURL): JoinURLJSON => {
+    return {
+        url: m.url,
+    };
+};
+
+// isJoinURL reports if a value has the fields of a JoinURL, e.g. to check data read from a cache or a websocket.
+export const isJoinURL = (value: unknown): value is JoinURL => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.url === "string";
+};
+
+export interface Default {
+    delete: (deleteRequest: Delete, callOptions?: CallOptions) => Promise<Keywordsfunction>;
+
+    new: (keywordsfunction: Keywordsfunction, callOptions?: CallOptions) => Promise<Delete>;
+
+    /** Timeout is renamed, since the clients have a timeout method. */
+    defaultTimeout: (optionsRequest: Options, callOptions?: CallOptions) => Promise<Delete>;
+
+    /** CreateDelete is renamed with the functions client style and builders, which create a Delete with createDelete. */
+    createDelete: (joinURLRequest: JoinURL, callOptions?: CallOptions) => Promise<Delete>;
+}
+
+// DefaultMethods are the Twirp routes of the methods of Default, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const DefaultMethods = {
+    delete: {
+        service: "keywords.Default",
+        method: "Delete",
+        path: "/twirp/keywords.Default/Delete",
+        inputType: "Delete",
+        outputType: "Keywordsfunction",
+    },
+    new: {
+        service: "keywords.Default",
+        method: "New",
+        path: "/twirp/keywords.Default/New",
+        inputType: "Keywordsfunction",
+        outputType: "Delete",
+    },
+    defaultTimeout: {
+        service: "keywords.Default",
+        method: "Timeout",
+        path: "/twirp/keywords.Default/Timeout",
+        inputType: "Options",
+        outputType: "Delete",
+    },
+    createDelete: {
+        service: "keywords.Default",
+        method: "CreateDelete",
+        path: "/twirp/keywords.Default/CreateDelete",
+        inputType: "JoinURL",
+        outputType: "Delete",
+    },
+} as const;
+
+export class DefaultDefault implements Default {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/keywords.Default/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    delete(deleteRequest: Delete, callOptions?: CallOptions): Promise<Keywordsfunction> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Delete");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "keywords.Default",
+                method: "Delete",
+                url: url,
+                request: deleteRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, DeleteToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToKeywordsfunction(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    new(keywordsfunction: Keywordsfunction, callOptions?: CallOptions): Promise<Delete> {
+        const url = joinURL(this.hostname, this.pathPrefix + "New");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "keywords.Default",
+                method: "New",
+                url: url,
+                request: keywordsfunction,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, KeywordsfunctionToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToDelete(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    /** Timeout is renamed, since the clients have a timeout method. */
+    defaultTimeout(optionsRequest: Options, callOptions?: CallOptions): Promise<Delete> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Timeout");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "keywords.Default",
+                method: "Timeout",
+                url: url,
+                request: optionsRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, OptionsToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToDelete(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    /** CreateDelete is renamed with the functions client style and builders, which create a Delete with createDelete. */
+    createDelete(joinURLRequest: JoinURL, callOptions?: CallOptions): Promise<Delete> {
+        const url = joinURL(this.hostname, this.pathPrefix + "CreateDelete");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "keywords.Default",
+                method: "CreateDelete",
+                url: url,
+                request: joinURLRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, JoinURLToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToDelete(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createDefaultClient creates a DefaultDefault with the config, which may be shared by the clients of other services.
+export const createDefaultClient = (config: TwirpClientConfig): DefaultDefault => {
+    return new DefaultDefault(config);
+};
+
+// A DefaultMockResponses sets the response of each DefaultMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface DefaultMockResponses {
+    delete?: Keywordsfunction | ((deleteRequest: Delete, callOptions?: CallOptions) => Keywordsfunction | Promise<Keywordsfunction>);
+    new?: Delete | ((keywordsfunction: Keywordsfunction, callOptions?: CallOptions) => Delete | Promise<Delete>);
+    defaultTimeout?: Delete | ((optionsRequest: Options, callOptions?: CallOptions) => Delete | Promise<Delete>);
+    createDelete?: Delete | ((joinURLRequest: JoinURL, callOptions?: CallOptions) => Delete | Promise<Delete>);
+}
+
+// DefaultMockClient is a Default for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class DefaultMockClient implements Default {
+    responses: DefaultMockResponses;
+
+    constructor(responses: DefaultMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    delete(deleteRequest: Delete, callOptions?: CallOptions): Promise<Keywordsfunction> {
+        const response = this.responses.delete;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Default.Delete"}));
+        }
+
+        return new Promise<Keywordsfunction>((resolve) => resolve(typeof response === "function" ? response(deleteRequest, callOptions) : response));
+    }
+
+    new(keywordsfunction: Keywordsfunction, callOptions?: CallOptions): Promise<Delete> {
+        const response = this.responses.new;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Default.New"}));
+        }
+
+        return new Promise<Delete>((resolve) => resolve(typeof response === "function" ? response(keywordsfunction, callOptions) : response));
+    }
+
+    defaultTimeout(optionsRequest: Options, callOptions?: CallOptions): Promise<Delete> {
+        const response = this.responses.defaultTimeout;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Default.Timeout"}));
+        }
+
+        return new Promise<Delete>((resolve) => resolve(typeof response === "function" ? response(optionsRequest, callOptions) : response));
+    }
+
+    createDelete(joinURLRequest: JoinURL, callOptions?: CallOptions): Promise<Delete> {
+        const response = this.responses.createDelete;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Default.CreateDelete"}));
+        }
+
+        return new Promise<Delete>((resolve) => resolve(typeof response === "function" ? response(joinURLRequest, callOptions) : response));
+    }
+}
+
+export const createDefaultMock = (overrides: DefaultMockResponses = {}): DefaultMockClient => {
+    return new DefaultMockClient(overrides);
+};
@@ -0,0 +1,36 @@
+import {Inject, Injectable, Optional} from '@angular/core';
+import {HttpClient} from '@angular/common/http';
+import {Observable} from 'rxjs';
+import {CallOptions} from './twirp';
+import {TWIRP_HOSTNAME, TWIRP_PREFIX, httpClientTransport, observeCall} from './twirp_angular';
+import {DefaultDefault, Delete, JoinURL, Keywordsfunction, Options} from './keywords';
+
+// DefaultService is an Angular service of the Default rpc methods, which are called with HttpClient
+// when the returned Observables are subscribed. The hostname is provided with the TWIRP_HOSTNAME token.
+@Injectable({providedIn: "root"})
+export class DefaultService {
+    // client is the Default client of the service, e.g. to add interceptors or a retry policy
+    readonly client: DefaultDefault;
+
+    constructor(http: HttpClient, @Inject(TWIRP_HOSTNAME) hostname: string, @Optional() @Inject(TWIRP_PREFIX) prefix?: string | null) {
+        this.client = new DefaultDefault(hostname, httpClientTransport(http), {}, prefix || undefined);
+    }
+
+    delete(deleteRequest: Delete, callOptions?: CallOptions): Observable<Keywordsfunction> {
+        return observeCall(callOptions, (options) => this.client.delete(deleteRequest, options));
+    }
+
+    new(keywordsfunction: Keywordsfunction, callOptions?: CallOptions): Observable<Delete> {
+        return observeCall(callOptions, (options) => this.client.new(keywordsfunction, options));
+    }
+
+    /** Timeout is renamed, since the clients have a timeout method. */
+    defaultTimeout(optionsRequest: Options, callOptions?: CallOptions): Observable<Delete> {
+        return observeCall(callOptions, (options) => this.client.defaultTimeout(optionsRequest, options));
+    }
+
+    /** CreateDelete is renamed with the functions client style and builders, which create a Delete with createDelete. */
+    createDelete(joinURLRequest: JoinURL, callOptions?: CallOptions): Observable<Delete> {
+        return observeCall(callOptions, (options) => this.client.createDelete(joinURLRequest, options));
+    }
+}
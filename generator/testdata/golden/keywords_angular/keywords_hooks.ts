@@ -0,0 +1,22 @@
+import {useRpc, RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Default, Delete, JoinURL, Keywordsfunction, Options} from './keywords';
+
+// useDelete calls Default.Delete with the request when the component mounts, and again when the request changes.
+export const useDelete = (client: Default, deleteRequest: Delete, options?: RpcHookOptions): RpcHookResult<Keywordsfunction> => {
+    return useRpc((req, callOptions) => client.delete(req, callOptions), deleteRequest, options);
+};
+
+// useNew calls Default.New with the request when the component mounts, and again when the request changes.
+export const useNew = (client: Default, keywordsfunction: Keywordsfunction, options?: RpcHookOptions): RpcHookResult<Delete> => {
+    return useRpc((req, callOptions) => client.new(req, callOptions), keywordsfunction, options);
+};
+
+// useTimeout calls Default.Timeout with the request when the component mounts, and again when the request changes.
+export const useTimeout = (client: Default, optionsRequest: Options, options?: RpcHookOptions): RpcHookResult<Delete> => {
+    return useRpc((req, callOptions) => client.defaultTimeout(req, callOptions), optionsRequest, options);
+};
+
+// useCreateDelete calls Default.CreateDelete with the request when the component mounts, and again when the request changes.
+export const useCreateDelete = (client: Default, joinURLRequest: JoinURL, options?: RpcHookOptions): RpcHookResult<Delete> => {
+    return useRpc((req, callOptions) => client.createDelete(req, callOptions), joinURLRequest, options);
+};
@@ -0,0 +1,268 @@
+import {CallOptions, BuildWhenSet} from './twirp';
+import {TwirpClient} from './interceptors';
+
+/** in is renamed, since a keyword is not a valid name of an enum. */
+export declare enum Keywordsin {
+    IN_UNSPECIFIED = 0,
+}
+
+export type DeleteCase =
+    | {kind: "if"; value: string}
+    | {kind: "else"; value: number};
+
+/** Delete has fields that are named after javascript keywords, which are valid property names. */
+export interface Delete {
+    delete: string;
+    new: number;
+    default: boolean;
+    function: string;
+    class: string[];
+    var: {[key: string]: string};
+    this?: string | undefined;
+    in: Keywordsin;
+    case?: DeleteCase;
+}
+
+// DeleteFields are the numbers and proto types of the fields of Delete by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DeleteFields: {
+    readonly delete: {readonly number: 1; readonly name: "delete"; readonly type: "string"};
+    readonly new: {readonly number: 2; readonly name: "new"; readonly type: "int32"};
+    readonly default: {readonly number: 3; readonly name: "default"; readonly type: "bool"};
+    readonly function: {readonly number: 4; readonly name: "function"; readonly type: "string"};
+    readonly class: {readonly number: 5; readonly name: "class"; readonly type: "string"};
+    readonly var: {readonly number: 6; readonly name: "var"; readonly type: "map"};
+    readonly this: {readonly number: 7; readonly name: "this"; readonly type: "string"};
+    readonly in: {readonly number: 10; readonly name: "in"; readonly type: "enum"};
+    readonly if: {readonly number: 8; readonly name: "if"; readonly type: "string"};
+    readonly else: {readonly number: 9; readonly name: "else"; readonly type: "int32"};
+};
+
+export interface DeleteJSON {
+    delete: string;
+    new: number;
+    default: boolean;
+    function: string;
+    class: string[];
+    var: {[key: string]: string};
+    this?: string;
+    in: string | number;
+    if?: string;
+    else?: number;
+}
+
+export declare const DeleteToJSON: (m: Delete) => DeleteJSON;
+
+export declare const JSONToDelete: (m: DeleteJSON) => Delete;
+
+// isDelete reports if a value has the fields of a Delete, e.g. to check data read from a cache or a websocket.
+export declare const isDelete: (value: unknown) => value is Delete;
+
+// createDelete creates a Delete whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createDelete: (partial?: Partial<Delete>) => Delete;
+
+// DeleteBuilder builds a Delete one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface DeleteBuilder<Set extends string = never> {
+    delete(value: string): DeleteBuilder<Set | "delete">;
+    "new"(value: number): DeleteBuilder<Set | "new">;
+    default(value: boolean): DeleteBuilder<Set | "default">;
+    function(value: string): DeleteBuilder<Set | "function">;
+    class(value: string[]): DeleteBuilder<Set | "class">;
+    var(value: {[key: string]: string}): DeleteBuilder<Set | "var">;
+    this(value: string): DeleteBuilder<Set | "this">;
+    in(value: Keywordsin): DeleteBuilder<Set | "in">;
+    case(value: DeleteCase): DeleteBuilder<Set | "case">;
+    build: BuildWhenSet<never, Set, Delete>;
+}
+
+// buildDelete starts a DeleteBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildDelete: () => DeleteBuilder;
+
+/** function is renamed, since a keyword is not a valid name of an interface. */
+export interface Keywordsfunction {
+    delete: Delete;
+}
+
+// KeywordsfunctionFields are the numbers and proto types of the fields of Keywordsfunction by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const KeywordsfunctionFields: {
+    readonly delete: {readonly number: 1; readonly name: "delete"; readonly type: "message"};
+};
+
+export interface KeywordsfunctionJSON {
+    delete: DeleteJSON;
+}
+
+export declare const KeywordsfunctionToJSON: (m: Keywordsfunction) => KeywordsfunctionJSON;
+
+export declare const JSONToKeywordsfunction: (m: KeywordsfunctionJSON) => Keywordsfunction;
+
+// isKeywordsfunction reports if a value has the fields of a Keywordsfunction, e.g. to check data read from a cache or a websocket.
+export declare const isKeywordsfunction: (value: unknown) => value is Keywordsfunction;
+
+// createKeywordsfunction creates a Keywordsfunction whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createKeywordsfunction: (partial?: Partial<Keywordsfunction>) => Keywordsfunction;
+
+// KeywordsfunctionBuilder builds a Keywordsfunction one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface KeywordsfunctionBuilder<Set extends string = never> {
+    delete(value: Delete): KeywordsfunctionBuilder<Set | "delete">;
+    build: BuildWhenSet<never, Set, Keywordsfunction>;
+}
+
+// buildKeywordsfunction starts a KeywordsfunctionBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildKeywordsfunction: () => KeywordsfunctionBuilder;
+
+/** Options is the request parameter of calls, which is renamed since the calls have options. */
+export interface Options {
+    id: string;
+}
+
+// OptionsFields are the numbers and proto types of the fields of Options by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const OptionsFields: {
+    readonly id: {readonly number: 1; readonly name: "id"; readonly type: "string"};
+};
+
+export interface OptionsJSON {
+    id: string;
+}
+
+export declare const OptionsToJSON: (m: Options) => OptionsJSON;
+
+// isOptions reports if a value has the fields of a Options, e.g. to check data read from a cache or a websocket.
+export declare const isOptions: (value: unknown) => value is Options;
+
+// createOptions creates a Options whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createOptions: (partial?: Partial<Options>) => Options;
+
+// OptionsBuilder builds a Options one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface OptionsBuilder<Set extends string = never> {
+    id(value: string): OptionsBuilder<Set | "id">;
+    build: BuildWhenSet<never, Set, Options>;
+}
+
+// buildOptions starts a OptionsBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildOptions: () => OptionsBuilder;
+
+/** JoinURL is the request parameter of calls, which is renamed since the calls import the joinURL function. */
+export interface JoinURL {
+    url: string;
+}
+
+// JoinURLFields are the numbers and proto types of the fields of JoinURL by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const JoinURLFields: {
+    readonly url: {readonly number: 1; readonly name: "url"; readonly type: "string"};
+};
+
+export interface JoinURLJSON {
+    url: string;
+}
+
+export declare const JoinURLToJSON: (m: JoinURL) => JoinURLJSON;
+
+// isJoinURL reports if a value has the fields of a JoinURL, e.g. to check data read from a cache or a websocket.
+export declare const isJoinURL: (value: unknown) => value is JoinURL;
+
+// createJoinURL creates a JoinURL whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createJoinURL: (partial?: Partial<JoinURL>) => JoinURL;
+
+// JoinURLBuilder builds a JoinURL one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface JoinURLBuilder<Set extends string = never> {
+    url(value: string): JoinURLBuilder<Set | "url">;
+    build: BuildWhenSet<never, Set, JoinURL>;
+}
+
+// buildJoinURL starts a JoinURLBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildJoinURL: () => JoinURLBuilder;
+
+export interface Default {
+    delete: (deleteRequest: Delete, callOptions?: CallOptions) => Promise<Keywordsfunction>;
+
+    new: (keywordsfunction: Keywordsfunction, callOptions?: CallOptions) => Promise<Delete>;
+
+    /** Timeout is renamed, since the clients have a timeout method. */
+    defaultTimeout: (optionsRequest: Options, callOptions?: CallOptions) => Promise<Delete>;
+
+    /** CreateDelete is renamed with the functions client style and builders, which create a Delete with createDelete. */
+    createDelete: (joinURLRequest: JoinURL, callOptions?: CallOptions) => Promise<Delete>;
+}
+
+// DefaultMethods are the Twirp routes of the methods of Default, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const DefaultMethods: {
+    readonly delete: {
+        readonly service: "keywords.Default";
+        readonly method: "Delete";
+        readonly path: "/twirp/keywords.Default/Delete";
+        readonly inputType: "Delete";
+        readonly outputType: "Keywordsfunction";
+    };
+    readonly new: {
+        readonly service: "keywords.Default";
+        readonly method: "New";
+        readonly path: "/twirp/keywords.Default/New";
+        readonly inputType: "Keywordsfunction";
+        readonly outputType: "Delete";
+    };
+    readonly defaultTimeout: {
+        readonly service: "keywords.Default";
+        readonly method: "Timeout";
+        readonly path: "/twirp/keywords.Default/Timeout";
+        readonly inputType: "Options";
+        readonly outputType: "Delete";
+    };
+    readonly createDelete: {
+        readonly service: "keywords.Default";
+        readonly method: "CreateDelete";
+        readonly path: "/twirp/keywords.Default/CreateDelete";
+        readonly inputType: "JoinURL";
+        readonly outputType: "Delete";
+    };
+};
+
+export declare const defaultDelete: (client: TwirpClient, deleteRequest: Delete, callOptions?: CallOptions) => Promise<Keywordsfunction>;
+
+export declare const defaultNew: (client: TwirpClient, keywordsfunction: Keywordsfunction, callOptions?: CallOptions) => Promise<Delete>;
+
+/** Timeout is renamed, since the clients have a timeout method. */
+export declare const timeout: (client: TwirpClient, optionsRequest: Options, callOptions?: CallOptions) => Promise<Delete>;
+
+/** CreateDelete is renamed with the functions client style and builders, which create a Delete with createDelete. */
+export declare const defaultCreateDelete: (client: TwirpClient, joinURLRequest: JoinURL, callOptions?: CallOptions) => Promise<Delete>;
+
+// createDefaultClient creates a Default of the rpc functions of Default, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export declare const createDefaultClient: (client: TwirpClient) => Default;
+
+// A DefaultMockResponses sets the response of each DefaultMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface DefaultMockResponses {
+    delete?: Keywordsfunction | ((deleteRequest: Delete, callOptions?: CallOptions) => Keywordsfunction | Promise<Keywordsfunction>);
+    new?: Delete | ((keywordsfunction: Keywordsfunction, callOptions?: CallOptions) => Delete | Promise<Delete>);
+    defaultTimeout?: Delete | ((optionsRequest: Options, callOptions?: CallOptions) => Delete | Promise<Delete>);
+    createDelete?: Delete | ((joinURLRequest: JoinURL, callOptions?: CallOptions) => Delete | Promise<Delete>);
+}
+
+// DefaultMockClient is a Default for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class DefaultMockClient implements Default {
+    responses: DefaultMockResponses;
+
+    constructor(responses?: DefaultMockResponses);
+
+    delete(deleteRequest: Delete, callOptions?: CallOptions): Promise<Keywordsfunction>;
+    new(keywordsfunction: Keywordsfunction, callOptions?: CallOptions): Promise<Delete>;
+    defaultTimeout(optionsRequest: Options, callOptions?: CallOptions): Promise<Delete>;
+    createDelete(joinURLRequest: JoinURL, callOptions?: CallOptions): Promise<Delete>;
+}
+
+export declare const createDefaultMock: (overrides?: DefaultMockResponses) => DefaultMockClient;
@@ -0,0 +1,14 @@
+import {RpcHookOptions, RpcHookResult} from './twirp_react';
+import {Default, Delete, JoinURL, Keywordsfunction, Options} from './keywords';
+
+// useDelete calls Default.Delete with the request when the component mounts, and again when the request changes.
+export declare const useDelete: (client: Default, deleteRequest: Delete, options?: RpcHookOptions) => RpcHookResult<Keywordsfunction>;
+
+// useNew calls Default.New with the request when the component mounts, and again when the request changes.
+export declare const useNew: (client: Default, keywordsfunction: Keywordsfunction, options?: RpcHookOptions) => RpcHookResult<Delete>;
+
+// useTimeout calls Default.Timeout with the request when the component mounts, and again when the request changes.
+export declare const useTimeout: (client: Default, optionsRequest: Options, options?: RpcHookOptions) => RpcHookResult<Delete>;
+
+// useCreateDelete calls Default.CreateDelete with the request when the component mounts, and again when the request changes.
+export declare const useCreateDelete: (client: Default, joinURLRequest: JoinURL, options?: RpcHookOptions) => RpcHookResult<Delete>;
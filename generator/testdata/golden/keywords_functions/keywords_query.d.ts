@@ -0,0 +1,38 @@
+import {RpcQueryOptions, RpcMutationOptions} from './twirp_query';
+import {Default, Delete, JoinURL, Keywordsfunction, Options} from './keywords';
+
+// deleteQueryKey is the query key of Default.Delete queries, e.g. to invalidate the cached response of a request.
+export declare const deleteQueryKey: (deleteRequest: Delete) => readonly ["keywords.Default", "Delete", Delete];
+
+// deleteQuery are the query options of Default.Delete, e.g. useQuery(deleteQuery(client, deleteRequest))
+export declare const deleteQuery: (client: Default, deleteRequest: Delete) => RpcQueryOptions<Keywordsfunction, ReturnType<typeof deleteQueryKey>>;
+
+// deleteMutation are the mutation options of Default.Delete, e.g. useMutation(deleteMutation(client))
+export declare const deleteMutation: (client: Default) => RpcMutationOptions<Keywordsfunction, Delete>;
+
+// newQueryKey is the query key of Default.New queries, e.g. to invalidate the cached response of a request.
+export declare const newQueryKey: (keywordsfunction: Keywordsfunction) => readonly ["keywords.Default", "New", Keywordsfunction];
+
+// newQuery are the query options of Default.New, e.g. useQuery(newQuery(client, keywordsfunction))
+export declare const newQuery: (client: Default, keywordsfunction: Keywordsfunction) => RpcQueryOptions<Delete, ReturnType<typeof newQueryKey>>;
+
+// newMutation are the mutation options of Default.New, e.g. useMutation(newMutation(client))
+export declare const newMutation: (client: Default) => RpcMutationOptions<Delete, Keywordsfunction>;
+
+// timeoutQueryKey is the query key of Default.Timeout queries, e.g. to invalidate the cached response of a request.
+export declare const timeoutQueryKey: (optionsRequest: Options) => readonly ["keywords.Default", "Timeout", Options];
+
+// timeoutQuery are the query options of Default.Timeout, e.g. useQuery(timeoutQuery(client, optionsRequest))
+export declare const timeoutQuery: (client: Default, optionsRequest: Options) => RpcQueryOptions<Delete, ReturnType<typeof timeoutQueryKey>>;
+
+// timeoutMutation are the mutation options of Default.Timeout, e.g. useMutation(timeoutMutation(client))
+export declare const timeoutMutation: (client: Default) => RpcMutationOptions<Delete, Options>;
+
+// createDeleteQueryKey is the query key of Default.CreateDelete queries, e.g. to invalidate the cached response of a request.
+export declare const createDeleteQueryKey: (joinURLRequest: JoinURL) => readonly ["keywords.Default", "CreateDelete", JoinURL];
+
+// createDeleteQuery are the query options of Default.CreateDelete, e.g. useQuery(createDeleteQuery(client, joinURLRequest))
+export declare const createDeleteQuery: (client: Default, joinURLRequest: JoinURL) => RpcQueryOptions<Delete, ReturnType<typeof createDeleteQueryKey>>;
+
+// createDeleteMutation are the mutation options of Default.CreateDelete, e.g. useMutation(createDeleteMutation(client))
+export declare const createDeleteMutation: (client: Default) => RpcMutationOptions<Delete, JoinURL>;
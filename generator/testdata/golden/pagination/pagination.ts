@@ -0,0 +1,508 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, jsonAliases, everyItem} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+export interface ListHatsRequest {
+    pageSize: number;
+    pageToken: string;
+    color: string;
+}
+
+// ListHatsRequestFields are the numbers and proto types of the fields of ListHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsRequestFields = {
+    pageSize: {number: 1, name: "page_size", type: "int32"},
+    pageToken: {number: 2, name: "page_token", type: "string"},
+    color: {number: 3, name: "color", type: "string"},
+} as const;
+
+export interface ListHatsRequestJSON {
+    page_size: number;
+    page_token: string;
+    color: string;
+}
+
+export const ListHatsRequestToJSON = (m: ListHatsRequest): ListHatsRequestJSON => {
+    return {
+        page_size: m.pageSize,
+        page_token: m.pageToken,
+        color: m.color,
+    };
+};
+
+// isListHatsRequest reports if a value has the fields of a ListHatsRequest, e.g. to check data read from a cache or a websocket.
+export const isListHatsRequest = (value: unknown): value is ListHatsRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.pageSize === "number"
+        && typeof m.pageToken === "string"
+        && typeof m.color === "string";
+};
+
+export interface ListHatsResponse {
+    hats: Hat[];
+    nextPageToken: string;
+    totalSize: number;
+}
+
+// ListHatsResponseFields are the numbers and proto types of the fields of ListHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsResponseFields = {
+    hats: {number: 1, name: "hats", type: "message"},
+    nextPageToken: {number: 2, name: "next_page_token", type: "string"},
+    totalSize: {number: 3, name: "total_size", type: "int32"},
+} as const;
+
+export interface ListHatsResponseJSON {
+    hats: HatJSON[];
+    next_page_token: string;
+    total_size: number;
+}
+
+export const JSONToListHatsResponse = (json: ListHatsResponseJSON): ListHatsResponse => {
+    const m = jsonAliases(json, {"nextPageToken": "next_page_token", "totalSize": "total_size"});
+
+    return {
+        hats: m.hats.map(JSONToHat),
+        nextPageToken: m.next_page_token,
+        totalSize: m.total_size,
+    };
+};
+
+// isListHatsResponse reports if a value has the fields of a ListHatsResponse, e.g. to check data read from a cache or a websocket.
+export const isListHatsResponse = (value: unknown): value is ListHatsResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.hats, isHat)
+        && typeof m.nextPageToken === "string"
+        && typeof m.totalSize === "number";
+};
+
+/** SearchHatsResponse has no next_page_token, so SearchHats is not a list method. */
+export interface SearchHatsResponse {
+    hats: Hat[];
+}
+
+// SearchHatsResponseFields are the numbers and proto types of the fields of SearchHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SearchHatsResponseFields = {
+    hats: {number: 1, name: "hats", type: "message"},
+} as const;
+
+export interface SearchHatsResponseJSON {
+    hats: HatJSON[];
+}
+
+export const JSONToSearchHatsResponse = (m: SearchHatsResponseJSON): SearchHatsResponse => {
+    return {
+        hats: m.hats.map(JSONToHat),
+    };
+};
+
+// isSearchHatsResponse reports if a value has the fields of a SearchHatsResponse, e.g. to check data read from a cache or a websocket.
+export const isSearchHatsResponse = (value: unknown): value is SearchHatsResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.hats, isHat);
+};
+
+/** A Haberdasher makes hats. */
+export interface Haberdasher {
+    /** ListHats lists the hats, a page at a time. */
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ListHatsResponse>;
+
+    searchHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<SearchHatsResponse>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    listHats: {
+        service: "pagination.Haberdasher",
+        method: "ListHats",
+        path: "/twirp/pagination.Haberdasher/ListHats",
+        inputType: "ListHatsRequest",
+        outputType: "ListHatsResponse",
+    },
+    searchHats: {
+        service: "pagination.Haberdasher",
+        method: "SearchHats",
+        path: "/twirp/pagination.Haberdasher/SearchHats",
+        inputType: "ListHatsRequest",
+        outputType: "SearchHatsResponse",
+    },
+    makeHat: {
+        service: "pagination.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/pagination.Haberdasher/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** A Haberdasher makes hats. */
+export class DefaultHaberdasher implements Haberdasher {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/pagination.Haberdasher/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** ListHats lists the hats, a page at a time. */
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> {
+        const url = joinURL(this.hostname, this.pathPrefix + "ListHats");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "pagination.Haberdasher",
+                method: "ListHats",
+                url: url,
+                request: listHatsRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToListHatsResponse(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    searchHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<SearchHatsResponse> {
+        const url = joinURL(this.hostname, this.pathPrefix + "SearchHats");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "pagination.Haberdasher",
+                method: "SearchHats",
+                url: url,
+                request: listHatsRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToSearchHatsResponse(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "pagination.Haberdasher",
+                method: "MakeHat",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export const createHaberdasherClient = (config: TwirpClientConfig): DefaultHaberdasher => {
+    return new DefaultHaberdasher(config);
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    listHats?: ListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ListHatsResponse | Promise<ListHatsResponse>);
+    searchHats?: SearchHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => SearchHatsResponse | Promise<SearchHatsResponse>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> {
+        const response = this.responses.listHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.ListHats"}));
+        }
+
+        return new Promise<ListHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+
+    searchHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<SearchHatsResponse> {
+        const response = this.responses.searchHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.SearchHats"}));
+        }
+
+        return new Promise<SearchHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
+
+/** Milliner has a ListHats method too, so its helper is named after the service. */
+export interface Milliner {
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ListHatsResponse>;
+}
+
+// MillinerMethods are the Twirp routes of the methods of Milliner, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const MillinerMethods = {
+    listHats: {
+        service: "pagination.Milliner",
+        method: "ListHats",
+        path: "/twirp/pagination.Milliner/ListHats",
+        inputType: "ListHatsRequest",
+        outputType: "ListHatsResponse",
+    },
+} as const;
+
+/** Milliner has a ListHats method too, so its helper is named after the service. */
+export class DefaultMilliner implements Milliner {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/pagination.Milliner/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> {
+        const url = joinURL(this.hostname, this.pathPrefix + "ListHats");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "pagination.Milliner",
+                method: "ListHats",
+                url: url,
+                request: listHatsRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToListHatsResponse(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createMillinerClient creates a DefaultMilliner with the config, which may be shared by the clients of other services.
+export const createMillinerClient = (config: TwirpClientConfig): DefaultMilliner => {
+    return new DefaultMilliner(config);
+};
+
+// A MillinerMockResponses sets the response of each MillinerMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface MillinerMockResponses {
+    listHats?: ListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ListHatsResponse | Promise<ListHatsResponse>);
+}
+
+// MillinerMockClient is a Milliner for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class MillinerMockClient implements Milliner {
+    responses: MillinerMockResponses;
+
+    constructor(responses: MillinerMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse> {
+        const response = this.responses.listHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Milliner.ListHats"}));
+        }
+
+        return new Promise<ListHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+}
+
+export const createMillinerMock = (overrides: MillinerMockResponses = {}): MillinerMockClient => {
+    return new MillinerMockClient(overrides);
+};
@@ -0,0 +1,17 @@
+import {paginate} from './twirp_pagination';
+import {CallOptions} from './twirp';
+import {Haberdasher, ListHatsRequest, ListHatsResponse, Milliner} from './pagination';
+
+// haberdasherListHatsPages calls Haberdasher.ListHats for each page of its results, starting with the
+// pageToken of the request, until a response has no nextPageToken, e.g.
+// for await (const page of haberdasherListHatsPages(client, listHatsRequest)) { ... page.hats ... }
+export const haberdasherListHatsPages = (client: Haberdasher, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): AsyncIterableIterator<ListHatsResponse> => {
+    return paginate(listHatsRequest, "pageToken", "nextPageToken", (req) => client.listHats(req, callOptions));
+};
+
+// millinerListHatsPages calls Milliner.ListHats for each page of its results, starting with the
+// pageToken of the request, until a response has no nextPageToken, e.g.
+// for await (const page of millinerListHatsPages(client, listHatsRequest)) { ... page.hats ... }
+export const millinerListHatsPages = (client: Milliner, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): AsyncIterableIterator<ListHatsResponse> => {
+    return paginate(listHatsRequest, "pageToken", "nextPageToken", (req) => client.listHats(req, callOptions));
+};
@@ -0,0 +1,47 @@
+// paginate calls list for each page of the results of a list method, starting with the page token of the request,
+// until a response has no next page token, or has the token of the page it is the response to. The pages are
+// requested one at a time, when the next page is read, and an error ends the iteration after it is thrown.
+export const paginate = <Req, Resp>(request: Req, pageToken: keyof Req, nextPageToken: keyof Resp, list: (req: Req) => Promise<Resp>): AsyncIterableIterator<Resp> => {
+    let token: any = request[pageToken];
+    let done = false;
+    let last: Promise<any> = Promise.resolve();
+
+    const next = (): Promise<IteratorResult<Resp>> => {
+        const page = last.then((): IteratorResult<Resp> | Promise<IteratorResult<Resp>> => {
+            if (done) {
+                return {value: undefined, done: true};
+            }
+
+            const req: any = {};
+            Object.keys(request).forEach((k) => req[k] = (request as any)[k]);
+            req[pageToken] = token;
+
+            return list(req as Req).then((resp) => {
+                const next: any = resp[nextPageToken];
+                done = !next || next === token;
+                token = next;
+
+                return {value: resp, done: false};
+            }, (err) => {
+                done = true;
+                throw err;
+            });
+        });
+
+        // the next page is requested after this one, whether or not it fails
+        last = page.catch(() => undefined);
+
+        return page;
+    };
+
+    const pages: AsyncIterableIterator<Resp> = {
+        next: next,
+        return: () => {
+            done = true;
+            return Promise.resolve<IteratorResult<Resp>>({value: undefined, done: true});
+        },
+        [Symbol.asyncIterator]: () => pages,
+    };
+
+    return pages;
+};
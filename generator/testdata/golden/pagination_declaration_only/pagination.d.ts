@@ -0,0 +1,265 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly size: {readonly number: 1; readonly name: "size"; readonly type: "int32"};
+    readonly color: {readonly number: 2; readonly name: "color"; readonly type: "string"};
+};
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export declare const HatToJSON: (m: Hat) => HatJSON;
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+export interface ListHatsRequest {
+    pageSize: number;
+    pageToken: string;
+    color: string;
+}
+
+// ListHatsRequestFields are the numbers and proto types of the fields of ListHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListHatsRequestFields: {
+    readonly pageSize: {readonly number: 1; readonly name: "page_size"; readonly type: "int32"};
+    readonly pageToken: {readonly number: 2; readonly name: "page_token"; readonly type: "string"};
+    readonly color: {readonly number: 3; readonly name: "color"; readonly type: "string"};
+};
+
+export interface ListHatsRequestJSON {
+    page_size: number;
+    page_token: string;
+    color: string;
+}
+
+export declare const ListHatsRequestToJSON: (m: ListHatsRequest) => ListHatsRequestJSON;
+
+// isListHatsRequest reports if a value has the fields of a ListHatsRequest, e.g. to check data read from a cache or a websocket.
+export declare const isListHatsRequest: (value: unknown) => value is ListHatsRequest;
+
+export interface ListHatsResponse {
+    hats: Hat[];
+    nextPageToken: string;
+    totalSize: number;
+}
+
+// ListHatsResponseFields are the numbers and proto types of the fields of ListHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListHatsResponseFields: {
+    readonly hats: {readonly number: 1; readonly name: "hats"; readonly type: "message"};
+    readonly nextPageToken: {readonly number: 2; readonly name: "next_page_token"; readonly type: "string"};
+    readonly totalSize: {readonly number: 3; readonly name: "total_size"; readonly type: "int32"};
+};
+
+export interface ListHatsResponseJSON {
+    hats: HatJSON[];
+    next_page_token: string;
+    total_size: number;
+}
+
+export declare const JSONToListHatsResponse: (m: ListHatsResponseJSON) => ListHatsResponse;
+
+// isListHatsResponse reports if a value has the fields of a ListHatsResponse, e.g. to check data read from a cache or a websocket.
+export declare const isListHatsResponse: (value: unknown) => value is ListHatsResponse;
+
+/** SearchHatsResponse has no next_page_token, so SearchHats is not a list method. */
+export interface SearchHatsResponse {
+    hats: Hat[];
+}
+
+// SearchHatsResponseFields are the numbers and proto types of the fields of SearchHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SearchHatsResponseFields: {
+    readonly hats: {readonly number: 1; readonly name: "hats"; readonly type: "message"};
+};
+
+export interface SearchHatsResponseJSON {
+    hats: HatJSON[];
+}
+
+export declare const JSONToSearchHatsResponse: (m: SearchHatsResponseJSON) => SearchHatsResponse;
+
+// isSearchHatsResponse reports if a value has the fields of a SearchHatsResponse, e.g. to check data read from a cache or a websocket.
+export declare const isSearchHatsResponse: (value: unknown) => value is SearchHatsResponse;
+
+/** A Haberdasher makes hats. */
+export interface Haberdasher {
+    /** ListHats lists the hats, a page at a time. */
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ListHatsResponse>;
+
+    searchHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<SearchHatsResponse>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const HaberdasherMethods: {
+    readonly listHats: {
+        readonly service: "pagination.Haberdasher";
+        readonly method: "ListHats";
+        readonly path: "/twirp/pagination.Haberdasher/ListHats";
+        readonly inputType: "ListHatsRequest";
+        readonly outputType: "ListHatsResponse";
+    };
+    readonly searchHats: {
+        readonly service: "pagination.Haberdasher";
+        readonly method: "SearchHats";
+        readonly path: "/twirp/pagination.Haberdasher/SearchHats";
+        readonly inputType: "ListHatsRequest";
+        readonly outputType: "SearchHatsResponse";
+    };
+    readonly makeHat: {
+        readonly service: "pagination.Haberdasher";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/pagination.Haberdasher/MakeHat";
+        readonly inputType: "Hat";
+        readonly outputType: "Hat";
+    };
+};
+
+/** A Haberdasher makes hats. */
+export declare class DefaultHaberdasher implements Haberdasher {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    /** ListHats lists the hats, a page at a time. */
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse>;
+    searchHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<SearchHatsResponse>;
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat>;
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export declare const createHaberdasherClient: (config: TwirpClientConfig) => DefaultHaberdasher;
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    listHats?: ListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ListHatsResponse | Promise<ListHatsResponse>);
+    searchHats?: SearchHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => SearchHatsResponse | Promise<SearchHatsResponse>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses?: HaberdasherMockResponses);
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse>;
+    searchHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<SearchHatsResponse>;
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat>;
+}
+
+export declare const createHaberdasherMock: (overrides?: HaberdasherMockResponses) => HaberdasherMockClient;
+
+/** Milliner has a ListHats method too, so its helper is named after the service. */
+export interface Milliner {
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ListHatsResponse>;
+}
+
+// MillinerMethods are the Twirp routes of the methods of Milliner, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const MillinerMethods: {
+    readonly listHats: {
+        readonly service: "pagination.Milliner";
+        readonly method: "ListHats";
+        readonly path: "/twirp/pagination.Milliner/ListHats";
+        readonly inputType: "ListHatsRequest";
+        readonly outputType: "ListHatsResponse";
+    };
+};
+
+/** Milliner has a ListHats method too, so its helper is named after the service. */
+export declare class DefaultMilliner implements Milliner {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse>;
+}
+
+// createMillinerClient creates a DefaultMilliner with the config, which may be shared by the clients of other services.
+export declare const createMillinerClient: (config: TwirpClientConfig) => DefaultMilliner;
+
+// A MillinerMockResponses sets the response of each MillinerMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface MillinerMockResponses {
+    listHats?: ListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ListHatsResponse | Promise<ListHatsResponse>);
+}
+
+// MillinerMockClient is a Milliner for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class MillinerMockClient implements Milliner {
+    responses: MillinerMockResponses;
+
+    constructor(responses?: MillinerMockResponses);
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ListHatsResponse>;
+}
+
+export declare const createMillinerMock: (overrides?: MillinerMockResponses) => MillinerMockClient;
@@ -0,0 +1,426 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, CallOptions, jsonAliases, everyItem} from './twirp';
+import {InterceptorContext, TwirpClient, runInterceptors} from './interceptors';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// ReadonlyHat is the interface of a Hat returned by the clients, whose fields cannot be changed.
+export interface ReadonlyHat {
+    readonly size: number;
+    readonly color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+export interface ListHatsRequest {
+    page_size: number;
+    page_token: string;
+    color: string;
+}
+
+// ListHatsRequestFields are the numbers and proto types of the fields of ListHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsRequestFields = {
+    page_size: {number: 1, name: "page_size", type: "int32"},
+    page_token: {number: 2, name: "page_token", type: "string"},
+    color: {number: 3, name: "color", type: "string"},
+} as const;
+
+export interface ListHatsRequestJSON {
+    page_size: number;
+    page_token: string;
+    color: string;
+}
+
+export const ListHatsRequestToJSON = (m: ListHatsRequest): ListHatsRequestJSON => {
+    return {
+        page_size: m.page_size,
+        page_token: m.page_token,
+        color: m.color,
+    };
+};
+
+// isListHatsRequest reports if a value has the fields of a ListHatsRequest, e.g. to check data read from a cache or a websocket.
+export const isListHatsRequest = (value: unknown): value is ListHatsRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.page_size === "number"
+        && typeof m.page_token === "string"
+        && typeof m.color === "string";
+};
+
+export interface ListHatsResponse {
+    hats: Hat[];
+    next_page_token: string;
+    total_size: number;
+}
+
+// ReadonlyListHatsResponse is the interface of a ListHatsResponse returned by the clients, whose fields cannot be changed.
+export interface ReadonlyListHatsResponse {
+    readonly hats: ReadonlyArray<ReadonlyHat>;
+    readonly next_page_token: string;
+    readonly total_size: number;
+}
+
+// ListHatsResponseFields are the numbers and proto types of the fields of ListHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListHatsResponseFields = {
+    hats: {number: 1, name: "hats", type: "message"},
+    next_page_token: {number: 2, name: "next_page_token", type: "string"},
+    total_size: {number: 3, name: "total_size", type: "int32"},
+} as const;
+
+export interface ListHatsResponseJSON {
+    hats: HatJSON[];
+    next_page_token: string;
+    total_size: number;
+}
+
+export const JSONToListHatsResponse = (json: ListHatsResponseJSON): ListHatsResponse => {
+    const m = jsonAliases(json, {"nextPageToken": "next_page_token", "totalSize": "total_size"});
+
+    return {
+        hats: m.hats.map(JSONToHat),
+        next_page_token: m.next_page_token,
+        total_size: m.total_size,
+    };
+};
+
+// isListHatsResponse reports if a value has the fields of a ListHatsResponse, e.g. to check data read from a cache or a websocket.
+export const isListHatsResponse = (value: unknown): value is ListHatsResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.hats, isHat)
+        && typeof m.next_page_token === "string"
+        && typeof m.total_size === "number";
+};
+
+/** SearchHatsResponse has no next_page_token, so SearchHats is not a list method. */
+export interface SearchHatsResponse {
+    hats: Hat[];
+}
+
+// ReadonlySearchHatsResponse is the interface of a SearchHatsResponse returned by the clients, whose fields cannot be changed.
+export interface ReadonlySearchHatsResponse {
+    readonly hats: ReadonlyArray<ReadonlyHat>;
+}
+
+// SearchHatsResponseFields are the numbers and proto types of the fields of SearchHatsResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SearchHatsResponseFields = {
+    hats: {number: 1, name: "hats", type: "message"},
+} as const;
+
+export interface SearchHatsResponseJSON {
+    hats: HatJSON[];
+}
+
+export const JSONToSearchHatsResponse = (m: SearchHatsResponseJSON): SearchHatsResponse => {
+    return {
+        hats: m.hats.map(JSONToHat),
+    };
+};
+
+// isSearchHatsResponse reports if a value has the fields of a SearchHatsResponse, e.g. to check data read from a cache or a websocket.
+export const isSearchHatsResponse = (value: unknown): value is SearchHatsResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.hats, isHat);
+};
+
+/** A Haberdasher makes hats. */
+export interface Haberdasher {
+    /** ListHats lists the hats, a page at a time. */
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ReadonlyListHatsResponse>;
+
+    searchHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ReadonlySearchHatsResponse>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<ReadonlyHat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    listHats: {
+        service: "pagination.Haberdasher",
+        method: "ListHats",
+        path: "/twirp/pagination.Haberdasher/ListHats",
+        inputType: "ListHatsRequest",
+        outputType: "ListHatsResponse",
+    },
+    searchHats: {
+        service: "pagination.Haberdasher",
+        method: "SearchHats",
+        path: "/twirp/pagination.Haberdasher/SearchHats",
+        inputType: "ListHatsRequest",
+        outputType: "SearchHatsResponse",
+    },
+    makeHat: {
+        service: "pagination.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/pagination.Haberdasher/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** ListHats lists the hats, a page at a time. */
+export const haberdasherListHats = (client: TwirpClient, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/pagination.Haberdasher/ListHats");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "pagination.Haberdasher",
+            method: "ListHats",
+            url: url,
+            request: listHatsRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToListHatsResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+export const searchHats = (client: TwirpClient, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlySearchHatsResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/pagination.Haberdasher/SearchHats");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "pagination.Haberdasher",
+            method: "SearchHats",
+            url: url,
+            request: listHatsRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToSearchHatsResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+export const makeHat = (client: TwirpClient, hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/pagination.Haberdasher/MakeHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "pagination.Haberdasher",
+            method: "MakeHat",
+            url: url,
+            request: hat,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createHaberdasherClient creates a Haberdasher of the rpc functions of Haberdasher, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createHaberdasherClient = (client: TwirpClient): Haberdasher => {
+    return {
+        listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => haberdasherListHats(client, listHatsRequest, callOptions),
+        searchHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => searchHats(client, listHatsRequest, callOptions),
+        makeHat: (hat: Hat, callOptions?: CallOptions) => makeHat(client, hat, callOptions),
+    };
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    listHats?: ReadonlyListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ReadonlyListHatsResponse | Promise<ReadonlyListHatsResponse>);
+    searchHats?: ReadonlySearchHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ReadonlySearchHatsResponse | Promise<ReadonlySearchHatsResponse>);
+    makeHat?: ReadonlyHat | ((hat: Hat, callOptions?: CallOptions) => ReadonlyHat | Promise<ReadonlyHat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse> {
+        const response = this.responses.listHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.ListHats"}));
+        }
+
+        return new Promise<ReadonlyListHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+
+    searchHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlySearchHatsResponse> {
+        const response = this.responses.searchHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.SearchHats"}));
+        }
+
+        return new Promise<ReadonlySearchHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<ReadonlyHat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
+
+/** Milliner has a ListHats method too, so its helper is named after the service. */
+export interface Milliner {
+    listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => Promise<ReadonlyListHatsResponse>;
+}
+
+// MillinerMethods are the Twirp routes of the methods of Milliner, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const MillinerMethods = {
+    listHats: {
+        service: "pagination.Milliner",
+        method: "ListHats",
+        path: "/twirp/pagination.Milliner/ListHats",
+        inputType: "ListHatsRequest",
+        outputType: "ListHatsResponse",
+    },
+} as const;
+
+export const millinerListHats = (client: TwirpClient, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/pagination.Milliner/ListHats");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "pagination.Milliner",
+            method: "ListHats",
+            url: url,
+            request: listHatsRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToListHatsResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createMillinerClient creates a Milliner of the rpc functions of Milliner, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createMillinerClient = (client: TwirpClient): Milliner => {
+    return {
+        listHats: (listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => millinerListHats(client, listHatsRequest, callOptions),
+    };
+};
+
+// A MillinerMockResponses sets the response of each MillinerMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface MillinerMockResponses {
+    listHats?: ReadonlyListHatsResponse | ((listHatsRequest: ListHatsRequest, callOptions?: CallOptions) => ReadonlyListHatsResponse | Promise<ReadonlyListHatsResponse>);
+}
+
+// MillinerMockClient is a Milliner for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class MillinerMockClient implements Milliner {
+    responses: MillinerMockResponses;
+
+    constructor(responses: MillinerMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    listHats(listHatsRequest: ListHatsRequest, callOptions?: CallOptions): Promise<ReadonlyListHatsResponse> {
+        const response = this.responses.listHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Milliner.ListHats"}));
+        }
+
+        return new Promise<ReadonlyListHatsResponse>((resolve) => resolve(typeof response === "function" ? response(listHatsRequest, callOptions) : response));
+    }
+}
+
+export const createMillinerMock = (overrides: MillinerMockResponses = {}): MillinerMockClient => {
+    return new MillinerMockClient(overrides);
+};
@@ -0,0 +1,17 @@
+import {paginate} from './twirp_pagination';
+import {CallOptions} from './twirp';
+import {Haberdasher, ListHatsRequest, Milliner, ReadonlyListHatsResponse} from './pagination';
+
+// haberdasherListHatsPages calls Haberdasher.ListHats for each page of its results, starting with the
+// page_token of the request, until a response has no next_page_token, e.g.
+// for await (const page of haberdasherListHatsPages(client, listHatsRequest)) { ... page.hats ... }
+export const haberdasherListHatsPages = (client: Haberdasher, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): AsyncIterableIterator<ReadonlyListHatsResponse> => {
+    return paginate(listHatsRequest, "page_token", "next_page_token", (req) => client.listHats(req, callOptions));
+};
+
+// millinerListHatsPages calls Milliner.ListHats for each page of its results, starting with the
+// page_token of the request, until a response has no next_page_token, e.g.
+// for await (const page of millinerListHatsPages(client, listHatsRequest)) { ... page.hats ... }
+export const millinerListHatsPages = (client: Milliner, listHatsRequest: ListHatsRequest, callOptions?: CallOptions): AsyncIterableIterator<ReadonlyListHatsResponse> => {
+    return paginate(listHatsRequest, "page_token", "next_page_token", (req) => client.listHats(req, callOptions));
+};
@@ -0,0 +1,181 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider, BuildWhenSet} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+export declare enum Fabric {
+    FABRIC_FELT = 1,
+    FABRIC_STRAW = 2,
+}
+
+export type HatBrim =
+    | {kind: "brimWidth"; value: number}
+    | {kind: "brimStyle"; value: string};
+
+/**
+ * A Hat of a proto2 file, whose optional fields are undefined when they are not set, unless they have a default
+ * value.
+ */
+export interface Hat {
+    inches: number;
+    name?: string | undefined;
+    color: string;
+    fabric: Fabric;
+    stock: number;
+    soft: boolean;
+    tag: Uint8Array;
+    ratio: number;
+    size?: number | undefined;
+    labels: string[];
+    measured: Size;
+    brim?: HatBrim;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly inches: {readonly number: 1; readonly name: "inches"; readonly type: "int32"};
+    readonly name: {readonly number: 2; readonly name: "name"; readonly type: "string"};
+    readonly color: {readonly number: 3; readonly name: "color"; readonly type: "string"};
+    readonly fabric: {readonly number: 4; readonly name: "fabric"; readonly type: "enum"};
+    readonly stock: {readonly number: 5; readonly name: "stock"; readonly type: "int64"};
+    readonly soft: {readonly number: 6; readonly name: "soft"; readonly type: "bool"};
+    readonly tag: {readonly number: 7; readonly name: "tag"; readonly type: "bytes"};
+    readonly ratio: {readonly number: 8; readonly name: "ratio"; readonly type: "double"};
+    readonly size: {readonly number: 9; readonly name: "size"; readonly type: "uint32"};
+    readonly labels: {readonly number: 10; readonly name: "labels"; readonly type: "string"};
+    readonly measured: {readonly number: 11; readonly name: "measured"; readonly type: "message"};
+    readonly brimWidth: {readonly number: 12; readonly name: "brim_width"; readonly type: "int32"};
+    readonly brimStyle: {readonly number: 13; readonly name: "brim_style"; readonly type: "string"};
+};
+
+export interface HatJSON {
+    inches: number;
+    name?: string;
+    color: string;
+    fabric: string | number;
+    stock: string;
+    soft: boolean;
+    tag: string;
+    ratio: number | string;
+    size?: number;
+    labels: string[];
+    measured: SizeJSON;
+    brim_width?: number;
+    brim_style?: string;
+}
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+// createHat creates a Hat whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createHat: (partial?: Partial<Hat>) => Hat;
+
+export interface Size {
+    inches: number;
+    scale: number;
+}
+
+// SizeFields are the numbers and proto types of the fields of Size by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SizeFields: {
+    readonly inches: {readonly number: 1; readonly name: "inches"; readonly type: "int32"};
+    readonly scale: {readonly number: 2; readonly name: "scale"; readonly type: "float"};
+};
+
+export interface SizeJSON {
+    inches: number;
+    scale: number | string;
+}
+
+export declare const SizeToJSON: (m: Size) => SizeJSON;
+
+export declare const JSONToSize: (m: SizeJSON) => Size;
+
+// isSize reports if a value has the fields of a Size, e.g. to check data read from a cache or a websocket.
+export declare const isSize: (value: unknown) => value is Size;
+
+// createSize creates a Size whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export declare const createSize: (partial?: Partial<Size>) => Size;
+
+// SizeBuilder builds a Size one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface SizeBuilder<Set extends string = never> {
+    inches(value: number): SizeBuilder<Set | "inches">;
+    scale(value: number): SizeBuilder<Set | "scale">;
+    build: BuildWhenSet<"inches", Set, Size>;
+}
+
+// buildSize starts a SizeBuilder, e.g. to build a request whose required fields are checked at compile time.
+export declare const buildSize: () => SizeBuilder;
+
+export interface Haberdasher {
+    makeHat: (size: Size, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const HaberdasherMethods: {
+    readonly makeHat: {
+        readonly service: "proto2.Haberdasher";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/proto2.Haberdasher/MakeHat";
+        readonly inputType: "Size";
+        readonly outputType: "Hat";
+    };
+};
+
+export declare class DefaultHaberdasher implements Haberdasher {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat>;
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export declare const createHaberdasherClient: (config: TwirpClientConfig) => DefaultHaberdasher;
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    makeHat?: Hat | ((size: Size, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses?: HaberdasherMockResponses);
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat>;
+}
+
+export declare const createHaberdasherMock: (overrides?: HaberdasherMockResponses) => HaberdasherMockClient;
@@ -0,0 +1,380 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, bytesToBase64, base64ToBytes, enumFromJSON, floatToJSON, floatFromJSON, jsonAliases, everyItem, oneofMember} from './twirp';
+import {parseLosslessJSON} from './twirp';
+import {cloneValue, valuesEqual} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+export enum Fabric {
+    FABRIC_FELT = 1,
+    FABRIC_STRAW = 2,
+}
+
+export type HatBrim =
+    | {kind: "brimWidth"; value: number}
+    | {kind: "brimStyle"; value: string};
+
+/**
+ * A Hat of a proto2 file, whose optional fields are undefined when they are not set, unless they have a default
+ * value.
+ */
+export class Hat {
+    inches: number;
+    name?: string | undefined;
+    color: string;
+    fabric: Fabric;
+    stock: string;
+    soft: boolean;
+    tag: Uint8Array;
+    ratio: number;
+    size?: number | undefined;
+    labels: string[];
+    measured: Size;
+    brim?: HatBrim;
+
+    constructor(init: Partial<Hat> = {}) {
+        this.inches = init.inches !== undefined ? init.inches : 0;
+        this.name = init.name;
+        this.color = init.color !== undefined ? init.color : "black";
+        this.fabric = init.fabric !== undefined ? init.fabric : Fabric.FABRIC_FELT;
+        this.stock = init.stock !== undefined ? init.stock : "12";
+        this.soft = init.soft !== undefined ? init.soft : true;
+        this.tag = init.tag !== undefined ? init.tag : new Uint8Array([104, 97, 116, 1]);
+        this.ratio = init.ratio !== undefined ? init.ratio : Infinity;
+        this.size = init.size;
+        this.labels = init.labels !== undefined ? init.labels : [];
+        this.measured = init.measured as Size;
+        this.brim = init.brim;
+    }
+
+    // clone returns a deep copy of the Hat.
+    clone(): Hat {
+        return new Hat({
+            inches: cloneValue(this.inches),
+            name: cloneValue(this.name),
+            color: cloneValue(this.color),
+            fabric: cloneValue(this.fabric),
+            stock: cloneValue(this.stock),
+            soft: cloneValue(this.soft),
+            tag: cloneValue(this.tag),
+            ratio: cloneValue(this.ratio),
+            size: cloneValue(this.size),
+            labels: cloneValue(this.labels),
+            measured: cloneValue(this.measured),
+            brim: cloneValue(this.brim),
+        });
+    }
+
+    // equals reports if the fields of the Hat are deeply equal to those of other.
+    equals(other: Hat): boolean {
+        return valuesEqual(this.inches, other.inches)
+            && valuesEqual(this.name, other.name)
+            && valuesEqual(this.color, other.color)
+            && valuesEqual(this.fabric, other.fabric)
+            && valuesEqual(this.stock, other.stock)
+            && valuesEqual(this.soft, other.soft)
+            && valuesEqual(this.tag, other.tag)
+            && valuesEqual(this.ratio, other.ratio)
+            && valuesEqual(this.size, other.size)
+            && valuesEqual(this.labels, other.labels)
+            && valuesEqual(this.measured, other.measured)
+            && valuesEqual(this.brim, other.brim);
+    }
+
+    static fromJSON(m: HatJSON): Hat {
+        return JSONToHat(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Hat is stringified as its proto3 JSON.
+    toJSON(): HatJSON {
+        return HatToJSON(this);
+    }
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    inches: {number: 1, name: "inches", type: "int32"},
+    name: {number: 2, name: "name", type: "string"},
+    color: {number: 3, name: "color", type: "string"},
+    fabric: {number: 4, name: "fabric", type: "enum"},
+    stock: {number: 5, name: "stock", type: "int64"},
+    soft: {number: 6, name: "soft", type: "bool"},
+    tag: {number: 7, name: "tag", type: "bytes"},
+    ratio: {number: 8, name: "ratio", type: "double"},
+    size: {number: 9, name: "size", type: "uint32"},
+    labels: {number: 10, name: "labels", type: "string"},
+    measured: {number: 11, name: "measured", type: "message"},
+    brimWidth: {number: 12, name: "brim_width", type: "int32"},
+    brimStyle: {number: 13, name: "brim_style", type: "string"},
+} as const;
+
+export interface HatJSON {
+    inches: number;
+    name?: string;
+    color: string;
+    fabric: string | number;
+    stock: string;
+    soft: boolean;
+    tag: string;
+    ratio: number | string;
+    size?: number;
+    labels: string[];
+    measured: SizeJSON;
+    brim_width?: number;
+    brim_style?: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        inches: m.inches,
+        name: m.name,
+        color: m.color,
+        fabric: Fabric[m.fabric],
+        stock: m.stock,
+        soft: m.soft,
+        tag: bytesToBase64(m.tag),
+        ratio: floatToJSON(m.ratio),
+        size: m.size,
+        labels: m.labels,
+        measured: SizeToJSON(m.measured),
+        brim_width: m.brim && m.brim.kind === "brimWidth" ? m.brim.value : undefined,
+        brim_style: m.brim && m.brim.kind === "brimStyle" ? m.brim.value : undefined,
+    };
+};
+
+export const JSONToHat = (json: HatJSON): Hat => {
+    const m = jsonAliases(json, {"brimWidth": "brim_width", "brimStyle": "brim_style"});
+
+    return new Hat({
+        inches: m.inches,
+        name: m.name,
+        color: m.color === undefined ? "black" : m.color,
+        fabric: m.fabric === undefined ? Fabric.FABRIC_FELT : enumFromJSON<Fabric>(Fabric, m.fabric),
+        stock: m.stock === undefined ? "12" : m.stock || "0",
+        soft: m.soft === undefined ? true : m.soft,
+        tag: m.tag === undefined ? new Uint8Array([104, 97, 116, 1]) : base64ToBytes(m.tag || ""),
+        ratio: m.ratio === undefined ? Infinity : floatFromJSON(m.ratio),
+        size: m.size,
+        labels: m.labels,
+        measured: JSONToSize(m.measured),
+        brim: m.brim_width !== undefined ? {kind: "brimWidth", value: m.brim_width} : m.brim_style !== undefined ? {kind: "brimStyle", value: m.brim_style} : undefined,
+    });
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (!(value instanceof Hat)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.inches === "number"
+        && (m.name === undefined || typeof m.name === "string")
+        && typeof m.color === "string"
+        && typeof m.fabric === "number"
+        && typeof m.stock === "string"
+        && typeof m.soft === "boolean"
+        && m.tag instanceof Uint8Array
+        && typeof m.ratio === "number"
+        && (m.size === undefined || typeof m.size === "number")
+        && everyItem(m.labels, (v) => typeof v === "string")
+        && isSize(m.measured)
+        && (m.brim === undefined || oneofMember(m.brim, {brimWidth: (v) => typeof v === "number", brimStyle: (v) => typeof v === "string"}));
+};
+
+export class Size {
+    inches: number;
+    scale: number;
+
+    constructor(init: Partial<Size> = {}) {
+        this.inches = init.inches !== undefined ? init.inches : 0;
+        this.scale = init.scale !== undefined ? init.scale : 1.5;
+    }
+
+    // clone returns a deep copy of the Size.
+    clone(): Size {
+        return new Size({
+            inches: cloneValue(this.inches),
+            scale: cloneValue(this.scale),
+        });
+    }
+
+    // equals reports if the fields of the Size are deeply equal to those of other.
+    equals(other: Size): boolean {
+        return valuesEqual(this.inches, other.inches)
+            && valuesEqual(this.scale, other.scale);
+    }
+
+    static fromJSON(m: SizeJSON): Size {
+        return JSONToSize(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Size is stringified as its proto3 JSON.
+    toJSON(): SizeJSON {
+        return SizeToJSON(this);
+    }
+}
+
+// SizeFields are the numbers and proto types of the fields of Size by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SizeFields = {
+    inches: {number: 1, name: "inches", type: "int32"},
+    scale: {number: 2, name: "scale", type: "float"},
+} as const;
+
+export interface SizeJSON {
+    inches: number;
+    scale: number | string;
+}
+
+export const SizeToJSON = (m: Size): SizeJSON => {
+    return {
+        inches: m.inches,
+        scale: floatToJSON(m.scale),
+    };
+};
+
+export const JSONToSize = (m: SizeJSON): Size => {
+    return new Size({
+        inches: m.inches,
+        scale: m.scale === undefined ? 1.5 : floatFromJSON(m.scale),
+    });
+};
+
+// isSize reports if a value has the fields of a Size, e.g. to check data read from a cache or a websocket.
+export const isSize = (value: unknown): value is Size => {
+    if (!(value instanceof Size)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.inches === "number"
+        && typeof m.scale === "number";
+};
+
+export interface Haberdasher {
+    makeHat: (size: Size, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    makeHat: {
+        service: "proto2.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/proto2.Haberdasher/MakeHat",
+        inputType: "Size",
+        outputType: "Hat",
+    },
+} as const;
+
+export class DefaultHaberdasher implements Haberdasher {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/proto2.Haberdasher/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "proto2.Haberdasher",
+                method: "MakeHat",
+                url: url,
+                request: size,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, SizeToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(parseLosslessJSON(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export const createHaberdasherClient = (config: TwirpClientConfig): DefaultHaberdasher => {
+    return new DefaultHaberdasher(config);
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    makeHat?: Hat | ((size: Size, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeHat(size: Size, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(size, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
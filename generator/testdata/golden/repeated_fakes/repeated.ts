@@ -0,0 +1,77 @@
+import {Any, Duration, everyItem, everyValue, isDurationObject} from './twirp';
+
+/**
+ * Schedule has repeated and map fields of messages, whose fakes are the bodies of the arrow functions of fakeArray
+ * and fakeMap.
+ */
+export interface Schedule {
+    slots: Slot[];
+    intervals: Duration[];
+    settings: {[key: string]: any}[];
+    attachments: Any[];
+    namedSlots: {[key: string]: Slot};
+    timeouts: {[key: string]: Duration};
+}
+
+// ScheduleFields are the numbers and proto types of the fields of Schedule by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ScheduleFields = {
+    slots: {number: 1, name: "slots", type: "message"},
+    intervals: {number: 2, name: "intervals", type: "message"},
+    settings: {number: 3, name: "settings", type: "message"},
+    attachments: {number: 4, name: "attachments", type: "message"},
+    namedSlots: {number: 5, name: "named_slots", type: "map"},
+    timeouts: {number: 6, name: "timeouts", type: "map"},
+} as const;
+
+export interface ScheduleJSON {
+    slots: SlotJSON[];
+    intervals: string[];
+    settings: {[key: string]: any}[];
+    attachments: Any[];
+    named_slots: {[key: string]: SlotJSON};
+    timeouts: {[key: string]: string};
+}
+
+// isSchedule reports if a value has the fields of a Schedule, e.g. to check data read from a cache or a websocket.
+export const isSchedule = (value: unknown): value is Schedule => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.slots, isSlot)
+        && everyItem(m.intervals, (v) => isDurationObject(v))
+        && everyItem(m.settings, (v) => typeof v === "object" && v !== null)
+        && everyItem(m.attachments, (v) => typeof v === "object" && v !== null)
+        && everyValue(m.namedSlots, isSlot)
+        && everyValue(m.timeouts, (v) => isDurationObject(v));
+};
+
+export interface Slot {
+    name: string;
+    length: Duration;
+}
+
+// SlotFields are the numbers and proto types of the fields of Slot by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SlotFields = {
+    name: {number: 1, name: "name", type: "string"},
+    length: {number: 2, name: "length", type: "message"},
+} as const;
+
+export interface SlotJSON {
+    name: string;
+    length: string;
+}
+
+// isSlot reports if a value has the fields of a Slot, e.g. to check data read from a cache or a websocket.
+export const isSlot = (value: unknown): value is Slot => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && isDurationObject(m.length);
+};
@@ -0,0 +1,14 @@
+import {fakeArray, fakeInt, fakeMap, fakeString, withOverrides} from './twirp_fakes';
+import {Schedule, Slot} from './repeated';
+
+// fakeSchedule is a fake Schedule message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeSchedule = (overrides: Partial<Schedule> = {}): Schedule => {
+    return withOverrides<Schedule>({slots: fakeArray(() => fakeSlot()), intervals: fakeArray(() => ({seconds: fakeInt(0, 3600), nanos: 0})), settings: fakeArray(() => ({settings: fakeString("settings")})), attachments: fakeArray(() => ({"@type": "type.googleapis.com/google.protobuf.Empty"})), namedSlots: fakeMap(() => fakeString("key"), () => fakeSlot()), timeouts: fakeMap(() => fakeString("key"), () => ({seconds: fakeInt(0, 3600), nanos: 0}))}, overrides);
+};
+
+// fakeSlot is a fake Slot message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeSlot = (overrides: Partial<Slot> = {}): Slot => {
+    return withOverrides<Slot>({name: fakeString("name"), length: ({seconds: fakeInt(0, 3600), nanos: 0})}, overrides);
+};
@@ -0,0 +1,161 @@
+import {CallOptions, TransportRequest, TransportResponse, TwirpError, TwirpErrorCode, TwirpHeaders, throwTwirpError} from './twirp';
+
+// HttpRule is the google.api.http binding of an rpc method, e.g. {method: "GET", path: "/v1/{name=shelves/*}"}
+export interface HttpRule {
+    method: string;
+    path: string;
+    // body is the field of the request that is sent as the body, or * for all of the fields that are not in the path.
+    // The fields that are neither in the path nor the body are sent as query parameters.
+    body?: string;
+    // responseBody is the field of the response that is read from the body, rather than the whole response
+    responseBody?: string;
+}
+
+// RESTRequest is the method, path, and body of a request mapped by an HttpRule, see restRequest.
+export interface RESTRequest {
+    method: string;
+    path: string;
+    body?: string;
+}
+
+const getField = (m: any, path: string): any => {
+    return path.split(".").reduce((v, name) => (v === undefined || v === null ? undefined : v[name]), m);
+};
+
+const deleteField = (m: any, path: string): void => {
+    const names = path.split(".");
+    const parent = getField(m, names.slice(0, -1).join("."));
+
+    if (names.length === 1) {
+        delete m[names[0]];
+    } else if (parent !== undefined && parent !== null) {
+        delete parent[names[names.length - 1]];
+    }
+};
+
+// queryParams adds the query parameters of a value, whose nested fields are joined with a dot and whose
+// repeated fields are repeated parameters, e.g. filter.tags=a&filter.tags=b
+const queryParams = (params: string[], name: string, v: any): void => {
+    if (v === undefined || v === null) {
+        return;
+    }
+
+    if (Array.isArray(v)) {
+        v.forEach((item) => queryParams(params, name, item));
+    } else if (typeof v === "object") {
+        Object.keys(v).forEach((k) => queryParams(params, name ? name + "." + k : k, v[k]));
+    } else {
+        params.push(encodeURIComponent(name) + "=" + encodeURIComponent(String(v)));
+    }
+};
+
+// pathVariable matches the variables of a path template, e.g. {name} or {name=shelves/*}
+const pathVariable = /\{([^}=]+)(=[^}]*)?\}/g;
+
+// restRequest maps the JSON of a request to a REST request. The fields of the path variables are removed from
+// the JSON, and the fields of a variable with a pattern may have slashes, e.g. shelves/1 for {name=shelves/*}.
+export const restRequest = (rule: HttpRule, message: object): RESTRequest => {
+    // the JSON of a request is copied, since the fields of the path and body are removed from it
+    const m = JSON.parse(JSON.stringify(message));
+
+    const path = rule.path.replace(pathVariable, (_, field: string, pattern?: string) => {
+        const v = getField(m, field);
+        const value = v === undefined || v === null ? "" : String(v);
+        deleteField(m, field);
+
+        return pattern ? value.split("/").map(encodeURIComponent).join("/") : encodeURIComponent(value);
+    });
+
+    if (rule.body === "*") {
+        return {method: rule.method, path: path, body: JSON.stringify(m)};
+    }
+
+    let body: string | undefined;
+    if (rule.body) {
+        body = JSON.stringify(getField(m, rule.body) || {});
+        deleteField(m, rule.body);
+    }
+
+    const params: string[] = [];
+    queryParams(params, "", m);
+
+    return {method: rule.method, path: params.length > 0 ? path + "?" + params.join("&") : path, body: body};
+};
+
+export const createRESTRequest = (url: string, req: RESTRequest, options: CallOptions = {}): TransportRequest => {
+    const headers: TwirpHeaders = {};
+    Object.keys(options.headers || {}).forEach((k) => { headers[k] = (options.headers || {})[k]; });
+
+    if (req.body !== undefined) {
+        headers["Content-Type"] = "application/json";
+    }
+
+    return {
+        method: req.method,
+        url: url,
+        headers: headers,
+        body: req.body === undefined ? "" : req.body,
+        signal: options.signal,
+    };
+};
+
+// restResponse is the JSON of a response, which is the field of the responseBody of the rule when it is set. The body
+// is parsed by parse, e.g. parseLosslessJSON for responses with 64 bit fields.
+export const restResponse = (rule: HttpRule, body: string, parse: (body: string) => any = JSON.parse): any => {
+    const m = parse(body);
+
+    if (rule.responseBody) {
+        const wrapped: {[key: string]: any} = {};
+        wrapped[rule.responseBody] = m;
+
+        return wrapped;
+    }
+
+    return m;
+};
+
+// grpcCodes are the Twirp error codes of the gRPC status codes, which are the codes of the errors of a REST gateway.
+const grpcCodes = [
+    TwirpErrorCode.Unknown,
+    TwirpErrorCode.Canceled,
+    TwirpErrorCode.Unknown,
+    TwirpErrorCode.InvalidArgument,
+    TwirpErrorCode.DeadlineExceeded,
+    TwirpErrorCode.NotFound,
+    TwirpErrorCode.AlreadyExists,
+    TwirpErrorCode.PermissionDenied,
+    TwirpErrorCode.ResourceExhausted,
+    TwirpErrorCode.FailedPrecondition,
+    TwirpErrorCode.Aborted,
+    TwirpErrorCode.OutOfRange,
+    TwirpErrorCode.Unimplemented,
+    TwirpErrorCode.Internal,
+    TwirpErrorCode.Unavailable,
+    TwirpErrorCode.DataLoss,
+    TwirpErrorCode.Unauthenticated,
+];
+
+// throwRESTError rejects with the TwirpError of an error response of a REST route. The errors of a REST gateway
+// have the gRPC status code, e.g. {"code": 5, "message": "book not found"}, while other errors are read as Twirp errors.
+export const throwRESTError = (resp: TransportResponse): Promise<never> => {
+    return resp.text().then((body: string) => {
+        let status: {code?: any; message?: string} | undefined;
+
+        try {
+            status = JSON.parse(body);
+        } catch (e) {
+            status = undefined;
+        }
+
+        if (!status || typeof status.code !== "number" || !grpcCodes[status.code]) {
+            return throwTwirpError({
+                ok: resp.ok,
+                status: resp.status,
+                text: () => Promise.resolve(body),
+                arrayBuffer: () => resp.arrayBuffer(),
+            });
+        }
+
+        throw new TwirpError({code: grpcCodes[status.code], msg: status.message || ""});
+    });
+};
@@ -0,0 +1,391 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+export interface Book {
+    name: string;
+    title: string;
+    authors: string[];
+    pages: number;
+}
+
+// BookFields are the numbers and proto types of the fields of Book by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const BookFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+    readonly title: {readonly number: 2; readonly name: "title"; readonly type: "string"};
+    readonly authors: {readonly number: 3; readonly name: "authors"; readonly type: "string"};
+    readonly pages: {readonly number: 4; readonly name: "pages"; readonly type: "int64"};
+};
+
+export interface BookJSON {
+    name: string;
+    title: string;
+    authors: string[];
+    pages: string;
+}
+
+export declare const BookToJSON: (m: Book) => BookJSON;
+
+export declare const JSONToBook: (m: BookJSON) => Book;
+
+// isBook reports if a value has the fields of a Book, e.g. to check data read from a cache or a websocket.
+export declare const isBook: (value: unknown) => value is Book;
+
+export interface GetBookRequest {
+    /** name is the resource name of the book, e.g. shelves/1/books/2 */
+    name: string;
+}
+
+// GetBookRequestFields are the numbers and proto types of the fields of GetBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const GetBookRequestFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+};
+
+export interface GetBookRequestJSON {
+    name: string;
+}
+
+export declare const GetBookRequestToJSON: (m: GetBookRequest) => GetBookRequestJSON;
+
+// isGetBookRequest reports if a value has the fields of a GetBookRequest, e.g. to check data read from a cache or a websocket.
+export declare const isGetBookRequest: (value: unknown) => value is GetBookRequest;
+
+export interface ListBooksRequest {
+    shelf: string;
+    pageSize: number;
+    filter: ListBooksRequestFilter;
+}
+
+// ListBooksRequestFields are the numbers and proto types of the fields of ListBooksRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListBooksRequestFields: {
+    readonly shelf: {readonly number: 1; readonly name: "shelf"; readonly type: "string"};
+    readonly pageSize: {readonly number: 2; readonly name: "page_size"; readonly type: "int32"};
+    readonly filter: {readonly number: 3; readonly name: "filter"; readonly type: "message"};
+};
+
+export interface ListBooksRequestJSON {
+    shelf: string;
+    page_size: number;
+    filter: ListBooksRequestFilterJSON;
+}
+
+export declare const ListBooksRequestToJSON: (m: ListBooksRequest) => ListBooksRequestJSON;
+
+// isListBooksRequest reports if a value has the fields of a ListBooksRequest, e.g. to check data read from a cache or a websocket.
+export declare const isListBooksRequest: (value: unknown) => value is ListBooksRequest;
+
+export interface ListBooksRequestFilter {
+    author: string;
+    tags: string[];
+}
+
+// ListBooksRequestFilterFields are the numbers and proto types of the fields of ListBooksRequestFilter by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListBooksRequestFilterFields: {
+    readonly author: {readonly number: 1; readonly name: "author"; readonly type: "string"};
+    readonly tags: {readonly number: 2; readonly name: "tags"; readonly type: "string"};
+};
+
+export interface ListBooksRequestFilterJSON {
+    author: string;
+    tags: string[];
+}
+
+export declare const ListBooksRequestFilterToJSON: (m: ListBooksRequestFilter) => ListBooksRequestFilterJSON;
+
+// isListBooksRequestFilter reports if a value has the fields of a ListBooksRequestFilter, e.g. to check data read from a cache or a websocket.
+export declare const isListBooksRequestFilter: (value: unknown) => value is ListBooksRequestFilter;
+
+export interface ListBooksResponse {
+    books: Book[];
+}
+
+// ListBooksResponseFields are the numbers and proto types of the fields of ListBooksResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ListBooksResponseFields: {
+    readonly books: {readonly number: 1; readonly name: "books"; readonly type: "message"};
+};
+
+export interface ListBooksResponseJSON {
+    books: BookJSON[];
+}
+
+export declare const JSONToListBooksResponse: (m: ListBooksResponseJSON) => ListBooksResponse;
+
+// isListBooksResponse reports if a value has the fields of a ListBooksResponse, e.g. to check data read from a cache or a websocket.
+export declare const isListBooksResponse: (value: unknown) => value is ListBooksResponse;
+
+export interface CreateBookRequest {
+    shelf: string;
+    book: Book;
+}
+
+// CreateBookRequestFields are the numbers and proto types of the fields of CreateBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const CreateBookRequestFields: {
+    readonly shelf: {readonly number: 1; readonly name: "shelf"; readonly type: "string"};
+    readonly book: {readonly number: 2; readonly name: "book"; readonly type: "message"};
+};
+
+export interface CreateBookRequestJSON {
+    shelf: string;
+    book: BookJSON;
+}
+
+export declare const CreateBookRequestToJSON: (m: CreateBookRequest) => CreateBookRequestJSON;
+
+// isCreateBookRequest reports if a value has the fields of a CreateBookRequest, e.g. to check data read from a cache or a websocket.
+export declare const isCreateBookRequest: (value: unknown) => value is CreateBookRequest;
+
+export interface UpdateBookRequest {
+    book: Book;
+    validateOnly: boolean;
+}
+
+// UpdateBookRequestFields are the numbers and proto types of the fields of UpdateBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const UpdateBookRequestFields: {
+    readonly book: {readonly number: 1; readonly name: "book"; readonly type: "message"};
+    readonly validateOnly: {readonly number: 2; readonly name: "validate_only"; readonly type: "bool"};
+};
+
+export interface UpdateBookRequestJSON {
+    book: BookJSON;
+    validate_only: boolean;
+}
+
+export declare const UpdateBookRequestToJSON: (m: UpdateBookRequest) => UpdateBookRequestJSON;
+
+// isUpdateBookRequest reports if a value has the fields of a UpdateBookRequest, e.g. to check data read from a cache or a websocket.
+export declare const isUpdateBookRequest: (value: unknown) => value is UpdateBookRequest;
+
+export interface DeleteBookRequest {
+    name: string;
+}
+
+// DeleteBookRequestFields are the numbers and proto types of the fields of DeleteBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const DeleteBookRequestFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+};
+
+export interface DeleteBookRequestJSON {
+    name: string;
+}
+
+export declare const DeleteBookRequestToJSON: (m: DeleteBookRequest) => DeleteBookRequestJSON;
+
+// isDeleteBookRequest reports if a value has the fields of a DeleteBookRequest, e.g. to check data read from a cache or a websocket.
+export declare const isDeleteBookRequest: (value: unknown) => value is DeleteBookRequest;
+
+export interface Empty {}
+
+// EmptyFields are the numbers and proto types of the fields of Empty by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const EmptyFields: {};
+
+export interface EmptyJSON {}
+
+export declare const JSONToEmpty: (m: EmptyJSON) => Empty;
+
+// isEmpty reports if a value has the fields of a Empty, e.g. to check data read from a cache or a websocket.
+export declare const isEmpty: (value: unknown) => value is Empty;
+
+export interface ArchiveBooksRequest {
+    shelf: string;
+}
+
+// ArchiveBooksRequestFields are the numbers and proto types of the fields of ArchiveBooksRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const ArchiveBooksRequestFields: {
+    readonly shelf: {readonly number: 1; readonly name: "shelf"; readonly type: "string"};
+};
+
+export interface ArchiveBooksRequestJSON {
+    shelf: string;
+}
+
+export declare const ArchiveBooksRequestToJSON: (m: ArchiveBooksRequest) => ArchiveBooksRequestJSON;
+
+// isArchiveBooksRequest reports if a value has the fields of a ArchiveBooksRequest, e.g. to check data read from a cache or a websocket.
+export declare const isArchiveBooksRequest: (value: unknown) => value is ArchiveBooksRequest;
+
+export interface Library {
+    getBook: (getBookRequest: GetBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    listBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<ListBooksResponse>;
+
+    createBook: (createBookRequest: CreateBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    updateBook: (updateBookRequest: UpdateBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    deleteBook: (deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions) => Promise<Empty>;
+
+    /** ListAuthors returns the books of the shelf, whose authors are the body of the response. */
+    listAuthors: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    archiveBooks: (archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions) => Promise<Empty>;
+
+    /** CountBooks has no HTTP binding, so it is only called with Twirp. */
+    countBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<Empty>;
+}
+
+// LibraryMethods are the Twirp routes of the methods of Library, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const LibraryMethods: {
+    readonly getBook: {
+        readonly service: "rest.Library";
+        readonly method: "GetBook";
+        readonly path: "/twirp/rest.Library/GetBook";
+        readonly inputType: "GetBookRequest";
+        readonly outputType: "Book";
+    };
+    readonly listBooks: {
+        readonly service: "rest.Library";
+        readonly method: "ListBooks";
+        readonly path: "/twirp/rest.Library/ListBooks";
+        readonly inputType: "ListBooksRequest";
+        readonly outputType: "ListBooksResponse";
+    };
+    readonly createBook: {
+        readonly service: "rest.Library";
+        readonly method: "CreateBook";
+        readonly path: "/twirp/rest.Library/CreateBook";
+        readonly inputType: "CreateBookRequest";
+        readonly outputType: "Book";
+    };
+    readonly updateBook: {
+        readonly service: "rest.Library";
+        readonly method: "UpdateBook";
+        readonly path: "/twirp/rest.Library/UpdateBook";
+        readonly inputType: "UpdateBookRequest";
+        readonly outputType: "Book";
+    };
+    readonly deleteBook: {
+        readonly service: "rest.Library";
+        readonly method: "DeleteBook";
+        readonly path: "/twirp/rest.Library/DeleteBook";
+        readonly inputType: "DeleteBookRequest";
+        readonly outputType: "Empty";
+    };
+    readonly listAuthors: {
+        readonly service: "rest.Library";
+        readonly method: "ListAuthors";
+        readonly path: "/twirp/rest.Library/ListAuthors";
+        readonly inputType: "ListBooksRequest";
+        readonly outputType: "Book";
+    };
+    readonly archiveBooks: {
+        readonly service: "rest.Library";
+        readonly method: "ArchiveBooks";
+        readonly path: "/twirp/rest.Library/ArchiveBooks";
+        readonly inputType: "ArchiveBooksRequest";
+        readonly outputType: "Empty";
+    };
+    readonly countBooks: {
+        readonly service: "rest.Library";
+        readonly method: "CountBooks";
+        readonly path: "/twirp/rest.Library/CountBooks";
+        readonly inputType: "ListBooksRequest";
+        readonly outputType: "Empty";
+    };
+};
+
+export declare class DefaultLibrary implements Library {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    getBook(getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book>;
+
+    // getBookRest calls Library.GetBook with its REST route, GET /v1/{name=shelves/*/books/*}
+    getBookRest(getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book>;
+    listBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse>;
+
+    // listBooksRest calls Library.ListBooks with its REST route, GET /v1/shelves/{shelf}/books
+    listBooksRest(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse>;
+    createBook(createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book>;
+
+    // createBookRest calls Library.CreateBook with its REST route, POST /v1/shelves/{shelf}/books
+    createBookRest(createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book>;
+    updateBook(updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book>;
+
+    // updateBookRest calls Library.UpdateBook with its REST route, PATCH /v1/{book.name=shelves/*/books/*}
+    updateBookRest(updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book>;
+    deleteBook(deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty>;
+
+    // deleteBookRest calls Library.DeleteBook with its REST route, DELETE /v1/{name=shelves/*/books/*}
+    deleteBookRest(deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty>;
+    /** ListAuthors returns the books of the shelf, whose authors are the body of the response. */
+    listAuthors(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book>;
+
+    // listAuthorsRest calls Library.ListAuthors with its REST route, GET /v1/shelves/{shelf}/authors
+    listAuthorsRest(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book>;
+    archiveBooks(archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty>;
+
+    // archiveBooksRest calls Library.ArchiveBooks with its REST route, ARCHIVE /v1/shelves/{shelf}:archive
+    archiveBooksRest(archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty>;
+    /** CountBooks has no HTTP binding, so it is only called with Twirp. */
+    countBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Empty>;
+}
+
+// createLibraryClient creates a DefaultLibrary with the config, which may be shared by the clients of other services.
+export declare const createLibraryClient: (config: TwirpClientConfig) => DefaultLibrary;
+
+// A LibraryMockResponses sets the response of each LibraryMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface LibraryMockResponses {
+    getBook?: Book | ((getBookRequest: GetBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    listBooks?: ListBooksResponse | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => ListBooksResponse | Promise<ListBooksResponse>);
+    createBook?: Book | ((createBookRequest: CreateBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    updateBook?: Book | ((updateBookRequest: UpdateBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    deleteBook?: Empty | ((deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+    listAuthors?: Book | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    archiveBooks?: Empty | ((archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+    countBooks?: Empty | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+}
+
+// LibraryMockClient is a Library for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class LibraryMockClient implements Library {
+    responses: LibraryMockResponses;
+
+    constructor(responses?: LibraryMockResponses);
+
+    getBook(getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book>;
+    listBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse>;
+    createBook(createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book>;
+    updateBook(updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book>;
+    deleteBook(deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty>;
+    listAuthors(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book>;
+    archiveBooks(archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty>;
+    countBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Empty>;
+}
+
+export declare const createLibraryMock: (overrides?: LibraryMockResponses) => LibraryMockClient;
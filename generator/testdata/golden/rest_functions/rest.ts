@@ -0,0 +1,937 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, CallOptions, everyItem} from './twirp';
+import {InterceptorContext, TwirpClient, runInterceptors} from './interceptors';
+import {restRequest, createRESTRequest, restResponse, throwRESTError} from './twirp_rest';
+
+export interface Book {
+    name: string;
+    title: string;
+    authors: string[];
+    pages: number;
+}
+
+// BookFields are the numbers and proto types of the fields of Book by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const BookFields = {
+    name: {number: 1, name: "name", type: "string"},
+    title: {number: 2, name: "title", type: "string"},
+    authors: {number: 3, name: "authors", type: "string"},
+    pages: {number: 4, name: "pages", type: "int64"},
+} as const;
+
+export interface BookJSON {
+    name: string;
+    title: string;
+    authors: string[];
+    pages: string;
+}
+
+export const BookToJSON = (m: Book): BookJSON => {
+    return {
+        name: m.name,
+        title: m.title,
+        authors: m.authors,
+        pages: String(m.pages),
+    };
+};
+
+export const JSONToBook = (m: BookJSON): Book => {
+    return {
+        name: m.name,
+        title: m.title,
+        authors: m.authors,
+        pages: Number(m.pages || "0"),
+    };
+};
+
+// isBook reports if a value has the fields of a Book, e.g. to check data read from a cache or a websocket.
+export const isBook = (value: unknown): value is Book => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && typeof m.title === "string"
+        && everyItem(m.authors, (v) => typeof v === "string")
+        && typeof m.pages === "number";
+};
+
+export interface GetBookRequest {
+    /** name is the resource name of the book, e.g. shelves/1/books/2 */
+    name: string;
+}
+
+// GetBookRequestFields are the numbers and proto types of the fields of GetBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const GetBookRequestFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface GetBookRequestJSON {
+    name: string;
+}
+
+export const GetBookRequestToJSON = (m: GetBookRequest): GetBookRequestJSON => {
+    return {
+        name: m.name,
+    };
+};
+
+// isGetBookRequest reports if a value has the fields of a GetBookRequest, e.g. to check data read from a cache or a websocket.
+export const isGetBookRequest = (value: unknown): value is GetBookRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
+
+export interface ListBooksRequest {
+    shelf: string;
+    pageSize: number;
+    filter: ListBooksRequestFilter;
+}
+
+// ListBooksRequestFields are the numbers and proto types of the fields of ListBooksRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListBooksRequestFields = {
+    shelf: {number: 1, name: "shelf", type: "string"},
+    pageSize: {number: 2, name: "page_size", type: "int32"},
+    filter: {number: 3, name: "filter", type: "message"},
+} as const;
+
+export interface ListBooksRequestJSON {
+    shelf: string;
+    page_size: number;
+    filter: ListBooksRequestFilterJSON;
+}
+
+export const ListBooksRequestToJSON = (m: ListBooksRequest): ListBooksRequestJSON => {
+    return {
+        shelf: m.shelf,
+        page_size: m.pageSize,
+        filter: ListBooksRequestFilterToJSON(m.filter),
+    };
+};
+
+// isListBooksRequest reports if a value has the fields of a ListBooksRequest, e.g. to check data read from a cache or a websocket.
+export const isListBooksRequest = (value: unknown): value is ListBooksRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.shelf === "string"
+        && typeof m.pageSize === "number"
+        && isListBooksRequestFilter(m.filter);
+};
+
+export interface ListBooksRequestFilter {
+    author: string;
+    tags: string[];
+}
+
+// ListBooksRequestFilterFields are the numbers and proto types of the fields of ListBooksRequestFilter by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListBooksRequestFilterFields = {
+    author: {number: 1, name: "author", type: "string"},
+    tags: {number: 2, name: "tags", type: "string"},
+} as const;
+
+export interface ListBooksRequestFilterJSON {
+    author: string;
+    tags: string[];
+}
+
+export const ListBooksRequestFilterToJSON = (m: ListBooksRequestFilter): ListBooksRequestFilterJSON => {
+    return {
+        author: m.author,
+        tags: m.tags,
+    };
+};
+
+// isListBooksRequestFilter reports if a value has the fields of a ListBooksRequestFilter, e.g. to check data read from a cache or a websocket.
+export const isListBooksRequestFilter = (value: unknown): value is ListBooksRequestFilter => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.author === "string"
+        && everyItem(m.tags, (v) => typeof v === "string");
+};
+
+export interface ListBooksResponse {
+    books: Book[];
+}
+
+// ListBooksResponseFields are the numbers and proto types of the fields of ListBooksResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ListBooksResponseFields = {
+    books: {number: 1, name: "books", type: "message"},
+} as const;
+
+export interface ListBooksResponseJSON {
+    books: BookJSON[];
+}
+
+export const JSONToListBooksResponse = (m: ListBooksResponseJSON): ListBooksResponse => {
+    return {
+        books: m.books.map(JSONToBook),
+    };
+};
+
+// isListBooksResponse reports if a value has the fields of a ListBooksResponse, e.g. to check data read from a cache or a websocket.
+export const isListBooksResponse = (value: unknown): value is ListBooksResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return everyItem(m.books, isBook);
+};
+
+export interface CreateBookRequest {
+    shelf: string;
+    book: Book;
+}
+
+// CreateBookRequestFields are the numbers and proto types of the fields of CreateBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const CreateBookRequestFields = {
+    shelf: {number: 1, name: "shelf", type: "string"},
+    book: {number: 2, name: "book", type: "message"},
+} as const;
+
+export interface CreateBookRequestJSON {
+    shelf: string;
+    book: BookJSON;
+}
+
+export const CreateBookRequestToJSON = (m: CreateBookRequest): CreateBookRequestJSON => {
+    return {
+        shelf: m.shelf,
+        book: BookToJSON(m.book),
+    };
+};
+
+// isCreateBookRequest reports if a value has the fields of a CreateBookRequest, e.g. to check data read from a cache or a websocket.
+export const isCreateBookRequest = (value: unknown): value is CreateBookRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.shelf === "string"
+        && isBook(m.book);
+};
+
+export interface UpdateBookRequest {
+    book: Book;
+    validateOnly: boolean;
+}
+
+// UpdateBookRequestFields are the numbers and proto types of the fields of UpdateBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const UpdateBookRequestFields = {
+    book: {number: 1, name: "book", type: "message"},
+    validateOnly: {number: 2, name: "validate_only", type: "bool"},
+} as const;
+
+export interface UpdateBookRequestJSON {
+    book: BookJSON;
+    validate_only: boolean;
+}
+
+export const UpdateBookRequestToJSON = (m: UpdateBookRequest): UpdateBookRequestJSON => {
+    return {
+        book: BookToJSON(m.book),
+        validate_only: m.validateOnly,
+    };
+};
+
+// isUpdateBookRequest reports if a value has the fields of a UpdateBookRequest, e.g. to check data read from a cache or a websocket.
+export const isUpdateBookRequest = (value: unknown): value is UpdateBookRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isBook(m.book)
+        && typeof m.validateOnly === "boolean";
+};
+
+export interface DeleteBookRequest {
+    name: string;
+}
+
+// DeleteBookRequestFields are the numbers and proto types of the fields of DeleteBookRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const DeleteBookRequestFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface DeleteBookRequestJSON {
+    name: string;
+}
+
+export const DeleteBookRequestToJSON = (m: DeleteBookRequest): DeleteBookRequestJSON => {
+    return {
+        name: m.name,
+    };
+};
+
+// isDeleteBookRequest reports if a value has the fields of a DeleteBookRequest, e.g. to check data read from a cache or a websocket.
+export const isDeleteBookRequest = (value: unknown): value is DeleteBookRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
+
+export interface Empty {}
+
+// EmptyFields are the numbers and proto types of the fields of Empty by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const EmptyFields = {} as const;
+
+export interface EmptyJSON {}
+
+export const JSONToEmpty = (m: EmptyJSON): Empty => {
+    return {};
+};
+
+// isEmpty reports if a value has the fields of a Empty, e.g. to check data read from a cache or a websocket.
+export const isEmpty = (value: unknown): value is Empty => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    return true;
+};
+
+export interface ArchiveBooksRequest {
+    shelf: string;
+}
+
+// ArchiveBooksRequestFields are the numbers and proto types of the fields of ArchiveBooksRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const ArchiveBooksRequestFields = {
+    shelf: {number: 1, name: "shelf", type: "string"},
+} as const;
+
+export interface ArchiveBooksRequestJSON {
+    shelf: string;
+}
+
+export const ArchiveBooksRequestToJSON = (m: ArchiveBooksRequest): ArchiveBooksRequestJSON => {
+    return {
+        shelf: m.shelf,
+    };
+};
+
+// isArchiveBooksRequest reports if a value has the fields of a ArchiveBooksRequest, e.g. to check data read from a cache or a websocket.
+export const isArchiveBooksRequest = (value: unknown): value is ArchiveBooksRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.shelf === "string";
+};
+
+export interface Library {
+    getBook: (getBookRequest: GetBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    listBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<ListBooksResponse>;
+
+    createBook: (createBookRequest: CreateBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    updateBook: (updateBookRequest: UpdateBookRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    deleteBook: (deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions) => Promise<Empty>;
+
+    /** ListAuthors returns the books of the shelf, whose authors are the body of the response. */
+    listAuthors: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<Book>;
+
+    archiveBooks: (archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions) => Promise<Empty>;
+
+    /** CountBooks has no HTTP binding, so it is only called with Twirp. */
+    countBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Promise<Empty>;
+}
+
+// LibraryMethods are the Twirp routes of the methods of Library, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const LibraryMethods = {
+    getBook: {
+        service: "rest.Library",
+        method: "GetBook",
+        path: "/twirp/rest.Library/GetBook",
+        inputType: "GetBookRequest",
+        outputType: "Book",
+    },
+    listBooks: {
+        service: "rest.Library",
+        method: "ListBooks",
+        path: "/twirp/rest.Library/ListBooks",
+        inputType: "ListBooksRequest",
+        outputType: "ListBooksResponse",
+    },
+    createBook: {
+        service: "rest.Library",
+        method: "CreateBook",
+        path: "/twirp/rest.Library/CreateBook",
+        inputType: "CreateBookRequest",
+        outputType: "Book",
+    },
+    updateBook: {
+        service: "rest.Library",
+        method: "UpdateBook",
+        path: "/twirp/rest.Library/UpdateBook",
+        inputType: "UpdateBookRequest",
+        outputType: "Book",
+    },
+    deleteBook: {
+        service: "rest.Library",
+        method: "DeleteBook",
+        path: "/twirp/rest.Library/DeleteBook",
+        inputType: "DeleteBookRequest",
+        outputType: "Empty",
+    },
+    listAuthors: {
+        service: "rest.Library",
+        method: "ListAuthors",
+        path: "/twirp/rest.Library/ListAuthors",
+        inputType: "ListBooksRequest",
+        outputType: "Book",
+    },
+    archiveBooks: {
+        service: "rest.Library",
+        method: "ArchiveBooks",
+        path: "/twirp/rest.Library/ArchiveBooks",
+        inputType: "ArchiveBooksRequest",
+        outputType: "Empty",
+    },
+    countBooks: {
+        service: "rest.Library",
+        method: "CountBooks",
+        path: "/twirp/rest.Library/CountBooks",
+        inputType: "ListBooksRequest",
+        outputType: "Empty",
+    },
+} as const;
+
+export const getBook = (client: TwirpClient, getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/GetBook");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "GetBook",
+            url: url,
+            request: getBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, GetBookRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// getBookRest calls Library.GetBook with its REST route, GET /v1/{name=shelves/*/books/*}
+export const getBookRest = (client: TwirpClient, getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const rule = {method: "GET", path: "/v1/{name=shelves/*/books/*}"};
+    const rest = restRequest(rule, GetBookRequestToJSON(getBookRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "GetBook",
+            url: url,
+            request: getBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const listBooks = (client: TwirpClient, listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/ListBooks");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ListBooks",
+            url: url,
+            request: listBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListBooksRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToListBooksResponse(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// listBooksRest calls Library.ListBooks with its REST route, GET /v1/shelves/{shelf}/books
+export const listBooksRest = (client: TwirpClient, listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse> => {
+    const rule = {method: "GET", path: "/v1/shelves/{shelf}/books"};
+    const rest = restRequest(rule, ListBooksRequestToJSON(listBooksRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ListBooks",
+            url: url,
+            request: listBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToListBooksResponse(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const createBook = (client: TwirpClient, createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/CreateBook");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "CreateBook",
+            url: url,
+            request: createBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, CreateBookRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createBookRest calls Library.CreateBook with its REST route, POST /v1/shelves/{shelf}/books
+export const createBookRest = (client: TwirpClient, createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const rule = {method: "POST", path: "/v1/shelves/{shelf}/books", body: "book"};
+    const rest = restRequest(rule, CreateBookRequestToJSON(createBookRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "CreateBook",
+            url: url,
+            request: createBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const updateBook = (client: TwirpClient, updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/UpdateBook");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "UpdateBook",
+            url: url,
+            request: updateBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, UpdateBookRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// updateBookRest calls Library.UpdateBook with its REST route, PATCH /v1/{book.name=shelves/*/books/*}
+export const updateBookRest = (client: TwirpClient, updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book> => {
+    const rule = {method: "PATCH", path: "/v1/{book.name=shelves/*/books/*}", body: "*"};
+    const rest = restRequest(rule, UpdateBookRequestToJSON(updateBookRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "UpdateBook",
+            url: url,
+            request: updateBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const deleteBook = (client: TwirpClient, deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/DeleteBook");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "DeleteBook",
+            url: url,
+            request: deleteBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, DeleteBookRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToEmpty(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// deleteBookRest calls Library.DeleteBook with its REST route, DELETE /v1/{name=shelves/*/books/*}
+export const deleteBookRest = (client: TwirpClient, deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty> => {
+    const rule = {method: "DELETE", path: "/v1/{name=shelves/*/books/*}"};
+    const rest = restRequest(rule, DeleteBookRequestToJSON(deleteBookRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "DeleteBook",
+            url: url,
+            request: deleteBookRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToEmpty(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+/** ListAuthors returns the books of the shelf, whose authors are the body of the response. */
+export const listAuthors = (client: TwirpClient, listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/ListAuthors");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ListAuthors",
+            url: url,
+            request: listBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListBooksRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// listAuthorsRest calls Library.ListAuthors with its REST route, GET /v1/shelves/{shelf}/authors
+export const listAuthorsRest = (client: TwirpClient, listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book> => {
+    const rule = {method: "GET", path: "/v1/shelves/{shelf}/authors", responseBody: "authors"};
+    const rest = restRequest(rule, ListBooksRequestToJSON(listBooksRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ListAuthors",
+            url: url,
+            request: listBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToBook(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+export const archiveBooks = (client: TwirpClient, archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/ArchiveBooks");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ArchiveBooks",
+            url: url,
+            request: archiveBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ArchiveBooksRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToEmpty(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// archiveBooksRest calls Library.ArchiveBooks with its REST route, ARCHIVE /v1/shelves/{shelf}:archive
+export const archiveBooksRest = (client: TwirpClient, archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty> => {
+    const rule = {method: "ARCHIVE", path: "/v1/shelves/{shelf}:archive"};
+    const rest = restRequest(rule, ArchiveBooksRequestToJSON(archiveBooksRequest));
+    const url = joinURL(client.hostname, rest.path);
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "ArchiveBooks",
+            url: url,
+            request: archiveBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createRESTRequest(ctx.url, rest, ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwRESTError(resp);
+                }
+
+                return resp.text().then((body) => JSONToEmpty(restResponse(rule, body)));
+            });
+        });
+    }));
+};
+
+/** CountBooks has no HTTP binding, so it is only called with Twirp. */
+export const countBooks = (client: TwirpClient, listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Empty> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/rest.Library/CountBooks");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "rest.Library",
+            method: "CountBooks",
+            url: url,
+            request: listBooksRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpRequest(ctx.url, ListBooksRequestToJSON(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.text().then((body) => JSONToEmpty(JSON.parse(body)));
+            });
+        });
+    }));
+};
+
+// createLibraryClient creates a Library of the rpc functions of Library, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createLibraryClient = (client: TwirpClient): Library => {
+    return {
+        getBook: (getBookRequest: GetBookRequest, callOptions?: CallOptions) => getBook(client, getBookRequest, callOptions),
+        listBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => listBooks(client, listBooksRequest, callOptions),
+        createBook: (createBookRequest: CreateBookRequest, callOptions?: CallOptions) => createBook(client, createBookRequest, callOptions),
+        updateBook: (updateBookRequest: UpdateBookRequest, callOptions?: CallOptions) => updateBook(client, updateBookRequest, callOptions),
+        deleteBook: (deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions) => deleteBook(client, deleteBookRequest, callOptions),
+        listAuthors: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => listAuthors(client, listBooksRequest, callOptions),
+        archiveBooks: (archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions) => archiveBooks(client, archiveBooksRequest, callOptions),
+        countBooks: (listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => countBooks(client, listBooksRequest, callOptions),
+    };
+};
+
+// A LibraryMockResponses sets the response of each LibraryMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface LibraryMockResponses {
+    getBook?: Book | ((getBookRequest: GetBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    listBooks?: ListBooksResponse | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => ListBooksResponse | Promise<ListBooksResponse>);
+    createBook?: Book | ((createBookRequest: CreateBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    updateBook?: Book | ((updateBookRequest: UpdateBookRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    deleteBook?: Empty | ((deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+    listAuthors?: Book | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Book | Promise<Book>);
+    archiveBooks?: Empty | ((archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+    countBooks?: Empty | ((listBooksRequest: ListBooksRequest, callOptions?: CallOptions) => Empty | Promise<Empty>);
+}
+
+// LibraryMockClient is a Library for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class LibraryMockClient implements Library {
+    responses: LibraryMockResponses;
+
+    constructor(responses: LibraryMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    getBook(getBookRequest: GetBookRequest, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.getBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.GetBook"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(getBookRequest, callOptions) : response));
+    }
+
+    listBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<ListBooksResponse> {
+        const response = this.responses.listBooks;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.ListBooks"}));
+        }
+
+        return new Promise<ListBooksResponse>((resolve) => resolve(typeof response === "function" ? response(listBooksRequest, callOptions) : response));
+    }
+
+    createBook(createBookRequest: CreateBookRequest, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.createBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.CreateBook"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(createBookRequest, callOptions) : response));
+    }
+
+    updateBook(updateBookRequest: UpdateBookRequest, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.updateBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.UpdateBook"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(updateBookRequest, callOptions) : response));
+    }
+
+    deleteBook(deleteBookRequest: DeleteBookRequest, callOptions?: CallOptions): Promise<Empty> {
+        const response = this.responses.deleteBook;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.DeleteBook"}));
+        }
+
+        return new Promise<Empty>((resolve) => resolve(typeof response === "function" ? response(deleteBookRequest, callOptions) : response));
+    }
+
+    listAuthors(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Book> {
+        const response = this.responses.listAuthors;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.ListAuthors"}));
+        }
+
+        return new Promise<Book>((resolve) => resolve(typeof response === "function" ? response(listBooksRequest, callOptions) : response));
+    }
+
+    archiveBooks(archiveBooksRequest: ArchiveBooksRequest, callOptions?: CallOptions): Promise<Empty> {
+        const response = this.responses.archiveBooks;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.ArchiveBooks"}));
+        }
+
+        return new Promise<Empty>((resolve) => resolve(typeof response === "function" ? response(archiveBooksRequest, callOptions) : response));
+    }
+
+    countBooks(listBooksRequest: ListBooksRequest, callOptions?: CallOptions): Promise<Empty> {
+        const response = this.responses.countBooks;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Library.CountBooks"}));
+        }
+
+        return new Promise<Empty>((resolve) => resolve(typeof response === "function" ? response(listBooksRequest, callOptions) : response));
+    }
+}
+
+export const createLibraryMock = (overrides: LibraryMockResponses = {}): LibraryMockClient => {
+    return new LibraryMockClient(overrides);
+};
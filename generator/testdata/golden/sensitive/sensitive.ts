@@ -0,0 +1,469 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, mapEntries, jsonAliases, everyItem, everyValue, oneofMember, redactFields, redacted, redactList, redactMap, redactOneof} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+export interface Card {
+    number: string;
+    expiryMonth: number;
+    expiryYear: number;
+}
+
+// CardFields are the numbers and proto types of the fields of Card by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const CardFields = {
+    number: {number: 1, name: "number", type: "string"},
+    expiryMonth: {number: 2, name: "expiry_month", type: "int32"},
+    expiryYear: {number: 3, name: "expiry_year", type: "int32"},
+} as const;
+
+export interface CardJSON {
+    number: string;
+    expiry_month: number;
+    expiry_year: number;
+}
+
+export const CardToJSON = (m: Card): CardJSON => {
+    return {
+        number: m.number,
+        expiry_month: m.expiryMonth,
+        expiry_year: m.expiryYear,
+    };
+};
+
+export const JSONToCard = (json: CardJSON): Card => {
+    const m = jsonAliases(json, {"expiryMonth": "expiry_month", "expiryYear": "expiry_year"});
+
+    return {
+        number: m.number,
+        expiryMonth: m.expiry_month,
+        expiryYear: m.expiry_year,
+    };
+};
+
+// redactCard copies a Card for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactCard = (m: Card): any => {
+    return redactFields(m, {number: redacted});
+};
+
+// isCard reports if a value has the fields of a Card, e.g. to check data read from a cache or a websocket.
+export const isCard = (value: unknown): value is Card => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.number === "string"
+        && typeof m.expiryMonth === "number"
+        && typeof m.expiryYear === "number";
+};
+
+export interface Credentials {
+    username: string;
+    password: string;
+}
+
+// CredentialsFields are the numbers and proto types of the fields of Credentials by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const CredentialsFields = {
+    username: {number: 1, name: "username", type: "string"},
+    password: {number: 2, name: "password", type: "string"},
+} as const;
+
+export interface CredentialsJSON {
+    username: string;
+    password: string;
+}
+
+export const CredentialsToJSON = (m: Credentials): CredentialsJSON => {
+    return {
+        username: m.username,
+        password: m.password,
+    };
+};
+
+// redactCredentials copies a Credentials for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactCredentials = (m: Credentials): any => {
+    return redactFields(m, {password: redacted});
+};
+
+// isCredentials reports if a value has the fields of a Credentials, e.g. to check data read from a cache or a websocket.
+export const isCredentials = (value: unknown): value is Credentials => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.username === "string"
+        && typeof m.password === "string";
+};
+
+export type LoginRequestSecondFactor =
+    | {kind: "otp"; value: string}
+    | {kind: "deviceId"; value: string};
+
+export interface LoginRequest {
+    credentials: Credentials;
+    secondFactor?: LoginRequestSecondFactor;
+}
+
+// LoginRequestFields are the numbers and proto types of the fields of LoginRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const LoginRequestFields = {
+    credentials: {number: 1, name: "credentials", type: "message"},
+    otp: {number: 2, name: "otp", type: "string"},
+    deviceId: {number: 3, name: "device_id", type: "string"},
+} as const;
+
+export interface LoginRequestJSON {
+    credentials: CredentialsJSON;
+    otp?: string;
+    device_id?: string;
+}
+
+export const LoginRequestToJSON = (m: LoginRequest): LoginRequestJSON => {
+    return {
+        credentials: CredentialsToJSON(m.credentials),
+        otp: m.secondFactor && m.secondFactor.kind === "otp" ? m.secondFactor.value : undefined,
+        device_id: m.secondFactor && m.secondFactor.kind === "deviceId" ? m.secondFactor.value : undefined,
+    };
+};
+
+// redactLoginRequest copies a LoginRequest for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactLoginRequest = (m: LoginRequest): any => {
+    return redactFields(m, {credentials: redactCredentials, secondFactor: redactOneof({otp: redacted})});
+};
+
+// isLoginRequest reports if a value has the fields of a LoginRequest, e.g. to check data read from a cache or a websocket.
+export const isLoginRequest = (value: unknown): value is LoginRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isCredentials(m.credentials)
+        && (m.secondFactor === undefined || oneofMember(m.secondFactor, {otp: (v) => typeof v === "string", deviceId: (v) => typeof v === "string"}));
+};
+
+export interface LoginResponse {
+    token: string;
+    displayName: string;
+    cards: Card[];
+    cardsByName: {[key: string]: Card};
+    labels: {[key: string]: string};
+    recoveryCodes: string[];
+}
+
+// LoginResponseFields are the numbers and proto types of the fields of LoginResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const LoginResponseFields = {
+    token: {number: 1, name: "token", type: "string"},
+    displayName: {number: 2, name: "display_name", type: "string"},
+    cards: {number: 3, name: "cards", type: "message"},
+    cardsByName: {number: 4, name: "cards_by_name", type: "map"},
+    labels: {number: 5, name: "labels", type: "map"},
+    recoveryCodes: {number: 6, name: "recovery_codes", type: "string"},
+} as const;
+
+export interface LoginResponseJSON {
+    token: string;
+    display_name: string;
+    cards: CardJSON[];
+    cards_by_name: {[key: string]: CardJSON};
+    labels: {[key: string]: string};
+    recovery_codes: string[];
+}
+
+export const JSONToLoginResponse = (json: LoginResponseJSON): LoginResponse => {
+    const m = jsonAliases(json, {"displayName": "display_name", "cardsByName": "cards_by_name", "recoveryCodes": "recovery_codes"});
+
+    return {
+        token: m.token,
+        displayName: m.display_name,
+        cards: m.cards.map(JSONToCard),
+        cardsByName: mapEntries(m.cards_by_name || {}, String, (v) => JSONToCard(v)),
+        labels: m.labels || {},
+        recoveryCodes: m.recovery_codes,
+    };
+};
+
+// redactLoginResponse copies a LoginResponse for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactLoginResponse = (m: LoginResponse): any => {
+    return redactFields(m, {token: redacted, cards: redactList(redactCard), cardsByName: redactMap(redactCard), recoveryCodes: redacted});
+};
+
+// isLoginResponse reports if a value has the fields of a LoginResponse, e.g. to check data read from a cache or a websocket.
+export const isLoginResponse = (value: unknown): value is LoginResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.token === "string"
+        && typeof m.displayName === "string"
+        && everyItem(m.cards, isCard)
+        && everyValue(m.cardsByName, isCard)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyItem(m.recoveryCodes, (v) => typeof v === "string");
+};
+
+/** A Hat has no sensitive fields, so it has no redact function. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** Auth logs in users. */
+export interface Auth {
+    login: (loginRequest: LoginRequest, callOptions?: CallOptions) => Promise<LoginResponse>;
+
+    addCard: (card: Card, callOptions?: CallOptions) => Promise<Hat>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// AuthMethods are the Twirp routes of the methods of Auth, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AuthMethods = {
+    login: {
+        service: "sensitive.Auth",
+        method: "Login",
+        path: "/twirp/sensitive.Auth/Login",
+        inputType: "LoginRequest",
+        outputType: "LoginResponse",
+    },
+    addCard: {
+        service: "sensitive.Auth",
+        method: "AddCard",
+        path: "/twirp/sensitive.Auth/AddCard",
+        inputType: "Card",
+        outputType: "Hat",
+    },
+    makeHat: {
+        service: "sensitive.Auth",
+        method: "MakeHat",
+        path: "/twirp/sensitive.Auth/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Auth logs in users. */
+export class DefaultAuth implements Auth {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/sensitive.Auth/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    login(loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Login");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "sensitive.Auth",
+                method: "Login",
+                url: url,
+                request: loginRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+                redactor: {request: redactLoginRequest, response: redactLoginResponse},
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, LoginRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToLoginResponse(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    addCard(card: Card, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "AddCard");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "sensitive.Auth",
+                method: "AddCard",
+                url: url,
+                request: card,
+                headers: options.headers || {},
+                signal: options.signal,
+                redactor: {request: redactCard},
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, CardToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "sensitive.Auth",
+                method: "MakeHat",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createAuthClient creates a DefaultAuth with the config, which may be shared by the clients of other services.
+export const createAuthClient = (config: TwirpClientConfig): DefaultAuth => {
+    return new DefaultAuth(config);
+};
+
+// A AuthMockResponses sets the response of each AuthMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AuthMockResponses {
+    login?: LoginResponse | ((loginRequest: LoginRequest, callOptions?: CallOptions) => LoginResponse | Promise<LoginResponse>);
+    addCard?: Hat | ((card: Card, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// AuthMockClient is a Auth for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AuthMockClient implements Auth {
+    responses: AuthMockResponses;
+
+    constructor(responses: AuthMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    login(loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse> {
+        const response = this.responses.login;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.Login"}));
+        }
+
+        return new Promise<LoginResponse>((resolve) => resolve(typeof response === "function" ? response(loginRequest, callOptions) : response));
+    }
+
+    addCard(card: Card, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.addCard;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.AddCard"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(card, callOptions) : response));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createAuthMock = (overrides: AuthMockResponses = {}): AuthMockClient => {
+    return new AuthMockClient(overrides);
+};
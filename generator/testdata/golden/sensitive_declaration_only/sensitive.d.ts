@@ -0,0 +1,245 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+export interface Card {
+    number: string;
+    expiryMonth: number;
+    expiryYear: number;
+}
+
+// CardFields are the numbers and proto types of the fields of Card by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const CardFields: {
+    readonly number: {readonly number: 1; readonly name: "number"; readonly type: "string"};
+    readonly expiryMonth: {readonly number: 2; readonly name: "expiry_month"; readonly type: "int32"};
+    readonly expiryYear: {readonly number: 3; readonly name: "expiry_year"; readonly type: "int32"};
+};
+
+export interface CardJSON {
+    number: string;
+    expiry_month: number;
+    expiry_year: number;
+}
+
+export declare const CardToJSON: (m: Card) => CardJSON;
+
+export declare const JSONToCard: (m: CardJSON) => Card;
+
+// redactCard copies a Card for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export declare const redactCard: (m: Card) => any;
+
+// isCard reports if a value has the fields of a Card, e.g. to check data read from a cache or a websocket.
+export declare const isCard: (value: unknown) => value is Card;
+
+export interface Credentials {
+    username: string;
+    password: string;
+}
+
+// CredentialsFields are the numbers and proto types of the fields of Credentials by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const CredentialsFields: {
+    readonly username: {readonly number: 1; readonly name: "username"; readonly type: "string"};
+    readonly password: {readonly number: 2; readonly name: "password"; readonly type: "string"};
+};
+
+export interface CredentialsJSON {
+    username: string;
+    password: string;
+}
+
+export declare const CredentialsToJSON: (m: Credentials) => CredentialsJSON;
+
+// redactCredentials copies a Credentials for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export declare const redactCredentials: (m: Credentials) => any;
+
+// isCredentials reports if a value has the fields of a Credentials, e.g. to check data read from a cache or a websocket.
+export declare const isCredentials: (value: unknown) => value is Credentials;
+
+export type LoginRequestSecondFactor =
+    | {kind: "otp"; value: string}
+    | {kind: "deviceId"; value: string};
+
+export interface LoginRequest {
+    credentials: Credentials;
+    secondFactor?: LoginRequestSecondFactor;
+}
+
+// LoginRequestFields are the numbers and proto types of the fields of LoginRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const LoginRequestFields: {
+    readonly credentials: {readonly number: 1; readonly name: "credentials"; readonly type: "message"};
+    readonly otp: {readonly number: 2; readonly name: "otp"; readonly type: "string"};
+    readonly deviceId: {readonly number: 3; readonly name: "device_id"; readonly type: "string"};
+};
+
+export interface LoginRequestJSON {
+    credentials: CredentialsJSON;
+    otp?: string;
+    device_id?: string;
+}
+
+export declare const LoginRequestToJSON: (m: LoginRequest) => LoginRequestJSON;
+
+// redactLoginRequest copies a LoginRequest for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export declare const redactLoginRequest: (m: LoginRequest) => any;
+
+// isLoginRequest reports if a value has the fields of a LoginRequest, e.g. to check data read from a cache or a websocket.
+export declare const isLoginRequest: (value: unknown) => value is LoginRequest;
+
+export interface LoginResponse {
+    token: string;
+    displayName: string;
+    cards: Card[];
+    cardsByName: {[key: string]: Card};
+    labels: {[key: string]: string};
+    recoveryCodes: string[];
+}
+
+// LoginResponseFields are the numbers and proto types of the fields of LoginResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const LoginResponseFields: {
+    readonly token: {readonly number: 1; readonly name: "token"; readonly type: "string"};
+    readonly displayName: {readonly number: 2; readonly name: "display_name"; readonly type: "string"};
+    readonly cards: {readonly number: 3; readonly name: "cards"; readonly type: "message"};
+    readonly cardsByName: {readonly number: 4; readonly name: "cards_by_name"; readonly type: "map"};
+    readonly labels: {readonly number: 5; readonly name: "labels"; readonly type: "map"};
+    readonly recoveryCodes: {readonly number: 6; readonly name: "recovery_codes"; readonly type: "string"};
+};
+
+export interface LoginResponseJSON {
+    token: string;
+    display_name: string;
+    cards: CardJSON[];
+    cards_by_name: {[key: string]: CardJSON};
+    labels: {[key: string]: string};
+    recovery_codes: string[];
+}
+
+export declare const JSONToLoginResponse: (m: LoginResponseJSON) => LoginResponse;
+
+// redactLoginResponse copies a LoginResponse for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export declare const redactLoginResponse: (m: LoginResponse) => any;
+
+// isLoginResponse reports if a value has the fields of a LoginResponse, e.g. to check data read from a cache or a websocket.
+export declare const isLoginResponse: (value: unknown) => value is LoginResponse;
+
+/** A Hat has no sensitive fields, so it has no redact function. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const HatFields: {
+    readonly size: {readonly number: 1; readonly name: "size"; readonly type: "int32"};
+    readonly color: {readonly number: 2; readonly name: "color"; readonly type: "string"};
+};
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export declare const HatToJSON: (m: Hat) => HatJSON;
+
+export declare const JSONToHat: (m: HatJSON) => Hat;
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export declare const isHat: (value: unknown) => value is Hat;
+
+/** Auth logs in users. */
+export interface Auth {
+    login: (loginRequest: LoginRequest, callOptions?: CallOptions) => Promise<LoginResponse>;
+
+    addCard: (card: Card, callOptions?: CallOptions) => Promise<Hat>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// AuthMethods are the Twirp routes of the methods of Auth, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const AuthMethods: {
+    readonly login: {
+        readonly service: "sensitive.Auth";
+        readonly method: "Login";
+        readonly path: "/twirp/sensitive.Auth/Login";
+        readonly inputType: "LoginRequest";
+        readonly outputType: "LoginResponse";
+    };
+    readonly addCard: {
+        readonly service: "sensitive.Auth";
+        readonly method: "AddCard";
+        readonly path: "/twirp/sensitive.Auth/AddCard";
+        readonly inputType: "Card";
+        readonly outputType: "Hat";
+    };
+    readonly makeHat: {
+        readonly service: "sensitive.Auth";
+        readonly method: "MakeHat";
+        readonly path: "/twirp/sensitive.Auth/MakeHat";
+        readonly inputType: "Hat";
+        readonly outputType: "Hat";
+    };
+};
+
+/** Auth logs in users. */
+export declare class DefaultAuth implements Auth {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    login(loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse>;
+    addCard(card: Card, callOptions?: CallOptions): Promise<Hat>;
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat>;
+}
+
+// createAuthClient creates a DefaultAuth with the config, which may be shared by the clients of other services.
+export declare const createAuthClient: (config: TwirpClientConfig) => DefaultAuth;
+
+// A AuthMockResponses sets the response of each AuthMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AuthMockResponses {
+    login?: LoginResponse | ((loginRequest: LoginRequest, callOptions?: CallOptions) => LoginResponse | Promise<LoginResponse>);
+    addCard?: Hat | ((card: Card, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// AuthMockClient is a Auth for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class AuthMockClient implements Auth {
+    responses: AuthMockResponses;
+
+    constructor(responses?: AuthMockResponses);
+
+    login(loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse>;
+    addCard(card: Card, callOptions?: CallOptions): Promise<Hat>;
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat>;
+}
+
+export declare const createAuthMock: (overrides?: AuthMockResponses) => AuthMockClient;
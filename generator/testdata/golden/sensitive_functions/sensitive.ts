@@ -0,0 +1,450 @@
+import {createTwirpProtobufRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, CallOptions, ProtobufReader, ProtobufWriter, everyItem, everyValue, oneofMember, redactFields, redacted, redactList, redactMap, redactOneof} from './twirp';
+import {InterceptorContext, TwirpClient, runInterceptors} from './interceptors';
+
+export interface Card {
+    number: string;
+    expiry_month: number;
+    expiry_year: number;
+}
+
+// CardFields are the numbers and proto types of the fields of Card by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const CardFields = {
+    number: {number: 1, name: "number", type: "string"},
+    expiry_month: {number: 2, name: "expiry_month", type: "int32"},
+    expiry_year: {number: 3, name: "expiry_year", type: "int32"},
+} as const;
+
+export interface CardJSON {
+    number: string;
+    expiry_month: number;
+    expiry_year: number;
+}
+
+export const CardToProtobuf = (m: Card): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.number) { w.tag(1, 2).string(m.number); }
+    if (m.expiry_month) { w.tag(2, 0).int32(m.expiry_month); }
+    if (m.expiry_year) { w.tag(3, 0).int32(m.expiry_year); }
+
+    return w.finish();
+};
+
+export const ProtobufToCard = (b: Uint8Array): Card => {
+    const r = new ProtobufReader(b);
+    const m = {number: "", expiry_month: 0, expiry_year: 0} as Card;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.number = r.string(); break;
+            case 2: m.expiry_month = r.int32(); break;
+            case 3: m.expiry_year = r.int32(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// redactCard copies a Card for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactCard = (m: Card): any => {
+    return redactFields(m, {number: redacted});
+};
+
+// isCard reports if a value has the fields of a Card, e.g. to check data read from a cache or a websocket.
+export const isCard = (value: unknown): value is Card => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.number === "string"
+        && typeof m.expiry_month === "number"
+        && typeof m.expiry_year === "number";
+};
+
+export interface Credentials {
+    username: string;
+    password: string;
+}
+
+// CredentialsFields are the numbers and proto types of the fields of Credentials by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const CredentialsFields = {
+    username: {number: 1, name: "username", type: "string"},
+    password: {number: 2, name: "password", type: "string"},
+} as const;
+
+export interface CredentialsJSON {
+    username: string;
+    password: string;
+}
+
+export const CredentialsToProtobuf = (m: Credentials): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.username) { w.tag(1, 2).string(m.username); }
+    if (m.password) { w.tag(2, 2).string(m.password); }
+
+    return w.finish();
+};
+
+// redactCredentials copies a Credentials for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactCredentials = (m: Credentials): any => {
+    return redactFields(m, {password: redacted});
+};
+
+// isCredentials reports if a value has the fields of a Credentials, e.g. to check data read from a cache or a websocket.
+export const isCredentials = (value: unknown): value is Credentials => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.username === "string"
+        && typeof m.password === "string";
+};
+
+export type LoginRequestSecondFactor =
+    | {kind: "otp"; value: string}
+    | {kind: "device_id"; value: string};
+
+export interface LoginRequest {
+    credentials: Credentials;
+    second_factor?: LoginRequestSecondFactor;
+}
+
+// LoginRequestFields are the numbers and proto types of the fields of LoginRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const LoginRequestFields = {
+    credentials: {number: 1, name: "credentials", type: "message"},
+    otp: {number: 2, name: "otp", type: "string"},
+    device_id: {number: 3, name: "device_id", type: "string"},
+} as const;
+
+export interface LoginRequestJSON {
+    credentials: CredentialsJSON;
+    otp?: string;
+    device_id?: string;
+}
+
+export const LoginRequestToProtobuf = (m: LoginRequest): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.credentials) { w.tag(1, 2).bytes(CredentialsToProtobuf(m.credentials)); }
+    if (m.second_factor && m.second_factor.kind === "otp") { w.tag(2, 2).string(m.second_factor.value); }
+    if (m.second_factor && m.second_factor.kind === "device_id") { w.tag(3, 2).string(m.second_factor.value); }
+
+    return w.finish();
+};
+
+// redactLoginRequest copies a LoginRequest for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactLoginRequest = (m: LoginRequest): any => {
+    return redactFields(m, {credentials: redactCredentials, second_factor: redactOneof({otp: redacted})});
+};
+
+// isLoginRequest reports if a value has the fields of a LoginRequest, e.g. to check data read from a cache or a websocket.
+export const isLoginRequest = (value: unknown): value is LoginRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isCredentials(m.credentials)
+        && (m.second_factor === undefined || oneofMember(m.second_factor, {otp: (v) => typeof v === "string", device_id: (v) => typeof v === "string"}));
+};
+
+export interface LoginResponse {
+    token: string;
+    display_name: string;
+    cards: Card[];
+    cards_by_name: {[key: string]: Card};
+    labels: {[key: string]: string};
+    recovery_codes: string[];
+}
+
+// LoginResponseFields are the numbers and proto types of the fields of LoginResponse by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const LoginResponseFields = {
+    token: {number: 1, name: "token", type: "string"},
+    display_name: {number: 2, name: "display_name", type: "string"},
+    cards: {number: 3, name: "cards", type: "message"},
+    cards_by_name: {number: 4, name: "cards_by_name", type: "map"},
+    labels: {number: 5, name: "labels", type: "map"},
+    recovery_codes: {number: 6, name: "recovery_codes", type: "string"},
+} as const;
+
+export interface LoginResponseJSON {
+    token: string;
+    display_name: string;
+    cards: CardJSON[];
+    cards_by_name: {[key: string]: CardJSON};
+    labels: {[key: string]: string};
+    recovery_codes: string[];
+}
+
+export const ProtobufToLoginResponse = (b: Uint8Array): LoginResponse => {
+    const r = new ProtobufReader(b);
+    const m = {token: "", display_name: "", cards: [], cards_by_name: {}, labels: {}, recovery_codes: []} as LoginResponse;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.token = r.string(); break;
+            case 2: m.display_name = r.string(); break;
+            case 3: m.cards.push(ProtobufToCard(r.bytes())); break;
+            case 4: r.entry("", ProtobufToCard(new Uint8Array(0)), (r) => r.string(), (r) => ProtobufToCard(r.bytes()), (k, v) => m.cards_by_name[k] = v); break;
+            case 5: r.entry("", "", (r) => r.string(), (r) => r.string(), (k, v) => m.labels[k] = v); break;
+            case 6: m.recovery_codes.push(r.string()); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// redactLoginResponse copies a LoginResponse for logs and error reports, whose sensitive fields are replaced by [REDACTED].
+export const redactLoginResponse = (m: LoginResponse): any => {
+    return redactFields(m, {token: redacted, cards: redactList(redactCard), cards_by_name: redactMap(redactCard), recovery_codes: redacted});
+};
+
+// isLoginResponse reports if a value has the fields of a LoginResponse, e.g. to check data read from a cache or a websocket.
+export const isLoginResponse = (value: unknown): value is LoginResponse => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.token === "string"
+        && typeof m.display_name === "string"
+        && everyItem(m.cards, isCard)
+        && everyValue(m.cards_by_name, isCard)
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && everyItem(m.recovery_codes, (v) => typeof v === "string");
+};
+
+/** A Hat has no sensitive fields, so it has no redact function. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToProtobuf = (m: Hat): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.size) { w.tag(1, 0).int32(m.size); }
+    if (m.color) { w.tag(2, 2).string(m.color); }
+
+    return w.finish();
+};
+
+export const ProtobufToHat = (b: Uint8Array): Hat => {
+    const r = new ProtobufReader(b);
+    const m = {size: 0, color: ""} as Hat;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.size = r.int32(); break;
+            case 2: m.color = r.string(); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** Auth logs in users. */
+export interface Auth {
+    login: (loginRequest: LoginRequest, callOptions?: CallOptions) => Promise<LoginResponse>;
+
+    addCard: (card: Card, callOptions?: CallOptions) => Promise<Hat>;
+
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// AuthMethods are the Twirp routes of the methods of Auth, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AuthMethods = {
+    login: {
+        service: "sensitive.Auth",
+        method: "Login",
+        path: "/twirp/sensitive.Auth/Login",
+        inputType: "LoginRequest",
+        outputType: "LoginResponse",
+    },
+    addCard: {
+        service: "sensitive.Auth",
+        method: "AddCard",
+        path: "/twirp/sensitive.Auth/AddCard",
+        inputType: "Card",
+        outputType: "Hat",
+    },
+    makeHat: {
+        service: "sensitive.Auth",
+        method: "MakeHat",
+        path: "/twirp/sensitive.Auth/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+export const login = (client: TwirpClient, loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/sensitive.Auth/Login");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "sensitive.Auth",
+            method: "Login",
+            url: url,
+            request: loginRequest,
+            headers: options.headers || {},
+            signal: options.signal,
+            redactor: {request: redactLoginRequest, response: redactLoginResponse},
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpProtobufRequest(ctx.url, LoginRequestToProtobuf(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.arrayBuffer().then((buf) => ProtobufToLoginResponse(new Uint8Array(buf)));
+            });
+        });
+    }));
+};
+
+export const addCard = (client: TwirpClient, card: Card, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/sensitive.Auth/AddCard");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "sensitive.Auth",
+            method: "AddCard",
+            url: url,
+            request: card,
+            headers: options.headers || {},
+            signal: options.signal,
+            redactor: {request: redactCard},
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpProtobufRequest(ctx.url, CardToProtobuf(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.arrayBuffer().then((buf) => ProtobufToHat(new Uint8Array(buf)));
+            });
+        });
+    }));
+};
+
+export const makeHat = (client: TwirpClient, hat: Hat, callOptions?: CallOptions): Promise<Hat> => {
+    const url = joinURL(client.hostname, (client.prefix !== undefined ? client.prefix : "/twirp") + "/sensitive.Auth/MakeHat");
+    return resolveCallOptions(client.headers, callOptions, client.timeoutMs).then((options) => withDeadline(options, (options) => {
+        const ctx: InterceptorContext = {
+            service: "sensitive.Auth",
+            method: "MakeHat",
+            url: url,
+            request: hat,
+            headers: options.headers || {},
+            signal: options.signal,
+        };
+
+        return runInterceptors(client.interceptors, ctx, (ctx) => {
+            return client.transport(createTwirpProtobufRequest(ctx.url, HatToProtobuf(ctx.request), ctx)).then((resp) => {
+                reportResponse(options, resp);
+                if (!resp.ok) {
+                    return throwTwirpError(resp);
+                }
+
+                return resp.arrayBuffer().then((buf) => ProtobufToHat(new Uint8Array(buf)));
+            });
+        });
+    }));
+};
+
+// createAuthClient creates a Auth of the rpc functions of Auth, which are called with the client. A
+// bundle that only imports the rpc functions it calls leaves out the others.
+export const createAuthClient = (client: TwirpClient): Auth => {
+    return {
+        login: (loginRequest: LoginRequest, callOptions?: CallOptions) => login(client, loginRequest, callOptions),
+        addCard: (card: Card, callOptions?: CallOptions) => addCard(client, card, callOptions),
+        makeHat: (hat: Hat, callOptions?: CallOptions) => makeHat(client, hat, callOptions),
+    };
+};
+
+// A AuthMockResponses sets the response of each AuthMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AuthMockResponses {
+    login?: LoginResponse | ((loginRequest: LoginRequest, callOptions?: CallOptions) => LoginResponse | Promise<LoginResponse>);
+    addCard?: Hat | ((card: Card, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// AuthMockClient is a Auth for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AuthMockClient implements Auth {
+    responses: AuthMockResponses;
+
+    constructor(responses: AuthMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    login(loginRequest: LoginRequest, callOptions?: CallOptions): Promise<LoginResponse> {
+        const response = this.responses.login;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.Login"}));
+        }
+
+        return new Promise<LoginResponse>((resolve) => resolve(typeof response === "function" ? response(loginRequest, callOptions) : response));
+    }
+
+    addCard(card: Card, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.addCard;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.AddCard"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(card, callOptions) : response));
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Auth.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createAuthMock = (overrides: AuthMockResponses = {}): AuthMockClient => {
+    return new AuthMockClient(overrides);
+};
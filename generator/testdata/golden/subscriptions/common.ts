@@ -0,0 +1,40 @@
+export enum Status {
+    STATUS_UNKNOWN = 0,
+    STATUS_ACTIVE = 1,
+}
+
+/** Page selects a range of results. */
+export interface Page {
+    offset: number;
+    limit: number;
+}
+
+// PageFields are the numbers and proto types of the fields of Page by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const PageFields = {
+    offset: {number: 1, name: "offset", type: "int32"},
+    limit: {number: 2, name: "limit", type: "int32"},
+} as const;
+
+export interface PageJSON {
+    offset: number;
+    limit: number;
+}
+
+export const PageToJSON = (m: Page): PageJSON => {
+    return {
+        offset: m.offset,
+        limit: m.limit,
+    };
+};
+
+// isPage reports if a value has the fields of a Page, e.g. to check data read from a cache or a websocket.
+export const isPage = (value: unknown): value is Page => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.offset === "number"
+        && typeof m.limit === "number";
+};
@@ -0,0 +1,512 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {Page, PageToJSON} from './common';
+
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** Order is a hat order, whose id is a 64 bit integer. */
+export interface Order {
+    id: number;
+    hat: Hat;
+}
+
+// OrderFields are the numbers and proto types of the fields of Order by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const OrderFields = {
+    id: {number: 1, name: "id", type: "int64"},
+    hat: {number: 2, name: "hat", type: "message"},
+} as const;
+
+export interface OrderJSON {
+    id: string;
+    hat: HatJSON;
+}
+
+export const JSONToOrder = (m: OrderJSON): Order => {
+    return {
+        id: Number(m.id || "0"),
+        hat: JSONToHat(m.hat),
+    };
+};
+
+// isOrder reports if a value has the fields of a Order, e.g. to check data read from a cache or a websocket.
+export const isOrder = (value: unknown): value is Order => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "number"
+        && isHat(m.hat);
+};
+
+export interface WatchHatsRequest {
+    color: string;
+}
+
+// WatchHatsRequestFields are the numbers and proto types of the fields of WatchHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const WatchHatsRequestFields = {
+    color: {number: 1, name: "color", type: "string"},
+} as const;
+
+export interface WatchHatsRequestJSON {
+    color: string;
+}
+
+export const WatchHatsRequestToJSON = (m: WatchHatsRequest): WatchHatsRequestJSON => {
+    return {
+        color: m.color,
+    };
+};
+
+// isWatchHatsRequest reports if a value has the fields of a WatchHatsRequest, e.g. to check data read from a cache or a websocket.
+export const isWatchHatsRequest = (value: unknown): value is WatchHatsRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.color === "string";
+};
+
+/** Haberdasher makes hats, and tells its clients about them. */
+export interface Haberdasher {
+    /** MakeHat is not a subscription method, so it only has a client method. */
+    makeHat: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+
+    /** WatchHats sends the hats of a color as they are made. */
+    watchHats: (watchHatsRequest: WatchHatsRequest, callOptions?: CallOptions) => Promise<Hat>;
+
+    /** SubscribeOrders sends the orders of hats as they are placed. */
+    subscribeOrders: (page: Page, callOptions?: CallOptions) => Promise<Order>;
+
+    /** Watchdog is not a subscription method, since Watch is not followed by a word. */
+    watchdog: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// HaberdasherMethods are the Twirp routes of the methods of Haberdasher, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const HaberdasherMethods = {
+    makeHat: {
+        service: "subscriptions.Haberdasher",
+        method: "MakeHat",
+        path: "/twirp/subscriptions.Haberdasher/MakeHat",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+    watchHats: {
+        service: "subscriptions.Haberdasher",
+        method: "WatchHats",
+        path: "/twirp/subscriptions.Haberdasher/WatchHats",
+        inputType: "WatchHatsRequest",
+        outputType: "Hat",
+    },
+    subscribeOrders: {
+        service: "subscriptions.Haberdasher",
+        method: "SubscribeOrders",
+        path: "/twirp/subscriptions.Haberdasher/SubscribeOrders",
+        inputType: "Page",
+        outputType: "Order",
+    },
+    watchdog: {
+        service: "subscriptions.Haberdasher",
+        method: "Watchdog",
+        path: "/twirp/subscriptions.Haberdasher/Watchdog",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Haberdasher makes hats, and tells its clients about them. */
+export class DefaultHaberdasher implements Haberdasher {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/subscriptions.Haberdasher/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    /** MakeHat is not a subscription method, so it only has a client method. */
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeHat");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Haberdasher",
+                method: "MakeHat",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    /** WatchHats sends the hats of a color as they are made. */
+    watchHats(watchHatsRequest: WatchHatsRequest, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "WatchHats");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Haberdasher",
+                method: "WatchHats",
+                url: url,
+                request: watchHatsRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, WatchHatsRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    /** SubscribeOrders sends the orders of hats as they are placed. */
+    subscribeOrders(page: Page, callOptions?: CallOptions): Promise<Order> {
+        const url = joinURL(this.hostname, this.pathPrefix + "SubscribeOrders");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Haberdasher",
+                method: "SubscribeOrders",
+                url: url,
+                request: page,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, PageToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToOrder(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+
+    /** Watchdog is not a subscription method, since Watch is not followed by a word. */
+    watchdog(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Watchdog");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Haberdasher",
+                method: "Watchdog",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createHaberdasherClient creates a DefaultHaberdasher with the config, which may be shared by the clients of other services.
+export const createHaberdasherClient = (config: TwirpClientConfig): DefaultHaberdasher => {
+    return new DefaultHaberdasher(config);
+};
+
+// A HaberdasherMockResponses sets the response of each HaberdasherMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface HaberdasherMockResponses {
+    makeHat?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    watchHats?: Hat | ((watchHatsRequest: WatchHatsRequest, callOptions?: CallOptions) => Hat | Promise<Hat>);
+    subscribeOrders?: Order | ((page: Page, callOptions?: CallOptions) => Order | Promise<Order>);
+    watchdog?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// HaberdasherMockClient is a Haberdasher for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class HaberdasherMockClient implements Haberdasher {
+    responses: HaberdasherMockResponses;
+
+    constructor(responses: HaberdasherMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeHat(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeHat;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.MakeHat"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+
+    watchHats(watchHatsRequest: WatchHatsRequest, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.watchHats;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.WatchHats"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(watchHatsRequest, callOptions) : response));
+    }
+
+    subscribeOrders(page: Page, callOptions?: CallOptions): Promise<Order> {
+        const response = this.responses.subscribeOrders;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.SubscribeOrders"}));
+        }
+
+        return new Promise<Order>((resolve) => resolve(typeof response === "function" ? response(page, callOptions) : response));
+    }
+
+    watchdog(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.watchdog;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Haberdasher.Watchdog"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createHaberdasherMock = (overrides: HaberdasherMockResponses = {}): HaberdasherMockClient => {
+    return new HaberdasherMockClient(overrides);
+};
+
+/** Tailor has no subscription methods. */
+export interface Tailor {
+    makeSuit: (hat: Hat, callOptions?: CallOptions) => Promise<Hat>;
+}
+
+// TailorMethods are the Twirp routes of the methods of Tailor, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const TailorMethods = {
+    makeSuit: {
+        service: "subscriptions.Tailor",
+        method: "MakeSuit",
+        path: "/twirp/subscriptions.Tailor/MakeSuit",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Tailor has no subscription methods. */
+export class DefaultTailor implements Tailor {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/subscriptions.Tailor/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    makeSuit(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeSuit");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Tailor",
+                method: "MakeSuit",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createTailorClient creates a DefaultTailor with the config, which may be shared by the clients of other services.
+export const createTailorClient = (config: TwirpClientConfig): DefaultTailor => {
+    return new DefaultTailor(config);
+};
+
+// A TailorMockResponses sets the response of each TailorMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface TailorMockResponses {
+    makeSuit?: Hat | ((hat: Hat, callOptions?: CallOptions) => Hat | Promise<Hat>);
+}
+
+// TailorMockClient is a Tailor for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class TailorMockClient implements Tailor {
+    responses: TailorMockResponses;
+
+    constructor(responses: TailorMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeSuit(hat: Hat, callOptions?: CallOptions): Promise<Hat> {
+        const response = this.responses.makeSuit;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Tailor.MakeSuit"}));
+        }
+
+        return new Promise<Hat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createTailorMock = (overrides: TailorMockResponses = {}): TailorMockClient => {
+    return new TailorMockClient(overrides);
+};
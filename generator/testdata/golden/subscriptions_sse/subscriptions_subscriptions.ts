@@ -0,0 +1,26 @@
+import {joinURL, parseLosslessJSON} from './twirp';
+import {Subscription, SubscriptionOptions, subscribe} from './twirp_subscriptions';
+import {Page, PageToJSON} from './common';
+import {Hat, JSONToHat, JSONToOrder, Order, WatchHatsRequest, WatchHatsRequestToJSON} from './subscriptions';
+
+// HaberdasherSubscriptions are the subscriptions to the subscription methods of Haberdasher, which receive the messages
+// of a connection to the route of the method.
+export interface HaberdasherSubscriptions {
+    /** WatchHats sends the hats of a color as they are made. */
+    watchHats: (watchHatsRequest: WatchHatsRequest) => Subscription<Hat>;
+    /** SubscribeOrders sends the orders of hats as they are placed. */
+    subscribeOrders: (page: Page) => Subscription<Order>;
+}
+
+// createHaberdasherSubscriptions creates the subscriptions of Haberdasher, which connect to the routes of the methods
+// of the endpoint, e.g. https://example.com/subscriptions/subscriptions.Haberdasher/WatchHats
+export const createHaberdasherSubscriptions = (endpoint: string, options: SubscriptionOptions = {}): HaberdasherSubscriptions => {
+    return {
+        watchHats: (watchHatsRequest: WatchHatsRequest): Subscription<Hat> => {
+            return subscribe(joinURL(endpoint, "/subscriptions.Haberdasher/WatchHats"), WatchHatsRequestToJSON(watchHatsRequest), (data) => JSONToHat(JSON.parse(data)), options);
+        },
+        subscribeOrders: (page: Page): Subscription<Order> => {
+            return subscribe(joinURL(endpoint, "/subscriptions.Haberdasher/SubscribeOrders"), PageToJSON(page), (data) => JSONToOrder(parseLosslessJSON(data)), options);
+        },
+    };
+};
@@ -0,0 +1,120 @@
+/** A Hat is a piece of headwear made by a Haberdasher. */
+export interface Hat {
+    size: number;
+    color: string;
+}
+
+// ReadonlyHat is the interface of a Hat returned by the clients, whose fields cannot be changed.
+export interface ReadonlyHat {
+    readonly size: number;
+    readonly color: string;
+}
+
+// HatFields are the numbers and proto types of the fields of Hat by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const HatFields = {
+    size: {number: 1, name: "size", type: "int32"},
+    color: {number: 2, name: "color", type: "string"},
+} as const;
+
+export interface HatJSON {
+    size: number;
+    color: string;
+}
+
+export const HatToJSON = (m: Hat): HatJSON => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+export const JSONToHat = (m: HatJSON): Hat => {
+    return {
+        size: m.size,
+        color: m.color,
+    };
+};
+
+// isHat reports if a value has the fields of a Hat, e.g. to check data read from a cache or a websocket.
+export const isHat = (value: unknown): value is Hat => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.size === "number"
+        && typeof m.color === "string";
+};
+
+/** Order is a hat order, whose id is a 64 bit integer. */
+export interface Order {
+    id: number;
+    hat: Hat;
+}
+
+// ReadonlyOrder is the interface of a Order returned by the clients, whose fields cannot be changed.
+export interface ReadonlyOrder {
+    readonly id: number;
+    readonly hat: ReadonlyHat;
+}
+
+// OrderFields are the numbers and proto types of the fields of Order by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const OrderFields = {
+    id: {number: 1, name: "id", type: "int64"},
+    hat: {number: 2, name: "hat", type: "message"},
+} as const;
+
+export interface OrderJSON {
+    id: string;
+    hat: HatJSON;
+}
+
+export const JSONToOrder = (m: OrderJSON): Order => {
+    return {
+        id: Number(m.id || "0"),
+        hat: JSONToHat(m.hat),
+    };
+};
+
+// isOrder reports if a value has the fields of a Order, e.g. to check data read from a cache or a websocket.
+export const isOrder = (value: unknown): value is Order => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.id === "number"
+        && isHat(m.hat);
+};
+
+export interface WatchHatsRequest {
+    color: string;
+}
+
+// WatchHatsRequestFields are the numbers and proto types of the fields of WatchHatsRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const WatchHatsRequestFields = {
+    color: {number: 1, name: "color", type: "string"},
+} as const;
+
+export interface WatchHatsRequestJSON {
+    color: string;
+}
+
+export const WatchHatsRequestToJSON = (m: WatchHatsRequest): WatchHatsRequestJSON => {
+    return {
+        color: m.color,
+    };
+};
+
+// isWatchHatsRequest reports if a value has the fields of a WatchHatsRequest, e.g. to check data read from a cache or a websocket.
+export const isWatchHatsRequest = (value: unknown): value is WatchHatsRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.color === "string";
+};
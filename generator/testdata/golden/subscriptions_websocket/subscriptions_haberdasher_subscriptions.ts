@@ -0,0 +1,26 @@
+import {joinURL} from './twirp';
+import {Subscription, SubscriptionOptions, subscribe} from './twirp_subscriptions';
+import {Page, PageToJSON} from './common';
+import {JSONToHat, JSONToOrder, ReadonlyHat, ReadonlyOrder, WatchHatsRequest, WatchHatsRequestToJSON} from './subscriptions';
+
+// HaberdasherSubscriptions are the subscriptions to the subscription methods of Haberdasher, which receive the messages
+// of a connection to the route of the method.
+export interface HaberdasherSubscriptions {
+    /** WatchHats sends the hats of a color as they are made. */
+    watchHats: (watchHatsRequest: WatchHatsRequest) => Subscription<ReadonlyHat>;
+    /** SubscribeOrders sends the orders of hats as they are placed. */
+    subscribeOrders: (page: Page) => Subscription<ReadonlyOrder>;
+}
+
+// createHaberdasherSubscriptions creates the subscriptions of Haberdasher, which connect to the routes of the methods
+// of the endpoint, e.g. wss://example.com/subscriptions/subscriptions.Haberdasher/WatchHats
+export const createHaberdasherSubscriptions = (endpoint: string, options: SubscriptionOptions = {}): HaberdasherSubscriptions => {
+    return {
+        watchHats: (watchHatsRequest: WatchHatsRequest): Subscription<ReadonlyHat> => {
+            return subscribe(joinURL(endpoint, "/subscriptions.Haberdasher/WatchHats"), WatchHatsRequestToJSON(watchHatsRequest), (data) => JSONToHat(JSON.parse(data)), options);
+        },
+        subscribeOrders: (page: Page): Subscription<ReadonlyOrder> => {
+            return subscribe(joinURL(endpoint, "/subscriptions.Haberdasher/SubscribeOrders"), PageToJSON(page), (data) => JSONToOrder(JSON.parse(data)), options);
+        },
+    };
+};
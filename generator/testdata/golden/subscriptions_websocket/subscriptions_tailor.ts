@@ -0,0 +1,133 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {Hat, HatToJSON, JSONToHat, ReadonlyHat} from './subscriptions';
+
+/** Tailor has no subscription methods. */
+export interface Tailor {
+    makeSuit: (hat: Hat, callOptions?: CallOptions) => Promise<ReadonlyHat>;
+}
+
+// TailorMethods are the Twirp routes of the methods of Tailor, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const TailorMethods = {
+    makeSuit: {
+        service: "subscriptions.Tailor",
+        method: "MakeSuit",
+        path: "/twirp/subscriptions.Tailor/MakeSuit",
+        inputType: "Hat",
+        outputType: "Hat",
+    },
+} as const;
+
+/** Tailor has no subscription methods. */
+export class DefaultTailor implements Tailor {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/subscriptions.Tailor/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    makeSuit(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat> {
+        const url = joinURL(this.hostname, this.pathPrefix + "MakeSuit");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "subscriptions.Tailor",
+                method: "MakeSuit",
+                url: url,
+                request: hat,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, HatToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToHat(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createTailorClient creates a DefaultTailor with the config, which may be shared by the clients of other services.
+export const createTailorClient = (config: TwirpClientConfig): DefaultTailor => {
+    return new DefaultTailor(config);
+};
+
+// A TailorMockResponses sets the response of each TailorMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface TailorMockResponses {
+    makeSuit?: ReadonlyHat | ((hat: Hat, callOptions?: CallOptions) => ReadonlyHat | Promise<ReadonlyHat>);
+}
+
+// TailorMockClient is a Tailor for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class TailorMockClient implements Tailor {
+    responses: TailorMockResponses;
+
+    constructor(responses: TailorMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    makeSuit(hat: Hat, callOptions?: CallOptions): Promise<ReadonlyHat> {
+        const response = this.responses.makeSuit;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Tailor.MakeSuit"}));
+        }
+
+        return new Promise<ReadonlyHat>((resolve) => resolve(typeof response === "function" ? response(hat, callOptions) : response));
+    }
+}
+
+export const createTailorMock = (overrides: TailorMockResponses = {}): TailorMockClient => {
+    return new TailorMockClient(overrides);
+};
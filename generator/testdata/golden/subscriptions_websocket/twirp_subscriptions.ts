@@ -0,0 +1,128 @@
+import {TwirpError, TwirpErrorCode} from './twirp';
+
+// Subscription is an async iterator of the messages of a subscription, e.g.
+// for await (const hat of subscriptions.watchHats(size)) { ... }
+// Breaking out of the loop closes the connection.
+export interface Subscription<T> extends AsyncIterableIterator<T> {
+    // close closes the connection, and ends the iteration after the messages that were already received
+    close(): void;
+}
+
+// SubscriptionOptions are the options of the connections of the subscriptions.
+export interface SubscriptionOptions {
+    // protocols are the subprotocols of the connections, e.g. to send a token
+    protocols?: string | string[];
+    // WebSocket is the constructor of the connections, e.g. from the ws package in Node
+    WebSocket?: {new (url: string, protocols?: string | string[]): WebSocket};
+}
+
+interface Reader<T> {
+    resolve: (result: IteratorResult<T>) => void;
+    reject: (err: any) => void;
+}
+
+// subscription is the Subscription of the messages that a connection pushes. The connection is opened by open,
+// which returns the function that closes it, and ends the subscription by calling end, with the error that failed
+// it, if any.
+const subscription = <T>(open: (push: (value: T) => void, end: (err?: any) => void) => () => void): Subscription<T> => {
+    const values: T[] = [];
+    const readers: Reader<T>[] = [];
+    let done = false;
+    let error: any;
+    let close = () => {};
+
+    // flush resolves the pending reads with the received messages, and then with the end of the subscription
+    const flush = () => {
+        while (readers.length > 0 && (values.length > 0 || done)) {
+            const reader = readers.shift() as Reader<T>;
+            if (values.length > 0) {
+                reader.resolve({value: values.shift() as T, done: false});
+            } else if (error !== undefined) {
+                reader.reject(error);
+                error = undefined;
+            } else {
+                reader.resolve({value: undefined, done: true});
+            }
+        }
+    };
+
+    const end = (err?: any) => {
+        if (done) {
+            return;
+        }
+
+        done = true;
+        error = err;
+        close();
+        flush();
+    };
+
+    const closeConnection = open((value) => {
+        if (!done) {
+            values.push(value);
+            flush();
+        }
+    }, end);
+
+    // the connection may fail while it is opened
+    if (done) {
+        closeConnection();
+    } else {
+        close = closeConnection;
+    }
+
+    const sub: Subscription<T> = {
+        next: () => new Promise<IteratorResult<T>>((resolve, reject) => {
+            readers.push({resolve: resolve, reject: reject});
+            flush();
+        }),
+        return: () => {
+            values.length = 0;
+            end();
+
+            return Promise.resolve<IteratorResult<T>>({value: undefined, done: true});
+        },
+        close: () => end(),
+        [Symbol.asyncIterator]: () => sub,
+    };
+
+    return sub;
+};
+
+// connectionError is the error of a connection that failed, or was closed by the server without ending the
+// subscription.
+const connectionError = (url: string): TwirpError => {
+    return new TwirpError({code: TwirpErrorCode.Unavailable, msg: "the connection to " + url + " failed"});
+};
+
+// subscribe opens the WebSocket of a subscription method, which sends the JSON of the request when it is open. The
+// server sends a text message with the JSON of each message, and closes the WebSocket with the code 1000 to end the
+// subscription, or with the JSON of a Twirp error as the reason to fail it.
+export const subscribe = <T>(url: string, request: object, decode: (data: string) => T, options: SubscriptionOptions): Subscription<T> => {
+    return subscription<T>((push, end) => {
+        const socket = new (options.WebSocket || WebSocket)(url, options.protocols);
+
+        socket.onopen = () => socket.send(JSON.stringify(request));
+        socket.onmessage = (e: MessageEvent) => {
+            try {
+                push(decode(String(e.data)));
+            } catch (err) {
+                end(err);
+            }
+        };
+        socket.onclose = (e: CloseEvent) => {
+            if (e.code === 1000) {
+                end();
+                return;
+            }
+
+            try {
+                end(new TwirpError(JSON.parse(e.reason)));
+            } catch (err) {
+                end(connectionError(url));
+            }
+        };
+
+        return () => socket.close();
+    });
+};
@@ -0,0 +1,480 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, bytesToBase64, mapEntries, floatToJSON, everyItem, everyValue, oneofMember, BuildWhenSet, messageBuilder} from './twirp';
+import {ValidationError, validationError, checkRule, validateMessage, validateList, validateMap, runeCount, isUnique, isEmail, isUUID} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {Money, MoneyJSON, MoneyToJSON, createMoney, isMoney, validateMoney} from './money';
+
+export enum Size {
+    SIZE_UNSPECIFIED = 0,
+    SIZE_SMALL = 1,
+    SIZE_LARGE = 2,
+}
+
+export interface Address {
+    street: string;
+    postalCode: string;
+}
+
+// AddressFields are the numbers and proto types of the fields of Address by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AddressFields = {
+    street: {number: 1, name: "street", type: "string"},
+    postalCode: {number: 2, name: "postal_code", type: "string"},
+} as const;
+
+export interface AddressJSON {
+    street: string;
+    postal_code: string;
+}
+
+export const AddressToJSON = (m: Address): AddressJSON => {
+    return {
+        street: m.street,
+        postal_code: m.postalCode,
+    };
+};
+
+// validateAddress checks the protoc-gen-validate rules of a Address, and returns the violated rules.
+export const validateAddress = (m: Address): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    checkRule(errors, "street", "string.min_len", runeCount(m.street || "") >= 1, "must be at least 1 characters");
+    checkRule(errors, "postal_code", "string.pattern", (m.postalCode || "") === "" || new RegExp("^[0-9]{5}$").test(m.postalCode || ""), "must match the pattern ^[0-9]{5}$");
+
+    return errors;
+};
+
+// isAddress reports if a value has the fields of a Address, e.g. to check data read from a cache or a websocket.
+export const isAddress = (value: unknown): value is Address => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.street === "string"
+        && typeof m.postalCode === "string";
+};
+
+// createAddress creates a Address whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createAddress = (partial: Partial<Address> = {}): Address => {
+    return {
+        street: partial.street !== undefined ? partial.street : "",
+        postalCode: partial.postalCode !== undefined ? partial.postalCode : "",
+    };
+};
+
+export type AccountContact =
+    | {kind: "phone"; value: string}
+    | {kind: "mail"; value: Address};
+
+export interface Account {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: number;
+    score: number;
+    level: number;
+    size: Size;
+    accepted: boolean;
+    avatar: Uint8Array;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: Address;
+    previous: Address[];
+    branches: {[key: string]: Address};
+    nickname?: string | undefined;
+    limit: Money;
+    contact?: AccountContact;
+}
+
+// AccountFields are the numbers and proto types of the fields of Account by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AccountFields = {
+    email: {number: 1, name: "email", type: "string"},
+    name: {number: 2, name: "name", type: "string"},
+    id: {number: 3, name: "id", type: "string"},
+    age: {number: 4, name: "age", type: "int32"},
+    balance: {number: 5, name: "balance", type: "int64"},
+    score: {number: 6, name: "score", type: "double"},
+    level: {number: 7, name: "level", type: "uint32"},
+    size: {number: 8, name: "size", type: "enum"},
+    accepted: {number: 9, name: "accepted", type: "bool"},
+    avatar: {number: 10, name: "avatar", type: "bytes"},
+    tags: {number: 11, name: "tags", type: "string"},
+    labels: {number: 12, name: "labels", type: "map"},
+    address: {number: 13, name: "address", type: "message"},
+    previous: {number: 14, name: "previous", type: "message"},
+    branches: {number: 15, name: "branches", type: "map"},
+    nickname: {number: 16, name: "nickname", type: "string"},
+    limit: {number: 19, name: "limit", type: "message"},
+    phone: {number: 17, name: "phone", type: "string"},
+    mail: {number: 18, name: "mail", type: "message"},
+} as const;
+
+export interface AccountJSON {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: string;
+    score: number | string;
+    level: number;
+    size: string | number;
+    accepted: boolean;
+    avatar: string;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: AddressJSON;
+    previous: AddressJSON[];
+    branches: {[key: string]: AddressJSON};
+    nickname?: string;
+    limit: MoneyJSON;
+    phone?: string;
+    mail?: AddressJSON;
+}
+
+export const AccountToJSON = (m: Account): AccountJSON => {
+    return {
+        email: m.email,
+        name: m.name,
+        id: m.id,
+        age: m.age,
+        balance: String(m.balance),
+        score: floatToJSON(m.score),
+        level: m.level,
+        size: Size[m.size],
+        accepted: m.accepted,
+        avatar: bytesToBase64(m.avatar),
+        tags: m.tags,
+        labels: m.labels,
+        address: AddressToJSON(m.address),
+        previous: m.previous.map(AddressToJSON),
+        branches: mapEntries(m.branches, String, (v) => AddressToJSON(v)),
+        nickname: m.nickname,
+        limit: MoneyToJSON(m.limit),
+        phone: m.contact && m.contact.kind === "phone" ? m.contact.value : undefined,
+        mail: m.contact && m.contact.kind === "mail" ? AddressToJSON(m.contact.value) : undefined,
+    };
+};
+
+// validateAccount checks the protoc-gen-validate rules of a Account, and returns the violated rules.
+export const validateAccount = (m: Account): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    checkRule(errors, "email", "string.email", isEmail(m.email || ""), "must be an email address");
+    checkRule(errors, "name", "string.min_len", runeCount(m.name || "") >= 2, "must be at least 2 characters");
+    checkRule(errors, "name", "string.max_len", runeCount(m.name || "") <= 64, "must be at most 64 characters");
+    checkRule(errors, "name", "string.not_contains", (m.name || "").indexOf("@") < 0, "must not contain \"@\"");
+    checkRule(errors, "id", "string.uuid", isUUID(m.id || ""), "must be a UUID");
+    checkRule(errors, "age", "int32.gte_lt", Number(m.age || 0) >= 18 && Number(m.age || 0) < 130, "must be greater than or equal to 18 and less than 130");
+    checkRule(errors, "balance", "int64.gt", Number(m.balance || 0) > 0, "must be greater than 0");
+    checkRule(errors, "score", "double.gt_lt", Number(m.score || 0) < 0 || Number(m.score || 0) > 1, "must be less than 0 or greater than 1");
+    checkRule(errors, "level", "uint32.in", [1, 2, 3].indexOf(Number(m.level || 0)) >= 0, "must be in [1, 2, 3]");
+    checkRule(errors, "size", "enum.defined_only", Size[(m.size || 0)] !== undefined, "must be a defined Size value");
+    checkRule(errors, "size", "enum.not_in", [0].indexOf((m.size || 0)) < 0, "must not be in [0]");
+    checkRule(errors, "accepted", "bool.const", !!m.accepted === true, "must equal true");
+    checkRule(errors, "avatar", "bytes.max_len", (m.avatar || new Uint8Array(0)).length <= 1024, "must be at most 1024 bytes");
+    checkRule(errors, "tags", "repeated.max_items", (m.tags || []).length <= 5, "must have at most 5 items");
+    checkRule(errors, "tags", "repeated.unique", isUnique(m.tags || []), "must have unique items");
+    (m.tags || []).forEach((v, i) => {
+        checkRule(errors, "tags[" + i + "]", "string.min_len", runeCount(v || "") >= 1, "must be at least 1 characters");
+        checkRule(errors, "tags[" + i + "]", "string.prefix", (v || "").indexOf("#") === 0, "must start with \"#\"");
+    });
+    checkRule(errors, "labels", "map.max_pairs", Object.keys(m.labels || {}).length <= 10, "must have at most 10 pairs");
+    checkRule(errors, "address", "message.required", m.address !== undefined && m.address !== null, "is required");
+    if (m.nickname !== undefined && m.nickname !== null) {
+        checkRule(errors, "nickname", "string.max_len", runeCount(m.nickname || "") <= 16, "must be at most 16 characters");
+    }
+    if (m.contact && m.contact.kind === "phone") {
+        checkRule(errors, "phone", "string.min_len", runeCount(m.contact.value || "") >= 7, "must be at least 7 characters");
+    }
+    checkRule(errors, "contact", "oneof.required", m.contact !== undefined, "is required");
+    validateMessage(errors, "address", m.address, validateAddress);
+    validateList(errors, "previous", m.previous, validateAddress);
+    validateMap(errors, "branches", m.branches, validateAddress);
+    validateMessage(errors, "limit", m.limit, validateMoney);
+    validateMessage(errors, "mail", m.contact && m.contact.kind === "mail" ? m.contact.value : undefined, validateAddress);
+
+    return errors;
+};
+
+// isAccount reports if a value has the fields of a Account, e.g. to check data read from a cache or a websocket.
+export const isAccount = (value: unknown): value is Account => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.email === "string"
+        && typeof m.name === "string"
+        && typeof m.id === "string"
+        && typeof m.age === "number"
+        && typeof m.balance === "number"
+        && typeof m.score === "number"
+        && typeof m.level === "number"
+        && typeof m.size === "number"
+        && typeof m.accepted === "boolean"
+        && m.avatar instanceof Uint8Array
+        && everyItem(m.tags, (v) => typeof v === "string")
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && isAddress(m.address)
+        && everyItem(m.previous, isAddress)
+        && everyValue(m.branches, isAddress)
+        && (m.nickname === undefined || typeof m.nickname === "string")
+        && isMoney(m.limit)
+        && (m.contact === undefined || oneofMember(m.contact, {phone: (v) => typeof v === "string", mail: isAddress}));
+};
+
+// createAccount creates a Account whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createAccount = (partial: Partial<Account> = {}): Account => {
+    return {
+        email: partial.email !== undefined ? partial.email : "",
+        name: partial.name !== undefined ? partial.name : "",
+        id: partial.id !== undefined ? partial.id : "",
+        age: partial.age !== undefined ? partial.age : 0,
+        balance: partial.balance !== undefined ? partial.balance : 0,
+        score: partial.score !== undefined ? partial.score : 0,
+        level: partial.level !== undefined ? partial.level : 0,
+        size: partial.size !== undefined ? partial.size : 0,
+        accepted: partial.accepted !== undefined ? partial.accepted : false,
+        avatar: partial.avatar !== undefined ? partial.avatar : new Uint8Array(0),
+        tags: partial.tags !== undefined ? partial.tags : [],
+        labels: partial.labels !== undefined ? partial.labels : {},
+        address: partial.address !== undefined ? partial.address : createAddress(),
+        previous: partial.previous !== undefined ? partial.previous : [],
+        branches: partial.branches !== undefined ? partial.branches : {},
+        nickname: partial.nickname,
+        limit: partial.limit !== undefined ? partial.limit : createMoney(),
+        contact: partial.contact,
+    };
+};
+
+// AccountBuilder builds a Account one field at a time, with a method that sets each field. Its build method can
+// only be called once its required fields are set, while the fields that are not set are their proto3 default values.
+export interface AccountBuilder<Set extends string = never> {
+    email(value: string): AccountBuilder<Set | "email">;
+    name(value: string): AccountBuilder<Set | "name">;
+    id(value: string): AccountBuilder<Set | "id">;
+    age(value: number): AccountBuilder<Set | "age">;
+    balance(value: number): AccountBuilder<Set | "balance">;
+    score(value: number): AccountBuilder<Set | "score">;
+    level(value: number): AccountBuilder<Set | "level">;
+    size(value: Size): AccountBuilder<Set | "size">;
+    accepted(value: boolean): AccountBuilder<Set | "accepted">;
+    avatar(value: Uint8Array): AccountBuilder<Set | "avatar">;
+    tags(value: string[]): AccountBuilder<Set | "tags">;
+    labels(value: {[key: string]: string}): AccountBuilder<Set | "labels">;
+    address(value: Address): AccountBuilder<Set | "address">;
+    previous(value: Address[]): AccountBuilder<Set | "previous">;
+    branches(value: {[key: string]: Address}): AccountBuilder<Set | "branches">;
+    nickname(value: string): AccountBuilder<Set | "nickname">;
+    limit(value: Money): AccountBuilder<Set | "limit">;
+    contact(value: AccountContact): AccountBuilder<Set | "contact">;
+    build: BuildWhenSet<"address" | "contact", Set, Account>;
+}
+
+// buildAccount starts a AccountBuilder, e.g. to build a request whose required fields are checked at compile time.
+export const buildAccount = (): AccountBuilder => messageBuilder<AccountBuilder>(createAccount, ["email", "name", "id", "age", "balance", "score", "level", "size", "accepted", "avatar", "tags", "labels", "address", "previous", "branches", "nickname", "limit", "contact"]);
+
+/** Audit has no rules, so no validate function is generated for it. */
+export interface Audit {
+    note: string;
+}
+
+// AuditFields are the numbers and proto types of the fields of Audit by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AuditFields = {
+    note: {number: 1, name: "note", type: "string"},
+} as const;
+
+export interface AuditJSON {
+    note: string;
+}
+
+export const JSONToAudit = (m: AuditJSON): Audit => {
+    return {
+        note: m.note,
+    };
+};
+
+// isAudit reports if a value has the fields of a Audit, e.g. to check data read from a cache or a websocket.
+export const isAudit = (value: unknown): value is Audit => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.note === "string";
+};
+
+// createAudit creates a Audit whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createAudit = (partial: Partial<Audit> = {}): Audit => {
+    return {
+        note: partial.note !== undefined ? partial.note : "",
+    };
+};
+
+export interface Skipped {
+    name: string;
+}
+
+// SkippedFields are the numbers and proto types of the fields of Skipped by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SkippedFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface SkippedJSON {
+    name: string;
+}
+
+// isSkipped reports if a value has the fields of a Skipped, e.g. to check data read from a cache or a websocket.
+export const isSkipped = (value: unknown): value is Skipped => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
+
+// createSkipped creates a Skipped whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createSkipped = (partial: Partial<Skipped> = {}): Skipped => {
+    return {
+        name: partial.name !== undefined ? partial.name : "",
+    };
+};
+
+export interface Accounts {
+    create: (account: Account, callOptions?: CallOptions) => Promise<Audit>;
+}
+
+// AccountsMethods are the Twirp routes of the methods of Accounts, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AccountsMethods = {
+    create: {
+        service: "validated.Accounts",
+        method: "Create",
+        path: "/twirp/validated.Accounts/Create",
+        inputType: "Account",
+        outputType: "Audit",
+    },
+} as const;
+
+export class DefaultAccounts implements Accounts {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/validated.Accounts/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit> {
+        const errors = validateAccount(account);
+        if (errors.length > 0) {
+            return Promise.reject(validationError(errors));
+        }
+        const url = joinURL(this.hostname, this.pathPrefix + "Create");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "validated.Accounts",
+                method: "Create",
+                url: url,
+                request: account,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, AccountToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToAudit(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createAccountsClient creates a DefaultAccounts with the config, which may be shared by the clients of other services.
+export const createAccountsClient = (config: TwirpClientConfig): DefaultAccounts => {
+    return new DefaultAccounts(config);
+};
+
+// A AccountsMockResponses sets the response of each AccountsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AccountsMockResponses {
+    create?: Audit | ((account: Account, callOptions?: CallOptions) => Audit | Promise<Audit>);
+}
+
+// AccountsMockClient is a Accounts for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AccountsMockClient implements Accounts {
+    responses: AccountsMockResponses;
+
+    constructor(responses: AccountsMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit> {
+        const response = this.responses.create;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Accounts.Create"}));
+        }
+
+        return new Promise<Audit>((resolve) => resolve(typeof response === "function" ? response(account, callOptions) : response));
+    }
+}
+
+export const createAccountsMock = (overrides: AccountsMockResponses = {}): AccountsMockClient => {
+    return new AccountsMockClient(overrides);
+};
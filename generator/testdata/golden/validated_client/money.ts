@@ -0,0 +1,44 @@
+import {ValidationError, checkRule, runeCount} from './twirp';
+
+export interface Money {
+    currency: string;
+    units: bigint;
+}
+
+// MoneyFields are the numbers and proto types of the fields of Money by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const MoneyFields = {
+    currency: {number: 1, name: "currency", type: "string"},
+    units: {number: 2, name: "units", type: "int64"},
+} as const;
+
+export interface MoneyJSON {
+    currency: string;
+    units: string;
+}
+
+export const MoneyToJSON = (m: Money): MoneyJSON => {
+    return {
+        currency: m.currency,
+        units: m.units.toString(),
+    };
+};
+
+// validateMoney checks the protoc-gen-validate rules of a Money, and returns the violated rules.
+export const validateMoney = (m: Money): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    checkRule(errors, "currency", "string.len", runeCount(m.currency || "") === 3, "must be 3 characters");
+
+    return errors;
+};
+
+// isMoney reports if a value has the fields of a Money, e.g. to check data read from a cache or a websocket.
+export const isMoney = (value: unknown): value is Money => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.currency === "string"
+        && typeof m.units === "bigint";
+};
@@ -0,0 +1,270 @@
+import {bytesToBase64, mapEntries, floatToJSON, everyItem, everyValue, oneofMember} from './twirp';
+import {ValidationError, checkRule, validateMessage, validateList, validateMap, runeCount, isUnique, isEmail, isUUID} from './twirp';
+import {Money, MoneyJSON, MoneyToJSON, isMoney, validateMoney} from './money';
+
+export enum Size {
+    SIZE_UNSPECIFIED = 0,
+    SIZE_SMALL = 1,
+    SIZE_LARGE = 2,
+}
+
+export interface Address {
+    street: string;
+    postalCode: string;
+}
+
+// AddressFields are the numbers and proto types of the fields of Address by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AddressFields = {
+    street: {number: 1, name: "street", type: "string"},
+    postalCode: {number: 2, name: "postal_code", type: "string"},
+} as const;
+
+export interface AddressJSON {
+    street: string;
+    postal_code: string;
+}
+
+export const AddressToJSON = (m: Address): AddressJSON => {
+    return {
+        street: m.street,
+        postal_code: m.postalCode,
+    };
+};
+
+// validateAddress checks the protoc-gen-validate rules of a Address, and returns the violated rules.
+export const validateAddress = (m: Address): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    checkRule(errors, "street", "string.min_len", runeCount(m.street || "") >= 1, "must be at least 1 characters");
+    checkRule(errors, "postal_code", "string.pattern", (m.postalCode || "") === "" || new RegExp("^[0-9]{5}$").test(m.postalCode || ""), "must match the pattern ^[0-9]{5}$");
+
+    return errors;
+};
+
+// isAddress reports if a value has the fields of a Address, e.g. to check data read from a cache or a websocket.
+export const isAddress = (value: unknown): value is Address => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.street === "string"
+        && typeof m.postalCode === "string";
+};
+
+export type AccountContact =
+    | {kind: "phone"; value: string}
+    | {kind: "mail"; value: Address};
+
+export interface Account {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: bigint;
+    score: number;
+    level: number;
+    size: Size;
+    accepted: boolean;
+    avatar: Uint8Array;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: Address;
+    previous: Address[];
+    branches: {[key: string]: Address};
+    nickname?: string | undefined;
+    limit: Money;
+    contact?: AccountContact;
+}
+
+// AccountFields are the numbers and proto types of the fields of Account by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AccountFields = {
+    email: {number: 1, name: "email", type: "string"},
+    name: {number: 2, name: "name", type: "string"},
+    id: {number: 3, name: "id", type: "string"},
+    age: {number: 4, name: "age", type: "int32"},
+    balance: {number: 5, name: "balance", type: "int64"},
+    score: {number: 6, name: "score", type: "double"},
+    level: {number: 7, name: "level", type: "uint32"},
+    size: {number: 8, name: "size", type: "enum"},
+    accepted: {number: 9, name: "accepted", type: "bool"},
+    avatar: {number: 10, name: "avatar", type: "bytes"},
+    tags: {number: 11, name: "tags", type: "string"},
+    labels: {number: 12, name: "labels", type: "map"},
+    address: {number: 13, name: "address", type: "message"},
+    previous: {number: 14, name: "previous", type: "message"},
+    branches: {number: 15, name: "branches", type: "map"},
+    nickname: {number: 16, name: "nickname", type: "string"},
+    limit: {number: 19, name: "limit", type: "message"},
+    phone: {number: 17, name: "phone", type: "string"},
+    mail: {number: 18, name: "mail", type: "message"},
+} as const;
+
+export interface AccountJSON {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: string;
+    score: number | string;
+    level: number;
+    size: string | number;
+    accepted: boolean;
+    avatar: string;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: AddressJSON;
+    previous: AddressJSON[];
+    branches: {[key: string]: AddressJSON};
+    nickname?: string;
+    limit: MoneyJSON;
+    phone?: string;
+    mail?: AddressJSON;
+}
+
+export const AccountToJSON = (m: Account): AccountJSON => {
+    return {
+        email: m.email,
+        name: m.name,
+        id: m.id,
+        age: m.age,
+        balance: m.balance.toString(),
+        score: floatToJSON(m.score),
+        level: m.level,
+        size: Size[m.size],
+        accepted: m.accepted,
+        avatar: bytesToBase64(m.avatar),
+        tags: m.tags,
+        labels: m.labels,
+        address: AddressToJSON(m.address),
+        previous: m.previous.map(AddressToJSON),
+        branches: mapEntries(m.branches, String, (v) => AddressToJSON(v)),
+        nickname: m.nickname,
+        limit: MoneyToJSON(m.limit),
+        phone: m.contact && m.contact.kind === "phone" ? m.contact.value : undefined,
+        mail: m.contact && m.contact.kind === "mail" ? AddressToJSON(m.contact.value) : undefined,
+    };
+};
+
+// validateAccount checks the protoc-gen-validate rules of a Account, and returns the violated rules.
+export const validateAccount = (m: Account): ValidationError[] => {
+    const errors: ValidationError[] = [];
+    checkRule(errors, "email", "string.email", isEmail(m.email || ""), "must be an email address");
+    checkRule(errors, "name", "string.min_len", runeCount(m.name || "") >= 2, "must be at least 2 characters");
+    checkRule(errors, "name", "string.max_len", runeCount(m.name || "") <= 64, "must be at most 64 characters");
+    checkRule(errors, "name", "string.not_contains", (m.name || "").indexOf("@") < 0, "must not contain \"@\"");
+    checkRule(errors, "id", "string.uuid", isUUID(m.id || ""), "must be a UUID");
+    checkRule(errors, "age", "int32.gte_lt", Number(m.age || 0) >= 18 && Number(m.age || 0) < 130, "must be greater than or equal to 18 and less than 130");
+    checkRule(errors, "balance", "int64.gt", (m.balance || BigInt(0)) > BigInt("0"), "must be greater than 0");
+    checkRule(errors, "score", "double.gt_lt", Number(m.score || 0) < 0 || Number(m.score || 0) > 1, "must be less than 0 or greater than 1");
+    checkRule(errors, "level", "uint32.in", [1, 2, 3].indexOf(Number(m.level || 0)) >= 0, "must be in [1, 2, 3]");
+    checkRule(errors, "size", "enum.defined_only", Size[(m.size || 0)] !== undefined, "must be a defined Size value");
+    checkRule(errors, "size", "enum.not_in", [0].indexOf((m.size || 0)) < 0, "must not be in [0]");
+    checkRule(errors, "accepted", "bool.const", !!m.accepted === true, "must equal true");
+    checkRule(errors, "avatar", "bytes.max_len", (m.avatar || new Uint8Array(0)).length <= 1024, "must be at most 1024 bytes");
+    checkRule(errors, "tags", "repeated.max_items", (m.tags || []).length <= 5, "must have at most 5 items");
+    checkRule(errors, "tags", "repeated.unique", isUnique(m.tags || []), "must have unique items");
+    (m.tags || []).forEach((v, i) => {
+        checkRule(errors, "tags[" + i + "]", "string.min_len", runeCount(v || "") >= 1, "must be at least 1 characters");
+        checkRule(errors, "tags[" + i + "]", "string.prefix", (v || "").indexOf("#") === 0, "must start with \"#\"");
+    });
+    checkRule(errors, "labels", "map.max_pairs", Object.keys(m.labels || {}).length <= 10, "must have at most 10 pairs");
+    checkRule(errors, "address", "message.required", m.address !== undefined && m.address !== null, "is required");
+    if (m.nickname !== undefined && m.nickname !== null) {
+        checkRule(errors, "nickname", "string.max_len", runeCount(m.nickname || "") <= 16, "must be at most 16 characters");
+    }
+    if (m.contact && m.contact.kind === "phone") {
+        checkRule(errors, "phone", "string.min_len", runeCount(m.contact.value || "") >= 7, "must be at least 7 characters");
+    }
+    checkRule(errors, "contact", "oneof.required", m.contact !== undefined, "is required");
+    validateMessage(errors, "address", m.address, validateAddress);
+    validateList(errors, "previous", m.previous, validateAddress);
+    validateMap(errors, "branches", m.branches, validateAddress);
+    validateMessage(errors, "limit", m.limit, validateMoney);
+    validateMessage(errors, "mail", m.contact && m.contact.kind === "mail" ? m.contact.value : undefined, validateAddress);
+
+    return errors;
+};
+
+// isAccount reports if a value has the fields of a Account, e.g. to check data read from a cache or a websocket.
+export const isAccount = (value: unknown): value is Account => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.email === "string"
+        && typeof m.name === "string"
+        && typeof m.id === "string"
+        && typeof m.age === "number"
+        && typeof m.balance === "bigint"
+        && typeof m.score === "number"
+        && typeof m.level === "number"
+        && typeof m.size === "number"
+        && typeof m.accepted === "boolean"
+        && m.avatar instanceof Uint8Array
+        && everyItem(m.tags, (v) => typeof v === "string")
+        && everyValue(m.labels, (v) => typeof v === "string")
+        && isAddress(m.address)
+        && everyItem(m.previous, isAddress)
+        && everyValue(m.branches, isAddress)
+        && (m.nickname === undefined || typeof m.nickname === "string")
+        && isMoney(m.limit)
+        && (m.contact === undefined || oneofMember(m.contact, {phone: (v) => typeof v === "string", mail: isAddress}));
+};
+
+/** Audit has no rules, so no validate function is generated for it. */
+export interface Audit {
+    note: string;
+}
+
+// AuditFields are the numbers and proto types of the fields of Audit by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const AuditFields = {
+    note: {number: 1, name: "note", type: "string"},
+} as const;
+
+export interface AuditJSON {
+    note: string;
+}
+
+export const JSONToAudit = (m: AuditJSON): Audit => {
+    return {
+        note: m.note,
+    };
+};
+
+// isAudit reports if a value has the fields of a Audit, e.g. to check data read from a cache or a websocket.
+export const isAudit = (value: unknown): value is Audit => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.note === "string";
+};
+
+export interface Skipped {
+    name: string;
+}
+
+// SkippedFields are the numbers and proto types of the fields of Skipped by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const SkippedFields = {
+    name: {number: 1, name: "name", type: "string"},
+} as const;
+
+export interface SkippedJSON {
+    name: string;
+}
+
+// isSkipped reports if a value has the fields of a Skipped, e.g. to check data read from a cache or a websocket.
+export const isSkipped = (value: unknown): value is Skipped => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string";
+};
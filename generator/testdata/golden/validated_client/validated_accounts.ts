@@ -0,0 +1,136 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {validationError} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+import {Account, AccountToJSON, Audit, JSONToAudit, validateAccount} from './validated';
+
+export interface Accounts {
+    create: (account: Account, callOptions?: CallOptions) => Promise<Audit>;
+}
+
+// AccountsMethods are the Twirp routes of the methods of Accounts, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const AccountsMethods = {
+    create: {
+        service: "validated.Accounts",
+        method: "Create",
+        path: "/twirp/validated.Accounts/Create",
+        inputType: "Account",
+        outputType: "Audit",
+    },
+} as const;
+
+export class DefaultAccounts implements Accounts {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/validated.Accounts/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit> {
+        const errors = validateAccount(account);
+        if (errors.length > 0) {
+            return Promise.reject(validationError(errors));
+        }
+        const url = joinURL(this.hostname, this.pathPrefix + "Create");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "validated.Accounts",
+                method: "Create",
+                url: url,
+                request: account,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, AccountToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToAudit(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createAccountsClient creates a DefaultAccounts with the config, which may be shared by the clients of other services.
+export const createAccountsClient = (config: TwirpClientConfig): DefaultAccounts => {
+    return new DefaultAccounts(config);
+};
+
+// A AccountsMockResponses sets the response of each AccountsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AccountsMockResponses {
+    create?: Audit | ((account: Account, callOptions?: CallOptions) => Audit | Promise<Audit>);
+}
+
+// AccountsMockClient is a Accounts for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class AccountsMockClient implements Accounts {
+    responses: AccountsMockResponses;
+
+    constructor(responses: AccountsMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit> {
+        const response = this.responses.create;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Accounts.Create"}));
+        }
+
+        return new Promise<Audit>((resolve) => resolve(typeof response === "function" ? response(account, callOptions) : response));
+    }
+}
+
+export const createAccountsMock = (overrides: AccountsMockResponses = {}): AccountsMockClient => {
+    return new AccountsMockClient(overrides);
+};
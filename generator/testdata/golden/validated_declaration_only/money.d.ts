@@ -0,0 +1,26 @@
+import {ValidationError} from './twirp';
+
+export interface Money {
+    currency: string;
+    units: number;
+}
+
+// MoneyFields are the numbers and proto types of the fields of Money by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const MoneyFields: {
+    readonly currency: {readonly number: 1; readonly name: "currency"; readonly type: "string"};
+    readonly units: {readonly number: 2; readonly name: "units"; readonly type: "int64"};
+};
+
+export interface MoneyJSON {
+    currency: string;
+    units: string;
+}
+
+export declare const MoneyToJSON: (m: Money) => MoneyJSON;
+
+// validateMoney checks the protoc-gen-validate rules of a Money, and returns the violated rules.
+export declare const validateMoney: (m: Money) => ValidationError[];
+
+// isMoney reports if a value has the fields of a Money, e.g. to check data read from a cache or a websocket.
+export declare const isMoney: (value: unknown) => value is Money;
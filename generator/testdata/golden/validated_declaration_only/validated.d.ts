@@ -0,0 +1,220 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider} from './twirp';
+import {ValidationError} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+import {Money, MoneyJSON} from './money';
+
+export declare enum Size {
+    SIZE_UNSPECIFIED = 0,
+    SIZE_SMALL = 1,
+    SIZE_LARGE = 2,
+}
+
+export interface Address {
+    street: string;
+    postalCode: string;
+}
+
+// AddressFields are the numbers and proto types of the fields of Address by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const AddressFields: {
+    readonly street: {readonly number: 1; readonly name: "street"; readonly type: "string"};
+    readonly postalCode: {readonly number: 2; readonly name: "postal_code"; readonly type: "string"};
+};
+
+export interface AddressJSON {
+    street: string;
+    postal_code: string;
+}
+
+export declare const AddressToJSON: (m: Address) => AddressJSON;
+
+// validateAddress checks the protoc-gen-validate rules of a Address, and returns the violated rules.
+export declare const validateAddress: (m: Address) => ValidationError[];
+
+// isAddress reports if a value has the fields of a Address, e.g. to check data read from a cache or a websocket.
+export declare const isAddress: (value: unknown) => value is Address;
+
+export type AccountContact =
+    | {kind: "phone"; value: string}
+    | {kind: "mail"; value: Address};
+
+export interface Account {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: number;
+    score: number;
+    level: number;
+    size: Size;
+    accepted: boolean;
+    avatar: Uint8Array;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: Address;
+    previous: Address[];
+    branches: {[key: string]: Address};
+    nickname?: string | undefined;
+    limit: Money;
+    contact?: AccountContact;
+}
+
+// AccountFields are the numbers and proto types of the fields of Account by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const AccountFields: {
+    readonly email: {readonly number: 1; readonly name: "email"; readonly type: "string"};
+    readonly name: {readonly number: 2; readonly name: "name"; readonly type: "string"};
+    readonly id: {readonly number: 3; readonly name: "id"; readonly type: "string"};
+    readonly age: {readonly number: 4; readonly name: "age"; readonly type: "int32"};
+    readonly balance: {readonly number: 5; readonly name: "balance"; readonly type: "int64"};
+    readonly score: {readonly number: 6; readonly name: "score"; readonly type: "double"};
+    readonly level: {readonly number: 7; readonly name: "level"; readonly type: "uint32"};
+    readonly size: {readonly number: 8; readonly name: "size"; readonly type: "enum"};
+    readonly accepted: {readonly number: 9; readonly name: "accepted"; readonly type: "bool"};
+    readonly avatar: {readonly number: 10; readonly name: "avatar"; readonly type: "bytes"};
+    readonly tags: {readonly number: 11; readonly name: "tags"; readonly type: "string"};
+    readonly labels: {readonly number: 12; readonly name: "labels"; readonly type: "map"};
+    readonly address: {readonly number: 13; readonly name: "address"; readonly type: "message"};
+    readonly previous: {readonly number: 14; readonly name: "previous"; readonly type: "message"};
+    readonly branches: {readonly number: 15; readonly name: "branches"; readonly type: "map"};
+    readonly nickname: {readonly number: 16; readonly name: "nickname"; readonly type: "string"};
+    readonly limit: {readonly number: 19; readonly name: "limit"; readonly type: "message"};
+    readonly phone: {readonly number: 17; readonly name: "phone"; readonly type: "string"};
+    readonly mail: {readonly number: 18; readonly name: "mail"; readonly type: "message"};
+};
+
+export interface AccountJSON {
+    email: string;
+    name: string;
+    id: string;
+    age: number;
+    balance: string;
+    score: number | string;
+    level: number;
+    size: string | number;
+    accepted: boolean;
+    avatar: string;
+    tags: string[];
+    labels: {[key: string]: string};
+    address: AddressJSON;
+    previous: AddressJSON[];
+    branches: {[key: string]: AddressJSON};
+    nickname?: string;
+    limit: MoneyJSON;
+    phone?: string;
+    mail?: AddressJSON;
+}
+
+export declare const AccountToJSON: (m: Account) => AccountJSON;
+
+// validateAccount checks the protoc-gen-validate rules of a Account, and returns the violated rules.
+export declare const validateAccount: (m: Account) => ValidationError[];
+
+// isAccount reports if a value has the fields of a Account, e.g. to check data read from a cache or a websocket.
+export declare const isAccount: (value: unknown) => value is Account;
+
+/** Audit has no rules, so no validate function is generated for it. */
+export interface Audit {
+    note: string;
+}
+
+// AuditFields are the numbers and proto types of the fields of Audit by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const AuditFields: {
+    readonly note: {readonly number: 1; readonly name: "note"; readonly type: "string"};
+};
+
+export interface AuditJSON {
+    note: string;
+}
+
+export declare const JSONToAudit: (m: AuditJSON) => Audit;
+
+// isAudit reports if a value has the fields of a Audit, e.g. to check data read from a cache or a websocket.
+export declare const isAudit: (value: unknown) => value is Audit;
+
+export interface Skipped {
+    name: string;
+}
+
+// SkippedFields are the numbers and proto types of the fields of Skipped by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const SkippedFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+};
+
+export interface SkippedJSON {
+    name: string;
+}
+
+// isSkipped reports if a value has the fields of a Skipped, e.g. to check data read from a cache or a websocket.
+export declare const isSkipped: (value: unknown) => value is Skipped;
+
+export interface Accounts {
+    create: (account: Account, callOptions?: CallOptions) => Promise<Audit>;
+}
+
+// AccountsMethods are the Twirp routes of the methods of Accounts, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const AccountsMethods: {
+    readonly create: {
+        readonly service: "validated.Accounts";
+        readonly method: "Create";
+        readonly path: "/twirp/validated.Accounts/Create";
+        readonly inputType: "Account";
+        readonly outputType: "Audit";
+    };
+};
+
+export declare class DefaultAccounts implements Accounts {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit>;
+}
+
+// createAccountsClient creates a DefaultAccounts with the config, which may be shared by the clients of other services.
+export declare const createAccountsClient: (config: TwirpClientConfig) => DefaultAccounts;
+
+// A AccountsMockResponses sets the response of each AccountsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface AccountsMockResponses {
+    create?: Audit | ((account: Account, callOptions?: CallOptions) => Audit | Promise<Audit>);
+}
+
+// AccountsMockClient is a Accounts for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class AccountsMockClient implements Accounts {
+    responses: AccountsMockResponses;
+
+    constructor(responses?: AccountsMockResponses);
+
+    create(account: Account, callOptions?: CallOptions): Promise<Audit>;
+}
+
+export declare const createAccountsMock: (overrides?: AccountsMockResponses) => AccountsMockClient;
@@ -0,0 +1,235 @@
+import {fieldMaskToString, fieldMaskFromString, Any, jsonAliases, everyItem} from './twirp';
+
+export interface Event {
+    createdOn: Date;
+    updates: Date[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: number | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string[];
+    venue: Venue;
+}
+
+// EventFields are the numbers and proto types of the fields of Event by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const EventFields = {
+    createdOn: {number: 1, name: "created_on", type: "message"},
+    updates: {number: 2, name: "updates", type: "message"},
+    ttl: {number: 3, name: "ttl", type: "message"},
+    intervals: {number: 4, name: "intervals", type: "message"},
+    note: {number: 5, name: "note", type: "message"},
+    count: {number: 6, name: "count", type: "message"},
+    checks: {number: 7, name: "checks", type: "message"},
+    metadata: {number: 8, name: "metadata", type: "message"},
+    extra: {number: 9, name: "extra", type: "message"},
+    detail: {number: 10, name: "detail", type: "message"},
+    mask: {number: 11, name: "mask", type: "message"},
+    venue: {number: 12, name: "venue", type: "message"},
+} as const;
+
+export interface EventJSON {
+    created_on: string;
+    updates: string[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: string | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string;
+    venue: VenueJSON;
+}
+
+export const EventToJSON = (m: Event): EventJSON => {
+    return {
+        created_on: m.createdOn.toISOString(),
+        updates: m.updates.map(DateToJSON),
+        ttl: m.ttl,
+        intervals: m.intervals,
+        note: m.note,
+        count: m.count === null ? null : String(m.count),
+        checks: m.checks,
+        metadata: m.metadata,
+        extra: m.extra,
+        detail: m.detail,
+        mask: fieldMaskToString(m.mask),
+        venue: VenueToJSON(m.venue),
+    };
+};
+
+export const JSONToEvent = (json: EventJSON): Event => {
+    const m = jsonAliases(json, {"createdOn": "created_on"});
+
+    return {
+        createdOn: new Date(m.created_on),
+        updates: m.updates.map(JSONToDate),
+        ttl: m.ttl,
+        intervals: m.intervals,
+        note: m.note === undefined ? null : m.note,
+        count: m.count === undefined || m.count === null ? null : Number(m.count),
+        checks: m.checks,
+        metadata: m.metadata,
+        extra: m.extra,
+        detail: m.detail,
+        mask: fieldMaskFromString(m.mask || ""),
+        venue: JSONToVenue(m.venue),
+    };
+};
+
+// isEvent reports if a value has the fields of a Event, e.g. to check data read from a cache or a websocket.
+export const isEvent = (value: unknown): value is Event => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return m.createdOn instanceof Date
+        && everyItem(m.updates, (v) => v instanceof Date)
+        && typeof m.ttl === "string"
+        && everyItem(m.intervals, (v) => typeof v === "string")
+        && (m.note === null || typeof m.note === "string")
+        && (m.count === null || typeof m.count === "number")
+        && everyItem(m.checks, (v) => (v === null || typeof v === "boolean"))
+        && typeof m.metadata === "object" && m.metadata !== null
+        && m.extra !== undefined
+        && typeof m.detail === "object" && m.detail !== null
+        && everyItem(m.mask, (v) => typeof v === "string")
+        && isVenue(m.venue);
+};
+
+// createEvent creates a Event whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createEvent = (partial: Partial<Event> = {}): Event => {
+    return {
+        createdOn: partial.createdOn !== undefined ? partial.createdOn : new Date(0),
+        updates: partial.updates !== undefined ? partial.updates : [],
+        ttl: partial.ttl !== undefined ? partial.ttl : "0s",
+        intervals: partial.intervals !== undefined ? partial.intervals : [],
+        note: partial.note !== undefined ? partial.note : null,
+        count: partial.count !== undefined ? partial.count : null,
+        checks: partial.checks !== undefined ? partial.checks : [],
+        metadata: partial.metadata !== undefined ? partial.metadata : {},
+        extra: partial.extra !== undefined ? partial.extra : null,
+        detail: partial.detail !== undefined ? partial.detail : {"@type": ""},
+        mask: partial.mask !== undefined ? partial.mask : [],
+        venue: partial.venue !== undefined ? partial.venue : createVenue(),
+    };
+};
+
+export interface Venue {
+    name: string;
+    parent?: Venue | undefined;
+    rooms: Venue[];
+}
+
+// VenueFields are the numbers and proto types of the fields of Venue by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const VenueFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    rooms: {number: 3, name: "rooms", type: "message"},
+} as const;
+
+export interface VenueJSON {
+    name: string;
+    parent?: VenueJSON;
+    rooms: VenueJSON[];
+}
+
+export const VenueToJSON = (m: Venue): VenueJSON => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : VenueToJSON(m.parent),
+        rooms: m.rooms.map(VenueToJSON),
+    };
+};
+
+export const JSONToVenue = (m: VenueJSON): Venue => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : JSONToVenue(m.parent),
+        rooms: m.rooms.map(JSONToVenue),
+    };
+};
+
+// isVenue reports if a value has the fields of a Venue, e.g. to check data read from a cache or a websocket.
+export const isVenue = (value: unknown): value is Venue => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isVenue(m.parent))
+        && everyItem(m.rooms, isVenue);
+};
+
+// createVenue creates a Venue whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createVenue = (partial: Partial<Venue> = {}): Venue => {
+    return {
+        name: partial.name !== undefined ? partial.name : "",
+        parent: partial.parent,
+        rooms: partial.rooms !== undefined ? partial.rooms : [],
+    };
+};
+
+export interface UpdateEventRequest {
+    event: Event;
+    updateMask: string[];
+}
+
+// UpdateEventRequestFields are the numbers and proto types of the fields of UpdateEventRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const UpdateEventRequestFields = {
+    event: {number: 1, name: "event", type: "message"},
+    updateMask: {number: 2, name: "update_mask", type: "message"},
+} as const;
+
+export interface UpdateEventRequestJSON {
+    event: EventJSON;
+    update_mask: string;
+}
+
+export const UpdateEventRequestToJSON = (m: UpdateEventRequest): UpdateEventRequestJSON => {
+    return {
+        event: EventToJSON(m.event),
+        update_mask: fieldMaskToString(m.updateMask),
+    };
+};
+
+export const JSONToUpdateEventRequest = (json: UpdateEventRequestJSON): UpdateEventRequest => {
+    const m = jsonAliases(json, {"updateMask": "update_mask"});
+
+    return {
+        event: JSONToEvent(m.event),
+        updateMask: fieldMaskFromString(m.update_mask || ""),
+    };
+};
+
+// isUpdateEventRequest reports if a value has the fields of a UpdateEventRequest, e.g. to check data read from a cache or a websocket.
+export const isUpdateEventRequest = (value: unknown): value is UpdateEventRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isEvent(m.event)
+        && everyItem(m.updateMask, (v) => typeof v === "string");
+};
+
+// createUpdateEventRequest creates a UpdateEventRequest whose fields are set by partial, or are their proto3 default values, e.g. to
+// initialize the state of a form. Its message fields are created too, while its optional fields and oneofs are unset.
+export const createUpdateEventRequest = (partial: Partial<UpdateEventRequest> = {}): UpdateEventRequest => {
+    return {
+        event: partial.event !== undefined ? partial.event : createEvent(),
+        updateMask: partial.updateMask !== undefined ? partial.updateMask : [],
+    };
+};
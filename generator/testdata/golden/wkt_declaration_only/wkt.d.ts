@@ -0,0 +1,200 @@
+import {Transport, CallOptions, TwirpHeaders, HeadersProvider, Any} from './twirp';
+import {Interceptor, InterceptorContext, RetryPolicy, InstrumentationHooks, DebugLogger, TwirpClientConfig} from './interceptors';
+
+export interface Event {
+    createdOn: Date;
+    updates: Date[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: number | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string[];
+    venue: Venue;
+}
+
+// EventFields are the numbers and proto types of the fields of Event by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const EventFields: {
+    readonly createdOn: {readonly number: 1; readonly name: "created_on"; readonly type: "message"};
+    readonly updates: {readonly number: 2; readonly name: "updates"; readonly type: "message"};
+    readonly ttl: {readonly number: 3; readonly name: "ttl"; readonly type: "message"};
+    readonly intervals: {readonly number: 4; readonly name: "intervals"; readonly type: "message"};
+    readonly note: {readonly number: 5; readonly name: "note"; readonly type: "message"};
+    readonly count: {readonly number: 6; readonly name: "count"; readonly type: "message"};
+    readonly checks: {readonly number: 7; readonly name: "checks"; readonly type: "message"};
+    readonly metadata: {readonly number: 8; readonly name: "metadata"; readonly type: "message"};
+    readonly extra: {readonly number: 9; readonly name: "extra"; readonly type: "message"};
+    readonly detail: {readonly number: 10; readonly name: "detail"; readonly type: "message"};
+    readonly mask: {readonly number: 11; readonly name: "mask"; readonly type: "message"};
+    readonly venue: {readonly number: 12; readonly name: "venue"; readonly type: "message"};
+};
+
+export interface EventJSON {
+    created_on: string;
+    updates: string[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: string | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string;
+    venue: VenueJSON;
+}
+
+export declare const EventToJSON: (m: Event) => EventJSON;
+
+export declare const JSONToEvent: (m: EventJSON) => Event;
+
+// isEvent reports if a value has the fields of a Event, e.g. to check data read from a cache or a websocket.
+export declare const isEvent: (value: unknown) => value is Event;
+
+export interface Venue {
+    name: string;
+    parent?: Venue | undefined;
+    rooms: Venue[];
+}
+
+// VenueFields are the numbers and proto types of the fields of Venue by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const VenueFields: {
+    readonly name: {readonly number: 1; readonly name: "name"; readonly type: "string"};
+    readonly parent: {readonly number: 2; readonly name: "parent"; readonly type: "message"};
+    readonly rooms: {readonly number: 3; readonly name: "rooms"; readonly type: "message"};
+};
+
+export interface VenueJSON {
+    name: string;
+    parent?: VenueJSON;
+    rooms: VenueJSON[];
+}
+
+export declare const VenueToJSON: (m: Venue) => VenueJSON;
+
+export declare const JSONToVenue: (m: VenueJSON) => Venue;
+
+// isVenue reports if a value has the fields of a Venue, e.g. to check data read from a cache or a websocket.
+export declare const isVenue: (value: unknown) => value is Venue;
+
+export interface UpdateEventRequest {
+    event: Event;
+    updateMask: string[];
+}
+
+// UpdateEventRequestFields are the numbers and proto types of the fields of UpdateEventRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export declare const UpdateEventRequestFields: {
+    readonly event: {readonly number: 1; readonly name: "event"; readonly type: "message"};
+    readonly updateMask: {readonly number: 2; readonly name: "update_mask"; readonly type: "message"};
+};
+
+export interface UpdateEventRequestJSON {
+    event: EventJSON;
+    update_mask: string;
+}
+
+export declare const UpdateEventRequestToJSON: (m: UpdateEventRequest) => UpdateEventRequestJSON;
+
+// isUpdateEventRequest reports if a value has the fields of a UpdateEventRequest, e.g. to check data read from a cache or a websocket.
+export declare const isUpdateEventRequest: (value: unknown) => value is UpdateEventRequest;
+
+// VenueFieldMaskPath is the path of a field of a Venue or of its nested messages in a FieldMask.
+export type VenueFieldMaskPath = "name" | "parent" | "rooms";
+
+// venueFieldMask is a FieldMask of the fields of a Venue, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export declare const venueFieldMask: (...paths: VenueFieldMaskPath[]) => string[];
+
+// EventFieldMaskPath is the path of a field of a Event or of its nested messages in a FieldMask.
+export type EventFieldMaskPath = "created_on" | "updates" | "ttl" | "intervals" | "note" | "count" | "checks" | "metadata" | "extra" | "detail" | "mask" | "venue" | "venue.name" | "venue.parent" | "venue.rooms";
+
+// eventFieldMask is a FieldMask of the fields of a Event, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export declare const eventFieldMask: (...paths: EventFieldMaskPath[]) => string[];
+
+export interface Events {
+    record: (event: Event, callOptions?: CallOptions) => Promise<void>;
+
+    update: (updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Promise<Event>;
+}
+
+// EventsMethods are the Twirp routes of the methods of Events, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export declare const EventsMethods: {
+    readonly record: {
+        readonly service: "wkt.Events";
+        readonly method: "Record";
+        readonly path: "/twirp/wkt.Events/Record";
+        readonly inputType: "Event";
+        readonly outputType: "google.protobuf.Empty";
+    };
+    readonly update: {
+        readonly service: "wkt.Events";
+        readonly method: "Update";
+        readonly path: "/twirp/wkt.Events/Update";
+        readonly inputType: "UpdateEventRequest";
+        readonly outputType: "Event";
+    };
+};
+
+export declare class DefaultEvents implements Events {
+    private hostname;
+    private transport;
+    private headers?;
+    private interceptors;
+    private pathPrefix;
+    private timeoutMs?;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+
+    use(interceptor: Interceptor): this;
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this;
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this;
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this;
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this;
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this;
+
+    record(event: Event, callOptions?: CallOptions): Promise<void>;
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event>;
+}
+
+// createEventsClient creates a DefaultEvents with the config, which may be shared by the clients of other services.
+export declare const createEventsClient: (config: TwirpClientConfig) => DefaultEvents;
+
+// A EventsMockResponses sets the response of each EventsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface EventsMockResponses {
+    record?: ((event: Event, callOptions?: CallOptions) => void | Promise<void>);
+    update?: Event | ((updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Event | Promise<Event>);
+}
+
+// EventsMockClient is a Events for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export declare class EventsMockClient implements Events {
+    responses: EventsMockResponses;
+
+    constructor(responses?: EventsMockResponses);
+
+    record(event: Event, callOptions?: CallOptions): Promise<void>;
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event>;
+}
+
+export declare const createEventsMock: (overrides?: EventsMockResponses) => EventsMockClient;
@@ -0,0 +1,20 @@
+import {fakeArray, fakeBoolean, fakeDate, fakeInt, fakeOptional, fakeString, withOverrides} from './twirp_fakes';
+import {Event, UpdateEventRequest, Venue} from './wkt';
+
+// fakeEvent is a fake Event message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeEvent = (overrides: Partial<Event> = {}): Event => {
+    return new Event(withOverrides<Partial<Event>>({createdOn: fakeDate(), updates: fakeArray(() => fakeDate()), ttl: ({seconds: fakeInt(0, 3600), nanos: 0}), intervals: fakeArray(() => ({seconds: fakeInt(0, 3600), nanos: 0})), note: fakeString("note"), count: fakeInt(0, 1000), checks: fakeArray(() => fakeBoolean()), metadata: ({metadata: fakeString("metadata")}), extra: fakeString("extra"), detail: ({"@type": "type.googleapis.com/google.protobuf.Empty"}), mask: fakeArray(() => fakeString("path")), venue: fakeVenue()}, overrides));
+};
+
+// fakeVenue is a fake Venue message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeVenue = (overrides: Partial<Venue> = {}): Venue => {
+    return new Venue(withOverrides<Partial<Venue>>({name: fakeString("name"), parent: fakeOptional(() => fakeVenue()), rooms: fakeArray(() => fakeVenue())}, overrides));
+};
+
+// fakeUpdateEventRequest is a fake UpdateEventRequest message, whose fields are set to fake values unless they are overridden, e.g. in
+// a story or a test. The fakes are deterministic, see seedFakes.
+export const fakeUpdateEventRequest = (overrides: Partial<UpdateEventRequest> = {}): UpdateEventRequest => {
+    return new UpdateEventRequest(withOverrides<Partial<UpdateEventRequest>>({event: fakeEvent(), updateMask: fakeArray(() => fakeString("path"))}, overrides));
+};
@@ -0,0 +1,502 @@
+import {createTwirpRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, fieldMaskToString, fieldMaskFromString, Any, jsonAliases, everyItem} from './twirp';
+import {cloneValue, valuesEqual} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+export class Event {
+    createdOn: Date;
+    updates: Date[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: number | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string[];
+    venue: Venue;
+
+    constructor(init: Partial<Event> = {}) {
+        this.createdOn = init.createdOn as Date;
+        this.updates = init.updates !== undefined ? init.updates : [];
+        this.ttl = init.ttl as string;
+        this.intervals = init.intervals !== undefined ? init.intervals : [];
+        this.note = init.note !== undefined ? init.note : null;
+        this.count = init.count !== undefined ? init.count : null;
+        this.checks = init.checks !== undefined ? init.checks : [];
+        this.metadata = init.metadata as {[key: string]: any};
+        this.extra = init.extra as any;
+        this.detail = init.detail as Any;
+        this.mask = init.mask !== undefined ? init.mask : [];
+        this.venue = init.venue as Venue;
+    }
+
+    // clone returns a deep copy of the Event.
+    clone(): Event {
+        return new Event({
+            createdOn: cloneValue(this.createdOn),
+            updates: cloneValue(this.updates),
+            ttl: cloneValue(this.ttl),
+            intervals: cloneValue(this.intervals),
+            note: cloneValue(this.note),
+            count: cloneValue(this.count),
+            checks: cloneValue(this.checks),
+            metadata: cloneValue(this.metadata),
+            extra: cloneValue(this.extra),
+            detail: cloneValue(this.detail),
+            mask: cloneValue(this.mask),
+            venue: cloneValue(this.venue),
+        });
+    }
+
+    // equals reports if the fields of the Event are deeply equal to those of other.
+    equals(other: Event): boolean {
+        return valuesEqual(this.createdOn, other.createdOn)
+            && valuesEqual(this.updates, other.updates)
+            && valuesEqual(this.ttl, other.ttl)
+            && valuesEqual(this.intervals, other.intervals)
+            && valuesEqual(this.note, other.note)
+            && valuesEqual(this.count, other.count)
+            && valuesEqual(this.checks, other.checks)
+            && valuesEqual(this.metadata, other.metadata)
+            && valuesEqual(this.extra, other.extra)
+            && valuesEqual(this.detail, other.detail)
+            && valuesEqual(this.mask, other.mask)
+            && valuesEqual(this.venue, other.venue);
+    }
+
+    static fromJSON(m: EventJSON): Event {
+        return JSONToEvent(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Event is stringified as its proto3 JSON.
+    toJSON(): EventJSON {
+        return EventToJSON(this);
+    }
+}
+
+// EventFields are the numbers and proto types of the fields of Event by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const EventFields = {
+    createdOn: {number: 1, name: "created_on", type: "message"},
+    updates: {number: 2, name: "updates", type: "message"},
+    ttl: {number: 3, name: "ttl", type: "message"},
+    intervals: {number: 4, name: "intervals", type: "message"},
+    note: {number: 5, name: "note", type: "message"},
+    count: {number: 6, name: "count", type: "message"},
+    checks: {number: 7, name: "checks", type: "message"},
+    metadata: {number: 8, name: "metadata", type: "message"},
+    extra: {number: 9, name: "extra", type: "message"},
+    detail: {number: 10, name: "detail", type: "message"},
+    mask: {number: 11, name: "mask", type: "message"},
+    venue: {number: 12, name: "venue", type: "message"},
+} as const;
+
+export interface EventJSON {
+    created_on: string;
+    updates: string[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: string | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string;
+    venue: VenueJSON;
+}
+
+export const EventToJSON = (m: Event): EventJSON => {
+    return {
+        created_on: m.createdOn.toISOString(),
+        updates: m.updates.map(DateToJSON),
+        ttl: m.ttl,
+        intervals: m.intervals,
+        note: m.note,
+        count: m.count === null ? null : String(m.count),
+        checks: m.checks,
+        metadata: m.metadata,
+        extra: m.extra,
+        detail: m.detail,
+        mask: fieldMaskToString(m.mask),
+        venue: VenueToJSON(m.venue),
+    };
+};
+
+export const JSONToEvent = (json: EventJSON): Event => {
+    const m = jsonAliases(json, {"createdOn": "created_on"});
+
+    return new Event({
+        createdOn: new Date(m.created_on),
+        updates: m.updates.map(JSONToDate),
+        ttl: m.ttl,
+        intervals: m.intervals,
+        note: m.note === undefined ? null : m.note,
+        count: m.count === undefined || m.count === null ? null : Number(m.count),
+        checks: m.checks,
+        metadata: m.metadata,
+        extra: m.extra,
+        detail: m.detail,
+        mask: fieldMaskFromString(m.mask || ""),
+        venue: JSONToVenue(m.venue),
+    });
+};
+
+// isEvent reports if a value has the fields of a Event, e.g. to check data read from a cache or a websocket.
+export const isEvent = (value: unknown): value is Event => {
+    if (!(value instanceof Event)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return m.createdOn instanceof Date
+        && everyItem(m.updates, (v) => v instanceof Date)
+        && typeof m.ttl === "string"
+        && everyItem(m.intervals, (v) => typeof v === "string")
+        && (m.note === null || typeof m.note === "string")
+        && (m.count === null || typeof m.count === "number")
+        && everyItem(m.checks, (v) => (v === null || typeof v === "boolean"))
+        && typeof m.metadata === "object" && m.metadata !== null
+        && m.extra !== undefined
+        && typeof m.detail === "object" && m.detail !== null
+        && everyItem(m.mask, (v) => typeof v === "string")
+        && isVenue(m.venue);
+};
+
+export class Venue {
+    name: string;
+    parent?: Venue | undefined;
+    rooms: Venue[];
+
+    constructor(init: Partial<Venue> = {}) {
+        this.name = init.name !== undefined ? init.name : "";
+        this.parent = init.parent;
+        this.rooms = init.rooms !== undefined ? init.rooms : [];
+    }
+
+    // clone returns a deep copy of the Venue.
+    clone(): Venue {
+        return new Venue({
+            name: cloneValue(this.name),
+            parent: cloneValue(this.parent),
+            rooms: cloneValue(this.rooms),
+        });
+    }
+
+    // equals reports if the fields of the Venue are deeply equal to those of other.
+    equals(other: Venue): boolean {
+        return valuesEqual(this.name, other.name)
+            && valuesEqual(this.parent, other.parent)
+            && valuesEqual(this.rooms, other.rooms);
+    }
+
+    static fromJSON(m: VenueJSON): Venue {
+        return JSONToVenue(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a Venue is stringified as its proto3 JSON.
+    toJSON(): VenueJSON {
+        return VenueToJSON(this);
+    }
+}
+
+// VenueFields are the numbers and proto types of the fields of Venue by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const VenueFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    rooms: {number: 3, name: "rooms", type: "message"},
+} as const;
+
+export interface VenueJSON {
+    name: string;
+    parent?: VenueJSON;
+    rooms: VenueJSON[];
+}
+
+export const VenueToJSON = (m: Venue): VenueJSON => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : VenueToJSON(m.parent),
+        rooms: m.rooms.map(VenueToJSON),
+    };
+};
+
+export const JSONToVenue = (m: VenueJSON): Venue => {
+    return new Venue({
+        name: m.name,
+        parent: m.parent === undefined ? undefined : JSONToVenue(m.parent),
+        rooms: m.rooms.map(JSONToVenue),
+    });
+};
+
+// isVenue reports if a value has the fields of a Venue, e.g. to check data read from a cache or a websocket.
+export const isVenue = (value: unknown): value is Venue => {
+    if (!(value instanceof Venue)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isVenue(m.parent))
+        && everyItem(m.rooms, isVenue);
+};
+
+export class UpdateEventRequest {
+    event: Event;
+    updateMask: string[];
+
+    constructor(init: Partial<UpdateEventRequest> = {}) {
+        this.event = init.event as Event;
+        this.updateMask = init.updateMask !== undefined ? init.updateMask : [];
+    }
+
+    // clone returns a deep copy of the UpdateEventRequest.
+    clone(): UpdateEventRequest {
+        return new UpdateEventRequest({
+            event: cloneValue(this.event),
+            updateMask: cloneValue(this.updateMask),
+        });
+    }
+
+    // equals reports if the fields of the UpdateEventRequest are deeply equal to those of other.
+    equals(other: UpdateEventRequest): boolean {
+        return valuesEqual(this.event, other.event)
+            && valuesEqual(this.updateMask, other.updateMask);
+    }
+
+    static fromJSON(m: UpdateEventRequestJSON): UpdateEventRequest {
+        return JSONToUpdateEventRequest(m);
+    }
+
+    // toJSON is also called by JSON.stringify, so a UpdateEventRequest is stringified as its proto3 JSON.
+    toJSON(): UpdateEventRequestJSON {
+        return UpdateEventRequestToJSON(this);
+    }
+}
+
+// UpdateEventRequestFields are the numbers and proto types of the fields of UpdateEventRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const UpdateEventRequestFields = {
+    event: {number: 1, name: "event", type: "message"},
+    updateMask: {number: 2, name: "update_mask", type: "message"},
+} as const;
+
+export interface UpdateEventRequestJSON {
+    event: EventJSON;
+    update_mask: string;
+}
+
+export const UpdateEventRequestToJSON = (m: UpdateEventRequest): UpdateEventRequestJSON => {
+    return {
+        event: EventToJSON(m.event),
+        update_mask: fieldMaskToString(m.updateMask),
+    };
+};
+
+export const JSONToUpdateEventRequest = (json: UpdateEventRequestJSON): UpdateEventRequest => {
+    const m = jsonAliases(json, {"updateMask": "update_mask"});
+
+    return new UpdateEventRequest({
+        event: JSONToEvent(m.event),
+        updateMask: fieldMaskFromString(m.update_mask || ""),
+    });
+};
+
+// isUpdateEventRequest reports if a value has the fields of a UpdateEventRequest, e.g. to check data read from a cache or a websocket.
+export const isUpdateEventRequest = (value: unknown): value is UpdateEventRequest => {
+    if (!(value instanceof UpdateEventRequest)) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isEvent(m.event)
+        && everyItem(m.updateMask, (v) => typeof v === "string");
+};
+
+// VenueFieldMaskPath is the path of a field of a Venue or of its nested messages in a FieldMask.
+export type VenueFieldMaskPath = "name" | "parent" | "rooms";
+
+// venueFieldMask is a FieldMask of the fields of a Venue, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export const venueFieldMask = (...paths: VenueFieldMaskPath[]): string[] => paths;
+
+// EventFieldMaskPath is the path of a field of a Event or of its nested messages in a FieldMask.
+export type EventFieldMaskPath = "created_on" | "updates" | "ttl" | "intervals" | "note" | "count" | "checks" | "metadata" | "extra" | "detail" | "mask" | "venue" | "venue.name" | "venue.parent" | "venue.rooms";
+
+// eventFieldMask is a FieldMask of the fields of a Event, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export const eventFieldMask = (...paths: EventFieldMaskPath[]): string[] => paths;
+
+export interface Events {
+    record: (event: Event, callOptions?: CallOptions) => Promise<void>;
+
+    update: (updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Promise<Event>;
+}
+
+// EventsMethods are the Twirp routes of the methods of Events, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const EventsMethods = {
+    record: {
+        service: "wkt.Events",
+        method: "Record",
+        path: "/twirp/wkt.Events/Record",
+        inputType: "Event",
+        outputType: "google.protobuf.Empty",
+    },
+    update: {
+        service: "wkt.Events",
+        method: "Update",
+        path: "/twirp/wkt.Events/Update",
+        inputType: "UpdateEventRequest",
+        outputType: "Event",
+    },
+} as const;
+
+export class DefaultEvents implements Events {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/wkt.Events/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    record(event: Event, callOptions?: CallOptions): Promise<void> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Record");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "wkt.Events",
+                method: "Record",
+                url: url,
+                request: event,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, EventToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then(() => undefined);
+                });
+            });
+        }));
+    }
+
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Update");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "wkt.Events",
+                method: "Update",
+                url: url,
+                request: updateEventRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpRequest(ctx.url, UpdateEventRequestToJSON(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.text().then((body) => JSONToEvent(JSON.parse(body)));
+                });
+            });
+        }));
+    }
+}
+
+// createEventsClient creates a DefaultEvents with the config, which may be shared by the clients of other services.
+export const createEventsClient = (config: TwirpClientConfig): DefaultEvents => {
+    return new DefaultEvents(config);
+};
+
+// A EventsMockResponses sets the response of each EventsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface EventsMockResponses {
+    record?: ((event: Event, callOptions?: CallOptions) => void | Promise<void>);
+    update?: Event | ((updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Event | Promise<Event>);
+}
+
+// EventsMockClient is a Events for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class EventsMockClient implements Events {
+    responses: EventsMockResponses;
+
+    constructor(responses: EventsMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    record(event: Event, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.record;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Events.Record"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(event, callOptions) : response));
+    }
+
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event> {
+        const response = this.responses.update;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Events.Update"}));
+        }
+
+        return new Promise<Event>((resolve) => resolve(typeof response === "function" ? response(updateEventRequest, callOptions) : response));
+    }
+}
+
+export const createEventsMock = (overrides: EventsMockResponses = {}): EventsMockClient => {
+    return new EventsMockClient(overrides);
+};
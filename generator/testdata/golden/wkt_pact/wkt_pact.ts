@@ -0,0 +1,32 @@
+import {PactInteraction, PactInteractionOptions, pactInteraction, withOverrides} from './twirp_pact';
+import {Event, EventToJSON, UpdateEventRequest, UpdateEventRequestToJSON, Venue} from './wkt';
+
+// exampleEvent is an example of the Event message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleEvent = (overrides: Partial<Event> = {}): Event => {
+    return new Event(withOverrides<Partial<Event>>({createdOn: new Date(0), updates: [], ttl: "0s", intervals: [], note: null, count: null, checks: [], metadata: {}, extra: null, detail: {"@type": ""}, mask: [], venue: exampleVenue()}, overrides));
+};
+
+// exampleVenue is an example of the Venue message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleVenue = (overrides: Partial<Venue> = {}): Venue => {
+    return new Venue(withOverrides<Partial<Venue>>({name: "", rooms: []}, overrides));
+};
+
+// exampleUpdateEventRequest is an example of the UpdateEventRequest message, whose fields are their proto3 default values unless they
+// are overridden. Its optional fields and oneofs are not set.
+export const exampleUpdateEventRequest = (overrides: Partial<UpdateEventRequest> = {}): UpdateEventRequest => {
+    return new UpdateEventRequest(withOverrides<Partial<UpdateEventRequest>>({event: exampleEvent(), updateMask: []}, overrides));
+};
+
+// recordInteraction is a pact interaction of Events.Record, e.g.
+// provider.addInteraction(recordInteraction({request: exampleEvent()}))
+export const recordInteraction = (options: PactInteractionOptions<Event, void>): PactInteraction => {
+    return pactInteraction("/twirp/wkt.Events/Record", "a request to Events.Record", options, EventToJSON, () => ({}));
+};
+
+// updateInteraction is a pact interaction of Events.Update, e.g.
+// provider.addInteraction(updateInteraction({request: exampleUpdateEventRequest(), response: exampleEvent()}))
+export const updateInteraction = (options: PactInteractionOptions<UpdateEventRequest, Event>): PactInteraction => {
+    return pactInteraction("/twirp/wkt.Events/Update", "a request to Events.Update", options, UpdateEventRequestToJSON, EventToJSON);
+};
@@ -0,0 +1,396 @@
+import {createTwirpProtobufRequest, joinURL, resolveCallOptions, withDeadline, reportResponse, throwTwirpError, TwirpError, TwirpErrorCode, Transport, CallOptions, TwirpHeaders, HeadersProvider, ProtobufReader, ProtobufWriter, timestampToProtobuf, protobufToTimestamp, fieldMaskToProtobuf, protobufToFieldMask, Any, anyToProtobuf, protobufToAny, structToProtobuf, protobufToStruct, valueToProtobuf, protobufToValue, Duration, durationToProtobuf, protobufToDuration, unwrapValue, everyItem, isDurationObject} from './twirp';
+import {InterceptorChain, Interceptor, InterceptorContext, RetryPolicy, retryInterceptor, InstrumentationHooks, instrumentationInterceptor, DebugLogger, debugInterceptor, idempotencyKeyInterceptor, TwirpClientConfig, clientConfig} from './interceptors';
+
+export interface Event {
+    createdOn: Date;
+    updates: Date[];
+    ttl: Duration;
+    intervals: Duration[];
+    note: string | null;
+    count: number | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string[];
+    venue: Venue;
+}
+
+// EventFields are the numbers and proto types of the fields of Event by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const EventFields = {
+    createdOn: {number: 1, name: "created_on", type: "message"},
+    updates: {number: 2, name: "updates", type: "message"},
+    ttl: {number: 3, name: "ttl", type: "message"},
+    intervals: {number: 4, name: "intervals", type: "message"},
+    note: {number: 5, name: "note", type: "message"},
+    count: {number: 6, name: "count", type: "message"},
+    checks: {number: 7, name: "checks", type: "message"},
+    metadata: {number: 8, name: "metadata", type: "message"},
+    extra: {number: 9, name: "extra", type: "message"},
+    detail: {number: 10, name: "detail", type: "message"},
+    mask: {number: 11, name: "mask", type: "message"},
+    venue: {number: 12, name: "venue", type: "message"},
+} as const;
+
+export interface EventJSON {
+    created_on: string;
+    updates: string[];
+    ttl: string;
+    intervals: string[];
+    note: string | null;
+    count: string | null;
+    checks: (boolean | null)[];
+    metadata: {[key: string]: any};
+    extra: any;
+    detail: Any;
+    mask: string;
+    venue: VenueJSON;
+}
+
+export const EventToProtobuf = (m: Event): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.createdOn) { w.tag(1, 2).bytes(timestampToProtobuf(m.createdOn)); }
+    m.updates.forEach((v) => w.tag(2, 2).bytes(timestampToProtobuf(v)));
+    if (m.ttl) { w.tag(3, 2).bytes(durationToProtobuf(m.ttl)); }
+    m.intervals.forEach((v) => w.tag(4, 2).bytes(durationToProtobuf(v)));
+    if (m.note !== null && m.note !== undefined) { w.tag(5, 2).bytes(new ProtobufWriter().tag(1, 2).string(m.note).finish()); }
+    if (m.count !== null && m.count !== undefined) { w.tag(6, 2).bytes(new ProtobufWriter().tag(1, 0).int64(String(m.count)).finish()); }
+    m.checks.forEach((v) => w.tag(7, 2).bytes(new ProtobufWriter().tag(1, 0).bool(v).finish()));
+    if (m.metadata !== undefined) { w.tag(8, 2).bytes(structToProtobuf(m.metadata)); }
+    if (m.extra !== undefined) { w.tag(9, 2).bytes(valueToProtobuf(m.extra)); }
+    if (m.detail !== undefined) { w.tag(10, 2).bytes(anyToProtobuf(m.detail)); }
+    if (m.mask && m.mask.length) { w.tag(11, 2).bytes(fieldMaskToProtobuf(m.mask)); }
+    if (m.venue) { w.tag(12, 2).bytes(VenueToProtobuf(m.venue)); }
+
+    return w.finish();
+};
+
+export const ProtobufToEvent = (b: Uint8Array): Event => {
+    const r = new ProtobufReader(b);
+    const m = {updates: [], intervals: [], note: null, count: null, checks: [], mask: []} as Event;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.createdOn = protobufToTimestamp(r.bytes()); break;
+            case 2: m.updates.push(protobufToTimestamp(r.bytes())); break;
+            case 3: m.ttl = protobufToDuration(r.bytes()); break;
+            case 4: m.intervals.push(protobufToDuration(r.bytes())); break;
+            case 5: m.note = unwrapValue(r.bytes(), "", (r) => r.string()); break;
+            case 6: m.count = unwrapValue(r.bytes(), 0, (r) => Number(r.int64())); break;
+            case 7: m.checks.push(unwrapValue(r.bytes(), false, (r) => r.bool())); break;
+            case 8: m.metadata = protobufToStruct(r.bytes()); break;
+            case 9: m.extra = protobufToValue(r.bytes()); break;
+            case 10: m.detail = protobufToAny(r.bytes()); break;
+            case 11: m.mask = protobufToFieldMask(r.bytes()); break;
+            case 12: m.venue = ProtobufToVenue(r.bytes()); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isEvent reports if a value has the fields of a Event, e.g. to check data read from a cache or a websocket.
+export const isEvent = (value: unknown): value is Event => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return m.createdOn instanceof Date
+        && everyItem(m.updates, (v) => v instanceof Date)
+        && isDurationObject(m.ttl)
+        && everyItem(m.intervals, (v) => isDurationObject(v))
+        && (m.note === null || typeof m.note === "string")
+        && (m.count === null || typeof m.count === "number")
+        && everyItem(m.checks, (v) => (v === null || typeof v === "boolean"))
+        && typeof m.metadata === "object" && m.metadata !== null
+        && m.extra !== undefined
+        && typeof m.detail === "object" && m.detail !== null
+        && everyItem(m.mask, (v) => typeof v === "string")
+        && isVenue(m.venue);
+};
+
+export interface Venue {
+    name: string;
+    parent?: Venue | undefined;
+    rooms: Venue[];
+}
+
+// VenueFields are the numbers and proto types of the fields of Venue by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const VenueFields = {
+    name: {number: 1, name: "name", type: "string"},
+    parent: {number: 2, name: "parent", type: "message"},
+    rooms: {number: 3, name: "rooms", type: "message"},
+} as const;
+
+export interface VenueJSON {
+    name: string;
+    parent?: VenueJSON;
+    rooms: VenueJSON[];
+}
+
+export const VenueToProtobuf = (m: Venue): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.name) { w.tag(1, 2).string(m.name); }
+    if (m.parent !== undefined) { w.tag(2, 2).bytes(VenueToProtobuf(m.parent)); }
+    m.rooms.forEach((v) => w.tag(3, 2).bytes(VenueToProtobuf(v)));
+
+    return w.finish();
+};
+
+export const ProtobufToVenue = (b: Uint8Array): Venue => {
+    const r = new ProtobufReader(b);
+    const m = {name: "", rooms: []} as Venue;
+
+    while (!r.done()) {
+        const tag = r.uint32();
+        switch (tag >>> 3) {
+            case 1: m.name = r.string(); break;
+            case 2: m.parent = ProtobufToVenue(r.bytes()); break;
+            case 3: m.rooms.push(ProtobufToVenue(r.bytes())); break;
+            default:
+                r.skip(tag & 7);
+        }
+    }
+
+    return m;
+};
+
+// isVenue reports if a value has the fields of a Venue, e.g. to check data read from a cache or a websocket.
+export const isVenue = (value: unknown): value is Venue => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return typeof m.name === "string"
+        && (m.parent === undefined || isVenue(m.parent))
+        && everyItem(m.rooms, isVenue);
+};
+
+export interface UpdateEventRequest {
+    event: Event;
+    updateMask: string[];
+}
+
+// UpdateEventRequestFields are the numbers and proto types of the fields of UpdateEventRequest by the names of their properties, with
+// their names in the proto file, e.g. for binary encoding, field masks or analytics schemas.
+export const UpdateEventRequestFields = {
+    event: {number: 1, name: "event", type: "message"},
+    updateMask: {number: 2, name: "update_mask", type: "message"},
+} as const;
+
+export interface UpdateEventRequestJSON {
+    event: EventJSON;
+    update_mask: string;
+}
+
+export const UpdateEventRequestToProtobuf = (m: UpdateEventRequest): Uint8Array => {
+    const w = new ProtobufWriter();
+    if (m.event) { w.tag(1, 2).bytes(EventToProtobuf(m.event)); }
+    if (m.updateMask && m.updateMask.length) { w.tag(2, 2).bytes(fieldMaskToProtobuf(m.updateMask)); }
+
+    return w.finish();
+};
+
+// isUpdateEventRequest reports if a value has the fields of a UpdateEventRequest, e.g. to check data read from a cache or a websocket.
+export const isUpdateEventRequest = (value: unknown): value is UpdateEventRequest => {
+    if (typeof value !== "object" || value === null) {
+        return false;
+    }
+
+    const m = value as {[key: string]: unknown};
+    return isEvent(m.event)
+        && everyItem(m.updateMask, (v) => typeof v === "string");
+};
+
+// VenueFieldMaskPath is the path of a field of a Venue or of its nested messages in a FieldMask.
+export type VenueFieldMaskPath = "name" | "parent" | "rooms";
+
+// venueFieldMask is a FieldMask of the fields of a Venue, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export const venueFieldMask = (...paths: VenueFieldMaskPath[]): string[] => paths;
+
+// EventFieldMaskPath is the path of a field of a Event or of its nested messages in a FieldMask.
+export type EventFieldMaskPath = "created_on" | "updates" | "ttl" | "intervals" | "note" | "count" | "checks" | "metadata" | "extra" | "detail" | "mask" | "venue" | "venue.name" | "venue.parent" | "venue.rooms";
+
+// eventFieldMask is a FieldMask of the fields of a Event, whose paths are checked at compile time, e.g. for the
+// update mask of a request.
+export const eventFieldMask = (...paths: EventFieldMaskPath[]): string[] => paths;
+
+export interface Events {
+    record: (event: Event, callOptions?: CallOptions) => Promise<void>;
+
+    update: (updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Promise<Event>;
+}
+
+// EventsMethods are the Twirp routes of the methods of Events, with the names of their input and output
+// types, e.g. for routing, mock handlers, or analytics.
+export const EventsMethods = {
+    record: {
+        service: "wkt.Events",
+        method: "Record",
+        path: "/twirp/wkt.Events/Record",
+        inputType: "Event",
+        outputType: "google.protobuf.Empty",
+    },
+    update: {
+        service: "wkt.Events",
+        method: "Update",
+        path: "/twirp/wkt.Events/Update",
+        inputType: "UpdateEventRequest",
+        outputType: "Event",
+    },
+} as const;
+
+export class DefaultEvents implements Events {
+    private hostname: string;
+    private transport: Transport;
+    private headers?: TwirpHeaders | HeadersProvider;
+    private interceptors = new InterceptorChain();
+    private pathPrefix: string;
+    private timeoutMs?: number;
+
+    constructor(config: TwirpClientConfig);
+    constructor(hostname: string, transport: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string);
+    constructor(hostname: string | TwirpClientConfig, transport?: Transport, headers?: TwirpHeaders | HeadersProvider, prefix?: string) {
+        const config = clientConfig(hostname, transport, headers, prefix);
+        this.hostname = config.hostname;
+        this.transport = config.transport;
+        this.headers = config.headers;
+        this.pathPrefix = (config.prefix !== undefined ? config.prefix : "/twirp") + "/wkt.Events/";
+        this.timeoutMs = config.timeoutMs;
+        (config.interceptors || []).forEach((interceptor) => this.use(interceptor));
+    }
+
+    use(interceptor: Interceptor): this {
+        this.interceptors.use(interceptor);
+        return this;
+    }
+
+    // retry retries calls that fail with a transient error, see RetryPolicy
+    retry(policy: RetryPolicy): this {
+        return this.use(retryInterceptor(policy));
+    }
+
+    // instrument calls the hooks around every call, e.g. to record metrics, see InstrumentationHooks
+    instrument(hooks: InstrumentationHooks): this {
+        return this.use(instrumentationInterceptor(hooks));
+    }
+
+    // debug logs every call with its latency, request and response, without the values of their sensitive fields
+    debug(log?: DebugLogger): this {
+        return this.use(debugInterceptor(log));
+    }
+
+    // idempotencyKey sends an Idempotency-Key header with the calls of the methods that are safe to retry, see
+    // idempotencyKeyInterceptor
+    idempotencyKey(generate?: (ctx: InterceptorContext) => string): this {
+        return this.use(idempotencyKeyInterceptor(generate));
+    }
+
+    // timeout sets the timeoutMs of every call, unless it is set by the CallOptions of the call
+    timeout(ms: number): this {
+        this.timeoutMs = ms;
+        return this;
+    }
+
+    record(event: Event, callOptions?: CallOptions): Promise<void> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Record");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "wkt.Events",
+                method: "Record",
+                url: url,
+                request: event,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, EventToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then(() => undefined);
+                });
+            });
+        }));
+    }
+
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event> {
+        const url = joinURL(this.hostname, this.pathPrefix + "Update");
+        return resolveCallOptions(this.headers, callOptions, this.timeoutMs).then((options) => withDeadline(options, (options) => {
+            const ctx: InterceptorContext = {
+                service: "wkt.Events",
+                method: "Update",
+                url: url,
+                request: updateEventRequest,
+                headers: options.headers || {},
+                signal: options.signal,
+            };
+
+            return this.interceptors.run(ctx, (ctx) => {
+                return this.transport(createTwirpProtobufRequest(ctx.url, UpdateEventRequestToProtobuf(ctx.request), ctx)).then((resp) => {
+                    reportResponse(options, resp);
+                    if (!resp.ok) {
+                        return throwTwirpError(resp);
+                    }
+
+                    return resp.arrayBuffer().then((buf) => ProtobufToEvent(new Uint8Array(buf)));
+                });
+            });
+        }));
+    }
+}
+
+// createEventsClient creates a DefaultEvents with the config, which may be shared by the clients of other services.
+export const createEventsClient = (config: TwirpClientConfig): DefaultEvents => {
+    return new DefaultEvents(config);
+};
+
+// A EventsMockResponses sets the response of each EventsMockClient method, either as a canned
+// response or a handler that is called with the request.
+export interface EventsMockResponses {
+    record?: ((event: Event, callOptions?: CallOptions) => void | Promise<void>);
+    update?: Event | ((updateEventRequest: UpdateEventRequest, callOptions?: CallOptions) => Event | Promise<Event>);
+}
+
+// EventsMockClient is a Events for tests, which returns the configured responses instead of calling a Twirp server.
+// Methods without a response reject with an unimplemented TwirpError.
+export class EventsMockClient implements Events {
+    responses: EventsMockResponses;
+
+    constructor(responses: EventsMockResponses = {}) {
+        this.responses = responses;
+    }
+
+    record(event: Event, callOptions?: CallOptions): Promise<void> {
+        const response = this.responses.record;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Events.Record"}));
+        }
+
+        return new Promise<void>((resolve) => resolve(typeof response === "function" ? response(event, callOptions) : response));
+    }
+
+    update(updateEventRequest: UpdateEventRequest, callOptions?: CallOptions): Promise<Event> {
+        const response = this.responses.update;
+        if (response === undefined) {
+            return Promise.reject(new TwirpError({code: TwirpErrorCode.Unimplemented, msg: "no mock response for Events.Update"}));
+        }
+
+        return new Promise<Event>((resolve) => resolve(typeof response === "function" ? response(updateEventRequest, callOptions) : response));
+    }
+}
+
+export const createEventsMock = (overrides: EventsMockResponses = {}): EventsMockClient => {
+    return new EventsMockClient(overrides);
+};
@@ -0,0 +1,72 @@
+import {durationToString, durationFromString, timestampToProtobufJs, timestampFromProtobufJs, durationFromProtobufJs, valueToProtobufJs, valueFromProtobufJs, structToProtobufJs, structFromProtobufJs} from './twirp';
+import {Event, Venue, UpdateEventRequest} from './wkt';
+
+// EventToProtobufJs converts the Event model to an object that the fromObject function of its protobuf.js message accepts
+export const EventToProtobufJs = (m: Event): {[key: string]: any} => {
+    return {
+        createdOn: timestampToProtobufJs(m.createdOn),
+        updates: m.updates.map((v) => timestampToProtobufJs(v)),
+        ttl: durationFromString(m.ttl),
+        intervals: m.intervals.map((v) => durationFromString(v)),
+        note: m.note === null ? undefined : {value: m.note},
+        count: m.count === null ? undefined : {value: m.count},
+        checks: m.checks.map((v) => v === null ? undefined : {value: v}),
+        metadata: structToProtobufJs(m.metadata),
+        extra: valueToProtobufJs(m.extra),
+        detail: m.detail,
+        mask: {paths: m.mask},
+        venue: m.venue === undefined ? undefined : VenueToProtobufJs(m.venue),
+    };
+};
+
+// ProtobufJsToEvent converts a protobuf.js Event message, e.g. from its decode function, to the Event model
+export const ProtobufJsToEvent = (m: {[key: string]: any}): Event => {
+    return {
+        createdOn: m.createdOn ? timestampFromProtobufJs(m.createdOn) : undefined,
+        updates: (m.updates || []).map((v) => timestampFromProtobufJs(v)),
+        ttl: durationToString(durationFromProtobufJs(m.ttl || {})),
+        intervals: (m.intervals || []).map((v) => durationToString(durationFromProtobufJs(v || {}))),
+        note: m.note ? m.note.value : null,
+        count: m.count ? Number(String(m.count.value)) : null,
+        checks: (m.checks || []).map((v) => v ? v.value : null),
+        metadata: structFromProtobufJs(m.metadata),
+        extra: valueFromProtobufJs(m.extra),
+        detail: m.detail,
+        mask: m.mask ? m.mask.paths || [] : [],
+        venue: m.venue ? ProtobufJsToVenue(m.venue) : undefined,
+    } as Event;
+};
+
+// VenueToProtobufJs converts the Venue model to an object that the fromObject function of its protobuf.js message accepts
+export const VenueToProtobufJs = (m: Venue): {[key: string]: any} => {
+    return {
+        name: m.name,
+        parent: m.parent === undefined ? undefined : VenueToProtobufJs(m.parent),
+        rooms: m.rooms.map((v) => VenueToProtobufJs(v)),
+    };
+};
+
+// ProtobufJsToVenue converts a protobuf.js Venue message, e.g. from its decode function, to the Venue model
+export const ProtobufJsToVenue = (m: {[key: string]: any}): Venue => {
+    return {
+        name: m.name,
+        parent: m.parent === null || m.parent === undefined ? undefined : ProtobufJsToVenue(m.parent),
+        rooms: (m.rooms || []).map((v) => ProtobufJsToVenue(v)),
+    } as Venue;
+};
+
+// UpdateEventRequestToProtobufJs converts the UpdateEventRequest model to an object that the fromObject function of its protobuf.js message accepts
+export const UpdateEventRequestToProtobufJs = (m: UpdateEventRequest): {[key: string]: any} => {
+    return {
+        event: m.event === undefined ? undefined : EventToProtobufJs(m.event),
+        updateMask: {paths: m.updateMask},
+    };
+};
+
+// ProtobufJsToUpdateEventRequest converts a protobuf.js UpdateEventRequest message, e.g. from its decode function, to the UpdateEventRequest model
+export const ProtobufJsToUpdateEventRequest = (m: {[key: string]: any}): UpdateEventRequest => {
+    return {
+        event: m.event ? ProtobufJsToEvent(m.event) : undefined,
+        updateMask: m.updateMask ? m.updateMask.paths || [] : [],
+    } as UpdateEventRequest;
+};
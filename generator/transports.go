@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"github.com/golang/protobuf/proto"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+// TransportLibrary is the runtime module of Transport adapters, which send the requests of generated
+// clients with window.fetch, node-fetch, XMLHttpRequest, or axios. With TargetNode, it also has nodeTransport,
+// which sends requests with the fetch of Node or the http and https modules.
+func TransportLibrary(target string) *plugin.CodeGeneratorResponse_File {
+	imports := `
+import {Fetch, ResponseHeaders, Transport, TransportRequest, TransportResponse} from './twirp';
+`
+	if target == TargetNode {
+		imports = `
+import * as http from 'http';
+import * as https from 'https';
+import {Fetch, ResponseHeaders, Transport, TransportRequest, TransportResponse} from './twirp';
+`
+	}
+
+	tmpl := imports + `
+// bufferHeaders are the ResponseHeaders of the headers of a response that were read into an object.
+const bufferHeaders = (headers: {[key: string]: string | string[] | undefined}): ResponseHeaders => {
+    const lower: {[key: string]: string} = {};
+
+    Object.keys(headers).forEach((k) => {
+        const v = headers[k];
+        if (v !== undefined) {
+            lower[k.toLowerCase()] = Array.isArray(v) ? v.join(", ") : String(v);
+        }
+    });
+
+    return {get: (name) => lower.hasOwnProperty(name.toLowerCase()) ? lower[name.toLowerCase()] : null};
+};
+
+// bufferResponse is the TransportResponse of a request that was read into a buffer.
+const bufferResponse = (status: number, buf: ArrayBuffer, headers: {[key: string]: string | string[] | undefined} = {}): TransportResponse => {
+    return {
+        ok: status >= 200 && status < 300,
+        status: status,
+        headers: bufferHeaders(headers),
+        text: () => Promise.resolve(new TextDecoder().decode(buf)),
+        arrayBuffer: () => Promise.resolve(buf),
+    };
+};
+
+// requestBody is the body of a request, which is not sent for a REST request without a body, e.g. a GET request.
+const requestBody = (req: TransportRequest): string | Uint8Array | undefined => {
+    return req.body === "" ? undefined : req.body;
+};
+
+// xhrHeaders parses the headers of an XMLHttpRequest response, which are lines of "name: value".
+const xhrHeaders = (xhr: XMLHttpRequest): {[key: string]: string} => {
+    const headers: {[key: string]: string} = {};
+
+    xhr.getAllResponseHeaders().split("\r\n").forEach((line) => {
+        const i = line.indexOf(":");
+        if (i > 0) {
+            headers[line.substring(0, i).trim()] = line.substring(i + 1).trim();
+        }
+    });
+
+    return headers;
+};
+
+// fetchTransport sends requests with a fetch implementation, e.g. window.fetch.bind(window) or isomorphic-fetch.
+export const fetchTransport = (fetch: Fetch): Transport => {
+    return (req) => fetch(req.url, {
+        method: req.method || "POST",
+        headers: req.headers,
+        body: requestBody(req),
+        signal: req.signal,
+    });
+};
+
+// NodeFetch is the fetch function of node-fetch, whose typings differ from the DOM fetch.
+export type NodeFetch = (url: string, init?: any) => Promise<any>;
+
+// nodeFetchTransport sends requests with node-fetch, e.g. nodeFetchTransport(require("node-fetch")).
+export const nodeFetchTransport = (fetch: NodeFetch): Transport => {
+    return (req) => fetch(req.url, {
+        method: req.method || "POST",
+        headers: req.headers,
+        body: requestBody(req),
+        signal: req.signal,
+    });
+};
+
+export interface XHRTransportOptions {
+    // onUploadProgress is called as the request body is sent, e.g. to show the progress of a large upload
+    onUploadProgress?: (loaded: number, total: number) => void;
+    // withCredentials sends cookies with cross origin requests
+    withCredentials?: boolean;
+}
+
+// xhrTransport sends requests with XMLHttpRequest, for browsers without fetch or to report upload progress.
+export const xhrTransport = (options: XHRTransportOptions = {}): Transport => {
+    return (req) => new Promise<TransportResponse>((resolve, reject) => {
+        const xhr = new XMLHttpRequest();
+        xhr.open(req.method || "POST", req.url);
+        xhr.responseType = "arraybuffer";
+        xhr.withCredentials = !!options.withCredentials;
+
+        Object.keys(req.headers).forEach((k) => xhr.setRequestHeader(k, req.headers[k]));
+
+        if (options.onUploadProgress) {
+            const onUploadProgress = options.onUploadProgress;
+            xhr.upload.onprogress = (e) => onUploadProgress(e.loaded, e.total);
+        }
+
+        xhr.onload = () => resolve(bufferResponse(xhr.status, xhr.response, xhrHeaders(xhr)));
+        xhr.onerror = () => reject(new TypeError("Network request failed"));
+        xhr.onabort = () => reject(new DOMException("Aborted", "AbortError"));
+
+        if (req.signal) {
+            if (req.signal.aborted) {
+                return reject(new DOMException("Aborted", "AbortError"));
+            }
+
+            req.signal.addEventListener("abort", () => xhr.abort());
+        }
+
+        xhr.send(requestBody(req) || null);
+    });
+};
+
+// Axios is the subset of an axios instance used by axiosTransport.
+export interface Axios {
+    request(config: any): Promise<{status: number; data: any; headers?: any}>;
+}
+
+// axiosTransport sends requests with axios, e.g. axiosTransport(axios.create({timeout: 5000})).
+export const axiosTransport = (axios: Axios): Transport => {
+    return (req) => axios.request({
+        url: req.url,
+        method: req.method || "POST",
+        headers: req.headers,
+        data: requestBody(req),
+        signal: req.signal,
+        responseType: "arraybuffer",
+        // Twirp errors are read from the response, rather than rejected by axios
+        validateStatus: () => true,
+    }).then((resp) => {
+        // axios reads an arraybuffer response into a Buffer in node
+        const data = resp.data instanceof ArrayBuffer ? resp.data : new Uint8Array(resp.data).slice().buffer;
+        return bufferResponse(resp.status, data, resp.headers || {});
+    });
+};
+`
+	if target == TargetNode {
+		tmpl += nodeTransportRuntime
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String("transports.ts")
+	cf.Content = proto.String(tmpl)
+
+	return cf
+}
+
+// nodeTransportRuntime is the nodeTransport adapter, which imports the Node http and https modules.
+const nodeTransportRuntime = `
+export interface NodeTransportOptions {
+    // dispatcher is the undici Dispatcher of requests sent with fetch, e.g. new ProxyAgent("http://proxy:3128")
+    // or new Agent({keepAliveTimeout: 30000}) from undici
+    dispatcher?: object;
+    // agent is the agent of requests sent with the http and https modules, e.g. a proxy agent such as
+    // new HttpsProxyAgent("http://proxy:3128"), instead of the default agents that keep connections alive
+    agent?: http.Agent;
+    // keepAlive keeps the connections of the default agents alive between requests, and is true by default
+    keepAlive?: boolean;
+}
+
+const httpTransport = (options: NodeTransportOptions): Transport => {
+    const keepAlive = options.keepAlive !== false;
+    const httpAgent = new http.Agent({keepAlive: keepAlive});
+    const httpsAgent = new https.Agent({keepAlive: keepAlive});
+
+    return (req: TransportRequest) => new Promise<TransportResponse>((resolve, reject) => {
+        const url = new URL(req.url);
+        const secure = url.protocol === "https:";
+        const body = typeof req.body === "string" ? Buffer.from(req.body) : Buffer.from(req.body.buffer, req.body.byteOffset, req.body.byteLength);
+
+        const headers: {[key: string]: string} = {"Content-Length": String(body.length)};
+        Object.keys(req.headers).forEach((k) => { headers[k] = req.headers[k]; });
+
+        const send = secure ? https.request : http.request;
+        const r = send(url, {
+            method: req.method || "POST",
+            headers: headers,
+            agent: options.agent || (secure ? httpsAgent : httpAgent),
+            signal: req.signal,
+        }, (resp) => {
+            const chunks: Buffer[] = [];
+
+            resp.on("data", (chunk: Buffer) => chunks.push(chunk));
+            resp.on("error", reject);
+            resp.on("end", () => {
+                const data = Buffer.concat(chunks);
+                resolve(bufferResponse(resp.statusCode || 0, data.buffer.slice(data.byteOffset, data.byteOffset + data.byteLength), resp.headers));
+            });
+        });
+
+        r.on("error", reject);
+        r.end(body);
+    });
+};
+
+// nodeTransport sends requests from Node, without a fetch polyfill. Requests are sent with the global fetch of
+// Node 18 or later, which is implemented by undici, or with the http and https modules when fetch is not available
+// or an agent is set.
+export const nodeTransport = (options: NodeTransportOptions = {}): Transport => {
+    if (options.agent || typeof fetch !== "function") {
+        return httpTransport(options);
+    }
+
+    return (req) => fetch(req.url, {
+        method: req.method || "POST",
+        headers: req.headers,
+        body: requestBody(req),
+        signal: req.signal,
+        // undici reads the dispatcher of the request, which is not part of the DOM RequestInit
+        dispatcher: options.dispatcher,
+    } as RequestInit);
+};
+`
@@ -6,21 +6,55 @@ import (
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 )
 
-func CreateTSConfig() *plugin.CodeGeneratorResponse_File {
+// tsModules maps the module option to the module compiler option of typescript.
+var tsModules = map[string]string{
+	ModuleCommonJS: "commonjs",
+	ModuleES6:      "es2015",
+	ModuleUMD:      "umd",
+}
+
+// CreateTSConfig generates the tsconfig.json of the generated package. The Angular services are compiled with
+// the decorators that Angular uses for dependency injection, and Node clients with the typings of Node.
+func CreateTSConfig(opts Options) *plugin.CodeGeneratorResponse_File {
+	var extra string
+	lib := `"dom", "es2015"`
+	if opts.Subscriptions != SubscriptionsNone || opts.Pagination {
+		// the subscriptions and the pages of the list methods are async iterators
+		lib += `, "es2018.asynciterable"`
+	}
+
+	if opts.Int64 == Int64BigInt {
+		// the 64 bit integers are bigints
+		lib += `, "es2020.bigint"`
+	}
+
+	if opts.Angular {
+		extra += `,
+    "experimentalDecorators": true,
+    "emitDecoratorMetadata": true`
+	}
+
+	if opts.Target == TargetNode {
+		extra += `,
+    "types": ["node"]`
+	}
+
 	content := fmt.Sprintf(`{
   "compilerOptions": {
     "target": "es5",
-    "module": "commonjs",
+    "lib": [%s],
+    "module": "%s",
+    "moduleResolution": "node",
     "declaration": true,
     "importHelpers": true,
     "strict": true,
     "noUnusedParameters": true,
     "noImplicitReturns": true,
     "noFallthroughCasesInSwitch": true,
-    "esModuleInterop": true
+    "esModuleInterop": true%s
   }
 }
-`)
+`, lib, tsModules[opts.Module], extra)
 
 	fileName := "tsconfig.json"
 	cf := &plugin.CodeGeneratorResponse_File{}
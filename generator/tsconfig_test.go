@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCreateTSConfig(t *testing.T) {
+	tests := []struct {
+		int64Type string
+		bigint    bool
+	}{
+		{Int64Number, false},
+		{Int64String, false},
+		{Int64BigInt, true},
+	}
+
+	for _, tt := range tests {
+		opts := DefaultOptions()
+		opts.Int64 = tt.int64Type
+
+		cf := CreateTSConfig(opts)
+
+		// the bigints of int64=bigint are declared by the es2020.bigint lib
+		if actual := strings.Contains(cf.GetContent(), `"es2020.bigint"`); actual != tt.bigint {
+			t.Errorf("%s: expected the es2020.bigint lib to be %v, got:\n%s", tt.int64Type, tt.bigint, cf.GetContent())
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// typeRef locates the generated typescript definition of a proto message or enum.
+type typeRef struct {
+	Name   string
+	Module string
+	pkg    string
+	// entry is the descriptor of a map entry, which is not generated as a typescript type
+	entry *descriptor.DescriptorProto
+}
+
+// typeRegistry maps fully qualified proto type names (e.g. .my.pkg.Hat) to their typescript definitions
+// across all of the files in a CodeGeneratorRequest.
+type typeRegistry map[string]typeRef
+
+// reservedNames are imported by every generated module, or are the typescript types of Google WKTs,
+// so messages and enums with these names are renamed as if they collide with another type.
+var reservedNames = map[string]bool{
+	"Any":                true,
+	"CallOptions":        true,
+	"Date":               true,
+	"Duration":           true,
+	"Fetch":              true,
+	"HeadersProvider":    true,
+	"Interceptor":        true,
+	"InterceptorChain":   true,
+	"InterceptorContext": true,
+	"ProtobufReader":     true,
+	"ProtobufWriter":     true,
+	"ServerRequest":      true,
+	"TwirpError":         true,
+	"TwirpErrorCode":     true,
+	"TwirpHeaders":       true,
+	"Transport":          true,
+	"TwirpRouter":        true,
+}
+
+// primitiveTypes are the names of the typescript primitive types, which are not valid names of interfaces and enums,
+// so messages and enums with these names or with the names of reservedWords are renamed as if they collide with
+// another type.
+var primitiveTypes = map[string]bool{
+	"any": true, "bigint": true, "boolean": true, "never": true, "number": true, "object": true, "string": true,
+	"symbol": true, "undefined": true, "unknown": true,
+}
+
+// newTypeRegistry registers the messages and enums of all files, including nested types, which are named
+// after their parent messages joined by the separator of opts.NestedNames, e.g. Outer.Inner => OuterInner
+func newTypeRegistry(files []*descriptor.FileDescriptorProto, opts Options) (typeRegistry, error) {
+	types := make(typeRegistry)
+	sep := nestedSeparators[opts.NestedNames]
+
+	for _, f := range files {
+		pkg := f.GetPackage()
+		module := tsModuleName(f, opts)
+
+		for _, e := range f.GetEnumType() {
+			name := fqName(pkg, e.GetName())
+			types[name] = typeRef{Name: nestedTypeName(name, pkg, sep), Module: module, pkg: pkg}
+		}
+
+		for _, m := range f.GetMessageType() {
+			name := fqName(pkg, m.GetName())
+			types[name] = typeRef{Name: m.GetName(), Module: module, pkg: pkg}
+			types.addNested(m, name, pkg, module, sep)
+		}
+	}
+
+	return types, types.resolveCollisions()
+}
+
+func (types typeRegistry) addNested(m *descriptor.DescriptorProto, parent string, pkg string, module string, sep string) {
+	for _, e := range m.GetEnumType() {
+		name := parent + "." + e.GetName()
+		types[name] = typeRef{Name: nestedTypeName(name, pkg, sep), Module: module, pkg: pkg}
+	}
+
+	for _, n := range m.GetNestedType() {
+		name := parent + "." + n.GetName()
+
+		if n.GetOptions().GetMapEntry() {
+			types[name] = typeRef{Module: module, pkg: pkg, entry: n}
+			continue
+		}
+
+		types[name] = typeRef{Name: nestedTypeName(name, pkg, sep), Module: module, pkg: pkg}
+		types.addNested(n, name, pkg, module, sep)
+	}
+}
+
+// resolveCollisions renames types with the same name from different packages, since they may be
+// used by the same module, or exported by the same package index. Colliding names are prefixed
+// with their package, e.g. .a.v1.Page and .b.Page => AV1Page and BPage
+//
+// Types in the same package can still collide, e.g. a message named OuterInner and the nested message
+// Outer.Inner, which is an error since the generated code would not compile.
+func (types typeRegistry) resolveCollisions() error {
+	byName := make(map[string][]string)
+	for fq, ref := range types {
+		if ref.entry != nil {
+			continue
+		}
+		byName[ref.Name] = append(byName[ref.Name], fq)
+	}
+
+	var names []string
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		fqs := byName[name]
+		keyword := reservedWords[name] || primitiveTypes[name]
+		if len(fqs) < 2 && !reservedNames[name] && !keyword {
+			continue
+		}
+
+		sort.Strings(fqs)
+
+		pkgs := make(map[string]string)
+		for _, fq := range fqs {
+			ref := types[fq]
+			if other, ok := pkgs[ref.pkg]; ok {
+				return fmt.Errorf("%s and %s are both generated as %s, rename one of them or set the nested_names parameter", other, fq, name)
+			}
+			pkgs[ref.pkg] = fq
+
+			ref.Name = packagePrefix(ref.pkg) + ref.Name
+			if ref.Name == name && keyword {
+				// a type without a package is not prefixed
+				ref.Name += "_"
+			}
+
+			types[fq] = ref
+		}
+	}
+
+	return nil
+}
+
+// name returns the typescript name for a fully qualified proto type name,
+// falling back to the unqualified proto name for types that are not registered.
+func (types typeRegistry) name(typeName string) string {
+	if ref, ok := types[typeName]; ok {
+		return ref.Name
+	}
+
+	return removePkg(typeName)
+}
+
+// mapEntry returns the descriptor of a map entry type, or nil if the type is not a map entry.
+func (types typeRegistry) mapEntry(typeName string) *descriptor.DescriptorProto {
+	return types[typeName].entry
+}
+
+// fqName is the fully qualified name of a top level type in a package, e.g. .my.pkg.Hat
+func fqName(pkg string, name string) string {
+	if pkg == "" {
+		return "." + name
+	}
+
+	return "." + pkg + "." + name
+}
+
+// packagePrefix converts a proto package to a prefix for typescript names, e.g. my.pkg_name.v1 => MyPkgNameV1
+func packagePrefix(pkg string) string {
+	var prefix string
+
+	for _, p := range strings.Split(pkg, ".") {
+		if p == "" {
+			continue
+		}
+
+		p = camelCase(p)
+		prefix += strings.ToUpper(p[0:1]) + p[1:]
+	}
+
+	return prefix
+}
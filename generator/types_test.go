@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewTypeRegistry_Collisions(t *testing.T) {
+	files := []*descriptor.FileDescriptorProto{
+		{
+			Name:    proto.String("a.proto"),
+			Package: proto.String("a.v1"),
+			MessageType: []*descriptor.DescriptorProto{
+				{Name: proto.String("Page")},
+				{Name: proto.String("Hat")},
+				{Name: proto.String("Duration")},
+			},
+		},
+		{
+			Name:    proto.String("b.proto"),
+			Package: proto.String("b"),
+			MessageType: []*descriptor.DescriptorProto{
+				{Name: proto.String("Page")},
+			},
+		},
+	}
+
+	types, err := newTypeRegistry(files, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		".a.v1.Page":     "AV1Page",
+		".b.Page":        "BPage",
+		".a.v1.Hat":      "Hat",
+		".a.v1.Duration": "AV1Duration",
+	}
+
+	for typeName, expected := range tests {
+		if actual := types.name(typeName); actual != expected {
+			t.Errorf("expected %s to be named %s, got %s", typeName, expected, actual)
+		}
+	}
+}
+
+func TestNewTypeRegistry_NestedCollision(t *testing.T) {
+	files := []*descriptor.FileDescriptorProto{{
+		Name:    proto.String("a.proto"),
+		Package: proto.String("a"),
+		MessageType: []*descriptor.DescriptorProto{
+			{Name: proto.String("Outer"), NestedType: []*descriptor.DescriptorProto{{Name: proto.String("Inner")}}},
+			{Name: proto.String("OuterInner")},
+		},
+	}}
+
+	_, err := newTypeRegistry(files, DefaultOptions())
+	expected := ".a.Outer.Inner and .a.OuterInner are both generated as OuterInner, rename one of them or set the nested_names parameter"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error %q, got %v", expected, err)
+	}
+
+	opts := DefaultOptions()
+	opts.NestedNames = NestedNamesUnderscore
+
+	types, err := newTypeRegistry(files, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := types.name(".a.Outer.Inner"); actual != "Outer_Inner" {
+		t.Errorf("expected Outer_Inner, got %s", actual)
+	}
+}
+
+func TestNewTypeRegistry_Keywords(t *testing.T) {
+	files := []*descriptor.FileDescriptorProto{
+		{
+			Name:        proto.String("a.proto"),
+			Package:     proto.String("a"),
+			MessageType: []*descriptor.DescriptorProto{{Name: proto.String("function")}, {Name: proto.String("Delete")}},
+			EnumType:    []*descriptor.EnumDescriptorProto{{Name: proto.String("string")}},
+		},
+		{
+			Name:        proto.String("b.proto"),
+			MessageType: []*descriptor.DescriptorProto{{Name: proto.String("new")}},
+		},
+	}
+
+	types, err := newTypeRegistry(files, DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		".a.function": "Afunction",
+		".a.Delete":   "Delete",
+		".a.string":   "Astring",
+		".new":        "new_",
+	}
+
+	for typeName, expected := range tests {
+		if actual := types.name(typeName); actual != expected {
+			t.Errorf("expected %s to be named %s, got %s", typeName, expected, actual)
+		}
+	}
+}
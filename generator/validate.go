@@ -0,0 +1,912 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// The protoc-gen-validate (PGV) rules are declared by validate/validate.proto, whose Go package is not a
+// dependency of the generator. The rules are decoded into the subset of the PGV messages below, which have
+// the same field numbers, and the rules that are not supported are ignored.
+// See https://github.com/bufbuild/protoc-gen-validate/blob/main/validate/validate.proto
+
+var (
+	extFieldRules = &proto.ExtensionDesc{
+		ExtendedType:  (*descriptor.FieldOptions)(nil),
+		ExtensionType: (*fieldRules)(nil),
+		Field:         1071,
+		Name:          "validate.rules",
+		Tag:           "bytes,1071,opt,name=rules",
+	}
+	extMessageDisabled = &proto.ExtensionDesc{
+		ExtendedType:  (*descriptor.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         1071,
+		Name:          "validate.disabled",
+		Tag:           "varint,1071,opt,name=disabled",
+	}
+	extMessageIgnored = &proto.ExtensionDesc{
+		ExtendedType:  (*descriptor.MessageOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         1072,
+		Name:          "validate.ignored",
+		Tag:           "varint,1072,opt,name=ignored",
+	}
+	extOneofRequired = &proto.ExtensionDesc{
+		ExtendedType:  (*descriptor.OneofOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         1071,
+		Name:          "validate.required",
+		Tag:           "varint,1071,opt,name=required",
+	}
+)
+
+type fieldRules struct {
+	Float    *floatRules    `protobuf:"bytes,1,opt,name=float"`
+	Double   *doubleRules   `protobuf:"bytes,2,opt,name=double"`
+	Int32    *int32Rules    `protobuf:"bytes,3,opt,name=int32"`
+	Int64    *int64Rules    `protobuf:"bytes,4,opt,name=int64"`
+	Uint32   *uint32Rules   `protobuf:"bytes,5,opt,name=uint32"`
+	Uint64   *uint64Rules   `protobuf:"bytes,6,opt,name=uint64"`
+	Sint32   *sint32Rules   `protobuf:"bytes,7,opt,name=sint32"`
+	Sint64   *sint64Rules   `protobuf:"bytes,8,opt,name=sint64"`
+	Fixed32  *fixed32Rules  `protobuf:"bytes,9,opt,name=fixed32"`
+	Fixed64  *fixed64Rules  `protobuf:"bytes,10,opt,name=fixed64"`
+	Sfixed32 *sfixed32Rules `protobuf:"bytes,11,opt,name=sfixed32"`
+	Sfixed64 *sfixed64Rules `protobuf:"bytes,12,opt,name=sfixed64"`
+	Bool     *boolRules     `protobuf:"bytes,13,opt,name=bool"`
+	String_  *stringRules   `protobuf:"bytes,14,opt,name=string"`
+	Bytes    *bytesRules    `protobuf:"bytes,15,opt,name=bytes"`
+	Enum     *enumRules     `protobuf:"bytes,16,opt,name=enum"`
+	Message  *messageRules  `protobuf:"bytes,17,opt,name=message"`
+	Repeated *repeatedRules `protobuf:"bytes,18,opt,name=repeated"`
+	Map      *mapRules      `protobuf:"bytes,19,opt,name=map"`
+}
+
+type floatRules struct {
+	Const       *float32  `protobuf:"fixed32,1,opt,name=const"`
+	Lt          *float32  `protobuf:"fixed32,2,opt,name=lt"`
+	Lte         *float32  `protobuf:"fixed32,3,opt,name=lte"`
+	Gt          *float32  `protobuf:"fixed32,4,opt,name=gt"`
+	Gte         *float32  `protobuf:"fixed32,5,opt,name=gte"`
+	In          []float32 `protobuf:"fixed32,6,rep,name=in"`
+	NotIn       []float32 `protobuf:"fixed32,7,rep,name=not_in"`
+	IgnoreEmpty *bool     `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type doubleRules struct {
+	Const       *float64  `protobuf:"fixed64,1,opt,name=const"`
+	Lt          *float64  `protobuf:"fixed64,2,opt,name=lt"`
+	Lte         *float64  `protobuf:"fixed64,3,opt,name=lte"`
+	Gt          *float64  `protobuf:"fixed64,4,opt,name=gt"`
+	Gte         *float64  `protobuf:"fixed64,5,opt,name=gte"`
+	In          []float64 `protobuf:"fixed64,6,rep,name=in"`
+	NotIn       []float64 `protobuf:"fixed64,7,rep,name=not_in"`
+	IgnoreEmpty *bool     `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type int32Rules struct {
+	Const       *int32  `protobuf:"varint,1,opt,name=const"`
+	Lt          *int32  `protobuf:"varint,2,opt,name=lt"`
+	Lte         *int32  `protobuf:"varint,3,opt,name=lte"`
+	Gt          *int32  `protobuf:"varint,4,opt,name=gt"`
+	Gte         *int32  `protobuf:"varint,5,opt,name=gte"`
+	In          []int32 `protobuf:"varint,6,rep,name=in"`
+	NotIn       []int32 `protobuf:"varint,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type int64Rules struct {
+	Const       *int64  `protobuf:"varint,1,opt,name=const"`
+	Lt          *int64  `protobuf:"varint,2,opt,name=lt"`
+	Lte         *int64  `protobuf:"varint,3,opt,name=lte"`
+	Gt          *int64  `protobuf:"varint,4,opt,name=gt"`
+	Gte         *int64  `protobuf:"varint,5,opt,name=gte"`
+	In          []int64 `protobuf:"varint,6,rep,name=in"`
+	NotIn       []int64 `protobuf:"varint,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type uint32Rules struct {
+	Const       *uint32  `protobuf:"varint,1,opt,name=const"`
+	Lt          *uint32  `protobuf:"varint,2,opt,name=lt"`
+	Lte         *uint32  `protobuf:"varint,3,opt,name=lte"`
+	Gt          *uint32  `protobuf:"varint,4,opt,name=gt"`
+	Gte         *uint32  `protobuf:"varint,5,opt,name=gte"`
+	In          []uint32 `protobuf:"varint,6,rep,name=in"`
+	NotIn       []uint32 `protobuf:"varint,7,rep,name=not_in"`
+	IgnoreEmpty *bool    `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type uint64Rules struct {
+	Const       *uint64  `protobuf:"varint,1,opt,name=const"`
+	Lt          *uint64  `protobuf:"varint,2,opt,name=lt"`
+	Lte         *uint64  `protobuf:"varint,3,opt,name=lte"`
+	Gt          *uint64  `protobuf:"varint,4,opt,name=gt"`
+	Gte         *uint64  `protobuf:"varint,5,opt,name=gte"`
+	In          []uint64 `protobuf:"varint,6,rep,name=in"`
+	NotIn       []uint64 `protobuf:"varint,7,rep,name=not_in"`
+	IgnoreEmpty *bool    `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type sint32Rules struct {
+	Const       *int32  `protobuf:"zigzag32,1,opt,name=const"`
+	Lt          *int32  `protobuf:"zigzag32,2,opt,name=lt"`
+	Lte         *int32  `protobuf:"zigzag32,3,opt,name=lte"`
+	Gt          *int32  `protobuf:"zigzag32,4,opt,name=gt"`
+	Gte         *int32  `protobuf:"zigzag32,5,opt,name=gte"`
+	In          []int32 `protobuf:"zigzag32,6,rep,name=in"`
+	NotIn       []int32 `protobuf:"zigzag32,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type sint64Rules struct {
+	Const       *int64  `protobuf:"zigzag64,1,opt,name=const"`
+	Lt          *int64  `protobuf:"zigzag64,2,opt,name=lt"`
+	Lte         *int64  `protobuf:"zigzag64,3,opt,name=lte"`
+	Gt          *int64  `protobuf:"zigzag64,4,opt,name=gt"`
+	Gte         *int64  `protobuf:"zigzag64,5,opt,name=gte"`
+	In          []int64 `protobuf:"zigzag64,6,rep,name=in"`
+	NotIn       []int64 `protobuf:"zigzag64,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type fixed32Rules struct {
+	Const       *uint32  `protobuf:"fixed32,1,opt,name=const"`
+	Lt          *uint32  `protobuf:"fixed32,2,opt,name=lt"`
+	Lte         *uint32  `protobuf:"fixed32,3,opt,name=lte"`
+	Gt          *uint32  `protobuf:"fixed32,4,opt,name=gt"`
+	Gte         *uint32  `protobuf:"fixed32,5,opt,name=gte"`
+	In          []uint32 `protobuf:"fixed32,6,rep,name=in"`
+	NotIn       []uint32 `protobuf:"fixed32,7,rep,name=not_in"`
+	IgnoreEmpty *bool    `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type fixed64Rules struct {
+	Const       *uint64  `protobuf:"fixed64,1,opt,name=const"`
+	Lt          *uint64  `protobuf:"fixed64,2,opt,name=lt"`
+	Lte         *uint64  `protobuf:"fixed64,3,opt,name=lte"`
+	Gt          *uint64  `protobuf:"fixed64,4,opt,name=gt"`
+	Gte         *uint64  `protobuf:"fixed64,5,opt,name=gte"`
+	In          []uint64 `protobuf:"fixed64,6,rep,name=in"`
+	NotIn       []uint64 `protobuf:"fixed64,7,rep,name=not_in"`
+	IgnoreEmpty *bool    `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type sfixed32Rules struct {
+	Const       *int32  `protobuf:"fixed32,1,opt,name=const"`
+	Lt          *int32  `protobuf:"fixed32,2,opt,name=lt"`
+	Lte         *int32  `protobuf:"fixed32,3,opt,name=lte"`
+	Gt          *int32  `protobuf:"fixed32,4,opt,name=gt"`
+	Gte         *int32  `protobuf:"fixed32,5,opt,name=gte"`
+	In          []int32 `protobuf:"fixed32,6,rep,name=in"`
+	NotIn       []int32 `protobuf:"fixed32,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type sfixed64Rules struct {
+	Const       *int64  `protobuf:"fixed64,1,opt,name=const"`
+	Lt          *int64  `protobuf:"fixed64,2,opt,name=lt"`
+	Lte         *int64  `protobuf:"fixed64,3,opt,name=lte"`
+	Gt          *int64  `protobuf:"fixed64,4,opt,name=gt"`
+	Gte         *int64  `protobuf:"fixed64,5,opt,name=gte"`
+	In          []int64 `protobuf:"fixed64,6,rep,name=in"`
+	NotIn       []int64 `protobuf:"fixed64,7,rep,name=not_in"`
+	IgnoreEmpty *bool   `protobuf:"varint,8,opt,name=ignore_empty"`
+}
+
+type boolRules struct {
+	Const *bool `protobuf:"varint,1,opt,name=const"`
+}
+
+type stringRules struct {
+	Const       *string  `protobuf:"bytes,1,opt,name=const"`
+	Len         *uint64  `protobuf:"varint,19,opt,name=len"`
+	MinLen      *uint64  `protobuf:"varint,2,opt,name=min_len"`
+	MaxLen      *uint64  `protobuf:"varint,3,opt,name=max_len"`
+	LenBytes    *uint64  `protobuf:"varint,20,opt,name=len_bytes"`
+	MinBytes    *uint64  `protobuf:"varint,4,opt,name=min_bytes"`
+	MaxBytes    *uint64  `protobuf:"varint,5,opt,name=max_bytes"`
+	Pattern     *string  `protobuf:"bytes,6,opt,name=pattern"`
+	Prefix      *string  `protobuf:"bytes,7,opt,name=prefix"`
+	Suffix      *string  `protobuf:"bytes,8,opt,name=suffix"`
+	Contains    *string  `protobuf:"bytes,9,opt,name=contains"`
+	NotContains *string  `protobuf:"bytes,23,opt,name=not_contains"`
+	In          []string `protobuf:"bytes,10,rep,name=in"`
+	NotIn       []string `protobuf:"bytes,11,rep,name=not_in"`
+	Email       *bool    `protobuf:"varint,12,opt,name=email"`
+	Hostname    *bool    `protobuf:"varint,13,opt,name=hostname"`
+	URI         *bool    `protobuf:"varint,17,opt,name=uri"`
+	UUID        *bool    `protobuf:"varint,22,opt,name=uuid"`
+	IgnoreEmpty *bool    `protobuf:"varint,26,opt,name=ignore_empty"`
+}
+
+type bytesRules struct {
+	Len         *uint64 `protobuf:"varint,13,opt,name=len"`
+	MinLen      *uint64 `protobuf:"varint,2,opt,name=min_len"`
+	MaxLen      *uint64 `protobuf:"varint,3,opt,name=max_len"`
+	IgnoreEmpty *bool   `protobuf:"varint,14,opt,name=ignore_empty"`
+}
+
+type enumRules struct {
+	Const       *int32  `protobuf:"varint,1,opt,name=const"`
+	DefinedOnly *bool   `protobuf:"varint,2,opt,name=defined_only"`
+	In          []int32 `protobuf:"varint,3,rep,name=in"`
+	NotIn       []int32 `protobuf:"varint,4,rep,name=not_in"`
+}
+
+type messageRules struct {
+	Skip     *bool `protobuf:"varint,1,opt,name=skip"`
+	Required *bool `protobuf:"varint,2,opt,name=required"`
+}
+
+type repeatedRules struct {
+	MinItems    *uint64     `protobuf:"varint,1,opt,name=min_items"`
+	MaxItems    *uint64     `protobuf:"varint,2,opt,name=max_items"`
+	Unique      *bool       `protobuf:"varint,3,opt,name=unique"`
+	Items       *fieldRules `protobuf:"bytes,4,opt,name=items"`
+	IgnoreEmpty *bool       `protobuf:"varint,5,opt,name=ignore_empty"`
+}
+
+type mapRules struct {
+	MinPairs    *uint64 `protobuf:"varint,1,opt,name=min_pairs"`
+	MaxPairs    *uint64 `protobuf:"varint,2,opt,name=max_pairs"`
+	IgnoreEmpty *bool   `protobuf:"varint,6,opt,name=ignore_empty"`
+}
+
+func (m *fieldRules) Reset()         { *m = fieldRules{} }
+func (m *fieldRules) String() string { return proto.CompactTextString(m) }
+func (*fieldRules) ProtoMessage()    {}
+
+func (m *floatRules) Reset()         { *m = floatRules{} }
+func (m *floatRules) String() string { return proto.CompactTextString(m) }
+func (*floatRules) ProtoMessage()    {}
+
+func (m *doubleRules) Reset()         { *m = doubleRules{} }
+func (m *doubleRules) String() string { return proto.CompactTextString(m) }
+func (*doubleRules) ProtoMessage()    {}
+
+func (m *int32Rules) Reset()         { *m = int32Rules{} }
+func (m *int32Rules) String() string { return proto.CompactTextString(m) }
+func (*int32Rules) ProtoMessage()    {}
+
+func (m *int64Rules) Reset()         { *m = int64Rules{} }
+func (m *int64Rules) String() string { return proto.CompactTextString(m) }
+func (*int64Rules) ProtoMessage()    {}
+
+func (m *uint32Rules) Reset()         { *m = uint32Rules{} }
+func (m *uint32Rules) String() string { return proto.CompactTextString(m) }
+func (*uint32Rules) ProtoMessage()    {}
+
+func (m *uint64Rules) Reset()         { *m = uint64Rules{} }
+func (m *uint64Rules) String() string { return proto.CompactTextString(m) }
+func (*uint64Rules) ProtoMessage()    {}
+
+func (m *sint32Rules) Reset()         { *m = sint32Rules{} }
+func (m *sint32Rules) String() string { return proto.CompactTextString(m) }
+func (*sint32Rules) ProtoMessage()    {}
+
+func (m *sint64Rules) Reset()         { *m = sint64Rules{} }
+func (m *sint64Rules) String() string { return proto.CompactTextString(m) }
+func (*sint64Rules) ProtoMessage()    {}
+
+func (m *fixed32Rules) Reset()         { *m = fixed32Rules{} }
+func (m *fixed32Rules) String() string { return proto.CompactTextString(m) }
+func (*fixed32Rules) ProtoMessage()    {}
+
+func (m *fixed64Rules) Reset()         { *m = fixed64Rules{} }
+func (m *fixed64Rules) String() string { return proto.CompactTextString(m) }
+func (*fixed64Rules) ProtoMessage()    {}
+
+func (m *sfixed32Rules) Reset()         { *m = sfixed32Rules{} }
+func (m *sfixed32Rules) String() string { return proto.CompactTextString(m) }
+func (*sfixed32Rules) ProtoMessage()    {}
+
+func (m *sfixed64Rules) Reset()         { *m = sfixed64Rules{} }
+func (m *sfixed64Rules) String() string { return proto.CompactTextString(m) }
+func (*sfixed64Rules) ProtoMessage()    {}
+
+func (m *boolRules) Reset()         { *m = boolRules{} }
+func (m *boolRules) String() string { return proto.CompactTextString(m) }
+func (*boolRules) ProtoMessage()    {}
+
+func (m *stringRules) Reset()         { *m = stringRules{} }
+func (m *stringRules) String() string { return proto.CompactTextString(m) }
+func (*stringRules) ProtoMessage()    {}
+
+func (m *bytesRules) Reset()         { *m = bytesRules{} }
+func (m *bytesRules) String() string { return proto.CompactTextString(m) }
+func (*bytesRules) ProtoMessage()    {}
+
+func (m *enumRules) Reset()         { *m = enumRules{} }
+func (m *enumRules) String() string { return proto.CompactTextString(m) }
+func (*enumRules) ProtoMessage()    {}
+
+func (m *messageRules) Reset()         { *m = messageRules{} }
+func (m *messageRules) String() string { return proto.CompactTextString(m) }
+func (*messageRules) ProtoMessage()    {}
+
+func (m *repeatedRules) Reset()         { *m = repeatedRules{} }
+func (m *repeatedRules) String() string { return proto.CompactTextString(m) }
+func (*repeatedRules) ProtoMessage()    {}
+
+func (m *mapRules) Reset()         { *m = mapRules{} }
+func (m *mapRules) String() string { return proto.CompactTextString(m) }
+func (*mapRules) ProtoMessage()    {}
+
+// validationFiles are the files of the PGV options, which declare no messages of an API.
+var validationFiles = map[string]bool{
+	"validate/validate.proto":          true,
+	"google/protobuf/descriptor.proto": true,
+}
+
+// IsValidationFile reports if a file declares the PGV options, so no module needs to be generated for it.
+func IsValidationFile(f *descriptor.FileDescriptorProto) bool {
+	return validationFiles[f.GetName()]
+}
+
+// getFieldRules returns the PGV rules of a field, or nil when the field has none.
+func getFieldRules(f *descriptor.FieldDescriptorProto) (*fieldRules, error) {
+	if f.GetOptions() == nil || !proto.HasExtension(f.GetOptions(), extFieldRules) {
+		return nil, nil
+	}
+
+	ext, err := proto.GetExtension(f.GetOptions(), extFieldRules)
+	if err != nil {
+		return nil, err
+	}
+
+	return ext.(*fieldRules), nil
+}
+
+// getBoolOption returns the value of a PGV bool option of a message or oneof.
+func getBoolOption(options proto.Message, ext *proto.ExtensionDesc) bool {
+	if reflect.ValueOf(options).IsNil() || !proto.HasExtension(options, ext) {
+		return false
+	}
+
+	v, err := proto.GetExtension(options, ext)
+	if err != nil {
+		return false
+	}
+
+	return *v.(*bool)
+}
+
+// isValidationDisabled reports if the PGV rules of a message are disabled or ignored.
+func isValidationDisabled(m *descriptor.DescriptorProto) bool {
+	return getBoolOption(m.GetOptions(), extMessageDisabled) || getBoolOption(m.GetOptions(), extMessageIgnored)
+}
+
+// fieldValidator generates the checks of the PGV rules of a field, as calls of the checkRule function of the
+// runtime, e.g. checkRule(errors, "name", "string.min_len", runeCount(m.name || "") >= 1, "must be at least 1 characters").
+type fieldValidator struct {
+	field ModelField
+	// path is the typescript expression of the name of the field in the validation errors
+	path string
+	// rule is the name of the PGV rules of the field type, e.g. string for string.min_len
+	rule   string
+	checks []string
+}
+
+func (v *fieldValidator) check(rule string, cond string, message string) {
+	v.checks = append(v.checks, fmt.Sprintf("checkRule(errors, %s, %q, %s, %q);", v.path, v.rule+"."+rule, cond, message))
+}
+
+// numberRules is a PGV rules message of a numeric type, whose values are formatted as typescript literals.
+type numberRules struct {
+	Const, Lt, Lte, Gt, Gte string
+	In, NotIn               []string
+	IgnoreEmpty             bool
+	// bounds are the values of the range rules, which are compared to detect an exclusive range
+	lower, upper float64
+	// text are the values of the rules as they are written in the messages, e.g. 0 for BigInt("0")
+	text map[string]string
+}
+
+// newNumberRules reads the fields of a PGV rules message of a numeric type, which all have the same names.
+func newNumberRules(rules interface{}, literal func(v interface{}) string) numberRules {
+	n := numberRules{text: make(map[string]string)}
+
+	r := reflect.ValueOf(rules).Elem()
+
+	get := func(name string, bound *float64) string {
+		f := r.FieldByName(name)
+		if f.IsNil() {
+			return ""
+		}
+
+		if bound != nil {
+			*bound, _ = strconv.ParseFloat(fmt.Sprint(f.Elem().Interface()), 64)
+		}
+
+		lit := literal(f.Elem().Interface())
+		n.text[lit] = fmt.Sprint(f.Elem().Interface())
+
+		return lit
+	}
+
+	list := func(name string) []string {
+		var values []string
+
+		f := r.FieldByName(name)
+		for i := 0; i < f.Len(); i++ {
+			lit := literal(f.Index(i).Interface())
+			n.text[lit] = fmt.Sprint(f.Index(i).Interface())
+			values = append(values, lit)
+		}
+
+		return values
+	}
+
+	n.Const = get("Const", nil)
+	n.Lt = get("Lt", &n.upper)
+	n.Lte = get("Lte", &n.upper)
+	n.Gt = get("Gt", &n.lower)
+	n.Gte = get("Gte", &n.lower)
+	n.In = list("In")
+	n.NotIn = list("NotIn")
+	n.IgnoreEmpty = r.FieldByName("IgnoreEmpty").Interface().(*bool) != nil && *r.FieldByName("IgnoreEmpty").Interface().(*bool)
+
+	return n
+}
+
+// numbers adds the checks of the PGV rules of a numeric field. A range with both a lower and upper bound is a
+// single check, which is an exclusive range when the lower bound is greater than the upper bound, e.g. {lt: 0, gt: 1}
+func (v *fieldValidator) numbers(value string, zero string, n numberRules) {
+	cond := func(c string) string {
+		if n.IgnoreEmpty {
+			return fmt.Sprintf("%s === %s || %s", value, zero, c)
+		}
+		return c
+	}
+
+	texts := func(values []string) string {
+		var t []string
+		for _, lit := range values {
+			t = append(t, n.text[lit])
+		}
+		return strings.Join(t, ", ")
+	}
+
+	if n.Const != "" {
+		v.check("const", cond(fmt.Sprintf("%s === %s", value, n.Const)), "must equal "+n.text[n.Const])
+	}
+
+	var lowerRule, lowerCond, lowerMessage string
+	switch {
+	case n.Gt != "":
+		lowerRule, lowerCond, lowerMessage = "gt", fmt.Sprintf("%s > %s", value, n.Gt), "greater than "+n.text[n.Gt]
+	case n.Gte != "":
+		lowerRule, lowerCond, lowerMessage = "gte", fmt.Sprintf("%s >= %s", value, n.Gte), "greater than or equal to "+n.text[n.Gte]
+	}
+
+	var upperRule, upperCond, upperMessage string
+	switch {
+	case n.Lt != "":
+		upperRule, upperCond, upperMessage = "lt", fmt.Sprintf("%s < %s", value, n.Lt), "less than "+n.text[n.Lt]
+	case n.Lte != "":
+		upperRule, upperCond, upperMessage = "lte", fmt.Sprintf("%s <= %s", value, n.Lte), "less than or equal to "+n.text[n.Lte]
+	}
+
+	switch {
+	case lowerRule != "" && upperRule != "" && n.lower < n.upper:
+		v.check(lowerRule+"_"+upperRule, cond(lowerCond+" && "+upperCond), "must be "+lowerMessage+" and "+upperMessage)
+	case lowerRule != "" && upperRule != "":
+		v.check(lowerRule+"_"+upperRule, cond(upperCond+" || "+lowerCond), "must be "+upperMessage+" or "+lowerMessage)
+	case lowerRule != "":
+		v.check(lowerRule, cond(lowerCond), "must be "+lowerMessage)
+	case upperRule != "":
+		v.check(upperRule, cond(upperCond), "must be "+upperMessage)
+	}
+
+	if len(n.In) > 0 {
+		v.check("in", cond(fmt.Sprintf("[%s].indexOf(%s) >= 0", strings.Join(n.In, ", "), value)), "must be in ["+texts(n.In)+"]")
+	}
+
+	if len(n.NotIn) > 0 {
+		v.check("not_in", cond(fmt.Sprintf("[%s].indexOf(%s) < 0", strings.Join(n.NotIn, ", "), value)), "must not be in ["+texts(n.NotIn)+"]")
+	}
+}
+
+func (v *fieldValidator) strings(value string, r *stringRules) {
+	cond := func(c string) string {
+		if r.IgnoreEmpty != nil && *r.IgnoreEmpty {
+			return fmt.Sprintf(`%s === "" || %s`, value, c)
+		}
+		return c
+	}
+
+	length := func(rule string, count string, op string, n *uint64, message string) {
+		if n != nil {
+			v.check(rule, cond(fmt.Sprintf("%s %s %d", call(count, value), op, *n)), fmt.Sprintf(message, *n))
+		}
+	}
+
+	if r.Const != nil {
+		v.check("const", cond(fmt.Sprintf("%s === %s", value, jsString(*r.Const))), "must equal "+jsString(*r.Const))
+	}
+
+	length("len", "runeCount", "===", r.Len, "must be %d characters")
+	length("min_len", "runeCount", ">=", r.MinLen, "must be at least %d characters")
+	length("max_len", "runeCount", "<=", r.MaxLen, "must be at most %d characters")
+	length("len_bytes", "utf8Length", "===", r.LenBytes, "must be %d bytes")
+	length("min_bytes", "utf8Length", ">=", r.MinBytes, "must be at least %d bytes")
+	length("max_bytes", "utf8Length", "<=", r.MaxBytes, "must be at most %d bytes")
+
+	if r.Pattern != nil {
+		v.check("pattern", cond(call(fmt.Sprintf("new RegExp(%s).test", jsString(*r.Pattern)), value)), "must match the pattern "+*r.Pattern)
+	}
+
+	if r.Prefix != nil {
+		v.check("prefix", cond(fmt.Sprintf("%s.indexOf(%s) === 0", value, jsString(*r.Prefix))), "must start with "+jsString(*r.Prefix))
+	}
+
+	if r.Suffix != nil {
+		v.check("suffix", cond(fmt.Sprintf("%s.slice(%s.length - %d) === %s", value, value, len(*r.Suffix), jsString(*r.Suffix))), "must end with "+jsString(*r.Suffix))
+	}
+
+	if r.Contains != nil {
+		v.check("contains", cond(fmt.Sprintf("%s.indexOf(%s) >= 0", value, jsString(*r.Contains))), "must contain "+jsString(*r.Contains))
+	}
+
+	if r.NotContains != nil {
+		v.check("not_contains", cond(fmt.Sprintf("%s.indexOf(%s) < 0", value, jsString(*r.NotContains))), "must not contain "+jsString(*r.NotContains))
+	}
+
+	if len(r.In) > 0 {
+		list := jsStrings(r.In)
+		v.check("in", cond(fmt.Sprintf("[%s].indexOf(%s) >= 0", list, value)), "must be in ["+list+"]")
+	}
+
+	if len(r.NotIn) > 0 {
+		list := jsStrings(r.NotIn)
+		v.check("not_in", cond(fmt.Sprintf("[%s].indexOf(%s) < 0", list, value)), "must not be in ["+list+"]")
+	}
+
+	formats := []struct {
+		rule    string
+		set     *bool
+		fn      string
+		message string
+	}{
+		{"email", r.Email, "isEmail", "must be an email address"},
+		{"hostname", r.Hostname, "isHostname", "must be a hostname"},
+		{"uri", r.URI, "isURI", "must be an absolute URI"},
+		{"uuid", r.UUID, "isUUID", "must be a UUID"},
+	}
+
+	for _, f := range formats {
+		if f.set != nil && *f.set {
+			v.check(f.rule, cond(call(f.fn, value)), f.message)
+		}
+	}
+}
+
+func (v *fieldValidator) bytes(value string, r *bytesRules) {
+	lengths := []struct {
+		rule    string
+		op      string
+		n       *uint64
+		message string
+	}{
+		{"len", "===", r.Len, "must be %d bytes"},
+		{"min_len", ">=", r.MinLen, "must be at least %d bytes"},
+		{"max_len", "<=", r.MaxLen, "must be at most %d bytes"},
+	}
+
+	for _, l := range lengths {
+		if l.n == nil {
+			continue
+		}
+
+		c := fmt.Sprintf("%s.length %s %d", value, l.op, *l.n)
+		if r.IgnoreEmpty != nil && *r.IgnoreEmpty {
+			c = fmt.Sprintf("%s.length === 0 || %s", value, c)
+		}
+
+		v.check(l.rule, c, fmt.Sprintf(l.message, *l.n))
+	}
+}
+
+func (v *fieldValidator) enum(value string, enum string, r *enumRules) {
+	literal := func(n interface{}) string { return fmt.Sprint(n) }
+
+	if r.DefinedOnly != nil && *r.DefinedOnly {
+		v.check("defined_only", fmt.Sprintf("%s[%s] !== undefined", enum, value), "must be a defined "+enum+" value")
+	}
+
+	v.numbers(value, "0", newNumberRules(&int32Rules{Const: r.Const, In: r.In, NotIn: r.NotIn}, literal))
+}
+
+// scalar adds the checks of the rules of a scalar value, e.g. a singular field or an item of a repeated field.
+// The access is the typescript expression of the value, which may be undefined.
+func (v *fieldValidator) scalar(access string, rules *fieldRules) {
+	f := v.field
+	number := func(n interface{}) string {
+		if f.IsLong && wrappedType(f) == Int64BigInt {
+			return fmt.Sprintf("BigInt(%q)", fmt.Sprint(n))
+		}
+
+		return strconv.FormatFloat(toFloat(n), 'g', -1, 64)
+	}
+
+	// numbers are compared as a number, unless they are a bigint, since they may be typed as strings
+	value, zero := fmt.Sprintf("Number(%s || 0)", access), "0"
+	if f.IsLong && wrappedType(f) == Int64BigInt {
+		value, zero = fmt.Sprintf("(%s || BigInt(0))", access), "BigInt(0)"
+	}
+
+	numeric := []interface{}{rules.Float, rules.Double, rules.Int32, rules.Int64, rules.Uint32, rules.Uint64,
+		rules.Sint32, rules.Sint64, rules.Fixed32, rules.Fixed64, rules.Sfixed32, rules.Sfixed64}
+	names := []string{"float", "double", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64"}
+
+	for i, r := range numeric {
+		if !reflect.ValueOf(r).IsNil() {
+			v.rule = names[i]
+			v.numbers(value, zero, newNumberRules(r, number))
+		}
+	}
+
+	switch {
+	case rules.Bool != nil && rules.Bool.Const != nil:
+		v.rule = "bool"
+		v.check("const", fmt.Sprintf("!!%s === %t", access, *rules.Bool.Const), fmt.Sprintf("must equal %t", *rules.Bool.Const))
+	case rules.String_ != nil:
+		v.rule = "string"
+		v.strings(fmt.Sprintf("(%s || \"\")", access), rules.String_)
+	case rules.Bytes != nil:
+		v.rule = "bytes"
+		v.bytes(fmt.Sprintf("(%s || new Uint8Array(0))", access), rules.Bytes)
+	case rules.Enum != nil:
+		v.rule = "enum"
+		v.enum(fmt.Sprintf("(%s || 0)", access), strings.TrimSuffix(f.Type, "[]"), rules.Enum)
+	case rules.Message != nil && rules.Message.Required != nil && *rules.Message.Required:
+		v.rule = "message"
+		v.check("required", fmt.Sprintf("%s !== undefined && %s !== null", access, access), "is required")
+	}
+}
+
+// validateField generates the checks of the PGV rules of a field, whose value is read from the access expression.
+func validateField(f ModelField, rules *fieldRules, access string) []string {
+	v := &fieldValidator{field: f, path: jsString(f.ProtoName)}
+
+	switch {
+	case f.IsRepeated && rules.Repeated != nil:
+		r := rules.Repeated
+		v.rule = "repeated"
+
+		list := fmt.Sprintf("(%s || [])", access)
+		cond := func(c string) string {
+			if r.IgnoreEmpty != nil && *r.IgnoreEmpty {
+				return fmt.Sprintf("%s.length === 0 || %s", list, c)
+			}
+			return c
+		}
+
+		if r.MinItems != nil {
+			v.check("min_items", cond(fmt.Sprintf("%s.length >= %d", list, *r.MinItems)), fmt.Sprintf("must have at least %d items", *r.MinItems))
+		}
+
+		if r.MaxItems != nil {
+			v.check("max_items", cond(fmt.Sprintf("%s.length <= %d", list, *r.MaxItems)), fmt.Sprintf("must have at most %d items", *r.MaxItems))
+		}
+
+		if r.Unique != nil && *r.Unique {
+			v.check("unique", call("isUnique", list), "must have unique items")
+		}
+
+		if r.Items != nil {
+			items := &fieldValidator{field: f, path: jsString(f.ProtoName+"[") + " + i + \"]\""}
+			items.scalar("v", r.Items)
+
+			if len(items.checks) > 0 {
+				v.checks = append(v.checks, fmt.Sprintf("%s.forEach((v, i) => {\n        %s\n    });", list, strings.Join(items.checks, "\n        ")))
+			}
+		}
+	case f.IsMap && rules.Map != nil:
+		r := rules.Map
+		v.rule = "map"
+
+		pairs := fmt.Sprintf("Object.keys(%s || {}).length", access)
+		cond := func(c string) string {
+			if r.IgnoreEmpty != nil && *r.IgnoreEmpty {
+				return fmt.Sprintf("%s === 0 || %s", pairs, c)
+			}
+			return c
+		}
+
+		if r.MinPairs != nil {
+			v.check("min_pairs", cond(fmt.Sprintf("%s >= %d", pairs, *r.MinPairs)), fmt.Sprintf("must have at least %d pairs", *r.MinPairs))
+		}
+
+		if r.MaxPairs != nil {
+			v.check("max_pairs", cond(fmt.Sprintf("%s <= %d", pairs, *r.MaxPairs)), fmt.Sprintf("must have at most %d pairs", *r.MaxPairs))
+		}
+	case !f.IsRepeated && !f.IsMap:
+		v.scalar(access, rules)
+	}
+
+	return v.checks
+}
+
+// markValidatedModels sets the Validate flag of the models with PGV rules, and of the models with message fields
+// whose models are validated, which validate their message fields with the validate function of the field's model.
+// The models of all files are marked together, since the model of a field may be declared in another file.
+func markValidatedModels(ctxs []*APIContext) {
+	for changed := true; changed; {
+		changed = false
+
+		for _, ctx := range ctxs {
+			for _, m := range ctx.Models {
+				if m.Validate || m.validationDisabled {
+					continue
+				}
+
+				for _, f := range m.fields() {
+					if ctx.validatesField(m, f) {
+						m.Validate = true
+						changed = true
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+// validatesField reports if a message field of a model is validated by the validate function of the field's model.
+func (ctx *APIContext) validatesField(m *Model, f ModelField) bool {
+	target, ok := ctx.modelLookup[strings.TrimSuffix(f.Type, "[]")]
+
+	return ok && f.IsMessage && target.Validate && !m.skipValidation[f.Name]
+}
+
+// nestedValidations generates the validation of the message fields of a model, whose errors are added with the
+// name of the field as a prefix, e.g. address.street. This must be called after markValidatedModels is called
+// on all files, since the model of a field may be declared in another file.
+func (ctx *APIContext) nestedValidations() {
+	for _, m := range ctx.Models {
+		if !m.Validate {
+			continue
+		}
+
+		nested := func(f ModelField, access string) {
+			if !ctx.validatesField(m, f) {
+				return
+			}
+			target := ctx.modelLookup[strings.TrimSuffix(f.Type, "[]")]
+
+			fn := "validateMessage"
+			switch {
+			case f.IsRepeated:
+				fn = "validateList"
+			case f.IsMap:
+				fn = "validateMap"
+			}
+
+			m.Validations = append(m.Validations, fmt.Sprintf("%s(errors, %s, %s, validate%s);", fn, jsString(f.ProtoName), access, target.Name))
+		}
+
+		for _, f := range m.Fields {
+			if f.IsMap {
+				value := *f.Value
+				value.Name = f.Name
+				value.IsMap = true
+				value.ProtoName = f.ProtoName
+				nested(value, "m."+f.Name)
+				continue
+			}
+
+			nested(f, "m."+f.Name)
+		}
+
+		for _, o := range m.Oneofs {
+			for _, f := range o.Fields {
+				nested(f, fmt.Sprintf("m.%s && m.%s.kind === %q ? m.%s.value : undefined", o.Name, o.Name, f.Name, o.Name))
+			}
+		}
+	}
+}
+
+// parseValidations generates the checks of the PGV rules of the fields and oneofs of a message.
+func (ctx *APIContext) parseValidations(model *Model, m *descriptor.DescriptorProto) error {
+	if isValidationDisabled(m) {
+		model.validationDisabled = true
+		return nil
+	}
+
+	synthetic := syntheticOneofs(m)
+	model.skipValidation = make(map[string]bool)
+	model.requiredRules = make(map[string]bool)
+
+	for _, f := range m.GetField() {
+		rules, err := getFieldRules(f)
+		if err != nil {
+			return fmt.Errorf("%s: invalid validate.rules of field %s.%s: %v", ctx.file, m.GetName(), f.GetName(), err)
+		}
+
+		if rules == nil {
+			continue
+		}
+
+		field := ctx.newModelField(f)
+
+		if rules.Message != nil && rules.Message.Skip != nil && *rules.Message.Skip {
+			model.skipValidation[field.Name] = true
+		}
+
+		if rules.Message != nil && rules.Message.Required != nil && *rules.Message.Required {
+			model.requiredRules[field.Name] = true
+		}
+
+		// the rules of oneof members and optional fields are only checked when the field is set
+		var guard string
+		access := "m." + field.Name
+
+		switch {
+		case f.OneofIndex != nil && !synthetic[f.GetOneofIndex()]:
+			o := fieldName(m.GetOneofDecl()[f.GetOneofIndex()].GetName(), ctx.Options)
+			guard = fmt.Sprintf("m.%s && m.%s.kind === %q", o, o, field.Name)
+			access = fmt.Sprintf("m.%s.value", o)
+		case field.IsOptional || field.IsWrapper:
+			guard = fmt.Sprintf("%s !== undefined && %s !== null", access, access)
+		}
+
+		checks := validateField(field, rules, access)
+		if guard != "" && len(checks) > 0 {
+			checks = []string{fmt.Sprintf("if (%s) {\n        %s\n    }", guard, strings.Join(checks, "\n        "))}
+		}
+
+		model.Validations = append(model.Validations, checks...)
+	}
+
+	for i, o := range m.GetOneofDecl() {
+		if synthetic[int32(i)] || !getBoolOption(o.GetOptions(), extOneofRequired) {
+			continue
+		}
+
+		name := fieldName(o.GetName(), ctx.Options)
+		model.requiredRules[name] = true
+		model.Validations = append(model.Validations, fmt.Sprintf("checkRule(errors, %s, \"oneof.required\", m.%s !== undefined, \"is required\");", jsString(o.GetName()), name))
+	}
+
+	model.Validate = len(model.Validations) > 0
+
+	return nil
+}
+
+// call is the typescript call of a runtime function with a value, whose parentheses are dropped when
+// it is a parenthesized expression, e.g. runeCount(m.name || "") for (m.name || "").
+func call(fn string, value string) string {
+	if strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")") {
+		return fn + value
+	}
+
+	return fmt.Sprintf("%s(%s)", fn, value)
+}
+
+func jsString(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+func jsStrings(values []string) string {
+	var quoted []string
+	for _, s := range values {
+		quoted = append(quoted, jsString(s))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func toFloat(n interface{}) float64 {
+	f, _ := strconv.ParseFloat(fmt.Sprint(n), 64)
+	return f
+}
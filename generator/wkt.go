@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+// mappedWKTFiles are the Google WKT files whose messages are represented by typescript types,
+// rather than generated interfaces, since proto3 JSON has a special representation for them.
+var mappedWKTFiles = map[string]bool{
+	"google/protobuf/any.proto":        true,
+	"google/protobuf/duration.proto":   true,
+	"google/protobuf/empty.proto":      true,
+	"google/protobuf/field_mask.proto": true,
+	"google/protobuf/struct.proto":     true,
+	"google/protobuf/timestamp.proto":  true,
+	"google/protobuf/wrappers.proto":   true,
+}
+
+// IsMappedWKT reports if no module needs to be generated for a Google WKT file.
+func IsMappedWKT(f *descriptor.FileDescriptorProto) bool {
+	return mappedWKTFiles[f.GetName()]
+}
+
+// passthroughTypes maps the Google WKTs that are passed through unchanged in JSON to their typescript type,
+// and the name used by their protobuf codecs in the runtime library, e.g. struct for structToProtobuf.
+var passthroughTypes = map[string]struct{ Type, Codec string }{
+	".google.protobuf.Any":       {"Any", "any"},
+	".google.protobuf.Empty":     {"{[key: string]: any}", "struct"},
+	".google.protobuf.Struct":    {"{[key: string]: any}", "struct"},
+	".google.protobuf.Value":     {"any", "value"},
+	".google.protobuf.ListValue": {"any[]", "listValue"},
+}
+
+// wrapperTypes maps the Google WKT wrappers to the type of the value they wrap.
+var wrapperTypes = map[string]descriptor.FieldDescriptorProto_Type{
+	".google.protobuf.DoubleValue": descriptor.FieldDescriptorProto_TYPE_DOUBLE,
+	".google.protobuf.FloatValue":  descriptor.FieldDescriptorProto_TYPE_FLOAT,
+	".google.protobuf.Int64Value":  descriptor.FieldDescriptorProto_TYPE_INT64,
+	".google.protobuf.UInt64Value": descriptor.FieldDescriptorProto_TYPE_UINT64,
+	".google.protobuf.Int32Value":  descriptor.FieldDescriptorProto_TYPE_INT32,
+	".google.protobuf.UInt32Value": descriptor.FieldDescriptorProto_TYPE_UINT32,
+	".google.protobuf.BoolValue":   descriptor.FieldDescriptorProto_TYPE_BOOL,
+	".google.protobuf.StringValue": descriptor.FieldDescriptorProto_TYPE_STRING,
+	".google.protobuf.BytesValue":  descriptor.FieldDescriptorProto_TYPE_BYTES,
+}
+
+// wrappedType is the typescript type of the value held by a wrapper field, without null.
+func wrappedType(f ModelField) string {
+	return strings.TrimSuffix(strings.Trim(singularType(f), "()"), " | null")
+}
+
+func wrapperToJSON(f ModelField, value string) string {
+	switch {
+	case f.IsLong:
+		return longToString(wrappedType(f), value)
+	case f.IsBytes:
+		return fmt.Sprintf("bytesToBase64(%s)", value)
+	case f.IsFloat:
+		return fmt.Sprintf("floatToJSON(%s)", value)
+	}
+
+	return value
+}
+
+func wrapperFromJSON(f ModelField, value string) string {
+	switch {
+	case f.IsLong:
+		return longFromString(wrappedType(f), value)
+	case f.IsBytes:
+		return fmt.Sprintf("base64ToBytes(%s)", value)
+	case f.IsFloat:
+		return fmt.Sprintf("floatFromJSON(%s)", value)
+	}
+
+	return value
+}
+
+// stringifyWrapper marshals a nullable wrapper field, where null is sent for an unset value.
+func stringifyWrapper(f ModelField) string {
+	if !f.IsLong && !f.IsBytes && !f.IsFloat {
+		return "m." + f.Name
+	}
+
+	if f.IsRepeated {
+		return fmt.Sprintf("m.%s.map((n) => n === null ? null : %s)", f.Name, wrapperToJSON(f, "n"))
+	}
+
+	return fmt.Sprintf("m.%s === null ? null : %s", f.Name, wrapperToJSON(f, "m."+f.Name))
+}
+
+// parseWrapper unmarshals a nullable wrapper field, where an absent value is null.
+func parseWrapper(f ModelField) string {
+	if f.IsRepeated {
+		if !f.IsLong && !f.IsBytes && !f.IsFloat {
+			return "m." + f.JSONName
+		}
+
+		return fmt.Sprintf("m.%s.map((n) => n === null ? null : %s)", f.JSONName, wrapperFromJSON(f, "n"))
+	}
+
+	if !f.IsLong && !f.IsBytes && !f.IsFloat {
+		return fmt.Sprintf("m.%s === undefined ? null : m.%s", f.JSONName, f.JSONName)
+	}
+
+	return fmt.Sprintf("m.%s === undefined || m.%s === null ? null : %s", f.JSONName, f.JSONName, wrapperFromJSON(f, "m."+f.JSONName))
+}
+
+// stringifyDuration marshals a Duration field to its proto3 JSON string, which is already
+// the representation of the field when durations are typed as strings.
+func stringifyDuration(f ModelField) string {
+	if singularType(f) == DurationString {
+		return "m." + f.Name
+	}
+
+	if f.IsRepeated {
+		return fmt.Sprintf("m.%s.map(durationToString)", f.Name)
+	}
+
+	return fmt.Sprintf("durationToString(m.%s)", f.Name)
+}
+
+func parseDuration(f ModelField) string {
+	if singularType(f) == DurationString {
+		return "m." + f.JSONName
+	}
+
+	if f.IsRepeated {
+		return fmt.Sprintf("m.%s.map(durationFromString)", f.JSONName)
+	}
+
+	// absent durations are treated as zero, rather than failing to parse
+	return fmt.Sprintf(`durationFromString(m.%s || "0s")`, f.JSONName)
+}
+
+// stringifyFieldMask marshals the paths of a FieldMask field to a comma separated string.
+func stringifyFieldMask(f ModelField) string {
+	if f.IsRepeated {
+		return fmt.Sprintf("m.%s.map(fieldMaskToString)", f.Name)
+	}
+
+	return fmt.Sprintf("fieldMaskToString(m.%s)", f.Name)
+}
+
+func parseFieldMask(f ModelField) string {
+	if f.IsRepeated {
+		return fmt.Sprintf("m.%s.map(fieldMaskFromString)", f.JSONName)
+	}
+
+	return fmt.Sprintf(`fieldMaskFromString(m.%s || "")`, f.JSONName)
+}
@@ -0,0 +1,140 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+)
+
+func TestNewField_Wrapper(t *testing.T) {
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("count"),
+		JsonName: proto.String("count"),
+		Number:   proto.Int32(1),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".google.protobuf.Int64Value"),
+	}, typeRegistry{}, Options{Int64: Int64BigInt})
+
+	if f.IsMessage || !f.IsWrapper || !f.IsLong {
+		t.Fatalf("expected Int64Value to be a long wrapper, got %+v", f)
+	}
+
+	if f.Type != "bigint | null" || f.JSONType != "string | null" {
+		t.Errorf("expected types bigint | null and string | null, got %s and %s", f.Type, f.JSONType)
+	}
+
+	if expected, actual := "m.count === null ? null : m.count.toString()", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "m.count === undefined || m.count === null ? null : BigInt(m.count)", parse(f); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "case 1: m.count = unwrapValue(r.bytes(), BigInt(0), (r) => BigInt(r.int64())); break;", decodeField(f); actual != expected {
+		t.Errorf("expected decodeField %s, got %s", expected, actual)
+	}
+}
+
+func TestStringifyAndParseDuration(t *testing.T) {
+	tests := []struct {
+		durationType string
+		stringify    string
+		parse        string
+	}{
+		{DurationString, "m.timeout", "m.timeout"},
+		{DurationObject, "durationToString(m.timeout)", `durationFromString(m.timeout || "0s")`},
+	}
+
+	for _, tt := range tests {
+		f := newField(&descriptor.FieldDescriptorProto{
+			Name:     proto.String("timeout"),
+			Number:   proto.Int32(1),
+			Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+			TypeName: proto.String(".google.protobuf.Duration"),
+		}, typeRegistry{}, Options{Int64: Int64Number, Duration: tt.durationType})
+
+		if actual := stringify(f); actual != tt.stringify {
+			t.Errorf("%s: expected stringify %s, got %s", tt.durationType, tt.stringify, actual)
+		}
+
+		if actual := parse(f); actual != tt.parse {
+			t.Errorf("%s: expected parse %s, got %s", tt.durationType, tt.parse, actual)
+		}
+	}
+}
+
+func TestNewField_Struct(t *testing.T) {
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("attrs"),
+		Number:   proto.Int32(2),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".google.protobuf.Struct"),
+	}, typeRegistry{}, DefaultOptions())
+
+	if f.IsMessage || f.Type != "{[key: string]: any}" || f.JSONType != f.Type {
+		t.Fatalf("expected Struct to be untyped JSON, got %+v", f)
+	}
+
+	if expected, actual := "m.attrs", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := "case 2: m.attrs = protobufToStruct(r.bytes()); break;", decodeField(f); actual != expected {
+		t.Errorf("expected decodeField %s, got %s", expected, actual)
+	}
+}
+
+func TestNewField_Any(t *testing.T) {
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("details"),
+		Number:   proto.Int32(3),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".google.protobuf.Any"),
+	}, typeRegistry{}, DefaultOptions())
+
+	if f.IsMessage || f.Type != "Any" || f.JSONType != "Any" {
+		t.Fatalf("expected Any to be passed through, got %+v", f)
+	}
+
+	if expected, actual := "if (m.details !== undefined) { w.tag(3, 2).bytes(anyToProtobuf(m.details)); }", encodeField(f); actual != expected {
+		t.Errorf("expected encodeField %s, got %s", expected, actual)
+	}
+}
+
+func TestStringifyAndParseFieldMask(t *testing.T) {
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("update_mask"),
+		Number:   proto.Int32(1),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".google.protobuf.FieldMask"),
+	}, typeRegistry{}, DefaultOptions())
+
+	if f.Type != "string[]" || f.JSONType != "string" {
+		t.Errorf("expected types string[] and string, got %s and %s", f.Type, f.JSONType)
+	}
+
+	if expected, actual := "fieldMaskToString(m.updateMask)", stringify(f); actual != expected {
+		t.Errorf("expected stringify %s, got %s", expected, actual)
+	}
+
+	if expected, actual := `fieldMaskFromString(m.update_mask || "")`, parse(f); actual != expected {
+		t.Errorf("expected parse %s, got %s", expected, actual)
+	}
+}
+
+func TestFakeValue_RepeatedDuration(t *testing.T) {
+	f := newField(&descriptor.FieldDescriptorProto{
+		Name:     proto.String("intervals"),
+		Number:   proto.Int32(1),
+		Label:    descriptor.FieldDescriptorProto_LABEL_REPEATED.Enum(),
+		Type:     descriptor.FieldDescriptorProto_TYPE_MESSAGE.Enum(),
+		TypeName: proto.String(".google.protobuf.Duration"),
+	}, typeRegistry{}, Options{Int64: Int64Number, Duration: DurationObject})
+
+	// the object literal is the body of an arrow function, which would be a block without the parentheses
+	if expected, actual := "fakeArray(() => ({seconds: fakeInt(0, 3600), nanos: 0}))", fakeValue(f, nil); actual != expected {
+		t.Errorf("expected fakeValue %s, got %s", expected, actual)
+	}
+}
@@ -0,0 +1,238 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
+)
+
+const zodTemplate = `import {z} from '{{if eq .Target "deno"}}npm:zod{{else}}zod{{end}}';
+{{- range .Imports}}
+import { {{- join .Names ", " -}} } from '{{importPath .Module}}';
+{{- end}}
+{{range .Models}}
+// {{.Name}}Schema checks the proto3 JSON of the {{.Name}} message, e.g. the body of a response.
+export const {{.Name}}Schema{{if recursive .}}: z.ZodType<any>{{end}} = z.object({
+    {{- range .Fields}}
+    {{.JSONName}}: {{zodField .}}.optional(),
+    {{- if .JSONAlias}}
+    {{.JSONAlias}}: {{zodField .}}.optional(),
+    {{- end}}
+    {{- end}}
+    {{- range .Oneofs}}{{range .Fields}}
+    {{.JSONName}}: {{zodField .}}.optional(),
+    {{- if .JSONAlias}}
+    {{.JSONAlias}}: {{zodField .}}.optional(),
+    {{- end}}
+    {{- end}}{{end}}
+});
+{{end}}`
+
+// zodModuleName is the name of the module of the zod schemas of the messages of a module, e.g. service_zod
+func zodModuleName(module string) string {
+	return module + "_zod"
+}
+
+// zodModule is the module of the zod schemas of the messages of a proto file, see renderZod.
+type zodModule struct {
+	Target  string
+	Imports []*Import
+	Models  []*Model
+}
+
+// zodField generates the zod schema of the proto3 JSON value of a field, which accepts the same values as the
+// JSON functions of its message, e.g. the names and the numbers of the values of an enum.
+func zodField(f ModelField, enums map[string]*Enum) string {
+	switch {
+	case f.IsMap:
+		key := "z.string()"
+		switch f.Key.ProtoType {
+		case descriptor.FieldDescriptorProto_TYPE_STRING:
+		case descriptor.FieldDescriptorProto_TYPE_BOOL:
+			key = "z.string().regex(/^(true|false)$/)"
+		default:
+			key = "z.string().regex(/^-?[0-9]+$/)"
+		}
+
+		return fmt.Sprintf("z.record(%s, %s)", key, zodField(*f.Value, enums))
+	case f.IsRepeated:
+		item := f
+		item.IsRepeated = false
+		item.Type = strings.TrimSuffix(f.Type, "[]")
+
+		return fmt.Sprintf("z.array(%s)", zodField(item, enums))
+	case f.IsWrapper:
+		value := f
+		value.IsWrapper = false
+
+		return zodField(value, enums) + ".nullable()"
+	case f.IsDuration:
+		return `z.string().regex(/^-?[0-9]+(\.[0-9]{1,9})?s$/)`
+	case f.IsFieldMask:
+		return "z.string()"
+	case f.Codec == "any":
+		return `z.object({"@type": z.string()}).passthrough()`
+	case f.Codec == "struct":
+		return "z.record(z.string(), z.unknown())"
+	case f.Codec == "listValue":
+		return "z.array(z.unknown())"
+	case f.Codec == "value":
+		return "z.unknown()"
+	case f.IsMessage && f.Type == "Date":
+		return "z.string().datetime({offset: true})"
+	case f.IsMessage:
+		return fmt.Sprintf("z.lazy(() => %sSchema)", f.Type)
+	case f.IsEnum:
+		e := enums[f.Type]
+		if f.EnumNumbers || e == nil {
+			return "z.number().int()"
+		}
+
+		var names []string
+		for _, v := range e.Values {
+			names = append(names, fmt.Sprintf("%q", v.Name))
+		}
+
+		return fmt.Sprintf("z.union([z.enum([%s]), z.number().int()])", strings.Join(names, ", "))
+	}
+
+	switch {
+	case f.IsFloat:
+		return "z.union([z.number(), z.string()])"
+	case f.IsLong:
+		return "z.union([z.string().regex(/^-?[0-9]+$/), z.number().int()])"
+	case f.IsBytes:
+		return "z.string()"
+	}
+
+	switch f.ProtoType {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return "z.boolean()"
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return "z.string()"
+	}
+
+	return "z.number().int()"
+}
+
+// recursive reports if a model contains itself through its message fields, including repeated, map and oneof fields.
+// The schemas of recursive models are typed as z.ZodType<any>, since typescript cannot infer a type from a schema
+// that refers to itself.
+func (ctx *APIContext) recursive(m *Model) bool {
+	var reaches func(mm *Model, visited map[*Model]bool) bool
+	reaches = func(mm *Model, visited map[*Model]bool) bool {
+		if visited[mm] {
+			return false
+		}
+		visited[mm] = true
+
+		for _, f := range mm.fields() {
+			if !f.IsMessage {
+				continue
+			}
+
+			next, ok := ctx.modelLookup[strings.TrimSuffix(f.Type, "[]")]
+			if !ok || next.Primitive {
+				continue
+			}
+
+			if next == m || reaches(next, visited) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return reaches(m, map[*Model]bool{})
+}
+
+// schemaImports are the schemas of the messages of the fields of the models that are declared in other modules,
+// which are imported from the schema modules of those modules, e.g. PageSchema from common_zod. The names of the
+// schemas of a message are returned by names, e.g. [PageSchema] for Page.
+func (ctx *APIContext) schemaImports(moduleName func(string) string, names func(string) []string) []*Import {
+	imports := make(map[string]map[string]bool)
+
+	for _, m := range ctx.Models {
+		for _, f := range m.fields() {
+			name := strings.TrimSuffix(f.Type, "[]")
+
+			module, ok := ctx.external[name]
+			if !ok || !f.IsMessage {
+				continue
+			}
+
+			module = moduleName(module)
+			if imports[module] == nil {
+				imports[module] = make(map[string]bool)
+			}
+
+			for _, n := range names(name) {
+				imports[module][n] = true
+			}
+		}
+	}
+
+	var out []*Import
+	for module, names := range imports {
+		imp := &Import{Module: module}
+		for n := range names {
+			imp.Names = append(imp.Names, n)
+		}
+		sort.Strings(imp.Names)
+
+		out = append(out, imp)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Module < out[j].Module
+	})
+
+	return out
+}
+
+// renderZod generates the zod schemas of the messages of the module, e.g. service_zod.ts, with Options.Zod.
+func (ctx *APIContext) renderZod(enums map[string]*Enum) (*plugin.CodeGeneratorResponse_File, error) {
+	module := zodModule{Target: ctx.Target, Imports: ctx.schemaImports(zodModuleName, func(name string) []string {
+		return []string{name + "Schema"}
+	})}
+
+	for _, m := range ctx.Models {
+		if !m.Primitive {
+			module.Models = append(module.Models, m)
+		}
+	}
+
+	funcMap := template.FuncMap{
+		"join":      strings.Join,
+		"recursive": ctx.recursive,
+		"zodField": func(f ModelField) string {
+			return zodField(f, enums)
+		},
+		"importPath": func(m string) string {
+			return runtimeImportPath(ctx.module, m, ctx.Options)
+		},
+	}
+
+	t, err := template.New("zod").Funcs(funcMap).Parse(zodTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	b := bytes.NewBufferString("")
+	if err := t.Execute(b, module); err != nil {
+		return nil, err
+	}
+
+	cf := &plugin.CodeGeneratorResponse_File{}
+	cf.Name = proto.String(zodModuleName(ctx.module) + ".ts")
+	cf.Content = proto.String(b.String())
+
+	return cf, nil
+}
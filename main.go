@@ -1,21 +1,53 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
-	"strings"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
 	plugin "github.com/golang/protobuf/protoc-gen-go/plugin"
 	"go.larrymyers.com/protoc-gen-twirp_typescript/generator"
 )
 
+// Timestamp, Commit and Branch describe the build of the plugin, and are set by the ldflags of the Makefile.
+var (
+	Timestamp string
+	Commit    string
+	Branch    string
+)
+
 func main() {
+	// protoc and buf run the plugin without arguments, so the flags are only used when it is run by hand,
+	// e.g. to check the version of the binary that is published as a buf remote plugin.
+	version := flag.Bool("version", false, "print the version of the plugin and exit")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nParameters, e.g. --twirp_typescript_out=protocol=protobuf:./ts_client, or the opt list of buf.gen.yaml:\n%s", generator.Usage())
+	}
+	flag.Parse()
+
+	if *version {
+		fmt.Printf("protoc-gen-twirp_typescript %s (%s, built %s)\n", orUnknown(Commit), orUnknown(Branch), orUnknown(Timestamp))
+		return
+	}
+
 	req := readRequest(os.Stdin)
 	writeResponse(os.Stdout, generate(req))
 }
 
+func orUnknown(s string) string {
+	if s == "" {
+		return "unknown"
+	}
+
+	return s
+}
+
 func readRequest(r io.Reader) *plugin.CodeGeneratorRequest {
 	data, err := ioutil.ReadAll(r)
 	if err != nil {
@@ -27,45 +59,77 @@ func readRequest(r io.Reader) *plugin.CodeGeneratorRequest {
 		panic(err)
 	}
 
-	if len(req.FileToGenerate) == 0 {
-		panic(err)
-	}
-
 	return req
 }
 
 func generate(in *plugin.CodeGeneratorRequest) *plugin.CodeGeneratorResponse {
 	resp := &plugin.CodeGeneratorResponse{}
+	resp.XXX_unrecognized = generator.SupportedFeatures()
+
+	if len(in.FileToGenerate) == 0 {
+		resp.Error = proto.String("no files to generate")
+		return resp
+	}
+
+	opts, err := generator.ParseOptions(in.GetParameter())
+	if err != nil {
+		resp.Error = proto.String(err.Error())
+		return resp
+	}
+	opts.PluginVersion = Commit
+	opts.CompilerVersion = generator.CompilerVersion(in.GetCompilerVersion())
 
+	var files []*descriptor.FileDescriptorProto
 	for _, f := range in.GetProtoFile() {
-		// skip google/protobuf/timestamp, we don't do any special serialization for jsonpb.
-		if *f.Name == "google/protobuf/timestamp.proto" {
+		// skip Google WKTs that are mapped to typescript types instead of generated interfaces.
+		if generator.IsMappedWKT(f) {
 			continue
 		}
 
-		cf, err := generator.CreateClientAPI(f)
-		if err != nil {
-			resp.Error = proto.String(err.Error())
-			return resp
+		// skip the protoc-gen-validate options, whose rules are generated into the validate functions of messages.
+		if generator.IsValidationFile(f) {
+			continue
 		}
 
-		resp.File = append(resp.File, cf)
+		// skip the google.api options, which are generated into the REST methods of clients and the types of fields.
+		if generator.IsGoogleAPIFile(f) {
+			continue
+		}
+
+		files = append(files, f)
 	}
 
-	resp.File = append(resp.File, generator.RuntimeLibrary())
+	cfs, err := generator.CreateClientAPIs(files, in.GetFileToGenerate(), opts)
+	if err != nil {
+		resp.Error = proto.String(err.Error())
+		return resp
+	}
+
+	resp.File = append(resp.File, cfs...)
+	resp.File = append(resp.File, generator.RuntimeLibraries(opts)...)
 
-	params := getParameters(in)
+	if opts.PackageName != "" {
+		// the barrels include the index.ts of the package, which exports the packages as namespaces
+		if !opts.Barrels {
+			idx, err := generator.CreatePackageIndex(resp.File, opts)
+			if err != nil {
+				resp.Error = proto.String(err.Error())
+				return resp
+			}
 
-	if pkgName, ok := params["package_name"]; ok {
-		idx, err := generator.CreatePackageIndex(resp.File)
-		if err != nil {
-			resp.Error = proto.String(err.Error())
-			return resp
+			resp.File = append(resp.File, idx)
 		}
 
-		resp.File = append(resp.File, idx)
-		resp.File = append(resp.File, generator.CreateTSConfig())
-		resp.File = append(resp.File, generator.CreatePackageJSON(pkgName))
+		resp.File = append(resp.File, generator.CreateTSConfig(opts))
+		resp.File = append(resp.File, generator.CreatePackageJSON(opts))
+	}
+
+	// the modules of the proto files are formatted and have their banners, and the runtime and package files have
+	// no proto file
+	generator.FormatFiles(resp.File[len(cfs):])
+	if err := generator.AddBanners(resp.File[len(cfs):], "", opts); err != nil {
+		resp.Error = proto.String(err.Error())
+		return resp
 	}
 
 	return resp
@@ -81,22 +145,3 @@ func writeResponse(w io.Writer, resp *plugin.CodeGeneratorResponse) {
 
 	}
 }
-
-type Params map[string]string
-
-func getParameters(in *plugin.CodeGeneratorRequest) Params {
-	params := make(Params)
-
-	if in.Parameter == nil {
-		return params
-	}
-
-	pairs := strings.Split(*in.Parameter, ",")
-
-	for _, pair := range pairs {
-		kv := strings.Split(pair, "=")
-		params[kv[0]] = kv[1]
-	}
-
-	return params
-}